@@ -0,0 +1,129 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AutoscalingPolicySpec defines the desired state of AutoscalingPolicy
+// Autoscaling policies are managed through the Elasticsearch autoscaling API (_autoscaling/policy/{name})
+// and are used by ECK to scale the node sets matching their roles
+type AutoscalingPolicySpec struct {
+	// SyncInterval defines how often the operator will reconcile this resource (default: 10s)
+	// Examples: "30s", "5m", "1h"
+	// +optional
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ns|us|ms|s|m|h))+$`
+	SyncInterval string `json:"syncInterval,omitempty"`
+
+	// ResourceSelector specifies the target Elasticsearch cluster for the autoscaling policies
+	// If omitted, the NamespaceDefaultCluster of the namespace is used
+	// +optional
+	ResourceSelector ResourceSelector `json:"resourceSelector,omitzero"`
+
+	// Resources contains the autoscaling policies to apply, keyed by policy name
+	// Each value is the policy definition as accepted by the autoscaling API (roles and deciders)
+	Resources map[string]apiextensionsv1.JSON `json:"resources"`
+
+	// DeletionPolicy defines what happens to the objects of the resource in the cluster when the resource is
+	// deleted: Delete removes them, and Retain leaves them in the cluster (e.g. to move them to another resource)
+	// +optional
+	// +kubebuilder:default=Delete
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// Suspend pauses the reconciliation of the resource, including the deletion of its objects from the cluster when
+	// the resource is deleted, while leaving its status untouched
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+}
+
+// AutoscalingPolicyStatus defines the observed state of AutoscalingPolicy.
+type AutoscalingPolicyStatus struct {
+	// Phase indicates the current phase of the AutoscalingPolicy.
+	// It can be "Pending", "Syncing", "Ready", or "Error".
+	// +optional
+	Phase string `json:"phase,omitempty"`
+
+	// Message provides a human-readable message about the current status.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// TargetCluster is the namespace/name of the target Elasticsearch cluster
+	// Format: "namespace/name"
+	// +optional
+	TargetCluster string `json:"targetCluster,omitempty"`
+
+	// AppliedResources lists the names of the autoscaling policies that were successfully applied to Elasticsearch.
+	// This is used to track which autoscaling policies need to be deleted if they are removed from the spec.
+	// +optional
+	AppliedResources []string `json:"appliedResources,omitempty"`
+
+	// LastSyncTime records the last time the resource was successfully synchronized with Elasticsearch.
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// ObservedGeneration is the generation of the spec the status refers to
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// conditions represent the current state of the AutoscalingPolicy resource.
+	// Each condition has a unique type and reflects the status of a specific aspect of the resource.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="Current phase of the AutoscalingPolicy"
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".status.targetCluster",description="Target cluster"
+// +kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.message",description="Detailed status message",priority=1
+// +kubebuilder:printcolumn:name="Last Sync",type="date",JSONPath=".status.lastSyncTime",description="Last successful synchronization time"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// AutoscalingPolicy is the Schema for the autoscalingpolicies API
+// This resource is specifically for Elasticsearch clusters (autoscaling API)
+type AutoscalingPolicy struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitzero"`
+
+	// spec defines the desired state of AutoscalingPolicy
+	// +required
+	Spec AutoscalingPolicySpec `json:"spec"`
+
+	// status defines the observed state of AutoscalingPolicy
+	// +optional
+	Status AutoscalingPolicyStatus `json:"status,omitzero"`
+}
+
+// +kubebuilder:object:root=true
+
+// AutoscalingPolicyList contains a list of AutoscalingPolicy
+type AutoscalingPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitzero"`
+	Items           []AutoscalingPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&AutoscalingPolicy{}, &AutoscalingPolicyList{})
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterSelector selects ECK Elasticsearch clusters by label, for cluster-scoped resources
+type ClusterSelector struct {
+	// LabelSelector selects the ECK Elasticsearch resources to target
+	LabelSelector metav1.LabelSelector `json:"labelSelector"`
+
+	// Namespaces restricts the selected clusters to these namespaces (default: all namespaces)
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+}
+
+// ClusterIndexTemplateSpec defines the desired state of ClusterIndexTemplate
+// +kubebuilder:validation:XValidation:rule="has(self.resourceSelector) != has(self.clusterSelector)",message="exactly one of resourceSelector or clusterSelector must be set"
+// +kubebuilder:validation:XValidation:rule="!has(self.resourceSelector) || (has(self.resourceSelector.__namespace__) && self.resourceSelector.__namespace__ != ”)",message="resourceSelector.namespace is required for cluster-scoped resources"
+type ClusterIndexTemplateSpec struct {
+	// SyncInterval defines how often the operator will reconcile this resource (default: 10s)
+	// Examples: "30s", "5m", "1h"
+	// +optional
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ns|us|ms|s|m|h))+$`
+	SyncInterval string `json:"syncInterval,omitempty"`
+
+	// ResourceSelector targets a single cluster by its explicit namespace and name
+	// +optional
+	ResourceSelector *ResourceSelector `json:"resourceSelector,omitempty"`
+
+	// ClusterSelector targets every ECK cluster matching a label selector
+	// +optional
+	ClusterSelector *ClusterSelector `json:"clusterSelector,omitempty"`
+
+	// Resources contains the index templates to apply on every target cluster, keyed by template name
+	Resources map[string]apiextensionsv1.JSON `json:"resources"`
+
+	// DeletionPolicy defines what happens to the objects of the resource in the cluster when the resource is
+	// deleted: Delete removes them, and Retain leaves them in the cluster (e.g. to move them to another resource)
+	// +optional
+	// +kubebuilder:default=Delete
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// Suspend pauses the reconciliation of the resource, including the deletion of its objects from the cluster when
+	// the resource is deleted, while leaving its status untouched
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+}
+
+// ClusterIndexTemplateStatus defines the observed state of ClusterIndexTemplate.
+type ClusterIndexTemplateStatus struct {
+	// Phase indicates the current phase of the ClusterIndexTemplate.
+	// It can be "Pending", "Syncing", "Ready", or "Error".
+	// +optional
+	Phase string `json:"phase,omitempty"`
+
+	// Message provides a human-readable message about the current status.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// TargetClusters lists the namespace/name of the clusters the index templates are applied to.
+	// This is used to remove the index templates from clusters that stop being targeted.
+	// +optional
+	TargetClusters []string `json:"targetClusters,omitempty"`
+
+	// AppliedResources lists the names of the index templates that were successfully applied to the target clusters.
+	// This is used to track which index templates need to be deleted if they are removed from the spec.
+	// +optional
+	AppliedResources []string `json:"appliedResources,omitempty"`
+
+	// LastSyncTime records the last time the resource was successfully synchronized with the target clusters.
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// ObservedGeneration is the generation of the spec the status refers to
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// conditions represent the current state of the ClusterIndexTemplate resource.
+	// Each condition has a unique type and reflects the status of a specific aspect of the resource.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="Current phase of the ClusterIndexTemplate"
+// +kubebuilder:printcolumn:name="Clusters",type="string",JSONPath=".status.targetClusters",description="Target clusters"
+// +kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.message",description="Detailed status message",priority=1
+// +kubebuilder:printcolumn:name="Last Sync",type="date",JSONPath=".status.lastSyncTime",description="Last successful synchronization time"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ClusterIndexTemplate is the Schema for the clusterindextemplates API
+// It is the cluster-scoped variant of IndexTemplate, able to target clusters in any namespace
+type ClusterIndexTemplate struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitzero"`
+
+	// spec defines the desired state of ClusterIndexTemplate
+	// +required
+	Spec ClusterIndexTemplateSpec `json:"spec"`
+
+	// status defines the observed state of ClusterIndexTemplate
+	// +optional
+	Status ClusterIndexTemplateStatus `json:"status,omitzero"`
+}
+
+// +kubebuilder:object:root=true
+
+// ClusterIndexTemplateList contains a list of ClusterIndexTemplate
+type ClusterIndexTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitzero"`
+	Items           []ClusterIndexTemplate `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterIndexTemplate{}, &ClusterIndexTemplateList{})
+}
@@ -26,15 +26,58 @@ type ClusterSettingsSpec struct {
 	// SyncInterval defines how often the operator will reconcile this resource (default: 10s)
 	// Examples: "30s", "5m", "1h"
 	// +optional
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ns|us|ms|s|m|h))+$`
 	SyncInterval string `json:"syncInterval,omitempty"`
 
 	// ResourceSelector specifies the target Elasticsearch cluster for cluster settings
-	ResourceSelector ResourceSelector `json:"resourceSelector"`
+	// If omitted, the NamespaceDefaultCluster of the namespace is used
+	// +optional
+	ResourceSelector ResourceSelector `json:"resourceSelector,omitzero"`
 
 	// Resources contains the cluster settings to apply, keyed by setting category
 	// Each key represents a category of settings (e.g., "persistent", "transient")
 	// The value is a JSON object containing the actual settings
 	Resources map[string]apiextensionsv1.JSON `json:"resources"`
+
+	// ValuesFrom are the ConfigMaps and Secrets whose keys can be referenced as ${key} in the strings of the
+	// resources, e.g. to use a different number of shards per node per environment. Later sources override the keys of the earlier
+	// ones, and the values are read again on every sync
+	// +optional
+	ValuesFrom []ValuesSource `json:"valuesFrom,omitempty"`
+
+	// Templating renders the strings of the resources as Go templates when set to GoTemplate, with the target cluster as
+	// .Cluster (.Cluster.Name, .Cluster.Version, ...) and the resource as .Name and .Namespace, e.g. to change
+	// the allocation awareness attributes per cluster of resourceSelector.matchLabels
+	// +optional
+	// +kubebuilder:validation:Enum=GoTemplate
+	Templating string `json:"templating,omitempty"`
+
+	// DryRun reports the changes the synchronization would make to Elasticsearch in the status and in events,
+	// without making them
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// RequiredClusterHealth is the minimum health of the cluster (green or yellow) for the changes to be applied.
+	// While the cluster is below it (e.g., red), the changes are postponed until it recovers
+	// +optional
+	// +kubebuilder:validation:Enum=green;yellow
+	RequiredClusterHealth string `json:"requiredClusterHealth,omitempty"`
+
+	// DependsOn lists the resources of the operator which must be Ready before the resource is synced (e.g., the
+	// SnapshotRepository referenced by its settings). The changes are postponed until all of them are Ready
+	// +optional
+	DependsOn []Dependency `json:"dependsOn,omitempty"`
+
+	// DeletionPolicy defines what happens to the objects of the resource in the cluster when the resource is
+	// deleted: Delete removes them, and Retain leaves them in the cluster (e.g. to move them to another resource)
+	// +optional
+	// +kubebuilder:default=Delete
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// Suspend pauses the reconciliation of the resource, including the deletion of its objects from the cluster when
+	// the resource is deleted, while leaving its status untouched
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
 }
 
 // ClusterSettingsStatus defines the observed state of ClusterSettings.
@@ -53,16 +96,49 @@ type ClusterSettingsStatus struct {
 	// +optional
 	TargetCluster string `json:"targetCluster,omitempty"`
 
+	// Clusters is the status of every cluster selected by resourceSelector.matchLabels, reported by the resource
+	// created for each of them
+	// +listType=map
+	// +listMapKey=cluster
+	// +optional
+	Clusters []ClusterTargetStatus `json:"clusters,omitempty"`
+
 	// AppliedResources lists the individual settings that were successfully applied to Elasticsearch.
 	// Format: "category.setting.path" (e.g., "persistent.cluster.routing.allocation.enable")
 	// This is used to track which settings need to be deleted if they are removed from the spec.
 	// +optional
 	AppliedResources []string `json:"appliedResources,omitempty"`
 
+	// AppliedCount is the number of setting categories (persistent, transient) applied by the last synchronization
+	// +optional
+	AppliedCount int32 `json:"appliedCount"`
+
+	// FailedCount is the number of setting categories that failed to sync in the last synchronization, leaving the
+	// resource Degraded
+	// +optional
+	FailedCount int32 `json:"failedCount"`
+
 	// LastSyncTime records the last time the resource was successfully synchronized with Elasticsearch.
 	// +optional
 	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
 
+	// ObservedGeneration is the generation of the spec the status refers to
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Resources is the status of every category of settings of the spec in Elasticsearch, keyed by category
+	// +optional
+	Resources map[string]ResourceStatus `json:"resources,omitempty"`
+
+	// Changes are the changes made to Elasticsearch by the last synchronization that changed anything
+	// +optional
+	Changes []ObjectChange `json:"changes,omitempty"`
+
+	// Settings is the verification of every desired setting against the settings stored by Elasticsearch, keyed by
+	// category.setting with the setting flattened (e.g., "persistent.cluster.routing.allocation.enable")
+	// +optional
+	Settings map[string]SettingStatus `json:"settings,omitempty"`
+
 	// conditions represent the current state of the ClusterSettings resource.
 	// Each condition has a unique type and reflects the status of a specific aspect of the resource.
 	// +listType=map
@@ -71,10 +147,33 @@ type ClusterSettingsStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+// States of the verification of a setting
+const (
+	// SettingStateVerified is the state of the settings stored with their desired value
+	SettingStateVerified = "Verified"
+	// SettingStateMismatch is the state of the settings stored with another value
+	SettingStateMismatch = "Mismatch"
+	// SettingStateMissing is the state of the settings not stored by Elasticsearch
+	SettingStateMissing = "Missing"
+	// SettingStateArchived is the state of the settings archived by Elasticsearch, as it does not know them
+	SettingStateArchived = "Archived"
+)
+
+// SettingStatus is the verification of a setting against the value stored by Elasticsearch
+type SettingStatus struct {
+	// State of the setting: Verified, Mismatch, Missing or Archived
+	State string `json:"state"`
+	// Live is the value stored by Elasticsearch, JSON-encoded, when it is not the desired one
+	// +optional
+	Live string `json:"live,omitempty"`
+}
+
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="Current phase of the ClusterSettings"
 // +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".status.targetCluster",description="Target cluster"
+// +kubebuilder:printcolumn:name="Applied",type="integer",JSONPath=".status.appliedCount",description="Objects applied by the last synchronization"
+// +kubebuilder:printcolumn:name="Failed",type="integer",JSONPath=".status.failedCount",description="Objects that failed to sync in the last synchronization"
 // +kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.message",description="Detailed status message",priority=1
 // +kubebuilder:printcolumn:name="Last Sync",type="date",JSONPath=".status.lastSyncTime",description="Last successful synchronization time"
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
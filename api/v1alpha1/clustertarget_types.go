@@ -0,0 +1,35 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// ClusterTargetStatus is the status of one of the clusters selected by resourceSelector.matchLabels, as reported by
+// the resource created for it
+type ClusterTargetStatus struct {
+	// Cluster is the namespace/name of the ECK Elasticsearch cluster
+	Cluster string `json:"cluster"`
+
+	// Resource is the name of the resource created for the cluster, in the namespace of this one
+	Resource string `json:"resource"`
+
+	// Phase is the phase of the resource created for the cluster
+	// +optional
+	Phase string `json:"phase,omitempty"`
+
+	// Message is the message of the resource created for the cluster
+	// +optional
+	Message string `json:"message,omitempty"`
+}
@@ -0,0 +1,34 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// Dependency references another resource of the operator which must be Ready before a resource is synced, e.g. the
+// IndexLifecyclePolicy used by the templates of an IndexTemplate
+type Dependency struct {
+	// Kind is the kind of the resource
+	// +kubebuilder:validation:Enum=IndexTemplate;ClusterIndexTemplate;IndexLifecyclePolicy;ClusterIndexLifecyclePolicy;SnapshotLifecyclePolicy;SnapshotRepository;IndexStateManagement;ClusterSettings;ElasticConfigBundle;AutoscalingPolicy;QueryRuleset;OpenSearchAlertingMonitor;ApplicationPrivilege;ElasticsearchRawResource;MachineLearningJob;NodeShutdown;OpenSearchAnomalyDetector;OpenSearchNotificationChannel;SearchApplication;SynonymsSet;KibanaAlertRule;KibanaSavedObjects;KibanaSpace;OpenSearchDashboardsSavedObjects;ElasticsearchClusterConnection;FleetAgentPolicy;IndexLifecycle
+	Kind string `json:"kind"`
+
+	// Name is the name of the resource
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the resource. Defaults to the namespace of the dependent resource, and is ignored
+	// for cluster-scoped kinds
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
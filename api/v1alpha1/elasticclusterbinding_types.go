@@ -0,0 +1,71 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ElasticClusterBindingSpec defines the desired state of ElasticClusterBinding
+// +kubebuilder:validation:XValidation:rule="has(self.namespaces) || has(self.namespaceSelector)",message="one of namespaces or namespaceSelector must be set"
+type ElasticClusterBindingSpec struct {
+	// Clusters lists the ECK clusters and ElasticsearchClusterConnections of the namespace of the binding
+	// that the selected namespaces are allowed to target
+	// +kubebuilder:validation:MinItems=1
+	Clusters []string `json:"clusters"`
+
+	// Namespaces lists the namespaces allowed to target the clusters
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// NamespaceSelector selects by label the namespaces allowed to target the clusters
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:printcolumn:name="Clusters",type=string,JSONPath=`.spec.clusters`
+// +kubebuilder:printcolumn:name="Namespaces",type=string,JSONPath=`.spec.namespaces`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// ElasticClusterBinding is the Schema for the elasticclusterbindings API.
+// It allows resources of other namespaces to target the clusters of its namespace
+// when the operator runs with --enforce-cluster-bindings
+type ElasticClusterBinding struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitzero"`
+
+	// spec defines the desired state of ElasticClusterBinding
+	// +required
+	Spec ElasticClusterBindingSpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+
+// ElasticClusterBindingList contains a list of ElasticClusterBinding
+type ElasticClusterBindingList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitzero"`
+	Items           []ElasticClusterBinding `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ElasticClusterBinding{}, &ElasticClusterBindingList{})
+}
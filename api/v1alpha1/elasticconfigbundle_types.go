@@ -0,0 +1,171 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ElasticConfigBundleSpec defines the desired state of ElasticConfigBundle
+// A bundle carries several resource types in one object, so an application's whole cluster footprint ships as one manifest
+type ElasticConfigBundleSpec struct {
+	// SyncInterval defines how often the operator will reconcile this resource (default: 10s)
+	// Examples: "30s", "5m", "1h"
+	// +optional
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ns|us|ms|s|m|h))+$`
+	SyncInterval string `json:"syncInterval,omitempty"`
+
+	// ResourceSelector specifies the target Elasticsearch cluster for the bundle
+	// If omitted, the NamespaceDefaultCluster of the namespace is used
+	// +optional
+	ResourceSelector ResourceSelector `json:"resourceSelector,omitzero"`
+
+	// ApplyOrder defines the order in which the resource kinds are applied. Removed resources are deleted in reverse order.
+	// Kinds not listed are applied after the listed ones, in the default order:
+	// ClusterSettings, IngestPipelines, IndexLifecyclePolicies, ComponentTemplates, IndexTemplates
+	// +optional
+	// +listType=set
+	ApplyOrder []BundleResourceKind `json:"applyOrder,omitempty"`
+
+	// ClusterSettings contains the cluster settings to apply, keyed by setting category ("persistent" or "transient")
+	// +optional
+	ClusterSettings map[string]apiextensionsv1.JSON `json:"clusterSettings,omitempty"`
+
+	// IngestPipelines contains the ingest pipelines to apply, keyed by pipeline ID
+	// +optional
+	IngestPipelines map[string]apiextensionsv1.JSON `json:"ingestPipelines,omitempty"`
+
+	// IndexLifecyclePolicies contains the ILM policies to apply, keyed by policy name (Elasticsearch only)
+	// +optional
+	IndexLifecyclePolicies map[string]apiextensionsv1.JSON `json:"indexLifecyclePolicies,omitempty"`
+
+	// ComponentTemplates contains the component templates to apply, keyed by template name
+	// +optional
+	ComponentTemplates map[string]apiextensionsv1.JSON `json:"componentTemplates,omitempty"`
+
+	// IndexTemplates contains the index templates to apply, keyed by template name
+	// +optional
+	IndexTemplates map[string]apiextensionsv1.JSON `json:"indexTemplates,omitempty"`
+
+	// DeletionPolicy defines what happens to the objects of the resource in the cluster when the resource is
+	// deleted: Delete removes them, and Retain leaves them in the cluster (e.g. to move them to another resource)
+	// +optional
+	// +kubebuilder:default=Delete
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// Suspend pauses the reconciliation of the resource, including the deletion of its objects from the cluster when
+	// the resource is deleted, while leaving its status untouched
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+}
+
+// BundleResourceKind is a resource kind carried by an ElasticConfigBundle
+// +kubebuilder:validation:Enum=ClusterSettings;IngestPipelines;IndexLifecyclePolicies;ComponentTemplates;IndexTemplates
+type BundleResourceKind string
+
+// Bundle resource kinds, as used in applyOrder and in the applied resources of the status
+const (
+	BundleKindClusterSettings        BundleResourceKind = "ClusterSettings"
+	BundleKindIngestPipelines        BundleResourceKind = "IngestPipelines"
+	BundleKindIndexLifecyclePolicies BundleResourceKind = "IndexLifecyclePolicies"
+	BundleKindComponentTemplates     BundleResourceKind = "ComponentTemplates"
+	BundleKindIndexTemplates         BundleResourceKind = "IndexTemplates"
+)
+
+// ElasticConfigBundleStatus defines the observed state of ElasticConfigBundle.
+type ElasticConfigBundleStatus struct {
+	// Phase indicates the current phase of the ElasticConfigBundle.
+	// It can be "Pending", "Syncing", "Ready", or "Error".
+	// +optional
+	Phase string `json:"phase,omitempty"`
+
+	// Message provides a human-readable message about the current status.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// TargetCluster is the namespace/name of the target Elasticsearch cluster
+	// Format: "namespace/name"
+	// +optional
+	TargetCluster string `json:"targetCluster,omitempty"`
+
+	// Clusters is the status of every cluster selected by resourceSelector.matchLabels, reported by the resource
+	// created for each of them
+	// +listType=map
+	// +listMapKey=cluster
+	// +optional
+	Clusters []ClusterTargetStatus `json:"clusters,omitempty"`
+
+	// AppliedResources lists the resources of the bundle that were successfully applied to Elasticsearch.
+	// Format: "kind/name" (e.g., "IndexTemplates/logs" or "ClusterSettings/persistent.cluster.routing.allocation.enable")
+	// This is used to track which resources need to be deleted if they are removed from the spec.
+	// +optional
+	AppliedResources []string `json:"appliedResources,omitempty"`
+
+	// LastSyncTime records the last time the resource was successfully synchronized with Elasticsearch.
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// ObservedGeneration is the generation of the spec the status refers to
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// conditions represent the current state of the ElasticConfigBundle resource.
+	// Each condition has a unique type and reflects the status of a specific aspect of the resource.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="Current phase of the ElasticConfigBundle"
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".status.targetCluster",description="Target cluster"
+// +kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.message",description="Detailed status message",priority=1
+// +kubebuilder:printcolumn:name="Last Sync",type="date",JSONPath=".status.lastSyncTime",description="Last successful synchronization time"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ElasticConfigBundle is the Schema for the elasticconfigbundles API
+type ElasticConfigBundle struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitzero"`
+
+	// spec defines the desired state of ElasticConfigBundle
+	// +required
+	Spec ElasticConfigBundleSpec `json:"spec"`
+
+	// status defines the observed state of ElasticConfigBundle
+	// +optional
+	Status ElasticConfigBundleStatus `json:"status,omitzero"`
+}
+
+// +kubebuilder:object:root=true
+
+// ElasticConfigBundleList contains a list of ElasticConfigBundle
+type ElasticConfigBundleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitzero"`
+	Items           []ElasticConfigBundle `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ElasticConfigBundle{}, &ElasticConfigBundleList{})
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ElasticsearchClusterConnectionSpec defines the desired state of ElasticsearchClusterConnection
+// +kubebuilder:validation:XValidation:rule="has(self.username) == has(self.passwordSecretRef)",message="username and passwordSecretRef must be set together"
+// +kubebuilder:validation:XValidation:rule="!(has(self.username) && has(self.apiKeySecretRef))",message="only one of username or apiKeySecretRef can be set"
+// +kubebuilder:validation:XValidation:rule="!self.endpoint.startsWith('http://') || !has(self.tls)",message="tls can not be set for plain HTTP endpoints"
+type ElasticsearchClusterConnectionSpec struct {
+	// SyncInterval defines how often the operator checks the connection and reloads the credentials (default: 10s)
+	// Examples: "30s", "5m", "1h"
+	// +optional
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ns|us|ms|s|m|h))+$`
+	SyncInterval string `json:"syncInterval,omitempty"`
+
+	// Endpoint is the Elasticsearch URL (e.g., https://my-elasticsearch.example.com:9200). Plain HTTP endpoints
+	// (http://) are reached without TLS
+	// +kubebuilder:validation:Pattern=`^https?://`
+	Endpoint string `json:"endpoint"`
+
+	// Username for basic authentication
+	// +optional
+	Username string `json:"username,omitempty"`
+	// PasswordSecretRef references a Secret containing the password
+	// +optional
+	PasswordSecretRef *SecretKeySelector `json:"passwordSecretRef,omitempty"`
+	// APIKeySecretRef references a Secret containing an encoded API key, used instead of basic authentication
+	// +optional
+	APIKeySecretRef *SecretKeySelector `json:"apiKeySecretRef,omitempty"`
+
+	// TLS configures the verification of the cluster certificate.
+	// If not defined, the certificate is verified against the system CAs
+	// +optional
+	TLS *ClusterConnectionTLS `json:"tls,omitempty"`
+
+	// ClusterType specifies the type of cluster: "elasticsearch" or "opensearch"
+	// If not specified, the operator will automatically detect the cluster type
+	// +optional
+	// +kubebuilder:validation:Enum=elasticsearch;opensearch
+	ClusterType string `json:"clusterType,omitempty"`
+
+	// NodeDiscovery enables the discovery of the cluster nodes (sniffing), spreading the requests across them
+	// instead of sending all of them to the endpoint
+	// +optional
+	NodeDiscovery *NodeDiscovery `json:"nodeDiscovery,omitempty"`
+
+	// Headers are extra HTTP headers attached to every request sent to the cluster
+	// (e.g., routing headers of API gateways or tenancy headers)
+	// +optional
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// Timeouts overrides the default request and dial timeouts of the operator for this cluster
+	// +optional
+	Timeouts *ConnectionTimeouts `json:"timeouts,omitempty"`
+
+	// Compression compresses the request bodies with gzip, reducing the traffic of large policies and templates.
+	// Responses are always requested compressed
+	// +optional
+	Compression bool `json:"compression,omitempty"`
+}
+
+// ClusterConnectionTLS defines how the cluster certificate is verified
+type ClusterConnectionTLS struct {
+	// CACertSecretRef references a Secret containing the CA certificate
+	// +optional
+	CACertSecretRef *SecretKeySelector `json:"caCertSecretRef,omitempty"`
+	// InsecureSkipVerify disables the verification of the cluster certificate (not recommended for production)
+	// +optional
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// ElasticsearchClusterConnectionStatus defines the observed state of ElasticsearchClusterConnection.
+type ElasticsearchClusterConnectionStatus struct {
+	// Phase indicates the current phase of the ElasticsearchClusterConnection.
+	// It can be "Pending", "Syncing", "Ready", or "Error".
+	// +optional
+	Phase string `json:"phase,omitempty"`
+
+	// Message provides a human-readable message about the current status.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// ClusterType is the detected type of the cluster: "elasticsearch" or "opensearch"
+	// +optional
+	ClusterType string `json:"clusterType,omitempty"`
+
+	// Version is the version of the cluster
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// LastSyncTime is the timestamp of the last successful connection check
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// ObservedGeneration is the generation of the spec the status refers to
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// conditions represent the current state of the ElasticsearchClusterConnection resource.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Endpoint",type=string,JSONPath=`.spec.endpoint`
+// +kubebuilder:printcolumn:name="Type",type=string,JSONPath=`.status.clusterType`
+// +kubebuilder:printcolumn:name="Version",type=string,JSONPath=`.status.version`
+// +kubebuilder:printcolumn:name="Message",type=string,JSONPath=`.status.message`,priority=1
+// +kubebuilder:printcolumn:name="Last Sync",type=date,JSONPath=`.status.lastSyncTime`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// ElasticsearchClusterConnection is the Schema for the elasticsearchclusterconnections API
+type ElasticsearchClusterConnection struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitzero"`
+
+	// spec defines the desired state of ElasticsearchClusterConnection
+	// +required
+	Spec ElasticsearchClusterConnectionSpec `json:"spec"`
+
+	// status defines the observed state of ElasticsearchClusterConnection
+	// +optional
+	Status ElasticsearchClusterConnectionStatus `json:"status,omitzero"`
+}
+
+// +kubebuilder:object:root=true
+
+// ElasticsearchClusterConnectionList contains a list of ElasticsearchClusterConnection
+type ElasticsearchClusterConnectionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitzero"`
+	Items           []ElasticsearchClusterConnection `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ElasticsearchClusterConnection{}, &ElasticsearchClusterConnectionList{})
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ElasticsearchRawResourceSpec defines the desired state of ElasticsearchRawResource
+// Raw resources cover the APIs without a dedicated CRD: the path, body and verbs of each resource are defined by the user
+type ElasticsearchRawResourceSpec struct {
+	// SyncInterval defines how often the operator will reconcile this resource (default: 10s)
+	// Examples: "30s", "5m", "1h"
+	// +optional
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ns|us|ms|s|m|h))+$`
+	SyncInterval string `json:"syncInterval,omitempty"`
+
+	// ResourceSelector specifies the target Elasticsearch or OpenSearch cluster for the raw resources
+	// If omitted, the NamespaceDefaultCluster of the namespace is used
+	// +optional
+	ResourceSelector ResourceSelector `json:"resourceSelector,omitzero"`
+
+	// Resources contains the raw resources to apply, keyed by resource name
+	Resources map[string]RawResource `json:"resources"`
+
+	// DeletionPolicy defines what happens to the objects of the resource in the cluster when the resource is
+	// deleted: Delete removes them, and Retain leaves them in the cluster (e.g. to move them to another resource)
+	// +optional
+	// +kubebuilder:default=Delete
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// Suspend pauses the reconciliation of the resource, including the deletion of its objects from the cluster when
+	// the resource is deleted, while leaving its status untouched
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+}
+
+// RawResource defines a single resource managed through raw API requests.
+// Paths may contain "{{name}}", which is replaced with the resource key
+type RawResource struct {
+	// Path is the API path of the resource (e.g., "/_ingest/pipeline/{{name}}")
+	// +kubebuilder:validation:Pattern=`^/`
+	Path string `json:"path"`
+
+	// Body is the desired body of the resource, sent on apply
+	// +optional
+	Body *apiextensionsv1.JSON `json:"body,omitempty"`
+
+	// GetMethod is the verb used to read the current state of the resource (default: GET)
+	// When "None", the resource is applied on every reconciliation
+	// +optional
+	// +kubebuilder:validation:Enum=GET;POST;None
+	// +kubebuilder:default=GET
+	GetMethod string `json:"getMethod,omitempty"`
+
+	// GetPath overrides the path used to read the resource (defaults to Path)
+	// +optional
+	GetPath string `json:"getPath,omitempty"`
+
+	// ResponsePath is the dot separated path of the resource body inside the read response
+	// (e.g., "{{name}}" for ingest pipelines or "index_templates.0.index_template" for index templates).
+	// When empty, the whole response is compared with the desired body
+	// +optional
+	ResponsePath string `json:"responsePath,omitempty"`
+
+	// ApplyMethod is the verb used to create or update the resource (default: PUT)
+	// +optional
+	// +kubebuilder:validation:Enum=PUT;POST;PATCH
+	// +kubebuilder:default=PUT
+	ApplyMethod string `json:"applyMethod,omitempty"`
+
+	// DeleteMethod is the verb used to delete the resource when it is removed (default: DELETE)
+	// When "None", the resource is left in the cluster when it is removed from the spec
+	// +optional
+	// +kubebuilder:validation:Enum=DELETE;None
+	// +kubebuilder:default=DELETE
+	DeleteMethod string `json:"deleteMethod,omitempty"`
+
+	// DeletePath overrides the path used to delete the resource (defaults to Path)
+	// +optional
+	DeletePath string `json:"deletePath,omitempty"`
+}
+
+// ElasticsearchRawResourceStatus defines the observed state of ElasticsearchRawResource.
+type ElasticsearchRawResourceStatus struct {
+	// Phase indicates the current phase of the ElasticsearchRawResource.
+	// It can be "Pending", "Syncing", "Ready", or "Error".
+	// +optional
+	Phase string `json:"phase,omitempty"`
+
+	// Message provides a human-readable message about the current status.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// TargetCluster is the namespace/name of the target Elasticsearch or OpenSearch cluster
+	// Format: "namespace/name"
+	// +optional
+	TargetCluster string `json:"targetCluster,omitempty"`
+
+	// AppliedResources lists the names of the raw resources that were successfully applied to the cluster.
+	// This is used to track which raw resources need to be deleted if they are removed from the spec.
+	// +optional
+	AppliedResources []string `json:"appliedResources,omitempty"`
+
+	// DeletePaths maps each applied resource name to the path used to delete it.
+	// Resources removed from the spec no longer carry their definition, so the path is kept here.
+	// Resources with the "None" delete method are not listed
+	// +optional
+	DeletePaths map[string]string `json:"deletePaths,omitempty"`
+
+	// LastSyncTime records the last time the resource was successfully synchronized with the cluster.
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// ObservedGeneration is the generation of the spec the status refers to
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// conditions represent the current state of the ElasticsearchRawResource resource.
+	// Each condition has a unique type and reflects the status of a specific aspect of the resource.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="Current phase of the ElasticsearchRawResource"
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".status.targetCluster",description="Target cluster"
+// +kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.message",description="Detailed status message",priority=1
+// +kubebuilder:printcolumn:name="Last Sync",type="date",JSONPath=".status.lastSyncTime",description="Last successful synchronization time"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ElasticsearchRawResource is the Schema for the elasticsearchrawresources API
+// This resource works with both Elasticsearch and OpenSearch clusters
+type ElasticsearchRawResource struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitzero"`
+
+	// spec defines the desired state of ElasticsearchRawResource
+	// +required
+	Spec ElasticsearchRawResourceSpec `json:"spec"`
+
+	// status defines the observed state of ElasticsearchRawResource
+	// +optional
+	Status ElasticsearchRawResourceStatus `json:"status,omitzero"`
+}
+
+// +kubebuilder:object:root=true
+
+// ElasticsearchRawResourceList contains a list of ElasticsearchRawResource
+type ElasticsearchRawResourceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitzero"`
+	Items           []ElasticsearchRawResource `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ElasticsearchRawResource{}, &ElasticsearchRawResourceList{})
+}
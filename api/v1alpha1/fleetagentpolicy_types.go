@@ -0,0 +1,150 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// FleetAgentPolicySpec defines the desired state of FleetAgentPolicy
+// Agent policies and their integrations are managed through the Kibana Fleet API (/api/fleet)
+type FleetAgentPolicySpec struct {
+	// SyncInterval defines how often the operator will reconcile this resource (default: 10s)
+	// Examples: "30s", "5m", "1h"
+	// +optional
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ns|us|ms|s|m|h))+$`
+	SyncInterval string `json:"syncInterval,omitempty"`
+
+	// KibanaSelector specifies the target Kibana instance running Fleet
+	KibanaSelector KibanaSelector `json:"kibanaSelector"`
+
+	// Space is the Kibana space the agent policies are created in (default: "default")
+	// +optional
+	Space string `json:"space,omitempty"`
+
+	// Resources contains the agent policies to apply, keyed by agent policy ID
+	Resources map[string]FleetAgentPolicyDefinition `json:"resources"`
+
+	// DeletionPolicy defines what happens to the objects of the resource in the cluster when the resource is
+	// deleted: Delete removes them, and Retain leaves them in the cluster (e.g. to move them to another resource)
+	// +optional
+	// +kubebuilder:default=Delete
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// Suspend pauses the reconciliation of the resource, including the deletion of its objects from the cluster when
+	// the resource is deleted, while leaving its status untouched
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+}
+
+// FleetAgentPolicyDefinition defines a single agent policy and its integrations
+type FleetAgentPolicyDefinition struct {
+	// Policy is the agent policy definition (name, namespace, description, monitoring_enabled, ...)
+	// The name defaults to the agent policy ID and the namespace to "default"
+	Policy apiextensionsv1.JSON `json:"policy"`
+
+	// PackagePolicies contains the integrations added to the agent policy, keyed by package policy ID
+	// Each value is the package policy definition (name, package, inputs, vars, ...)
+	// +optional
+	PackagePolicies map[string]apiextensionsv1.JSON `json:"packagePolicies,omitempty"`
+}
+
+// FleetAgentPolicyStatus defines the observed state of FleetAgentPolicy.
+type FleetAgentPolicyStatus struct {
+	// Phase indicates the current phase of the FleetAgentPolicy.
+	// It can be "Pending", "Syncing", "Ready", or "Error".
+	// +optional
+	Phase string `json:"phase,omitempty"`
+
+	// Message provides a human-readable message about the current status.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// TargetKibana is the namespace/name of the target Kibana instance
+	// Format: "namespace/name"
+	// +optional
+	TargetKibana string `json:"targetKibana,omitempty"`
+
+	// Space is the Kibana space the agent policies were created in
+	// +optional
+	Space string `json:"space,omitempty"`
+
+	// AppliedResources lists the IDs of the agent policies that were successfully applied to Kibana.
+	// This is used to track which agent policies need to be deleted if they are removed from the spec.
+	// +optional
+	AppliedResources []string `json:"appliedResources,omitempty"`
+
+	// AppliedPackagePolicies lists the IDs of the package policies that were successfully applied to Kibana.
+	// This is used to track which integrations need to be deleted if they are removed from the spec.
+	// +optional
+	AppliedPackagePolicies []string `json:"appliedPackagePolicies,omitempty"`
+
+	// LastSyncTime records the last time the resource was successfully synchronized with Kibana.
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// ObservedGeneration is the generation of the spec the status refers to
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// conditions represent the current state of the FleetAgentPolicy resource.
+	// Each condition has a unique type and reflects the status of a specific aspect of the resource.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="Current phase of the FleetAgentPolicy"
+// +kubebuilder:printcolumn:name="Kibana",type="string",JSONPath=".status.targetKibana",description="Target Kibana"
+// +kubebuilder:printcolumn:name="Space",type="string",JSONPath=".status.space",description="Target Kibana space"
+// +kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.message",description="Detailed status message",priority=1
+// +kubebuilder:printcolumn:name="Last Sync",type="date",JSONPath=".status.lastSyncTime",description="Last successful synchronization time"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// FleetAgentPolicy is the Schema for the fleetagentpolicies API
+type FleetAgentPolicy struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitzero"`
+
+	// spec defines the desired state of FleetAgentPolicy
+	// +required
+	Spec FleetAgentPolicySpec `json:"spec"`
+
+	// status defines the observed state of FleetAgentPolicy
+	// +optional
+	Status FleetAgentPolicyStatus `json:"status,omitzero"`
+}
+
+// +kubebuilder:object:root=true
+
+// FleetAgentPolicyList contains a list of FleetAgentPolicy
+type FleetAgentPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitzero"`
+	Items           []FleetAgentPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&FleetAgentPolicy{}, &FleetAgentPolicyList{})
+}
@@ -0,0 +1,297 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+// ILMPolicy is the structured definition of an ILM policy, validated by the CRD schema. Policies using actions or
+// fields it doesn't know are written as raw JSON in the resources of the spec instead
+type ILMPolicy struct {
+	// Phases are the phases of the policy, run in order: hot, warm, cold, frozen and delete
+	Phases ILMPhases `json:"phases"`
+
+	// Meta is arbitrary metadata stored with the policy
+	// +optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	Meta *apiextensionsv1.JSON `json:"_meta,omitempty"`
+}
+
+// ILMPhases are the phases of an ILM policy. Every phase only accepts the actions Elasticsearch allows in it
+// +kubebuilder:validation:MinProperties=1
+type ILMPhases struct {
+	// +optional
+	Hot *ILMHotPhase `json:"hot,omitempty"`
+	// +optional
+	Warm *ILMWarmPhase `json:"warm,omitempty"`
+	// +optional
+	Cold *ILMColdPhase `json:"cold,omitempty"`
+	// +optional
+	Frozen *ILMFrozenPhase `json:"frozen,omitempty"`
+	// +optional
+	Delete *ILMDeletePhase `json:"delete,omitempty"`
+}
+
+// ILMHotPhase is the hot phase of an ILM policy
+type ILMHotPhase struct {
+	// MinAge is the age of the index when it enters the phase (e.g., 0ms)
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[0-9]+(d|h|m|s|ms|micros|nanos)$`
+	MinAge string `json:"min_age,omitempty"`
+
+	Actions ILMHotActions `json:"actions"`
+}
+
+// ILMHotActions are the actions allowed in the hot phase
+type ILMHotActions struct {
+	// +optional
+	SetPriority *ILMSetPriorityAction `json:"set_priority,omitempty"`
+	// +optional
+	Unfollow *ILMEmptyAction `json:"unfollow,omitempty"`
+	// +optional
+	Rollover *ILMRolloverAction `json:"rollover,omitempty"`
+	// +optional
+	Readonly *ILMEmptyAction `json:"readonly,omitempty"`
+	// +optional
+	Downsample *ILMDownsampleAction `json:"downsample,omitempty"`
+	// +optional
+	Shrink *ILMShrinkAction `json:"shrink,omitempty"`
+	// +optional
+	Forcemerge *ILMForcemergeAction `json:"forcemerge,omitempty"`
+	// +optional
+	SearchableSnapshot *ILMSearchableSnapshotAction `json:"searchable_snapshot,omitempty"`
+}
+
+// ILMWarmPhase is the warm phase of an ILM policy
+type ILMWarmPhase struct {
+	// MinAge is the age of the index when it enters the phase (e.g., 7d)
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[0-9]+(d|h|m|s|ms|micros|nanos)$`
+	MinAge string `json:"min_age,omitempty"`
+
+	Actions ILMWarmActions `json:"actions"`
+}
+
+// ILMWarmActions are the actions allowed in the warm phase
+type ILMWarmActions struct {
+	// +optional
+	SetPriority *ILMSetPriorityAction `json:"set_priority,omitempty"`
+	// +optional
+	Unfollow *ILMEmptyAction `json:"unfollow,omitempty"`
+	// +optional
+	Readonly *ILMEmptyAction `json:"readonly,omitempty"`
+	// +optional
+	Downsample *ILMDownsampleAction `json:"downsample,omitempty"`
+	// +optional
+	Allocate *ILMAllocateAction `json:"allocate,omitempty"`
+	// +optional
+	Migrate *ILMMigrateAction `json:"migrate,omitempty"`
+	// +optional
+	Shrink *ILMShrinkAction `json:"shrink,omitempty"`
+	// +optional
+	Forcemerge *ILMForcemergeAction `json:"forcemerge,omitempty"`
+}
+
+// ILMColdPhase is the cold phase of an ILM policy
+type ILMColdPhase struct {
+	// MinAge is the age of the index when it enters the phase (e.g., 30d)
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[0-9]+(d|h|m|s|ms|micros|nanos)$`
+	MinAge string `json:"min_age,omitempty"`
+
+	Actions ILMColdActions `json:"actions"`
+}
+
+// ILMColdActions are the actions allowed in the cold phase
+type ILMColdActions struct {
+	// +optional
+	SetPriority *ILMSetPriorityAction `json:"set_priority,omitempty"`
+	// +optional
+	Unfollow *ILMEmptyAction `json:"unfollow,omitempty"`
+	// +optional
+	Readonly *ILMEmptyAction `json:"readonly,omitempty"`
+	// +optional
+	Downsample *ILMDownsampleAction `json:"downsample,omitempty"`
+	// +optional
+	SearchableSnapshot *ILMSearchableSnapshotAction `json:"searchable_snapshot,omitempty"`
+	// +optional
+	Allocate *ILMAllocateAction `json:"allocate,omitempty"`
+	// +optional
+	Migrate *ILMMigrateAction `json:"migrate,omitempty"`
+	// Freeze is deprecated, and ignored by Elasticsearch 8
+	// +optional
+	Freeze *ILMEmptyAction `json:"freeze,omitempty"`
+}
+
+// ILMFrozenPhase is the frozen phase of an ILM policy
+type ILMFrozenPhase struct {
+	// MinAge is the age of the index when it enters the phase (e.g., 90d)
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[0-9]+(d|h|m|s|ms|micros|nanos)$`
+	MinAge string `json:"min_age,omitempty"`
+
+	Actions ILMFrozenActions `json:"actions"`
+}
+
+// ILMFrozenActions are the actions allowed in the frozen phase
+type ILMFrozenActions struct {
+	// +optional
+	Unfollow *ILMEmptyAction `json:"unfollow,omitempty"`
+	// +optional
+	SearchableSnapshot *ILMSearchableSnapshotAction `json:"searchable_snapshot,omitempty"`
+}
+
+// ILMDeletePhase is the delete phase of an ILM policy
+type ILMDeletePhase struct {
+	// MinAge is the age of the index when it enters the phase (e.g., 90d)
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[0-9]+(d|h|m|s|ms|micros|nanos)$`
+	MinAge string `json:"min_age,omitempty"`
+
+	Actions ILMDeleteActions `json:"actions"`
+}
+
+// ILMDeleteActions are the actions allowed in the delete phase
+type ILMDeleteActions struct {
+	// +optional
+	WaitForSnapshot *ILMWaitForSnapshotAction `json:"wait_for_snapshot,omitempty"`
+	// +optional
+	Delete *ILMDeleteAction `json:"delete,omitempty"`
+}
+
+// ILMEmptyAction is an action without options, such as readonly or unfollow
+type ILMEmptyAction struct{}
+
+// ILMSetPriorityAction sets the recovery priority of the index
+type ILMSetPriorityAction struct {
+	// +kubebuilder:validation:Minimum=0
+	Priority int32 `json:"priority"`
+}
+
+// ILMRolloverAction rolls over the index once any of the max conditions, and all of the min conditions, are met
+// +kubebuilder:validation:MinProperties=1
+type ILMRolloverAction struct {
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[0-9]+(d|h|m|s|ms|micros|nanos)$`
+	MaxAge string `json:"max_age,omitempty"`
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxDocs *int64 `json:"max_docs,omitempty"`
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?([kKmMgGtTpP]?[bB])$`
+	MaxSize string `json:"max_size,omitempty"`
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?([kKmMgGtTpP]?[bB])$`
+	MaxPrimaryShardSize string `json:"max_primary_shard_size,omitempty"`
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxPrimaryShardDocs *int64 `json:"max_primary_shard_docs,omitempty"`
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[0-9]+(d|h|m|s|ms|micros|nanos)$`
+	MinAge string `json:"min_age,omitempty"`
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MinDocs *int64 `json:"min_docs,omitempty"`
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?([kKmMgGtTpP]?[bB])$`
+	MinSize string `json:"min_size,omitempty"`
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?([kKmMgGtTpP]?[bB])$`
+	MinPrimaryShardSize string `json:"min_primary_shard_size,omitempty"`
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MinPrimaryShardDocs *int64 `json:"min_primary_shard_docs,omitempty"`
+}
+
+// ILMDownsampleAction rolls up the documents of a time series index in intervals
+type ILMDownsampleAction struct {
+	// FixedInterval is the interval the documents are aggregated in (e.g., 1h)
+	// +kubebuilder:validation:Pattern=`^[0-9]+(d|h|m|s|ms)$`
+	FixedInterval string `json:"fixed_interval"`
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[0-9]+(d|h|m|s|ms|micros|nanos)$`
+	WaitTimeout string `json:"wait_timeout,omitempty"`
+}
+
+// ILMShrinkAction shrinks the index into fewer primary shards. One of numberOfShards or maxPrimaryShardSize is
+// required
+// +kubebuilder:validation:XValidation:rule="has(self.number_of_shards) != has(self.max_primary_shard_size)",message="exactly one of number_of_shards or max_primary_shard_size must be set"
+type ILMShrinkAction struct {
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	NumberOfShards *int32 `json:"number_of_shards,omitempty"`
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?([kKmMgGtTpP]?[bB])$`
+	MaxPrimaryShardSize string `json:"max_primary_shard_size,omitempty"`
+	// +optional
+	AllowWriteAfterShrink bool `json:"allow_write_after_shrink,omitempty"`
+}
+
+// ILMForcemergeAction force merges the index into at most maxNumSegments segments
+type ILMForcemergeAction struct {
+	// +kubebuilder:validation:Minimum=1
+	MaxNumSegments int32 `json:"max_num_segments"`
+	// +optional
+	// +kubebuilder:validation:Enum=best_compression
+	IndexCodec string `json:"index_codec,omitempty"`
+}
+
+// ILMSearchableSnapshotAction snapshots the index into a repository and mounts it as a searchable snapshot
+type ILMSearchableSnapshotAction struct {
+	// +kubebuilder:validation:MinLength=1
+	SnapshotRepository string `json:"snapshot_repository"`
+	// +optional
+	ForceMergeIndex *bool `json:"force_merge_index,omitempty"`
+}
+
+// ILMAllocateAction updates the allocation filters and the number of replicas of the index
+// +kubebuilder:validation:MinProperties=1
+type ILMAllocateAction struct {
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	NumberOfReplicas *int32 `json:"number_of_replicas,omitempty"`
+	// +optional
+	// +kubebuilder:validation:Minimum=-1
+	TotalShardsPerNode *int32 `json:"total_shards_per_node,omitempty"`
+	// +optional
+	Include map[string]string `json:"include,omitempty"`
+	// +optional
+	Exclude map[string]string `json:"exclude,omitempty"`
+	// +optional
+	Require map[string]string `json:"require,omitempty"`
+}
+
+// ILMMigrateAction moves the index to the data tier of the phase
+type ILMMigrateAction struct {
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+// ILMWaitForSnapshotAction waits for an SLM policy to take a snapshot before deleting the index
+type ILMWaitForSnapshotAction struct {
+	// +kubebuilder:validation:MinLength=1
+	Policy string `json:"policy"`
+}
+
+// ILMDeleteAction deletes the index
+type ILMDeleteAction struct {
+	// +optional
+	DeleteSearchableSnapshot *bool `json:"delete_searchable_snapshot,omitempty"`
+}
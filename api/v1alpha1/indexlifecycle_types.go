@@ -0,0 +1,182 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// IndexLifecycleSpec defines the desired state of IndexLifecycle
+// The policies are written as ILM policies to Elasticsearch clusters and as ISM policies to OpenSearch clusters
+type IndexLifecycleSpec struct {
+	// SyncInterval defines how often the operator will reconcile this resource (default: 10s)
+	// Examples: "30s", "5m", "1h"
+	// +optional
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ns|us|ms|s|m|h))+$`
+	SyncInterval string `json:"syncInterval,omitempty"`
+
+	// ResourceSelector specifies the target Elasticsearch or OpenSearch cluster for the policies
+	// If omitted, the NamespaceDefaultCluster of the namespace is used
+	// +optional
+	ResourceSelector ResourceSelector `json:"resourceSelector,omitzero"`
+
+	// Policies contains the lifecycle policies to apply, keyed by policy name
+	// +kubebuilder:validation:MinProperties=1
+	Policies map[string]LifecyclePolicy `json:"policies"`
+
+	// DeletionPolicy defines what happens to the objects of the resource in the cluster when the resource is
+	// deleted: Delete removes them, and Retain leaves them in the cluster (e.g. to move them to another resource)
+	// +optional
+	// +kubebuilder:default=Delete
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// Suspend pauses the reconciliation of the resource, including the deletion of its objects from the cluster when
+	// the resource is deleted, while leaving its status untouched
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+}
+
+// LifecyclePolicy defines a lifecycle policy in the terms shared by ILM and ISM: the indices are rolled over, then
+// deleted once they are old enough
+// +kubebuilder:validation:XValidation:rule="has(self.rollover) || has(self.retention)",message="one of rollover or retention must be set"
+type LifecyclePolicy struct {
+	// Description of the policy, stored in the _meta of ILM policies and in the description of ISM policies
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// IndexPatterns attaches the policy to the new indices matching them, through the ism_template of the ISM
+	// policies. Elasticsearch attaches ILM policies through the index.lifecycle.name setting of the index templates
+	// instead, so they are not used there
+	// +optional
+	IndexPatterns []string `json:"indexPatterns,omitempty"`
+
+	// Priority of the ism_template, choosing the policy of the indices matching the patterns of several policies
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	Priority int32 `json:"priority,omitempty"`
+
+	// Rollover rolls the write index over once any of its conditions is met
+	// +optional
+	Rollover *LifecycleRollover `json:"rollover,omitempty"`
+
+	// Retention is the age the indices are deleted at (e.g., "30d"), counted from their rollover when the policy
+	// rolls them over, or from their creation otherwise
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[0-9]+(d|h|m|s)$`
+	Retention string `json:"retention,omitempty"`
+}
+
+// LifecycleRollover defines the conditions rolling the write index over
+// +kubebuilder:validation:XValidation:rule="has(self.maxAge) || has(self.maxPrimaryShardSize) || has(self.maxDocs)",message="one of maxAge, maxPrimaryShardSize or maxDocs must be set"
+type LifecycleRollover struct {
+	// MaxAge is the age of the index since its creation (e.g., "1d")
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[0-9]+(d|h|m|s)$`
+	MaxAge string `json:"maxAge,omitempty"`
+
+	// MaxPrimaryShardSize is the size of the largest primary shard of the index (e.g., "50gb")
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[0-9]+(b|kb|mb|gb|tb|pb)$`
+	MaxPrimaryShardSize string `json:"maxPrimaryShardSize,omitempty"`
+
+	// MaxDocs is the number of documents of the index
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxDocs *int64 `json:"maxDocs,omitempty"`
+}
+
+// IndexLifecycleStatus defines the observed state of IndexLifecycle.
+type IndexLifecycleStatus struct {
+	// Phase indicates the current phase of the IndexLifecycle.
+	// It can be "Pending", "Syncing", "Ready", or "Error".
+	// +optional
+	Phase string `json:"phase,omitempty"`
+
+	// Message provides a human-readable message about the current status.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// TargetCluster is the namespace/name of the target cluster
+	// Format: "namespace/name"
+	// +optional
+	TargetCluster string `json:"targetCluster,omitempty"`
+
+	// ClusterType is the type of the target cluster (elasticsearch or opensearch), telling whether the policies were
+	// applied as ILM or ISM policies
+	// +optional
+	ClusterType string `json:"clusterType,omitempty"`
+
+	// AppliedResources lists the names of the policies that were successfully applied to the cluster.
+	// This is used to track which policies need to be deleted if they are removed from the spec.
+	// +optional
+	AppliedResources []string `json:"appliedResources,omitempty"`
+
+	// LastSyncTime records the last time the resource was successfully synchronized with the cluster.
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// ObservedGeneration is the generation of the spec the status refers to
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// conditions represent the current state of the IndexLifecycle resource.
+	// Each condition has a unique type and reflects the status of a specific aspect of the resource.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="Current phase of the IndexLifecycle"
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".status.targetCluster",description="Target cluster"
+// +kubebuilder:printcolumn:name="Type",type="string",JSONPath=".status.clusterType",description="Type of the target cluster"
+// +kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.message",description="Detailed status message",priority=1
+// +kubebuilder:printcolumn:name="Last Sync",type="date",JSONPath=".status.lastSyncTime",description="Last successful synchronization time"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// IndexLifecycle is the Schema for the indexlifecycles API
+// This resource works with both Elasticsearch (ILM API) and OpenSearch (ISM API) clusters
+type IndexLifecycle struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitzero"`
+
+	// spec defines the desired state of IndexLifecycle
+	// +required
+	Spec IndexLifecycleSpec `json:"spec"`
+
+	// status defines the observed state of IndexLifecycle
+	// +optional
+	Status IndexLifecycleStatus `json:"status,omitzero"`
+}
+
+// +kubebuilder:object:root=true
+
+// IndexLifecycleList contains a list of IndexLifecycle
+type IndexLifecycleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitzero"`
+	Items           []IndexLifecycle `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&IndexLifecycle{}, &IndexLifecycleList{})
+}
@@ -17,6 +17,9 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"encoding/json"
+	"fmt"
+
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -25,13 +28,104 @@ import (
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
 // IndexLifecyclePolicySpec defines the desired state of IndexLifecyclePolicy
+// +kubebuilder:validation:XValidation:rule="has(self.resources) || has(self.policies)",message="one of resources or policies must be set"
+// +kubebuilder:validation:XValidation:rule="!has(self.resources) || !has(self.policies) || self.policies.all(name, !(name in self.resources))",message="policies can not be defined in both resources and policies"
 type IndexLifecyclePolicySpec struct {
-	ResourceSelector ResourceSelector                `json:"resourceSelector"`
-	Resources        map[string]apiextensionsv1.JSON `json:"resources"`
+	// ResourceSelector specifies the target cluster. If omitted, the NamespaceDefaultCluster of the namespace is used
+	// +optional
+	ResourceSelector ResourceSelector `json:"resourceSelector,omitzero"`
+
+	// Resources are the ILM policies as raw JSON bodies, keyed by name, for the policies using actions or fields
+	// unknown to the policies schema
+	// +optional
+	Resources map[string]apiextensionsv1.JSON `json:"resources,omitempty"`
+
+	// Policies are the ILM policies keyed by name, validated by the CRD schema
+	// +optional
+	Policies map[string]ILMPolicy `json:"policies,omitempty"`
+
+	// ValuesFrom are the ConfigMaps and Secrets whose keys can be referenced as ${key} in the strings of the
+	// resources, e.g. to use a different retention per environment. Later sources override the keys of the earlier
+	// ones, and the values are read again on every sync
+	// +optional
+	ValuesFrom []ValuesSource `json:"valuesFrom,omitempty"`
+
+	// Templating renders the strings of the policies as Go templates when set to GoTemplate, with the target cluster as
+	// .Cluster (.Cluster.Name, .Cluster.Version, ...) and the resource as .Name and .Namespace, e.g. to change
+	// the retention of the delete phase per cluster of resourceSelector.matchLabels
+	// +optional
+	// +kubebuilder:validation:Enum=GoTemplate
+	Templating string `json:"templating,omitempty"`
+
 	// SyncInterval defines the interval for reconciliation (e.g., "30s", "5m"). Defaults to 10s.
 	// +optional
 	// +kubebuilder:default="10s"
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ns|us|ms|s|m|h))+$`
 	SyncInterval string `json:"syncInterval,omitempty"`
+
+	// DryRun reports the changes the synchronization would make to Elasticsearch in the status and in events,
+	// without making them
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// RequiredClusterHealth is the minimum health of the cluster (green or yellow) for the changes to be applied.
+	// While the cluster is below it (e.g., red), the changes are postponed until it recovers
+	// +optional
+	// +kubebuilder:validation:Enum=green;yellow
+	RequiredClusterHealth string `json:"requiredClusterHealth,omitempty"`
+
+	// DependsOn lists the resources of the operator which must be Ready before the resource is synced (e.g., the
+	// SnapshotLifecyclePolicy waited for by its delete phases). The changes are postponed until all of them are Ready
+	// +optional
+	DependsOn []Dependency `json:"dependsOn,omitempty"`
+
+	// DeletionPolicy defines what happens to the objects of the resource in the cluster when the resource is
+	// deleted: Delete removes them, and Retain leaves them in the cluster (e.g. to move them to another resource)
+	// +optional
+	// +kubebuilder:default=Delete
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// Suspend pauses the reconciliation of the resource, including the deletion of its objects from the cluster when
+	// the resource is deleted, while leaving its status untouched
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+
+	// AdoptionPolicy defines what happens to the policies of the spec that already exist in the cluster but were not
+	// created by the resource (e.g., created by hand or owned by another resource): Adopt overwrites them, Fail leaves
+	// them untouched and reports a Conflict condition, and IgnoreDifferences leaves them as they are
+	// +optional
+	// +kubebuilder:default=Fail
+	AdoptionPolicy AdoptionPolicy `json:"adoptionPolicy,omitempty"`
+
+	// OpenSearchConversion defines what happens when the target cluster is OpenSearch, which has no ILM: Fail
+	// rejects it, and Convert writes a best-effort ISM translation of every policy, leaving out the actions without an
+	// ISM equivalent. Meant to smooth migrations from Elasticsearch to OpenSearch
+	// +optional
+	// +kubebuilder:default=Fail
+	OpenSearchConversion OpenSearchConversion `json:"openSearchConversion,omitempty"`
+
+	// Force deletes the ILM policies still in use by indices, data streams or index templates, when they are removed
+	// from the spec or the resource is deleted. Without it, they are left in the cluster and reported in the
+	// DeletionBlocked condition, as deleting them breaks the rollover of the live data streams using them
+	// +optional
+	Force bool `json:"force,omitempty"`
+}
+
+// PolicyBodies returns the bodies of all the policies of the spec keyed by name: the raw resources, and the
+// structured policies wrapped in the policy field expected by the ILM API
+func (spec *IndexLifecyclePolicySpec) PolicyBodies() (map[string]apiextensionsv1.JSON, error) {
+	bodies := make(map[string]apiextensionsv1.JSON, len(spec.Resources)+len(spec.Policies))
+	for name, body := range spec.Resources {
+		bodies[name] = body
+	}
+	for name, policy := range spec.Policies {
+		body, err := json.Marshal(map[string]ILMPolicy{"policy": policy})
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal policy %s: %w", name, err)
+		}
+		bodies[name] = apiextensionsv1.JSON{Raw: body}
+	}
+	return bodies, nil
 }
 
 // SecretKeySelector selects a key of a Secret.
@@ -45,32 +139,298 @@ type SecretKeySelector struct {
 	Key string `json:"key"`
 }
 
+// ConfigMapKeySelector selects a key of a ConfigMap
+type ConfigMapKeySelector struct {
+	// Name of the configmap
+	Name string `json:"name"`
+	// Namespace of the configmap (optional, defaults to the same namespace as the resource)
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// Key in the configmap to select
+	Key string `json:"key"`
+}
+
+// EndpointSource references the Secret or ConfigMap key holding the endpoint of a cluster
+// +kubebuilder:validation:XValidation:rule="has(self.secretKeyRef) != has(self.configMapKeyRef)",message="exactly one of secretKeyRef or configMapKeyRef must be set"
+type EndpointSource struct {
+	// SecretKeyRef selects the key of a Secret holding the endpoint
+	// +optional
+	SecretKeyRef *SecretKeySelector `json:"secretKeyRef,omitempty"`
+	// ConfigMapKeyRef selects the key of a ConfigMap holding the endpoint
+	// +optional
+	ConfigMapKeyRef *ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
+}
+
+// SecretReference references a whole Secret
+type SecretReference struct {
+	// Name of the secret
+	Name string `json:"name"`
+	// Namespace of the secret (optional, defaults to the same namespace as the resource)
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
 // ResourceSelector defines how to select and connect to an Elasticsearch cluster
+// +kubebuilder:validation:XValidation:rule="has(self.name) || has(self.connectionRef) || has(self.matchLabels)",message="one of name, connectionRef or matchLabels must be set"
+// +kubebuilder:validation:XValidation:rule="!has(self.connectionRef) || !(has(self.endpoint) || has(self.endpointFrom))",message="endpoint and endpointFrom can not be set together with connectionRef"
+// +kubebuilder:validation:XValidation:rule="!(has(self.endpoint) && has(self.endpointFrom))",message="only one of endpoint or endpointFrom can be set"
+// +kubebuilder:validation:XValidation:rule="!has(self.connectionRef) || !(has(self.basicAuthSecretRef) || has(self.apiKeySecretRef) || has(self.tokenSecretRef) || has(self.clientCertSecretRef))",message="basicAuthSecretRef, apiKeySecretRef, tokenSecretRef and clientCertSecretRef can not be set together with connectionRef"
+// +kubebuilder:validation:XValidation:rule="[has(self.username), has(self.basicAuthSecretRef), has(self.apiKeySecretRef), has(self.tokenSecretRef), has(self.aws)].filter(x, x).size() <= 1",message="only one of username, basicAuthSecretRef, apiKeySecretRef, tokenSecretRef or aws can be set"
+// +kubebuilder:validation:XValidation:rule="!has(self.aws) || has(self.endpoint) || has(self.endpointFrom)",message="endpoint or endpointFrom is required when aws is set"
+// +kubebuilder:validation:XValidation:rule="!(has(self.aws) && has(self.nodeDiscovery))",message="nodeDiscovery can not be set together with aws"
+// +kubebuilder:validation:XValidation:rule="!has(self.connectionRef) || !has(self.proxyURL)",message="proxyURL can not be set together with connectionRef"
+// +kubebuilder:validation:XValidation:rule="!has(self.connectionRef) || !has(self.nodeDiscovery)",message="nodeDiscovery can not be set together with connectionRef, set it in the ElasticsearchClusterConnection instead"
+// +kubebuilder:validation:XValidation:rule="!has(self.connectionRef) || !has(self.headers)",message="headers can not be set together with connectionRef, set them in the ElasticsearchClusterConnection instead"
+// +kubebuilder:validation:XValidation:rule="!has(self.connectionRef) || !has(self.timeouts)",message="timeouts can not be set together with connectionRef, set them in the ElasticsearchClusterConnection instead"
+// +kubebuilder:validation:XValidation:rule="!has(self.connectionRef) || !has(self.compression)",message="compression can not be set together with connectionRef, set it in the ElasticsearchClusterConnection instead"
+// +kubebuilder:validation:XValidation:rule="!(has(self.endpoint) || has(self.endpointFrom) || has(self.cloudID) || has(self.connectionRef)) || !(has(self.serviceName) || has(self.port))",message="serviceName and port can only be set with automatic discovery"
+// +kubebuilder:validation:XValidation:rule="!has(self.cloudID) || !(has(self.endpoint) || has(self.endpointFrom) || has(self.connectionRef) || has(self.aws) || has(self.nodeDiscovery))",message="cloudID can not be set together with endpoint, endpointFrom, connectionRef, aws or nodeDiscovery"
+// +kubebuilder:validation:XValidation:rule="!has(self.endpoint) || !self.endpoint.startsWith('http://') || !(has(self.caCertSecretRef) || has(self.caCertConfigMapRef) || has(self.clientCertSecretRef) || has(self.tlsServerName))",message="caCertSecretRef, caCertConfigMapRef, clientCertSecretRef and tlsServerName can not be set for plain HTTP endpoints"
+// +kubebuilder:validation:XValidation:rule="!(has(self.caCertSecretRef) && has(self.caCertConfigMapRef))",message="only one of caCertSecretRef or caCertConfigMapRef can be set"
+// +kubebuilder:validation:XValidation:rule="!has(self.matchLabels) || !(has(self.name) || has(self.endpoint) || has(self.endpointFrom) || has(self.cloudID) || has(self.connectionRef))",message="matchLabels can not be set together with name, endpoint, endpointFrom, cloudID or connectionRef"
+// +kubebuilder:validation:XValidation:rule="has(self.matchLabels) == has(oldSelf.matchLabels)",message="matchLabels can not be added or removed, recreate the resource instead"
 type ResourceSelector struct {
-	// Name of the Elasticsearch resource (ECK cluster name)
-	Name string `json:"name"`
+	// Name of the Elasticsearch resource (ECK cluster name) or of the OpenSearchCluster resource (OpenSearch
+	// Kubernetes operator). Optional when connectionRef or matchLabels is set
+	// +optional
+	Name string `json:"name,omitempty"`
 	// Namespace of the Elasticsearch resource (defaults to the same namespace as this resource)
 	// +optional
 	Namespace string `json:"namespace,omitempty"`
+	// MatchLabels selects every ECK Elasticsearch resource of the namespace with these labels, instead of a single
+	// cluster by name. The resource is applied to each of them through a resource per cluster, owned by this one and
+	// reported in its status.clusters. Only supported by the kinds listed in the README
+	// +optional
+	MatchLabels map[string]string `json:"matchLabels,omitempty"`
+	// ServiceName overrides the HTTP Service of the ECK cluster ({name}-es-http), for clusters reached through a
+	// custom Service (e.g., the Service of a coordinating-only nodeSet). Only used with automatic discovery, where it
+	// also replaces the Service of an OpenSearchCluster
+	// +optional
+	ServiceName string `json:"serviceName,omitempty"`
+	// Port overrides the port of the HTTP Service of the ECK cluster or OpenSearchCluster. Only used with automatic
+	// discovery
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	Port int32 `json:"port,omitempty"`
 
 	// Manual configuration (optional) - if provided, these values override ECK automatic discovery
-	// Endpoint is the Elasticsearch URL (e.g., https://my-elasticsearch.example.com:9200)
+	// Endpoint is the Elasticsearch URL (e.g., https://my-elasticsearch.example.com:9200). Plain HTTP endpoints
+	// (http://) are reached without TLS, as for development clusters or meshes terminating TLS
+	// +kubebuilder:validation:Pattern=`^https?://`
 	// +optional
 	Endpoint string `json:"endpoint,omitempty"`
-	// Username for Elasticsearch authentication
+	// EndpointFrom reads the endpoint from a Secret or ConfigMap key, for endpoints provisioned by other systems
+	// (e.g., Crossplane or Terraform). The value is read again on every sync
+	// +optional
+	EndpointFrom *EndpointSource `json:"endpointFrom,omitempty"`
+	// CloudID of an Elastic Cloud deployment, used instead of the endpoint. Its certificate is verified with the
+	// system CA certificates, and the credentials are provided as for a manual endpoint (e.g., apiKeySecretRef)
+	// +optional
+	CloudID string `json:"cloudID,omitempty"`
+	// Username for Elasticsearch authentication. With ECK automatic discovery it replaces the elastic user,
+	// while the endpoint and CA certificate are still discovered
 	// +optional
 	Username string `json:"username,omitempty"`
 	// PasswordSecretRef references a Secret containing the password
 	// +optional
 	PasswordSecretRef *SecretKeySelector `json:"passwordSecretRef,omitempty"`
+	// BasicAuthSecretRef references a Secret containing both the username and password keys
+	// (e.g., a Secret of type kubernetes.io/basic-auth), used instead of username and passwordSecretRef
+	// +optional
+	BasicAuthSecretRef *SecretReference `json:"basicAuthSecretRef,omitempty"`
+	// APIKeySecretRef references a Secret containing an encoded API key, used instead of basic authentication.
+	// It can also be combined with ECK automatic discovery, replacing the credentials of the elastic user
+	// +optional
+	APIKeySecretRef *SecretKeySelector `json:"apiKeySecretRef,omitempty"`
+	// TokenSecretRef references a Secret containing a bearer token (e.g., a service account token), sent as
+	// "Authorization: Bearer <token>" instead of basic authentication
+	// +optional
+	TokenSecretRef *SecretKeySelector `json:"tokenSecretRef,omitempty"`
+	// ClientCertSecretRef references a Secret containing a client certificate (tls.crt and tls.key keys),
+	// presented to clusters requiring PKI realm authentication
+	// +optional
+	ClientCertSecretRef *SecretReference `json:"clientCertSecretRef,omitempty"`
+	// AWS signs the requests with AWS Signature Version 4, to manage domains of Amazon OpenSearch Service
+	// +optional
+	AWS *AWSAuthentication `json:"aws,omitempty"`
 	// CACertSecretRef references a Secret containing the CA certificate
 	// +optional
 	CACertSecretRef *SecretKeySelector `json:"caCertSecretRef,omitempty"`
+	// CACertConfigMapRef references a ConfigMap containing the CA certificate, such as the trust bundles
+	// distributed by trust-manager
+	// +optional
+	CACertConfigMapRef *ConfigMapKeySelector `json:"caCertConfigMapRef,omitempty"`
+	// UseSystemCA verifies the certificate of the endpoint with the CA certificates of the operator host,
+	// for clusters using publicly trusted certificates
+	// +optional
+	UseSystemCA bool `json:"useSystemCA,omitempty"`
+	// InsecureSkipTLSVerify disables the verification of the certificate of the endpoint (not recommended for production).
+	// Manually configured clusters need a CA certificate, useSystemCA or this option
+	// +optional
+	InsecureSkipTLSVerify bool `json:"insecureSkipTLSVerify,omitempty"`
+	// TLSServerName overrides the hostname used for SNI and to verify the certificate of the cluster, for clusters
+	// reached through load balancers or tunnels whose hostname does not match the certificate
+	// +optional
+	TLSServerName string `json:"tlsServerName,omitempty"`
+	// ProxyURL is the URL of the HTTP, HTTPS or SOCKS5 proxy used to reach the cluster
+	// (e.g., http://proxy.example.com:3128). If not defined, the HTTP_PROXY, HTTPS_PROXY and NO_PROXY
+	// environment variables of the operator are honored
+	// +optional
+	// +kubebuilder:validation:Pattern=`^(https?|socks5)://`
+	ProxyURL string `json:"proxyURL,omitempty"`
+	// Headers are extra HTTP headers attached to every request sent to the cluster
+	// (e.g., routing headers of API gateways or tenancy headers)
+	// +optional
+	Headers map[string]string `json:"headers,omitempty"`
+	// Timeouts overrides the default request and dial timeouts of the operator for this cluster
+	// +optional
+	Timeouts *ConnectionTimeouts `json:"timeouts,omitempty"`
+	// Compression compresses the request bodies with gzip, reducing the traffic of large policies and templates.
+	// Responses are always requested compressed
+	// +optional
+	Compression bool `json:"compression,omitempty"`
 	// ClusterType specifies the type of cluster: "elasticsearch" or "opensearch"
 	// If not specified, the operator will automatically detect the cluster type
 	// +optional
 	// +kubebuilder:validation:Enum=elasticsearch;opensearch
 	ClusterType string `json:"clusterType,omitempty"`
+	// NodeDiscovery enables the discovery of the cluster nodes (sniffing), spreading the requests across them
+	// instead of sending all of them to the endpoint
+	// +optional
+	NodeDiscovery *NodeDiscovery `json:"nodeDiscovery,omitempty"`
+
+	// ConnectionRef references an ElasticsearchClusterConnection defining the endpoint, authentication and TLS
+	// of the cluster. When set, it replaces ECK automatic discovery and the manual configuration
+	// +optional
+	ConnectionRef *ClusterConnectionReference `json:"connectionRef,omitempty"`
+}
+
+// AWSAuthentication defines how requests to Amazon OpenSearch Service are signed
+// +kubebuilder:validation:XValidation:rule="has(self.accessKeyIDSecretRef) == has(self.secretAccessKeySecretRef)",message="accessKeyIDSecretRef and secretAccessKeySecretRef must be set together"
+type AWSAuthentication struct {
+	// Region of the domain (e.g., "eu-west-1")
+	Region string `json:"region"`
+	// Service is the signing name of the domain: "es" for managed domains (default) or "aoss" for OpenSearch Serverless
+	// +optional
+	// +kubebuilder:validation:Enum=es;aoss
+	Service string `json:"service,omitempty"`
+	// RoleARN is the ARN of a role assumed before signing the requests.
+	// It is assumed with the static keys when provided, or with the default credentials of the operator otherwise
+	// +optional
+	RoleARN string `json:"roleARN,omitempty"`
+	// AccessKeyIDSecretRef references a Secret containing the access key ID of static credentials.
+	// When omitted, the default credentials of the operator are used (IRSA, EKS Pod Identity, instance profile, ...)
+	// +optional
+	AccessKeyIDSecretRef *SecretKeySelector `json:"accessKeyIDSecretRef,omitempty"`
+	// SecretAccessKeySecretRef references a Secret containing the secret access key of static credentials
+	// +optional
+	SecretAccessKeySecretRef *SecretKeySelector `json:"secretAccessKeySecretRef,omitempty"`
+}
+
+// NodeDiscovery configures the discovery of the nodes of a cluster. The discovered nodes must be reachable by the
+// operator through their published HTTP address, so it is meant for self-managed clusters
+type NodeDiscovery struct {
+	// Interval defines how often the nodes are discovered again (e.g., "5m").
+	// If not defined, the nodes are only discovered when the connection is created
+	// +optional
+	Interval string `json:"interval,omitempty"`
+}
+
+// ConnectionTimeouts defines the timeouts of the requests sent to a cluster
+type ConnectionTimeouts struct {
+	// Request is the time to wait for the response headers of a request (e.g., "60s" for slow snapshot
+	// repository verifications). Defaults to the --elasticsearch-request-timeout flag of the operator (10s)
+	// +optional
+	Request string `json:"request,omitempty"`
+	// Dial is the time to wait for a connection to the cluster to be established.
+	// Defaults to the --elasticsearch-dial-timeout flag of the operator (30s)
+	// +optional
+	Dial string `json:"dial,omitempty"`
+}
+
+// ClusterConnectionReference references an ElasticsearchClusterConnection
+type ClusterConnectionReference struct {
+	// Name of the ElasticsearchClusterConnection
+	Name string `json:"name"`
+	// Namespace of the ElasticsearchClusterConnection (defaults to the same namespace as this resource)
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// DeletionPolicy defines what happens to the objects of a resource in the cluster when the resource is deleted
+// +kubebuilder:validation:Enum=Delete;Retain
+type DeletionPolicy string
+
+const (
+	// DeletionPolicyDelete deletes the objects of the resource from the cluster
+	DeletionPolicyDelete DeletionPolicy = "Delete"
+	// DeletionPolicyRetain leaves the objects of the resource in the cluster, orphaned
+	DeletionPolicyRetain DeletionPolicy = "Retain"
+)
+
+// AdoptionPolicy defines what happens to the objects of the spec that already exist in the cluster but were not
+// created by the resource
+// +kubebuilder:validation:Enum=Adopt;Fail;IgnoreDifferences
+type AdoptionPolicy string
+
+const (
+	// AdoptionPolicyAdopt overwrites the existing objects, which become owned by the resource
+	AdoptionPolicyAdopt AdoptionPolicy = "Adopt"
+	// AdoptionPolicyFail leaves the existing objects untouched, and fails their synchronization with a conflict
+	AdoptionPolicyFail AdoptionPolicy = "Fail"
+	// AdoptionPolicyIgnoreDifferences leaves the existing objects as they are in the cluster, without failing
+	AdoptionPolicyIgnoreDifferences AdoptionPolicy = "IgnoreDifferences"
+)
+
+// OpenSearchConversion defines what happens to the ILM policies of a resource targeting an OpenSearch cluster
+// +kubebuilder:validation:Enum=Fail;Convert
+type OpenSearchConversion string
+
+const (
+	// OpenSearchConversionFail fails the synchronization, as OpenSearch has no ILM
+	OpenSearchConversionFail OpenSearchConversion = "Fail"
+	// OpenSearchConversionConvert writes the policies as ISM policies translated from their ILM phases
+	OpenSearchConversionConvert OpenSearchConversion = "Convert"
+)
+
+// ResourceStatus is the status of an object of a resource in the cluster
+type ResourceStatus struct {
+	// State of the object after the last synchronization: Applied, Failed or Ignored
+	State string `json:"state"`
+	// LastAppliedTime is the time the object was last written to the cluster
+	// +optional
+	LastAppliedTime *metav1.Time `json:"lastAppliedTime,omitempty"`
+	// LastError is the error of the last synchronization of the object, when it failed
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+	// Hash of the last body applied, so the unchanged objects are not written again
+	// +optional
+	Hash string `json:"hash,omitempty"`
+}
+
+// ObjectChange is a change made by a synchronization to an object of the cluster
+type ObjectChange struct {
+	// Name of the changed object
+	Name string `json:"name"`
+	// Action made on the object
+	// +kubebuilder:validation:Enum=Create;Update;Delete
+	Action string `json:"action"`
+	// Fields are the fields changed by an update, with their desired and live values
+	// +optional
+	Fields []FieldChange `json:"fields,omitempty"`
+}
+
+// FieldChange is a field changed by a synchronization
+type FieldChange struct {
+	// Path of the field, with dotted keys and indexes (e.g. "policy.phases.hot.actions.rollover.max_age")
+	Path string `json:"path"`
+	// Desired is the JSON value set by the synchronization, empty when the field is removed
+	// +optional
+	Desired string `json:"desired,omitempty"`
+	// Live is the JSON value the field had in the cluster, empty when the field was missing
+	// +optional
+	Live string `json:"live,omitempty"`
 }
 
 // IndexLifecyclePolicyStatus defines the observed state of IndexLifecyclePolicy.
@@ -95,14 +455,42 @@ type IndexLifecyclePolicyStatus struct {
 	// +optional
 	TargetCluster string `json:"targetCluster,omitempty"`
 
+	// Clusters is the status of every cluster selected by resourceSelector.matchLabels, reported by the resource
+	// created for each of them
+	// +listType=map
+	// +listMapKey=cluster
+	// +optional
+	Clusters []ClusterTargetStatus `json:"clusters,omitempty"`
+
 	// AppliedResources is a list of resource names that have been successfully applied to Elasticsearch
 	// +optional
 	AppliedResources []string `json:"appliedResources,omitempty"`
 
+	// AppliedCount is the number of policies applied by the last synchronization
+	// +optional
+	AppliedCount int32 `json:"appliedCount"`
+
+	// FailedCount is the number of policies that failed to sync in the last synchronization, which is Degraded when
+	// some of them were applied all the same
+	// +optional
+	FailedCount int32 `json:"failedCount"`
+
 	// LastSyncTime is the timestamp of the last successful synchronization with Elasticsearch
 	// +optional
 	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
 
+	// ObservedGeneration is the generation of the spec the status refers to
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Resources is the status of every policy of the spec in Elasticsearch, keyed by name
+	// +optional
+	Resources map[string]ResourceStatus `json:"resources,omitempty"`
+
+	// Changes are the changes made to Elasticsearch by the last synchronization that changed anything
+	// +optional
+	Changes []ObjectChange `json:"changes,omitempty"`
+
 	// conditions represent the current state of the IndexLifecyclePolicy resource.
 	// Each condition has a unique type and reflects the status of a specific aspect of the resource.
 	//
@@ -122,6 +510,8 @@ type IndexLifecyclePolicyStatus struct {
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
 // +kubebuilder:printcolumn:name="Cluster",type=string,JSONPath=`.status.targetCluster`
+// +kubebuilder:printcolumn:name="Applied",type=integer,JSONPath=`.status.appliedCount`
+// +kubebuilder:printcolumn:name="Failed",type=integer,JSONPath=`.status.failedCount`
 // +kubebuilder:printcolumn:name="Message",type=string,JSONPath=`.status.message`,priority=1
 // +kubebuilder:printcolumn:name="Last Sync",type=date,JSONPath=`.status.lastSyncTime`
 // +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
@@ -27,14 +27,39 @@ type IndexStateManagementSpec struct {
 	// SyncInterval defines how often the operator will reconcile this resource (default: 10s)
 	// Examples: "30s", "5m", "1h"
 	// +optional
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ns|us|ms|s|m|h))+$`
 	SyncInterval string `json:"syncInterval,omitempty"`
 
 	// ResourceSelector specifies the target OpenSearch cluster for ISM policies
-	ResourceSelector ResourceSelector `json:"resourceSelector"`
+	// If omitted, the NamespaceDefaultCluster of the namespace is used
+	// +optional
+	ResourceSelector ResourceSelector `json:"resourceSelector,omitzero"`
 
 	// Resources contains the ISM policies to apply, keyed by policy name
 	// Each key represents a policy name, the value is the policy definition
 	Resources map[string]apiextensionsv1.JSON `json:"resources"`
+
+	// UpdateManagedIndices changes the indices managed by the policies to their new versions when they are updated,
+	// through the change_policy API. Only the indices matching the ism_template index patterns of a policy are
+	// changed, as indices otherwise keep running the version of the policy they started with
+	// +optional
+	UpdateManagedIndices bool `json:"updateManagedIndices,omitempty"`
+
+	// ApplyToExistingIndices attaches the policies to the existing indices matching their ism_template index patterns
+	// that are not managed by any policy, through the add API, as the ism_template only applies to new indices
+	// +optional
+	ApplyToExistingIndices bool `json:"applyToExistingIndices,omitempty"`
+
+	// DeletionPolicy defines what happens to the objects of the resource in the cluster when the resource is
+	// deleted: Delete removes them, and Retain leaves them in the cluster (e.g. to move them to another resource)
+	// +optional
+	// +kubebuilder:default=Delete
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// Suspend pauses the reconciliation of the resource, including the deletion of its objects from the cluster when
+	// the resource is deleted, while leaving its status untouched
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
 }
 
 // IndexStateManagementStatus defines the observed state of IndexStateManagement.
@@ -62,6 +87,10 @@ type IndexStateManagementStatus struct {
 	// +optional
 	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
 
+	// ObservedGeneration is the generation of the spec the status refers to
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
 	// conditions represent the current state of the IndexStateManagement resource.
 	// Each condition has a unique type and reflects the status of a specific aspect of the resource.
 	// +listType=map
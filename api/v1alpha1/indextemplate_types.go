@@ -17,6 +17,8 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"sort"
+
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -25,13 +27,103 @@ import (
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
 // IndexTemplateSpec defines the desired state of IndexTemplate
+// +kubebuilder:validation:XValidation:rule="has(self.resources) || has(self.resourcesFrom)",message="one of resources or resourcesFrom must be set"
 type IndexTemplateSpec struct {
-	ResourceSelector ResourceSelector                `json:"resourceSelector"`
-	Resources        map[string]apiextensionsv1.JSON `json:"resources"`
+	// ResourceSelector specifies the target cluster. If omitted, the NamespaceDefaultCluster of the namespace is used
+	// +optional
+	ResourceSelector ResourceSelector `json:"resourceSelector,omitzero"`
+
+	// Resources are the index templates as raw JSON bodies, keyed by name
+	// +optional
+	Resources map[string]apiextensionsv1.JSON `json:"resources,omitempty"`
+
+	// ResourcesFrom are the ConfigMaps and Secrets holding more index templates, for the ones too large to be kept
+	// inline. They are merged with the resources, and read again on every sync and whenever they change. A template
+	// can only be defined once
+	// +optional
+	ResourcesFrom []ResourcesSource `json:"resourcesFrom,omitempty"`
+
+	// ValuesFrom are the ConfigMaps and Secrets whose keys can be referenced as ${key} in the strings of the
+	// resources, e.g. to use a different number of replicas per environment. Later sources override the keys of the earlier
+	// ones, and the values are read again on every sync
+	// +optional
+	ValuesFrom []ValuesSource `json:"valuesFrom,omitempty"`
+
+	// Templating renders the strings of the resources as Go templates when set to GoTemplate, with the target cluster as
+	// .Cluster (.Cluster.Name, .Cluster.Version, ...) and the resource as .Name and .Namespace, e.g. to change
+	// the number of replicas of the templates per cluster of resourceSelector.matchLabels
+	// +optional
+	// +kubebuilder:validation:Enum=GoTemplate
+	Templating string `json:"templating,omitempty"`
+
+	// ApplyOrder lists the names of the templates applied first, in order. The templates missing from it are applied
+	// after them, sorted by name. It keeps the order of the resources of the v1beta1 API
+	// +optional
+	// +listType=set
+	ApplyOrder []string `json:"applyOrder,omitempty"`
+
 	// SyncInterval defines the interval for reconciliation (e.g., "30s", "5m"). Defaults to 10s.
 	// +optional
 	// +kubebuilder:default="10s"
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ns|us|ms|s|m|h))+$`
 	SyncInterval string `json:"syncInterval,omitempty"`
+
+	// DryRun reports the changes the synchronization would make to Elasticsearch in the status and in events,
+	// without making them
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// RequiredClusterHealth is the minimum health of the cluster (green or yellow) for the changes to be applied.
+	// While the cluster is below it (e.g., red), the changes are postponed until it recovers
+	// +optional
+	// +kubebuilder:validation:Enum=green;yellow
+	RequiredClusterHealth string `json:"requiredClusterHealth,omitempty"`
+
+	// DependsOn lists the resources of the operator which must be Ready before the resource is synced (e.g., the
+	// IndexLifecyclePolicy used by its templates). The changes are postponed until all of them are Ready
+	// +optional
+	DependsOn []Dependency `json:"dependsOn,omitempty"`
+
+	// DeletionPolicy defines what happens to the objects of the resource in the cluster when the resource is
+	// deleted: Delete removes them, and Retain leaves them in the cluster (e.g. to move them to another resource)
+	// +optional
+	// +kubebuilder:default=Delete
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// Suspend pauses the reconciliation of the resource, including the deletion of its objects from the cluster when
+	// the resource is deleted, while leaving its status untouched
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+
+	// AdoptionPolicy defines what happens to the templates of the spec that already exist in the cluster but were not
+	// created by the resource (e.g., created by hand or owned by another resource): Adopt overwrites them, Fail leaves
+	// them untouched and reports a Conflict condition, and IgnoreDifferences leaves them as they are
+	// +optional
+	// +kubebuilder:default=Fail
+	AdoptionPolicy AdoptionPolicy `json:"adoptionPolicy,omitempty"`
+
+	// Bootstrap creates the initial index of the rollover aliases of the templates, with the alias as its write
+	// alias, when the alias does not exist yet. Rollover policies (ILM or ISM) only work once that index exists
+	// +optional
+	Bootstrap []RolloverBootstrap `json:"bootstrap,omitempty"`
+
+	// CreateDataStreams creates the data streams of the data stream templates once they are applied, one for every
+	// index pattern without wildcards, so producers don't race the creation of the templates on fresh clusters
+	// +optional
+	CreateDataStreams bool `json:"createDataStreams,omitempty"`
+}
+
+// RolloverBootstrap is a rollover alias whose initial index is created along with the templates
+type RolloverBootstrap struct {
+	// Alias is the write alias rolled over by the lifecycle policy (e.g., the index.lifecycle.rollover_alias setting of
+	// the template)
+	// +kubebuilder:validation:MinLength=1
+	Alias string `json:"alias"`
+
+	// Index is the name of the initial index, which must match an index pattern of a template and end with a number
+	// to be rolled over. Defaults to <alias>-000001
+	// +optional
+	Index string `json:"index,omitempty"`
 }
 
 // IndexTemplateStatus defines the observed state of IndexTemplate.
@@ -56,14 +148,42 @@ type IndexTemplateStatus struct {
 	// +optional
 	TargetCluster string `json:"targetCluster,omitempty"`
 
+	// Clusters is the status of every cluster selected by resourceSelector.matchLabels, reported by the resource
+	// created for each of them
+	// +listType=map
+	// +listMapKey=cluster
+	// +optional
+	Clusters []ClusterTargetStatus `json:"clusters,omitempty"`
+
 	// AppliedResources is a list of resource names that have been successfully applied to Elasticsearch
 	// +optional
 	AppliedResources []string `json:"appliedResources,omitempty"`
 
+	// AppliedCount is the number of templates applied by the last synchronization
+	// +optional
+	AppliedCount int32 `json:"appliedCount"`
+
+	// FailedCount is the number of templates that failed to sync in the last synchronization, while the other ones
+	// were applied
+	// +optional
+	FailedCount int32 `json:"failedCount"`
+
 	// LastSyncTime is the timestamp of the last successful synchronization with Elasticsearch
 	// +optional
 	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
 
+	// ObservedGeneration is the generation of the spec the status refers to
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Resources is the status of every template of the spec in Elasticsearch, keyed by name
+	// +optional
+	Resources map[string]ResourceStatus `json:"resources,omitempty"`
+
+	// Changes are the changes made to Elasticsearch by the last synchronization that changed anything
+	// +optional
+	Changes []ObjectChange `json:"changes,omitempty"`
+
 	// conditions represent the current state of the IndexTemplate resource.
 	// Each condition has a unique type and reflects the status of a specific aspect of the resource.
 	//
@@ -81,8 +201,11 @@ type IndexTemplateStatus struct {
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
 // +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
 // +kubebuilder:printcolumn:name="Cluster",type=string,JSONPath=`.status.targetCluster`
+// +kubebuilder:printcolumn:name="Applied",type=integer,JSONPath=`.status.appliedCount`
+// +kubebuilder:printcolumn:name="Failed",type=integer,JSONPath=`.status.failedCount`
 // +kubebuilder:printcolumn:name="Message",type=string,JSONPath=`.status.message`,priority=1
 // +kubebuilder:printcolumn:name="Last Sync",type=date,JSONPath=`.status.lastSyncTime`
 // +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
@@ -113,6 +236,34 @@ type IndexTemplateList struct {
 	Items           []IndexTemplate `json:"items"`
 }
 
+// OrderedResourceNames returns the names of the templates of the spec in the order they are applied: the ones of
+// ApplyOrder first, then the rest sorted by name. Names of ApplyOrder missing from the resources are skipped
+func (spec *IndexTemplateSpec) OrderedResourceNames() []string {
+	return spec.OrderNames(spec.Resources)
+}
+
+// OrderNames returns the names of the given templates in the order of ApplyOrder, like OrderedResourceNames, for
+// templates which include the ones loaded from resourcesFrom
+func (spec *IndexTemplateSpec) OrderNames(resources map[string]apiextensionsv1.JSON) []string {
+	names := make([]string, 0, len(resources))
+	ordered := make(map[string]bool, len(spec.ApplyOrder))
+	for _, name := range spec.ApplyOrder {
+		if _, ok := resources[name]; ok && !ordered[name] {
+			names = append(names, name)
+			ordered[name] = true
+		}
+	}
+
+	rest := make([]string, 0, len(resources)-len(names))
+	for name := range resources {
+		if !ordered[name] {
+			rest = append(rest, name)
+		}
+	}
+	sort.Strings(rest)
+	return append(names, rest...)
+}
+
 func init() {
 	SchemeBuilder.Register(&IndexTemplate{}, &IndexTemplateList{})
 }
@@ -0,0 +1,162 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// KibanaConnector defines a Kibana connector used by the alerting rules actions
+type KibanaConnector struct {
+	// Name is the display name of the connector (defaults to the connector ID)
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// ConnectorTypeID is the type of the connector (e.g., ".webhook", ".slack", ".email", ".index")
+	// It cannot be changed once the connector is created
+	ConnectorTypeID string `json:"connectorTypeId"`
+
+	// Config contains the connector type specific configuration
+	// +optional
+	Config *apiextensionsv1.JSON `json:"config,omitempty"`
+
+	// SecretRefs references Secrets holding the sensitive connector settings, keyed by secret field name
+	// (e.g., "webhookUrl" for Slack connectors, "user" and "password" for webhook connectors)
+	// +optional
+	SecretRefs map[string]SecretKeySelector `json:"secretRefs,omitempty"`
+}
+
+// KibanaAlertRuleSpec defines the desired state of KibanaAlertRule
+type KibanaAlertRuleSpec struct {
+	// SyncInterval defines how often the operator will reconcile this resource (default: 10s)
+	// Examples: "30s", "5m", "1h"
+	// +optional
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ns|us|ms|s|m|h))+$`
+	SyncInterval string `json:"syncInterval,omitempty"`
+
+	// KibanaSelector specifies the target Kibana instance for the rules and connectors
+	KibanaSelector KibanaSelector `json:"kibanaSelector"`
+
+	// Space is the Kibana space the rules and connectors are created in (default: "default")
+	// +optional
+	Space string `json:"space,omitempty"`
+
+	// Connectors contains the connectors used by the rules actions, keyed by connector ID
+	// +optional
+	Connectors map[string]KibanaConnector `json:"connectors,omitempty"`
+
+	// Resources contains the alerting rules to apply, keyed by rule ID
+	// Each value is the rule definition (name, rule_type_id, consumer, schedule, params, actions, ...)
+	Resources map[string]apiextensionsv1.JSON `json:"resources"`
+
+	// DeletionPolicy defines what happens to the objects of the resource in the cluster when the resource is
+	// deleted: Delete removes them, and Retain leaves them in the cluster (e.g. to move them to another resource)
+	// +optional
+	// +kubebuilder:default=Delete
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// Suspend pauses the reconciliation of the resource, including the deletion of its objects from the cluster when
+	// the resource is deleted, while leaving its status untouched
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+}
+
+// KibanaAlertRuleStatus defines the observed state of KibanaAlertRule.
+type KibanaAlertRuleStatus struct {
+	// Phase indicates the current phase of the KibanaAlertRule.
+	// It can be "Pending", "Syncing", "Ready", or "Error".
+	// +optional
+	Phase string `json:"phase,omitempty"`
+
+	// Message provides a human-readable message about the current status.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// TargetKibana is the namespace/name of the target Kibana instance
+	// Format: "namespace/name"
+	// +optional
+	TargetKibana string `json:"targetKibana,omitempty"`
+
+	// Space is the Kibana space the rules and connectors were created in
+	// +optional
+	Space string `json:"space,omitempty"`
+
+	// AppliedResources lists the IDs of the rules that were successfully applied to Kibana.
+	// This is used to track which rules need to be deleted if they are removed from the spec.
+	// +optional
+	AppliedResources []string `json:"appliedResources,omitempty"`
+
+	// AppliedConnectors lists the IDs of the connectors that were successfully applied to Kibana.
+	// This is used to track which connectors need to be deleted if they are removed from the spec.
+	// +optional
+	AppliedConnectors []string `json:"appliedConnectors,omitempty"`
+
+	// LastSyncTime records the last time the resource was successfully synchronized with Kibana.
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// ObservedGeneration is the generation of the spec the status refers to
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// conditions represent the current state of the KibanaAlertRule resource.
+	// Each condition has a unique type and reflects the status of a specific aspect of the resource.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="Current phase of the KibanaAlertRule"
+// +kubebuilder:printcolumn:name="Kibana",type="string",JSONPath=".status.targetKibana",description="Target Kibana"
+// +kubebuilder:printcolumn:name="Space",type="string",JSONPath=".status.space",description="Target Kibana space"
+// +kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.message",description="Detailed status message",priority=1
+// +kubebuilder:printcolumn:name="Last Sync",type="date",JSONPath=".status.lastSyncTime",description="Last successful synchronization time"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// KibanaAlertRule is the Schema for the kibanaalertrules API
+type KibanaAlertRule struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitzero"`
+
+	// spec defines the desired state of KibanaAlertRule
+	// +required
+	Spec KibanaAlertRuleSpec `json:"spec"`
+
+	// status defines the observed state of KibanaAlertRule
+	// +optional
+	Status KibanaAlertRuleStatus `json:"status,omitzero"`
+}
+
+// +kubebuilder:object:root=true
+
+// KibanaAlertRuleList contains a list of KibanaAlertRule
+type KibanaAlertRuleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitzero"`
+	Items           []KibanaAlertRule `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&KibanaAlertRule{}, &KibanaAlertRuleList{})
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NamespaceDefaultClusterName is the name of the NamespaceDefaultCluster read by the operator in each namespace
+const NamespaceDefaultClusterName = "default"
+
+// NamespaceDefaultClusterSpec defines the desired state of NamespaceDefaultCluster
+type NamespaceDefaultClusterSpec struct {
+	// ResourceSelector is used by the resources of the namespace that don't define their own resourceSelector
+	ResourceSelector ResourceSelector `json:"resourceSelector"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:validation:XValidation:rule="self.metadata.name == 'default'",message="the NamespaceDefaultCluster of a namespace must be named 'default'"
+// +kubebuilder:printcolumn:name="Cluster",type=string,JSONPath=`.spec.resourceSelector.name`
+// +kubebuilder:printcolumn:name="Connection",type=string,JSONPath=`.spec.resourceSelector.connectionRef.name`
+// +kubebuilder:printcolumn:name="Endpoint",type=string,JSONPath=`.spec.resourceSelector.endpoint`,priority=1
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// NamespaceDefaultCluster is the Schema for the namespacedefaultclusters API.
+// It defines the cluster targeted by the resources of its namespace that omit the resourceSelector
+type NamespaceDefaultCluster struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitzero"`
+
+	// spec defines the desired state of NamespaceDefaultCluster
+	// +required
+	Spec NamespaceDefaultClusterSpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+
+// NamespaceDefaultClusterList contains a list of NamespaceDefaultCluster
+type NamespaceDefaultClusterList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitzero"`
+	Items           []NamespaceDefaultCluster `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&NamespaceDefaultCluster{}, &NamespaceDefaultClusterList{})
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NodeShutdownSpec defines the desired state of NodeShutdown
+// Shutdowns are managed through the Elasticsearch node shutdown API (_nodes/{node_id}/shutdown)
+type NodeShutdownSpec struct {
+	// SyncInterval defines how often the operator will reconcile this resource (default: 10s)
+	// Examples: "30s", "5m", "1h"
+	// +optional
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ns|us|ms|s|m|h))+$`
+	SyncInterval string `json:"syncInterval,omitempty"`
+
+	// ResourceSelector specifies the target Elasticsearch cluster for the node shutdowns
+	// If omitted, the NamespaceDefaultCluster of the namespace is used
+	// +optional
+	ResourceSelector ResourceSelector `json:"resourceSelector,omitzero"`
+
+	// Resources contains the nodes to prepare for shutdown, keyed by node name or ID.
+	// Removing a node from the list cancels its shutdown, so it gets shards allocated again
+	Resources map[string]NodeShutdownDefinition `json:"resources"`
+
+	// DeletionPolicy defines what happens to the objects of the resource in the cluster when the resource is
+	// deleted: Delete removes them, and Retain leaves them in the cluster (e.g. to move them to another resource)
+	// +optional
+	// +kubebuilder:default=Delete
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// Suspend pauses the reconciliation of the resource, including the deletion of its objects from the cluster when
+	// the resource is deleted, while leaving its status untouched
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+}
+
+// NodeShutdownDefinition defines the shutdown of a single node
+// +kubebuilder:validation:XValidation:rule="self.type == 'replace' || !has(self.targetNodeName)",message="targetNodeName is only allowed for replace shutdowns"
+// +kubebuilder:validation:XValidation:rule="self.type != 'replace' || has(self.targetNodeName)",message="targetNodeName is required for replace shutdowns"
+// +kubebuilder:validation:XValidation:rule="self.type == 'restart' || !has(self.allocationDelay)",message="allocationDelay is only allowed for restart shutdowns"
+type NodeShutdownDefinition struct {
+	// Type of shutdown: "restart" keeps the shards of the node, "remove" and "replace" migrate them to other nodes
+	// +kubebuilder:validation:Enum=restart;remove;replace
+	Type string `json:"type"`
+
+	// Reason is a human-readable reason recorded with the shutdown
+	Reason string `json:"reason"`
+
+	// AllocationDelay is how long to wait for a restarting node to come back before reallocating its shards (e.g., "10m")
+	// +optional
+	AllocationDelay string `json:"allocationDelay,omitempty"`
+
+	// TargetNodeName is the name of the node replacing the shut down node
+	// +optional
+	TargetNodeName string `json:"targetNodeName,omitempty"`
+}
+
+// NodeShutdownProgress reports the progress of the shutdown of a node
+type NodeShutdownProgress struct {
+	// NodeID is the ID of the node, resolved when the shutdown was registered
+	NodeID string `json:"nodeID"`
+
+	// Status is the overall shutdown status: NOT_STARTED, IN_PROGRESS, STALLED or COMPLETE
+	// +optional
+	Status string `json:"status,omitempty"`
+
+	// ShardMigrationStatus is the status of the migration of the shards of the node
+	// +optional
+	ShardMigrationStatus string `json:"shardMigrationStatus,omitempty"`
+
+	// ShardMigrationsRemaining is the number of shards still to be migrated
+	// +optional
+	ShardMigrationsRemaining int64 `json:"shardMigrationsRemaining,omitempty"`
+
+	// Explanation describes why the shard migration is stalled, if it is
+	// +optional
+	Explanation string `json:"explanation,omitempty"`
+}
+
+// NodeShutdownStatus defines the observed state of NodeShutdown.
+type NodeShutdownStatus struct {
+	// Phase indicates the current phase of the NodeShutdown.
+	// It can be "Pending", "Syncing", "Ready", or "Error".
+	// +optional
+	Phase string `json:"phase,omitempty"`
+
+	// Message provides a human-readable message about the current status.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// TargetCluster is the namespace/name of the target Elasticsearch cluster
+	// Format: "namespace/name"
+	// +optional
+	TargetCluster string `json:"targetCluster,omitempty"`
+
+	// AppliedResources lists the nodes whose shutdown was successfully registered in Elasticsearch.
+	// This is used to track which shutdowns need to be cancelled if they are removed from the spec.
+	// +optional
+	AppliedResources []string `json:"appliedResources,omitempty"`
+
+	// Nodes reports the shutdown progress of each node, keyed like the resources
+	// +optional
+	Nodes map[string]NodeShutdownProgress `json:"nodes,omitempty"`
+
+	// LastSyncTime records the last time the resource was successfully synchronized with Elasticsearch.
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// ObservedGeneration is the generation of the spec the status refers to
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// conditions represent the current state of the NodeShutdown resource.
+	// Each condition has a unique type and reflects the status of a specific aspect of the resource.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="Current phase of the NodeShutdown"
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".status.targetCluster",description="Target cluster"
+// +kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.message",description="Detailed status message",priority=1
+// +kubebuilder:printcolumn:name="Last Sync",type="date",JSONPath=".status.lastSyncTime",description="Last successful synchronization time"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// NodeShutdown is the Schema for the nodeshutdowns API
+// This resource is specifically for Elasticsearch clusters (node shutdown API)
+type NodeShutdown struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitzero"`
+
+	// spec defines the desired state of NodeShutdown
+	// +required
+	Spec NodeShutdownSpec `json:"spec"`
+
+	// status defines the observed state of NodeShutdown
+	// +optional
+	Status NodeShutdownStatus `json:"status,omitzero"`
+}
+
+// +kubebuilder:object:root=true
+
+// NodeShutdownList contains a list of NodeShutdown
+type NodeShutdownList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitzero"`
+	Items           []NodeShutdown `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&NodeShutdown{}, &NodeShutdownList{})
+}
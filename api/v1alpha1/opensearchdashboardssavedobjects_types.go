@@ -0,0 +1,154 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OpenSearchDashboardsSelector defines how to connect to an OpenSearch Dashboards instance
+type OpenSearchDashboardsSelector struct {
+	// Endpoint is the OpenSearch Dashboards URL (e.g., https://my-dashboards.example.com:5601)
+	Endpoint string `json:"endpoint"`
+	// Username for OpenSearch Dashboards authentication
+	// +optional
+	Username string `json:"username,omitempty"`
+	// PasswordSecretRef references a Secret containing the password
+	// +optional
+	PasswordSecretRef *SecretKeySelector `json:"passwordSecretRef,omitempty"`
+	// CACertSecretRef references a Secret containing the CA certificate
+	// +optional
+	CACertSecretRef *SecretKeySelector `json:"caCertSecretRef,omitempty"`
+}
+
+// OpenSearchDashboardsSavedObjectsSpec defines the desired state of OpenSearchDashboardsSavedObjects
+type OpenSearchDashboardsSavedObjectsSpec struct {
+	// SyncInterval defines how often the operator will reconcile this resource (default: 10s)
+	// Examples: "30s", "5m", "1h"
+	// +optional
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ns|us|ms|s|m|h))+$`
+	SyncInterval string `json:"syncInterval,omitempty"`
+
+	// DashboardsSelector specifies the target OpenSearch Dashboards instance for the saved objects
+	DashboardsSelector OpenSearchDashboardsSelector `json:"dashboardsSelector"`
+
+	// Tenant is the security plugin tenant the saved objects are imported into ("global", "private" or a custom tenant)
+	// When omitted, the default tenant of the user is used
+	// +optional
+	Tenant string `json:"tenant,omitempty"`
+
+	// Overwrite defines whether existing saved objects with the same ID are overwritten (default: true)
+	// When false, objects that already exist in OpenSearch Dashboards are left untouched
+	// +optional
+	Overwrite *bool `json:"overwrite,omitempty"`
+
+	// Resources contains the saved objects to import, keyed by saved object ID
+	// Each value is a saved object in the OpenSearch Dashboards export format (type, attributes, references, ...)
+	Resources map[string]apiextensionsv1.JSON `json:"resources"`
+
+	// DeletionPolicy defines what happens to the objects of the resource in the cluster when the resource is
+	// deleted: Delete removes them, and Retain leaves them in the cluster (e.g. to move them to another resource)
+	// +optional
+	// +kubebuilder:default=Delete
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// Suspend pauses the reconciliation of the resource, including the deletion of its objects from the cluster when
+	// the resource is deleted, while leaving its status untouched
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+}
+
+// OpenSearchDashboardsSavedObjectsStatus defines the observed state of OpenSearchDashboardsSavedObjects.
+type OpenSearchDashboardsSavedObjectsStatus struct {
+	// Phase indicates the current phase of the OpenSearchDashboardsSavedObjects.
+	// It can be "Pending", "Syncing", "Ready", or "Error".
+	// +optional
+	Phase string `json:"phase,omitempty"`
+
+	// Message provides a human-readable message about the current status.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// TargetDashboards is the endpoint of the target OpenSearch Dashboards instance
+	// +optional
+	TargetDashboards string `json:"targetDashboards,omitempty"`
+
+	// Tenant is the security plugin tenant the saved objects were imported into
+	// +optional
+	Tenant string `json:"tenant,omitempty"`
+
+	// AppliedResources lists the saved objects that were successfully imported into OpenSearch Dashboards.
+	// Format: "type/id" (e.g., "dashboard/my-dashboard")
+	// This is used to track which saved objects need to be deleted if they are removed from the spec.
+	// +optional
+	AppliedResources []string `json:"appliedResources,omitempty"`
+
+	// LastSyncTime records the last time the resource was successfully synchronized with OpenSearch Dashboards.
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// ObservedGeneration is the generation of the spec the status refers to
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// conditions represent the current state of the OpenSearchDashboardsSavedObjects resource.
+	// Each condition has a unique type and reflects the status of a specific aspect of the resource.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="Current phase of the OpenSearchDashboardsSavedObjects"
+// +kubebuilder:printcolumn:name="Dashboards",type="string",JSONPath=".status.targetDashboards",description="Target OpenSearch Dashboards"
+// +kubebuilder:printcolumn:name="Tenant",type="string",JSONPath=".status.tenant",description="Target security tenant"
+// +kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.message",description="Detailed status message",priority=1
+// +kubebuilder:printcolumn:name="Last Sync",type="date",JSONPath=".status.lastSyncTime",description="Last successful synchronization time"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// OpenSearchDashboardsSavedObjects is the Schema for the opensearchdashboardssavedobjects API
+type OpenSearchDashboardsSavedObjects struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitzero"`
+
+	// spec defines the desired state of OpenSearchDashboardsSavedObjects
+	// +required
+	Spec OpenSearchDashboardsSavedObjectsSpec `json:"spec"`
+
+	// status defines the observed state of OpenSearchDashboardsSavedObjects
+	// +optional
+	Status OpenSearchDashboardsSavedObjectsStatus `json:"status,omitzero"`
+}
+
+// +kubebuilder:object:root=true
+
+// OpenSearchDashboardsSavedObjectsList contains a list of OpenSearchDashboardsSavedObjects
+type OpenSearchDashboardsSavedObjectsList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitzero"`
+	Items           []OpenSearchDashboardsSavedObjects `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OpenSearchDashboardsSavedObjects{}, &OpenSearchDashboardsSavedObjectsList{})
+}
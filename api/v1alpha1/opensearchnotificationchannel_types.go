@@ -0,0 +1,203 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OpenSearchNotificationChannelSpec defines the desired state of OpenSearchNotificationChannel
+// Channels are managed through the OpenSearch Notifications plugin (_plugins/_notifications/configs)
+type OpenSearchNotificationChannelSpec struct {
+	// SyncInterval defines how often the operator will reconcile this resource (default: 10s)
+	// Examples: "30s", "5m", "1h"
+	// +optional
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ns|us|ms|s|m|h))+$`
+	SyncInterval string `json:"syncInterval,omitempty"`
+
+	// ResourceSelector specifies the target OpenSearch cluster for notification channels
+	// If omitted, the NamespaceDefaultCluster of the namespace is used
+	// +optional
+	ResourceSelector ResourceSelector `json:"resourceSelector,omitzero"`
+
+	// Resources contains the notification channels to apply, keyed by channel config ID
+	// Each key is used as the config_id in OpenSearch, so monitors can reference it as a destination
+	Resources map[string]NotificationChannel `json:"resources"`
+
+	// DeletionPolicy defines what happens to the objects of the resource in the cluster when the resource is
+	// deleted: Delete removes them, and Retain leaves them in the cluster (e.g. to move them to another resource)
+	// +optional
+	// +kubebuilder:default=Delete
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// Suspend pauses the reconciliation of the resource, including the deletion of its objects from the cluster when
+	// the resource is deleted, while leaving its status untouched
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+}
+
+// NotificationChannel defines a single OpenSearch notification channel
+type NotificationChannel struct {
+	// Name is the display name of the channel (defaults to the resource key)
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Description of the channel
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// ConfigType is the type of the channel. It must match the configuration block that is set
+	// +kubebuilder:validation:Enum=webhook;slack;sns
+	ConfigType string `json:"configType"`
+
+	// Enabled defines whether the channel is active (default: true)
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Webhook configuration, required when configType is "webhook"
+	// +optional
+	Webhook *WebhookChannel `json:"webhook,omitempty"`
+
+	// Slack configuration, required when configType is "slack"
+	// +optional
+	Slack *SlackChannel `json:"slack,omitempty"`
+
+	// SNS configuration, required when configType is "sns"
+	// +optional
+	SNS *SNSChannel `json:"sns,omitempty"`
+}
+
+// WebhookChannel defines a custom webhook destination
+type WebhookChannel struct {
+	// URL of the webhook. Either url or urlSecretRef must be set
+	// +optional
+	URL string `json:"url,omitempty"`
+
+	// URLSecretRef references a Secret containing the webhook URL
+	// +optional
+	URLSecretRef *SecretKeySelector `json:"urlSecretRef,omitempty"`
+
+	// Method is the HTTP method used to call the webhook (default: POST)
+	// +optional
+	// +kubebuilder:validation:Enum=POST;PUT;PATCH
+	Method string `json:"method,omitempty"`
+
+	// HeaderParams are plain HTTP headers sent with each notification
+	// +optional
+	HeaderParams map[string]string `json:"headerParams,omitempty"`
+
+	// HeaderSecretRefs are HTTP headers whose values are read from Secrets (e.g. Authorization)
+	// +optional
+	HeaderSecretRefs map[string]SecretKeySelector `json:"headerSecretRefs,omitempty"`
+}
+
+// SlackChannel defines a Slack incoming webhook destination
+type SlackChannel struct {
+	// URL of the Slack incoming webhook. Either url or urlSecretRef must be set
+	// +optional
+	URL string `json:"url,omitempty"`
+
+	// URLSecretRef references a Secret containing the Slack incoming webhook URL
+	// +optional
+	URLSecretRef *SecretKeySelector `json:"urlSecretRef,omitempty"`
+}
+
+// SNSChannel defines an Amazon SNS topic destination
+type SNSChannel struct {
+	// TopicARN is the ARN of the SNS topic
+	TopicARN string `json:"topicArn"`
+
+	// RoleARN is the ARN of the IAM role OpenSearch assumes to publish to the topic
+	// +optional
+	RoleARN string `json:"roleArn,omitempty"`
+}
+
+// OpenSearchNotificationChannelStatus defines the observed state of OpenSearchNotificationChannel.
+type OpenSearchNotificationChannelStatus struct {
+	// Phase indicates the current phase of the OpenSearchNotificationChannel.
+	// It can be "Pending", "Syncing", "Ready", or "Error".
+	// +optional
+	Phase string `json:"phase,omitempty"`
+
+	// Message provides a human-readable message about the current status.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// TargetCluster is the namespace/name of the target OpenSearch cluster
+	// Format: "namespace/name"
+	// +optional
+	TargetCluster string `json:"targetCluster,omitempty"`
+
+	// AppliedResources lists the config IDs of the channels that were successfully applied to OpenSearch.
+	// This is used to track which channels need to be deleted if they are removed from the spec.
+	// +optional
+	AppliedResources []string `json:"appliedResources,omitempty"`
+
+	// LastSyncTime records the last time the resource was successfully synchronized with OpenSearch.
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// ObservedGeneration is the generation of the spec the status refers to
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// conditions represent the current state of the OpenSearchNotificationChannel resource.
+	// Each condition has a unique type and reflects the status of a specific aspect of the resource.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="Current phase of the OpenSearchNotificationChannel"
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".status.targetCluster",description="Target cluster"
+// +kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.message",description="Detailed status message",priority=1
+// +kubebuilder:printcolumn:name="Last Sync",type="date",JSONPath=".status.lastSyncTime",description="Last successful synchronization time"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// OpenSearchNotificationChannel is the Schema for the opensearchnotificationchannels API
+// This resource is specifically for OpenSearch clusters (Notifications plugin)
+type OpenSearchNotificationChannel struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitzero"`
+
+	// spec defines the desired state of OpenSearchNotificationChannel
+	// +required
+	Spec OpenSearchNotificationChannelSpec `json:"spec"`
+
+	// status defines the observed state of OpenSearchNotificationChannel
+	// +optional
+	Status OpenSearchNotificationChannelStatus `json:"status,omitzero"`
+}
+
+// +kubebuilder:object:root=true
+
+// OpenSearchNotificationChannelList contains a list of OpenSearchNotificationChannel
+type OpenSearchNotificationChannelList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitzero"`
+	Items           []OpenSearchNotificationChannel `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OpenSearchNotificationChannel{}, &OpenSearchNotificationChannelList{})
+}
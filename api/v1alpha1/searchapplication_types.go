@@ -0,0 +1,161 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SearchApplicationSpec defines the desired state of SearchApplication
+// Search applications are managed through the Elasticsearch search application API (_application/search_application/{name})
+type SearchApplicationSpec struct {
+	// SyncInterval defines how often the operator will reconcile this resource (default: 10s)
+	// Examples: "30s", "5m", "1h"
+	// +optional
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ns|us|ms|s|m|h))+$`
+	SyncInterval string `json:"syncInterval,omitempty"`
+
+	// ResourceSelector specifies the target Elasticsearch cluster for the search applications
+	// If omitted, the NamespaceDefaultCluster of the namespace is used
+	// +optional
+	ResourceSelector ResourceSelector `json:"resourceSelector,omitzero"`
+
+	// Resources contains the search applications to apply, keyed by search application name
+	Resources map[string]SearchApplicationDefinition `json:"resources"`
+
+	// DeletionPolicy defines what happens to the objects of the resource in the cluster when the resource is
+	// deleted: Delete removes them, and Retain leaves them in the cluster (e.g. to move them to another resource)
+	// +optional
+	// +kubebuilder:default=Delete
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// Suspend pauses the reconciliation of the resource, including the deletion of its objects from the cluster when
+	// the resource is deleted, while leaving its status untouched
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+}
+
+// SearchApplicationDefinition defines a single search application
+type SearchApplicationDefinition struct {
+	// Indices are the indices (or aliases) searched by the application
+	// +kubebuilder:validation:MinItems=1
+	Indices []string `json:"indices"`
+
+	// AnalyticsCollectionName is the behavioral analytics collection associated to the application
+	// +optional
+	AnalyticsCollectionName string `json:"analyticsCollectionName,omitempty"`
+
+	// Template is the search template used to build the queries of the application.
+	// When omitted, Elasticsearch uses a default template searching all fields
+	// +optional
+	Template *SearchApplicationTemplate `json:"template,omitempty"`
+}
+
+// SearchApplicationTemplate defines the search template of a search application
+type SearchApplicationTemplate struct {
+	// Source is the template source, either a query object or a mustache string
+	Source apiextensionsv1.JSON `json:"source"`
+
+	// Params contains the default values of the template parameters, used when a search doesn't set them
+	// +optional
+	Params map[string]apiextensionsv1.JSON `json:"params,omitempty"`
+
+	// Lang is the template language (default: mustache)
+	// +optional
+	Lang string `json:"lang,omitempty"`
+
+	// Dictionary is a JSON schema used to validate the parameters sent to the application
+	// +optional
+	Dictionary *apiextensionsv1.JSON `json:"dictionary,omitempty"`
+}
+
+// SearchApplicationStatus defines the observed state of SearchApplication.
+type SearchApplicationStatus struct {
+	// Phase indicates the current phase of the SearchApplication.
+	// It can be "Pending", "Syncing", "Ready", or "Error".
+	// +optional
+	Phase string `json:"phase,omitempty"`
+
+	// Message provides a human-readable message about the current status.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// TargetCluster is the namespace/name of the target Elasticsearch cluster
+	// Format: "namespace/name"
+	// +optional
+	TargetCluster string `json:"targetCluster,omitempty"`
+
+	// AppliedResources lists the names of the search applications that were successfully applied to Elasticsearch.
+	// This is used to track which search applications need to be deleted if they are removed from the spec.
+	// +optional
+	AppliedResources []string `json:"appliedResources,omitempty"`
+
+	// LastSyncTime records the last time the resource was successfully synchronized with Elasticsearch.
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// ObservedGeneration is the generation of the spec the status refers to
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// conditions represent the current state of the SearchApplication resource.
+	// Each condition has a unique type and reflects the status of a specific aspect of the resource.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="Current phase of the SearchApplication"
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".status.targetCluster",description="Target cluster"
+// +kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.message",description="Detailed status message",priority=1
+// +kubebuilder:printcolumn:name="Last Sync",type="date",JSONPath=".status.lastSyncTime",description="Last successful synchronization time"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// SearchApplication is the Schema for the searchapplications API
+// This resource is specifically for Elasticsearch clusters (search application API)
+type SearchApplication struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitzero"`
+
+	// spec defines the desired state of SearchApplication
+	// +required
+	Spec SearchApplicationSpec `json:"spec"`
+
+	// status defines the observed state of SearchApplication
+	// +optional
+	Status SearchApplicationStatus `json:"status,omitzero"`
+}
+
+// +kubebuilder:object:root=true
+
+// SearchApplicationList contains a list of SearchApplication
+type SearchApplicationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitzero"`
+	Items           []SearchApplication `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&SearchApplication{}, &SearchApplicationList{})
+}
@@ -26,12 +26,56 @@ import (
 
 // SnapshotLifecyclePolicySpec defines the desired state of SnapshotLifecyclePolicy
 type SnapshotLifecyclePolicySpec struct {
-	ResourceSelector ResourceSelector                `json:"resourceSelector"`
+	// ResourceSelector specifies the target cluster. If omitted, the NamespaceDefaultCluster of the namespace is used
+	// +optional
+	ResourceSelector ResourceSelector                `json:"resourceSelector,omitzero"`
 	Resources        map[string]apiextensionsv1.JSON `json:"resources"`
+
+	// ValuesFrom are the ConfigMaps and Secrets whose keys can be referenced as ${key} in the strings of the
+	// resources, e.g. to use a different retention per environment. Later sources override the keys of the earlier
+	// ones, and the values are read again on every sync
+	// +optional
+	ValuesFrom []ValuesSource `json:"valuesFrom,omitempty"`
+
+	// Templating renders the strings of the resources as Go templates when set to GoTemplate, with the target cluster as
+	// .Cluster (.Cluster.Name, .Cluster.Version, ...) and the resource as .Name and .Namespace, e.g. to change
+	// the repository of the policies per cluster of resourceSelector.matchLabels
+	// +optional
+	// +kubebuilder:validation:Enum=GoTemplate
+	Templating string `json:"templating,omitempty"`
+
 	// SyncInterval defines the interval for reconciliation (e.g., "30s", "5m"). Defaults to 10s.
 	// +optional
 	// +kubebuilder:default="10s"
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ns|us|ms|s|m|h))+$`
 	SyncInterval string `json:"syncInterval,omitempty"`
+
+	// DryRun reports the changes the synchronization would make to Elasticsearch in the status and in events,
+	// without making them
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// RequiredClusterHealth is the minimum health of the cluster (green or yellow) for the changes to be applied.
+	// While the cluster is below it (e.g., red), the changes are postponed until it recovers
+	// +optional
+	// +kubebuilder:validation:Enum=green;yellow
+	RequiredClusterHealth string `json:"requiredClusterHealth,omitempty"`
+
+	// DependsOn lists the resources of the operator which must be Ready before the resource is synced (e.g., the
+	// SnapshotRepository of its snapshots). The changes are postponed until all of them are Ready
+	// +optional
+	DependsOn []Dependency `json:"dependsOn,omitempty"`
+
+	// DeletionPolicy defines what happens to the objects of the resource in the cluster when the resource is
+	// deleted: Delete removes them, and Retain leaves them in the cluster (e.g. to move them to another resource)
+	// +optional
+	// +kubebuilder:default=Delete
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// Suspend pauses the reconciliation of the resource, including the deletion of its objects from the cluster when
+	// the resource is deleted, while leaving its status untouched
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
 }
 
 // SnapshotLifecyclePolicyStatus defines the observed state of SnapshotLifecyclePolicy.
@@ -56,14 +100,41 @@ type SnapshotLifecyclePolicyStatus struct {
 	// +optional
 	TargetCluster string `json:"targetCluster,omitempty"`
 
+	// Clusters is the status of every cluster selected by resourceSelector.matchLabels, reported by the resource
+	// created for each of them
+	// +listType=map
+	// +listMapKey=cluster
+	// +optional
+	Clusters []ClusterTargetStatus `json:"clusters,omitempty"`
+
 	// AppliedResources is a list of resource names that have been successfully applied to Elasticsearch
 	// +optional
 	AppliedResources []string `json:"appliedResources,omitempty"`
 
+	// AppliedCount is the number of policies applied by the last synchronization
+	// +optional
+	AppliedCount int32 `json:"appliedCount"`
+
+	// FailedCount is the number of policies that failed to sync in the last synchronization
+	// +optional
+	FailedCount int32 `json:"failedCount"`
+
 	// LastSyncTime is the timestamp of the last successful synchronization with Elasticsearch
 	// +optional
 	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
 
+	// ObservedGeneration is the generation of the spec the status refers to
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Resources is the status of every policy of the spec in Elasticsearch, keyed by name
+	// +optional
+	Resources map[string]ResourceStatus `json:"resources,omitempty"`
+
+	// Changes are the changes made to Elasticsearch by the last synchronization that changed anything
+	// +optional
+	Changes []ObjectChange `json:"changes,omitempty"`
+
 	// conditions represent the current state of the SnapshotLifecyclePolicy resource.
 	// Each condition has a unique type and reflects the status of a specific aspect of the resource.
 	//
@@ -83,6 +154,8 @@ type SnapshotLifecyclePolicyStatus struct {
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
 // +kubebuilder:printcolumn:name="Cluster",type=string,JSONPath=`.status.targetCluster`
+// +kubebuilder:printcolumn:name="Applied",type=integer,JSONPath=`.status.appliedCount`
+// +kubebuilder:printcolumn:name="Failed",type=integer,JSONPath=`.status.failedCount`
 // +kubebuilder:printcolumn:name="Message",type=string,JSONPath=`.status.message`,priority=1
 // +kubebuilder:printcolumn:name="Last Sync",type=date,JSONPath=`.status.lastSyncTime`
 // +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
@@ -26,12 +26,46 @@ import (
 
 // SnapshotRepositorySpec defines the desired state of SnapshotRepository
 type SnapshotRepositorySpec struct {
-	ResourceSelector ResourceSelector                `json:"resourceSelector"`
+	// ResourceSelector specifies the target cluster. If omitted, the NamespaceDefaultCluster of the namespace is used
+	// +optional
+	ResourceSelector ResourceSelector                `json:"resourceSelector,omitzero"`
 	Resources        map[string]apiextensionsv1.JSON `json:"resources"`
+
+	// ValuesFrom are the ConfigMaps and Secrets whose keys can be referenced as ${key} in the strings of the
+	// resources, e.g. to use a different bucket per environment. Later sources override the keys of the earlier
+	// ones, and the values are read again on every sync
+	// +optional
+	ValuesFrom []ValuesSource `json:"valuesFrom,omitempty"`
+
+	// Templating renders the strings of the resources as Go templates when set to GoTemplate, with the target cluster as
+	// .Cluster (.Cluster.Name, .Cluster.Version, ...) and the resource as .Name and .Namespace, e.g. to change
+	// the base_path of the repositories per cluster of resourceSelector.matchLabels
+	// +optional
+	// +kubebuilder:validation:Enum=GoTemplate
+	Templating string `json:"templating,omitempty"`
+
 	// SyncInterval defines the interval for reconciliation (e.g., "30s", "5m"). Defaults to 10s.
 	// +optional
 	// +kubebuilder:default="10s"
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ns|us|ms|s|m|h))+$`
 	SyncInterval string `json:"syncInterval,omitempty"`
+
+	// DeletionPolicy defines what happens to the objects of the resource in the cluster when the resource is
+	// deleted: Delete removes them, and Retain leaves them in the cluster (e.g. to move them to another resource)
+	// +optional
+	// +kubebuilder:default=Delete
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// Suspend pauses the reconciliation of the resource, including the deletion of its objects from the cluster when
+	// the resource is deleted, while leaving its status untouched
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+
+	// AllowDeleteWithSnapshots deletes the repositories still holding snapshots, when they are removed from the spec
+	// or the resource is deleted. Without it, they are left in the cluster and reported in the DeletionBlocked
+	// condition, so the backup history is not lost by mistake
+	// +optional
+	AllowDeleteWithSnapshots bool `json:"allowDeleteWithSnapshots,omitempty"`
 }
 
 // SnapshotRepositoryStatus defines the observed state of SnapshotRepository.
@@ -56,6 +90,13 @@ type SnapshotRepositoryStatus struct {
 	// +optional
 	TargetCluster string `json:"targetCluster,omitempty"`
 
+	// Clusters is the status of every cluster selected by resourceSelector.matchLabels, reported by the resource
+	// created for each of them
+	// +listType=map
+	// +listMapKey=cluster
+	// +optional
+	Clusters []ClusterTargetStatus `json:"clusters,omitempty"`
+
 	// AppliedResources is a list of resource names that have been successfully applied to Elasticsearch
 	// +optional
 	AppliedResources []string `json:"appliedResources,omitempty"`
@@ -64,6 +105,10 @@ type SnapshotRepositoryStatus struct {
 	// +optional
 	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
 
+	// ObservedGeneration is the generation of the spec the status refers to
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
 	// conditions represent the current state of the SnapshotRepository resource.
 	// Each condition has a unique type and reflects the status of a specific aspect of the resource.
 	//
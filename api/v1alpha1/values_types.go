@@ -0,0 +1,54 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// ValuesSource references a ConfigMap or a Secret of the namespace of a resource, whose keys can be referenced as
+// ${key} in the bodies of the resource
+type ValuesSource struct {
+	// Kind is the kind of the source
+	// +kubebuilder:validation:Enum=ConfigMap;Secret
+	Kind string `json:"kind"`
+
+	// Name is the name of the ConfigMap or Secret
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Optional skips the source when it does not exist, instead of failing the sync
+	// +optional
+	Optional bool `json:"optional,omitempty"`
+}
+
+// ResourcesSource references a ConfigMap or a Secret of the namespace of a resource holding JSON bodies of the
+// resource, for the bodies too large to be kept inline
+type ResourcesSource struct {
+	// Kind is the kind of the source
+	// +kubebuilder:validation:Enum=ConfigMap;Secret
+	Kind string `json:"kind"`
+
+	// Name is the name of the ConfigMap or Secret
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Key is the key of the source holding the body. When omitted, every key of the source is loaded. The bodies are
+	// named after their keys, without the .json extension
+	// +optional
+	Key string `json:"key,omitempty"`
+
+	// Optional skips the source when it, or its key, does not exist, instead of failing the sync
+	// +optional
+	Optional bool `json:"optional,omitempty"`
+}
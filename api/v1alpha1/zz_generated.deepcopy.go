@@ -27,7 +27,48 @@ import (
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterSettings) DeepCopyInto(out *ClusterSettings) {
+func (in *AWSAuthentication) DeepCopyInto(out *AWSAuthentication) {
+	*out = *in
+	if in.AccessKeyIDSecretRef != nil {
+		in, out := &in.AccessKeyIDSecretRef, &out.AccessKeyIDSecretRef
+		*out = new(SecretKeySelector)
+		**out = **in
+	}
+	if in.SecretAccessKeySecretRef != nil {
+		in, out := &in.SecretAccessKeySecretRef, &out.SecretAccessKeySecretRef
+		*out = new(SecretKeySelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSAuthentication.
+func (in *AWSAuthentication) DeepCopy() *AWSAuthentication {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSAuthentication)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AnomalyDetector) DeepCopyInto(out *AnomalyDetector) {
+	*out = *in
+	in.Definition.DeepCopyInto(&out.Definition)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AnomalyDetector.
+func (in *AnomalyDetector) DeepCopy() *AnomalyDetector {
+	if in == nil {
+		return nil
+	}
+	out := new(AnomalyDetector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationPrivilege) DeepCopyInto(out *ApplicationPrivilege) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -35,18 +76,18 @@ func (in *ClusterSettings) DeepCopyInto(out *ClusterSettings) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSettings.
-func (in *ClusterSettings) DeepCopy() *ClusterSettings {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationPrivilege.
+func (in *ApplicationPrivilege) DeepCopy() *ApplicationPrivilege {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterSettings)
+	out := new(ApplicationPrivilege)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterSettings) DeepCopyObject() runtime.Object {
+func (in *ApplicationPrivilege) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -54,31 +95,56 @@ func (in *ClusterSettings) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterSettingsList) DeepCopyInto(out *ClusterSettingsList) {
+func (in *ApplicationPrivilegeDefinition) DeepCopyInto(out *ApplicationPrivilegeDefinition) {
+	*out = *in
+	if in.Actions != nil {
+		in, out := &in.Actions, &out.Actions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = new(v1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationPrivilegeDefinition.
+func (in *ApplicationPrivilegeDefinition) DeepCopy() *ApplicationPrivilegeDefinition {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationPrivilegeDefinition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationPrivilegeList) DeepCopyInto(out *ApplicationPrivilegeList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]ClusterSettings, len(*in))
+		*out = make([]ApplicationPrivilege, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSettingsList.
-func (in *ClusterSettingsList) DeepCopy() *ClusterSettingsList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationPrivilegeList.
+func (in *ApplicationPrivilegeList) DeepCopy() *ApplicationPrivilegeList {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterSettingsList)
+	out := new(ApplicationPrivilegeList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ClusterSettingsList) DeepCopyObject() runtime.Object {
+func (in *ApplicationPrivilegeList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -86,30 +152,52 @@ func (in *ClusterSettingsList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterSettingsSpec) DeepCopyInto(out *ClusterSettingsSpec) {
+func (in *ApplicationPrivilegeSet) DeepCopyInto(out *ApplicationPrivilegeSet) {
+	*out = *in
+	if in.Privileges != nil {
+		in, out := &in.Privileges, &out.Privileges
+		*out = make(map[string]ApplicationPrivilegeDefinition, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationPrivilegeSet.
+func (in *ApplicationPrivilegeSet) DeepCopy() *ApplicationPrivilegeSet {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationPrivilegeSet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationPrivilegeSpec) DeepCopyInto(out *ApplicationPrivilegeSpec) {
 	*out = *in
 	in.ResourceSelector.DeepCopyInto(&out.ResourceSelector)
 	if in.Resources != nil {
 		in, out := &in.Resources, &out.Resources
-		*out = make(map[string]v1.JSON, len(*in))
+		*out = make(map[string]ApplicationPrivilegeSet, len(*in))
 		for key, val := range *in {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSettingsSpec.
-func (in *ClusterSettingsSpec) DeepCopy() *ClusterSettingsSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationPrivilegeSpec.
+func (in *ApplicationPrivilegeSpec) DeepCopy() *ApplicationPrivilegeSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterSettingsSpec)
+	out := new(ApplicationPrivilegeSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClusterSettingsStatus) DeepCopyInto(out *ClusterSettingsStatus) {
+func (in *ApplicationPrivilegeStatus) DeepCopyInto(out *ApplicationPrivilegeStatus) {
 	*out = *in
 	if in.AppliedResources != nil {
 		in, out := &in.AppliedResources, &out.AppliedResources
@@ -129,18 +217,18 @@ func (in *ClusterSettingsStatus) DeepCopyInto(out *ClusterSettingsStatus) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSettingsStatus.
-func (in *ClusterSettingsStatus) DeepCopy() *ClusterSettingsStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationPrivilegeStatus.
+func (in *ApplicationPrivilegeStatus) DeepCopy() *ApplicationPrivilegeStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ClusterSettingsStatus)
+	out := new(ApplicationPrivilegeStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IndexLifecyclePolicy) DeepCopyInto(out *IndexLifecyclePolicy) {
+func (in *AutoscalingPolicy) DeepCopyInto(out *AutoscalingPolicy) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -148,18 +236,18 @@ func (in *IndexLifecyclePolicy) DeepCopyInto(out *IndexLifecyclePolicy) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexLifecyclePolicy.
-func (in *IndexLifecyclePolicy) DeepCopy() *IndexLifecyclePolicy {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalingPolicy.
+func (in *AutoscalingPolicy) DeepCopy() *AutoscalingPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(IndexLifecyclePolicy)
+	out := new(AutoscalingPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *IndexLifecyclePolicy) DeepCopyObject() runtime.Object {
+func (in *AutoscalingPolicy) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -167,31 +255,31 @@ func (in *IndexLifecyclePolicy) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IndexLifecyclePolicyList) DeepCopyInto(out *IndexLifecyclePolicyList) {
+func (in *AutoscalingPolicyList) DeepCopyInto(out *AutoscalingPolicyList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]IndexLifecyclePolicy, len(*in))
+		*out = make([]AutoscalingPolicy, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexLifecyclePolicyList.
-func (in *IndexLifecyclePolicyList) DeepCopy() *IndexLifecyclePolicyList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalingPolicyList.
+func (in *AutoscalingPolicyList) DeepCopy() *AutoscalingPolicyList {
 	if in == nil {
 		return nil
 	}
-	out := new(IndexLifecyclePolicyList)
+	out := new(AutoscalingPolicyList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *IndexLifecyclePolicyList) DeepCopyObject() runtime.Object {
+func (in *AutoscalingPolicyList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -199,7 +287,7 @@ func (in *IndexLifecyclePolicyList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IndexLifecyclePolicySpec) DeepCopyInto(out *IndexLifecyclePolicySpec) {
+func (in *AutoscalingPolicySpec) DeepCopyInto(out *AutoscalingPolicySpec) {
 	*out = *in
 	in.ResourceSelector.DeepCopyInto(&out.ResourceSelector)
 	if in.Resources != nil {
@@ -211,18 +299,18 @@ func (in *IndexLifecyclePolicySpec) DeepCopyInto(out *IndexLifecyclePolicySpec)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexLifecyclePolicySpec.
-func (in *IndexLifecyclePolicySpec) DeepCopy() *IndexLifecyclePolicySpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalingPolicySpec.
+func (in *AutoscalingPolicySpec) DeepCopy() *AutoscalingPolicySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(IndexLifecyclePolicySpec)
+	out := new(AutoscalingPolicySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IndexLifecyclePolicyStatus) DeepCopyInto(out *IndexLifecyclePolicyStatus) {
+func (in *AutoscalingPolicyStatus) DeepCopyInto(out *AutoscalingPolicyStatus) {
 	*out = *in
 	if in.AppliedResources != nil {
 		in, out := &in.AppliedResources, &out.AppliedResources
@@ -242,18 +330,53 @@ func (in *IndexLifecyclePolicyStatus) DeepCopyInto(out *IndexLifecyclePolicyStat
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexLifecyclePolicyStatus.
-func (in *IndexLifecyclePolicyStatus) DeepCopy() *IndexLifecyclePolicyStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalingPolicyStatus.
+func (in *AutoscalingPolicyStatus) DeepCopy() *AutoscalingPolicyStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(IndexLifecyclePolicyStatus)
+	out := new(AutoscalingPolicyStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IndexStateManagement) DeepCopyInto(out *IndexStateManagement) {
+func (in *ClusterConnectionReference) DeepCopyInto(out *ClusterConnectionReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterConnectionReference.
+func (in *ClusterConnectionReference) DeepCopy() *ClusterConnectionReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterConnectionReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterConnectionTLS) DeepCopyInto(out *ClusterConnectionTLS) {
+	*out = *in
+	if in.CACertSecretRef != nil {
+		in, out := &in.CACertSecretRef, &out.CACertSecretRef
+		*out = new(SecretKeySelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterConnectionTLS.
+func (in *ClusterConnectionTLS) DeepCopy() *ClusterConnectionTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterConnectionTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterIndexLifecyclePolicy) DeepCopyInto(out *ClusterIndexLifecyclePolicy) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -261,18 +384,18 @@ func (in *IndexStateManagement) DeepCopyInto(out *IndexStateManagement) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexStateManagement.
-func (in *IndexStateManagement) DeepCopy() *IndexStateManagement {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterIndexLifecyclePolicy.
+func (in *ClusterIndexLifecyclePolicy) DeepCopy() *ClusterIndexLifecyclePolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(IndexStateManagement)
+	out := new(ClusterIndexLifecyclePolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *IndexStateManagement) DeepCopyObject() runtime.Object {
+func (in *ClusterIndexLifecyclePolicy) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -280,31 +403,31 @@ func (in *IndexStateManagement) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IndexStateManagementList) DeepCopyInto(out *IndexStateManagementList) {
+func (in *ClusterIndexLifecyclePolicyList) DeepCopyInto(out *ClusterIndexLifecyclePolicyList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]IndexStateManagement, len(*in))
+		*out = make([]ClusterIndexLifecyclePolicy, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexStateManagementList.
-func (in *IndexStateManagementList) DeepCopy() *IndexStateManagementList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterIndexLifecyclePolicyList.
+func (in *ClusterIndexLifecyclePolicyList) DeepCopy() *ClusterIndexLifecyclePolicyList {
 	if in == nil {
 		return nil
 	}
-	out := new(IndexStateManagementList)
+	out := new(ClusterIndexLifecyclePolicyList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *IndexStateManagementList) DeepCopyObject() runtime.Object {
+func (in *ClusterIndexLifecyclePolicyList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -312,9 +435,18 @@ func (in *IndexStateManagementList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IndexStateManagementSpec) DeepCopyInto(out *IndexStateManagementSpec) {
+func (in *ClusterIndexLifecyclePolicySpec) DeepCopyInto(out *ClusterIndexLifecyclePolicySpec) {
 	*out = *in
-	in.ResourceSelector.DeepCopyInto(&out.ResourceSelector)
+	if in.ResourceSelector != nil {
+		in, out := &in.ResourceSelector, &out.ResourceSelector
+		*out = new(ResourceSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClusterSelector != nil {
+		in, out := &in.ClusterSelector, &out.ClusterSelector
+		*out = new(ClusterSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Resources != nil {
 		in, out := &in.Resources, &out.Resources
 		*out = make(map[string]v1.JSON, len(*in))
@@ -324,19 +456,24 @@ func (in *IndexStateManagementSpec) DeepCopyInto(out *IndexStateManagementSpec)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexStateManagementSpec.
-func (in *IndexStateManagementSpec) DeepCopy() *IndexStateManagementSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterIndexLifecyclePolicySpec.
+func (in *ClusterIndexLifecyclePolicySpec) DeepCopy() *ClusterIndexLifecyclePolicySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(IndexStateManagementSpec)
+	out := new(ClusterIndexLifecyclePolicySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IndexStateManagementStatus) DeepCopyInto(out *IndexStateManagementStatus) {
+func (in *ClusterIndexLifecyclePolicyStatus) DeepCopyInto(out *ClusterIndexLifecyclePolicyStatus) {
 	*out = *in
+	if in.TargetClusters != nil {
+		in, out := &in.TargetClusters, &out.TargetClusters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.AppliedResources != nil {
 		in, out := &in.AppliedResources, &out.AppliedResources
 		*out = make([]string, len(*in))
@@ -355,18 +492,18 @@ func (in *IndexStateManagementStatus) DeepCopyInto(out *IndexStateManagementStat
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexStateManagementStatus.
-func (in *IndexStateManagementStatus) DeepCopy() *IndexStateManagementStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterIndexLifecyclePolicyStatus.
+func (in *ClusterIndexLifecyclePolicyStatus) DeepCopy() *ClusterIndexLifecyclePolicyStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(IndexStateManagementStatus)
+	out := new(ClusterIndexLifecyclePolicyStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IndexTemplate) DeepCopyInto(out *IndexTemplate) {
+func (in *ClusterIndexTemplate) DeepCopyInto(out *ClusterIndexTemplate) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -374,18 +511,18 @@ func (in *IndexTemplate) DeepCopyInto(out *IndexTemplate) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexTemplate.
-func (in *IndexTemplate) DeepCopy() *IndexTemplate {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterIndexTemplate.
+func (in *ClusterIndexTemplate) DeepCopy() *ClusterIndexTemplate {
 	if in == nil {
 		return nil
 	}
-	out := new(IndexTemplate)
+	out := new(ClusterIndexTemplate)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *IndexTemplate) DeepCopyObject() runtime.Object {
+func (in *ClusterIndexTemplate) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -393,31 +530,31 @@ func (in *IndexTemplate) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IndexTemplateList) DeepCopyInto(out *IndexTemplateList) {
+func (in *ClusterIndexTemplateList) DeepCopyInto(out *ClusterIndexTemplateList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]IndexTemplate, len(*in))
+		*out = make([]ClusterIndexTemplate, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexTemplateList.
-func (in *IndexTemplateList) DeepCopy() *IndexTemplateList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterIndexTemplateList.
+func (in *ClusterIndexTemplateList) DeepCopy() *ClusterIndexTemplateList {
 	if in == nil {
 		return nil
 	}
-	out := new(IndexTemplateList)
+	out := new(ClusterIndexTemplateList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *IndexTemplateList) DeepCopyObject() runtime.Object {
+func (in *ClusterIndexTemplateList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -425,9 +562,18 @@ func (in *IndexTemplateList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IndexTemplateSpec) DeepCopyInto(out *IndexTemplateSpec) {
+func (in *ClusterIndexTemplateSpec) DeepCopyInto(out *ClusterIndexTemplateSpec) {
 	*out = *in
-	in.ResourceSelector.DeepCopyInto(&out.ResourceSelector)
+	if in.ResourceSelector != nil {
+		in, out := &in.ResourceSelector, &out.ResourceSelector
+		*out = new(ResourceSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClusterSelector != nil {
+		in, out := &in.ClusterSelector, &out.ClusterSelector
+		*out = new(ClusterSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Resources != nil {
 		in, out := &in.Resources, &out.Resources
 		*out = make(map[string]v1.JSON, len(*in))
@@ -437,19 +583,24 @@ func (in *IndexTemplateSpec) DeepCopyInto(out *IndexTemplateSpec) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexTemplateSpec.
-func (in *IndexTemplateSpec) DeepCopy() *IndexTemplateSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterIndexTemplateSpec.
+func (in *ClusterIndexTemplateSpec) DeepCopy() *ClusterIndexTemplateSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(IndexTemplateSpec)
+	out := new(ClusterIndexTemplateSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IndexTemplateStatus) DeepCopyInto(out *IndexTemplateStatus) {
+func (in *ClusterIndexTemplateStatus) DeepCopyInto(out *ClusterIndexTemplateStatus) {
 	*out = *in
+	if in.TargetClusters != nil {
+		in, out := &in.TargetClusters, &out.TargetClusters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.AppliedResources != nil {
 		in, out := &in.AppliedResources, &out.AppliedResources
 		*out = make([]string, len(*in))
@@ -468,58 +619,39 @@ func (in *IndexTemplateStatus) DeepCopyInto(out *IndexTemplateStatus) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexTemplateStatus.
-func (in *IndexTemplateStatus) DeepCopy() *IndexTemplateStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterIndexTemplateStatus.
+func (in *ClusterIndexTemplateStatus) DeepCopy() *ClusterIndexTemplateStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(IndexTemplateStatus)
+	out := new(ClusterIndexTemplateStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ResourceSelector) DeepCopyInto(out *ResourceSelector) {
+func (in *ClusterSelector) DeepCopyInto(out *ClusterSelector) {
 	*out = *in
-	if in.PasswordSecretRef != nil {
-		in, out := &in.PasswordSecretRef, &out.PasswordSecretRef
-		*out = new(SecretKeySelector)
-		**out = **in
-	}
-	if in.CACertSecretRef != nil {
-		in, out := &in.CACertSecretRef, &out.CACertSecretRef
-		*out = new(SecretKeySelector)
-		**out = **in
-	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceSelector.
-func (in *ResourceSelector) DeepCopy() *ResourceSelector {
-	if in == nil {
-		return nil
+	in.LabelSelector.DeepCopyInto(&out.LabelSelector)
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	out := new(ResourceSelector)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SecretKeySelector) DeepCopyInto(out *SecretKeySelector) {
-	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretKeySelector.
-func (in *SecretKeySelector) DeepCopy() *SecretKeySelector {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSelector.
+func (in *ClusterSelector) DeepCopy() *ClusterSelector {
 	if in == nil {
 		return nil
 	}
-	out := new(SecretKeySelector)
+	out := new(ClusterSelector)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SnapshotLifecyclePolicy) DeepCopyInto(out *SnapshotLifecyclePolicy) {
+func (in *ClusterSettings) DeepCopyInto(out *ClusterSettings) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -527,18 +659,18 @@ func (in *SnapshotLifecyclePolicy) DeepCopyInto(out *SnapshotLifecyclePolicy) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotLifecyclePolicy.
-func (in *SnapshotLifecyclePolicy) DeepCopy() *SnapshotLifecyclePolicy {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSettings.
+func (in *ClusterSettings) DeepCopy() *ClusterSettings {
 	if in == nil {
 		return nil
 	}
-	out := new(SnapshotLifecyclePolicy)
+	out := new(ClusterSettings)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *SnapshotLifecyclePolicy) DeepCopyObject() runtime.Object {
+func (in *ClusterSettings) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -546,31 +678,31 @@ func (in *SnapshotLifecyclePolicy) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SnapshotLifecyclePolicyList) DeepCopyInto(out *SnapshotLifecyclePolicyList) {
+func (in *ClusterSettingsList) DeepCopyInto(out *ClusterSettingsList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]SnapshotLifecyclePolicy, len(*in))
+		*out = make([]ClusterSettings, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotLifecyclePolicyList.
-func (in *SnapshotLifecyclePolicyList) DeepCopy() *SnapshotLifecyclePolicyList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSettingsList.
+func (in *ClusterSettingsList) DeepCopy() *ClusterSettingsList {
 	if in == nil {
 		return nil
 	}
-	out := new(SnapshotLifecyclePolicyList)
+	out := new(ClusterSettingsList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *SnapshotLifecyclePolicyList) DeepCopyObject() runtime.Object {
+func (in *ClusterSettingsList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -578,7 +710,7 @@ func (in *SnapshotLifecyclePolicyList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SnapshotLifecyclePolicySpec) DeepCopyInto(out *SnapshotLifecyclePolicySpec) {
+func (in *ClusterSettingsSpec) DeepCopyInto(out *ClusterSettingsSpec) {
 	*out = *in
 	in.ResourceSelector.DeepCopyInto(&out.ResourceSelector)
 	if in.Resources != nil {
@@ -588,21 +720,36 @@ func (in *SnapshotLifecyclePolicySpec) DeepCopyInto(out *SnapshotLifecyclePolicy
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.ValuesFrom != nil {
+		in, out := &in.ValuesFrom, &out.ValuesFrom
+		*out = make([]ValuesSource, len(*in))
+		copy(*out, *in)
+	}
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]Dependency, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotLifecyclePolicySpec.
-func (in *SnapshotLifecyclePolicySpec) DeepCopy() *SnapshotLifecyclePolicySpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSettingsSpec.
+func (in *ClusterSettingsSpec) DeepCopy() *ClusterSettingsSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(SnapshotLifecyclePolicySpec)
+	out := new(ClusterSettingsSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SnapshotLifecyclePolicyStatus) DeepCopyInto(out *SnapshotLifecyclePolicyStatus) {
+func (in *ClusterSettingsStatus) DeepCopyInto(out *ClusterSettingsStatus) {
 	*out = *in
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]ClusterTargetStatus, len(*in))
+		copy(*out, *in)
+	}
 	if in.AppliedResources != nil {
 		in, out := &in.AppliedResources, &out.AppliedResources
 		*out = make([]string, len(*in))
@@ -612,6 +759,27 @@ func (in *SnapshotLifecyclePolicyStatus) DeepCopyInto(out *SnapshotLifecyclePoli
 		in, out := &in.LastSyncTime, &out.LastSyncTime
 		*out = (*in).DeepCopy()
 	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make(map[string]ResourceStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.Changes != nil {
+		in, out := &in.Changes, &out.Changes
+		*out = make([]ObjectChange, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Settings != nil {
+		in, out := &in.Settings, &out.Settings
+		*out = make(map[string]SettingStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]metav1.Condition, len(*in))
@@ -621,7 +789,3906 @@ func (in *SnapshotLifecyclePolicyStatus) DeepCopyInto(out *SnapshotLifecyclePoli
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotLifecyclePolicyStatus.
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSettingsStatus.
+func (in *ClusterSettingsStatus) DeepCopy() *ClusterSettingsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterSettingsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterTargetStatus) DeepCopyInto(out *ClusterTargetStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTargetStatus.
+func (in *ClusterTargetStatus) DeepCopy() *ClusterTargetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterTargetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigMapKeySelector) DeepCopyInto(out *ConfigMapKeySelector) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapKeySelector.
+func (in *ConfigMapKeySelector) DeepCopy() *ConfigMapKeySelector {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigMapKeySelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConnectionTimeouts) DeepCopyInto(out *ConnectionTimeouts) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectionTimeouts.
+func (in *ConnectionTimeouts) DeepCopy() *ConnectionTimeouts {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectionTimeouts)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Dependency) DeepCopyInto(out *Dependency) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Dependency.
+func (in *Dependency) DeepCopy() *Dependency {
+	if in == nil {
+		return nil
+	}
+	out := new(Dependency)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticClusterBinding) DeepCopyInto(out *ElasticClusterBinding) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticClusterBinding.
+func (in *ElasticClusterBinding) DeepCopy() *ElasticClusterBinding {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticClusterBinding)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticClusterBinding) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticClusterBindingList) DeepCopyInto(out *ElasticClusterBindingList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ElasticClusterBinding, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticClusterBindingList.
+func (in *ElasticClusterBindingList) DeepCopy() *ElasticClusterBindingList {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticClusterBindingList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticClusterBindingList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticClusterBindingSpec) DeepCopyInto(out *ElasticClusterBindingSpec) {
+	*out = *in
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticClusterBindingSpec.
+func (in *ElasticClusterBindingSpec) DeepCopy() *ElasticClusterBindingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticClusterBindingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticConfigBundle) DeepCopyInto(out *ElasticConfigBundle) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticConfigBundle.
+func (in *ElasticConfigBundle) DeepCopy() *ElasticConfigBundle {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticConfigBundle)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticConfigBundle) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticConfigBundleList) DeepCopyInto(out *ElasticConfigBundleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ElasticConfigBundle, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticConfigBundleList.
+func (in *ElasticConfigBundleList) DeepCopy() *ElasticConfigBundleList {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticConfigBundleList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticConfigBundleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticConfigBundleSpec) DeepCopyInto(out *ElasticConfigBundleSpec) {
+	*out = *in
+	in.ResourceSelector.DeepCopyInto(&out.ResourceSelector)
+	if in.ApplyOrder != nil {
+		in, out := &in.ApplyOrder, &out.ApplyOrder
+		*out = make([]BundleResourceKind, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClusterSettings != nil {
+		in, out := &in.ClusterSettings, &out.ClusterSettings
+		*out = make(map[string]v1.JSON, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.IngestPipelines != nil {
+		in, out := &in.IngestPipelines, &out.IngestPipelines
+		*out = make(map[string]v1.JSON, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.IndexLifecyclePolicies != nil {
+		in, out := &in.IndexLifecyclePolicies, &out.IndexLifecyclePolicies
+		*out = make(map[string]v1.JSON, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.ComponentTemplates != nil {
+		in, out := &in.ComponentTemplates, &out.ComponentTemplates
+		*out = make(map[string]v1.JSON, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.IndexTemplates != nil {
+		in, out := &in.IndexTemplates, &out.IndexTemplates
+		*out = make(map[string]v1.JSON, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticConfigBundleSpec.
+func (in *ElasticConfigBundleSpec) DeepCopy() *ElasticConfigBundleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticConfigBundleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticConfigBundleStatus) DeepCopyInto(out *ElasticConfigBundleStatus) {
+	*out = *in
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]ClusterTargetStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.AppliedResources != nil {
+		in, out := &in.AppliedResources, &out.AppliedResources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticConfigBundleStatus.
+func (in *ElasticConfigBundleStatus) DeepCopy() *ElasticConfigBundleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticConfigBundleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchClusterConnection) DeepCopyInto(out *ElasticsearchClusterConnection) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchClusterConnection.
+func (in *ElasticsearchClusterConnection) DeepCopy() *ElasticsearchClusterConnection {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchClusterConnection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticsearchClusterConnection) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchClusterConnectionList) DeepCopyInto(out *ElasticsearchClusterConnectionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ElasticsearchClusterConnection, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchClusterConnectionList.
+func (in *ElasticsearchClusterConnectionList) DeepCopy() *ElasticsearchClusterConnectionList {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchClusterConnectionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticsearchClusterConnectionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchClusterConnectionSpec) DeepCopyInto(out *ElasticsearchClusterConnectionSpec) {
+	*out = *in
+	if in.PasswordSecretRef != nil {
+		in, out := &in.PasswordSecretRef, &out.PasswordSecretRef
+		*out = new(SecretKeySelector)
+		**out = **in
+	}
+	if in.APIKeySecretRef != nil {
+		in, out := &in.APIKeySecretRef, &out.APIKeySecretRef
+		*out = new(SecretKeySelector)
+		**out = **in
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(ClusterConnectionTLS)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeDiscovery != nil {
+		in, out := &in.NodeDiscovery, &out.NodeDiscovery
+		*out = new(NodeDiscovery)
+		**out = **in
+	}
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Timeouts != nil {
+		in, out := &in.Timeouts, &out.Timeouts
+		*out = new(ConnectionTimeouts)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchClusterConnectionSpec.
+func (in *ElasticsearchClusterConnectionSpec) DeepCopy() *ElasticsearchClusterConnectionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchClusterConnectionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchClusterConnectionStatus) DeepCopyInto(out *ElasticsearchClusterConnectionStatus) {
+	*out = *in
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchClusterConnectionStatus.
+func (in *ElasticsearchClusterConnectionStatus) DeepCopy() *ElasticsearchClusterConnectionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchClusterConnectionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchRawResource) DeepCopyInto(out *ElasticsearchRawResource) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchRawResource.
+func (in *ElasticsearchRawResource) DeepCopy() *ElasticsearchRawResource {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchRawResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticsearchRawResource) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchRawResourceList) DeepCopyInto(out *ElasticsearchRawResourceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ElasticsearchRawResource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchRawResourceList.
+func (in *ElasticsearchRawResourceList) DeepCopy() *ElasticsearchRawResourceList {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchRawResourceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticsearchRawResourceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchRawResourceSpec) DeepCopyInto(out *ElasticsearchRawResourceSpec) {
+	*out = *in
+	in.ResourceSelector.DeepCopyInto(&out.ResourceSelector)
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make(map[string]RawResource, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchRawResourceSpec.
+func (in *ElasticsearchRawResourceSpec) DeepCopy() *ElasticsearchRawResourceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchRawResourceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticsearchRawResourceStatus) DeepCopyInto(out *ElasticsearchRawResourceStatus) {
+	*out = *in
+	if in.AppliedResources != nil {
+		in, out := &in.AppliedResources, &out.AppliedResources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DeletePaths != nil {
+		in, out := &in.DeletePaths, &out.DeletePaths
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticsearchRawResourceStatus.
+func (in *ElasticsearchRawResourceStatus) DeepCopy() *ElasticsearchRawResourceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticsearchRawResourceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EndpointSource) DeepCopyInto(out *EndpointSource) {
+	*out = *in
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(SecretKeySelector)
+		**out = **in
+	}
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(ConfigMapKeySelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EndpointSource.
+func (in *EndpointSource) DeepCopy() *EndpointSource {
+	if in == nil {
+		return nil
+	}
+	out := new(EndpointSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FieldChange) DeepCopyInto(out *FieldChange) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FieldChange.
+func (in *FieldChange) DeepCopy() *FieldChange {
+	if in == nil {
+		return nil
+	}
+	out := new(FieldChange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FleetAgentPolicy) DeepCopyInto(out *FleetAgentPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FleetAgentPolicy.
+func (in *FleetAgentPolicy) DeepCopy() *FleetAgentPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(FleetAgentPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FleetAgentPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FleetAgentPolicyDefinition) DeepCopyInto(out *FleetAgentPolicyDefinition) {
+	*out = *in
+	in.Policy.DeepCopyInto(&out.Policy)
+	if in.PackagePolicies != nil {
+		in, out := &in.PackagePolicies, &out.PackagePolicies
+		*out = make(map[string]v1.JSON, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FleetAgentPolicyDefinition.
+func (in *FleetAgentPolicyDefinition) DeepCopy() *FleetAgentPolicyDefinition {
+	if in == nil {
+		return nil
+	}
+	out := new(FleetAgentPolicyDefinition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FleetAgentPolicyList) DeepCopyInto(out *FleetAgentPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]FleetAgentPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FleetAgentPolicyList.
+func (in *FleetAgentPolicyList) DeepCopy() *FleetAgentPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(FleetAgentPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FleetAgentPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FleetAgentPolicySpec) DeepCopyInto(out *FleetAgentPolicySpec) {
+	*out = *in
+	in.KibanaSelector.DeepCopyInto(&out.KibanaSelector)
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make(map[string]FleetAgentPolicyDefinition, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FleetAgentPolicySpec.
+func (in *FleetAgentPolicySpec) DeepCopy() *FleetAgentPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FleetAgentPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FleetAgentPolicyStatus) DeepCopyInto(out *FleetAgentPolicyStatus) {
+	*out = *in
+	if in.AppliedResources != nil {
+		in, out := &in.AppliedResources, &out.AppliedResources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AppliedPackagePolicies != nil {
+		in, out := &in.AppliedPackagePolicies, &out.AppliedPackagePolicies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FleetAgentPolicyStatus.
+func (in *FleetAgentPolicyStatus) DeepCopy() *FleetAgentPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FleetAgentPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ILMAllocateAction) DeepCopyInto(out *ILMAllocateAction) {
+	*out = *in
+	if in.NumberOfReplicas != nil {
+		in, out := &in.NumberOfReplicas, &out.NumberOfReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TotalShardsPerNode != nil {
+		in, out := &in.TotalShardsPerNode, &out.TotalShardsPerNode
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Include != nil {
+		in, out := &in.Include, &out.Include
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Exclude != nil {
+		in, out := &in.Exclude, &out.Exclude
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Require != nil {
+		in, out := &in.Require, &out.Require
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ILMAllocateAction.
+func (in *ILMAllocateAction) DeepCopy() *ILMAllocateAction {
+	if in == nil {
+		return nil
+	}
+	out := new(ILMAllocateAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ILMColdActions) DeepCopyInto(out *ILMColdActions) {
+	*out = *in
+	if in.SetPriority != nil {
+		in, out := &in.SetPriority, &out.SetPriority
+		*out = new(ILMSetPriorityAction)
+		**out = **in
+	}
+	if in.Unfollow != nil {
+		in, out := &in.Unfollow, &out.Unfollow
+		*out = new(ILMEmptyAction)
+		**out = **in
+	}
+	if in.Readonly != nil {
+		in, out := &in.Readonly, &out.Readonly
+		*out = new(ILMEmptyAction)
+		**out = **in
+	}
+	if in.Downsample != nil {
+		in, out := &in.Downsample, &out.Downsample
+		*out = new(ILMDownsampleAction)
+		**out = **in
+	}
+	if in.SearchableSnapshot != nil {
+		in, out := &in.SearchableSnapshot, &out.SearchableSnapshot
+		*out = new(ILMSearchableSnapshotAction)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Allocate != nil {
+		in, out := &in.Allocate, &out.Allocate
+		*out = new(ILMAllocateAction)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Migrate != nil {
+		in, out := &in.Migrate, &out.Migrate
+		*out = new(ILMMigrateAction)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Freeze != nil {
+		in, out := &in.Freeze, &out.Freeze
+		*out = new(ILMEmptyAction)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ILMColdActions.
+func (in *ILMColdActions) DeepCopy() *ILMColdActions {
+	if in == nil {
+		return nil
+	}
+	out := new(ILMColdActions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ILMColdPhase) DeepCopyInto(out *ILMColdPhase) {
+	*out = *in
+	in.Actions.DeepCopyInto(&out.Actions)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ILMColdPhase.
+func (in *ILMColdPhase) DeepCopy() *ILMColdPhase {
+	if in == nil {
+		return nil
+	}
+	out := new(ILMColdPhase)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ILMDeleteAction) DeepCopyInto(out *ILMDeleteAction) {
+	*out = *in
+	if in.DeleteSearchableSnapshot != nil {
+		in, out := &in.DeleteSearchableSnapshot, &out.DeleteSearchableSnapshot
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ILMDeleteAction.
+func (in *ILMDeleteAction) DeepCopy() *ILMDeleteAction {
+	if in == nil {
+		return nil
+	}
+	out := new(ILMDeleteAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ILMDeleteActions) DeepCopyInto(out *ILMDeleteActions) {
+	*out = *in
+	if in.WaitForSnapshot != nil {
+		in, out := &in.WaitForSnapshot, &out.WaitForSnapshot
+		*out = new(ILMWaitForSnapshotAction)
+		**out = **in
+	}
+	if in.Delete != nil {
+		in, out := &in.Delete, &out.Delete
+		*out = new(ILMDeleteAction)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ILMDeleteActions.
+func (in *ILMDeleteActions) DeepCopy() *ILMDeleteActions {
+	if in == nil {
+		return nil
+	}
+	out := new(ILMDeleteActions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ILMDeletePhase) DeepCopyInto(out *ILMDeletePhase) {
+	*out = *in
+	in.Actions.DeepCopyInto(&out.Actions)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ILMDeletePhase.
+func (in *ILMDeletePhase) DeepCopy() *ILMDeletePhase {
+	if in == nil {
+		return nil
+	}
+	out := new(ILMDeletePhase)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ILMDownsampleAction) DeepCopyInto(out *ILMDownsampleAction) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ILMDownsampleAction.
+func (in *ILMDownsampleAction) DeepCopy() *ILMDownsampleAction {
+	if in == nil {
+		return nil
+	}
+	out := new(ILMDownsampleAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ILMEmptyAction) DeepCopyInto(out *ILMEmptyAction) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ILMEmptyAction.
+func (in *ILMEmptyAction) DeepCopy() *ILMEmptyAction {
+	if in == nil {
+		return nil
+	}
+	out := new(ILMEmptyAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ILMForcemergeAction) DeepCopyInto(out *ILMForcemergeAction) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ILMForcemergeAction.
+func (in *ILMForcemergeAction) DeepCopy() *ILMForcemergeAction {
+	if in == nil {
+		return nil
+	}
+	out := new(ILMForcemergeAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ILMFrozenActions) DeepCopyInto(out *ILMFrozenActions) {
+	*out = *in
+	if in.Unfollow != nil {
+		in, out := &in.Unfollow, &out.Unfollow
+		*out = new(ILMEmptyAction)
+		**out = **in
+	}
+	if in.SearchableSnapshot != nil {
+		in, out := &in.SearchableSnapshot, &out.SearchableSnapshot
+		*out = new(ILMSearchableSnapshotAction)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ILMFrozenActions.
+func (in *ILMFrozenActions) DeepCopy() *ILMFrozenActions {
+	if in == nil {
+		return nil
+	}
+	out := new(ILMFrozenActions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ILMFrozenPhase) DeepCopyInto(out *ILMFrozenPhase) {
+	*out = *in
+	in.Actions.DeepCopyInto(&out.Actions)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ILMFrozenPhase.
+func (in *ILMFrozenPhase) DeepCopy() *ILMFrozenPhase {
+	if in == nil {
+		return nil
+	}
+	out := new(ILMFrozenPhase)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ILMHotActions) DeepCopyInto(out *ILMHotActions) {
+	*out = *in
+	if in.SetPriority != nil {
+		in, out := &in.SetPriority, &out.SetPriority
+		*out = new(ILMSetPriorityAction)
+		**out = **in
+	}
+	if in.Unfollow != nil {
+		in, out := &in.Unfollow, &out.Unfollow
+		*out = new(ILMEmptyAction)
+		**out = **in
+	}
+	if in.Rollover != nil {
+		in, out := &in.Rollover, &out.Rollover
+		*out = new(ILMRolloverAction)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Readonly != nil {
+		in, out := &in.Readonly, &out.Readonly
+		*out = new(ILMEmptyAction)
+		**out = **in
+	}
+	if in.Downsample != nil {
+		in, out := &in.Downsample, &out.Downsample
+		*out = new(ILMDownsampleAction)
+		**out = **in
+	}
+	if in.Shrink != nil {
+		in, out := &in.Shrink, &out.Shrink
+		*out = new(ILMShrinkAction)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Forcemerge != nil {
+		in, out := &in.Forcemerge, &out.Forcemerge
+		*out = new(ILMForcemergeAction)
+		**out = **in
+	}
+	if in.SearchableSnapshot != nil {
+		in, out := &in.SearchableSnapshot, &out.SearchableSnapshot
+		*out = new(ILMSearchableSnapshotAction)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ILMHotActions.
+func (in *ILMHotActions) DeepCopy() *ILMHotActions {
+	if in == nil {
+		return nil
+	}
+	out := new(ILMHotActions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ILMHotPhase) DeepCopyInto(out *ILMHotPhase) {
+	*out = *in
+	in.Actions.DeepCopyInto(&out.Actions)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ILMHotPhase.
+func (in *ILMHotPhase) DeepCopy() *ILMHotPhase {
+	if in == nil {
+		return nil
+	}
+	out := new(ILMHotPhase)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ILMMigrateAction) DeepCopyInto(out *ILMMigrateAction) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ILMMigrateAction.
+func (in *ILMMigrateAction) DeepCopy() *ILMMigrateAction {
+	if in == nil {
+		return nil
+	}
+	out := new(ILMMigrateAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ILMPhases) DeepCopyInto(out *ILMPhases) {
+	*out = *in
+	if in.Hot != nil {
+		in, out := &in.Hot, &out.Hot
+		*out = new(ILMHotPhase)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Warm != nil {
+		in, out := &in.Warm, &out.Warm
+		*out = new(ILMWarmPhase)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Cold != nil {
+		in, out := &in.Cold, &out.Cold
+		*out = new(ILMColdPhase)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Frozen != nil {
+		in, out := &in.Frozen, &out.Frozen
+		*out = new(ILMFrozenPhase)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Delete != nil {
+		in, out := &in.Delete, &out.Delete
+		*out = new(ILMDeletePhase)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ILMPhases.
+func (in *ILMPhases) DeepCopy() *ILMPhases {
+	if in == nil {
+		return nil
+	}
+	out := new(ILMPhases)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ILMPolicy) DeepCopyInto(out *ILMPolicy) {
+	*out = *in
+	in.Phases.DeepCopyInto(&out.Phases)
+	if in.Meta != nil {
+		in, out := &in.Meta, &out.Meta
+		*out = new(v1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ILMPolicy.
+func (in *ILMPolicy) DeepCopy() *ILMPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ILMPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ILMRolloverAction) DeepCopyInto(out *ILMRolloverAction) {
+	*out = *in
+	if in.MaxDocs != nil {
+		in, out := &in.MaxDocs, &out.MaxDocs
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxPrimaryShardDocs != nil {
+		in, out := &in.MaxPrimaryShardDocs, &out.MaxPrimaryShardDocs
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MinDocs != nil {
+		in, out := &in.MinDocs, &out.MinDocs
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MinPrimaryShardDocs != nil {
+		in, out := &in.MinPrimaryShardDocs, &out.MinPrimaryShardDocs
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ILMRolloverAction.
+func (in *ILMRolloverAction) DeepCopy() *ILMRolloverAction {
+	if in == nil {
+		return nil
+	}
+	out := new(ILMRolloverAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ILMSearchableSnapshotAction) DeepCopyInto(out *ILMSearchableSnapshotAction) {
+	*out = *in
+	if in.ForceMergeIndex != nil {
+		in, out := &in.ForceMergeIndex, &out.ForceMergeIndex
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ILMSearchableSnapshotAction.
+func (in *ILMSearchableSnapshotAction) DeepCopy() *ILMSearchableSnapshotAction {
+	if in == nil {
+		return nil
+	}
+	out := new(ILMSearchableSnapshotAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ILMSetPriorityAction) DeepCopyInto(out *ILMSetPriorityAction) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ILMSetPriorityAction.
+func (in *ILMSetPriorityAction) DeepCopy() *ILMSetPriorityAction {
+	if in == nil {
+		return nil
+	}
+	out := new(ILMSetPriorityAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ILMShrinkAction) DeepCopyInto(out *ILMShrinkAction) {
+	*out = *in
+	if in.NumberOfShards != nil {
+		in, out := &in.NumberOfShards, &out.NumberOfShards
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ILMShrinkAction.
+func (in *ILMShrinkAction) DeepCopy() *ILMShrinkAction {
+	if in == nil {
+		return nil
+	}
+	out := new(ILMShrinkAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ILMWaitForSnapshotAction) DeepCopyInto(out *ILMWaitForSnapshotAction) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ILMWaitForSnapshotAction.
+func (in *ILMWaitForSnapshotAction) DeepCopy() *ILMWaitForSnapshotAction {
+	if in == nil {
+		return nil
+	}
+	out := new(ILMWaitForSnapshotAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ILMWarmActions) DeepCopyInto(out *ILMWarmActions) {
+	*out = *in
+	if in.SetPriority != nil {
+		in, out := &in.SetPriority, &out.SetPriority
+		*out = new(ILMSetPriorityAction)
+		**out = **in
+	}
+	if in.Unfollow != nil {
+		in, out := &in.Unfollow, &out.Unfollow
+		*out = new(ILMEmptyAction)
+		**out = **in
+	}
+	if in.Readonly != nil {
+		in, out := &in.Readonly, &out.Readonly
+		*out = new(ILMEmptyAction)
+		**out = **in
+	}
+	if in.Downsample != nil {
+		in, out := &in.Downsample, &out.Downsample
+		*out = new(ILMDownsampleAction)
+		**out = **in
+	}
+	if in.Allocate != nil {
+		in, out := &in.Allocate, &out.Allocate
+		*out = new(ILMAllocateAction)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Migrate != nil {
+		in, out := &in.Migrate, &out.Migrate
+		*out = new(ILMMigrateAction)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Shrink != nil {
+		in, out := &in.Shrink, &out.Shrink
+		*out = new(ILMShrinkAction)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Forcemerge != nil {
+		in, out := &in.Forcemerge, &out.Forcemerge
+		*out = new(ILMForcemergeAction)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ILMWarmActions.
+func (in *ILMWarmActions) DeepCopy() *ILMWarmActions {
+	if in == nil {
+		return nil
+	}
+	out := new(ILMWarmActions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ILMWarmPhase) DeepCopyInto(out *ILMWarmPhase) {
+	*out = *in
+	in.Actions.DeepCopyInto(&out.Actions)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ILMWarmPhase.
+func (in *ILMWarmPhase) DeepCopy() *ILMWarmPhase {
+	if in == nil {
+		return nil
+	}
+	out := new(ILMWarmPhase)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IndexLifecycle) DeepCopyInto(out *IndexLifecycle) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexLifecycle.
+func (in *IndexLifecycle) DeepCopy() *IndexLifecycle {
+	if in == nil {
+		return nil
+	}
+	out := new(IndexLifecycle)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *IndexLifecycle) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IndexLifecycleList) DeepCopyInto(out *IndexLifecycleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]IndexLifecycle, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexLifecycleList.
+func (in *IndexLifecycleList) DeepCopy() *IndexLifecycleList {
+	if in == nil {
+		return nil
+	}
+	out := new(IndexLifecycleList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *IndexLifecycleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IndexLifecyclePolicy) DeepCopyInto(out *IndexLifecyclePolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexLifecyclePolicy.
+func (in *IndexLifecyclePolicy) DeepCopy() *IndexLifecyclePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(IndexLifecyclePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *IndexLifecyclePolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IndexLifecyclePolicyList) DeepCopyInto(out *IndexLifecyclePolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]IndexLifecyclePolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexLifecyclePolicyList.
+func (in *IndexLifecyclePolicyList) DeepCopy() *IndexLifecyclePolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(IndexLifecyclePolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *IndexLifecyclePolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IndexLifecyclePolicySpec) DeepCopyInto(out *IndexLifecyclePolicySpec) {
+	*out = *in
+	in.ResourceSelector.DeepCopyInto(&out.ResourceSelector)
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make(map[string]v1.JSON, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = make(map[string]ILMPolicy, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.ValuesFrom != nil {
+		in, out := &in.ValuesFrom, &out.ValuesFrom
+		*out = make([]ValuesSource, len(*in))
+		copy(*out, *in)
+	}
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]Dependency, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexLifecyclePolicySpec.
+func (in *IndexLifecyclePolicySpec) DeepCopy() *IndexLifecyclePolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IndexLifecyclePolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IndexLifecyclePolicyStatus) DeepCopyInto(out *IndexLifecyclePolicyStatus) {
+	*out = *in
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]ClusterTargetStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.AppliedResources != nil {
+		in, out := &in.AppliedResources, &out.AppliedResources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make(map[string]ResourceStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.Changes != nil {
+		in, out := &in.Changes, &out.Changes
+		*out = make([]ObjectChange, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexLifecyclePolicyStatus.
+func (in *IndexLifecyclePolicyStatus) DeepCopy() *IndexLifecyclePolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(IndexLifecyclePolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IndexLifecycleSpec) DeepCopyInto(out *IndexLifecycleSpec) {
+	*out = *in
+	in.ResourceSelector.DeepCopyInto(&out.ResourceSelector)
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = make(map[string]LifecyclePolicy, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexLifecycleSpec.
+func (in *IndexLifecycleSpec) DeepCopy() *IndexLifecycleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IndexLifecycleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IndexLifecycleStatus) DeepCopyInto(out *IndexLifecycleStatus) {
+	*out = *in
+	if in.AppliedResources != nil {
+		in, out := &in.AppliedResources, &out.AppliedResources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexLifecycleStatus.
+func (in *IndexLifecycleStatus) DeepCopy() *IndexLifecycleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(IndexLifecycleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IndexStateManagement) DeepCopyInto(out *IndexStateManagement) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexStateManagement.
+func (in *IndexStateManagement) DeepCopy() *IndexStateManagement {
+	if in == nil {
+		return nil
+	}
+	out := new(IndexStateManagement)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *IndexStateManagement) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IndexStateManagementList) DeepCopyInto(out *IndexStateManagementList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]IndexStateManagement, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexStateManagementList.
+func (in *IndexStateManagementList) DeepCopy() *IndexStateManagementList {
+	if in == nil {
+		return nil
+	}
+	out := new(IndexStateManagementList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *IndexStateManagementList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IndexStateManagementSpec) DeepCopyInto(out *IndexStateManagementSpec) {
+	*out = *in
+	in.ResourceSelector.DeepCopyInto(&out.ResourceSelector)
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make(map[string]v1.JSON, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexStateManagementSpec.
+func (in *IndexStateManagementSpec) DeepCopy() *IndexStateManagementSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IndexStateManagementSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IndexStateManagementStatus) DeepCopyInto(out *IndexStateManagementStatus) {
+	*out = *in
+	if in.AppliedResources != nil {
+		in, out := &in.AppliedResources, &out.AppliedResources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexStateManagementStatus.
+func (in *IndexStateManagementStatus) DeepCopy() *IndexStateManagementStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(IndexStateManagementStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IndexTemplate) DeepCopyInto(out *IndexTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexTemplate.
+func (in *IndexTemplate) DeepCopy() *IndexTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(IndexTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *IndexTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IndexTemplateList) DeepCopyInto(out *IndexTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]IndexTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexTemplateList.
+func (in *IndexTemplateList) DeepCopy() *IndexTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(IndexTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *IndexTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IndexTemplateSpec) DeepCopyInto(out *IndexTemplateSpec) {
+	*out = *in
+	in.ResourceSelector.DeepCopyInto(&out.ResourceSelector)
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make(map[string]v1.JSON, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.ResourcesFrom != nil {
+		in, out := &in.ResourcesFrom, &out.ResourcesFrom
+		*out = make([]ResourcesSource, len(*in))
+		copy(*out, *in)
+	}
+	if in.ValuesFrom != nil {
+		in, out := &in.ValuesFrom, &out.ValuesFrom
+		*out = make([]ValuesSource, len(*in))
+		copy(*out, *in)
+	}
+	if in.ApplyOrder != nil {
+		in, out := &in.ApplyOrder, &out.ApplyOrder
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]Dependency, len(*in))
+		copy(*out, *in)
+	}
+	if in.Bootstrap != nil {
+		in, out := &in.Bootstrap, &out.Bootstrap
+		*out = make([]RolloverBootstrap, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexTemplateSpec.
+func (in *IndexTemplateSpec) DeepCopy() *IndexTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IndexTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IndexTemplateStatus) DeepCopyInto(out *IndexTemplateStatus) {
+	*out = *in
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]ClusterTargetStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.AppliedResources != nil {
+		in, out := &in.AppliedResources, &out.AppliedResources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make(map[string]ResourceStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.Changes != nil {
+		in, out := &in.Changes, &out.Changes
+		*out = make([]ObjectChange, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IndexTemplateStatus.
+func (in *IndexTemplateStatus) DeepCopy() *IndexTemplateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(IndexTemplateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KibanaAlertRule) DeepCopyInto(out *KibanaAlertRule) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KibanaAlertRule.
+func (in *KibanaAlertRule) DeepCopy() *KibanaAlertRule {
+	if in == nil {
+		return nil
+	}
+	out := new(KibanaAlertRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KibanaAlertRule) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KibanaAlertRuleList) DeepCopyInto(out *KibanaAlertRuleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KibanaAlertRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KibanaAlertRuleList.
+func (in *KibanaAlertRuleList) DeepCopy() *KibanaAlertRuleList {
+	if in == nil {
+		return nil
+	}
+	out := new(KibanaAlertRuleList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KibanaAlertRuleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KibanaAlertRuleSpec) DeepCopyInto(out *KibanaAlertRuleSpec) {
+	*out = *in
+	in.KibanaSelector.DeepCopyInto(&out.KibanaSelector)
+	if in.Connectors != nil {
+		in, out := &in.Connectors, &out.Connectors
+		*out = make(map[string]KibanaConnector, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make(map[string]v1.JSON, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KibanaAlertRuleSpec.
+func (in *KibanaAlertRuleSpec) DeepCopy() *KibanaAlertRuleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KibanaAlertRuleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KibanaAlertRuleStatus) DeepCopyInto(out *KibanaAlertRuleStatus) {
+	*out = *in
+	if in.AppliedResources != nil {
+		in, out := &in.AppliedResources, &out.AppliedResources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AppliedConnectors != nil {
+		in, out := &in.AppliedConnectors, &out.AppliedConnectors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KibanaAlertRuleStatus.
+func (in *KibanaAlertRuleStatus) DeepCopy() *KibanaAlertRuleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KibanaAlertRuleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KibanaConnector) DeepCopyInto(out *KibanaConnector) {
+	*out = *in
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = new(v1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretRefs != nil {
+		in, out := &in.SecretRefs, &out.SecretRefs
+		*out = make(map[string]SecretKeySelector, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KibanaConnector.
+func (in *KibanaConnector) DeepCopy() *KibanaConnector {
+	if in == nil {
+		return nil
+	}
+	out := new(KibanaConnector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KibanaSavedObjects) DeepCopyInto(out *KibanaSavedObjects) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KibanaSavedObjects.
+func (in *KibanaSavedObjects) DeepCopy() *KibanaSavedObjects {
+	if in == nil {
+		return nil
+	}
+	out := new(KibanaSavedObjects)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KibanaSavedObjects) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KibanaSavedObjectsList) DeepCopyInto(out *KibanaSavedObjectsList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KibanaSavedObjects, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KibanaSavedObjectsList.
+func (in *KibanaSavedObjectsList) DeepCopy() *KibanaSavedObjectsList {
+	if in == nil {
+		return nil
+	}
+	out := new(KibanaSavedObjectsList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KibanaSavedObjectsList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KibanaSavedObjectsSpec) DeepCopyInto(out *KibanaSavedObjectsSpec) {
+	*out = *in
+	in.KibanaSelector.DeepCopyInto(&out.KibanaSelector)
+	if in.Overwrite != nil {
+		in, out := &in.Overwrite, &out.Overwrite
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make(map[string]v1.JSON, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KibanaSavedObjectsSpec.
+func (in *KibanaSavedObjectsSpec) DeepCopy() *KibanaSavedObjectsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KibanaSavedObjectsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KibanaSavedObjectsStatus) DeepCopyInto(out *KibanaSavedObjectsStatus) {
+	*out = *in
+	if in.AppliedResources != nil {
+		in, out := &in.AppliedResources, &out.AppliedResources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KibanaSavedObjectsStatus.
+func (in *KibanaSavedObjectsStatus) DeepCopy() *KibanaSavedObjectsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KibanaSavedObjectsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KibanaSelector) DeepCopyInto(out *KibanaSelector) {
+	*out = *in
+	if in.PasswordSecretRef != nil {
+		in, out := &in.PasswordSecretRef, &out.PasswordSecretRef
+		*out = new(SecretKeySelector)
+		**out = **in
+	}
+	if in.CACertSecretRef != nil {
+		in, out := &in.CACertSecretRef, &out.CACertSecretRef
+		*out = new(SecretKeySelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KibanaSelector.
+func (in *KibanaSelector) DeepCopy() *KibanaSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(KibanaSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KibanaSpace) DeepCopyInto(out *KibanaSpace) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KibanaSpace.
+func (in *KibanaSpace) DeepCopy() *KibanaSpace {
+	if in == nil {
+		return nil
+	}
+	out := new(KibanaSpace)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KibanaSpace) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KibanaSpaceList) DeepCopyInto(out *KibanaSpaceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KibanaSpace, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KibanaSpaceList.
+func (in *KibanaSpaceList) DeepCopy() *KibanaSpaceList {
+	if in == nil {
+		return nil
+	}
+	out := new(KibanaSpaceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KibanaSpaceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KibanaSpaceSpec) DeepCopyInto(out *KibanaSpaceSpec) {
+	*out = *in
+	in.KibanaSelector.DeepCopyInto(&out.KibanaSelector)
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make(map[string]v1.JSON, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KibanaSpaceSpec.
+func (in *KibanaSpaceSpec) DeepCopy() *KibanaSpaceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KibanaSpaceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KibanaSpaceStatus) DeepCopyInto(out *KibanaSpaceStatus) {
+	*out = *in
+	if in.AppliedResources != nil {
+		in, out := &in.AppliedResources, &out.AppliedResources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KibanaSpaceStatus.
+func (in *KibanaSpaceStatus) DeepCopy() *KibanaSpaceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KibanaSpaceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LifecyclePolicy) DeepCopyInto(out *LifecyclePolicy) {
+	*out = *in
+	if in.IndexPatterns != nil {
+		in, out := &in.IndexPatterns, &out.IndexPatterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Rollover != nil {
+		in, out := &in.Rollover, &out.Rollover
+		*out = new(LifecycleRollover)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LifecyclePolicy.
+func (in *LifecyclePolicy) DeepCopy() *LifecyclePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(LifecyclePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LifecycleRollover) DeepCopyInto(out *LifecycleRollover) {
+	*out = *in
+	if in.MaxDocs != nil {
+		in, out := &in.MaxDocs, &out.MaxDocs
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LifecycleRollover.
+func (in *LifecycleRollover) DeepCopy() *LifecycleRollover {
+	if in == nil {
+		return nil
+	}
+	out := new(LifecycleRollover)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineLearningJob) DeepCopyInto(out *MachineLearningJob) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineLearningJob.
+func (in *MachineLearningJob) DeepCopy() *MachineLearningJob {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineLearningJob)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MachineLearningJob) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineLearningJobDefinition) DeepCopyInto(out *MachineLearningJobDefinition) {
+	*out = *in
+	in.Job.DeepCopyInto(&out.Job)
+	if in.Datafeed != nil {
+		in, out := &in.Datafeed, &out.Datafeed
+		*out = new(v1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineLearningJobDefinition.
+func (in *MachineLearningJobDefinition) DeepCopy() *MachineLearningJobDefinition {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineLearningJobDefinition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineLearningJobList) DeepCopyInto(out *MachineLearningJobList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]MachineLearningJob, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineLearningJobList.
+func (in *MachineLearningJobList) DeepCopy() *MachineLearningJobList {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineLearningJobList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MachineLearningJobList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineLearningJobSpec) DeepCopyInto(out *MachineLearningJobSpec) {
+	*out = *in
+	in.ResourceSelector.DeepCopyInto(&out.ResourceSelector)
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make(map[string]MachineLearningJobDefinition, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineLearningJobSpec.
+func (in *MachineLearningJobSpec) DeepCopy() *MachineLearningJobSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineLearningJobSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineLearningJobStatus) DeepCopyInto(out *MachineLearningJobStatus) {
+	*out = *in
+	if in.AppliedResources != nil {
+		in, out := &in.AppliedResources, &out.AppliedResources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineLearningJobStatus.
+func (in *MachineLearningJobStatus) DeepCopy() *MachineLearningJobStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineLearningJobStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceDefaultCluster) DeepCopyInto(out *NamespaceDefaultCluster) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceDefaultCluster.
+func (in *NamespaceDefaultCluster) DeepCopy() *NamespaceDefaultCluster {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceDefaultCluster)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NamespaceDefaultCluster) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceDefaultClusterList) DeepCopyInto(out *NamespaceDefaultClusterList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NamespaceDefaultCluster, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceDefaultClusterList.
+func (in *NamespaceDefaultClusterList) DeepCopy() *NamespaceDefaultClusterList {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceDefaultClusterList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NamespaceDefaultClusterList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceDefaultClusterSpec) DeepCopyInto(out *NamespaceDefaultClusterSpec) {
+	*out = *in
+	in.ResourceSelector.DeepCopyInto(&out.ResourceSelector)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceDefaultClusterSpec.
+func (in *NamespaceDefaultClusterSpec) DeepCopy() *NamespaceDefaultClusterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceDefaultClusterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeDiscovery) DeepCopyInto(out *NodeDiscovery) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeDiscovery.
+func (in *NodeDiscovery) DeepCopy() *NodeDiscovery {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeDiscovery)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeShutdown) DeepCopyInto(out *NodeShutdown) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeShutdown.
+func (in *NodeShutdown) DeepCopy() *NodeShutdown {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeShutdown)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NodeShutdown) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeShutdownDefinition) DeepCopyInto(out *NodeShutdownDefinition) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeShutdownDefinition.
+func (in *NodeShutdownDefinition) DeepCopy() *NodeShutdownDefinition {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeShutdownDefinition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeShutdownList) DeepCopyInto(out *NodeShutdownList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NodeShutdown, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeShutdownList.
+func (in *NodeShutdownList) DeepCopy() *NodeShutdownList {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeShutdownList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NodeShutdownList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeShutdownProgress) DeepCopyInto(out *NodeShutdownProgress) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeShutdownProgress.
+func (in *NodeShutdownProgress) DeepCopy() *NodeShutdownProgress {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeShutdownProgress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeShutdownSpec) DeepCopyInto(out *NodeShutdownSpec) {
+	*out = *in
+	in.ResourceSelector.DeepCopyInto(&out.ResourceSelector)
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make(map[string]NodeShutdownDefinition, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeShutdownSpec.
+func (in *NodeShutdownSpec) DeepCopy() *NodeShutdownSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeShutdownSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeShutdownStatus) DeepCopyInto(out *NodeShutdownStatus) {
+	*out = *in
+	if in.AppliedResources != nil {
+		in, out := &in.AppliedResources, &out.AppliedResources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make(map[string]NodeShutdownProgress, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeShutdownStatus.
+func (in *NodeShutdownStatus) DeepCopy() *NodeShutdownStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeShutdownStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationChannel) DeepCopyInto(out *NotificationChannel) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(WebhookChannel)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Slack != nil {
+		in, out := &in.Slack, &out.Slack
+		*out = new(SlackChannel)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SNS != nil {
+		in, out := &in.SNS, &out.SNS
+		*out = new(SNSChannel)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationChannel.
+func (in *NotificationChannel) DeepCopy() *NotificationChannel {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationChannel)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectChange) DeepCopyInto(out *ObjectChange) {
+	*out = *in
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = make([]FieldChange, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectChange.
+func (in *ObjectChange) DeepCopy() *ObjectChange {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectChange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchAlertingMonitor) DeepCopyInto(out *OpenSearchAlertingMonitor) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchAlertingMonitor.
+func (in *OpenSearchAlertingMonitor) DeepCopy() *OpenSearchAlertingMonitor {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchAlertingMonitor)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OpenSearchAlertingMonitor) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchAlertingMonitorList) DeepCopyInto(out *OpenSearchAlertingMonitorList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OpenSearchAlertingMonitor, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchAlertingMonitorList.
+func (in *OpenSearchAlertingMonitorList) DeepCopy() *OpenSearchAlertingMonitorList {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchAlertingMonitorList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OpenSearchAlertingMonitorList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchAlertingMonitorSpec) DeepCopyInto(out *OpenSearchAlertingMonitorSpec) {
+	*out = *in
+	in.ResourceSelector.DeepCopyInto(&out.ResourceSelector)
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make(map[string]v1.JSON, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchAlertingMonitorSpec.
+func (in *OpenSearchAlertingMonitorSpec) DeepCopy() *OpenSearchAlertingMonitorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchAlertingMonitorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchAlertingMonitorStatus) DeepCopyInto(out *OpenSearchAlertingMonitorStatus) {
+	*out = *in
+	if in.AppliedResources != nil {
+		in, out := &in.AppliedResources, &out.AppliedResources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MonitorIDs != nil {
+		in, out := &in.MonitorIDs, &out.MonitorIDs
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchAlertingMonitorStatus.
+func (in *OpenSearchAlertingMonitorStatus) DeepCopy() *OpenSearchAlertingMonitorStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchAlertingMonitorStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchAnomalyDetector) DeepCopyInto(out *OpenSearchAnomalyDetector) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchAnomalyDetector.
+func (in *OpenSearchAnomalyDetector) DeepCopy() *OpenSearchAnomalyDetector {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchAnomalyDetector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OpenSearchAnomalyDetector) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchAnomalyDetectorList) DeepCopyInto(out *OpenSearchAnomalyDetectorList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OpenSearchAnomalyDetector, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchAnomalyDetectorList.
+func (in *OpenSearchAnomalyDetectorList) DeepCopy() *OpenSearchAnomalyDetectorList {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchAnomalyDetectorList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OpenSearchAnomalyDetectorList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchAnomalyDetectorSpec) DeepCopyInto(out *OpenSearchAnomalyDetectorSpec) {
+	*out = *in
+	in.ResourceSelector.DeepCopyInto(&out.ResourceSelector)
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make(map[string]AnomalyDetector, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchAnomalyDetectorSpec.
+func (in *OpenSearchAnomalyDetectorSpec) DeepCopy() *OpenSearchAnomalyDetectorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchAnomalyDetectorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchAnomalyDetectorStatus) DeepCopyInto(out *OpenSearchAnomalyDetectorStatus) {
+	*out = *in
+	if in.AppliedResources != nil {
+		in, out := &in.AppliedResources, &out.AppliedResources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DetectorIDs != nil {
+		in, out := &in.DetectorIDs, &out.DetectorIDs
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.DetectorStates != nil {
+		in, out := &in.DetectorStates, &out.DetectorStates
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchAnomalyDetectorStatus.
+func (in *OpenSearchAnomalyDetectorStatus) DeepCopy() *OpenSearchAnomalyDetectorStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchAnomalyDetectorStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchDashboardsSavedObjects) DeepCopyInto(out *OpenSearchDashboardsSavedObjects) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchDashboardsSavedObjects.
+func (in *OpenSearchDashboardsSavedObjects) DeepCopy() *OpenSearchDashboardsSavedObjects {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchDashboardsSavedObjects)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OpenSearchDashboardsSavedObjects) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchDashboardsSavedObjectsList) DeepCopyInto(out *OpenSearchDashboardsSavedObjectsList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OpenSearchDashboardsSavedObjects, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchDashboardsSavedObjectsList.
+func (in *OpenSearchDashboardsSavedObjectsList) DeepCopy() *OpenSearchDashboardsSavedObjectsList {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchDashboardsSavedObjectsList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OpenSearchDashboardsSavedObjectsList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchDashboardsSavedObjectsSpec) DeepCopyInto(out *OpenSearchDashboardsSavedObjectsSpec) {
+	*out = *in
+	in.DashboardsSelector.DeepCopyInto(&out.DashboardsSelector)
+	if in.Overwrite != nil {
+		in, out := &in.Overwrite, &out.Overwrite
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make(map[string]v1.JSON, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchDashboardsSavedObjectsSpec.
+func (in *OpenSearchDashboardsSavedObjectsSpec) DeepCopy() *OpenSearchDashboardsSavedObjectsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchDashboardsSavedObjectsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchDashboardsSavedObjectsStatus) DeepCopyInto(out *OpenSearchDashboardsSavedObjectsStatus) {
+	*out = *in
+	if in.AppliedResources != nil {
+		in, out := &in.AppliedResources, &out.AppliedResources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchDashboardsSavedObjectsStatus.
+func (in *OpenSearchDashboardsSavedObjectsStatus) DeepCopy() *OpenSearchDashboardsSavedObjectsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchDashboardsSavedObjectsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchDashboardsSelector) DeepCopyInto(out *OpenSearchDashboardsSelector) {
+	*out = *in
+	if in.PasswordSecretRef != nil {
+		in, out := &in.PasswordSecretRef, &out.PasswordSecretRef
+		*out = new(SecretKeySelector)
+		**out = **in
+	}
+	if in.CACertSecretRef != nil {
+		in, out := &in.CACertSecretRef, &out.CACertSecretRef
+		*out = new(SecretKeySelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchDashboardsSelector.
+func (in *OpenSearchDashboardsSelector) DeepCopy() *OpenSearchDashboardsSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchDashboardsSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchNotificationChannel) DeepCopyInto(out *OpenSearchNotificationChannel) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchNotificationChannel.
+func (in *OpenSearchNotificationChannel) DeepCopy() *OpenSearchNotificationChannel {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchNotificationChannel)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OpenSearchNotificationChannel) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchNotificationChannelList) DeepCopyInto(out *OpenSearchNotificationChannelList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OpenSearchNotificationChannel, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchNotificationChannelList.
+func (in *OpenSearchNotificationChannelList) DeepCopy() *OpenSearchNotificationChannelList {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchNotificationChannelList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OpenSearchNotificationChannelList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchNotificationChannelSpec) DeepCopyInto(out *OpenSearchNotificationChannelSpec) {
+	*out = *in
+	in.ResourceSelector.DeepCopyInto(&out.ResourceSelector)
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make(map[string]NotificationChannel, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchNotificationChannelSpec.
+func (in *OpenSearchNotificationChannelSpec) DeepCopy() *OpenSearchNotificationChannelSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchNotificationChannelSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenSearchNotificationChannelStatus) DeepCopyInto(out *OpenSearchNotificationChannelStatus) {
+	*out = *in
+	if in.AppliedResources != nil {
+		in, out := &in.AppliedResources, &out.AppliedResources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchNotificationChannelStatus.
+func (in *OpenSearchNotificationChannelStatus) DeepCopy() *OpenSearchNotificationChannelStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenSearchNotificationChannelStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QueryRuleset) DeepCopyInto(out *QueryRuleset) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryRuleset.
+func (in *QueryRuleset) DeepCopy() *QueryRuleset {
+	if in == nil {
+		return nil
+	}
+	out := new(QueryRuleset)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *QueryRuleset) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QueryRulesetList) DeepCopyInto(out *QueryRulesetList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]QueryRuleset, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryRulesetList.
+func (in *QueryRulesetList) DeepCopy() *QueryRulesetList {
+	if in == nil {
+		return nil
+	}
+	out := new(QueryRulesetList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *QueryRulesetList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QueryRulesetSpec) DeepCopyInto(out *QueryRulesetSpec) {
+	*out = *in
+	in.ResourceSelector.DeepCopyInto(&out.ResourceSelector)
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make(map[string]v1.JSON, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryRulesetSpec.
+func (in *QueryRulesetSpec) DeepCopy() *QueryRulesetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(QueryRulesetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QueryRulesetStatus) DeepCopyInto(out *QueryRulesetStatus) {
+	*out = *in
+	if in.AppliedResources != nil {
+		in, out := &in.AppliedResources, &out.AppliedResources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryRulesetStatus.
+func (in *QueryRulesetStatus) DeepCopy() *QueryRulesetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(QueryRulesetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RawResource) DeepCopyInto(out *RawResource) {
+	*out = *in
+	if in.Body != nil {
+		in, out := &in.Body, &out.Body
+		*out = new(v1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RawResource.
+func (in *RawResource) DeepCopy() *RawResource {
+	if in == nil {
+		return nil
+	}
+	out := new(RawResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceSelector) DeepCopyInto(out *ResourceSelector) {
+	*out = *in
+	if in.MatchLabels != nil {
+		in, out := &in.MatchLabels, &out.MatchLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.EndpointFrom != nil {
+		in, out := &in.EndpointFrom, &out.EndpointFrom
+		*out = new(EndpointSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PasswordSecretRef != nil {
+		in, out := &in.PasswordSecretRef, &out.PasswordSecretRef
+		*out = new(SecretKeySelector)
+		**out = **in
+	}
+	if in.BasicAuthSecretRef != nil {
+		in, out := &in.BasicAuthSecretRef, &out.BasicAuthSecretRef
+		*out = new(SecretReference)
+		**out = **in
+	}
+	if in.APIKeySecretRef != nil {
+		in, out := &in.APIKeySecretRef, &out.APIKeySecretRef
+		*out = new(SecretKeySelector)
+		**out = **in
+	}
+	if in.TokenSecretRef != nil {
+		in, out := &in.TokenSecretRef, &out.TokenSecretRef
+		*out = new(SecretKeySelector)
+		**out = **in
+	}
+	if in.ClientCertSecretRef != nil {
+		in, out := &in.ClientCertSecretRef, &out.ClientCertSecretRef
+		*out = new(SecretReference)
+		**out = **in
+	}
+	if in.AWS != nil {
+		in, out := &in.AWS, &out.AWS
+		*out = new(AWSAuthentication)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CACertSecretRef != nil {
+		in, out := &in.CACertSecretRef, &out.CACertSecretRef
+		*out = new(SecretKeySelector)
+		**out = **in
+	}
+	if in.CACertConfigMapRef != nil {
+		in, out := &in.CACertConfigMapRef, &out.CACertConfigMapRef
+		*out = new(ConfigMapKeySelector)
+		**out = **in
+	}
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Timeouts != nil {
+		in, out := &in.Timeouts, &out.Timeouts
+		*out = new(ConnectionTimeouts)
+		**out = **in
+	}
+	if in.NodeDiscovery != nil {
+		in, out := &in.NodeDiscovery, &out.NodeDiscovery
+		*out = new(NodeDiscovery)
+		**out = **in
+	}
+	if in.ConnectionRef != nil {
+		in, out := &in.ConnectionRef, &out.ConnectionRef
+		*out = new(ClusterConnectionReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceSelector.
+func (in *ResourceSelector) DeepCopy() *ResourceSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceStatus) DeepCopyInto(out *ResourceStatus) {
+	*out = *in
+	if in.LastAppliedTime != nil {
+		in, out := &in.LastAppliedTime, &out.LastAppliedTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceStatus.
+func (in *ResourceStatus) DeepCopy() *ResourceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourcesSource) DeepCopyInto(out *ResourcesSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourcesSource.
+func (in *ResourcesSource) DeepCopy() *ResourcesSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourcesSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloverBootstrap) DeepCopyInto(out *RolloverBootstrap) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloverBootstrap.
+func (in *RolloverBootstrap) DeepCopy() *RolloverBootstrap {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloverBootstrap)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SNSChannel) DeepCopyInto(out *SNSChannel) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SNSChannel.
+func (in *SNSChannel) DeepCopy() *SNSChannel {
+	if in == nil {
+		return nil
+	}
+	out := new(SNSChannel)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SearchApplication) DeepCopyInto(out *SearchApplication) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SearchApplication.
+func (in *SearchApplication) DeepCopy() *SearchApplication {
+	if in == nil {
+		return nil
+	}
+	out := new(SearchApplication)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SearchApplication) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SearchApplicationDefinition) DeepCopyInto(out *SearchApplicationDefinition) {
+	*out = *in
+	if in.Indices != nil {
+		in, out := &in.Indices, &out.Indices
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Template != nil {
+		in, out := &in.Template, &out.Template
+		*out = new(SearchApplicationTemplate)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SearchApplicationDefinition.
+func (in *SearchApplicationDefinition) DeepCopy() *SearchApplicationDefinition {
+	if in == nil {
+		return nil
+	}
+	out := new(SearchApplicationDefinition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SearchApplicationList) DeepCopyInto(out *SearchApplicationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SearchApplication, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SearchApplicationList.
+func (in *SearchApplicationList) DeepCopy() *SearchApplicationList {
+	if in == nil {
+		return nil
+	}
+	out := new(SearchApplicationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SearchApplicationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SearchApplicationSpec) DeepCopyInto(out *SearchApplicationSpec) {
+	*out = *in
+	in.ResourceSelector.DeepCopyInto(&out.ResourceSelector)
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make(map[string]SearchApplicationDefinition, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SearchApplicationSpec.
+func (in *SearchApplicationSpec) DeepCopy() *SearchApplicationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SearchApplicationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SearchApplicationStatus) DeepCopyInto(out *SearchApplicationStatus) {
+	*out = *in
+	if in.AppliedResources != nil {
+		in, out := &in.AppliedResources, &out.AppliedResources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SearchApplicationStatus.
+func (in *SearchApplicationStatus) DeepCopy() *SearchApplicationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SearchApplicationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SearchApplicationTemplate) DeepCopyInto(out *SearchApplicationTemplate) {
+	*out = *in
+	in.Source.DeepCopyInto(&out.Source)
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make(map[string]v1.JSON, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.Dictionary != nil {
+		in, out := &in.Dictionary, &out.Dictionary
+		*out = new(v1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SearchApplicationTemplate.
+func (in *SearchApplicationTemplate) DeepCopy() *SearchApplicationTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(SearchApplicationTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretKeySelector) DeepCopyInto(out *SecretKeySelector) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretKeySelector.
+func (in *SecretKeySelector) DeepCopy() *SecretKeySelector {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretKeySelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretReference) DeepCopyInto(out *SecretReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretReference.
+func (in *SecretReference) DeepCopy() *SecretReference {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SettingStatus) DeepCopyInto(out *SettingStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SettingStatus.
+func (in *SettingStatus) DeepCopy() *SettingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SettingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlackChannel) DeepCopyInto(out *SlackChannel) {
+	*out = *in
+	if in.URLSecretRef != nil {
+		in, out := &in.URLSecretRef, &out.URLSecretRef
+		*out = new(SecretKeySelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlackChannel.
+func (in *SlackChannel) DeepCopy() *SlackChannel {
+	if in == nil {
+		return nil
+	}
+	out := new(SlackChannel)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotLifecyclePolicy) DeepCopyInto(out *SnapshotLifecyclePolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotLifecyclePolicy.
+func (in *SnapshotLifecyclePolicy) DeepCopy() *SnapshotLifecyclePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotLifecyclePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SnapshotLifecyclePolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotLifecyclePolicyList) DeepCopyInto(out *SnapshotLifecyclePolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SnapshotLifecyclePolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotLifecyclePolicyList.
+func (in *SnapshotLifecyclePolicyList) DeepCopy() *SnapshotLifecyclePolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotLifecyclePolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SnapshotLifecyclePolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotLifecyclePolicySpec) DeepCopyInto(out *SnapshotLifecyclePolicySpec) {
+	*out = *in
+	in.ResourceSelector.DeepCopyInto(&out.ResourceSelector)
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make(map[string]v1.JSON, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.ValuesFrom != nil {
+		in, out := &in.ValuesFrom, &out.ValuesFrom
+		*out = make([]ValuesSource, len(*in))
+		copy(*out, *in)
+	}
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]Dependency, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotLifecyclePolicySpec.
+func (in *SnapshotLifecyclePolicySpec) DeepCopy() *SnapshotLifecyclePolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotLifecyclePolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotLifecyclePolicyStatus) DeepCopyInto(out *SnapshotLifecyclePolicyStatus) {
+	*out = *in
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]ClusterTargetStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.AppliedResources != nil {
+		in, out := &in.AppliedResources, &out.AppliedResources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make(map[string]ResourceStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.Changes != nil {
+		in, out := &in.Changes, &out.Changes
+		*out = make([]ObjectChange, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotLifecyclePolicyStatus.
 func (in *SnapshotLifecyclePolicyStatus) DeepCopy() *SnapshotLifecyclePolicyStatus {
 	if in == nil {
 		return nil
@@ -701,6 +4768,11 @@ func (in *SnapshotRepositorySpec) DeepCopyInto(out *SnapshotRepositorySpec) {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.ValuesFrom != nil {
+		in, out := &in.ValuesFrom, &out.ValuesFrom
+		*out = make([]ValuesSource, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotRepositorySpec.
@@ -716,6 +4788,11 @@ func (in *SnapshotRepositorySpec) DeepCopy() *SnapshotRepositorySpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SnapshotRepositoryStatus) DeepCopyInto(out *SnapshotRepositoryStatus) {
 	*out = *in
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]ClusterTargetStatus, len(*in))
+		copy(*out, *in)
+	}
 	if in.AppliedResources != nil {
 		in, out := &in.AppliedResources, &out.AppliedResources
 		*out = make([]string, len(*in))
@@ -743,3 +4820,176 @@ func (in *SnapshotRepositoryStatus) DeepCopy() *SnapshotRepositoryStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SynonymsSet) DeepCopyInto(out *SynonymsSet) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SynonymsSet.
+func (in *SynonymsSet) DeepCopy() *SynonymsSet {
+	if in == nil {
+		return nil
+	}
+	out := new(SynonymsSet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SynonymsSet) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SynonymsSetList) DeepCopyInto(out *SynonymsSetList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SynonymsSet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SynonymsSetList.
+func (in *SynonymsSetList) DeepCopy() *SynonymsSetList {
+	if in == nil {
+		return nil
+	}
+	out := new(SynonymsSetList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SynonymsSetList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SynonymsSetSpec) DeepCopyInto(out *SynonymsSetSpec) {
+	*out = *in
+	in.ResourceSelector.DeepCopyInto(&out.ResourceSelector)
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make(map[string]map[string]string, len(*in))
+		for key, val := range *in {
+			var outVal map[string]string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make(map[string]string, len(*in))
+				for key, val := range *in {
+					(*out)[key] = val
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SynonymsSetSpec.
+func (in *SynonymsSetSpec) DeepCopy() *SynonymsSetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SynonymsSetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SynonymsSetStatus) DeepCopyInto(out *SynonymsSetStatus) {
+	*out = *in
+	if in.AppliedResources != nil {
+		in, out := &in.AppliedResources, &out.AppliedResources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SynonymsSetStatus.
+func (in *SynonymsSetStatus) DeepCopy() *SynonymsSetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SynonymsSetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ValuesSource) DeepCopyInto(out *ValuesSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ValuesSource.
+func (in *ValuesSource) DeepCopy() *ValuesSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ValuesSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookChannel) DeepCopyInto(out *WebhookChannel) {
+	*out = *in
+	if in.URLSecretRef != nil {
+		in, out := &in.URLSecretRef, &out.URLSecretRef
+		*out = new(SecretKeySelector)
+		**out = **in
+	}
+	if in.HeaderParams != nil {
+		in, out := &in.HeaderParams, &out.HeaderParams
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.HeaderSecretRefs != nil {
+		in, out := &in.HeaderSecretRefs, &out.HeaderSecretRefs
+		*out = make(map[string]SecretKeySelector, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookChannel.
+func (in *WebhookChannel) DeepCopy() *WebhookChannel {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookChannel)
+	in.DeepCopyInto(out)
+	return out
+}
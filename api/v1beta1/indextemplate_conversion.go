@@ -0,0 +1,167 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"encoding/json"
+	"fmt"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
+)
+
+// ApplyOrderAnnotation keeps the applyOrder of a v1alpha1 IndexTemplate served as v1beta1 when it names templates
+// loaded from resourcesFrom, which the resources of v1beta1 can not order
+const ApplyOrderAnnotation = "elastic-config-operator.freepik.com/apply-order"
+
+// ConvertTo converts an IndexTemplate to the v1alpha1 hub. The order of the resources is kept in applyOrder, along
+// with the names of ApplyOrderAnnotation missing from them
+func (src *IndexTemplate) ConvertTo(dstRaw conversion.Hub) error {
+	dst, ok := dstRaw.(*v1alpha1.IndexTemplate)
+	if !ok {
+		return fmt.Errorf("unexpected conversion hub %T", dstRaw)
+	}
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	dst.Spec = v1alpha1.IndexTemplateSpec{
+		ResourceSelector:      *src.Spec.ResourceSelector.DeepCopy(),
+		ResourcesFrom:         append([]v1alpha1.ResourcesSource(nil), src.Spec.ResourcesFrom...),
+		ValuesFrom:            append([]v1alpha1.ValuesSource(nil), src.Spec.ValuesFrom...),
+		Templating:            src.Spec.Templating,
+		SyncInterval:          src.Spec.SyncInterval,
+		DryRun:                src.Spec.DryRun,
+		RequiredClusterHealth: src.Spec.RequiredClusterHealth,
+		DependsOn:             append([]v1alpha1.Dependency(nil), src.Spec.DependsOn...),
+		DeletionPolicy:        src.Spec.DeletionPolicy,
+		Suspend:               src.Spec.Suspend,
+		AdoptionPolicy:        src.Spec.AdoptionPolicy,
+		Bootstrap:             append([]v1alpha1.RolloverBootstrap(nil), src.Spec.Bootstrap...),
+		CreateDataStreams:     src.Spec.CreateDataStreams,
+	}
+	var inline []string
+	if src.Spec.Resources != nil {
+		dst.Spec.Resources = make(map[string]apiextensionsv1.JSON, len(src.Spec.Resources))
+		for _, resource := range src.Spec.Resources {
+			dst.Spec.Resources[resource.Name] = *resource.Definition.DeepCopy()
+			inline = append(inline, resource.Name)
+		}
+	}
+
+	var kept []string
+	if value, ok := dst.Annotations[ApplyOrderAnnotation]; ok {
+		if err := json.Unmarshal([]byte(value), &kept); err != nil {
+			return fmt.Errorf("invalid %s annotation: %w", ApplyOrderAnnotation, err)
+		}
+		delete(dst.Annotations, ApplyOrderAnnotation)
+		if len(dst.Annotations) == 0 {
+			dst.Annotations = nil
+		}
+	}
+	dst.Spec.ApplyOrder = mergeApplyOrder(kept, inline)
+
+	dst.Status = *src.Status.DeepCopy()
+	return nil
+}
+
+// ConvertFrom converts an IndexTemplate from the v1alpha1 hub. The resources are listed in the order they are
+// applied: the ones of applyOrder first, then the rest sorted by name. An applyOrder naming other templates is kept
+// in ApplyOrderAnnotation
+func (dst *IndexTemplate) ConvertFrom(srcRaw conversion.Hub) error {
+	src, ok := srcRaw.(*v1alpha1.IndexTemplate)
+	if !ok {
+		return fmt.Errorf("unexpected conversion hub %T", srcRaw)
+	}
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	dst.Spec = IndexTemplateSpec{
+		ResourceSelector:      *src.Spec.ResourceSelector.DeepCopy(),
+		ResourcesFrom:         append([]v1alpha1.ResourcesSource(nil), src.Spec.ResourcesFrom...),
+		ValuesFrom:            append([]v1alpha1.ValuesSource(nil), src.Spec.ValuesFrom...),
+		Templating:            src.Spec.Templating,
+		SyncInterval:          src.Spec.SyncInterval,
+		DryRun:                src.Spec.DryRun,
+		RequiredClusterHealth: src.Spec.RequiredClusterHealth,
+		DependsOn:             append([]v1alpha1.Dependency(nil), src.Spec.DependsOn...),
+		DeletionPolicy:        src.Spec.DeletionPolicy,
+		Suspend:               src.Spec.Suspend,
+		AdoptionPolicy:        src.Spec.AdoptionPolicy,
+		Bootstrap:             append([]v1alpha1.RolloverBootstrap(nil), src.Spec.Bootstrap...),
+		CreateDataStreams:     src.Spec.CreateDataStreams,
+	}
+	if src.Spec.Resources != nil {
+		dst.Spec.Resources = make([]IndexTemplateResource, 0, len(src.Spec.Resources))
+		for _, name := range src.Spec.OrderedResourceNames() {
+			definition := src.Spec.Resources[name]
+			dst.Spec.Resources = append(dst.Spec.Resources, IndexTemplateResource{
+				Name:       name,
+				Definition: *definition.DeepCopy(),
+			})
+		}
+	}
+
+	delete(dst.Annotations, ApplyOrderAnnotation)
+	for _, name := range src.Spec.ApplyOrder {
+		if _, inline := src.Spec.Resources[name]; inline {
+			continue
+		}
+		encoded, err := json.Marshal(src.Spec.ApplyOrder)
+		if err != nil {
+			return err
+		}
+		if dst.Annotations == nil {
+			dst.Annotations = make(map[string]string, 1)
+		}
+		dst.Annotations[ApplyOrderAnnotation] = string(encoded)
+		break
+	}
+	if len(dst.Annotations) == 0 {
+		dst.Annotations = nil
+	}
+
+	dst.Status = *src.Status.DeepCopy()
+	return nil
+}
+
+// mergeApplyOrder returns the applyOrder of the inline resources, in their order, with the other names of the kept
+// applyOrder in their places. The inline resources take the places of the inline names of the kept applyOrder, so
+// the resources reordered in v1beta1 keep their new order
+func mergeApplyOrder(kept, inline []string) []string {
+	if kept == nil && inline == nil {
+		return nil
+	}
+
+	isInline := make(map[string]bool, len(inline))
+	for _, name := range inline {
+		isInline[name] = true
+	}
+
+	order := make([]string, 0, len(kept)+len(inline))
+	next := 0
+	for _, name := range kept {
+		if !isInline[name] {
+			order = append(order, name)
+			continue
+		}
+		if next < len(inline) {
+			order = append(order, inline[next])
+			next++
+		}
+	}
+	return append(order, inline[next:]...)
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"slices"
+	"testing"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
+)
+
+// templateBody returns the raw JSON body of an index template matching the pattern
+func templateBody(pattern string) apiextensionsv1.JSON {
+	return apiextensionsv1.JSON{Raw: []byte(`{"index_patterns":["` + pattern + `"]}`)}
+}
+
+// hubIndexTemplate returns a v1alpha1 IndexTemplate with the inline templates and applyOrder
+func hubIndexTemplate(applyOrder []string, names ...string) *v1alpha1.IndexTemplate {
+	hub := &v1alpha1.IndexTemplate{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "templates", Labels: map[string]string{"team": "a"}},
+		Spec: v1alpha1.IndexTemplateSpec{
+			ResourcesFrom: []v1alpha1.ResourcesSource{{Kind: "ConfigMap", Name: "shared-templates"}},
+			ApplyOrder:    applyOrder,
+		},
+	}
+	if len(names) > 0 {
+		hub.Spec.Resources = make(map[string]apiextensionsv1.JSON, len(names))
+		for _, name := range names {
+			hub.Spec.Resources[name] = templateBody(name + "-*")
+		}
+	}
+	return hub
+}
+
+func TestIndexTemplateHubRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		hub  *v1alpha1.IndexTemplate
+		// wantNames are the names of the v1beta1 resources, in order
+		wantNames []string
+		// wantAnnotation is the applyOrder kept in ApplyOrderAnnotation, empty when none is kept
+		wantAnnotation string
+	}{
+		{
+			name:      "inline templates only",
+			hub:       hubIndexTemplate([]string{"metrics", "logs"}, "logs", "metrics"),
+			wantNames: []string{"metrics", "logs"},
+		},
+		{
+			name:           "applyOrder naming templates of resourcesFrom",
+			hub:            hubIndexTemplate([]string{"logs", "shared-base", "metrics", "shared-logs"}, "logs", "metrics"),
+			wantNames:      []string{"logs", "metrics"},
+			wantAnnotation: `["logs","shared-base","metrics","shared-logs"]`,
+		},
+		{
+			name:           "applyOrder naming templates of resourcesFrom only",
+			hub:            hubIndexTemplate([]string{"shared-base", "shared-logs"}),
+			wantAnnotation: `["shared-base","shared-logs"]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spoke := &IndexTemplate{}
+			if err := spoke.ConvertFrom(tt.hub); err != nil {
+				t.Fatalf("ConvertFrom() = %v", err)
+			}
+
+			names := make([]string, 0, len(spoke.Spec.Resources))
+			for _, resource := range spoke.Spec.Resources {
+				names = append(names, resource.Name)
+			}
+			if !slices.Equal(names, tt.wantNames) {
+				t.Errorf("v1beta1 resources = %v, want %v", names, tt.wantNames)
+			}
+			if annotation := spoke.Annotations[ApplyOrderAnnotation]; annotation != tt.wantAnnotation {
+				t.Errorf("%s annotation = %q, want %q", ApplyOrderAnnotation, annotation, tt.wantAnnotation)
+			}
+
+			hub := &v1alpha1.IndexTemplate{}
+			if err := spoke.ConvertTo(hub); err != nil {
+				t.Fatalf("ConvertTo() = %v", err)
+			}
+			if !equality.Semantic.DeepEqual(hub, tt.hub) {
+				t.Errorf("round trip changed the IndexTemplate:\ngot  %+v\nwant %+v", hub, tt.hub)
+			}
+		})
+	}
+}
+
+func TestIndexTemplateSpokeRoundTrip(t *testing.T) {
+	spoke := &IndexTemplate{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "templates"},
+		Spec: IndexTemplateSpec{
+			Resources: []IndexTemplateResource{
+				{Name: "metrics", Definition: templateBody("metrics-*")},
+				{Name: "logs", Definition: templateBody("logs-*")},
+				{Name: "audit", Definition: templateBody("audit-*")},
+			},
+			CreateDataStreams: true,
+		},
+	}
+
+	hub := &v1alpha1.IndexTemplate{}
+	if err := spoke.ConvertTo(hub); err != nil {
+		t.Fatalf("ConvertTo() = %v", err)
+	}
+	if want := []string{"metrics", "logs", "audit"}; !slices.Equal(hub.Spec.ApplyOrder, want) {
+		t.Errorf("applyOrder = %v, want %v", hub.Spec.ApplyOrder, want)
+	}
+
+	converted := &IndexTemplate{}
+	if err := converted.ConvertFrom(hub); err != nil {
+		t.Fatalf("ConvertFrom() = %v", err)
+	}
+	if !equality.Semantic.DeepEqual(converted, spoke) {
+		t.Errorf("round trip changed the IndexTemplate:\ngot  %+v\nwant %+v", converted, spoke)
+	}
+}
+
+func TestIndexTemplateReorderedKeepsResourcesFromOrder(t *testing.T) {
+	hub := hubIndexTemplate([]string{"shared-base", "logs", "metrics", "shared-logs"}, "logs", "metrics")
+
+	spoke := &IndexTemplate{}
+	if err := spoke.ConvertFrom(hub); err != nil {
+		t.Fatalf("ConvertFrom() = %v", err)
+	}
+
+	// The inline templates are reordered and a new one added through v1beta1
+	spoke.Spec.Resources = []IndexTemplateResource{
+		{Name: "metrics", Definition: templateBody("metrics-*")},
+		{Name: "logs", Definition: templateBody("logs-*")},
+		{Name: "audit", Definition: templateBody("audit-*")},
+	}
+
+	converted := &v1alpha1.IndexTemplate{}
+	if err := spoke.ConvertTo(converted); err != nil {
+		t.Fatalf("ConvertTo() = %v", err)
+	}
+	if want := []string{"shared-base", "metrics", "logs", "shared-logs", "audit"}; !slices.Equal(converted.Spec.ApplyOrder, want) {
+		t.Errorf("applyOrder = %v, want %v", converted.Spec.ApplyOrder, want)
+	}
+	if _, exists := converted.Annotations[ApplyOrderAnnotation]; exists {
+		t.Errorf("%s annotation left in the v1alpha1 IndexTemplate", ApplyOrderAnnotation)
+	}
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
+)
+
+// IndexTemplateSpec defines the desired state of IndexTemplate
+// +kubebuilder:validation:XValidation:rule="has(self.resources) || has(self.resourcesFrom)",message="one of resources or resourcesFrom must be set"
+type IndexTemplateSpec struct {
+	// ResourceSelector specifies the target cluster. If omitted, the NamespaceDefaultCluster of the namespace is used
+	// +optional
+	ResourceSelector v1alpha1.ResourceSelector `json:"resourceSelector,omitzero"`
+
+	// Resources are the index templates, applied in the order of the list
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Resources []IndexTemplateResource `json:"resources,omitempty"`
+
+	// ResourcesFrom are the ConfigMaps and Secrets holding more index templates, for the ones too large to be kept
+	// inline. They are applied after the resources, sorted by name, and read again on every sync and whenever they
+	// change. A template can only be defined once
+	// +optional
+	ResourcesFrom []v1alpha1.ResourcesSource `json:"resourcesFrom,omitempty"`
+
+	// ValuesFrom are the ConfigMaps and Secrets whose keys can be referenced as ${key} in the strings of the
+	// resources, e.g. to use a different number of replicas per environment. Later sources override the keys of the earlier
+	// ones, and the values are read again on every sync
+	// +optional
+	ValuesFrom []v1alpha1.ValuesSource `json:"valuesFrom,omitempty"`
+
+	// Templating renders the strings of the resources as Go templates when set to GoTemplate, with the target cluster as
+	// .Cluster (.Cluster.Name, .Cluster.Version, ...) and the resource as .Name and .Namespace, e.g. to change
+	// the number of replicas of the templates per cluster of resourceSelector.matchLabels
+	// +optional
+	// +kubebuilder:validation:Enum=GoTemplate
+	Templating string `json:"templating,omitempty"`
+
+	// SyncInterval defines the interval for reconciliation (e.g., "30s", "5m"). Defaults to 10s.
+	// +optional
+	// +kubebuilder:default="10s"
+	// +kubebuilder:validation:Pattern=`^([0-9]+(\.[0-9]+)?(ns|us|ms|s|m|h))+$`
+	SyncInterval string `json:"syncInterval,omitempty"`
+
+	// DryRun reports the changes the synchronization would make to Elasticsearch in the status and in events,
+	// without making them
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// RequiredClusterHealth is the minimum health of the cluster (green or yellow) for the changes to be applied.
+	// While the cluster is below it (e.g., red), the changes are postponed until it recovers
+	// +optional
+	// +kubebuilder:validation:Enum=green;yellow
+	RequiredClusterHealth string `json:"requiredClusterHealth,omitempty"`
+
+	// DependsOn lists the resources of the operator which must be Ready before the resource is synced (e.g., the
+	// IndexLifecyclePolicy used by its templates). The changes are postponed until all of them are Ready
+	// +optional
+	DependsOn []v1alpha1.Dependency `json:"dependsOn,omitempty"`
+
+	// DeletionPolicy defines what happens to the objects of the resource in the cluster when the resource is
+	// deleted: Delete removes them, and Retain leaves them in the cluster (e.g. to move them to another resource)
+	// +optional
+	// +kubebuilder:default=Delete
+	DeletionPolicy v1alpha1.DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// Suspend pauses the reconciliation of the resource, including the deletion of its objects from the cluster when
+	// the resource is deleted, while leaving its status untouched
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+
+	// AdoptionPolicy defines what happens to the templates of the spec that already exist in the cluster but were not
+	// created by the resource (e.g., created by hand or owned by another resource): Adopt overwrites them, Fail leaves
+	// them untouched and reports a Conflict condition, and IgnoreDifferences leaves them as they are
+	// +optional
+	// +kubebuilder:default=Fail
+	AdoptionPolicy v1alpha1.AdoptionPolicy `json:"adoptionPolicy,omitempty"`
+
+	// Bootstrap creates the initial index of the rollover aliases of the templates, with the alias as its write
+	// alias, when the alias does not exist yet. Rollover policies (ILM or ISM) only work once that index exists
+	// +optional
+	Bootstrap []v1alpha1.RolloverBootstrap `json:"bootstrap,omitempty"`
+
+	// CreateDataStreams creates the data streams of the data stream templates once they are applied, one for every
+	// index pattern without wildcards, so producers don't race the creation of the templates on fresh clusters
+	// +optional
+	CreateDataStreams bool `json:"createDataStreams,omitempty"`
+}
+
+// IndexTemplateResource is an index template of the spec
+type IndexTemplateResource struct {
+	// Name is the name of the index template in Elasticsearch
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Definition is the body of the index template, as sent to the index template API
+	Definition apiextensionsv1.JSON `json:"definition"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:unservedversion
+// +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Cluster",type=string,JSONPath=`.status.targetCluster`
+// +kubebuilder:printcolumn:name="Applied",type=integer,JSONPath=`.status.appliedCount`
+// +kubebuilder:printcolumn:name="Failed",type=integer,JSONPath=`.status.failedCount`
+// +kubebuilder:printcolumn:name="Message",type=string,JSONPath=`.status.message`,priority=1
+// +kubebuilder:printcolumn:name="Last Sync",type=date,JSONPath=`.status.lastSyncTime`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// IndexTemplate is the Schema for the indextemplates API
+type IndexTemplate struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitzero"`
+
+	// spec defines the desired state of IndexTemplate
+	// +required
+	Spec IndexTemplateSpec `json:"spec"`
+
+	// status defines the observed state of IndexTemplate
+	// +optional
+	Status v1alpha1.IndexTemplateStatus `json:"status,omitzero"`
+}
+
+// +kubebuilder:object:root=true
+
+// IndexTemplateList contains a list of IndexTemplate
+type IndexTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitzero"`
+	Items           []IndexTemplate `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&IndexTemplate{}, &IndexTemplateList{})
+}
@@ -7,12 +7,35 @@ set -e
 SCRIPT_DIR="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)"
 CRDS_DIR="$(dirname "$SCRIPT_DIR")/crds"
 CRDS=(
+  "applicationprivileges.elastic-config-operator.freepik.com"
+  "autoscalingpolicies.elastic-config-operator.freepik.com"
+  "clusterindexlifecyclepolicies.elastic-config-operator.freepik.com"
+  "clusterindextemplates.elastic-config-operator.freepik.com"
   "clustersettings.elastic-config-operator.freepik.com"
+  "elasticclusterbindings.elastic-config-operator.freepik.com"
+  "elasticconfigbundles.elastic-config-operator.freepik.com"
+  "elasticsearchclusterconnections.elastic-config-operator.freepik.com"
+  "elasticsearchrawresources.elastic-config-operator.freepik.com"
+  "fleetagentpolicies.elastic-config-operator.freepik.com"
   "indexlifecyclepolicies.elastic-config-operator.freepik.com"
+  "indexlifecycles.elastic-config-operator.freepik.com"
   "indexstatemanagements.elastic-config-operator.freepik.com"
   "indextemplates.elastic-config-operator.freepik.com"
+  "kibanaalertrules.elastic-config-operator.freepik.com"
+  "kibanasavedobjects.elastic-config-operator.freepik.com"
+  "kibanaspaces.elastic-config-operator.freepik.com"
+  "machinelearningjobs.elastic-config-operator.freepik.com"
+  "namespacedefaultclusters.elastic-config-operator.freepik.com"
+  "nodeshutdowns.elastic-config-operator.freepik.com"
+  "opensearchalertingmonitors.elastic-config-operator.freepik.com"
+  "opensearchanomalydetectors.elastic-config-operator.freepik.com"
+  "opensearchdashboardssavedobjects.elastic-config-operator.freepik.com"
+  "opensearchnotificationchannels.elastic-config-operator.freepik.com"
+  "queryrulesets.elastic-config-operator.freepik.com"
+  "searchapplications.elastic-config-operator.freepik.com"
   "snapshotlifecyclepolicies.elastic-config-operator.freepik.com"
   "snapshotrepositories.elastic-config-operator.freepik.com"
+  "synonymssets.elastic-config-operator.freepik.com"
 )
 
 COLOR_GREEN='\033[0;32m'
@@ -45,6 +68,11 @@ function check_kubectl() {
   fi
 }
 
+# The IndexTemplate CRD is rendered by the chart, as its conversion webhook depends on the release
+function print_indextemplates_hint() {
+  echo -e "${COLOR_YELLOW}The IndexTemplate CRD is not in $CRDS_DIR, it is rendered by the chart. See CRD-MANAGEMENT.md${COLOR_RESET}"
+}
+
 function install_crds() {
   echo -e "${COLOR_BLUE}📦 Installing CRDs...${COLOR_RESET}"
   
@@ -56,6 +84,7 @@ function install_crds() {
   kubectl apply -f "$CRDS_DIR"
   
   echo -e "${COLOR_GREEN}✅ CRDs installed successfully${COLOR_RESET}"
+  print_indextemplates_hint
 }
 
 function update_crds() {
@@ -69,6 +98,7 @@ function update_crds() {
   kubectl apply -f "$CRDS_DIR"
   
   echo -e "${COLOR_GREEN}✅ CRDs updated successfully${COLOR_RESET}"
+  print_indextemplates_hint
 }
 
 function delete_crds() {
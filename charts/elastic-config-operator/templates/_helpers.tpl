@@ -60,3 +60,14 @@ Create the name of the service account to use
 {{- default "default" .Values.controller.serviceAccount.name }}
 {{- end }}
 {{- end }}
+
+{{/*
+Name of the Secret holding the certificate of the webhook server
+*/}}
+{{- define "elastic-config-operator.webhookCertSecretName" -}}
+{{- if .Values.webhook.certManager.enabled }}
+{{- printf "%s-webhook-cert" (include "elastic-config-operator.fullname" .) }}
+{{- else }}
+{{- required "webhook.secretName is required when webhook.certManager is disabled" .Values.webhook.secretName }}
+{{- end }}
+{{- end }}
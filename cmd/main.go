@@ -19,7 +19,10 @@ package main
 import (
 	"crypto/tls"
 	"flag"
+	"fmt"
+	"net/http"
 	"os"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -36,14 +39,39 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	eckconfigoperatorfreepikcomv1alpha1 "elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
+	eckconfigoperatorfreepikcomv1beta1 "elastic-config-operator.freepik.com/elastic-config-operator/api/v1beta1"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/controller/applicationprivilege"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/controller/autoscalingpolicy"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/controller/clusterindexlifecyclepolicy"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/controller/clusterindextemplate"
 	"elastic-config-operator.freepik.com/elastic-config-operator/internal/controller/clustersettings"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/controller/connectionsecret"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/controller/elasticconfigbundle"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/controller/elasticsearchclusterconnection"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/controller/elasticsearchrawresource"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/controller/fleetagentpolicy"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/controller/indexlifecycle"
 	"elastic-config-operator.freepik.com/elastic-config-operator/internal/controller/indexlifecyclepolicy"
 	"elastic-config-operator.freepik.com/elastic-config-operator/internal/controller/indexstatemanagement"
 	"elastic-config-operator.freepik.com/elastic-config-operator/internal/controller/indextemplate"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/controller/kibanaalertrule"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/controller/kibanasavedobjects"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/controller/kibanaspace"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/controller/machinelearningjob"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/controller/multicluster"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/controller/nodeshutdown"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/controller/opensearchalertingmonitor"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/controller/opensearchanomalydetector"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/controller/opensearchdashboardssavedobjects"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/controller/opensearchnotificationchannel"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/controller/queryruleset"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/controller/searchapplication"
 	"elastic-config-operator.freepik.com/elastic-config-operator/internal/controller/snapshotlifecyclepolicy"
 	"elastic-config-operator.freepik.com/elastic-config-operator/internal/controller/snapshotrepository"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/controller/synonymsset"
 	"elastic-config-operator.freepik.com/elastic-config-operator/internal/globals"
 	"elastic-config-operator.freepik.com/elastic-config-operator/internal/pools"
+	webhookv1alpha1 "elastic-config-operator.freepik.com/elastic-config-operator/internal/webhook/v1alpha1"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -55,12 +83,16 @@ var (
 	ElasticsearchConnectionsPool = &pools.ElasticsearchConnectionsStore{
 		Store: make(map[string]*pools.ElasticsearchConnection),
 	}
+	KibanaConnectionsPool = &pools.KibanaConnectionsStore{
+		Store: make(map[string]*pools.KibanaConnection),
+	}
 )
 
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
 	utilruntime.Must(eckconfigoperatorfreepikcomv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(eckconfigoperatorfreepikcomv1beta1.AddToScheme(scheme))
 	// +kubebuilder:scaffold:scheme
 }
 
@@ -73,6 +105,25 @@ func main() {
 	var probeAddr string
 	var secureMetrics bool
 	var enableHTTP2 bool
+	var enforceClusterBindings bool
+	var enableWebhooks bool
+	var webhookPort int
+	var auditMode bool
+	var elasticsearchRequestTimeout, elasticsearchDialTimeout time.Duration
+	var elasticsearchMaxRetries int
+	var elasticsearchRequestsPerSecond float64
+	var elasticsearchRequestsBurst int
+	var elasticsearchMaxIdleConnsPerHost int
+	var elasticsearchKeepAlive, elasticsearchIdleConnTimeout, elasticsearchTLSHandshakeTimeout time.Duration
+	var elasticsearchEnableHTTP2 bool
+	var connectionTTL, connectionIdleTimeout, connectionHealthCheckInterval time.Duration
+	var connectionPoolMaxSize int
+	var errorBackoffBase, errorBackoffMax time.Duration
+	var minSyncInterval time.Duration
+	var syncIntervalJitter float64
+	var deletionMaxAttempts int
+	var forbiddenClusterSettings, allowedClusterSettings string
+	var maxObjectsPerNamespace int
 	var tlsOpts []func(*tls.Config)
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
@@ -82,6 +133,10 @@ func main() {
 			"Enabling this will ensure there is only one active controller manager.")
 	flag.BoolVar(&secureMetrics, "metrics-secure", true,
 		"If set, the metrics endpoint is served securely via HTTPS. Use --metrics-secure=false to use HTTP instead.")
+	flag.BoolVar(&enableWebhooks, "enable-webhooks", false,
+		"If set, the defaulting and validating webhooks of the resources are served, defaulting them and rejecting "+
+			"the invalid ones when applied.")
+	flag.IntVar(&webhookPort, "webhook-port", webhook.DefaultPort, "The port the webhook server binds to.")
 	flag.StringVar(&webhookCertPath, "webhook-cert-path", "", "The directory that contains the webhook certificate.")
 	flag.StringVar(&webhookCertName, "webhook-cert-name", "tls.crt", "The name of the webhook certificate file.")
 	flag.StringVar(&webhookCertKey, "webhook-cert-key", "tls.key", "The name of the webhook key file.")
@@ -91,6 +146,62 @@ func main() {
 	flag.StringVar(&metricsCertKey, "metrics-cert-key", "tls.key", "The name of the metrics server key file.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.BoolVar(&enforceClusterBindings, "enforce-cluster-bindings", false,
+		"If set, resources can only target clusters of other namespaces allowed by an ElasticClusterBinding")
+	flag.BoolVar(&auditMode, "audit-mode", false,
+		"If set, the controllers run read-only: they report the changes and drift they find, but never write to the clusters.")
+	flag.DurationVar(&elasticsearchRequestTimeout, "elasticsearch-request-timeout", 10*time.Second,
+		"The default time to wait for the response headers of the requests to the clusters.")
+	flag.DurationVar(&elasticsearchDialTimeout, "elasticsearch-dial-timeout", 30*time.Second,
+		"The default time to wait for the connections to the clusters to be established.")
+	flag.IntVar(&elasticsearchMaxRetries, "elasticsearch-max-retries", 3,
+		"The number of retries, with exponential backoff honoring Retry-After, of the requests to the clusters "+
+			"answered with 429 or 503. Use 0 to disable the retries.")
+	flag.Float64Var(&elasticsearchRequestsPerSecond, "elasticsearch-requests-per-second", 0,
+		"The maximum rate of the requests sent to every cluster, shared by all the controllers. Use 0 to disable the limit.")
+	flag.IntVar(&elasticsearchRequestsBurst, "elasticsearch-requests-burst", 0,
+		"The number of requests sent to a cluster in a burst above its rate limit. Defaults to the requests per second.")
+	flag.IntVar(&elasticsearchMaxIdleConnsPerHost, "elasticsearch-max-idle-conns-per-host", http.DefaultMaxIdleConnsPerHost,
+		"The maximum number of idle TCP connections kept open to every node of the clusters.")
+	flag.DurationVar(&elasticsearchKeepAlive, "elasticsearch-keep-alive", 30*time.Second,
+		"The interval between TCP keep-alive probes of the connections to the clusters. Use a negative value to disable them.")
+	flag.DurationVar(&elasticsearchIdleConnTimeout, "elasticsearch-idle-conn-timeout", 10*time.Second,
+		"The maximum time an idle TCP connection to the clusters is kept open before closing it. Use 0 for no limit.")
+	flag.DurationVar(&elasticsearchTLSHandshakeTimeout, "elasticsearch-tls-handshake-timeout", 10*time.Second,
+		"The maximum time to wait for the TLS handshake with the clusters. Use 0 for no limit.")
+	flag.BoolVar(&elasticsearchEnableHTTP2, "elasticsearch-enable-http2", false,
+		"If set, HTTP/2 is negotiated with the clusters (or the proxies in front of them) supporting it.")
+	flag.DurationVar(&connectionTTL, "connection-ttl", time.Hour,
+		"The maximum age of the pooled cluster connections before they are re-created. Use 0 to disable it.")
+	flag.DurationVar(&connectionIdleTimeout, "connection-idle-timeout", 30*time.Minute,
+		"The maximum time a pooled cluster connection can stay unused before it is removed. Use 0 to disable it.")
+	flag.DurationVar(&connectionHealthCheckInterval, "connection-health-check-interval", time.Minute,
+		"How often the pooled cluster connections are checked, removing the unreachable or unauthorized ones. "+
+			"Use 0 to disable the checks.")
+	flag.IntVar(&connectionPoolMaxSize, "connection-pool-max-size", 0,
+		"The maximum number of pooled connections of each pool, evicting the least recently used one when exceeded. "+
+			"Use 0 for an unlimited pool.")
+	flag.DurationVar(&errorBackoffBase, "error-backoff-base", time.Second,
+		"The wait before retrying a failed reconcile, doubled on every consecutive failure of the resource.")
+	flag.DurationVar(&errorBackoffMax, "error-backoff-max", 5*time.Minute,
+		"The maximum wait before retrying a failed reconcile. Healthy resources are still synced at their syncInterval.")
+	flag.DurationVar(&minSyncInterval, "min-sync-interval", 5*time.Second,
+		"The shortest interval between the periodic syncs of a resource. Shorter syncIntervals are raised to it.")
+	flag.Float64Var(&syncIntervalJitter, "sync-interval-jitter", 0.1,
+		"The largest fraction of the syncInterval added at random to every periodic sync (e.g., 0.1 for up to 10%), "+
+			"so resources sharing a syncInterval don't sync in lockstep. Use 0 to disable it.")
+	flag.IntVar(&deletionMaxAttempts, "deletion-max-attempts", 10,
+		"The number of failed cleanups of a deleted resource before giving up, leaving its objects in the cluster "+
+			"and removing its finalizer. Use 0 to retry them forever.")
+	flag.StringVar(&forbiddenClusterSettings, "forbidden-cluster-settings", "",
+		"Comma-separated patterns of the cluster settings the ClusterSettings resources are not allowed to manage "+
+			"(e.g., cluster.blocks.*,discovery). A pattern also matches the settings under it.")
+	flag.StringVar(&allowedClusterSettings, "allowed-cluster-settings", "",
+		"Comma-separated patterns of the only cluster settings the ClusterSettings resources are allowed to manage. "+
+			"Leave empty to allow all the settings not forbidden.")
+	flag.IntVar(&maxObjectsPerNamespace, "max-objects-per-namespace", 0,
+		"The largest number of index templates, lifecycle policies, snapshot lifecycle policies and cluster settings "+
+			"the resources of a namespace may manage on each cluster. Use 0 for unlimited.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -117,6 +228,7 @@ func main() {
 	// Initial webhook TLS options
 	webhookTLSOpts := tlsOpts
 	webhookServerOptions := webhook.Options{
+		Port:    webhookPort,
 		TLSOpts: webhookTLSOpts,
 	}
 
@@ -197,11 +309,65 @@ func main() {
 		setupLog.Error(err, "unable to set up kubernetes clients")
 		os.Exit(1)
 	}
+	globals.Application.EnforceClusterBindings = enforceClusterBindings
+	globals.Application.AuditMode = auditMode
+	globals.Application.ElasticsearchRequestTimeout = elasticsearchRequestTimeout
+	globals.Application.ElasticsearchDialTimeout = elasticsearchDialTimeout
+	globals.Application.ElasticsearchMaxRetries = elasticsearchMaxRetries
+	globals.Application.ElasticsearchRequestsPerSecond = elasticsearchRequestsPerSecond
+	globals.Application.ElasticsearchRequestsBurst = elasticsearchRequestsBurst
+	globals.Application.ElasticsearchMaxIdleConnsPerHost = elasticsearchMaxIdleConnsPerHost
+	globals.Application.ElasticsearchKeepAlive = elasticsearchKeepAlive
+	globals.Application.ElasticsearchIdleConnTimeout = elasticsearchIdleConnTimeout
+	globals.Application.ElasticsearchTLSHandshakeTimeout = elasticsearchTLSHandshakeTimeout
+	globals.Application.ElasticsearchEnableHTTP2 = elasticsearchEnableHTTP2
+	globals.Application.ErrorBackoffBase = errorBackoffBase
+	globals.Application.ErrorBackoffMax = errorBackoffMax
+	globals.Application.MinSyncInterval = minSyncInterval
+	if syncIntervalJitter < 0 || syncIntervalJitter > 1 {
+		setupLog.Error(fmt.Errorf("%v is not between 0 and 1", syncIntervalJitter), "invalid sync interval jitter")
+		os.Exit(1)
+	}
+	globals.Application.SyncIntervalJitter = syncIntervalJitter
+	globals.Application.DeletionMaxAttempts = deletionMaxAttempts
+
+	globals.Application.ForbiddenClusterSettings, err = globals.ParseClusterSettingPatterns(forbiddenClusterSettings)
+	if err != nil {
+		setupLog.Error(err, "invalid forbidden cluster settings")
+		os.Exit(1)
+	}
+	globals.Application.AllowedClusterSettings, err = globals.ParseClusterSettingPatterns(allowedClusterSettings)
+	if err != nil {
+		setupLog.Error(err, "invalid allowed cluster settings")
+		os.Exit(1)
+	}
+	if maxObjectsPerNamespace < 0 {
+		setupLog.Error(fmt.Errorf("%d is negative", maxObjectsPerNamespace), "invalid max objects per namespace")
+		os.Exit(1)
+	}
+	globals.Application.MaxObjectsPerNamespace = maxObjectsPerNamespace
+
+	// Expired connections of the pools are evicted every minute, and unhealthy ones on every health check
+	ElasticsearchConnectionsPool.TTL, ElasticsearchConnectionsPool.IdleTimeout = connectionTTL, connectionIdleTimeout
+	KibanaConnectionsPool.TTL, KibanaConnectionsPool.IdleTimeout = connectionTTL, connectionIdleTimeout
+	ElasticsearchConnectionsPool.MaxSize, KibanaConnectionsPool.MaxSize = connectionPoolMaxSize, connectionPoolMaxSize
+	pools.RegisterMetrics(ElasticsearchConnectionsPool, KibanaConnectionsPool)
+	globals.RegisterMetrics()
+	if err := mgr.Add(&pools.ConnectionsEvictor{
+		ElasticsearchConnectionsPool: ElasticsearchConnectionsPool,
+		KibanaConnectionsPool:        KibanaConnectionsPool,
+		Interval:                     time.Minute,
+		HealthCheckInterval:          connectionHealthCheckInterval,
+	}); err != nil {
+		setupLog.Error(err, "unable to set up the connections evictor")
+		os.Exit(1)
+	}
 
 	if err := (&indexlifecyclepolicy.IndexLifecyclePolicyReconciler{
 		Client:                       mgr.GetClient(),
 		Scheme:                       mgr.GetScheme(),
 		ElasticsearchConnectionsPool: ElasticsearchConnectionsPool,
+		Recorder:                     mgr.GetEventRecorderFor("indexlifecyclepolicy-controller"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "IndexLifecyclePolicy")
 		os.Exit(1)
@@ -210,6 +376,7 @@ func main() {
 		Client:                       mgr.GetClient(),
 		Scheme:                       mgr.GetScheme(),
 		ElasticsearchConnectionsPool: ElasticsearchConnectionsPool,
+		Recorder:                     mgr.GetEventRecorderFor("indextemplate-controller"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "IndexTemplate")
 		os.Exit(1)
@@ -218,6 +385,7 @@ func main() {
 		Client:                       mgr.GetClient(),
 		Scheme:                       mgr.GetScheme(),
 		ElasticsearchConnectionsPool: ElasticsearchConnectionsPool,
+		Recorder:                     mgr.GetEventRecorderFor("snapshotrepository-controller"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "SnapshotRepository")
 		os.Exit(1)
@@ -226,6 +394,7 @@ func main() {
 		Client:                       mgr.GetClient(),
 		Scheme:                       mgr.GetScheme(),
 		ElasticsearchConnectionsPool: ElasticsearchConnectionsPool,
+		Recorder:                     mgr.GetEventRecorderFor("snapshotlifecyclepolicy-controller"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "SnapshotLifecyclePolicy")
 		os.Exit(1)
@@ -234,6 +403,7 @@ func main() {
 		Client:                       mgr.GetClient(),
 		Scheme:                       mgr.GetScheme(),
 		ElasticsearchConnectionsPool: ElasticsearchConnectionsPool,
+		Recorder:                     mgr.GetEventRecorderFor("clustersettings-controller"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ClusterSettings")
 		os.Exit(1)
@@ -242,10 +412,230 @@ func main() {
 		Client:                       mgr.GetClient(),
 		Scheme:                       mgr.GetScheme(),
 		ElasticsearchConnectionsPool: ElasticsearchConnectionsPool,
+		Recorder:                     mgr.GetEventRecorderFor("indexstatemanagement-controller"),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "IndexStateManagement")
 		os.Exit(1)
 	}
+	if err := (&opensearchalertingmonitor.OpenSearchAlertingMonitorReconciler{
+		Client:                       mgr.GetClient(),
+		Scheme:                       mgr.GetScheme(),
+		ElasticsearchConnectionsPool: ElasticsearchConnectionsPool,
+		Recorder:                     mgr.GetEventRecorderFor("opensearchalertingmonitor-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "OpenSearchAlertingMonitor")
+		os.Exit(1)
+	}
+	if err := (&opensearchnotificationchannel.OpenSearchNotificationChannelReconciler{
+		Client:                       mgr.GetClient(),
+		Scheme:                       mgr.GetScheme(),
+		ElasticsearchConnectionsPool: ElasticsearchConnectionsPool,
+		Recorder:                     mgr.GetEventRecorderFor("opensearchnotificationchannel-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "OpenSearchNotificationChannel")
+		os.Exit(1)
+	}
+	if err := (&opensearchanomalydetector.OpenSearchAnomalyDetectorReconciler{
+		Client:                       mgr.GetClient(),
+		Scheme:                       mgr.GetScheme(),
+		ElasticsearchConnectionsPool: ElasticsearchConnectionsPool,
+		Recorder:                     mgr.GetEventRecorderFor("opensearchanomalydetector-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "OpenSearchAnomalyDetector")
+		os.Exit(1)
+	}
+	if err := (&kibanasavedobjects.KibanaSavedObjectsReconciler{
+		Client:                mgr.GetClient(),
+		Scheme:                mgr.GetScheme(),
+		KibanaConnectionsPool: KibanaConnectionsPool,
+		Recorder:              mgr.GetEventRecorderFor("kibanasavedobjects-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "KibanaSavedObjects")
+		os.Exit(1)
+	}
+	if err := (&kibanaspace.KibanaSpaceReconciler{
+		Client:                mgr.GetClient(),
+		Scheme:                mgr.GetScheme(),
+		KibanaConnectionsPool: KibanaConnectionsPool,
+		Recorder:              mgr.GetEventRecorderFor("kibanaspace-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "KibanaSpace")
+		os.Exit(1)
+	}
+	if err := (&kibanaalertrule.KibanaAlertRuleReconciler{
+		Client:                mgr.GetClient(),
+		Scheme:                mgr.GetScheme(),
+		KibanaConnectionsPool: KibanaConnectionsPool,
+		Recorder:              mgr.GetEventRecorderFor("kibanaalertrule-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "KibanaAlertRule")
+		os.Exit(1)
+	}
+	if err := (&synonymsset.SynonymsSetReconciler{
+		Client:                       mgr.GetClient(),
+		Scheme:                       mgr.GetScheme(),
+		ElasticsearchConnectionsPool: ElasticsearchConnectionsPool,
+		Recorder:                     mgr.GetEventRecorderFor("synonymsset-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "SynonymsSet")
+		os.Exit(1)
+	}
+	if err := (&queryruleset.QueryRulesetReconciler{
+		Client:                       mgr.GetClient(),
+		Scheme:                       mgr.GetScheme(),
+		ElasticsearchConnectionsPool: ElasticsearchConnectionsPool,
+		Recorder:                     mgr.GetEventRecorderFor("queryruleset-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "QueryRuleset")
+		os.Exit(1)
+	}
+	if err := (&searchapplication.SearchApplicationReconciler{
+		Client:                       mgr.GetClient(),
+		Scheme:                       mgr.GetScheme(),
+		ElasticsearchConnectionsPool: ElasticsearchConnectionsPool,
+		Recorder:                     mgr.GetEventRecorderFor("searchapplication-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "SearchApplication")
+		os.Exit(1)
+	}
+	if err := (&autoscalingpolicy.AutoscalingPolicyReconciler{
+		Client:                       mgr.GetClient(),
+		Scheme:                       mgr.GetScheme(),
+		ElasticsearchConnectionsPool: ElasticsearchConnectionsPool,
+		Recorder:                     mgr.GetEventRecorderFor("autoscalingpolicy-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "AutoscalingPolicy")
+		os.Exit(1)
+	}
+	if err := (&elasticsearchrawresource.ElasticsearchRawResourceReconciler{
+		Client:                       mgr.GetClient(),
+		Scheme:                       mgr.GetScheme(),
+		ElasticsearchConnectionsPool: ElasticsearchConnectionsPool,
+		Recorder:                     mgr.GetEventRecorderFor("elasticsearchrawresource-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ElasticsearchRawResource")
+		os.Exit(1)
+	}
+	if err := (&clusterindextemplate.ClusterIndexTemplateReconciler{
+		Client:                       mgr.GetClient(),
+		Scheme:                       mgr.GetScheme(),
+		ElasticsearchConnectionsPool: ElasticsearchConnectionsPool,
+		Recorder:                     mgr.GetEventRecorderFor("clusterindextemplate-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ClusterIndexTemplate")
+		os.Exit(1)
+	}
+	if err := (&clusterindexlifecyclepolicy.ClusterIndexLifecyclePolicyReconciler{
+		Client:                       mgr.GetClient(),
+		Scheme:                       mgr.GetScheme(),
+		ElasticsearchConnectionsPool: ElasticsearchConnectionsPool,
+		Recorder:                     mgr.GetEventRecorderFor("clusterindexlifecyclepolicy-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ClusterIndexLifecyclePolicy")
+		os.Exit(1)
+	}
+	if err := (&elasticconfigbundle.ElasticConfigBundleReconciler{
+		Client:                       mgr.GetClient(),
+		Scheme:                       mgr.GetScheme(),
+		ElasticsearchConnectionsPool: ElasticsearchConnectionsPool,
+		Recorder:                     mgr.GetEventRecorderFor("elasticconfigbundle-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ElasticConfigBundle")
+		os.Exit(1)
+	}
+	if err := (&elasticsearchclusterconnection.ElasticsearchClusterConnectionReconciler{
+		Client:                       mgr.GetClient(),
+		Scheme:                       mgr.GetScheme(),
+		ElasticsearchConnectionsPool: ElasticsearchConnectionsPool,
+		Recorder:                     mgr.GetEventRecorderFor("elasticsearchclusterconnection-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ElasticsearchClusterConnection")
+		os.Exit(1)
+	}
+	if err := (&machinelearningjob.MachineLearningJobReconciler{
+		Client:                       mgr.GetClient(),
+		Scheme:                       mgr.GetScheme(),
+		ElasticsearchConnectionsPool: ElasticsearchConnectionsPool,
+		Recorder:                     mgr.GetEventRecorderFor("machinelearningjob-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "MachineLearningJob")
+		os.Exit(1)
+	}
+	if err := (&nodeshutdown.NodeShutdownReconciler{
+		Client:                       mgr.GetClient(),
+		Scheme:                       mgr.GetScheme(),
+		ElasticsearchConnectionsPool: ElasticsearchConnectionsPool,
+		Recorder:                     mgr.GetEventRecorderFor("nodeshutdown-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "NodeShutdown")
+		os.Exit(1)
+	}
+	if err := (&fleetagentpolicy.FleetAgentPolicyReconciler{
+		Client:                mgr.GetClient(),
+		Scheme:                mgr.GetScheme(),
+		KibanaConnectionsPool: KibanaConnectionsPool,
+		Recorder:              mgr.GetEventRecorderFor("fleetagentpolicy-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "FleetAgentPolicy")
+		os.Exit(1)
+	}
+	if err := (&opensearchdashboardssavedobjects.OpenSearchDashboardsSavedObjectsReconciler{
+		Client:                mgr.GetClient(),
+		Scheme:                mgr.GetScheme(),
+		KibanaConnectionsPool: KibanaConnectionsPool,
+		Recorder:              mgr.GetEventRecorderFor("opensearchdashboardssavedobjects-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "OpenSearchDashboardsSavedObjects")
+		os.Exit(1)
+	}
+	if err := (&applicationprivilege.ApplicationPrivilegeReconciler{
+		Client:                       mgr.GetClient(),
+		Scheme:                       mgr.GetScheme(),
+		ElasticsearchConnectionsPool: ElasticsearchConnectionsPool,
+		Recorder:                     mgr.GetEventRecorderFor("applicationprivilege-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ApplicationPrivilege")
+		os.Exit(1)
+	}
+	if err := (&indexlifecycle.IndexLifecycleReconciler{
+		Client:                       mgr.GetClient(),
+		Scheme:                       mgr.GetScheme(),
+		ElasticsearchConnectionsPool: ElasticsearchConnectionsPool,
+		Recorder:                     mgr.GetEventRecorderFor("indexlifecycle-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "IndexLifecycle")
+		os.Exit(1)
+	}
+	if err := (&connectionsecret.ConnectionSecretReconciler{
+		Client:                       mgr.GetClient(),
+		ElasticsearchConnectionsPool: ElasticsearchConnectionsPool,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ConnectionSecret")
+		os.Exit(1)
+	}
+	if err := (&connectionsecret.ConnectionConfigMapReconciler{
+		Client:                       mgr.GetClient(),
+		ElasticsearchConnectionsPool: ElasticsearchConnectionsPool,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ConnectionConfigMap")
+		os.Exit(1)
+	}
+	for _, kind := range multicluster.SupportedKinds {
+		if err := (&multicluster.MultiClusterReconciler{
+			Client: mgr.GetClient(),
+			Scheme: mgr.GetScheme(),
+			Kind:   kind,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", kind+"MultiCluster")
+			os.Exit(1)
+		}
+	}
+	if enableWebhooks {
+		if err := webhookv1alpha1.SetupWebhooksWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhooks")
+			os.Exit(1)
+		}
+	}
 	// +kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
@@ -0,0 +1,253 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package applicationprivilege
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/elastic/go-elasticsearch/v8"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/globals"
+)
+
+// Sync executes the synchronization of application privileges with Elasticsearch
+func (r *ApplicationPrivilegeReconciler) Sync(ctx context.Context, eventType watch.EventType, resource *v1alpha1.ApplicationPrivilege) (err error) {
+
+	logger := log.FromContext(ctx)
+
+	// The target cluster is resolved without changing the spec: the NamespaceDefaultCluster is used when the resource
+	// doesn't select one, and the namespace of the resource when the selector has none
+	resourceSelector, err := globals.ResolveResourceSelector(ctx, &resource.Spec.ResourceSelector, resource.Namespace)
+	if err != nil {
+		logger.Error(err, "Failed to resolve the target cluster")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	// Check the namespace of the resource is allowed to target the cluster
+	if err := globals.AuthorizeClusterAccess(ctx, resourceSelector, resource.Namespace, &resource.Status.Conditions); err != nil {
+		logger.Error(err, "Access to the target cluster denied")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	if err := globals.ValidateTLSVerification(resourceSelector, &resource.Status.Conditions); err != nil {
+		logger.Error(err, "TLS verification of the target cluster not configured")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	// Build the cluster key identifying the target cluster in the logs
+	clusterKey := fmt.Sprintf("%s_%s", resourceSelector.Namespace, resourceSelector.Name)
+
+	if eventType == watch.Deleted {
+		logger.Info(fmt.Sprintf("Deleting ApplicationPrivilege %s/%s", resource.Namespace, resource.Name))
+
+		// Get Elasticsearch connection to delete the privileges
+		esConnection, err := globals.GetOrCreateElasticsearchConnection(ctx, resourceSelector, resource.Namespace, r.ElasticsearchConnectionsPool)
+		if err != nil {
+			logger.Error(err, "Failed to get Elasticsearch connection for deletion")
+			return err
+		}
+
+		// Writes are serialized with the other resources targeting the same cluster
+		unlock, err := globals.LockCluster(ctx, esConnection.Endpoint)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+
+		// Delete each privilege from Elasticsearch
+		for _, privilegeKey := range resource.Status.AppliedResources {
+			logger.Info(fmt.Sprintf("Deleting privilege %s from Elasticsearch", privilegeKey))
+			if err := r.deletePrivilege(ctx, esConnection.Client, privilegeKey); err != nil {
+				logger.Error(err, fmt.Sprintf("Failed to delete privilege %s", privilegeKey))
+				return err
+			}
+			logger.Info(fmt.Sprintf("Privilege %s deleted successfully", privilegeKey))
+		}
+
+		return nil
+	}
+
+	logger.Info(fmt.Sprintf("Syncing ApplicationPrivilege %s/%s", resource.Namespace, resource.Name))
+
+	// Set status to Syncing at the beginning
+	r.SetSyncing(ctx, resource)
+
+	// Step 1: Get or create Elasticsearch connection
+	esConnection, err := globals.GetOrCreateElasticsearchConnection(ctx, resourceSelector, resource.Namespace, r.ElasticsearchConnectionsPool)
+	globals.UpdateClusterReachableCondition(&resource.Status.Conditions, err)
+	if err != nil {
+		logger.Error(err, "Failed to get or create Elasticsearch connection")
+		if errors.Is(err, globals.ErrClusterNotReady) {
+			r.SetWaitingForCluster(ctx, resource, err)
+			return err
+		}
+		r.SetError(ctx, resource, fmt.Errorf("%w to Elasticsearch: %w", globals.ErrConnectionFailed, err))
+		return err
+	}
+
+	// Writes are serialized with the other resources targeting the same cluster
+	unlock, err := globals.LockCluster(ctx, esConnection.Endpoint)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	logger.Info(fmt.Sprintf("Elasticsearch connection established for cluster %s (type: %s, version: %s)", clusterKey, esConnection.ClusterType, esConnection.Version))
+
+	// Validate cluster type - application privileges are only available in Elasticsearch
+	if esConnection.ClusterType == "opensearch" {
+		err := fmt.Errorf("application privileges are not available in OpenSearch")
+		logger.Error(err, "Incompatible cluster type for ApplicationPrivilege")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	// Step 2: Build the list of desired privileges from Spec
+	// Format: "application/privilege"
+	desiredPrivileges := make(map[string]bool)
+	for application, privilegeSet := range resource.Spec.Resources {
+		for privilege := range privilegeSet.Privileges {
+			desiredPrivileges[fmt.Sprintf("%s/%s", application, privilege)] = true
+		}
+	}
+
+	// Step 3: Delete privileges that are no longer desired
+	for _, privilegeKey := range resource.Status.AppliedResources {
+		if !desiredPrivileges[privilegeKey] {
+			logger.Info(fmt.Sprintf("Privilege %s is no longer desired, deleting from Elasticsearch", privilegeKey))
+			if err := r.deletePrivilege(ctx, esConnection.Client, privilegeKey); err != nil {
+				logger.Error(err, fmt.Sprintf("Failed to delete privilege %s", privilegeKey))
+				r.SetError(ctx, resource, fmt.Errorf("failed to delete privilege %s: %w", privilegeKey, err))
+				return err
+			}
+			logger.Info(fmt.Sprintf("Privilege %s deleted successfully", privilegeKey))
+			globals.RecordPruned(r.Recorder, resource, privilegeKey)
+		}
+	}
+
+	// Step 4: Apply all desired privileges in a single request (PUT creates or replaces each privilege)
+	if len(desiredPrivileges) > 0 {
+		logger.Info(fmt.Sprintf("Applying %d privileges of %d applications", len(desiredPrivileges), len(resource.Spec.Resources)))
+		if err := r.applyPrivileges(ctx, esConnection.Client, resource.Spec.Resources); err != nil {
+			logger.Error(err, "Failed to apply privileges")
+			r.SetError(ctx, resource, fmt.Errorf("failed to apply privileges: %w", err))
+			return err
+		}
+		logger.Info(fmt.Sprintf("%d privileges applied successfully", len(desiredPrivileges)))
+	}
+
+	newAppliedPrivileges := make([]string, 0, len(desiredPrivileges))
+	for privilegeKey := range desiredPrivileges {
+		newAppliedPrivileges = append(newAppliedPrivileges, privilegeKey)
+	}
+
+	// Step 5: Update the Status with the new list of applied privileges
+	targetCluster := fmt.Sprintf("%s/%s", resourceSelector.Namespace, resourceSelector.Name)
+	r.SetReady(ctx, resource, targetCluster, newAppliedPrivileges)
+
+	logger.Info(fmt.Sprintf("ApplicationPrivilege %s/%s synced successfully", resource.Namespace, resource.Name))
+
+	return nil
+}
+
+// applyPrivileges creates or replaces the privileges of all the applications in Elasticsearch
+func (r *ApplicationPrivilegeReconciler) applyPrivileges(ctx context.Context, esClient *elasticsearch.Client, applications map[string]v1alpha1.ApplicationPrivilegeSet) error {
+	// The API expects the privileges grouped by application: {"app": {"privilege": {"actions": [...], "metadata": {...}}}}
+	body := make(map[string]map[string]interface{}, len(applications))
+	for application, privilegeSet := range applications {
+		body[application] = make(map[string]interface{}, len(privilegeSet.Privileges))
+		for privilege, definition := range privilegeSet.Privileges {
+			privilegeBody := map[string]interface{}{
+				"actions": definition.Actions,
+			}
+			if definition.Metadata != nil {
+				var metadata map[string]interface{}
+				if err := json.Unmarshal(definition.Metadata.Raw, &metadata); err != nil {
+					return fmt.Errorf("failed to unmarshal metadata of privilege %s/%s: %w", application, privilege, err)
+				}
+				privilegeBody["metadata"] = metadata
+			}
+			body[application][privilege] = privilegeBody
+		}
+	}
+
+	bodyJSON, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal privileges: %w", err)
+	}
+
+	res, err := esClient.Security.PutPrivileges(
+		bytes.NewReader(bodyJSON),
+		esClient.Security.PutPrivileges.WithContext(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to put privileges: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		bodyBytes, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("elasticsearch API error: %s - %s", res.Status(), string(bodyBytes))
+	}
+
+	return nil
+}
+
+// deletePrivilege deletes an application privilege from Elasticsearch. privilegeKey has the format "application/privilege"
+func (r *ApplicationPrivilegeReconciler) deletePrivilege(ctx context.Context, esClient *elasticsearch.Client, privilegeKey string) error {
+	logger := log.FromContext(ctx)
+
+	application, privilege, found := strings.Cut(privilegeKey, "/")
+	if !found {
+		return fmt.Errorf("invalid privilege key %s, expected application/privilege", privilegeKey)
+	}
+
+	res, err := esClient.Security.DeletePrivileges(
+		privilege,
+		application,
+		esClient.Security.DeletePrivileges.WithContext(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete privilege: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		// If the privilege doesn't exist (404), consider it already deleted
+		if res.StatusCode == http.StatusNotFound {
+			logger.Info(fmt.Sprintf("Privilege %s not found in Elasticsearch (already deleted)", privilegeKey))
+			return nil
+		}
+		bodyBytes, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("elasticsearch API error: %s - %s", res.Status(), string(bodyBytes))
+	}
+
+	return nil
+}
@@ -0,0 +1,210 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package autoscalingpolicy
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/elastic/go-elasticsearch/v8"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/globals"
+)
+
+// Sync executes the synchronization of autoscaling policies with Elasticsearch
+func (r *AutoscalingPolicyReconciler) Sync(ctx context.Context, eventType watch.EventType, resource *v1alpha1.AutoscalingPolicy) (err error) {
+
+	logger := log.FromContext(ctx)
+
+	// The target cluster is resolved without changing the spec: the NamespaceDefaultCluster is used when the resource
+	// doesn't select one, and the namespace of the resource when the selector has none
+	resourceSelector, err := globals.ResolveResourceSelector(ctx, &resource.Spec.ResourceSelector, resource.Namespace)
+	if err != nil {
+		logger.Error(err, "Failed to resolve the target cluster")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	// Check the namespace of the resource is allowed to target the cluster
+	if err := globals.AuthorizeClusterAccess(ctx, resourceSelector, resource.Namespace, &resource.Status.Conditions); err != nil {
+		logger.Error(err, "Access to the target cluster denied")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	if err := globals.ValidateTLSVerification(resourceSelector, &resource.Status.Conditions); err != nil {
+		logger.Error(err, "TLS verification of the target cluster not configured")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	// Build the cluster key identifying the target cluster in the logs
+	clusterKey := fmt.Sprintf("%s_%s", resourceSelector.Namespace, resourceSelector.Name)
+
+	if eventType == watch.Deleted {
+		logger.Info(fmt.Sprintf("Deleting AutoscalingPolicy %s/%s", resource.Namespace, resource.Name))
+
+		// Get Elasticsearch connection to delete the policies
+		esConnection, err := globals.GetOrCreateElasticsearchConnection(ctx, resourceSelector, resource.Namespace, r.ElasticsearchConnectionsPool)
+		if err != nil {
+			logger.Error(err, "Failed to get Elasticsearch connection for deletion")
+			return err
+		}
+
+		// Writes are serialized with the other resources targeting the same cluster
+		unlock, err := globals.LockCluster(ctx, esConnection.Endpoint)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+
+		// Delete each autoscaling policy from Elasticsearch
+		for _, policyName := range resource.Status.AppliedResources {
+			logger.Info(fmt.Sprintf("Deleting autoscaling policy %s from Elasticsearch", policyName))
+			if err := r.deleteAutoscalingPolicy(ctx, esConnection.Client, policyName); err != nil {
+				logger.Error(err, fmt.Sprintf("Failed to delete autoscaling policy %s", policyName))
+				return err
+			}
+			logger.Info(fmt.Sprintf("Autoscaling policy %s deleted successfully", policyName))
+		}
+
+		return nil
+	}
+
+	logger.Info(fmt.Sprintf("Syncing AutoscalingPolicy %s/%s", resource.Namespace, resource.Name))
+
+	// Set status to Syncing at the beginning
+	r.SetSyncing(ctx, resource)
+
+	// Step 1: Get or create Elasticsearch connection
+	esConnection, err := globals.GetOrCreateElasticsearchConnection(ctx, resourceSelector, resource.Namespace, r.ElasticsearchConnectionsPool)
+	globals.UpdateClusterReachableCondition(&resource.Status.Conditions, err)
+	if err != nil {
+		logger.Error(err, "Failed to get or create Elasticsearch connection")
+		if errors.Is(err, globals.ErrClusterNotReady) {
+			r.SetWaitingForCluster(ctx, resource, err)
+			return err
+		}
+		r.SetError(ctx, resource, fmt.Errorf("%w to Elasticsearch: %w", globals.ErrConnectionFailed, err))
+		return err
+	}
+
+	// Writes are serialized with the other resources targeting the same cluster
+	unlock, err := globals.LockCluster(ctx, esConnection.Endpoint)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	logger.Info(fmt.Sprintf("Elasticsearch connection established for cluster %s (type: %s, version: %s)", clusterKey, esConnection.ClusterType, esConnection.Version))
+
+	// Validate cluster type - autoscaling is only available in Elasticsearch
+	if esConnection.ClusterType == "opensearch" {
+		err := fmt.Errorf("autoscaling policies are not available in OpenSearch")
+		logger.Error(err, "Incompatible cluster type for AutoscalingPolicy")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	// Step 2: Delete policys that are no longer desired
+	for _, policyName := range resource.Status.AppliedResources {
+		if _, desired := resource.Spec.Resources[policyName]; !desired {
+			logger.Info(fmt.Sprintf("Autoscaling policy %s is no longer desired, deleting from Elasticsearch", policyName))
+			if err := r.deleteAutoscalingPolicy(ctx, esConnection.Client, policyName); err != nil {
+				logger.Error(err, fmt.Sprintf("Failed to delete autoscaling policy %s", policyName))
+				r.SetError(ctx, resource, fmt.Errorf("failed to delete autoscaling policy %s: %w", policyName, err))
+				return err
+			}
+			logger.Info(fmt.Sprintf("Autoscaling policy %s deleted successfully", policyName))
+			globals.RecordPruned(r.Recorder, resource, policyName)
+		}
+	}
+
+	// Step 3: Apply all desired policies (PUT replaces the whole policy, so it is idempotent)
+	newAppliedPolicies := make([]string, 0, len(resource.Spec.Resources))
+	for policyName, policyResource := range resource.Spec.Resources {
+		logger.Info(fmt.Sprintf("Applying autoscaling policy %s", policyName))
+		if err := r.applyAutoscalingPolicy(ctx, esConnection.Client, policyName, policyResource.Raw); err != nil {
+			logger.Error(err, fmt.Sprintf("Failed to apply autoscaling policy %s", policyName))
+			r.SetError(ctx, resource, fmt.Errorf("failed to apply autoscaling policy %s: %w", policyName, err))
+			return err
+		}
+		newAppliedPolicies = append(newAppliedPolicies, policyName)
+		logger.Info(fmt.Sprintf("Autoscaling policy %s applied successfully", policyName))
+	}
+
+	// Step 4: Update the Status with the new list of applied policies
+	targetCluster := fmt.Sprintf("%s/%s", resourceSelector.Namespace, resourceSelector.Name)
+	r.SetReady(ctx, resource, targetCluster, newAppliedPolicies)
+
+	logger.Info(fmt.Sprintf("AutoscalingPolicy %s/%s synced successfully", resource.Namespace, resource.Name))
+
+	return nil
+}
+
+// applyAutoscalingPolicy creates or replaces an autoscaling policy in Elasticsearch
+func (r *AutoscalingPolicyReconciler) applyAutoscalingPolicy(ctx context.Context, esClient *elasticsearch.Client, policyName string, policy []byte) error {
+	res, err := esClient.AutoscalingPutAutoscalingPolicy(
+		policyName,
+		bytes.NewReader(policy),
+		esClient.AutoscalingPutAutoscalingPolicy.WithContext(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to put autoscaling policy: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		bodyBytes, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("elasticsearch API error: %s - %s", res.Status(), string(bodyBytes))
+	}
+
+	return nil
+}
+
+// deleteAutoscalingPolicy deletes an autoscaling policy from Elasticsearch
+func (r *AutoscalingPolicyReconciler) deleteAutoscalingPolicy(ctx context.Context, esClient *elasticsearch.Client, policyName string) error {
+	logger := log.FromContext(ctx)
+
+	res, err := esClient.AutoscalingDeleteAutoscalingPolicy(
+		policyName,
+		esClient.AutoscalingDeleteAutoscalingPolicy.WithContext(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete autoscaling policy: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		// If the policy doesn't exist (404), consider it already deleted
+		if res.StatusCode == http.StatusNotFound {
+			logger.Info(fmt.Sprintf("Autoscaling policy %s not found in Elasticsearch (already deleted)", policyName))
+			return nil
+		}
+		bodyBytes, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("elasticsearch API error: %s - %s", res.Status(), string(bodyBytes))
+	}
+
+	return nil
+}
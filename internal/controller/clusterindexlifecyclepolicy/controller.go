@@ -0,0 +1,190 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterindexlifecyclepolicy
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/controller"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/globals"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/pools"
+)
+
+// ClusterIndexLifecyclePolicyReconciler reconciles a ClusterIndexLifecyclePolicy object
+type ClusterIndexLifecyclePolicyReconciler struct {
+	client.Client
+	Scheme                       *runtime.Scheme
+	ElasticsearchConnectionsPool *pools.ElasticsearchConnectionsStore
+	Recorder                     record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=elastic-config-operator.freepik.com,resources=clusterindexlifecyclepolicies,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=elastic-config-operator.freepik.com,resources=clusterindexlifecyclepolicies/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=elastic-config-operator.freepik.com,resources=clusterindexlifecyclepolicies/finalizers,verbs=update
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=elasticsearch.k8s.elastic.co,resources=elasticsearches,verbs=get;list;watch
+
+// Reconcile writes the ILM policies of a ClusterIndexLifecyclePolicy to the clusters it selects on every syncInterval,
+// and deletes them from the clusters it selects once the resource is deleted, unless its deletionPolicy retains them
+func (r *ClusterIndexLifecyclePolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
+	logger := logf.FromContext(ctx)
+
+	// 1. Get the content of the resource
+	clusterIndexLifecyclePolicyResource := &v1alpha1.ClusterIndexLifecyclePolicy{}
+	err = r.Get(ctx, req.NamespacedName, clusterIndexLifecyclePolicyResource)
+
+	// 2. Check existence on the cluster
+	if err != nil {
+
+		// 2.1 It does NOT exist: manage removal
+		if err = client.IgnoreNotFound(err); err == nil {
+			logger.Info(fmt.Sprintf(controller.ResourceNotFoundError, controller.ClusterIndexLifecyclePolicyResourceType, req.NamespacedName))
+			return result, err
+		}
+
+		// 2.2 Failed to get the resource, requeue the request
+		logger.Info(fmt.Sprintf(controller.ResourceSyncTimeRetrievalError, controller.ClusterIndexLifecyclePolicyResourceType, req.NamespacedName, err.Error()))
+		return result, err
+	}
+
+	// Suspended resources are not reconciled, even when deleted: the cluster and their status are left untouched until
+	// they are resumed
+	if clusterIndexLifecyclePolicyResource.Spec.Suspend {
+		logger.Info(fmt.Sprintf(controller.ResourceSuspendedMessage, controller.ClusterIndexLifecyclePolicyResourceType, req.NamespacedName))
+		return result, nil
+	}
+
+	// 3. Check if the ClusterIndexLifecyclePolicy instance is marked to be deleted
+	if !clusterIndexLifecyclePolicyResource.DeletionTimestamp.IsZero() {
+		if controllerutil.ContainsFinalizer(clusterIndexLifecyclePolicyResource, controller.ResourceFinalizer) {
+			original := clusterIndexLifecyclePolicyResource.DeepCopy()
+
+			// 3.1 Delete the resources associated with the ClusterIndexLifecyclePolicy, unless the deletion policy retains them
+			if clusterIndexLifecyclePolicyResource.Spec.DeletionPolicy == v1alpha1.DeletionPolicyRetain {
+				logger.Info(fmt.Sprintf(controller.ResourceRetainedMessage, controller.ClusterIndexLifecyclePolicyResourceType, req.NamespacedName))
+			} else if globals.TargetClusterGone(ctx, clusterIndexLifecyclePolicyResource.Spec.ResourceSelector, clusterIndexLifecyclePolicyResource.Namespace) {
+				logger.Info(fmt.Sprintf(controller.ResourceTargetGoneMessage, controller.ClusterIndexLifecyclePolicyResourceType, req.NamespacedName))
+			} else {
+				err = r.Sync(ctx, watch.Deleted, clusterIndexLifecyclePolicyResource)
+			}
+
+			// Failed cleanups are retried with backoff, keeping the finalizer and reporting why in the status, until they
+			// are given up
+			if globals.RetryCleanup(clusterIndexLifecyclePolicyResource, err) {
+				logger.Info(fmt.Sprintf(controller.ResourceCleanupError, controller.ClusterIndexLifecyclePolicyResourceType, req.NamespacedName, err.Error()))
+				if updateErr := globals.PatchStatus(ctx, r.Client, clusterIndexLifecyclePolicyResource, original); updateErr != nil {
+					logger.Info(fmt.Sprintf(controller.ResourceConditionUpdateError, controller.ClusterIndexLifecyclePolicyResourceType, req.NamespacedName, updateErr.Error()))
+				}
+				return result, err
+			}
+			if err != nil {
+				logger.Info(fmt.Sprintf(controller.ResourceCleanupGivenUpMessage, controller.ClusterIndexLifecyclePolicyResourceType, req.NamespacedName, err.Error()))
+			}
+
+			// Remove the finalizers on ClusterIndexLifecyclePolicy CR
+			err = globals.RemoveFinalizer(ctx, r.Client, clusterIndexLifecyclePolicyResource, controller.ResourceFinalizer)
+			if err != nil {
+				logger.Info(fmt.Sprintf(controller.ResourceFinalizersUpdateError, controller.ClusterIndexLifecyclePolicyResourceType, req.NamespacedName, err.Error()))
+			}
+		}
+
+		result = ctrl.Result{}
+		err = nil
+		return result, err
+	}
+
+	// 4. Add finalizer to the ClusterIndexLifecyclePolicy CR
+	if !controllerutil.ContainsFinalizer(clusterIndexLifecyclePolicyResource, controller.ResourceFinalizer) {
+		err = globals.AddFinalizer(ctx, r.Client, clusterIndexLifecyclePolicyResource, controller.ResourceFinalizer)
+		if err != nil {
+			return result, err
+		}
+	}
+
+	// 5. Update the status before the requeue, writing the changes made to it during the reconcile at once
+	original := clusterIndexLifecyclePolicyResource.DeepCopy()
+	defer func() {
+		clusterIndexLifecyclePolicyResource.Status.ObservedGeneration = clusterIndexLifecyclePolicyResource.Generation
+		// The sync error is kept as the result, so failed syncs are requeued with backoff
+		if updateErr := globals.PatchStatus(ctx, r.Client, clusterIndexLifecyclePolicyResource, original); updateErr != nil {
+			logger.Info(fmt.Sprintf(controller.ResourceConditionUpdateError, controller.ClusterIndexLifecyclePolicyResourceType, req.NamespacedName, updateErr.Error()))
+		}
+	}()
+
+	// 6. Schedule periodical request
+	RequeueTime, err := controller.RequeueInterval(clusterIndexLifecyclePolicyResource.Spec.SyncInterval)
+	if err != nil {
+		logger.Info(fmt.Sprintf(controller.ResourceSyncTimeRetrievalError, controller.ClusterIndexLifecyclePolicyResourceType, req.NamespacedName, err.Error()))
+		return result, err
+	}
+	result = ctrl.Result{
+		RequeueAfter: RequeueTime,
+	}
+
+	// 7. Sync the ILM policies
+	err = r.Sync(ctx, watch.Modified, clusterIndexLifecyclePolicyResource)
+	if err != nil {
+		// Failed syncs are requeued with the error backoff instead of the syncInterval
+		result = ctrl.Result{}
+		r.UpdateConditionKubernetesApiCallFailure(clusterIndexLifecyclePolicyResource)
+		logger.Info(fmt.Sprintf(controller.SyncTargetError, controller.ClusterIndexLifecyclePolicyResourceType, req.NamespacedName, err.Error()))
+		return result, err
+	}
+
+	// 8. Success, update the status
+	r.UpdateConditionSuccess(clusterIndexLifecyclePolicyResource)
+
+	return result, err
+
+}
+
+// SetupWithManager sets up the controller with the Manager. The ECK clusters and the Secrets referenced by the
+// resources are watched too, so the resources are synced as soon as their cluster becomes ready or their Secrets change
+func (r *ClusterIndexLifecyclePolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	controllerBuilder := ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.ClusterIndexLifecyclePolicy{}, builder.WithPredicates(predicate.GenerationChangedPredicate{}))
+
+	controllerBuilder = globals.WatchECKClusters(controllerBuilder, mgr, &v1alpha1.ClusterIndexLifecyclePolicyList{}, func(object, cluster client.Object) bool {
+		spec := object.(*v1alpha1.ClusterIndexLifecyclePolicy).Spec
+		return globals.SelectsECKCluster(spec.ResourceSelector, object.GetNamespace(), cluster) ||
+			globals.MatchesClusterSelector(spec.ClusterSelector, cluster)
+	})
+
+	controllerBuilder, err := globals.WatchReferencedSecrets(controllerBuilder, mgr, &v1alpha1.ClusterIndexLifecyclePolicy{}, &v1alpha1.ClusterIndexLifecyclePolicyList{}, func(object client.Object) []*v1alpha1.ResourceSelector {
+		return []*v1alpha1.ResourceSelector{object.(*v1alpha1.ClusterIndexLifecyclePolicy).Spec.ResourceSelector}
+	})
+	if err != nil {
+		return err
+	}
+
+	return controllerBuilder.
+		Named("clusterindexlifecyclepolicy").
+		WithOptions(globals.ControllerOptions()).
+		Complete(r)
+}
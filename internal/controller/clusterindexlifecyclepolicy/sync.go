@@ -0,0 +1,244 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterindexlifecyclepolicy
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/elastic/go-elasticsearch/v8"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/globals"
+)
+
+// Sync executes the synchronization of ILM policies with every target cluster
+func (r *ClusterIndexLifecyclePolicyReconciler) Sync(ctx context.Context, eventType watch.EventType, resource *v1alpha1.ClusterIndexLifecyclePolicy) (err error) {
+
+	logger := log.FromContext(ctx)
+
+	if eventType == watch.Deleted {
+		logger.Info(fmt.Sprintf("Deleting ClusterIndexLifecyclePolicy %s", resource.Name))
+
+		// Delete the ILM policies from every cluster they were applied to
+		var deleteErrors []error
+		for _, targetCluster := range resource.Status.TargetClusters {
+			if err := r.deleteFromCluster(ctx, targetCluster, resource.Status.AppliedResources); err != nil {
+				logger.Error(err, fmt.Sprintf("Failed to delete ILM policies from cluster %s", targetCluster))
+				deleteErrors = append(deleteErrors, fmt.Errorf("cluster %s: %w", targetCluster, err))
+			}
+		}
+
+		return errors.Join(deleteErrors...)
+	}
+
+	logger.Info(fmt.Sprintf("Syncing ClusterIndexLifecyclePolicy %s", resource.Name))
+
+	// Set status to Syncing at the beginning
+	r.SetSyncing(ctx, resource)
+
+	// Step 1: Resolve the clusters targeted by the resource
+	targets, err := globals.ResolveTargetClusters(ctx, resource.Spec.ResourceSelector, resource.Spec.ClusterSelector)
+	if err != nil {
+		logger.Error(err, "Failed to resolve target clusters")
+		r.SetError(ctx, resource, fmt.Errorf("failed to resolve target clusters: %w", err))
+		return err
+	}
+
+	newTargetClusters := make([]string, 0, len(targets))
+	for _, target := range targets {
+		newTargetClusters = append(newTargetClusters, fmt.Sprintf("%s/%s", target.Namespace, target.Name))
+	}
+	logger.Info(fmt.Sprintf("ClusterIndexLifecyclePolicy %s targets %d clusters: %s", resource.Name, len(targets), strings.Join(newTargetClusters, ", ")))
+
+	// Step 2: Remove the ILM policies from clusters that are no longer targeted.
+	// Those clusters may be gone already, so failures are logged and ignored
+	for _, targetCluster := range resource.Status.TargetClusters {
+		if containsString(newTargetClusters, targetCluster) {
+			continue
+		}
+		logger.Info(fmt.Sprintf("Cluster %s is no longer targeted, deleting ILM policies from it", targetCluster))
+		if err := r.deleteFromCluster(ctx, targetCluster, resource.Status.AppliedResources); err != nil {
+			logger.Error(err, fmt.Sprintf("Failed to delete ILM policies from cluster %s, ignoring", targetCluster))
+		}
+	}
+
+	// Step 3: Sync the ILM policies with every target cluster
+	var syncErrors []error
+	for i := range targets {
+		if err := r.syncCluster(ctx, resource, &targets[i]); err != nil {
+			logger.Error(err, fmt.Sprintf("Failed to sync ILM policies with cluster %s", newTargetClusters[i]))
+			syncErrors = append(syncErrors, fmt.Errorf("cluster %s: %w", newTargetClusters[i], err))
+		}
+	}
+	if err := errors.Join(syncErrors...); err != nil {
+		if errors.Is(err, globals.ErrClusterNotReady) {
+			r.SetWaitingForCluster(ctx, resource, err)
+			return err
+		}
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	newAppliedResources := make([]string, 0, len(resource.Spec.Resources))
+	for resourceName := range resource.Spec.Resources {
+		newAppliedResources = append(newAppliedResources, resourceName)
+	}
+
+	// Step 4: Update the Status with the new list of target clusters and applied ILM policies
+	r.SetReady(ctx, resource, newTargetClusters, newAppliedResources)
+
+	logger.Info(fmt.Sprintf("ClusterIndexLifecyclePolicy %s synced successfully", resource.Name))
+
+	return nil
+}
+
+// syncCluster prunes the ILM policies removed from the spec and applies the desired ones on a single cluster
+func (r *ClusterIndexLifecyclePolicyReconciler) syncCluster(ctx context.Context, resource *v1alpha1.ClusterIndexLifecyclePolicy, target *v1alpha1.ResourceSelector) error {
+	logger := log.FromContext(ctx)
+
+	clusterKey := fmt.Sprintf("%s_%s", target.Namespace, target.Name)
+	esConnection, err := globals.GetOrCreateElasticsearchConnection(ctx, target, target.Namespace, r.ElasticsearchConnectionsPool)
+	if err != nil {
+		return fmt.Errorf("%w to Elasticsearch: %w", globals.ErrConnectionFailed, err)
+	}
+
+	// Writes are serialized with the other resources targeting the same cluster
+	unlock, err := globals.LockCluster(ctx, esConnection.Endpoint)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	logger.Info(fmt.Sprintf("Elasticsearch connection established for cluster %s (type: %s, version: %s)", clusterKey, esConnection.ClusterType, esConnection.Version))
+
+	// Validate cluster type - ILM is only available in Elasticsearch
+	if esConnection.ClusterType == "opensearch" {
+		return fmt.Errorf("ILM (Index Lifecycle Management) is not available in OpenSearch. OpenSearch uses ISM (Index State Management) instead")
+	}
+
+	// Delete ILM policies that are no longer desired
+	for _, resourceName := range resource.Status.AppliedResources {
+		if _, desired := resource.Spec.Resources[resourceName]; desired {
+			continue
+		}
+		logger.Info(fmt.Sprintf("ILM policy %s is no longer desired, deleting from cluster %s", resourceName, clusterKey))
+		if err := r.deleteILMPolicy(ctx, esConnection.Client, resourceName); err != nil {
+			return fmt.Errorf("failed to delete ILM policy %s: %w", resourceName, err)
+		}
+		globals.RecordPruned(r.Recorder, resource, fmt.Sprintf("%s in cluster %s", resourceName, clusterKey))
+	}
+
+	// Apply all desired ILM policies (idempotent)
+	for resourceName, resourceBody := range resource.Spec.Resources {
+		logger.Info(fmt.Sprintf("Applying ILM policy %s to cluster %s", resourceName, clusterKey))
+		if err := r.applyILMPolicy(ctx, esConnection.Client, resourceName, resourceBody.Raw); err != nil {
+			return fmt.Errorf("failed to apply ILM policy %s: %w", resourceName, err)
+		}
+	}
+
+	return nil
+}
+
+// deleteFromCluster deletes the given ILM policies from a cluster identified by namespace/name
+func (r *ClusterIndexLifecyclePolicyReconciler) deleteFromCluster(ctx context.Context, targetCluster string, resourceNames []string) error {
+	namespace, name, _ := strings.Cut(targetCluster, "/")
+	target := &v1alpha1.ResourceSelector{Name: name, Namespace: namespace}
+
+	esConnection, err := globals.GetOrCreateElasticsearchConnection(ctx, target, namespace, r.ElasticsearchConnectionsPool)
+	if err != nil {
+		return fmt.Errorf("%w to Elasticsearch: %w", globals.ErrConnectionFailed, err)
+	}
+
+	// Writes are serialized with the other resources targeting the same cluster
+	unlock, err := globals.LockCluster(ctx, esConnection.Endpoint)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	for _, resourceName := range resourceNames {
+		if err := r.deleteILMPolicy(ctx, esConnection.Client, resourceName); err != nil {
+			return fmt.Errorf("failed to delete ILM policy %s: %w", resourceName, err)
+		}
+	}
+
+	return nil
+}
+
+// applyILMPolicy creates or updates an ILM policy in Elasticsearch
+func (r *ClusterIndexLifecyclePolicyReconciler) applyILMPolicy(ctx context.Context, esClient *elasticsearch.Client, name string, body []byte) error {
+	res, err := esClient.ILM.PutLifecycle(
+		name,
+		esClient.ILM.PutLifecycle.WithBody(bytes.NewReader(body)),
+		esClient.ILM.PutLifecycle.WithContext(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to apply ILM policy: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		bodyBytes, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("elasticsearch API error: %s - %s", res.Status(), string(bodyBytes))
+	}
+
+	return nil
+}
+
+// deleteILMPolicy deletes an ILM policy from Elasticsearch
+func (r *ClusterIndexLifecyclePolicyReconciler) deleteILMPolicy(ctx context.Context, esClient *elasticsearch.Client, name string) error {
+	logger := log.FromContext(ctx)
+
+	res, err := esClient.ILM.DeleteLifecycle(
+		name,
+		esClient.ILM.DeleteLifecycle.WithContext(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete ILM policy: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		// If the ILM policy doesn't exist (404), consider it already deleted
+		if res.StatusCode == http.StatusNotFound {
+			logger.Info(fmt.Sprintf("ILM policy %s not found in Elasticsearch (already deleted)", name))
+			return nil
+		}
+		bodyBytes, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("elasticsearch API error: %s - %s", res.Status(), string(bodyBytes))
+	}
+
+	return nil
+}
+
+// containsString reports whether a slice contains the given value
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,263 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterindextemplate
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/elastic/go-elasticsearch/v8"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/globals"
+)
+
+// Sync executes the synchronization of index templates with every target cluster
+func (r *ClusterIndexTemplateReconciler) Sync(ctx context.Context, eventType watch.EventType, resource *v1alpha1.ClusterIndexTemplate) (err error) {
+
+	logger := log.FromContext(ctx)
+
+	if eventType == watch.Deleted {
+		logger.Info(fmt.Sprintf("Deleting ClusterIndexTemplate %s", resource.Name))
+
+		// Delete the index templates from every cluster they were applied to
+		var deleteErrors []error
+		for _, targetCluster := range resource.Status.TargetClusters {
+			if err := r.deleteFromCluster(ctx, targetCluster, resource.Status.AppliedResources); err != nil {
+				logger.Error(err, fmt.Sprintf("Failed to delete index templates from cluster %s", targetCluster))
+				deleteErrors = append(deleteErrors, fmt.Errorf("cluster %s: %w", targetCluster, err))
+			}
+		}
+
+		return errors.Join(deleteErrors...)
+	}
+
+	logger.Info(fmt.Sprintf("Syncing ClusterIndexTemplate %s", resource.Name))
+
+	// Set status to Syncing at the beginning
+	r.SetSyncing(ctx, resource)
+
+	// Step 1: Resolve the clusters targeted by the resource
+	targets, err := globals.ResolveTargetClusters(ctx, resource.Spec.ResourceSelector, resource.Spec.ClusterSelector)
+	if err != nil {
+		logger.Error(err, "Failed to resolve target clusters")
+		r.SetError(ctx, resource, fmt.Errorf("failed to resolve target clusters: %w", err))
+		return err
+	}
+
+	newTargetClusters := make([]string, 0, len(targets))
+	for _, target := range targets {
+		newTargetClusters = append(newTargetClusters, fmt.Sprintf("%s/%s", target.Namespace, target.Name))
+	}
+	logger.Info(fmt.Sprintf("ClusterIndexTemplate %s targets %d clusters: %s", resource.Name, len(targets), strings.Join(newTargetClusters, ", ")))
+
+	// Step 2: Remove the index templates from clusters that are no longer targeted.
+	// Those clusters may be gone already, so failures are logged and ignored
+	for _, targetCluster := range resource.Status.TargetClusters {
+		if containsString(newTargetClusters, targetCluster) {
+			continue
+		}
+		logger.Info(fmt.Sprintf("Cluster %s is no longer targeted, deleting index templates from it", targetCluster))
+		if err := r.deleteFromCluster(ctx, targetCluster, resource.Status.AppliedResources); err != nil {
+			logger.Error(err, fmt.Sprintf("Failed to delete index templates from cluster %s, ignoring", targetCluster))
+		}
+	}
+
+	// Step 3: Sync the index templates with every target cluster
+	var syncErrors []error
+	for i := range targets {
+		if err := r.syncCluster(ctx, resource, &targets[i]); err != nil {
+			logger.Error(err, fmt.Sprintf("Failed to sync index templates with cluster %s", newTargetClusters[i]))
+			syncErrors = append(syncErrors, fmt.Errorf("cluster %s: %w", newTargetClusters[i], err))
+		}
+	}
+	if err := errors.Join(syncErrors...); err != nil {
+		if errors.Is(err, globals.ErrClusterNotReady) {
+			r.SetWaitingForCluster(ctx, resource, err)
+			return err
+		}
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	newAppliedResources := make([]string, 0, len(resource.Spec.Resources))
+	for resourceName := range resource.Spec.Resources {
+		newAppliedResources = append(newAppliedResources, resourceName)
+	}
+
+	// Step 4: Update the Status with the new list of target clusters and applied index templates
+	r.SetReady(ctx, resource, newTargetClusters, newAppliedResources)
+
+	logger.Info(fmt.Sprintf("ClusterIndexTemplate %s synced successfully", resource.Name))
+
+	return nil
+}
+
+// syncCluster prunes the index templates removed from the spec and applies the desired ones on a single cluster
+func (r *ClusterIndexTemplateReconciler) syncCluster(ctx context.Context, resource *v1alpha1.ClusterIndexTemplate, target *v1alpha1.ResourceSelector) error {
+	logger := log.FromContext(ctx)
+
+	clusterKey := fmt.Sprintf("%s_%s", target.Namespace, target.Name)
+	esConnection, err := globals.GetOrCreateElasticsearchConnection(ctx, target, target.Namespace, r.ElasticsearchConnectionsPool)
+	if err != nil {
+		return fmt.Errorf("%w to Elasticsearch: %w", globals.ErrConnectionFailed, err)
+	}
+
+	// Writes are serialized with the other resources targeting the same cluster
+	unlock, err := globals.LockCluster(ctx, esConnection.Endpoint)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	logger.Info(fmt.Sprintf("Elasticsearch connection established for cluster %s (type: %s, version: %s)", clusterKey, esConnection.ClusterType, esConnection.Version))
+
+	// Delete index templates that are no longer desired
+	for _, resourceName := range resource.Status.AppliedResources {
+		if _, desired := resource.Spec.Resources[resourceName]; desired {
+			continue
+		}
+		logger.Info(fmt.Sprintf("Index template %s is no longer desired, deleting from cluster %s", resourceName, clusterKey))
+		if err := r.deleteIndexTemplate(ctx, esConnection.Client, resourceName); err != nil {
+			return fmt.Errorf("failed to delete index template %s: %w", resourceName, err)
+		}
+		globals.RecordPruned(r.Recorder, resource, fmt.Sprintf("%s in cluster %s", resourceName, clusterKey))
+	}
+
+	// Apply all desired index templates (idempotent)
+	for resourceName, resourceBody := range resource.Spec.Resources {
+		logger.Info(fmt.Sprintf("Applying index template %s to cluster %s", resourceName, clusterKey))
+		if err := r.simulateIndexTemplate(ctx, esConnection.Client, resourceName, resourceBody.Raw); err != nil {
+			return fmt.Errorf("index template %s failed the simulation: %w", resourceName, err)
+		}
+		if err := r.applyIndexTemplate(ctx, esConnection.Client, resourceName, resourceBody.Raw); err != nil {
+			return fmt.Errorf("failed to apply index template %s: %w", resourceName, err)
+		}
+	}
+
+	return nil
+}
+
+// deleteFromCluster deletes the given index templates from a cluster identified by namespace/name
+func (r *ClusterIndexTemplateReconciler) deleteFromCluster(ctx context.Context, targetCluster string, resourceNames []string) error {
+	namespace, name, _ := strings.Cut(targetCluster, "/")
+	target := &v1alpha1.ResourceSelector{Name: name, Namespace: namespace}
+
+	esConnection, err := globals.GetOrCreateElasticsearchConnection(ctx, target, namespace, r.ElasticsearchConnectionsPool)
+	if err != nil {
+		return fmt.Errorf("%w to Elasticsearch: %w", globals.ErrConnectionFailed, err)
+	}
+
+	// Writes are serialized with the other resources targeting the same cluster
+	unlock, err := globals.LockCluster(ctx, esConnection.Endpoint)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	for _, resourceName := range resourceNames {
+		if err := r.deleteIndexTemplate(ctx, esConnection.Client, resourceName); err != nil {
+			return fmt.Errorf("failed to delete index template %s: %w", resourceName, err)
+		}
+	}
+
+	return nil
+}
+
+// simulateIndexTemplate validates an index template with the simulate API, which composes it with its component
+// templates as if it replaced the template of the same name, without storing it
+func (r *ClusterIndexTemplateReconciler) simulateIndexTemplate(ctx context.Context, esClient *elasticsearch.Client, name string, body []byte) error {
+	res, err := esClient.Indices.SimulateTemplate(
+		esClient.Indices.SimulateTemplate.WithName(name),
+		esClient.Indices.SimulateTemplate.WithBody(bytes.NewReader(body)),
+		esClient.Indices.SimulateTemplate.WithContext(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to simulate index template: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		bodyBytes, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("invalid index template: %s - %s", res.Status(), string(bodyBytes))
+	}
+
+	return nil
+}
+
+// applyIndexTemplate creates or updates an index template in Elasticsearch
+func (r *ClusterIndexTemplateReconciler) applyIndexTemplate(ctx context.Context, esClient *elasticsearch.Client, name string, body []byte) error {
+	res, err := esClient.Indices.PutIndexTemplate(
+		name,
+		bytes.NewReader(body),
+		esClient.Indices.PutIndexTemplate.WithContext(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to apply index template: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		bodyBytes, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("elasticsearch API error: %s - %s", res.Status(), string(bodyBytes))
+	}
+
+	return nil
+}
+
+// deleteIndexTemplate deletes an index template from Elasticsearch
+func (r *ClusterIndexTemplateReconciler) deleteIndexTemplate(ctx context.Context, esClient *elasticsearch.Client, name string) error {
+	logger := log.FromContext(ctx)
+
+	res, err := esClient.Indices.DeleteIndexTemplate(
+		name,
+		esClient.Indices.DeleteIndexTemplate.WithContext(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete index template: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		// If the index template doesn't exist (404), consider it already deleted
+		if res.StatusCode == http.StatusNotFound {
+			logger.Info(fmt.Sprintf("Index template %s not found in Elasticsearch (already deleted)", name))
+			return nil
+		}
+		bodyBytes, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("elasticsearch API error: %s - %s", res.Status(), string(bodyBytes))
+	}
+
+	return nil
+}
+
+// containsString reports whether a slice contains the given value
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -19,11 +19,12 @@ package clustersettings
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -31,6 +32,7 @@ import (
 
 	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
 	"elastic-config-operator.freepik.com/elastic-config-operator/internal/controller"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/globals"
 	"elastic-config-operator.freepik.com/elastic-config-operator/internal/pools"
 )
 
@@ -39,6 +41,7 @@ type ClusterSettingsReconciler struct {
 	client.Client
 	Scheme                       *runtime.Scheme
 	ElasticsearchConnectionsPool *pools.ElasticsearchConnectionsStore
+	Recorder                     record.EventRecorder
 }
 
 // +kubebuilder:rbac:groups=elastic-config-operator.freepik.com,resources=clustersettings,verbs=get;list;watch;create;update;patch;delete
@@ -47,8 +50,8 @@ type ClusterSettingsReconciler struct {
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
 // +kubebuilder:rbac:groups=elasticsearch.k8s.elastic.co,resources=elasticsearches,verbs=get;list;watch
 
-// Reconcile is part of the main kubernetes reconciliation loop which aims to
-// move the current state of the cluster closer to the desired state.
+// Reconcile writes the cluster settings of a ClusterSettings to its cluster on every syncInterval, and deletes them
+// from the cluster once the resource is deleted, unless its deletionPolicy retains them
 func (r *ClusterSettingsReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
 	logger := logf.FromContext(ctx)
 
@@ -70,16 +73,52 @@ func (r *ClusterSettingsReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		return result, err
 	}
 
+	// Suspended resources are not reconciled, even when deleted: the cluster and their status are left untouched until
+	// they are resumed
+	if clusterSettingsResource.Spec.Suspend {
+		logger.Info(fmt.Sprintf(controller.ResourceSuspendedMessage, controller.ClusterSettingsResourceType, req.NamespacedName))
+		return result, nil
+	}
+
+	// Resources selecting their clusters by labels are synced through the resources created for every cluster
+	if len(clusterSettingsResource.Spec.ResourceSelector.MatchLabels) > 0 {
+		logger.Info(fmt.Sprintf(controller.ResourceSelectsClustersByLabelsMessage, controller.ClusterSettingsResourceType, req.NamespacedName))
+		return result, nil
+	}
+
 	// 3. Check if the ClusterSettings instance is marked to be deleted: indicated by the deletion timestamp being set
 	if !clusterSettingsResource.DeletionTimestamp.IsZero() {
 		if controllerutil.ContainsFinalizer(clusterSettingsResource, controller.ResourceFinalizer) {
+			original := clusterSettingsResource.DeepCopy()
+
+			// The objects of the resource are released from the quota of its namespace even when their cleanup is
+			// skipped or fails
+			globals.ReleaseNamespaceQuota(globals.ManagedByMarker(controller.ClusterSettingsResourceType, clusterSettingsResource))
+
+			// 3.1 Delete the resources associated with the ClusterSettings, unless the deletion policy retains them
+			if clusterSettingsResource.Spec.DeletionPolicy == v1alpha1.DeletionPolicyRetain {
+				logger.Info(fmt.Sprintf(controller.ResourceRetainedMessage, controller.ClusterSettingsResourceType, req.NamespacedName))
+			} else if globals.TargetClusterGone(ctx, &clusterSettingsResource.Spec.ResourceSelector, clusterSettingsResource.Namespace) {
+				logger.Info(fmt.Sprintf(controller.ResourceTargetGoneMessage, controller.ClusterSettingsResourceType, req.NamespacedName))
+			} else {
+				err = r.Sync(ctx, watch.Deleted, clusterSettingsResource)
+			}
 
-			// 3.1 Delete the resources associated with the ClusterSettings
-			err = r.Sync(ctx, watch.Deleted, clusterSettingsResource)
+			// Failed cleanups are retried with backoff, keeping the finalizer and reporting why in the status, until they
+			// are given up
+			if globals.RetryCleanup(clusterSettingsResource, err) {
+				logger.Info(fmt.Sprintf(controller.ResourceCleanupError, controller.ClusterSettingsResourceType, req.NamespacedName, err.Error()))
+				if updateErr := globals.PatchStatus(ctx, r.Client, clusterSettingsResource, original); updateErr != nil {
+					logger.Info(fmt.Sprintf(controller.ResourceConditionUpdateError, controller.ClusterSettingsResourceType, req.NamespacedName, updateErr.Error()))
+				}
+				return result, err
+			}
+			if err != nil {
+				logger.Info(fmt.Sprintf(controller.ResourceCleanupGivenUpMessage, controller.ClusterSettingsResourceType, req.NamespacedName, err.Error()))
+			}
 
 			// Remove the finalizers on ClusterSettings CR
-			controllerutil.RemoveFinalizer(clusterSettingsResource, controller.ResourceFinalizer)
-			err = r.Update(ctx, clusterSettingsResource)
+			err = globals.RemoveFinalizer(ctx, r.Client, clusterSettingsResource, controller.ResourceFinalizer)
 			if err != nil {
 				logger.Info(fmt.Sprintf(controller.ResourceFinalizersUpdateError, controller.ClusterSettingsResourceType, req.NamespacedName, err.Error()))
 			}
@@ -92,27 +131,24 @@ func (r *ClusterSettingsReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 
 	// 4. Add finalizer to the ClusterSettings CR
 	if !controllerutil.ContainsFinalizer(clusterSettingsResource, controller.ResourceFinalizer) {
-		controllerutil.AddFinalizer(clusterSettingsResource, controller.ResourceFinalizer)
-		err = r.Update(ctx, clusterSettingsResource)
+		err = globals.AddFinalizer(ctx, r.Client, clusterSettingsResource, controller.ResourceFinalizer)
 		if err != nil {
 			return result, err
 		}
 	}
 
-	// 5. Update the status before the requeue
+	// 5. Update the status before the requeue, writing the changes made to it during the reconcile at once
+	original := clusterSettingsResource.DeepCopy()
 	defer func() {
-		err = r.Status().Update(ctx, clusterSettingsResource)
-		if err != nil {
-			logger.Info(fmt.Sprintf(controller.ResourceConditionUpdateError, controller.ClusterSettingsResourceType, req.NamespacedName, err.Error()))
+		clusterSettingsResource.Status.ObservedGeneration = clusterSettingsResource.Generation
+		// The sync error is kept as the result, so failed syncs are requeued with backoff
+		if updateErr := globals.PatchStatus(ctx, r.Client, clusterSettingsResource, original); updateErr != nil {
+			logger.Info(fmt.Sprintf(controller.ResourceConditionUpdateError, controller.ClusterSettingsResourceType, req.NamespacedName, updateErr.Error()))
 		}
 	}()
 
 	// 6. Schedule periodical request
-	syncInterval := clusterSettingsResource.Spec.SyncInterval
-	if syncInterval == "" {
-		syncInterval = controller.DefaultSyncInterval
-	}
-	RequeueTime, err := time.ParseDuration(syncInterval)
+	RequeueTime, err := controller.RequeueInterval(clusterSettingsResource.Spec.SyncInterval)
 	if err != nil {
 		logger.Info(fmt.Sprintf(controller.ResourceSyncTimeRetrievalError, controller.ClusterSettingsResourceType, req.NamespacedName, err.Error()))
 		return result, err
@@ -124,6 +160,8 @@ func (r *ClusterSettingsReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 	// 7. Sync the cluster settings
 	err = r.Sync(ctx, watch.Modified, clusterSettingsResource)
 	if err != nil {
+		// Failed syncs are requeued with the error backoff instead of the syncInterval
+		result = ctrl.Result{}
 		r.UpdateConditionKubernetesApiCallFailure(clusterSettingsResource)
 		logger.Info(fmt.Sprintf(controller.SyncTargetError, controller.ClusterSettingsResourceType, req.NamespacedName, err.Error()))
 		return result, err
@@ -136,11 +174,25 @@ func (r *ClusterSettingsReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 
 }
 
-// SetupWithManager sets up the controller with the Manager.
+// SetupWithManager sets up the controller with the Manager. The ECK clusters and the Secrets referenced by the
+// resources are watched too, so the resources are synced as soon as their cluster becomes ready or their Secrets change
 func (r *ClusterSettingsReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&v1alpha1.ClusterSettings{}).
-		WithEventFilter(predicate.GenerationChangedPredicate{}).
+	controllerBuilder := ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.ClusterSettings{}, builder.WithPredicates(predicate.GenerationChangedPredicate{}))
+
+	controllerBuilder = globals.WatchECKClusters(controllerBuilder, mgr, &v1alpha1.ClusterSettingsList{}, func(object, cluster client.Object) bool {
+		return globals.SelectsECKCluster(&object.(*v1alpha1.ClusterSettings).Spec.ResourceSelector, object.GetNamespace(), cluster)
+	})
+
+	controllerBuilder, err := globals.WatchReferencedSecrets(controllerBuilder, mgr, &v1alpha1.ClusterSettings{}, &v1alpha1.ClusterSettingsList{}, func(object client.Object) []*v1alpha1.ResourceSelector {
+		return []*v1alpha1.ResourceSelector{&object.(*v1alpha1.ClusterSettings).Spec.ResourceSelector}
+	})
+	if err != nil {
+		return err
+	}
+
+	return controllerBuilder.
 		Named("clustersettings").
+		WithOptions(globals.ControllerOptions()).
 		Complete(r)
 }
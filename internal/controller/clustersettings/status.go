@@ -21,7 +21,6 @@ import (
 	"fmt"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
 	"elastic-config-operator.freepik.com/elastic-config-operator/internal/controller"
@@ -43,7 +42,7 @@ func (r *ClusterSettingsReconciler) UpdateConditionSuccess(clusterSettings *v1al
 func (r *ClusterSettingsReconciler) UpdateConditionKubernetesApiCallFailure(clusterSettings *v1alpha1.ClusterSettings) {
 
 	// Create the new condition with the failure status
-	condition := globals.NewCondition(globals.ConditionTypeResourceSynced, metav1.ConditionTrue,
+	condition := globals.NewCondition(globals.ConditionTypeResourceSynced, metav1.ConditionFalse,
 		globals.ConditionReasonKubernetesApiCallErrorType, globals.ConditionReasonKubernetesApiCallErrorMessage)
 
 	// Update the status of the ClusterSettings resource
@@ -52,28 +51,101 @@ func (r *ClusterSettingsReconciler) UpdateConditionKubernetesApiCallFailure(clus
 
 // SetSyncing updates the status to Syncing phase
 func (r *ClusterSettingsReconciler) SetSyncing(ctx context.Context, resource *v1alpha1.ClusterSettings) {
-	logger := log.FromContext(ctx)
+	if globals.IsPeriodicSync(resource.Status.Conditions, resource.Status.ObservedGeneration, resource.Generation) {
+		return
+	}
 	resource.Status.Phase = controller.PhaseSyncing
 	resource.Status.Message = "Synchronizing with Elasticsearch"
-	if err := r.Status().Update(ctx, resource); err != nil {
-		logger.Error(err, "Failed to update status to Syncing")
-	}
+	globals.SetSyncingConditions(&resource.Status.Conditions, resource.Status.ObservedGeneration, resource.Generation, resource.Status.Message)
+	resource.Status.ObservedGeneration = resource.Generation
 }
 
 // SetReady updates the status to Ready phase with applied resources
-func (r *ClusterSettingsReconciler) SetReady(ctx context.Context, resource *v1alpha1.ClusterSettings, targetCluster string, appliedResources []string) error {
+func (r *ClusterSettingsReconciler) SetReady(ctx context.Context, resource *v1alpha1.ClusterSettings, targetCluster string, appliedResources []string, resources map[string]v1alpha1.ResourceStatus) {
 	now := metav1.Now()
 	resource.Status.Phase = controller.PhaseReady
 	resource.Status.Message = fmt.Sprintf("Successfully synced %d cluster settings", len(appliedResources))
 	resource.Status.TargetCluster = targetCluster
 	resource.Status.AppliedResources = appliedResources
 	resource.Status.LastSyncTime = &now
-	return r.Status().Update(ctx, resource)
+	resource.Status.Resources = resources
+	resource.Status.AppliedCount, resource.Status.FailedCount = globals.CountResources(resources)
+	globals.DeletePendingChanges(controller.ClusterSettingsResourceType, resource.Namespace, resource.Name)
+	globals.RecordApplied(r.Recorder, resource, resource.Status.Conditions, resource.Status.Message)
+	globals.UpdateClusterBlockedCondition(&resource.Status.Conditions, nil)
+	globals.SetReadyConditions(&resource.Status.Conditions, globals.ConditionReasonTargetSynced, resource.Status.Message)
+	resource.Status.ObservedGeneration = resource.Generation
+}
+
+// SetDryRun updates the status to DryRun phase with the changes the synchronization would make, and the error of the
+// objects that could not be compared
+func (r *ClusterSettingsReconciler) SetDryRun(ctx context.Context, resource *v1alpha1.ClusterSettings, targetCluster string, changes []v1alpha1.ObjectChange, err error) {
+	now := metav1.Now()
+	resource.Status.Phase = controller.PhaseDryRun
+	resource.Status.Message = fmt.Sprintf("Dry run: %d pending changes", len(changes))
+	if err != nil {
+		resource.Status.Message = fmt.Sprintf("%s, %s", resource.Status.Message, err)
+	}
+	resource.Status.TargetCluster = targetCluster
+	resource.Status.Changes = changes
+	resource.Status.LastSyncTime = &now
+	globals.SetPendingChanges(controller.ClusterSettingsResourceType, resource.Namespace, resource.Name, len(changes))
+	globals.SetReadyConditions(&resource.Status.Conditions, globals.ConditionReasonDryRun, resource.Status.Message)
+	globals.RecordSyncError(r.Recorder, resource, err)
+	resource.Status.ObservedGeneration = resource.Generation
+}
+
+// SetDegraded updates the status to Degraded phase when some settings failed to sync, tracking the ones applied
+func (r *ClusterSettingsReconciler) SetDegraded(ctx context.Context, resource *v1alpha1.ClusterSettings, targetCluster string, appliedResources []string, resources map[string]v1alpha1.ResourceStatus, err error) {
+	now := metav1.Now()
+	resource.Status.Phase = controller.PhaseDegraded
+	resource.Status.AppliedCount, resource.Status.FailedCount = globals.CountResources(resources)
+	resource.Status.Message = fmt.Sprintf("Synced with errors, %d applied and %s", resource.Status.AppliedCount, err)
+	resource.Status.TargetCluster = targetCluster
+	resource.Status.AppliedResources = appliedResources
+	resource.Status.Resources = resources
+	resource.Status.LastSyncTime = &now
+	globals.UpdateClusterBlockedCondition(&resource.Status.Conditions, err)
+	globals.SetStalledConditions(&resource.Status.Conditions, globals.ConditionReasonPartiallySynced, resource.Status.Message)
+	globals.RecordSyncError(r.Recorder, resource, err)
+	resource.Status.ObservedGeneration = resource.Generation
 }
 
 // SetError updates the status to Error phase with error message
 func (r *ClusterSettingsReconciler) SetError(ctx context.Context, resource *v1alpha1.ClusterSettings, err error) {
 	resource.Status.Phase = controller.PhaseError
 	resource.Status.Message = err.Error()
-	_ = r.Status().Update(ctx, resource)
+	globals.UpdateClusterBlockedCondition(&resource.Status.Conditions, err)
+	globals.SetStalledConditions(&resource.Status.Conditions, globals.ConditionReasonSyncFailed, resource.Status.Message)
+	globals.RecordSyncError(r.Recorder, resource, err)
+	resource.Status.ObservedGeneration = resource.Generation
+}
+
+// SetWaitingForCluster updates the status to WaitingForCluster phase while the target cluster is not reachable yet
+func (r *ClusterSettingsReconciler) SetWaitingForCluster(ctx context.Context, resource *v1alpha1.ClusterSettings, err error) {
+	resource.Status.Phase = controller.PhaseWaitingForCluster
+	resource.Status.Message = err.Error()
+	globals.SetReconcilingConditions(&resource.Status.Conditions, globals.ConditionReasonWaitingForCluster, resource.Status.Message)
+	globals.RecordSyncError(r.Recorder, resource, err)
+	resource.Status.ObservedGeneration = resource.Generation
+}
+
+// SetWaitingForClusterHealth updates the status to WaitingForClusterHealth phase while the health of the target
+// cluster is below the required one
+func (r *ClusterSettingsReconciler) SetWaitingForClusterHealth(ctx context.Context, resource *v1alpha1.ClusterSettings, err error) {
+	resource.Status.Phase = controller.PhaseWaitingForClusterHealth
+	resource.Status.Message = err.Error()
+	globals.SetReconcilingConditions(&resource.Status.Conditions, globals.ConditionReasonWaitingForClusterHealth, resource.Status.Message)
+	globals.RecordSyncError(r.Recorder, resource, err)
+	resource.Status.ObservedGeneration = resource.Generation
+}
+
+// SetWaitingForDependencies updates the status to WaitingForDependencies phase while some resources it depends on
+// are missing or not Ready
+func (r *ClusterSettingsReconciler) SetWaitingForDependencies(ctx context.Context, resource *v1alpha1.ClusterSettings, err error) {
+	resource.Status.Phase = controller.PhaseWaitingForDependencies
+	resource.Status.Message = err.Error()
+	globals.SetReconcilingConditions(&resource.Status.Conditions, globals.ConditionReasonWaitingForDependencies, resource.Status.Message)
+	globals.RecordSyncError(r.Recorder, resource, err)
+	resource.Status.ObservedGeneration = resource.Generation
 }
@@ -20,15 +20,19 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"sort"
+	"strings"
 
 	"github.com/elastic/go-elasticsearch/v8"
 	"k8s.io/apimachinery/pkg/watch"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/controller"
 	"elastic-config-operator.freepik.com/elastic-config-operator/internal/globals"
 )
 
@@ -37,24 +41,59 @@ func (r *ClusterSettingsReconciler) Sync(ctx context.Context, eventType watch.Ev
 
 	logger := log.FromContext(ctx)
 
-	// Get the ECK cluster associated to the resource
-	if resource.Spec.ResourceSelector.Namespace == "" {
-		resource.Spec.ResourceSelector.Namespace = resource.Namespace
+	// Resources only report the changes they would make in dry run or audit mode
+	dryRun := globals.IsDryRun(resource.Spec.DryRun)
+
+	// The target cluster is resolved without changing the spec: the NamespaceDefaultCluster is used when the resource
+	// doesn't select one, and the namespace of the resource when the selector has none
+	resourceSelector, err := globals.ResolveResourceSelector(ctx, &resource.Spec.ResourceSelector, resource.Namespace)
+	if err != nil {
+		logger.Error(err, "Failed to resolve the target cluster")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	// Check the namespace of the resource is allowed to target the cluster
+	if err := globals.AuthorizeClusterAccess(ctx, resourceSelector, resource.Namespace, &resource.Status.Conditions); err != nil {
+		logger.Error(err, "Access to the target cluster denied")
+		r.SetError(ctx, resource, err)
+		return err
 	}
 
-	// Build the cluster key for the pools
-	clusterKey := fmt.Sprintf("%s_%s", resource.Spec.ResourceSelector.Namespace, resource.Spec.ResourceSelector.Name)
+	if err := globals.ValidateTLSVerification(resourceSelector, &resource.Status.Conditions); err != nil {
+		logger.Error(err, "TLS verification of the target cluster not configured")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	// Build the cluster key identifying the target cluster in the logs
+	clusterKey := fmt.Sprintf("%s_%s", resourceSelector.Namespace, resourceSelector.Name)
 
 	if eventType == watch.Deleted {
 		logger.Info(fmt.Sprintf("Deleting ClusterSettings %s/%s", resource.Namespace, resource.Name))
 
+		globals.DeletePendingChanges(controller.ClusterSettingsResourceType, resource.Namespace, resource.Name)
+
+		// Resources in dry run or audit mode never change Elasticsearch, not even when they are deleted
+		if dryRun {
+			logger.Info("Dry run enabled, leaving Elasticsearch unchanged")
+			return nil
+		}
+
 		// Get Elasticsearch connection to delete the settings
-		esConnection, err := globals.GetOrCreateElasticsearchConnection(ctx, clusterKey, &resource.Spec.ResourceSelector, resource.Namespace, r.ElasticsearchConnectionsPool)
+		esConnection, err := globals.GetOrCreateElasticsearchConnection(ctx, resourceSelector, resource.Namespace, r.ElasticsearchConnectionsPool)
 		if err != nil {
 			logger.Error(err, "Failed to get Elasticsearch connection for deletion")
 			return err
 		}
 
+		// Writes are serialized with the other resources targeting the same cluster
+		unlock, err := globals.LockCluster(ctx, esConnection.Endpoint)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+
 		// Reset individual cluster settings that were applied (from Status.AppliedResources)
 		// Format: "category.setting.path"
 		settingsToResetByCategory := make(map[string][]string)
@@ -89,17 +128,77 @@ func (r *ClusterSettingsReconciler) Sync(ctx context.Context, eventType watch.Ev
 
 	logger.Info(fmt.Sprintf("Syncing ClusterSettings %s/%s", resource.Namespace, resource.Name))
 
+	// Changes are postponed until the resources it depends on are Ready. Dry runs never change the cluster, so they
+	// are not gated
+	if !dryRun {
+		err = globals.CheckDependencies(ctx, resource.Spec.DependsOn, resource.Namespace)
+		globals.UpdateDependenciesReadyCondition(&resource.Status.Conditions, resource.Spec.DependsOn, err)
+		if err != nil {
+			logger.Error(err, "Failed to check the dependencies")
+			if errors.Is(err, globals.ErrDependenciesNotReady) {
+				r.SetWaitingForDependencies(ctx, resource, err)
+				return err
+			}
+			r.SetError(ctx, resource, err)
+			return err
+		}
+	}
+
+	// The ${key} references of the settings are replaced by the values of valuesFrom
+	settingsResources, err := globals.ExpandValues(ctx, resource.Spec.ValuesFrom, resource.Namespace, resource.Spec.Resources)
+	if err != nil {
+		logger.Error(err, "Failed to expand the values of valuesFrom")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
 	// Set status to Syncing at the beginning
 	r.SetSyncing(ctx, resource)
 
 	// Step 1: Get or create Elasticsearch connection
-	esConnection, err := globals.GetOrCreateElasticsearchConnection(ctx, clusterKey, &resource.Spec.ResourceSelector, resource.Namespace, r.ElasticsearchConnectionsPool)
+	esConnection, err := globals.GetOrCreateElasticsearchConnection(ctx, resourceSelector, resource.Namespace, r.ElasticsearchConnectionsPool)
+	globals.UpdateClusterReachableCondition(&resource.Status.Conditions, err)
 	if err != nil {
 		logger.Error(err, "Failed to get or create Elasticsearch connection")
-		r.SetError(ctx, resource, fmt.Errorf("failed to connect to Elasticsearch: %w", err))
+		if errors.Is(err, globals.ErrClusterNotReady) {
+			r.SetWaitingForCluster(ctx, resource, err)
+			return err
+		}
+		r.SetError(ctx, resource, fmt.Errorf("%w to Elasticsearch: %w", globals.ErrConnectionFailed, err))
 		return err
 	}
 
+	// The Go templates of the settings are rendered with the cluster they are synced to
+	settingsResources, err = globals.RenderTemplates(resource.Spec.Templating, settingsResources, globals.NewTemplateData(resource, resourceSelector, esConnection))
+	if err != nil {
+		logger.Error(err, "Failed to render the templates of the settings")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	// Changes are postponed while the cluster is below the required health (e.g., while it is red). Dry runs never
+	// change the cluster, so they are not gated
+	if !dryRun {
+		err = globals.CheckClusterHealth(ctx, esConnection.Client, resource.Spec.RequiredClusterHealth)
+		globals.UpdateClusterHealthyCondition(&resource.Status.Conditions, resource.Spec.RequiredClusterHealth, err)
+		if err != nil {
+			logger.Error(err, "Failed to check the cluster health")
+			if errors.Is(err, globals.ErrClusterUnhealthy) {
+				r.SetWaitingForClusterHealth(ctx, resource, err)
+				return err
+			}
+			r.SetError(ctx, resource, err)
+			return err
+		}
+	}
+
+	// Writes are serialized with the other resources targeting the same cluster
+	unlock, err := globals.LockCluster(ctx, esConnection.Endpoint)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	logger.Info(fmt.Sprintf("Elasticsearch connection established for cluster %s", clusterKey))
 
 	// Step 2: Get the list of individual settings currently applied (from Status)
@@ -113,7 +212,7 @@ func (r *ClusterSettingsReconciler) Sync(ctx context.Context, eventType watch.Ev
 	desiredSettings := make(map[string]bool)
 	desiredSettingsByCategory := make(map[string]map[string]interface{})
 
-	for category, settingsResource := range resource.Spec.Resources {
+	for category, settingsResource := range settingsResources {
 		var settings map[string]interface{}
 		settingsJSON, err := settingsResource.MarshalJSON()
 		if err != nil {
@@ -136,6 +235,36 @@ func (r *ClusterSettingsReconciler) Sync(ctx context.Context, eventType watch.Ev
 		}
 	}
 
+	// Resources managing settings forbidden by the operator configuration, or taking their namespace over its quota of
+	// objects on the cluster, are rejected as a whole, before changing anything, so a bad resource can't block or split
+	// the cluster
+	var settingKeys []string
+	for _, settings := range desiredSettingsByCategory {
+		for settingKey := range globals.FlattenSettings(settings) {
+			settingKeys = append(settingKeys, settingKey)
+		}
+	}
+	if err := globals.CheckClusterSettings(settingKeys); err != nil {
+		logger.Error(err, "Cluster settings rejected")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+	marker := globals.ManagedByMarker(controller.ClusterSettingsResourceType, resource)
+	if err := globals.CheckNamespaceQuota(esConnection.Endpoint, marker, resource, len(settingKeys), dryRun); err != nil {
+		logger.Error(err, "Cluster settings rejected")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	// Changes made to Elasticsearch by this synchronization, reported in events and in the status
+	var changes []v1alpha1.ObjectChange
+
+	// A failing category does not stop the synchronization of the other ones, its error is reported once all of them
+	// are synced
+	failed := make(map[string]error)
+	newAppliedSettings := make([]string, 0)
+	newResources := make(map[string]v1alpha1.ResourceStatus, len(desiredSettingsByCategory))
+
 	// Step 4: Reset individual settings that are no longer desired
 	settingsToReset := make(map[string][]string) // category -> []settingKeys
 	for appliedKey := range appliedSettings {
@@ -153,6 +282,12 @@ func (r *ClusterSettingsReconciler) Sync(ctx context.Context, eventType watch.Ev
 				category := appliedKey[:dotIndex]
 				settingKey := appliedKey[dotIndex+1:]
 				logger.Info(fmt.Sprintf("Setting %s is no longer desired, will reset it", appliedKey))
+				change := v1alpha1.ObjectChange{Name: appliedKey, Action: globals.ChangeActionDelete}
+				globals.RecordChange(r.Recorder, resource, change, dryRun)
+				changes = append(changes, change)
+				if dryRun {
+					continue
+				}
 				settingsToReset[category] = append(settingsToReset[category], settingKey)
 			}
 		}
@@ -162,22 +297,76 @@ func (r *ClusterSettingsReconciler) Sync(ctx context.Context, eventType watch.Ev
 	for category, settingKeys := range settingsToReset {
 		if err := r.resetClusterSettings(ctx, esConnection.Client, category, settingKeys); err != nil {
 			logger.Error(err, fmt.Sprintf("Failed to reset cluster settings for category %s", category))
-			r.SetError(ctx, resource, fmt.Errorf("failed to reset cluster settings: %w", err))
-			return err
+			failed[category] = fmt.Errorf("failed to reset settings: %w", err)
+
+			// Keep tracking the settings, so their reset is retried
+			for _, settingKey := range settingKeys {
+				newAppliedSettings = append(newAppliedSettings, fmt.Sprintf("%s.%s", category, settingKey))
+			}
+			continue
 		}
 		logger.Info(fmt.Sprintf("Reset %d settings in category %s", len(settingKeys), category))
+		for _, settingKey := range settingKeys {
+			globals.RecordPruned(r.Recorder, resource, fmt.Sprintf("%s.%s", category, settingKey))
+		}
 	}
 
-	// Step 5: Apply all desired cluster settings (idempotent)
-	newAppliedSettings := make([]string, 0)
+	// Step 5: Apply the categories of settings that changed in the spec or in Elasticsearch since they were last
+	// applied, detecting the out-of-band changes of the categories whose desired settings did not change
+	liveSettings, err := r.getClusterSettings(ctx, esConnection.Client)
+	if err != nil {
+		logger.Error(err, "Failed to get cluster settings")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	drifted := make(map[string][]string)
+	var appliedCategories []string
 	for category, settings := range desiredSettingsByCategory {
 		logger.Info(fmt.Sprintf("Processing cluster settings for category: %s", category))
 
+		// Elasticsearch returns the settings flattened
+		fields := globals.DiffFields(globals.FlattenSettings(settings), liveSettings[category])
+		paths := make([]string, 0, len(fields))
+		for i := range fields {
+			fields[i].Path = fmt.Sprintf("%s.%s", category, fields[i].Path)
+			paths = append(paths, fields[i].Path)
+		}
+
+		desiredHash := globals.HashJSON(settings)
+		if resource.Status.Resources[category].Hash == desiredHash {
+			if len(paths) == 0 {
+				logger.Info(fmt.Sprintf("Cluster settings for category %s are up to date, skipping", category))
+				for settingKey := range settings {
+					newAppliedSettings = append(newAppliedSettings, fmt.Sprintf("%s.%s", category, settingKey))
+				}
+				newResources[category] = resource.Status.Resources[category]
+				continue
+			}
+			logger.Info(fmt.Sprintf("Cluster settings of category %s were changed out of band: %s", category, strings.Join(paths, ", ")))
+			drifted[category] = paths
+		}
+
+		change := v1alpha1.ObjectChange{Name: category, Action: globals.ChangeActionUpdate, Fields: fields}
+		globals.RecordChange(r.Recorder, resource, change, dryRun)
+		changes = append(changes, change)
+		if dryRun {
+			continue
+		}
+
 		// Apply the cluster settings (PUT /_cluster/settings is idempotent)
 		if err := r.applyClusterSettings(ctx, esConnection.Client, category, settings); err != nil {
 			logger.Error(err, fmt.Sprintf("Failed to apply cluster settings for category %s", category))
-			r.SetError(ctx, resource, fmt.Errorf("failed to apply cluster settings for category %s: %w", category, err))
-			return err
+			failed[category] = err
+
+			// Keep tracking the settings applied by a previous synchronization, so they are reset once removed from
+			// the spec, without the hash of the category, so they are applied again
+			for settingKey := range settings {
+				if fullKey := fmt.Sprintf("%s.%s", category, settingKey); appliedSettings[fullKey] {
+					newAppliedSettings = append(newAppliedSettings, fullKey)
+				}
+			}
+			continue
 		}
 
 		// Track each individual setting applied
@@ -185,22 +374,140 @@ func (r *ClusterSettingsReconciler) Sync(ctx context.Context, eventType watch.Ev
 			fullKey := fmt.Sprintf("%s.%s", category, settingKey)
 			newAppliedSettings = append(newAppliedSettings, fullKey)
 		}
+		newResources[category] = globals.AppliedResourceStatus(desiredHash)
+		appliedCategories = append(appliedCategories, category)
 
 		logger.Info(fmt.Sprintf("Cluster settings for category %s applied successfully (%d settings)", category, len(settings)))
 	}
 
-	// Step 6: Update the Status with the new list of applied settings
-	targetCluster := fmt.Sprintf("%s/%s", resource.Spec.ResourceSelector.Namespace, resource.Spec.ResourceSelector.Name)
-	if err := r.SetReady(ctx, resource, targetCluster, newAppliedSettings); err != nil {
-		logger.Error(err, "Failed to update ClusterSettings status")
-		return err
+	// Step 6: Verify the settings stored by Elasticsearch once applied, as it archives the settings it does not know
+	// and may store other values than the desired ones
+	var verification map[string]v1alpha1.SettingStatus
+	if !dryRun {
+		if len(appliedCategories) > 0 {
+			liveSettings, err = r.getClusterSettings(ctx, esConnection.Client)
+			if err != nil {
+				logger.Error(err, "Failed to get cluster settings to verify them")
+				for _, category := range appliedCategories {
+					failed[category] = fmt.Errorf("failed to verify settings: %w", err)
+				}
+			}
+		}
+
+		verification = make(map[string]v1alpha1.SettingStatus)
+		for category, settings := range desiredSettingsByCategory {
+			if _, categoryFailed := failed[category]; categoryFailed {
+				continue
+			}
+
+			var unverified []string
+			for settingKey, status := range verifySettings(settings, liveSettings[category]) {
+				fullKey := fmt.Sprintf("%s.%s", category, settingKey)
+				verification[fullKey] = status
+				if status.State != v1alpha1.SettingStateVerified {
+					unverified = append(unverified, fmt.Sprintf("%s (%s)", fullKey, status.State))
+				}
+			}
+			if len(unverified) > 0 {
+				sort.Strings(unverified)
+				logger.Info(fmt.Sprintf("Cluster settings of category %s not stored as desired: %s", category, strings.Join(unverified, ", ")))
+				failed[category] = fmt.Errorf("settings not stored as desired: %s", strings.Join(unverified, ", "))
+			}
+		}
 	}
 
+	// Report the error of the failed categories in their status, without their hash, so they are applied again
+	for category, err := range failed {
+		newResources[category] = globals.FailedResourceStatus(resource.Status.Resources[category], err)
+	}
+	failedErr := globals.ResourceErrors(failed)
+
+	globals.RecordDrift(r.Recorder, resource, &resource.Status.Conditions, drifted, dryRun)
+
+	targetCluster := fmt.Sprintf("%s/%s", resourceSelector.Namespace, resourceSelector.Name)
+
+	// Resources in dry run or audit mode only report the changes the synchronization would make
+	if dryRun {
+		logger.Info(fmt.Sprintf("Dry run of ClusterSettings %s/%s found %d pending changes", resource.Namespace, resource.Name, len(changes)))
+		r.SetDryRun(ctx, resource, targetCluster, changes, failedErr)
+		return failedErr
+	}
+
+	// Keep the changes of the last synchronization that changed anything
+	if len(changes) > 0 {
+		resource.Status.Changes = changes
+	}
+	resource.Status.Settings = verification
+
+	if failedErr != nil {
+		logger.Error(failedErr, "Failed to sync some categories of cluster settings")
+		r.SetDegraded(ctx, resource, targetCluster, newAppliedSettings, newResources, failedErr)
+		return failedErr
+	}
+
+	// Step 7: Update the Status with the new list of applied settings
+	r.SetReady(ctx, resource, targetCluster, newAppliedSettings, newResources)
+
 	logger.Info(fmt.Sprintf("ClusterSettings %s/%s synced successfully", resource.Namespace, resource.Name))
 
 	return nil
 }
 
+// verifySettings returns the verification of the desired settings of a category against the flat settings stored
+// by Elasticsearch, keyed by flattened setting. Values are compared like in drift detection, and settings reset with
+// null are verified when they are not stored
+func verifySettings(settings map[string]interface{}, liveSettings map[string]interface{}) map[string]v1alpha1.SettingStatus {
+	verification := make(map[string]v1alpha1.SettingStatus)
+	for settingKey, value := range globals.FlattenSettings(settings) {
+		liveValue, stored := liveSettings[settingKey]
+		switch {
+		case !stored && value == nil:
+			verification[settingKey] = v1alpha1.SettingStatus{State: v1alpha1.SettingStateVerified}
+		case !stored && liveSettings["archived."+settingKey] != nil:
+			verification[settingKey] = v1alpha1.SettingStatus{State: v1alpha1.SettingStateArchived}
+		case !stored:
+			verification[settingKey] = v1alpha1.SettingStatus{State: v1alpha1.SettingStateMissing}
+		default:
+			fields := globals.DiffFields(value, liveValue)
+			if len(fields) == 0 {
+				verification[settingKey] = v1alpha1.SettingStatus{State: v1alpha1.SettingStateVerified}
+				continue
+			}
+			verification[settingKey] = v1alpha1.SettingStatus{State: v1alpha1.SettingStateMismatch, Live: fields[0].Live}
+		}
+	}
+	return verification
+}
+
+// getClusterSettings returns the flat persistent and transient settings stored in Elasticsearch, by category. The
+// defaults are not included, so only the settings set in the cluster are returned
+func (r *ClusterSettingsReconciler) getClusterSettings(ctx context.Context, esClient *elasticsearch.Client) (map[string]map[string]interface{}, error) {
+	res, err := esClient.Cluster.GetSettings(
+		esClient.Cluster.GetSettings.WithFlatSettings(true),
+		esClient.Cluster.GetSettings.WithIncludeDefaults(false),
+		esClient.Cluster.GetSettings.WithContext(ctx),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cluster settings: %w", err)
+	}
+	defer res.Body.Close()
+
+	bodyBytes, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if res.IsError() {
+		return nil, fmt.Errorf("elasticsearch API error: %s - %s", res.Status(), string(bodyBytes))
+	}
+
+	var settings map[string]map[string]interface{}
+	if err := json.Unmarshal(bodyBytes, &settings); err != nil {
+		return nil, fmt.Errorf("failed to parse cluster settings: %w", err)
+	}
+
+	return settings, nil
+}
+
 // applyClusterSettings creates or updates cluster settings in Elasticsearch
 func (r *ClusterSettingsReconciler) applyClusterSettings(ctx context.Context, esClient *elasticsearch.Client, category string, settings map[string]interface{}) error {
 	logger := log.FromContext(ctx)
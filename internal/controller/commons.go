@@ -3,12 +3,33 @@ package controller
 const (
 
 	// Resource types
-	IndexLifecyclePolicyResourceType    = "IndexLifecyclePolicy"
-	IndexTemplateResourceType           = "IndexTemplate"
-	SnapshotRepositoryResourceType      = "SnapshotRepository"
-	SnapshotLifecyclePolicyResourceType = "SnapshotLifecyclePolicy"
-	ClusterSettingsResourceType         = "ClusterSettings"
-	IndexStateManagementResourceType    = "IndexStateManagement"
+	IndexLifecyclePolicyResourceType             = "IndexLifecyclePolicy"
+	IndexTemplateResourceType                    = "IndexTemplate"
+	SnapshotRepositoryResourceType               = "SnapshotRepository"
+	SnapshotLifecyclePolicyResourceType          = "SnapshotLifecyclePolicy"
+	ClusterSettingsResourceType                  = "ClusterSettings"
+	IndexStateManagementResourceType             = "IndexStateManagement"
+	OpenSearchAlertingMonitorResourceType        = "OpenSearchAlertingMonitor"
+	OpenSearchNotificationChannelResourceType    = "OpenSearchNotificationChannel"
+	OpenSearchAnomalyDetectorResourceType        = "OpenSearchAnomalyDetector"
+	KibanaSavedObjectsResourceType               = "KibanaSavedObjects"
+	KibanaSpaceResourceType                      = "KibanaSpace"
+	KibanaAlertRuleResourceType                  = "KibanaAlertRule"
+	SynonymsSetResourceType                      = "SynonymsSet"
+	QueryRulesetResourceType                     = "QueryRuleset"
+	SearchApplicationResourceType                = "SearchApplication"
+	AutoscalingPolicyResourceType                = "AutoscalingPolicy"
+	ElasticsearchRawResourceResourceType         = "ElasticsearchRawResource"
+	ClusterIndexTemplateResourceType             = "ClusterIndexTemplate"
+	ClusterIndexLifecyclePolicyResourceType      = "ClusterIndexLifecyclePolicy"
+	ElasticConfigBundleResourceType              = "ElasticConfigBundle"
+	ElasticsearchClusterConnectionResourceType   = "ElasticsearchClusterConnection"
+	MachineLearningJobResourceType               = "MachineLearningJob"
+	NodeShutdownResourceType                     = "NodeShutdown"
+	FleetAgentPolicyResourceType                 = "FleetAgentPolicy"
+	OpenSearchDashboardsSavedObjectsResourceType = "OpenSearchDashboardsSavedObjects"
+	ApplicationPrivilegeResourceType             = "ApplicationPrivilege"
+	IndexLifecycleResourceType                   = "IndexLifecycle"
 
 	// Sync interval to check if the resources are up to date
 	DefaultSyncInterval = "1m"
@@ -19,12 +40,33 @@ const (
 	PhaseReady   = "Ready"
 	PhaseError   = "Error"
 
+	// PhaseWaitingForCluster is set while the target cluster is not reachable yet (e.g., a fresh ECK deployment)
+	PhaseWaitingForCluster = "WaitingForCluster"
+
+	// PhaseWaitingForClusterHealth is set while the target cluster is below the health required by the resource
+	PhaseWaitingForClusterHealth = "WaitingForClusterHealth"
+
+	// PhaseWaitingForDependencies is set while some resources the resource depends on are missing or not Ready
+	PhaseWaitingForDependencies = "WaitingForDependencies"
+
+	// PhaseDryRun is set on resources in dry run mode, which report the changes they would make without making them
+	PhaseDryRun = "DryRun"
+
+	// PhaseDegraded is set when some objects of a resource failed to sync, while the other ones were synced
+	PhaseDegraded = "Degraded"
+
 	// Error messages
 	ResourceNotFoundError                  = "%s '%s' resource not found. Ignoring since object must be deleted."
 	CanNotGetResourceError                 = "%s '%s' resource not found. Error: %v"
 	ResourceFinalizersUpdateError          = "Failed to update finalizer of %s '%s': %s"
 	ResourceConditionUpdateError           = "Failed to update the condition on %s '%s': %s"
 	ResourceSyncTimeRetrievalError         = "can not get synchronization time from the %s '%s': %s"
+	ResourceSuspendedMessage               = "%s '%s' is suspended, skipping its reconciliation"
+	ResourceSelectsClustersByLabelsMessage = "%s '%s' selects its clusters by labels, it is synced through the resources of every cluster"
+	ResourceRetainedMessage                = "%s '%s' has the Retain deletion policy, leaving its objects in the cluster"
+	ResourceTargetGoneMessage              = "%s '%s' targets a cluster that no longer exists, skipping the cleanup of its objects"
+	ResourceCleanupError                   = "Failed to clean up the objects of %s '%s', retrying: %s"
+	ResourceCleanupGivenUpMessage          = "Giving up the cleanup of %s '%s', leaving its objects in the cluster: %s"
 	SyncTargetError                        = "can not sync the target for the %s '%s': %s"
 	ValidatorNotFoundErrorMessage          = "validator %s not found"
 	ValidationFailedErrorMessage           = "validation failed: %s"
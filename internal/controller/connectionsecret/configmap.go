@@ -0,0 +1,79 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package connectionsecret
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/pools"
+)
+
+// ConnectionConfigMapReconciler evicts the pooled connections created from a ConfigMap when it changes, so renewed
+// CA certificates and moved endpoints are used on the next sync
+type ConnectionConfigMapReconciler struct {
+	client.Client
+	ElasticsearchConnectionsPool *pools.ElasticsearchConnectionsStore
+}
+
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
+
+// Reconcile evicts the connections created from the changed ConfigMap
+func (r *ConnectionConfigMapReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := logf.FromContext(ctx)
+
+	for _, key := range r.ElasticsearchConnectionsPool.EvictByConfigMap(req.Namespace, req.Name) {
+		logger.Info(fmt.Sprintf("ConfigMap %s changed, evicted Elasticsearch connection %s", req.NamespacedName, key))
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager. Only the metadata of the ConfigMaps is watched,
+// and only the changes of ConfigMaps referenced by pooled connections are reconciled
+func (r *ConnectionConfigMapReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	referenced := func(object client.Object) bool {
+		return r.ElasticsearchConnectionsPool.ReferencesConfigMap(object.GetNamespace(), object.GetName())
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&corev1.ConfigMap{}, builder.OnlyMetadata).
+		WithEventFilter(predicate.Funcs{
+			CreateFunc: func(event.CreateEvent) bool {
+				return false
+			},
+			UpdateFunc: func(e event.UpdateEvent) bool {
+				return e.ObjectOld.GetResourceVersion() != e.ObjectNew.GetResourceVersion() && referenced(e.ObjectNew)
+			},
+			DeleteFunc: func(e event.DeleteEvent) bool {
+				return referenced(e.Object)
+			},
+			GenericFunc: func(event.GenericEvent) bool {
+				return false
+			},
+		}).
+		Named("connectionconfigmap").
+		Complete(r)
+}
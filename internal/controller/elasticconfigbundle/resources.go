@@ -0,0 +1,159 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elasticconfigbundle
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
+)
+
+// applyResource creates or updates a single bundle resource in Elasticsearch.
+// For cluster settings, name is the settings category and body contains its settings
+func (r *ElasticConfigBundleReconciler) applyResource(ctx context.Context, esClient *elasticsearch.Client, kind v1alpha1.BundleResourceKind, name string, body []byte) error {
+	var res *esapi.Response
+	var err error
+
+	switch kind {
+	case v1alpha1.BundleKindClusterSettings:
+		// Build the request body: { "category": { ... settings ... } }
+		requestJSON, marshalErr := json.Marshal(map[string]json.RawMessage{name: body})
+		if marshalErr != nil {
+			return fmt.Errorf("failed to marshal cluster settings: %w", marshalErr)
+		}
+		res, err = esClient.Cluster.PutSettings(
+			bytes.NewReader(requestJSON),
+			esClient.Cluster.PutSettings.WithContext(ctx),
+		)
+	case v1alpha1.BundleKindIngestPipelines:
+		res, err = esClient.Ingest.PutPipeline(
+			name,
+			bytes.NewReader(body),
+			esClient.Ingest.PutPipeline.WithContext(ctx),
+		)
+	case v1alpha1.BundleKindIndexLifecyclePolicies:
+		res, err = esClient.ILM.PutLifecycle(
+			name,
+			esClient.ILM.PutLifecycle.WithBody(bytes.NewReader(body)),
+			esClient.ILM.PutLifecycle.WithContext(ctx),
+		)
+	case v1alpha1.BundleKindComponentTemplates:
+		res, err = esClient.Cluster.PutComponentTemplate(
+			name,
+			bytes.NewReader(body),
+			esClient.Cluster.PutComponentTemplate.WithContext(ctx),
+		)
+	case v1alpha1.BundleKindIndexTemplates:
+		res, err = esClient.Indices.PutIndexTemplate(
+			name,
+			bytes.NewReader(body),
+			esClient.Indices.PutIndexTemplate.WithContext(ctx),
+		)
+	default:
+		return fmt.Errorf("unknown bundle resource kind %s", kind)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to apply %s/%s: %w", kind, name, err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		bodyBytes, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("elasticsearch API error: %s - %s", res.Status(), string(bodyBytes))
+	}
+
+	return nil
+}
+
+// deleteResource deletes a single bundle resource from Elasticsearch.
+// For cluster settings, name is "category.setting" and the setting is reset to its default value
+func (r *ElasticConfigBundleReconciler) deleteResource(ctx context.Context, esClient *elasticsearch.Client, kind v1alpha1.BundleResourceKind, name string) error {
+	logger := log.FromContext(ctx)
+
+	var res *esapi.Response
+	var err error
+
+	switch kind {
+	case v1alpha1.BundleKindClusterSettings:
+		category, settingKey, found := strings.Cut(name, ".")
+		if !found {
+			return fmt.Errorf("invalid cluster setting %s, expected category.setting", name)
+		}
+		// Build the request body: { "category": { "setting": null } }
+		requestJSON, marshalErr := json.Marshal(map[string]interface{}{
+			category: map[string]interface{}{settingKey: nil},
+		})
+		if marshalErr != nil {
+			return fmt.Errorf("failed to marshal reset request: %w", marshalErr)
+		}
+		res, err = esClient.Cluster.PutSettings(
+			bytes.NewReader(requestJSON),
+			esClient.Cluster.PutSettings.WithContext(ctx),
+		)
+	case v1alpha1.BundleKindIngestPipelines:
+		res, err = esClient.Ingest.DeletePipeline(
+			name,
+			esClient.Ingest.DeletePipeline.WithContext(ctx),
+		)
+	case v1alpha1.BundleKindIndexLifecyclePolicies:
+		res, err = esClient.ILM.DeleteLifecycle(
+			name,
+			esClient.ILM.DeleteLifecycle.WithContext(ctx),
+		)
+	case v1alpha1.BundleKindComponentTemplates:
+		res, err = esClient.Cluster.DeleteComponentTemplate(
+			name,
+			esClient.Cluster.DeleteComponentTemplate.WithContext(ctx),
+		)
+	case v1alpha1.BundleKindIndexTemplates:
+		res, err = esClient.Indices.DeleteIndexTemplate(
+			name,
+			esClient.Indices.DeleteIndexTemplate.WithContext(ctx),
+		)
+	default:
+		logger.Info(fmt.Sprintf("Unknown bundle resource kind %s, skipping deletion of %s", kind, name))
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to delete %s/%s: %w", kind, name, err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		// If the resource doesn't exist (404), consider it already deleted
+		if res.StatusCode == http.StatusNotFound {
+			logger.Info(fmt.Sprintf("Bundle resource %s/%s not found in Elasticsearch (already deleted)", kind, name))
+			return nil
+		}
+		bodyBytes, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("elasticsearch API error: %s - %s", res.Status(), string(bodyBytes))
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elasticconfigbundle
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/controller"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/globals"
+)
+
+// UpdateConditionSuccess updates the status of the ElasticConfigBundle resource with a success condition
+func (r *ElasticConfigBundleReconciler) UpdateConditionSuccess(elasticConfigBundle *v1alpha1.ElasticConfigBundle) {
+
+	// Create the new condition with the success status
+	condition := globals.NewCondition(globals.ConditionTypeResourceSynced, metav1.ConditionTrue,
+		globals.ConditionReasonTargetSynced, globals.ConditionReasonTargetSyncedMessage)
+
+	// Update the status of the ElasticConfigBundle resource
+	globals.UpdateCondition(&elasticConfigBundle.Status.Conditions, condition)
+}
+
+// UpdateConditionKubernetesApiCallFailure updates the status of the ElasticConfigBundle resource with a failure condition
+func (r *ElasticConfigBundleReconciler) UpdateConditionKubernetesApiCallFailure(elasticConfigBundle *v1alpha1.ElasticConfigBundle) {
+
+	// Create the new condition with the failure status
+	condition := globals.NewCondition(globals.ConditionTypeResourceSynced, metav1.ConditionFalse,
+		globals.ConditionReasonKubernetesApiCallErrorType, globals.ConditionReasonKubernetesApiCallErrorMessage)
+
+	// Update the status of the ElasticConfigBundle resource
+	globals.UpdateCondition(&elasticConfigBundle.Status.Conditions, condition)
+}
+
+// SetSyncing updates the status to Syncing phase
+func (r *ElasticConfigBundleReconciler) SetSyncing(ctx context.Context, resource *v1alpha1.ElasticConfigBundle) {
+	if globals.IsPeriodicSync(resource.Status.Conditions, resource.Status.ObservedGeneration, resource.Generation) {
+		return
+	}
+	resource.Status.Phase = controller.PhaseSyncing
+	resource.Status.Message = "Synchronizing with Elasticsearch"
+	globals.SetSyncingConditions(&resource.Status.Conditions, resource.Status.ObservedGeneration, resource.Generation, resource.Status.Message)
+	resource.Status.ObservedGeneration = resource.Generation
+}
+
+// SetReady updates the status to Ready phase with applied resources
+func (r *ElasticConfigBundleReconciler) SetReady(ctx context.Context, resource *v1alpha1.ElasticConfigBundle, targetCluster string, appliedResources []string) {
+	now := metav1.Now()
+	resource.Status.Phase = controller.PhaseReady
+	resource.Status.Message = fmt.Sprintf("Successfully synced %d bundle resources", len(appliedResources))
+	resource.Status.TargetCluster = targetCluster
+	resource.Status.AppliedResources = appliedResources
+	resource.Status.LastSyncTime = &now
+	globals.RecordApplied(r.Recorder, resource, resource.Status.Conditions, resource.Status.Message)
+	globals.UpdateClusterBlockedCondition(&resource.Status.Conditions, nil)
+	globals.SetReadyConditions(&resource.Status.Conditions, globals.ConditionReasonTargetSynced, resource.Status.Message)
+	resource.Status.ObservedGeneration = resource.Generation
+}
+
+// SetError updates the status to Error phase with error message
+func (r *ElasticConfigBundleReconciler) SetError(ctx context.Context, resource *v1alpha1.ElasticConfigBundle, err error) {
+	resource.Status.Phase = controller.PhaseError
+	resource.Status.Message = err.Error()
+	globals.UpdateClusterBlockedCondition(&resource.Status.Conditions, err)
+	globals.SetStalledConditions(&resource.Status.Conditions, globals.ConditionReasonSyncFailed, resource.Status.Message)
+	globals.RecordSyncError(r.Recorder, resource, err)
+	resource.Status.ObservedGeneration = resource.Generation
+}
+
+// SetWaitingForCluster updates the status to WaitingForCluster phase while the target cluster is not reachable yet
+func (r *ElasticConfigBundleReconciler) SetWaitingForCluster(ctx context.Context, resource *v1alpha1.ElasticConfigBundle, err error) {
+	resource.Status.Phase = controller.PhaseWaitingForCluster
+	resource.Status.Message = err.Error()
+	globals.SetReconcilingConditions(&resource.Status.Conditions, globals.ConditionReasonWaitingForCluster, resource.Status.Message)
+	globals.RecordSyncError(r.Recorder, resource, err)
+	resource.Status.ObservedGeneration = resource.Generation
+}
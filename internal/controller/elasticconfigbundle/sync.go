@@ -0,0 +1,305 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elasticconfigbundle
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/globals"
+)
+
+// defaultApplyOrder applies the resources before the ones depending on them:
+// pipelines and ILM policies are referenced by templates, and component templates by index templates
+var defaultApplyOrder = []v1alpha1.BundleResourceKind{
+	v1alpha1.BundleKindClusterSettings,
+	v1alpha1.BundleKindIngestPipelines,
+	v1alpha1.BundleKindIndexLifecyclePolicies,
+	v1alpha1.BundleKindComponentTemplates,
+	v1alpha1.BundleKindIndexTemplates,
+}
+
+// Sync executes the synchronization of the bundle resources with Elasticsearch
+func (r *ElasticConfigBundleReconciler) Sync(ctx context.Context, eventType watch.EventType, resource *v1alpha1.ElasticConfigBundle) (err error) {
+
+	logger := log.FromContext(ctx)
+
+	// The target cluster is resolved without changing the spec: the NamespaceDefaultCluster is used when the resource
+	// doesn't select one, and the namespace of the resource when the selector has none
+	resourceSelector, err := globals.ResolveResourceSelector(ctx, &resource.Spec.ResourceSelector, resource.Namespace)
+	if err != nil {
+		logger.Error(err, "Failed to resolve the target cluster")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	// Check the namespace of the resource is allowed to target the cluster
+	if err := globals.AuthorizeClusterAccess(ctx, resourceSelector, resource.Namespace, &resource.Status.Conditions); err != nil {
+		logger.Error(err, "Access to the target cluster denied")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	if err := globals.ValidateTLSVerification(resourceSelector, &resource.Status.Conditions); err != nil {
+		logger.Error(err, "TLS verification of the target cluster not configured")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	// Build the cluster key identifying the target cluster in the logs
+	clusterKey := fmt.Sprintf("%s_%s", resourceSelector.Namespace, resourceSelector.Name)
+
+	applyOrder := resolveApplyOrder(resource.Spec.ApplyOrder)
+
+	if eventType == watch.Deleted {
+		logger.Info(fmt.Sprintf("Deleting ElasticConfigBundle %s/%s", resource.Namespace, resource.Name))
+
+		// Get Elasticsearch connection to delete the bundle resources
+		esConnection, err := globals.GetOrCreateElasticsearchConnection(ctx, resourceSelector, resource.Namespace, r.ElasticsearchConnectionsPool)
+		if err != nil {
+			logger.Error(err, "Failed to get Elasticsearch connection for deletion")
+			return err
+		}
+
+		// Writes are serialized with the other resources targeting the same cluster
+		unlock, err := globals.LockCluster(ctx, esConnection.Endpoint)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+
+		// Delete every applied resource, in reverse apply order
+		for _, entry := range sortEntries(resource.Status.AppliedResources, applyOrder, true) {
+			kind, name, _ := strings.Cut(entry, "/")
+			logger.Info(fmt.Sprintf("Deleting bundle resource %s", entry))
+			if err := r.deleteResource(ctx, esConnection.Client, v1alpha1.BundleResourceKind(kind), name); err != nil {
+				logger.Error(err, fmt.Sprintf("Failed to delete bundle resource %s", entry))
+				return err
+			}
+			logger.Info(fmt.Sprintf("Bundle resource %s deleted successfully", entry))
+		}
+
+		return nil
+	}
+
+	logger.Info(fmt.Sprintf("Syncing ElasticConfigBundle %s/%s", resource.Namespace, resource.Name))
+
+	// Set status to Syncing at the beginning
+	r.SetSyncing(ctx, resource)
+
+	// Step 1: Get or create Elasticsearch connection
+	esConnection, err := globals.GetOrCreateElasticsearchConnection(ctx, resourceSelector, resource.Namespace, r.ElasticsearchConnectionsPool)
+	globals.UpdateClusterReachableCondition(&resource.Status.Conditions, err)
+	if err != nil {
+		logger.Error(err, "Failed to get or create Elasticsearch connection")
+		if errors.Is(err, globals.ErrClusterNotReady) {
+			r.SetWaitingForCluster(ctx, resource, err)
+			return err
+		}
+		r.SetError(ctx, resource, fmt.Errorf("%w to Elasticsearch: %w", globals.ErrConnectionFailed, err))
+		return err
+	}
+
+	// Writes are serialized with the other resources targeting the same cluster
+	unlock, err := globals.LockCluster(ctx, esConnection.Endpoint)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	logger.Info(fmt.Sprintf("Elasticsearch connection established for cluster %s (type: %s, version: %s)", clusterKey, esConnection.ClusterType, esConnection.Version))
+
+	// Validate cluster type - ILM is only available in Elasticsearch
+	if esConnection.ClusterType == "opensearch" && len(resource.Spec.IndexLifecyclePolicies) > 0 {
+		err := fmt.Errorf("ILM (Index Lifecycle Management) is not available in OpenSearch. Remove indexLifecyclePolicies from the bundle and use the IndexStateManagement CRD instead")
+		logger.Error(err, "Incompatible cluster type for ElasticConfigBundle")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	// Step 2: Build the list of desired resources from Spec, as "kind/name" entries
+	desiredEntries := make(map[string]bool)
+	for _, kind := range applyOrder {
+		entries, err := desiredEntriesOf(kind, desiredResourcesOf(&resource.Spec, kind))
+		if err != nil {
+			logger.Error(err, fmt.Sprintf("Failed to read the %s of the bundle", kind))
+			r.SetError(ctx, resource, err)
+			return err
+		}
+		for _, entry := range entries {
+			desiredEntries[entry] = true
+		}
+	}
+
+	// Bundles managing cluster settings forbidden by the operator configuration are rejected before changing anything,
+	// like ClusterSettings resources
+	var settingKeys []string
+	for category, settings := range resource.Spec.ClusterSettings {
+		var categorySettings map[string]interface{}
+		if err := json.Unmarshal(settings.Raw, &categorySettings); err != nil {
+			err = fmt.Errorf("failed to unmarshal cluster settings for category %s: %w", category, err)
+			r.SetError(ctx, resource, err)
+			return err
+		}
+		for settingKey := range globals.FlattenSettings(categorySettings) {
+			settingKeys = append(settingKeys, settingKey)
+		}
+	}
+	if err := globals.CheckClusterSettings(settingKeys); err != nil {
+		logger.Error(err, "Cluster settings of the bundle rejected")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	// Step 3: Delete resources that are no longer desired, in reverse apply order
+	for _, entry := range sortEntries(resource.Status.AppliedResources, applyOrder, true) {
+		if desiredEntries[entry] {
+			continue
+		}
+		kind, name, _ := strings.Cut(entry, "/")
+		logger.Info(fmt.Sprintf("Bundle resource %s is no longer desired, deleting from Elasticsearch", entry))
+		if err := r.deleteResource(ctx, esConnection.Client, v1alpha1.BundleResourceKind(kind), name); err != nil {
+			logger.Error(err, fmt.Sprintf("Failed to delete bundle resource %s", entry))
+			r.SetError(ctx, resource, fmt.Errorf("failed to delete %s: %w", entry, err))
+			return err
+		}
+		logger.Info(fmt.Sprintf("Bundle resource %s deleted successfully", entry))
+		globals.RecordPruned(r.Recorder, resource, entry)
+	}
+
+	// Step 4: Apply all desired resources, kind by kind in apply order
+	for _, kind := range applyOrder {
+		resources := desiredResourcesOf(&resource.Spec, kind)
+
+		names := make([]string, 0, len(resources))
+		for name := range resources {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			logger.Info(fmt.Sprintf("Applying bundle resource %s/%s", kind, name))
+			if err := r.applyResource(ctx, esConnection.Client, kind, name, resources[name].Raw); err != nil {
+				logger.Error(err, fmt.Sprintf("Failed to apply bundle resource %s/%s", kind, name))
+				r.SetError(ctx, resource, fmt.Errorf("failed to apply %s/%s: %w", kind, name, err))
+				return err
+			}
+			logger.Info(fmt.Sprintf("Bundle resource %s/%s applied successfully", kind, name))
+		}
+	}
+
+	newAppliedResources := make([]string, 0, len(desiredEntries))
+	for entry := range desiredEntries {
+		newAppliedResources = append(newAppliedResources, entry)
+	}
+
+	// Step 5: Update the Status with the new list of applied resources
+	targetCluster := fmt.Sprintf("%s/%s", resourceSelector.Namespace, resourceSelector.Name)
+	r.SetReady(ctx, resource, targetCluster, sortEntries(newAppliedResources, applyOrder, false))
+
+	logger.Info(fmt.Sprintf("ElasticConfigBundle %s/%s synced successfully", resource.Namespace, resource.Name))
+
+	return nil
+}
+
+// resolveApplyOrder returns the kinds listed in applyOrder followed by the remaining kinds in the default order
+func resolveApplyOrder(applyOrder []v1alpha1.BundleResourceKind) []v1alpha1.BundleResourceKind {
+	order := make([]v1alpha1.BundleResourceKind, 0, len(defaultApplyOrder))
+	listed := make(map[v1alpha1.BundleResourceKind]bool)
+	for _, kind := range applyOrder {
+		if !listed[kind] {
+			order = append(order, kind)
+			listed[kind] = true
+		}
+	}
+	for _, kind := range defaultApplyOrder {
+		if !listed[kind] {
+			order = append(order, kind)
+		}
+	}
+	return order
+}
+
+// desiredResourcesOf returns the resources of the given kind defined in the bundle spec
+func desiredResourcesOf(spec *v1alpha1.ElasticConfigBundleSpec, kind v1alpha1.BundleResourceKind) map[string]apiextensionsv1.JSON {
+	switch kind {
+	case v1alpha1.BundleKindClusterSettings:
+		return spec.ClusterSettings
+	case v1alpha1.BundleKindIngestPipelines:
+		return spec.IngestPipelines
+	case v1alpha1.BundleKindIndexLifecyclePolicies:
+		return spec.IndexLifecyclePolicies
+	case v1alpha1.BundleKindComponentTemplates:
+		return spec.ComponentTemplates
+	case v1alpha1.BundleKindIndexTemplates:
+		return spec.IndexTemplates
+	}
+	return nil
+}
+
+// desiredEntriesOf returns the status entries of the given resources. Cluster settings are tracked
+// individually ("ClusterSettings/category.setting"), so removed settings can be reset one by one
+func desiredEntriesOf(kind v1alpha1.BundleResourceKind, resources map[string]apiextensionsv1.JSON) ([]string, error) {
+	entries := make([]string, 0, len(resources))
+	for name, resource := range resources {
+		if kind != v1alpha1.BundleKindClusterSettings {
+			entries = append(entries, fmt.Sprintf("%s/%s", kind, name))
+			continue
+		}
+
+		var settings map[string]interface{}
+		if err := json.Unmarshal(resource.Raw, &settings); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal cluster settings for category %s: %w", name, err)
+		}
+		for settingKey := range settings {
+			entries = append(entries, fmt.Sprintf("%s/%s.%s", kind, name, settingKey))
+		}
+	}
+	return entries, nil
+}
+
+// sortEntries sorts "kind/name" entries by the position of their kind in the apply order, and by name within a kind.
+// When reverse is set, the kinds are sorted in reverse apply order
+func sortEntries(entries []string, applyOrder []v1alpha1.BundleResourceKind, reverse bool) []string {
+	position := make(map[string]int, len(applyOrder))
+	for i, kind := range applyOrder {
+		position[string(kind)] = i
+		if reverse {
+			position[string(kind)] = len(applyOrder) - i
+		}
+	}
+
+	sorted := append([]string(nil), entries...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		kindI, nameI, _ := strings.Cut(sorted[i], "/")
+		kindJ, nameJ, _ := strings.Cut(sorted[j], "/")
+		if position[kindI] != position[kindJ] {
+			return position[kindI] < position[kindJ]
+		}
+		return nameI < nameJ
+	})
+	return sorted
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elasticsearchclusterconnection
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/controller"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/globals"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/pools"
+)
+
+// ElasticsearchClusterConnectionReconciler reconciles an ElasticsearchClusterConnection object
+type ElasticsearchClusterConnectionReconciler struct {
+	client.Client
+	Scheme                       *runtime.Scheme
+	ElasticsearchConnectionsPool *pools.ElasticsearchConnectionsStore
+	Recorder                     record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=elastic-config-operator.freepik.com,resources=elasticsearchclusterconnections,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=elastic-config-operator.freepik.com,resources=elasticsearchclusterconnections/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=elastic-config-operator.freepik.com,resources=elasticsearchclusterconnections/finalizers,verbs=update
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+
+// Reconcile connects to the cluster of an ElasticsearchClusterConnection on every syncInterval, reporting its type and
+// version, and drops the pooled connection once the resource is deleted
+func (r *ElasticsearchClusterConnectionReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
+	logger := logf.FromContext(ctx)
+
+	// 1. Get the content of the resource
+	elasticsearchClusterConnectionResource := &v1alpha1.ElasticsearchClusterConnection{}
+	err = r.Get(ctx, req.NamespacedName, elasticsearchClusterConnectionResource)
+
+	// 2. Check existence on the cluster
+	if err != nil {
+
+		// 2.1 It does NOT exist: manage removal
+		if err = client.IgnoreNotFound(err); err == nil {
+			logger.Info(fmt.Sprintf(controller.ResourceNotFoundError, controller.ElasticsearchClusterConnectionResourceType, req.NamespacedName))
+			return result, err
+		}
+
+		// 2.2 Failed to get the resource, requeue the request
+		logger.Info(fmt.Sprintf(controller.ResourceSyncTimeRetrievalError, controller.ElasticsearchClusterConnectionResourceType, req.NamespacedName, err.Error()))
+		return result, err
+	}
+
+	// 3. Check if the ElasticsearchClusterConnection instance is marked to be deleted
+	if !elasticsearchClusterConnectionResource.DeletionTimestamp.IsZero() {
+		if controllerutil.ContainsFinalizer(elasticsearchClusterConnectionResource, controller.ResourceFinalizer) {
+			original := elasticsearchClusterConnectionResource.DeepCopy()
+
+			// 3.1 Delete the resources associated with the ElasticsearchClusterConnection
+			err = r.Sync(ctx, watch.Deleted, elasticsearchClusterConnectionResource)
+
+			// Failed cleanups are retried with backoff, keeping the finalizer and reporting why in the status, until they
+			// are given up
+			if globals.RetryCleanup(elasticsearchClusterConnectionResource, err) {
+				logger.Info(fmt.Sprintf(controller.ResourceCleanupError, controller.ElasticsearchClusterConnectionResourceType, req.NamespacedName, err.Error()))
+				if updateErr := globals.PatchStatus(ctx, r.Client, elasticsearchClusterConnectionResource, original); updateErr != nil {
+					logger.Info(fmt.Sprintf(controller.ResourceConditionUpdateError, controller.ElasticsearchClusterConnectionResourceType, req.NamespacedName, updateErr.Error()))
+				}
+				return result, err
+			}
+			if err != nil {
+				logger.Info(fmt.Sprintf(controller.ResourceCleanupGivenUpMessage, controller.ElasticsearchClusterConnectionResourceType, req.NamespacedName, err.Error()))
+			}
+
+			// Remove the finalizers on ElasticsearchClusterConnection CR
+			err = globals.RemoveFinalizer(ctx, r.Client, elasticsearchClusterConnectionResource, controller.ResourceFinalizer)
+			if err != nil {
+				logger.Info(fmt.Sprintf(controller.ResourceFinalizersUpdateError, controller.ElasticsearchClusterConnectionResourceType, req.NamespacedName, err.Error()))
+			}
+		}
+
+		result = ctrl.Result{}
+		err = nil
+		return result, err
+	}
+
+	// 4. Add finalizer to the ElasticsearchClusterConnection CR
+	if !controllerutil.ContainsFinalizer(elasticsearchClusterConnectionResource, controller.ResourceFinalizer) {
+		err = globals.AddFinalizer(ctx, r.Client, elasticsearchClusterConnectionResource, controller.ResourceFinalizer)
+		if err != nil {
+			return result, err
+		}
+	}
+
+	// 5. Update the status before the requeue, writing the changes made to it during the reconcile at once
+	original := elasticsearchClusterConnectionResource.DeepCopy()
+	defer func() {
+		elasticsearchClusterConnectionResource.Status.ObservedGeneration = elasticsearchClusterConnectionResource.Generation
+		// The sync error is kept as the result, so failed syncs are requeued with backoff
+		if updateErr := globals.PatchStatus(ctx, r.Client, elasticsearchClusterConnectionResource, original); updateErr != nil {
+			logger.Info(fmt.Sprintf(controller.ResourceConditionUpdateError, controller.ElasticsearchClusterConnectionResourceType, req.NamespacedName, updateErr.Error()))
+		}
+	}()
+
+	// 6. Schedule periodical request
+	RequeueTime, err := controller.RequeueInterval(elasticsearchClusterConnectionResource.Spec.SyncInterval)
+	if err != nil {
+		logger.Info(fmt.Sprintf(controller.ResourceSyncTimeRetrievalError, controller.ElasticsearchClusterConnectionResourceType, req.NamespacedName, err.Error()))
+		return result, err
+	}
+	result = ctrl.Result{
+		RequeueAfter: RequeueTime,
+	}
+
+	// 7. Check the connection and refresh the pooled client
+	err = r.Sync(ctx, watch.Modified, elasticsearchClusterConnectionResource)
+	if err != nil {
+		// Failed syncs are requeued with the error backoff instead of the syncInterval
+		result = ctrl.Result{}
+		r.UpdateConditionKubernetesApiCallFailure(elasticsearchClusterConnectionResource)
+		logger.Info(fmt.Sprintf(controller.SyncTargetError, controller.ElasticsearchClusterConnectionResourceType, req.NamespacedName, err.Error()))
+		return result, err
+	}
+
+	// 8. Success, update the status
+	r.UpdateConditionSuccess(elasticsearchClusterConnectionResource)
+
+	return result, err
+
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ElasticsearchClusterConnectionReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.ElasticsearchClusterConnection{}).
+		WithEventFilter(predicate.GenerationChangedPredicate{}).
+		Named("elasticsearchclusterconnection").
+		WithOptions(globals.ControllerOptions()).
+		Complete(r)
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elasticsearchclusterconnection
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/globals"
+)
+
+// Sync checks the connection defined by the resource and refreshes the pooled connection shared by the
+// resources referencing it, so rotated credentials are picked up on the next reconciliation
+func (r *ElasticsearchClusterConnectionReconciler) Sync(ctx context.Context, eventType watch.EventType, resource *v1alpha1.ElasticsearchClusterConnection) (err error) {
+
+	logger := log.FromContext(ctx)
+
+	connectionKey := globals.ClusterConnectionKey(resource.Namespace, resource.Name)
+
+	if eventType == watch.Deleted {
+		logger.Info(fmt.Sprintf("Deleting ElasticsearchClusterConnection %s/%s", resource.Namespace, resource.Name))
+
+		// Resources still referencing the connection will fail to connect until it is created again
+		r.ElasticsearchConnectionsPool.Delete(connectionKey)
+
+		return nil
+	}
+
+	logger.Info(fmt.Sprintf("Syncing ElasticsearchClusterConnection %s/%s", resource.Namespace, resource.Name))
+
+	// Set status to Syncing at the beginning
+	r.SetSyncing(ctx, resource)
+
+	// Step 1: Create a new connection with the current credentials
+	esConnection, err := globals.NewClusterConnection(ctx, resource)
+	if err != nil {
+		logger.Error(err, "Failed to connect to Elasticsearch")
+		// Drop the pooled connection, so the referencing resources don't keep using outdated settings
+		r.ElasticsearchConnectionsPool.Delete(connectionKey)
+		r.SetError(ctx, resource, fmt.Errorf("%w to Elasticsearch: %w", globals.ErrConnectionFailed, err))
+		return err
+	}
+
+	logger.Info(fmt.Sprintf("Elasticsearch connection established for %s (type: %s, version: %s)", connectionKey, esConnection.ClusterType, esConnection.Version))
+
+	// Step 2: Replace the pooled connection used by the referencing resources
+	r.ElasticsearchConnectionsPool.Set(connectionKey, esConnection)
+
+	// Step 3: Update the Status with the detected cluster
+	r.SetReady(ctx, resource, esConnection.ClusterType, esConnection.Version)
+
+	logger.Info(fmt.Sprintf("ElasticsearchClusterConnection %s/%s synced successfully", resource.Namespace, resource.Name))
+
+	return nil
+}
@@ -0,0 +1,342 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package elasticsearchrawresource
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/elastic/go-elasticsearch/v8"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/globals"
+)
+
+const (
+	// nameTemplate is replaced with the resource key in paths and response paths
+	nameTemplate = "{{name}}"
+
+	// methodNone disables the read or delete request of a raw resource
+	methodNone = "None"
+)
+
+// Sync executes the synchronization of raw resources with the cluster
+func (r *ElasticsearchRawResourceReconciler) Sync(ctx context.Context, eventType watch.EventType, resource *v1alpha1.ElasticsearchRawResource) (err error) {
+
+	logger := log.FromContext(ctx)
+
+	// The target cluster is resolved without changing the spec: the NamespaceDefaultCluster is used when the resource
+	// doesn't select one, and the namespace of the resource when the selector has none
+	resourceSelector, err := globals.ResolveResourceSelector(ctx, &resource.Spec.ResourceSelector, resource.Namespace)
+	if err != nil {
+		logger.Error(err, "Failed to resolve the target cluster")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	// Check the namespace of the resource is allowed to target the cluster
+	if err := globals.AuthorizeClusterAccess(ctx, resourceSelector, resource.Namespace, &resource.Status.Conditions); err != nil {
+		logger.Error(err, "Access to the target cluster denied")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	if err := globals.ValidateTLSVerification(resourceSelector, &resource.Status.Conditions); err != nil {
+		logger.Error(err, "TLS verification of the target cluster not configured")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	// Build the cluster key identifying the target cluster in the logs
+	clusterKey := fmt.Sprintf("%s_%s", resourceSelector.Namespace, resourceSelector.Name)
+
+	if eventType == watch.Deleted {
+		logger.Info(fmt.Sprintf("Deleting ElasticsearchRawResource %s/%s", resource.Namespace, resource.Name))
+
+		// Get Elasticsearch connection to delete the raw resources
+		esConnection, err := globals.GetOrCreateElasticsearchConnection(ctx, resourceSelector, resource.Namespace, r.ElasticsearchConnectionsPool)
+		if err != nil {
+			logger.Error(err, "Failed to get Elasticsearch connection for deletion")
+			return err
+		}
+
+		// Writes are serialized with the other resources targeting the same cluster
+		unlock, err := globals.LockCluster(ctx, esConnection.Endpoint)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+
+		// Delete each raw resource from the cluster
+		for resourceName, deletePath := range resource.Status.DeletePaths {
+			logger.Info(fmt.Sprintf("Deleting raw resource %s (%s)", resourceName, deletePath))
+			if err := r.deleteRawResource(ctx, esConnection.Client, deletePath); err != nil {
+				logger.Error(err, fmt.Sprintf("Failed to delete raw resource %s", resourceName))
+				return err
+			}
+			logger.Info(fmt.Sprintf("Raw resource %s deleted successfully", resourceName))
+		}
+
+		return nil
+	}
+
+	logger.Info(fmt.Sprintf("Syncing ElasticsearchRawResource %s/%s", resource.Namespace, resource.Name))
+
+	// Set status to Syncing at the beginning
+	r.SetSyncing(ctx, resource)
+
+	// Step 1: Get or create Elasticsearch connection
+	esConnection, err := globals.GetOrCreateElasticsearchConnection(ctx, resourceSelector, resource.Namespace, r.ElasticsearchConnectionsPool)
+	globals.UpdateClusterReachableCondition(&resource.Status.Conditions, err)
+	if err != nil {
+		logger.Error(err, "Failed to get or create Elasticsearch connection")
+		if errors.Is(err, globals.ErrClusterNotReady) {
+			r.SetWaitingForCluster(ctx, resource, err)
+			return err
+		}
+		r.SetError(ctx, resource, fmt.Errorf("%w to Elasticsearch: %w", globals.ErrConnectionFailed, err))
+		return err
+	}
+
+	// Writes are serialized with the other resources targeting the same cluster
+	unlock, err := globals.LockCluster(ctx, esConnection.Endpoint)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	logger.Info(fmt.Sprintf("Elasticsearch connection established for cluster %s (type: %s, version: %s)", clusterKey, esConnection.ClusterType, esConnection.Version))
+
+	// Step 2: Delete raw resources that are no longer desired, using the path recorded when they were applied
+	for _, resourceName := range resource.Status.AppliedResources {
+		if _, desired := resource.Spec.Resources[resourceName]; desired {
+			continue
+		}
+		deletePath, found := resource.Status.DeletePaths[resourceName]
+		if !found {
+			logger.Info(fmt.Sprintf("Raw resource %s is no longer desired but has no delete method, leaving it in the cluster", resourceName))
+			continue
+		}
+		logger.Info(fmt.Sprintf("Raw resource %s is no longer desired, deleting it (%s)", resourceName, deletePath))
+		if err := r.deleteRawResource(ctx, esConnection.Client, deletePath); err != nil {
+			logger.Error(err, fmt.Sprintf("Failed to delete raw resource %s", resourceName))
+			r.SetError(ctx, resource, fmt.Errorf("failed to delete raw resource %s: %w", resourceName, err))
+			return err
+		}
+		logger.Info(fmt.Sprintf("Raw resource %s deleted successfully", resourceName))
+		globals.RecordPruned(r.Recorder, resource, resourceName)
+	}
+
+	// Step 3: Apply all desired raw resources
+	newAppliedResources := make([]string, 0, len(resource.Spec.Resources))
+	newDeletePaths := make(map[string]string, len(resource.Spec.Resources))
+	for resourceName, rawResource := range resource.Spec.Resources {
+		logger.Info(fmt.Sprintf("Applying raw resource %s", resourceName))
+		if err := r.applyRawResource(ctx, esConnection.Client, resourceName, rawResource); err != nil {
+			logger.Error(err, fmt.Sprintf("Failed to apply raw resource %s", resourceName))
+			r.SetError(ctx, resource, fmt.Errorf("failed to apply raw resource %s: %w", resourceName, err))
+			return err
+		}
+		newAppliedResources = append(newAppliedResources, resourceName)
+		if rawResource.DeleteMethod != methodNone {
+			newDeletePaths[resourceName] = renderPath(rawResource.DeletePath, rawResource.Path, resourceName)
+		}
+		logger.Info(fmt.Sprintf("Raw resource %s applied successfully", resourceName))
+	}
+
+	// Step 4: Update the Status with the new list of applied raw resources
+	resource.Status.DeletePaths = newDeletePaths
+	targetCluster := fmt.Sprintf("%s/%s", resourceSelector.Namespace, resourceSelector.Name)
+	r.SetReady(ctx, resource, targetCluster, newAppliedResources)
+
+	logger.Info(fmt.Sprintf("ElasticsearchRawResource %s/%s synced successfully", resource.Namespace, resource.Name))
+
+	return nil
+}
+
+// applyRawResource reads the current state of a raw resource and applies the desired body when it differs.
+// Resources without a read method are applied on every reconciliation
+func (r *ElasticsearchRawResourceReconciler) applyRawResource(ctx context.Context, esClient *elasticsearch.Client, resourceName string, rawResource v1alpha1.RawResource) error {
+	logger := log.FromContext(ctx)
+
+	var desired interface{}
+	var body []byte
+	if rawResource.Body != nil {
+		body = rawResource.Body.Raw
+		if err := json.Unmarshal(body, &desired); err != nil {
+			return fmt.Errorf("failed to unmarshal body: %w", err)
+		}
+	}
+
+	if rawResource.GetMethod != methodNone {
+		getMethod := rawResource.GetMethod
+		if getMethod == "" {
+			getMethod = http.MethodGet
+		}
+
+		live, exists, err := r.getRawResource(ctx, esClient, getMethod, renderPath(rawResource.GetPath, rawResource.Path, resourceName))
+		if err != nil {
+			return err
+		}
+
+		if exists {
+			live, found := lookupResponsePath(live, rawResource.ResponsePath, resourceName)
+			if found && (desired == nil || globals.IsSubset(desired, live)) {
+				logger.Info(fmt.Sprintf("Raw resource %s is up to date", resourceName))
+				return nil
+			}
+		}
+	}
+
+	applyMethod := rawResource.ApplyMethod
+	if applyMethod == "" {
+		applyMethod = http.MethodPut
+	}
+
+	res, err := r.performRequest(ctx, esClient, applyMethod, renderPath("", rawResource.Path, resourceName), body)
+	if err != nil {
+		return fmt.Errorf("failed to apply raw resource: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= 400 {
+		bodyBytes, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("elasticsearch API error: %s - %s", res.Status, string(bodyBytes))
+	}
+
+	return nil
+}
+
+// getRawResource reads a raw resource. It returns the decoded response and whether the resource exists
+func (r *ElasticsearchRawResourceReconciler) getRawResource(ctx context.Context, esClient *elasticsearch.Client, method, path string) (interface{}, bool, error) {
+	res, err := r.performRequest(ctx, esClient, method, path, nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get raw resource: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, false, nil
+	}
+
+	bodyBytes, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if res.StatusCode >= 400 {
+		return nil, false, fmt.Errorf("elasticsearch API error: %s - %s", res.Status, string(bodyBytes))
+	}
+
+	var live interface{}
+	if err := json.Unmarshal(bodyBytes, &live); err != nil {
+		return nil, false, fmt.Errorf("failed to parse response body: %w", err)
+	}
+
+	return live, true, nil
+}
+
+// deleteRawResource deletes a raw resource from the cluster
+func (r *ElasticsearchRawResourceReconciler) deleteRawResource(ctx context.Context, esClient *elasticsearch.Client, path string) error {
+	logger := log.FromContext(ctx)
+
+	res, err := r.performRequest(ctx, esClient, http.MethodDelete, path, nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete raw resource: %w", err)
+	}
+	defer res.Body.Close()
+
+	// If the resource doesn't exist (404), consider it already deleted
+	if res.StatusCode == http.StatusNotFound {
+		logger.Info(fmt.Sprintf("Raw resource %s not found in the cluster (already deleted)", path))
+		return nil
+	}
+
+	if res.StatusCode >= 400 {
+		bodyBytes, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("elasticsearch API error: %s - %s", res.Status, string(bodyBytes))
+	}
+
+	return nil
+}
+
+// performRequest sends a raw request to the cluster API
+func (r *ElasticsearchRawResourceReconciler) performRequest(ctx context.Context, esClient *elasticsearch.Client, method, path string, body []byte) (*http.Response, error) {
+	var bodyReader io.Reader
+	if body != nil {
+		bodyReader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, path, bodyReader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	return esClient.Perform(req)
+}
+
+// renderPath returns the path override, or the default path when it is empty, with the resource name templated in
+func renderPath(override, defaultPath, resourceName string) string {
+	path := override
+	if path == "" {
+		path = defaultPath
+	}
+	return strings.ReplaceAll(path, nameTemplate, resourceName)
+}
+
+// lookupResponsePath walks a dot separated path through a decoded response. Numeric segments index arrays.
+// The name template is replaced after splitting, so resource names containing dots are supported
+func lookupResponsePath(value interface{}, responsePath, resourceName string) (interface{}, bool) {
+	if responsePath == "" {
+		return value, true
+	}
+
+	for _, segment := range strings.Split(responsePath, ".") {
+		segment = strings.ReplaceAll(segment, nameTemplate, resourceName)
+		switch current := value.(type) {
+		case map[string]interface{}:
+			next, found := current[segment]
+			if !found {
+				return nil, false
+			}
+			value = next
+		case []interface{}:
+			index, err := strconv.Atoi(segment)
+			if err != nil || index < 0 || index >= len(current) {
+				return nil, false
+			}
+			value = current[index]
+		default:
+			return nil, false
+		}
+	}
+
+	return value, true
+}
@@ -0,0 +1,317 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fleetagentpolicy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/globals"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/pools"
+)
+
+const (
+	defaultSpace = "default"
+
+	// defaultPolicyNamespace is the data stream namespace Fleet uses when the policy doesn't set one
+	defaultPolicyNamespace = "default"
+)
+
+// Sync executes the synchronization of agent policies and their integrations with Kibana Fleet
+func (r *FleetAgentPolicyReconciler) Sync(ctx context.Context, eventType watch.EventType, resource *v1alpha1.FleetAgentPolicy) (err error) {
+
+	logger := log.FromContext(ctx)
+
+	// The Kibana instance is resolved without changing the spec, in the namespace of the resource when the selector
+	// has none
+	kibanaSelector := resource.Spec.KibanaSelector.DeepCopy()
+	if kibanaSelector.Namespace == "" {
+		kibanaSelector.Namespace = resource.Namespace
+	}
+
+	// Build the Kibana key for the pools
+	kibanaKey := fmt.Sprintf("%s_%s", kibanaSelector.Namespace, kibanaSelector.Name)
+
+	if eventType == watch.Deleted {
+		logger.Info(fmt.Sprintf("Deleting FleetAgentPolicy %s/%s", resource.Namespace, resource.Name))
+
+		// Get Kibana connection to delete the agent policies and integrations
+		kibanaConnection, err := globals.GetOrCreateKibanaConnection(ctx, kibanaKey, kibanaSelector, resource.Namespace, r.KibanaConnectionsPool)
+		if err != nil {
+			logger.Error(err, "Failed to get Kibana connection for deletion")
+			return err
+		}
+
+		// Integrations are deleted first, as they belong to the agent policies
+		for _, packagePolicyID := range resource.Status.AppliedPackagePolicies {
+			logger.Info(fmt.Sprintf("Deleting package policy %s from Kibana", packagePolicyID))
+			if err := r.deletePackagePolicy(ctx, kibanaConnection, resource.Status.Space, packagePolicyID); err != nil {
+				logger.Error(err, fmt.Sprintf("Failed to delete package policy %s", packagePolicyID))
+				return err
+			}
+			logger.Info(fmt.Sprintf("Package policy %s deleted successfully", packagePolicyID))
+		}
+
+		for _, policyID := range resource.Status.AppliedResources {
+			logger.Info(fmt.Sprintf("Deleting agent policy %s from Kibana", policyID))
+			if err := r.deleteAgentPolicy(ctx, kibanaConnection, resource.Status.Space, policyID); err != nil {
+				logger.Error(err, fmt.Sprintf("Failed to delete agent policy %s", policyID))
+				return err
+			}
+			logger.Info(fmt.Sprintf("Agent policy %s deleted successfully", policyID))
+		}
+
+		return nil
+	}
+
+	logger.Info(fmt.Sprintf("Syncing FleetAgentPolicy %s/%s", resource.Namespace, resource.Name))
+
+	// Set status to Syncing at the beginning
+	r.SetSyncing(ctx, resource)
+
+	// Step 1: Get or create Kibana connection
+	kibanaConnection, err := globals.GetOrCreateKibanaConnection(ctx, kibanaKey, kibanaSelector, resource.Namespace, r.KibanaConnectionsPool)
+	if err != nil {
+		logger.Error(err, "Failed to get or create Kibana connection")
+		r.SetError(ctx, resource, fmt.Errorf("%w to Kibana: %w", globals.ErrConnectionFailed, err))
+		return err
+	}
+
+	logger.Info(fmt.Sprintf("Kibana connection established for %s (version: %s)", kibanaKey, kibanaConnection.Version))
+
+	space := resource.Spec.Space
+	if space == "" {
+		space = defaultSpace
+	}
+
+	// When the space changes, everything created in the previous space is removed
+	previousSpace := resource.Status.Space
+	if previousSpace == "" {
+		previousSpace = space
+	}
+
+	// Step 2: Build the list of desired integrations from Spec
+	desiredPackagePolicies := make(map[string]bool)
+	for _, policy := range resource.Spec.Resources {
+		for packagePolicyID := range policy.PackagePolicies {
+			desiredPackagePolicies[packagePolicyID] = true
+		}
+	}
+
+	// Step 3: Delete integrations that are no longer desired, before the agent policies they belong to
+	for _, packagePolicyID := range resource.Status.AppliedPackagePolicies {
+		if desiredPackagePolicies[packagePolicyID] && previousSpace == space {
+			continue
+		}
+		logger.Info(fmt.Sprintf("Package policy %s is no longer desired in space %s, deleting from Kibana", packagePolicyID, previousSpace))
+		if err := r.deletePackagePolicy(ctx, kibanaConnection, previousSpace, packagePolicyID); err != nil {
+			logger.Error(err, fmt.Sprintf("Failed to delete package policy %s", packagePolicyID))
+			r.SetError(ctx, resource, fmt.Errorf("failed to delete package policy %s: %w", packagePolicyID, err))
+			return err
+		}
+		logger.Info(fmt.Sprintf("Package policy %s deleted successfully", packagePolicyID))
+		globals.RecordPruned(r.Recorder, resource, packagePolicyID)
+	}
+
+	// Step 4: Delete agent policies that are no longer desired
+	for _, policyID := range resource.Status.AppliedResources {
+		if _, desired := resource.Spec.Resources[policyID]; desired && previousSpace == space {
+			continue
+		}
+		logger.Info(fmt.Sprintf("Agent policy %s is no longer desired in space %s, deleting from Kibana", policyID, previousSpace))
+		if err := r.deleteAgentPolicy(ctx, kibanaConnection, previousSpace, policyID); err != nil {
+			logger.Error(err, fmt.Sprintf("Failed to delete agent policy %s", policyID))
+			r.SetError(ctx, resource, fmt.Errorf("failed to delete agent policy %s: %w", policyID, err))
+			return err
+		}
+		logger.Info(fmt.Sprintf("Agent policy %s deleted successfully", policyID))
+		globals.RecordPruned(r.Recorder, resource, policyID)
+	}
+
+	// Step 5: Create or update all desired agent policies, followed by their integrations
+	newAppliedPolicies := make([]string, 0, len(resource.Spec.Resources))
+	newAppliedPackagePolicies := make([]string, 0, len(desiredPackagePolicies))
+	for policyID, policyResource := range resource.Spec.Resources {
+		var policy map[string]interface{}
+		if err := json.Unmarshal(policyResource.Policy.Raw, &policy); err != nil {
+			logger.Error(err, fmt.Sprintf("Failed to unmarshal agent policy %s", policyID))
+			r.SetError(ctx, resource, fmt.Errorf("failed to unmarshal agent policy %s: %w", policyID, err))
+			return err
+		}
+
+		// The ID is taken from the resource key, while the name and namespace get defaults as both are required
+		delete(policy, "id")
+		if name, _ := policy["name"].(string); name == "" {
+			policy["name"] = policyID
+		}
+		if namespace, _ := policy["namespace"].(string); namespace == "" {
+			policy["namespace"] = defaultPolicyNamespace
+		}
+
+		logger.Info(fmt.Sprintf("Applying agent policy %s to Kibana", policyID))
+		if err := r.applyObject(ctx, kibanaConnection, space, "/api/fleet/agent_policies", policyID, policy); err != nil {
+			logger.Error(err, fmt.Sprintf("Failed to apply agent policy %s", policyID))
+			r.SetError(ctx, resource, fmt.Errorf("failed to apply agent policy %s: %w", policyID, err))
+			return err
+		}
+		newAppliedPolicies = append(newAppliedPolicies, policyID)
+		logger.Info(fmt.Sprintf("Agent policy %s applied successfully", policyID))
+
+		for packagePolicyID, packagePolicyResource := range policyResource.PackagePolicies {
+			var packagePolicy map[string]interface{}
+			if err := json.Unmarshal(packagePolicyResource.Raw, &packagePolicy); err != nil {
+				logger.Error(err, fmt.Sprintf("Failed to unmarshal package policy %s", packagePolicyID))
+				r.SetError(ctx, resource, fmt.Errorf("failed to unmarshal package policy %s: %w", packagePolicyID, err))
+				return err
+			}
+
+			// The integration always belongs to the agent policy it is declared in
+			delete(packagePolicy, "id")
+			delete(packagePolicy, "policy_ids")
+			packagePolicy["policy_id"] = policyID
+			if name, _ := packagePolicy["name"].(string); name == "" {
+				packagePolicy["name"] = packagePolicyID
+			}
+
+			logger.Info(fmt.Sprintf("Applying package policy %s to agent policy %s", packagePolicyID, policyID))
+			if err := r.applyObject(ctx, kibanaConnection, space, "/api/fleet/package_policies", packagePolicyID, packagePolicy); err != nil {
+				logger.Error(err, fmt.Sprintf("Failed to apply package policy %s", packagePolicyID))
+				r.SetError(ctx, resource, fmt.Errorf("failed to apply package policy %s: %w", packagePolicyID, err))
+				return err
+			}
+			newAppliedPackagePolicies = append(newAppliedPackagePolicies, packagePolicyID)
+			logger.Info(fmt.Sprintf("Package policy %s applied successfully", packagePolicyID))
+		}
+	}
+
+	// Step 6: Update the Status with the new list of applied agent policies and integrations
+	resource.Status.Space = space
+	resource.Status.AppliedPackagePolicies = newAppliedPackagePolicies
+	targetKibana := fmt.Sprintf("%s/%s", kibanaSelector.Namespace, kibanaSelector.Name)
+	r.SetReady(ctx, resource, targetKibana, newAppliedPolicies)
+
+	logger.Info(fmt.Sprintf("FleetAgentPolicy %s/%s synced successfully", resource.Namespace, resource.Name))
+
+	return nil
+}
+
+// applyObject updates a Fleet agent policy or package policy, creating it with the given ID when it doesn't exist yet
+func (r *FleetAgentPolicyReconciler) applyObject(ctx context.Context, kibanaConnection *pools.KibanaConnection, space, basePath, id string, body map[string]interface{}) error {
+	logger := log.FromContext(ctx)
+
+	// PUT /s/{space}/api/fleet/{agent_policies|package_policies}/{id}
+	res, err := r.performRequest(ctx, kibanaConnection, http.MethodPut, space, fmt.Sprintf("%s/%s", basePath, id), body)
+	if err != nil {
+		return fmt.Errorf("failed to update %s: %w", id, err)
+	}
+	if res.StatusCode != http.StatusNotFound {
+		return r.expectSuccess(res, nil)
+	}
+	res.Body.Close()
+
+	// If the object doesn't exist (404), create it. Fleet only accepts the ID on creation
+	logger.Info(fmt.Sprintf("Object %s not found in Fleet, creating it", id))
+	create := make(map[string]interface{}, len(body)+1)
+	for field, value := range body {
+		create[field] = value
+	}
+	create["id"] = id
+
+	// POST /s/{space}/api/fleet/{agent_policies|package_policies}
+	return r.expectSuccess(r.performRequest(ctx, kibanaConnection, http.MethodPost, space, basePath, create))
+}
+
+// deleteAgentPolicy deletes an agent policy from Fleet. Fleet refuses it while agents are still enrolled in the policy
+func (r *FleetAgentPolicyReconciler) deleteAgentPolicy(ctx context.Context, kibanaConnection *pools.KibanaConnection, space, policyID string) error {
+	logger := log.FromContext(ctx)
+
+	// POST /s/{space}/api/fleet/agent_policies/delete
+	res, err := r.performRequest(ctx, kibanaConnection, http.MethodPost, space, "/api/fleet/agent_policies/delete",
+		map[string]interface{}{"agentPolicyId": policyID})
+	if err != nil {
+		return fmt.Errorf("failed to delete agent policy: %w", err)
+	}
+
+	// If the agent policy doesn't exist (404), consider it already deleted
+	if res.StatusCode == http.StatusNotFound {
+		res.Body.Close()
+		logger.Info(fmt.Sprintf("Agent policy %s not found in Fleet (already deleted)", policyID))
+		return nil
+	}
+
+	return r.expectSuccess(res, nil)
+}
+
+// deletePackagePolicy deletes an integration from its agent policy
+func (r *FleetAgentPolicyReconciler) deletePackagePolicy(ctx context.Context, kibanaConnection *pools.KibanaConnection, space, packagePolicyID string) error {
+	logger := log.FromContext(ctx)
+
+	// DELETE /s/{space}/api/fleet/package_policies/{id}
+	res, err := r.performRequest(ctx, kibanaConnection, http.MethodDelete, space,
+		fmt.Sprintf("/api/fleet/package_policies/%s", packagePolicyID), nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete package policy: %w", err)
+	}
+
+	// If the package policy doesn't exist (404), consider it already deleted
+	if res.StatusCode == http.StatusNotFound {
+		res.Body.Close()
+		logger.Info(fmt.Sprintf("Package policy %s not found in Fleet (already deleted)", packagePolicyID))
+		return nil
+	}
+
+	return r.expectSuccess(res, nil)
+}
+
+// performRequest sends a JSON request to the Kibana API
+func (r *FleetAgentPolicyReconciler) performRequest(ctx context.Context, kibanaConnection *pools.KibanaConnection, method, space, path string, body map[string]interface{}) (*http.Response, error) {
+	var bodyReader io.Reader
+	contentType := ""
+	if body != nil {
+		bodyJSON, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		bodyReader = bytes.NewReader(bodyJSON)
+		contentType = "application/json"
+	}
+
+	return globals.PerformKibanaRequest(ctx, kibanaConnection, method, space, path, bodyReader, contentType)
+}
+
+// expectSuccess closes the response and turns any error status into an error
+func (r *FleetAgentPolicyReconciler) expectSuccess(res *http.Response, err error) error {
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= 400 {
+		bodyBytes, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("kibana API error: %s - %s", res.Status, string(bodyBytes))
+	}
+
+	return nil
+}
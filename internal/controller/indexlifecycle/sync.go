@@ -0,0 +1,575 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package indexlifecycle
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+
+	"github.com/elastic/go-elasticsearch/v8"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/controller"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/globals"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/pools"
+)
+
+// Sync executes the synchronization of the lifecycle policies with the cluster, as ILM policies for Elasticsearch
+// and as ISM policies for OpenSearch
+func (r *IndexLifecycleReconciler) Sync(ctx context.Context, eventType watch.EventType, resource *v1alpha1.IndexLifecycle) (err error) {
+
+	logger := log.FromContext(ctx)
+
+	// The target cluster is resolved without changing the spec: the NamespaceDefaultCluster is used when the resource
+	// doesn't select one, and the namespace of the resource when the selector has none
+	resourceSelector, err := globals.ResolveResourceSelector(ctx, &resource.Spec.ResourceSelector, resource.Namespace)
+	if err != nil {
+		logger.Error(err, "Failed to resolve the target cluster")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	// Check the namespace of the resource is allowed to target the cluster
+	if err := globals.AuthorizeClusterAccess(ctx, resourceSelector, resource.Namespace, &resource.Status.Conditions); err != nil {
+		logger.Error(err, "Access to the target cluster denied")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	if err := globals.ValidateTLSVerification(resourceSelector, &resource.Status.Conditions); err != nil {
+		logger.Error(err, "TLS verification of the target cluster not configured")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	// Build the cluster key identifying the target cluster in the logs
+	clusterKey := fmt.Sprintf("%s_%s", resourceSelector.Namespace, resourceSelector.Name)
+
+	if eventType == watch.Deleted {
+		logger.Info(fmt.Sprintf("Deleting IndexLifecycle %s/%s", resource.Namespace, resource.Name))
+
+		marker := globals.ManagedByMarker(controller.IndexLifecycleResourceType, resource)
+
+		// Get the connection to delete the policies
+		esConnection, err := globals.GetOrCreateElasticsearchConnection(ctx, resourceSelector, resource.Namespace, r.ElasticsearchConnectionsPool)
+		if err != nil {
+			logger.Error(err, "Failed to get the cluster connection for deletion")
+			return err
+		}
+
+		// Writes are serialized with the other resources targeting the same cluster
+		unlock, err := globals.LockCluster(ctx, esConnection.Endpoint)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+
+		// Delete each policy from the cluster, but the ones another resource still declares
+		for _, policyName := range resource.Status.AppliedResources {
+			if globals.ClaimedByOthers(esConnection.Endpoint, globals.ClaimTypeLifecyclePolicy, marker, policyName) {
+				logger.Info(fmt.Sprintf("Policy %s is declared by another resource, leaving it in the cluster", policyName))
+				continue
+			}
+			if err := r.deletePolicy(ctx, esConnection, policyName); err != nil {
+				logger.Error(err, fmt.Sprintf("Failed to delete %s policy %s", lifecycleAPI(esConnection.ClusterType), policyName))
+				return err
+			}
+			logger.Info(fmt.Sprintf("%s policy %s deleted successfully", lifecycleAPI(esConnection.ClusterType), policyName))
+		}
+
+		return nil
+	}
+
+	logger.Info(fmt.Sprintf("Syncing IndexLifecycle %s/%s", resource.Namespace, resource.Name))
+
+	// Set status to Syncing at the beginning
+	r.SetSyncing(ctx, resource)
+
+	// Step 1: Get or create the cluster connection
+	esConnection, err := globals.GetOrCreateElasticsearchConnection(ctx, resourceSelector, resource.Namespace, r.ElasticsearchConnectionsPool)
+	globals.UpdateClusterReachableCondition(&resource.Status.Conditions, err)
+	if err != nil {
+		logger.Error(err, "Failed to get or create the cluster connection")
+		if errors.Is(err, globals.ErrClusterNotReady) {
+			r.SetWaitingForCluster(ctx, resource, err)
+			return err
+		}
+		r.SetError(ctx, resource, fmt.Errorf("%w to the cluster: %w", globals.ErrConnectionFailed, err))
+		return err
+	}
+
+	// Writes are serialized with the other resources targeting the same cluster
+	unlock, err := globals.LockCluster(ctx, esConnection.Endpoint)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	logger.Info(fmt.Sprintf("Connection established for cluster %s (type: %s, version: %s)", clusterKey, esConnection.ClusterType, esConnection.Version))
+
+	// Step 2: Nothing is written while the policies take the namespace over its quota of objects on the cluster, or
+	// an older resource declares some of them for the same cluster too, e.g. an IndexLifecyclePolicy, so the
+	// resources do not overwrite each other on every sync
+	policyNames := make([]string, 0, len(resource.Spec.Policies))
+	for policyName := range resource.Spec.Policies {
+		policyNames = append(policyNames, policyName)
+	}
+	sort.Strings(policyNames)
+
+	marker := globals.ManagedByMarker(controller.IndexLifecycleResourceType, resource)
+	if err := globals.CheckNamespaceQuota(esConnection.Endpoint, marker, resource, len(policyNames), false); err != nil {
+		logger.Error(err, "Policies rejected")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+	conflicts := globals.ClaimObjects(esConnection.Endpoint, globals.ClaimTypeLifecyclePolicy, marker, resource, policyNames, false)
+	globals.RecordConflicts(r.Recorder, resource, &resource.Status.Conditions, conflicts)
+	if len(conflicts) > 0 {
+		err := globals.ResourceErrors(conflicts)
+		logger.Error(err, "Policies declared by another resource")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	// Step 3: Delete the policies that are no longer desired
+	for _, policyName := range resource.Status.AppliedResources {
+		if _, desired := resource.Spec.Policies[policyName]; desired {
+			continue
+		}
+		if globals.ClaimedByOthers(esConnection.Endpoint, globals.ClaimTypeLifecyclePolicy, marker, policyName) {
+			logger.Info(fmt.Sprintf("Policy %s is no longer desired but declared by another resource, leaving it in the cluster", policyName))
+			continue
+		}
+		logger.Info(fmt.Sprintf("Policy %s is no longer desired, deleting from the cluster", policyName))
+		if err := r.deletePolicy(ctx, esConnection, policyName); err != nil {
+			logger.Error(err, fmt.Sprintf("Failed to delete %s policy %s", lifecycleAPI(esConnection.ClusterType), policyName))
+			r.SetError(ctx, resource, fmt.Errorf("failed to delete policy %s: %w", policyName, err))
+			return err
+		}
+		globals.RecordPruned(r.Recorder, resource, policyName)
+	}
+
+	// Step 4: Apply the desired policies, translated to the lifecycle API of the cluster. Policies are only written
+	// when they changed, as every write creates a new version of the policy
+	for _, policyName := range policyNames {
+		desiredPolicy, err := translatePolicy(esConnection.ClusterType, resource.Spec.Policies[policyName])
+		if err != nil {
+			logger.Error(err, fmt.Sprintf("Failed to translate policy %s", policyName))
+			r.SetError(ctx, resource, err)
+			return err
+		}
+
+		livePolicy, exists, err := r.getPolicy(ctx, esConnection, policyName)
+		if err != nil {
+			logger.Error(err, fmt.Sprintf("Failed to get %s policy %s", lifecycleAPI(esConnection.ClusterType), policyName))
+			r.SetError(ctx, resource, fmt.Errorf("failed to get policy %s: %w", policyName, err))
+			return err
+		}
+		if exists && len(globals.DiffJSON(desiredPolicy, livePolicy)) == 0 {
+			logger.Info(fmt.Sprintf("%s policy %s is up to date, skipping", lifecycleAPI(esConnection.ClusterType), policyName))
+			continue
+		}
+
+		if err := r.applyPolicy(ctx, esConnection, policyName, desiredPolicy); err != nil {
+			logger.Error(err, fmt.Sprintf("Failed to apply %s policy %s", lifecycleAPI(esConnection.ClusterType), policyName))
+			r.SetError(ctx, resource, fmt.Errorf("failed to apply policy %s: %w", policyName, err))
+			return err
+		}
+		logger.Info(fmt.Sprintf("%s policy %s applied successfully", lifecycleAPI(esConnection.ClusterType), policyName))
+	}
+
+	// Step 5: Update the Status with the new list of applied policies
+	targetCluster := fmt.Sprintf("%s/%s", resourceSelector.Namespace, resourceSelector.Name)
+	r.SetReady(ctx, resource, targetCluster, esConnection.ClusterType, policyNames)
+
+	logger.Info(fmt.Sprintf("IndexLifecycle %s/%s synced successfully", resource.Namespace, resource.Name))
+
+	return nil
+}
+
+// lifecycleAPI returns the name of the lifecycle API of a cluster type: ISM for OpenSearch, and ILM for
+// Elasticsearch
+func lifecycleAPI(clusterType string) string {
+	if clusterType == "opensearch" {
+		return "ISM"
+	}
+	return "ILM"
+}
+
+// translatePolicy returns the body of a lifecycle policy for the lifecycle API of the cluster type, without the
+// "policy" wrapper of the requests. The body is normalized to its JSON form, so it compares with the live policies
+func translatePolicy(clusterType string, policy v1alpha1.LifecyclePolicy) (map[string]interface{}, error) {
+	var body map[string]interface{}
+	if clusterType == "opensearch" {
+		body = ismPolicyBody(policy)
+	} else {
+		body = ilmPolicyBody(policy)
+	}
+
+	bodyJSON, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal policy: %w", err)
+	}
+	var normalized map[string]interface{}
+	if err := json.Unmarshal(bodyJSON, &normalized); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal policy: %w", err)
+	}
+	return normalized, nil
+}
+
+// ilmPolicyBody returns the ILM policy of a lifecycle policy: a hot phase rolling the indices over, and a delete
+// phase deleting them at the retention age, which ILM counts from the rollover
+func ilmPolicyBody(policy v1alpha1.LifecyclePolicy) map[string]interface{} {
+	phases := make(map[string]interface{})
+	if rollover := policy.Rollover; rollover != nil {
+		conditions := make(map[string]interface{})
+		if rollover.MaxAge != "" {
+			conditions["max_age"] = rollover.MaxAge
+		}
+		if rollover.MaxPrimaryShardSize != "" {
+			conditions["max_primary_shard_size"] = rollover.MaxPrimaryShardSize
+		}
+		if rollover.MaxDocs != nil {
+			conditions["max_docs"] = *rollover.MaxDocs
+		}
+		phases["hot"] = map[string]interface{}{
+			"min_age": "0ms",
+			"actions": map[string]interface{}{"rollover": conditions},
+		}
+	}
+	if policy.Retention != "" {
+		phases["delete"] = map[string]interface{}{
+			"min_age": policy.Retention,
+			"actions": map[string]interface{}{"delete": map[string]interface{}{}},
+		}
+	}
+
+	body := map[string]interface{}{"phases": phases}
+	if policy.Description != "" {
+		body["_meta"] = map[string]interface{}{"description": policy.Description}
+	}
+	return body
+}
+
+// ismPolicyBody returns the ISM policy of a lifecycle policy: a hot state rolling the indices over, transitioning to
+// a delete state at the retention age. The age is counted from the rollover when the indices are rolled over, like
+// ILM does, and from their creation otherwise
+func ismPolicyBody(policy v1alpha1.LifecyclePolicy) map[string]interface{} {
+	hotActions := []interface{}{}
+	hotTransitions := []interface{}{}
+	if rollover := policy.Rollover; rollover != nil {
+		conditions := make(map[string]interface{})
+		if rollover.MaxAge != "" {
+			conditions["min_index_age"] = rollover.MaxAge
+		}
+		if rollover.MaxPrimaryShardSize != "" {
+			conditions["min_primary_shard_size"] = rollover.MaxPrimaryShardSize
+		}
+		if rollover.MaxDocs != nil {
+			conditions["min_doc_count"] = *rollover.MaxDocs
+		}
+		hotActions = append(hotActions, map[string]interface{}{"rollover": conditions})
+	}
+
+	var deleteStates []interface{}
+	if policy.Retention != "" {
+		ageCondition := "min_index_age"
+		if policy.Rollover != nil {
+			ageCondition = "min_rollover_age"
+		}
+		hotTransitions = append(hotTransitions, map[string]interface{}{
+			"state_name": "delete",
+			"conditions": map[string]interface{}{ageCondition: policy.Retention},
+		})
+		deleteStates = append(deleteStates, map[string]interface{}{
+			"name":        "delete",
+			"actions":     []interface{}{map[string]interface{}{"delete": map[string]interface{}{}}},
+			"transitions": []interface{}{},
+		})
+	}
+	states := append([]interface{}{
+		map[string]interface{}{"name": "hot", "actions": hotActions, "transitions": hotTransitions},
+	}, deleteStates...)
+
+	body := map[string]interface{}{
+		"description":   policy.Description,
+		"default_state": "hot",
+		"states":        states,
+	}
+	if len(policy.IndexPatterns) > 0 {
+		body["ism_template"] = []interface{}{map[string]interface{}{
+			"index_patterns": slices.Clone(policy.IndexPatterns),
+			"priority":       policy.Priority,
+		}}
+	}
+	return body
+}
+
+// getPolicy returns the policy stored in the cluster, without the "policy" wrapper, and whether it exists
+func (r *IndexLifecycleReconciler) getPolicy(ctx context.Context, esConnection *pools.ElasticsearchConnection, policyName string) (map[string]interface{}, bool, error) {
+	if esConnection.ClusterType == "opensearch" {
+		policy, exists, err := r.getISMPolicy(ctx, esConnection.Client, policyName)
+		return policy.Policy, exists, err
+	}
+
+	policy, exists, err := r.getILMPolicy(ctx, esConnection.Client, policyName)
+	if err != nil || !exists {
+		return nil, exists, err
+	}
+	body, _ := policy["policy"].(map[string]interface{})
+	return body, true, nil
+}
+
+// applyPolicy creates or updates a policy in the cluster
+func (r *IndexLifecycleReconciler) applyPolicy(ctx context.Context, esConnection *pools.ElasticsearchConnection, policyName string, policy map[string]interface{}) error {
+	if esConnection.ClusterType == "opensearch" {
+		return r.applyISMPolicy(ctx, esConnection.Client, policyName, policy)
+	}
+	return r.applyILMPolicy(ctx, esConnection.Client, policyName, policy)
+}
+
+// deletePolicy deletes a policy from the cluster
+func (r *IndexLifecycleReconciler) deletePolicy(ctx context.Context, esConnection *pools.ElasticsearchConnection, policyName string) error {
+	if esConnection.ClusterType == "opensearch" {
+		return r.deleteISMPolicy(ctx, esConnection.Client, policyName)
+	}
+	return r.deleteILMPolicy(ctx, esConnection.Client, policyName)
+}
+
+// applyILMPolicy creates or updates an ILM policy in Elasticsearch
+func (r *IndexLifecycleReconciler) applyILMPolicy(ctx context.Context, esClient *elasticsearch.Client, policyName string, policy map[string]interface{}) error {
+	policyJSON, err := json.Marshal(map[string]interface{}{"policy": policy})
+	if err != nil {
+		return fmt.Errorf("failed to marshal policy: %w", err)
+	}
+
+	// PutLifecycle is idempotent - creates or updates
+	res, err := esClient.ILM.PutLifecycle(
+		policyName,
+		esClient.ILM.PutLifecycle.WithBody(bytes.NewReader(policyJSON)),
+		esClient.ILM.PutLifecycle.WithContext(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to apply ILM policy: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		bodyBytes, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("elasticsearch API error: %s - %s", res.Status(), string(bodyBytes))
+	}
+
+	return nil
+}
+
+// getILMPolicy returns the ILM policy stored in Elasticsearch ({"policy": {...}} along with its metadata),
+// and whether it exists
+func (r *IndexLifecycleReconciler) getILMPolicy(ctx context.Context, esClient *elasticsearch.Client, policyName string) (map[string]interface{}, bool, error) {
+	res, err := esClient.ILM.GetLifecycle(
+		esClient.ILM.GetLifecycle.WithPolicy(policyName),
+		esClient.ILM.GetLifecycle.WithContext(ctx),
+	)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get ILM policy: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, false, nil
+	}
+
+	bodyBytes, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if res.IsError() {
+		return nil, false, fmt.Errorf("elasticsearch API error: %s - %s", res.Status(), string(bodyBytes))
+	}
+
+	var policies map[string]map[string]interface{}
+	if err := json.Unmarshal(bodyBytes, &policies); err != nil {
+		return nil, false, fmt.Errorf("failed to parse ILM policy: %w", err)
+	}
+
+	policy, exists := policies[policyName]
+	return policy, exists, nil
+}
+
+// deleteILMPolicy deletes an ILM policy from Elasticsearch
+func (r *IndexLifecycleReconciler) deleteILMPolicy(ctx context.Context, esClient *elasticsearch.Client, policyName string) error {
+	logger := log.FromContext(ctx)
+
+	res, err := esClient.ILM.DeleteLifecycle(
+		policyName,
+		esClient.ILM.DeleteLifecycle.WithContext(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete ILM policy: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		// If the policy doesn't exist (404), consider it already deleted
+		if res.StatusCode == http.StatusNotFound {
+			logger.Info(fmt.Sprintf("ILM policy %s not found in Elasticsearch (already deleted)", policyName))
+			return nil
+		}
+		bodyBytes, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("elasticsearch API error: %s - %s", res.Status(), string(bodyBytes))
+	}
+
+	return nil
+}
+
+// errISMPolicyChanged is returned when an ISM policy changed between reading its version and updating it
+var errISMPolicyChanged = errors.New("ISM policy changed while updating it")
+
+// storedISMPolicy is an ISM policy stored in OpenSearch, with the sequence number and primary term of its version,
+// required to update it
+type storedISMPolicy struct {
+	SeqNo       int64                  `json:"_seq_no"`
+	PrimaryTerm int64                  `json:"_primary_term"`
+	Policy      map[string]interface{} `json:"policy"`
+}
+
+// applyISMPolicy creates or updates an ISM policy in OpenSearch. Updates of existing policies are conditioned to
+// their current version, read again when the policy changed in the meantime
+func (r *IndexLifecycleReconciler) applyISMPolicy(ctx context.Context, esClient *elasticsearch.Client, policyName string, policy map[string]interface{}) error {
+	policyJSON, err := json.Marshal(map[string]interface{}{"policy": policy})
+	if err != nil {
+		return fmt.Errorf("failed to marshal policy: %w", err)
+	}
+
+	return retry.OnError(retry.DefaultRetry, func(err error) bool {
+		return errors.Is(err, errISMPolicyChanged)
+	}, func() error {
+		current, exists, err := r.getISMPolicy(ctx, esClient, policyName)
+		if err != nil {
+			return err
+		}
+
+		// PUT /_plugins/_ism/policies/{policy_name}?if_seq_no={seq_no}&if_primary_term={primary_term}
+		path := fmt.Sprintf("/_plugins/_ism/policies/%s", policyName)
+		if exists {
+			query := url.Values{}
+			query.Set("if_seq_no", strconv.FormatInt(current.SeqNo, 10))
+			query.Set("if_primary_term", strconv.FormatInt(current.PrimaryTerm, 10))
+			path += "?" + query.Encode()
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "PUT", path, bytes.NewReader(policyJSON))
+		if err != nil {
+			return fmt.Errorf("failed to create request: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		res, err := esClient.Perform(req)
+		if err != nil {
+			return fmt.Errorf("failed to apply ISM policy: %w", err)
+		}
+		defer res.Body.Close()
+
+		// The policy was changed, or created, since its version was read
+		if res.StatusCode == http.StatusConflict {
+			bodyBytes, _ := io.ReadAll(res.Body)
+			return fmt.Errorf("%w: %s", errISMPolicyChanged, string(bodyBytes))
+		}
+
+		if res.StatusCode >= 400 {
+			bodyBytes, _ := io.ReadAll(res.Body)
+			return fmt.Errorf("OpenSearch API error: %s - %s", res.Status, string(bodyBytes))
+		}
+
+		return nil
+	})
+}
+
+// getISMPolicy returns the ISM policy stored in OpenSearch with its version, and whether it exists
+func (r *IndexLifecycleReconciler) getISMPolicy(ctx context.Context, esClient *elasticsearch.Client, policyName string) (storedISMPolicy, bool, error) {
+	// GET /_plugins/_ism/policies/{policy_name}
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("/_plugins/_ism/policies/%s", policyName), nil)
+	if err != nil {
+		return storedISMPolicy{}, false, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	res, err := esClient.Perform(req)
+	if err != nil {
+		return storedISMPolicy{}, false, fmt.Errorf("failed to get ISM policy: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return storedISMPolicy{}, false, nil
+	}
+
+	bodyBytes, err := io.ReadAll(res.Body)
+	if err != nil {
+		return storedISMPolicy{}, false, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if res.StatusCode >= 400 {
+		return storedISMPolicy{}, false, fmt.Errorf("OpenSearch API error: %s - %s", res.Status, string(bodyBytes))
+	}
+
+	var policy storedISMPolicy
+	if err := json.Unmarshal(bodyBytes, &policy); err != nil {
+		return storedISMPolicy{}, false, fmt.Errorf("failed to parse ISM policy: %w", err)
+	}
+
+	return policy, true, nil
+}
+
+// deleteISMPolicy deletes an ISM policy from OpenSearch
+func (r *IndexLifecycleReconciler) deleteISMPolicy(ctx context.Context, esClient *elasticsearch.Client, policyName string) error {
+	logger := log.FromContext(ctx)
+
+	// DELETE /_plugins/_ism/policies/{policy_name}
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("/_plugins/_ism/policies/%s", policyName), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	res, err := esClient.Perform(req)
+	if err != nil {
+		return fmt.Errorf("failed to delete ISM policy: %w", err)
+	}
+	defer res.Body.Close()
+
+	// If the policy doesn't exist (404), consider it already deleted
+	if res.StatusCode == http.StatusNotFound {
+		logger.Info(fmt.Sprintf("ISM policy %s not found in OpenSearch (already deleted)", policyName))
+		return nil
+	}
+
+	if res.StatusCode >= 400 {
+		bodyBytes, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("OpenSearch API error: %s - %s", res.Status, string(bodyBytes))
+	}
+
+	return nil
+}
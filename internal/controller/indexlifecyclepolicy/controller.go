@@ -19,11 +19,12 @@ package indexlifecyclepolicy
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -31,6 +32,7 @@ import (
 
 	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
 	"elastic-config-operator.freepik.com/elastic-config-operator/internal/controller"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/globals"
 	"elastic-config-operator.freepik.com/elastic-config-operator/internal/pools"
 )
 
@@ -39,6 +41,7 @@ type IndexLifecyclePolicyReconciler struct {
 	client.Client
 	Scheme                       *runtime.Scheme
 	ElasticsearchConnectionsPool *pools.ElasticsearchConnectionsStore
+	Recorder                     record.EventRecorder
 }
 
 // +kubebuilder:rbac:groups=elastic-config-operator.freepik.com,resources=indexlifecyclepolicies,verbs=get;list;watch;create;update;patch;delete
@@ -47,19 +50,12 @@ type IndexLifecyclePolicyReconciler struct {
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
 // +kubebuilder:rbac:groups=elasticsearch.k8s.elastic.co,resources=elasticsearches,verbs=get;list;watch
 
-// Reconcile is part of the main kubernetes reconciliation loop which aims to
-// move the current state of the cluster closer to the desired state.
-// TODO(user): Modify the Reconcile function to compare the state specified by
-// the IndexLifecyclePolicy object against the actual cluster state, and then
-// perform operations to make the cluster state reflect the state specified by
-// the user.
-//
-// For more details, check Reconcile and its Result here:
-// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.22.4/pkg/reconcile
+// Reconcile writes the ILM policies of an IndexLifecyclePolicy to its cluster on every syncInterval, and deletes them
+// from the cluster once the resource is deleted, unless its deletionPolicy retains them
 func (r *IndexLifecyclePolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
 	logger := logf.FromContext(ctx)
 
-	// 1. Get the content of the Patch
+	// 1. Get the content of the resource
 	indexLifecyclePolicyResource := &v1alpha1.IndexLifecyclePolicy{}
 	err = r.Get(ctx, req.NamespacedName, indexLifecyclePolicyResource)
 
@@ -77,16 +73,53 @@ func (r *IndexLifecyclePolicyReconciler) Reconcile(ctx context.Context, req ctrl
 		return result, err
 	}
 
+	// Suspended resources are not reconciled, even when deleted: the cluster and their status are left untouched until
+	// they are resumed
+	if indexLifecyclePolicyResource.Spec.Suspend {
+		logger.Info(fmt.Sprintf(controller.ResourceSuspendedMessage, controller.IndexLifecyclePolicyResourceType, req.NamespacedName))
+		return result, nil
+	}
+
+	// Resources selecting their clusters by labels are synced through the resources created for every cluster
+	if len(indexLifecyclePolicyResource.Spec.ResourceSelector.MatchLabels) > 0 {
+		logger.Info(fmt.Sprintf(controller.ResourceSelectsClustersByLabelsMessage, controller.IndexLifecyclePolicyResourceType, req.NamespacedName))
+		return result, nil
+	}
+
 	// 3. Check if the IndexLifecyclePolicy instance is marked to be deleted: indicated by the deletion timestamp being set
 	if !indexLifecyclePolicyResource.DeletionTimestamp.IsZero() {
 		if controllerutil.ContainsFinalizer(indexLifecyclePolicyResource, controller.ResourceFinalizer) {
+			original := indexLifecyclePolicyResource.DeepCopy()
+
+			// The objects claimed by the resource, and counted in the quota of its namespace, are released even when
+			// their cleanup is skipped or fails, so the newer resources declaring them are not left in conflict
+			globals.ReleaseObjects(globals.ClaimTypeLifecyclePolicy, globals.ManagedByMarker(controller.IndexLifecyclePolicyResourceType, indexLifecyclePolicyResource))
+			globals.ReleaseNamespaceQuota(globals.ManagedByMarker(controller.IndexLifecyclePolicyResourceType, indexLifecyclePolicyResource))
+
+			// 3.1 Delete the resources associated with the IndexLifecyclePolicy, unless the deletion policy retains them
+			if indexLifecyclePolicyResource.Spec.DeletionPolicy == v1alpha1.DeletionPolicyRetain {
+				logger.Info(fmt.Sprintf(controller.ResourceRetainedMessage, controller.IndexLifecyclePolicyResourceType, req.NamespacedName))
+			} else if globals.TargetClusterGone(ctx, &indexLifecyclePolicyResource.Spec.ResourceSelector, indexLifecyclePolicyResource.Namespace) {
+				logger.Info(fmt.Sprintf(controller.ResourceTargetGoneMessage, controller.IndexLifecyclePolicyResourceType, req.NamespacedName))
+			} else {
+				err = r.Sync(ctx, watch.Deleted, indexLifecyclePolicyResource)
+			}
 
-			// 3.1 Delete the resources associated with the SearchRule
-			err = r.Sync(ctx, watch.Deleted, indexLifecyclePolicyResource)
+			// Failed cleanups are retried with backoff, keeping the finalizer and reporting why in the status, until they
+			// are given up
+			if globals.RetryCleanup(indexLifecyclePolicyResource, err) {
+				logger.Info(fmt.Sprintf(controller.ResourceCleanupError, controller.IndexLifecyclePolicyResourceType, req.NamespacedName, err.Error()))
+				if updateErr := globals.PatchStatus(ctx, r.Client, indexLifecyclePolicyResource, original); updateErr != nil {
+					logger.Info(fmt.Sprintf(controller.ResourceConditionUpdateError, controller.IndexLifecyclePolicyResourceType, req.NamespacedName, updateErr.Error()))
+				}
+				return result, err
+			}
+			if err != nil {
+				logger.Info(fmt.Sprintf(controller.ResourceCleanupGivenUpMessage, controller.IndexLifecyclePolicyResourceType, req.NamespacedName, err.Error()))
+			}
 
-			// Remove the finalizers on Patch CR
-			controllerutil.RemoveFinalizer(indexLifecyclePolicyResource, controller.ResourceFinalizer)
-			err = r.Update(ctx, indexLifecyclePolicyResource)
+			// Remove the finalizers on IndexLifecyclePolicy CR
+			err = globals.RemoveFinalizer(ctx, r.Client, indexLifecyclePolicyResource, controller.ResourceFinalizer)
 			if err != nil {
 				logger.Info(fmt.Sprintf(controller.ResourceFinalizersUpdateError, controller.IndexLifecyclePolicyResourceType, req.NamespacedName, err.Error()))
 			}
@@ -97,29 +130,26 @@ func (r *IndexLifecyclePolicyReconciler) Reconcile(ctx context.Context, req ctrl
 		return result, err
 	}
 
-	// 4. Add finalizer to the SearchRule CR
+	// 4. Add finalizer to the IndexLifecyclePolicy CR
 	if !controllerutil.ContainsFinalizer(indexLifecyclePolicyResource, controller.ResourceFinalizer) {
-		controllerutil.AddFinalizer(indexLifecyclePolicyResource, controller.ResourceFinalizer)
-		err = r.Update(ctx, indexLifecyclePolicyResource)
+		err = globals.AddFinalizer(ctx, r.Client, indexLifecyclePolicyResource, controller.ResourceFinalizer)
 		if err != nil {
 			return result, err
 		}
 	}
 
-	// 5. Update the status before the requeue
+	// 5. Update the status before the requeue, writing the changes made to it during the reconcile at once
+	original := indexLifecyclePolicyResource.DeepCopy()
 	defer func() {
-		err = r.Status().Update(ctx, indexLifecyclePolicyResource)
-		if err != nil {
-			logger.Info(fmt.Sprintf(controller.ResourceConditionUpdateError, controller.IndexLifecyclePolicyResourceType, req.NamespacedName, err.Error()))
+		indexLifecyclePolicyResource.Status.ObservedGeneration = indexLifecyclePolicyResource.Generation
+		// The sync error is kept as the result, so failed syncs are requeued with backoff
+		if updateErr := globals.PatchStatus(ctx, r.Client, indexLifecyclePolicyResource, original); updateErr != nil {
+			logger.Info(fmt.Sprintf(controller.ResourceConditionUpdateError, controller.IndexLifecyclePolicyResourceType, req.NamespacedName, updateErr.Error()))
 		}
 	}()
 
 	// 6. Schedule periodical request
-	syncInterval := indexLifecyclePolicyResource.Spec.SyncInterval
-	if syncInterval == "" {
-		syncInterval = controller.DefaultSyncInterval
-	}
-	RequeueTime, err := time.ParseDuration(syncInterval)
+	RequeueTime, err := controller.RequeueInterval(indexLifecyclePolicyResource.Spec.SyncInterval)
 	if err != nil {
 		logger.Info(fmt.Sprintf(controller.ResourceSyncTimeRetrievalError, controller.IndexLifecyclePolicyResourceType, req.NamespacedName, err.Error()))
 		return result, err
@@ -128,9 +158,11 @@ func (r *IndexLifecyclePolicyReconciler) Reconcile(ctx context.Context, req ctrl
 		RequeueAfter: RequeueTime,
 	}
 
-	// 7. Check the rule
+	// 7. Sync the objects of the resource
 	err = r.Sync(ctx, watch.Modified, indexLifecyclePolicyResource)
 	if err != nil {
+		// Failed syncs are requeued with the error backoff instead of the syncInterval
+		result = ctrl.Result{}
 		r.UpdateConditionKubernetesApiCallFailure(indexLifecyclePolicyResource)
 		logger.Info(fmt.Sprintf(controller.SyncTargetError, controller.IndexLifecyclePolicyResourceType, req.NamespacedName, err.Error()))
 		return result, err
@@ -143,11 +175,25 @@ func (r *IndexLifecyclePolicyReconciler) Reconcile(ctx context.Context, req ctrl
 
 }
 
-// SetupWithManager sets up the controller with the Manager.
+// SetupWithManager sets up the controller with the Manager. The ECK clusters and the Secrets referenced by the
+// resources are watched too, so the resources are synced as soon as their cluster becomes ready or their Secrets change
 func (r *IndexLifecyclePolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&v1alpha1.IndexLifecyclePolicy{}).
-		WithEventFilter(predicate.GenerationChangedPredicate{}).
+	controllerBuilder := ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.IndexLifecyclePolicy{}, builder.WithPredicates(predicate.GenerationChangedPredicate{}))
+
+	controllerBuilder = globals.WatchECKClusters(controllerBuilder, mgr, &v1alpha1.IndexLifecyclePolicyList{}, func(object, cluster client.Object) bool {
+		return globals.SelectsECKCluster(&object.(*v1alpha1.IndexLifecyclePolicy).Spec.ResourceSelector, object.GetNamespace(), cluster)
+	})
+
+	controllerBuilder, err := globals.WatchReferencedSecrets(controllerBuilder, mgr, &v1alpha1.IndexLifecyclePolicy{}, &v1alpha1.IndexLifecyclePolicyList{}, func(object client.Object) []*v1alpha1.ResourceSelector {
+		return []*v1alpha1.ResourceSelector{&object.(*v1alpha1.IndexLifecyclePolicy).Spec.ResourceSelector}
+	})
+	if err != nil {
+		return err
+	}
+
+	return controllerBuilder.
 		Named("indexlifecyclepolicy").
+		WithOptions(globals.ControllerOptions()).
 		Complete(r)
 }
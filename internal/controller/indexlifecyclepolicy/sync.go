@@ -20,17 +20,26 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/elastic/go-elasticsearch/v8"
 	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	//
 	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/controller"
 	"elastic-config-operator.freepik.com/elastic-config-operator/internal/globals"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/pools"
 )
 
 // Sync execute the query to the elasticsearch and evaluate the condition. Then trigger the action adding the alert to the pool
@@ -39,32 +48,105 @@ func (r *IndexLifecyclePolicyReconciler) Sync(ctx context.Context, eventType wat
 
 	logger := log.FromContext(ctx)
 
-	// Get the ECK cluster associated to the resource
-	if resource.Spec.ResourceSelector.Namespace == "" {
-		resource.Spec.ResourceSelector.Namespace = resource.Namespace
+	// Resources only report the changes they would make in dry run or audit mode
+	dryRun := globals.IsDryRun(resource.Spec.DryRun)
+
+	// The structured policies are synced along with the raw ones
+	policyBodies, err := resource.Spec.PolicyBodies()
+	if err != nil {
+		logger.Error(err, "Failed to build the policies")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	// The target cluster is resolved without changing the spec: the NamespaceDefaultCluster is used when the resource
+	// doesn't select one, and the namespace of the resource when the selector has none
+	resourceSelector, err := globals.ResolveResourceSelector(ctx, &resource.Spec.ResourceSelector, resource.Namespace)
+	if err != nil {
+		logger.Error(err, "Failed to resolve the target cluster")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	// Check the namespace of the resource is allowed to target the cluster
+	if err := globals.AuthorizeClusterAccess(ctx, resourceSelector, resource.Namespace, &resource.Status.Conditions); err != nil {
+		logger.Error(err, "Access to the target cluster denied")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	if err := globals.ValidateTLSVerification(resourceSelector, &resource.Status.Conditions); err != nil {
+		logger.Error(err, "TLS verification of the target cluster not configured")
+		r.SetError(ctx, resource, err)
+		return err
 	}
 
-	// Build the cluster key for the pools
-	clusterKey := fmt.Sprintf("%s_%s", resource.Spec.ResourceSelector.Namespace, resource.Spec.ResourceSelector.Name)
+	// Build the cluster key identifying the target cluster in the logs
+	clusterKey := fmt.Sprintf("%s_%s", resourceSelector.Namespace, resourceSelector.Name)
 
 	if eventType == watch.Deleted {
 		logger.Info(fmt.Sprintf("Deleting IndexLifecyclePolicy %s/%s", resource.Namespace, resource.Name))
 
+		globals.DeletePendingChanges(controller.IndexLifecyclePolicyResourceType, resource.Namespace, resource.Name)
+
+		// Resources in dry run or audit mode never change Elasticsearch, not even when they are deleted
+		if dryRun {
+			logger.Info("Dry run enabled, leaving Elasticsearch unchanged")
+			return nil
+		}
+
 		// Get Elasticsearch connection to delete the policies
-		esConnection, err := globals.GetOrCreateElasticsearchConnection(ctx, clusterKey, &resource.Spec.ResourceSelector, resource.Namespace, r.ElasticsearchConnectionsPool)
+		esConnection, err := globals.GetOrCreateElasticsearchConnection(ctx, resourceSelector, resource.Namespace, r.ElasticsearchConnectionsPool)
 		if err != nil {
 			logger.Error(err, "Failed to get Elasticsearch connection for deletion")
 			return err
 		}
 
-		// Delete each ILM policy from Elasticsearch
-		for policyName := range resource.Spec.Resources {
-			logger.Info(fmt.Sprintf("Deleting ILM policy %s from Elasticsearch", policyName))
-			if err := r.deleteILMPolicy(ctx, esConnection.Client, policyName); err != nil {
-				logger.Error(err, fmt.Sprintf("Failed to delete ILM policy %s", policyName))
+		// Writes are serialized with the other resources targeting the same cluster
+		unlock, err := globals.LockCluster(ctx, esConnection.Endpoint)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+
+		// Delete each policy owned by the resource from the cluster, as ISM policies when they were converted for
+		// OpenSearch
+		api := lifecycleAPI(esConnection.ClusterType)
+		marker := globals.ManagedByMarker(controller.IndexLifecyclePolicyResourceType, resource)
+		blocked := make(map[string]error)
+		for policyName := range policyBodies {
+			owned, err := r.ownsPolicy(ctx, esConnection, policyName, marker, slices.Contains(resource.Status.AppliedResources, policyName))
+			if err != nil {
+				logger.Error(err, fmt.Sprintf("Failed to get %s policy %s", api, policyName))
+				return err
+			}
+			if !owned || globals.ClaimedByOthers(esConnection.Endpoint, globals.ClaimTypeLifecyclePolicy, marker, policyName) {
+				logger.Info(fmt.Sprintf("%s policy %s is owned by someone else, leaving it in the cluster", api, policyName))
+				continue
+			}
+			if !resource.Spec.Force {
+				if err := r.checkPolicyNotInUse(ctx, esConnection, policyName); err != nil {
+					if !errors.Is(err, globals.ErrObjectInUse) {
+						logger.Error(err, fmt.Sprintf("Failed to get %s policy %s", api, policyName))
+						return err
+					}
+					logger.Info(fmt.Sprintf("%s policy %s left in the cluster: %s", api, policyName, err))
+					blocked[policyName] = err
+					continue
+				}
+			}
+			logger.Info(fmt.Sprintf("Deleting %s policy %s from the cluster", api, policyName))
+			if err := r.deletePolicy(ctx, esConnection, policyName); err != nil {
+				logger.Error(err, fmt.Sprintf("Failed to delete %s policy %s", api, policyName))
 				return err
 			}
-			logger.Info(fmt.Sprintf("ILM policy %s deleted successfully", policyName))
+			logger.Info(fmt.Sprintf("%s policy %s deleted successfully", api, policyName))
+		}
+
+		// The deletion is retried until the policies in use are released, and given up with the other failed cleanups
+		if len(blocked) > 0 {
+			globals.UpdateDeletionBlockedCondition(&resource.Status.Conditions, blocked, "force")
+			return globals.ResourceErrors(blocked)
 		}
 
 		return nil
@@ -72,26 +154,89 @@ func (r *IndexLifecyclePolicyReconciler) Sync(ctx context.Context, eventType wat
 
 	logger.Info(fmt.Sprintf("Syncing IndexLifecyclePolicy %s/%s", resource.Namespace, resource.Name))
 
+	// Changes are postponed until the resources it depends on are Ready. Dry runs never change the cluster, so they
+	// are not gated
+	if !dryRun {
+		err = globals.CheckDependencies(ctx, resource.Spec.DependsOn, resource.Namespace)
+		globals.UpdateDependenciesReadyCondition(&resource.Status.Conditions, resource.Spec.DependsOn, err)
+		if err != nil {
+			logger.Error(err, "Failed to check the dependencies")
+			if errors.Is(err, globals.ErrDependenciesNotReady) {
+				r.SetWaitingForDependencies(ctx, resource, err)
+				return err
+			}
+			r.SetError(ctx, resource, err)
+			return err
+		}
+	}
+
+	// The ${key} references of the policies are replaced by the values of valuesFrom
+	policyBodies, err = globals.ExpandValues(ctx, resource.Spec.ValuesFrom, resource.Namespace, policyBodies)
+	if err != nil {
+		logger.Error(err, "Failed to expand the values of valuesFrom")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
 	// Set status to Syncing at the beginning
 	r.SetSyncing(ctx, resource)
 
 	// Step 1: Get or create Elasticsearch connection
-	esConnection, err := globals.GetOrCreateElasticsearchConnection(ctx, clusterKey, &resource.Spec.ResourceSelector, resource.Namespace, r.ElasticsearchConnectionsPool)
+	esConnection, err := globals.GetOrCreateElasticsearchConnection(ctx, resourceSelector, resource.Namespace, r.ElasticsearchConnectionsPool)
+	globals.UpdateClusterReachableCondition(&resource.Status.Conditions, err)
 	if err != nil {
 		logger.Error(err, "Failed to get or create Elasticsearch connection")
-		r.SetError(ctx, resource, fmt.Errorf("failed to connect to Elasticsearch: %w", err))
+		if errors.Is(err, globals.ErrClusterNotReady) {
+			r.SetWaitingForCluster(ctx, resource, err)
+			return err
+		}
+		r.SetError(ctx, resource, fmt.Errorf("%w to Elasticsearch: %w", globals.ErrConnectionFailed, err))
+		return err
+	}
+
+	// The Go templates of the policies are rendered with the cluster they are synced to
+	policyBodies, err = globals.RenderTemplates(resource.Spec.Templating, policyBodies, globals.NewTemplateData(resource, resourceSelector, esConnection))
+	if err != nil {
+		logger.Error(err, "Failed to render the templates of the policies")
+		r.SetError(ctx, resource, err)
 		return err
 	}
 
+	// Changes are postponed while the cluster is below the required health (e.g., while it is red). Dry runs never
+	// change the cluster, so they are not gated
+	if !dryRun {
+		err = globals.CheckClusterHealth(ctx, esConnection.Client, resource.Spec.RequiredClusterHealth)
+		globals.UpdateClusterHealthyCondition(&resource.Status.Conditions, resource.Spec.RequiredClusterHealth, err)
+		if err != nil {
+			logger.Error(err, "Failed to check the cluster health")
+			if errors.Is(err, globals.ErrClusterUnhealthy) {
+				r.SetWaitingForClusterHealth(ctx, resource, err)
+				return err
+			}
+			r.SetError(ctx, resource, err)
+			return err
+		}
+	}
+
+	// Writes are serialized with the other resources targeting the same cluster
+	unlock, err := globals.LockCluster(ctx, esConnection.Endpoint)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	logger.Info(fmt.Sprintf("Elasticsearch connection established for cluster %s (type: %s, version: %s)", clusterKey, esConnection.ClusterType, esConnection.Version))
 
-	// Validate cluster type - ILM is only available in Elasticsearch
-	if esConnection.ClusterType == "opensearch" {
-		err := fmt.Errorf("ILM (Index Lifecycle Management) is not available in OpenSearch. OpenSearch uses ISM (Index State Management) instead. Please use the IndexStateManagement CRD for OpenSearch clusters")
+	// Validate cluster type - ILM is only available in Elasticsearch, so the policies are only written to OpenSearch
+	// when they are converted to ISM policies
+	convert := esConnection.ClusterType == "opensearch"
+	if convert && resource.Spec.OpenSearchConversion != v1alpha1.OpenSearchConversionConvert {
+		err := fmt.Errorf("ILM (Index Lifecycle Management) is not available in OpenSearch. OpenSearch uses ISM (Index State Management) instead. Please use the IndexStateManagement CRD for OpenSearch clusters, or set openSearchConversion to Convert")
 		logger.Error(err, "Incompatible cluster type for IndexLifecyclePolicy")
 		r.SetError(ctx, resource, err)
 		return err
 	}
+	api := lifecycleAPI(esConnection.ClusterType)
 
 	// Step 2: Get the list of policies currently applied (from Status)
 	appliedPolicies := make(map[string]bool)
@@ -101,60 +246,261 @@ func (r *IndexLifecyclePolicyReconciler) Sync(ctx context.Context, eventType wat
 
 	// Step 3: Get the list of desired policies (from Spec)
 	desiredPolicies := make(map[string]bool)
-	for policyName := range resource.Spec.Resources {
+	for policyName := range policyBodies {
 		desiredPolicies[policyName] = true
 	}
 
+	// Changes made to Elasticsearch by this synchronization, reported in events and in the status
+	var changes []v1alpha1.ObjectChange
+
+	// A failing policy does not stop the synchronization of the other ones, its error is reported once all of
+	// them are synced
+	failed := make(map[string]error)
+	newAppliedPolicies := make([]string, 0, len(policyBodies))
+	newResources := make(map[string]v1alpha1.ResourceStatus, len(policyBodies))
+
+	// Objects are marked with the resource that wrote them, so the ones owned by someone else are left untouched
+	marker := globals.ManagedByMarker(controller.IndexLifecyclePolicyResourceType, resource)
+	conflicts := make(map[string]error)
+
+	// Policies still in use are not deleted unless forced, as deleting them breaks the rollover of the live data
+	// streams using them
+	blocked := make(map[string]error)
+
+	// Resources taking their namespace over its quota of objects on the cluster are rejected before changing anything
+	if err := globals.CheckNamespaceQuota(esConnection.Endpoint, marker, resource, len(policyBodies), dryRun); err != nil {
+		logger.Error(err, "Policies rejected")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	// Policies declared by an older resource for the same cluster too, e.g. by an IndexLifecycle, are left to it
+	// whatever the adoption policy
+	claimConflicts := globals.ClaimObjects(esConnection.Endpoint, globals.ClaimTypeLifecyclePolicy, marker, resource,
+		slices.Collect(maps.Keys(desiredPolicies)), dryRun)
+
+	// Parts of the policies left out of their conversion to ISM policies, keyed by policy name
+	var converted map[string][]string
+	if convert {
+		converted = make(map[string][]string, len(policyBodies))
+	}
+
 	// Step 4: Delete policies that are no longer desired
 	for policyName := range appliedPolicies {
 		if !desiredPolicies[policyName] {
-			logger.Info(fmt.Sprintf("Policy %s is no longer desired, deleting from Elasticsearch", policyName))
-			if err := r.deleteILMPolicy(ctx, esConnection.Client, policyName); err != nil {
-				logger.Error(err, fmt.Sprintf("Failed to delete ILM policy %s", policyName))
-				return err
+			owned, err := r.ownsPolicy(ctx, esConnection, policyName, marker, true)
+			if err != nil {
+				logger.Error(err, fmt.Sprintf("Failed to get %s policy %s", api, policyName))
+				failed[policyName] = fmt.Errorf("failed to delete: %w", err)
+				continue
+			}
+			if !owned || globals.ClaimedByOthers(esConnection.Endpoint, globals.ClaimTypeLifecyclePolicy, marker, policyName) {
+				logger.Info(fmt.Sprintf("Policy %s is no longer desired but owned by someone else, leaving it in the cluster", policyName))
+				continue
+			}
+			if !resource.Spec.Force {
+				if err := r.checkPolicyNotInUse(ctx, esConnection, policyName); err != nil {
+					logger.Info(fmt.Sprintf("Policy %s is no longer desired but left in the cluster: %s", policyName, err))
+					if errors.Is(err, globals.ErrObjectInUse) {
+						blocked[policyName] = err
+					}
+					failed[policyName] = fmt.Errorf("failed to delete: %w", err)
+					continue
+				}
+			}
+			logger.Info(fmt.Sprintf("Policy %s is no longer desired, deleting from the cluster", policyName))
+			change := v1alpha1.ObjectChange{Name: policyName, Action: globals.ChangeActionDelete}
+			globals.RecordChange(r.Recorder, resource, change, dryRun)
+			changes = append(changes, change)
+			if dryRun {
+				continue
 			}
-			logger.Info(fmt.Sprintf("ILM policy %s deleted successfully", policyName))
+			if err := r.deletePolicy(ctx, esConnection, policyName); err != nil {
+				logger.Error(err, fmt.Sprintf("Failed to delete %s policy %s", api, policyName))
+				failed[policyName] = fmt.Errorf("failed to delete: %w", err)
+				continue
+			}
+			logger.Info(fmt.Sprintf("%s policy %s deleted successfully", api, policyName))
+			globals.RecordPruned(r.Recorder, resource, policyName)
 		}
 	}
 
-	// Step 5: Apply all desired policies (idempotent)
-	newAppliedPolicies := make([]string, 0, len(resource.Spec.Resources))
-	for policyName, policyResource := range resource.Spec.Resources {
-		logger.Info(fmt.Sprintf("Processing ILM policy: %s", policyName))
+	// Step 5: Apply the desired policies that changed in the spec or in the cluster since they were last applied,
+	// detecting the out-of-band changes of the policies whose desired body did not change
+	drifted := make(map[string][]string)
+	for policyName, policyResource := range policyBodies {
+		logger.Info(fmt.Sprintf("Processing %s policy: %s", api, policyName))
+
+		if claimErr := claimConflicts[policyName]; claimErr != nil {
+			logger.Info(fmt.Sprintf("%s policy %s left untouched: %s", api, policyName, claimErr))
+			conflicts[policyName] = claimErr
+			failed[policyName] = claimErr
+			continue
+		}
 
 		// Parse the desired policy from the resource
 		var desiredPolicy map[string]interface{}
 		policyJSON, err := policyResource.MarshalJSON()
 		if err != nil {
 			logger.Error(err, fmt.Sprintf("Failed to marshal policy %s", policyName))
-			return err
+			failed[policyName] = err
+			continue
 		}
 		if err := json.Unmarshal(policyJSON, &desiredPolicy); err != nil {
 			logger.Error(err, fmt.Sprintf("Failed to unmarshal policy %s", policyName))
-			return err
+			failed[policyName] = err
+			continue
 		}
 
-		// Apply the policy (PutLifecycle is idempotent - creates or updates)
-		if err := r.applyILMPolicy(ctx, esConnection.Client, policyName, desiredPolicy); err != nil {
-			logger.Error(err, fmt.Sprintf("Failed to apply ILM policy %s", policyName))
-			return err
+		// The ILM phases are translated to ISM states for OpenSearch, leaving out what has no ISM equivalent
+		if convert {
+			desiredPolicy, converted[policyName], err = globals.ConvertILMPolicy(policyName, desiredPolicy)
+			if err != nil {
+				logger.Error(err, fmt.Sprintf("Failed to convert policy %s to an ISM policy", policyName))
+				delete(converted, policyName)
+				failed[policyName] = err
+				continue
+			}
 		}
-		logger.Info(fmt.Sprintf("ILM policy %s applied successfully", policyName))
+
+		livePolicy, exists, err := r.getPolicy(ctx, esConnection, policyName)
+		if err != nil {
+			logger.Error(err, fmt.Sprintf("Failed to get %s policy %s", api, policyName))
+			failed[policyName] = err
+			continue
+		}
+
+		// Objects existing in the cluster but not created by the resource are handled by its adoption policy
+		ownershipErr := globals.CheckOwnership(policyManagedBy(livePolicy), exists, marker, appliedPolicies[policyName])
+		switch {
+		case ownershipErr == nil:
+		case resource.Spec.AdoptionPolicy == v1alpha1.AdoptionPolicyAdopt:
+			logger.Info(fmt.Sprintf("Adopting %s policy %s: %s", api, policyName, ownershipErr))
+		case resource.Spec.AdoptionPolicy == v1alpha1.AdoptionPolicyIgnoreDifferences:
+			logger.Info(fmt.Sprintf("%s policy %s left as it is: %s", api, policyName, ownershipErr))
+			newResources[policyName] = globals.IgnoredResourceStatus()
+			continue
+		default:
+			logger.Info(fmt.Sprintf("%s policy %s left untouched: %s", api, policyName, ownershipErr))
+			conflicts[policyName] = ownershipErr
+			failed[policyName] = ownershipErr
+			continue
+		}
+		// ISM policies have no _meta, so the converted ones are owned by the resource that applied them
+		if policy, ok := desiredPolicy["policy"].(map[string]interface{}); ok && !convert {
+			desiredPolicy["policy"] = globals.SetManagedBy(policy, marker)
+		}
+		desiredHash := globals.HashJSON(desiredPolicy)
+		if resource.Status.Resources[policyName].Hash == desiredHash {
+			paths := globals.DetectDrift(desiredPolicy, livePolicy, exists)
+			if len(paths) == 0 {
+				logger.Info(fmt.Sprintf("%s policy %s is up to date, skipping", api, policyName))
+				newAppliedPolicies = append(newAppliedPolicies, policyName)
+				newResources[policyName] = resource.Status.Resources[policyName]
+				continue
+			}
+			logger.Info(fmt.Sprintf("%s policy %s was changed out of band: %s", api, policyName, strings.Join(paths, ", ")))
+			drifted[policyName] = paths
+		}
+
+		change := globals.NewObjectChange(policyName, desiredPolicy, livePolicy, exists)
+		globals.RecordChange(r.Recorder, resource, change, dryRun)
+		changes = append(changes, change)
+		if dryRun {
+			continue
+		}
+
+		// Apply the policy (creates or updates)
+		if err := r.applyPolicy(ctx, esConnection, policyName, desiredPolicy); err != nil {
+			logger.Error(err, fmt.Sprintf("Failed to apply %s policy %s", api, policyName))
+			failed[policyName] = err
+			continue
+		}
+		logger.Info(fmt.Sprintf("%s policy %s applied successfully", api, policyName))
 		newAppliedPolicies = append(newAppliedPolicies, policyName)
+		newResources[policyName] = globals.AppliedResourceStatus(desiredHash)
 	}
 
-	// Step 6: Update the Status with the new list of applied policies
-	targetCluster := fmt.Sprintf("%s/%s", resource.Spec.ResourceSelector.Namespace, resource.Spec.ResourceSelector.Name)
-	if err := r.SetReady(ctx, resource, targetCluster, newAppliedPolicies); err != nil {
-		logger.Error(err, "Failed to update IndexLifecyclePolicy status")
-		return err
+	// Keep tracking the failed policies applied by a previous synchronization, so they are deleted once removed from the
+	// spec, and report their error in their status, without their hash, so they are applied again
+	for policyName, err := range failed {
+		newResources[policyName] = globals.FailedResourceStatus(resource.Status.Resources[policyName], err)
+		if appliedPolicies[policyName] {
+			newAppliedPolicies = append(newAppliedPolicies, policyName)
+		}
+	}
+	failedErr := globals.ResourceErrors(failed)
+
+	globals.RecordDrift(r.Recorder, resource, &resource.Status.Conditions, drifted, dryRun)
+	globals.RecordConflicts(r.Recorder, resource, &resource.Status.Conditions, conflicts)
+	globals.UpdateDeletionBlockedCondition(&resource.Status.Conditions, blocked, "force")
+	globals.UpdateConvertedToISMCondition(&resource.Status.Conditions, converted)
+
+	targetCluster := fmt.Sprintf("%s/%s", resourceSelector.Namespace, resourceSelector.Name)
+
+	// Resources in dry run or audit mode only report the changes the synchronization would make
+	if dryRun {
+		logger.Info(fmt.Sprintf("Dry run of IndexLifecyclePolicy %s/%s found %d pending changes", resource.Namespace, resource.Name, len(changes)))
+		r.SetDryRun(ctx, resource, targetCluster, changes, failedErr)
+		return failedErr
+	}
+
+	// Keep the changes of the last synchronization that changed anything
+	if len(changes) > 0 {
+		resource.Status.Changes = changes
 	}
 
+	if failedErr != nil {
+		logger.Error(failedErr, "Failed to sync some policies")
+		r.SetDegraded(ctx, resource, targetCluster, newAppliedPolicies, newResources, failedErr)
+		return failedErr
+	}
+
+	// Step 6: Update the Status with the new list of applied policies
+	r.SetReady(ctx, resource, targetCluster, newAppliedPolicies, newResources)
+
 	logger.Info(fmt.Sprintf("IndexLifecyclePolicy %s/%s synced successfully", resource.Namespace, resource.Name))
 
 	return nil
 }
 
+// lifecycleAPI returns the name of the lifecycle API the policies are written with: ISM for OpenSearch, where they
+// are converted, and ILM for Elasticsearch
+func lifecycleAPI(clusterType string) string {
+	if clusterType == "opensearch" {
+		return "ISM"
+	}
+	return "ILM"
+}
+
+// getPolicy returns the policy stored in the cluster ({"policy": {...}}), and whether it exists
+func (r *IndexLifecyclePolicyReconciler) getPolicy(ctx context.Context, esConnection *pools.ElasticsearchConnection, policyName string) (map[string]interface{}, bool, error) {
+	if esConnection.ClusterType == "opensearch" {
+		policy, exists, err := r.getISMPolicy(ctx, esConnection.Client, policyName)
+		if err != nil || !exists {
+			return nil, exists, err
+		}
+		return map[string]interface{}{"policy": policy.Policy}, true, nil
+	}
+	return r.getILMPolicy(ctx, esConnection.Client, policyName)
+}
+
+// applyPolicy creates or updates a policy in the cluster
+func (r *IndexLifecyclePolicyReconciler) applyPolicy(ctx context.Context, esConnection *pools.ElasticsearchConnection, policyName string, policy map[string]interface{}) error {
+	if esConnection.ClusterType == "opensearch" {
+		return r.applyISMPolicy(ctx, esConnection.Client, policyName, policy)
+	}
+	return r.applyILMPolicy(ctx, esConnection.Client, policyName, policy)
+}
+
+// deletePolicy deletes a policy from the cluster
+func (r *IndexLifecyclePolicyReconciler) deletePolicy(ctx context.Context, esConnection *pools.ElasticsearchConnection, policyName string) error {
+	if esConnection.ClusterType == "opensearch" {
+		return r.deleteISMPolicy(ctx, esConnection.Client, policyName)
+	}
+	return r.deleteILMPolicy(ctx, esConnection.Client, policyName)
+}
+
 // applyILMPolicy creates or updates an ILM policy in Elasticsearch
 func (r *IndexLifecyclePolicyReconciler) applyILMPolicy(ctx context.Context, esClient *elasticsearch.Client, policyName string, policy map[string]interface{}) error {
 	logger := log.FromContext(ctx)
@@ -186,6 +532,74 @@ func (r *IndexLifecyclePolicyReconciler) applyILMPolicy(ctx context.Context, esC
 	return nil
 }
 
+// getILMPolicy returns the ILM policy stored in Elasticsearch ({"policy": {...}} along with its metadata),
+// and whether it exists
+func (r *IndexLifecyclePolicyReconciler) getILMPolicy(ctx context.Context, esClient *elasticsearch.Client, policyName string) (map[string]interface{}, bool, error) {
+	res, err := esClient.ILM.GetLifecycle(
+		esClient.ILM.GetLifecycle.WithPolicy(policyName),
+		esClient.ILM.GetLifecycle.WithContext(ctx),
+	)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get ILM policy: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, false, nil
+	}
+
+	bodyBytes, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if res.IsError() {
+		return nil, false, fmt.Errorf("elasticsearch API error: %s - %s", res.Status(), string(bodyBytes))
+	}
+
+	var policies map[string]map[string]interface{}
+	if err := json.Unmarshal(bodyBytes, &policies); err != nil {
+		return nil, false, fmt.Errorf("failed to parse ILM policy: %w", err)
+	}
+
+	policy, exists := policies[policyName]
+	return policy, exists, nil
+}
+
+// ownsPolicy reports whether the resource owns a policy of the cluster, given its marker and whether the resource
+// applied it. Missing policies are owned, as deleting them changes nothing
+func (r *IndexLifecyclePolicyReconciler) ownsPolicy(ctx context.Context, esConnection *pools.ElasticsearchConnection, policyName, marker string, applied bool) (bool, error) {
+	livePolicy, exists, err := r.getPolicy(ctx, esConnection, policyName)
+	if err != nil {
+		return false, err
+	}
+	if !exists {
+		return true, nil
+	}
+	return globals.OwnsObject(policyManagedBy(livePolicy), marker, applied), nil
+}
+
+// checkPolicyNotInUse fails with ErrObjectInUse when an ILM policy is used by indices, data streams or index
+// templates, naming them. ISM policies are not checked, as OpenSearch does not report their users
+func (r *IndexLifecyclePolicyReconciler) checkPolicyNotInUse(ctx context.Context, esConnection *pools.ElasticsearchConnection, policyName string) error {
+	if esConnection.ClusterType == "opensearch" {
+		return nil
+	}
+	livePolicy, exists, err := r.getILMPolicy(ctx, esConnection.Client, policyName)
+	if err != nil {
+		return err
+	}
+	if users := globals.ILMPolicyUsers(livePolicy); exists && users != "" {
+		return fmt.Errorf("%w by %s", globals.ErrObjectInUse, users)
+	}
+	return nil
+}
+
+// policyManagedBy returns the marker in the _meta of an ILM policy, which is nested under "policy"
+func policyManagedBy(policy map[string]interface{}) string {
+	body, _ := policy["policy"].(map[string]interface{})
+	return globals.ManagedBy(body)
+}
+
 // deleteILMPolicy deletes an ILM policy from Elasticsearch
 func (r *IndexLifecyclePolicyReconciler) deleteILMPolicy(ctx context.Context, esClient *elasticsearch.Client, policyName string) error {
 	logger := log.FromContext(ctx)
@@ -214,3 +628,130 @@ func (r *IndexLifecyclePolicyReconciler) deleteILMPolicy(ctx context.Context, es
 
 	return nil
 }
+
+// errISMPolicyChanged is returned when an ISM policy changed between reading its version and updating it
+var errISMPolicyChanged = errors.New("ISM policy changed while updating it")
+
+// storedISMPolicy is an ISM policy stored in OpenSearch, with the sequence number and primary term of its version,
+// required to update it
+type storedISMPolicy struct {
+	SeqNo       int64                  `json:"_seq_no"`
+	PrimaryTerm int64                  `json:"_primary_term"`
+	Policy      map[string]interface{} `json:"policy"`
+}
+
+// applyISMPolicy creates or updates an ISM policy ({"policy": {...}}) in OpenSearch. Updates of existing policies are conditioned to
+// their current version, read again when the policy changed in the meantime
+func (r *IndexLifecyclePolicyReconciler) applyISMPolicy(ctx context.Context, esClient *elasticsearch.Client, policyName string, policy map[string]interface{}) error {
+	policyJSON, err := json.Marshal(policy)
+	if err != nil {
+		return fmt.Errorf("failed to marshal policy: %w", err)
+	}
+
+	return retry.OnError(retry.DefaultRetry, func(err error) bool {
+		return errors.Is(err, errISMPolicyChanged)
+	}, func() error {
+		current, exists, err := r.getISMPolicy(ctx, esClient, policyName)
+		if err != nil {
+			return err
+		}
+
+		// PUT /_plugins/_ism/policies/{policy_name}?if_seq_no={seq_no}&if_primary_term={primary_term}
+		path := fmt.Sprintf("/_plugins/_ism/policies/%s", policyName)
+		if exists {
+			query := url.Values{}
+			query.Set("if_seq_no", strconv.FormatInt(current.SeqNo, 10))
+			query.Set("if_primary_term", strconv.FormatInt(current.PrimaryTerm, 10))
+			path += "?" + query.Encode()
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "PUT", path, bytes.NewReader(policyJSON))
+		if err != nil {
+			return fmt.Errorf("failed to create request: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		res, err := esClient.Perform(req)
+		if err != nil {
+			return fmt.Errorf("failed to apply ISM policy: %w", err)
+		}
+		defer res.Body.Close()
+
+		// The policy was changed, or created, since its version was read
+		if res.StatusCode == http.StatusConflict {
+			bodyBytes, _ := io.ReadAll(res.Body)
+			return fmt.Errorf("%w: %s", errISMPolicyChanged, string(bodyBytes))
+		}
+
+		if res.StatusCode >= 400 {
+			bodyBytes, _ := io.ReadAll(res.Body)
+			return fmt.Errorf("OpenSearch API error: %s - %s", res.Status, string(bodyBytes))
+		}
+
+		return nil
+	})
+}
+
+// getISMPolicy returns the ISM policy stored in OpenSearch with its version, and whether it exists
+func (r *IndexLifecyclePolicyReconciler) getISMPolicy(ctx context.Context, esClient *elasticsearch.Client, policyName string) (storedISMPolicy, bool, error) {
+	// GET /_plugins/_ism/policies/{policy_name}
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("/_plugins/_ism/policies/%s", policyName), nil)
+	if err != nil {
+		return storedISMPolicy{}, false, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	res, err := esClient.Perform(req)
+	if err != nil {
+		return storedISMPolicy{}, false, fmt.Errorf("failed to get ISM policy: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return storedISMPolicy{}, false, nil
+	}
+
+	bodyBytes, err := io.ReadAll(res.Body)
+	if err != nil {
+		return storedISMPolicy{}, false, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if res.StatusCode >= 400 {
+		return storedISMPolicy{}, false, fmt.Errorf("OpenSearch API error: %s - %s", res.Status, string(bodyBytes))
+	}
+
+	var policy storedISMPolicy
+	if err := json.Unmarshal(bodyBytes, &policy); err != nil {
+		return storedISMPolicy{}, false, fmt.Errorf("failed to parse ISM policy: %w", err)
+	}
+
+	return policy, true, nil
+}
+
+// deleteISMPolicy deletes an ISM policy from OpenSearch
+func (r *IndexLifecyclePolicyReconciler) deleteISMPolicy(ctx context.Context, esClient *elasticsearch.Client, policyName string) error {
+	logger := log.FromContext(ctx)
+
+	// DELETE /_plugins/_ism/policies/{policy_name}
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("/_plugins/_ism/policies/%s", policyName), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	res, err := esClient.Perform(req)
+	if err != nil {
+		return fmt.Errorf("failed to delete ISM policy: %w", err)
+	}
+	defer res.Body.Close()
+
+	// If the policy doesn't exist (404), consider it already deleted
+	if res.StatusCode == http.StatusNotFound {
+		logger.Info(fmt.Sprintf("ISM policy %s not found in OpenSearch (already deleted)", policyName))
+		return nil
+	}
+
+	if res.StatusCode >= 400 {
+		bodyBytes, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("OpenSearch API error: %s - %s", res.Status, string(bodyBytes))
+	}
+
+	return nil
+}
@@ -19,11 +19,12 @@ package indexstatemanagement
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -31,6 +32,7 @@ import (
 
 	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
 	"elastic-config-operator.freepik.com/elastic-config-operator/internal/controller"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/globals"
 	"elastic-config-operator.freepik.com/elastic-config-operator/internal/pools"
 )
 
@@ -39,6 +41,7 @@ type IndexStateManagementReconciler struct {
 	client.Client
 	Scheme                       *runtime.Scheme
 	ElasticsearchConnectionsPool *pools.ElasticsearchConnectionsStore
+	Recorder                     record.EventRecorder
 }
 
 // +kubebuilder:rbac:groups=elastic-config-operator.freepik.com,resources=indexstatemanagements,verbs=get;list;watch;create;update;patch;delete
@@ -47,7 +50,8 @@ type IndexStateManagementReconciler struct {
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
 // +kubebuilder:rbac:groups=elasticsearch.k8s.elastic.co,resources=elasticsearches,verbs=get;list;watch
 
-// Reconcile is part of the main kubernetes reconciliation loop
+// Reconcile writes the ISM policies of an IndexStateManagement to its cluster on every syncInterval, and deletes them
+// from the cluster once the resource is deleted, unless its deletionPolicy retains them
 func (r *IndexStateManagementReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
 	logger := logf.FromContext(ctx)
 
@@ -69,16 +73,42 @@ func (r *IndexStateManagementReconciler) Reconcile(ctx context.Context, req ctrl
 		return result, err
 	}
 
+	// Suspended resources are not reconciled, even when deleted: the cluster and their status are left untouched until
+	// they are resumed
+	if indexStateManagementResource.Spec.Suspend {
+		logger.Info(fmt.Sprintf(controller.ResourceSuspendedMessage, controller.IndexStateManagementResourceType, req.NamespacedName))
+		return result, nil
+	}
+
 	// 3. Check if the IndexStateManagement instance is marked to be deleted
 	if !indexStateManagementResource.DeletionTimestamp.IsZero() {
 		if controllerutil.ContainsFinalizer(indexStateManagementResource, controller.ResourceFinalizer) {
+			original := indexStateManagementResource.DeepCopy()
+
+			// 3.1 Delete the resources associated with the IndexStateManagement, unless the deletion policy retains them
+			if indexStateManagementResource.Spec.DeletionPolicy == v1alpha1.DeletionPolicyRetain {
+				logger.Info(fmt.Sprintf(controller.ResourceRetainedMessage, controller.IndexStateManagementResourceType, req.NamespacedName))
+			} else if globals.TargetClusterGone(ctx, &indexStateManagementResource.Spec.ResourceSelector, indexStateManagementResource.Namespace) {
+				logger.Info(fmt.Sprintf(controller.ResourceTargetGoneMessage, controller.IndexStateManagementResourceType, req.NamespacedName))
+			} else {
+				err = r.Sync(ctx, watch.Deleted, indexStateManagementResource)
+			}
 
-			// 3.1 Delete the resources associated with the IndexStateManagement
-			err = r.Sync(ctx, watch.Deleted, indexStateManagementResource)
+			// Failed cleanups are retried with backoff, keeping the finalizer and reporting why in the status, until they
+			// are given up
+			if globals.RetryCleanup(indexStateManagementResource, err) {
+				logger.Info(fmt.Sprintf(controller.ResourceCleanupError, controller.IndexStateManagementResourceType, req.NamespacedName, err.Error()))
+				if updateErr := globals.PatchStatus(ctx, r.Client, indexStateManagementResource, original); updateErr != nil {
+					logger.Info(fmt.Sprintf(controller.ResourceConditionUpdateError, controller.IndexStateManagementResourceType, req.NamespacedName, updateErr.Error()))
+				}
+				return result, err
+			}
+			if err != nil {
+				logger.Info(fmt.Sprintf(controller.ResourceCleanupGivenUpMessage, controller.IndexStateManagementResourceType, req.NamespacedName, err.Error()))
+			}
 
 			// Remove the finalizers on IndexStateManagement CR
-			controllerutil.RemoveFinalizer(indexStateManagementResource, controller.ResourceFinalizer)
-			err = r.Update(ctx, indexStateManagementResource)
+			err = globals.RemoveFinalizer(ctx, r.Client, indexStateManagementResource, controller.ResourceFinalizer)
 			if err != nil {
 				logger.Info(fmt.Sprintf(controller.ResourceFinalizersUpdateError, controller.IndexStateManagementResourceType, req.NamespacedName, err.Error()))
 			}
@@ -91,27 +121,24 @@ func (r *IndexStateManagementReconciler) Reconcile(ctx context.Context, req ctrl
 
 	// 4. Add finalizer to the IndexStateManagement CR
 	if !controllerutil.ContainsFinalizer(indexStateManagementResource, controller.ResourceFinalizer) {
-		controllerutil.AddFinalizer(indexStateManagementResource, controller.ResourceFinalizer)
-		err = r.Update(ctx, indexStateManagementResource)
+		err = globals.AddFinalizer(ctx, r.Client, indexStateManagementResource, controller.ResourceFinalizer)
 		if err != nil {
 			return result, err
 		}
 	}
 
-	// 5. Update the status before the requeue
+	// 5. Update the status before the requeue, writing the changes made to it during the reconcile at once
+	original := indexStateManagementResource.DeepCopy()
 	defer func() {
-		err = r.Status().Update(ctx, indexStateManagementResource)
-		if err != nil {
-			logger.Info(fmt.Sprintf(controller.ResourceConditionUpdateError, controller.IndexStateManagementResourceType, req.NamespacedName, err.Error()))
+		indexStateManagementResource.Status.ObservedGeneration = indexStateManagementResource.Generation
+		// The sync error is kept as the result, so failed syncs are requeued with backoff
+		if updateErr := globals.PatchStatus(ctx, r.Client, indexStateManagementResource, original); updateErr != nil {
+			logger.Info(fmt.Sprintf(controller.ResourceConditionUpdateError, controller.IndexStateManagementResourceType, req.NamespacedName, updateErr.Error()))
 		}
 	}()
 
 	// 6. Schedule periodical request
-	syncInterval := indexStateManagementResource.Spec.SyncInterval
-	if syncInterval == "" {
-		syncInterval = controller.DefaultSyncInterval
-	}
-	RequeueTime, err := time.ParseDuration(syncInterval)
+	RequeueTime, err := controller.RequeueInterval(indexStateManagementResource.Spec.SyncInterval)
 	if err != nil {
 		logger.Info(fmt.Sprintf(controller.ResourceSyncTimeRetrievalError, controller.IndexStateManagementResourceType, req.NamespacedName, err.Error()))
 		return result, err
@@ -123,6 +150,8 @@ func (r *IndexStateManagementReconciler) Reconcile(ctx context.Context, req ctrl
 	// 7. Sync the ISM policies
 	err = r.Sync(ctx, watch.Modified, indexStateManagementResource)
 	if err != nil {
+		// Failed syncs are requeued with the error backoff instead of the syncInterval
+		result = ctrl.Result{}
 		r.UpdateConditionKubernetesApiCallFailure(indexStateManagementResource)
 		logger.Info(fmt.Sprintf(controller.SyncTargetError, controller.IndexStateManagementResourceType, req.NamespacedName, err.Error()))
 		return result, err
@@ -135,11 +164,21 @@ func (r *IndexStateManagementReconciler) Reconcile(ctx context.Context, req ctrl
 
 }
 
-// SetupWithManager sets up the controller with the Manager.
+// SetupWithManager sets up the controller with the Manager. The Secrets referenced by the resources are watched
+// too, so the resources are synced with the new credentials as soon as they change
 func (r *IndexStateManagementReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&v1alpha1.IndexStateManagement{}).
-		WithEventFilter(predicate.GenerationChangedPredicate{}).
+	controllerBuilder := ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.IndexStateManagement{}, builder.WithPredicates(predicate.GenerationChangedPredicate{}))
+
+	controllerBuilder, err := globals.WatchReferencedSecrets(controllerBuilder, mgr, &v1alpha1.IndexStateManagement{}, &v1alpha1.IndexStateManagementList{}, func(object client.Object) []*v1alpha1.ResourceSelector {
+		return []*v1alpha1.ResourceSelector{&object.(*v1alpha1.IndexStateManagement).Spec.ResourceSelector}
+	})
+	if err != nil {
+		return err
+	}
+
+	return controllerBuilder.
 		Named("indexstatemanagement").
+		WithOptions(globals.ControllerOptions()).
 		Complete(r)
 }
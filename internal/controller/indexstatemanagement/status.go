@@ -21,7 +21,6 @@ import (
 	"fmt"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
 	"elastic-config-operator.freepik.com/elastic-config-operator/internal/controller"
@@ -43,7 +42,7 @@ func (r *IndexStateManagementReconciler) UpdateConditionSuccess(indexStateManage
 func (r *IndexStateManagementReconciler) UpdateConditionKubernetesApiCallFailure(indexStateManagement *v1alpha1.IndexStateManagement) {
 
 	// Create the new condition with the failure status
-	condition := globals.NewCondition(globals.ConditionTypeResourceSynced, metav1.ConditionTrue,
+	condition := globals.NewCondition(globals.ConditionTypeResourceSynced, metav1.ConditionFalse,
 		globals.ConditionReasonKubernetesApiCallErrorType, globals.ConditionReasonKubernetesApiCallErrorMessage)
 
 	// Update the status of the IndexStateManagement resource
@@ -52,28 +51,44 @@ func (r *IndexStateManagementReconciler) UpdateConditionKubernetesApiCallFailure
 
 // SetSyncing updates the status to Syncing phase
 func (r *IndexStateManagementReconciler) SetSyncing(ctx context.Context, resource *v1alpha1.IndexStateManagement) {
-	logger := log.FromContext(ctx)
+	if globals.IsPeriodicSync(resource.Status.Conditions, resource.Status.ObservedGeneration, resource.Generation) {
+		return
+	}
 	resource.Status.Phase = controller.PhaseSyncing
 	resource.Status.Message = "Synchronizing with OpenSearch"
-	if err := r.Status().Update(ctx, resource); err != nil {
-		logger.Error(err, "Failed to update status to Syncing")
-	}
+	globals.SetSyncingConditions(&resource.Status.Conditions, resource.Status.ObservedGeneration, resource.Generation, resource.Status.Message)
+	resource.Status.ObservedGeneration = resource.Generation
 }
 
 // SetReady updates the status to Ready phase with applied resources
-func (r *IndexStateManagementReconciler) SetReady(ctx context.Context, resource *v1alpha1.IndexStateManagement, targetCluster string, appliedResources []string) error {
+func (r *IndexStateManagementReconciler) SetReady(ctx context.Context, resource *v1alpha1.IndexStateManagement, targetCluster string, appliedResources []string) {
 	now := metav1.Now()
 	resource.Status.Phase = controller.PhaseReady
 	resource.Status.Message = fmt.Sprintf("Successfully synced %d policies", len(appliedResources))
 	resource.Status.TargetCluster = targetCluster
 	resource.Status.AppliedResources = appliedResources
 	resource.Status.LastSyncTime = &now
-	return r.Status().Update(ctx, resource)
+	globals.RecordApplied(r.Recorder, resource, resource.Status.Conditions, resource.Status.Message)
+	globals.UpdateClusterBlockedCondition(&resource.Status.Conditions, nil)
+	globals.SetReadyConditions(&resource.Status.Conditions, globals.ConditionReasonTargetSynced, resource.Status.Message)
+	resource.Status.ObservedGeneration = resource.Generation
 }
 
 // SetError updates the status to Error phase with error message
 func (r *IndexStateManagementReconciler) SetError(ctx context.Context, resource *v1alpha1.IndexStateManagement, err error) {
 	resource.Status.Phase = controller.PhaseError
 	resource.Status.Message = err.Error()
-	_ = r.Status().Update(ctx, resource)
+	globals.UpdateClusterBlockedCondition(&resource.Status.Conditions, err)
+	globals.SetStalledConditions(&resource.Status.Conditions, globals.ConditionReasonSyncFailed, resource.Status.Message)
+	globals.RecordSyncError(r.Recorder, resource, err)
+	resource.Status.ObservedGeneration = resource.Generation
+}
+
+// SetWaitingForCluster updates the status to WaitingForCluster phase while the target cluster is not reachable yet
+func (r *IndexStateManagementReconciler) SetWaitingForCluster(ctx context.Context, resource *v1alpha1.IndexStateManagement, err error) {
+	resource.Status.Phase = controller.PhaseWaitingForCluster
+	resource.Status.Message = err.Error()
+	globals.SetReconcilingConditions(&resource.Status.Conditions, globals.ConditionReasonWaitingForCluster, resource.Status.Message)
+	globals.RecordSyncError(r.Recorder, resource, err)
+	resource.Status.ObservedGeneration = resource.Generation
 }
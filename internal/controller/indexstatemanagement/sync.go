@@ -20,12 +20,19 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/elastic/go-elasticsearch/v8"
 	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
@@ -37,24 +44,48 @@ func (r *IndexStateManagementReconciler) Sync(ctx context.Context, eventType wat
 
 	logger := log.FromContext(ctx)
 
-	// Get the cluster associated to the resource
-	if resource.Spec.ResourceSelector.Namespace == "" {
-		resource.Spec.ResourceSelector.Namespace = resource.Namespace
+	// The target cluster is resolved without changing the spec: the NamespaceDefaultCluster is used when the resource
+	// doesn't select one, and the namespace of the resource when the selector has none
+	resourceSelector, err := globals.ResolveResourceSelector(ctx, &resource.Spec.ResourceSelector, resource.Namespace)
+	if err != nil {
+		logger.Error(err, "Failed to resolve the target cluster")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	// Check the namespace of the resource is allowed to target the cluster
+	if err := globals.AuthorizeClusterAccess(ctx, resourceSelector, resource.Namespace, &resource.Status.Conditions); err != nil {
+		logger.Error(err, "Access to the target cluster denied")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	if err := globals.ValidateTLSVerification(resourceSelector, &resource.Status.Conditions); err != nil {
+		logger.Error(err, "TLS verification of the target cluster not configured")
+		r.SetError(ctx, resource, err)
+		return err
 	}
 
-	// Build the cluster key for the pools
-	clusterKey := fmt.Sprintf("%s_%s", resource.Spec.ResourceSelector.Namespace, resource.Spec.ResourceSelector.Name)
+	// Build the cluster key identifying the target cluster in the logs
+	clusterKey := fmt.Sprintf("%s_%s", resourceSelector.Namespace, resourceSelector.Name)
 
 	if eventType == watch.Deleted {
 		logger.Info(fmt.Sprintf("Deleting IndexStateManagement %s/%s", resource.Namespace, resource.Name))
 
 		// Get OpenSearch connection to delete the policies
-		esConnection, err := globals.GetOrCreateElasticsearchConnection(ctx, clusterKey, &resource.Spec.ResourceSelector, resource.Namespace, r.ElasticsearchConnectionsPool)
+		esConnection, err := globals.GetOrCreateElasticsearchConnection(ctx, resourceSelector, resource.Namespace, r.ElasticsearchConnectionsPool)
 		if err != nil {
 			logger.Error(err, "Failed to get OpenSearch connection for deletion")
 			return err
 		}
 
+		// Writes are serialized with the other resources targeting the same cluster
+		unlock, err := globals.LockCluster(ctx, esConnection.Endpoint)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+
 		// Delete each ISM policy from OpenSearch
 		for policyName := range resource.Spec.Resources {
 			logger.Info(fmt.Sprintf("Deleting ISM policy %s from OpenSearch", policyName))
@@ -74,13 +105,25 @@ func (r *IndexStateManagementReconciler) Sync(ctx context.Context, eventType wat
 	r.SetSyncing(ctx, resource)
 
 	// Step 1: Get or create OpenSearch connection
-	esConnection, err := globals.GetOrCreateElasticsearchConnection(ctx, clusterKey, &resource.Spec.ResourceSelector, resource.Namespace, r.ElasticsearchConnectionsPool)
+	esConnection, err := globals.GetOrCreateElasticsearchConnection(ctx, resourceSelector, resource.Namespace, r.ElasticsearchConnectionsPool)
+	globals.UpdateClusterReachableCondition(&resource.Status.Conditions, err)
 	if err != nil {
 		logger.Error(err, "Failed to get or create OpenSearch connection")
-		r.SetError(ctx, resource, fmt.Errorf("failed to connect to OpenSearch: %w", err))
+		if errors.Is(err, globals.ErrClusterNotReady) {
+			r.SetWaitingForCluster(ctx, resource, err)
+			return err
+		}
+		r.SetError(ctx, resource, fmt.Errorf("%w to OpenSearch: %w", globals.ErrConnectionFailed, err))
 		return err
 	}
 
+	// Writes are serialized with the other resources targeting the same cluster
+	unlock, err := globals.LockCluster(ctx, esConnection.Endpoint)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	logger.Info(fmt.Sprintf("OpenSearch connection established for cluster %s (type: %s, version: %s)", clusterKey, esConnection.ClusterType, esConnection.Version))
 
 	// Validate cluster type - ISM is only available in OpenSearch
@@ -112,6 +155,7 @@ func (r *IndexStateManagementReconciler) Sync(ctx context.Context, eventType wat
 				return err
 			}
 			logger.Info(fmt.Sprintf("ISM policy %s deleted successfully", policyName))
+			globals.RecordPruned(r.Recorder, resource, policyName)
 		}
 	}
 
@@ -132,28 +176,82 @@ func (r *IndexStateManagementReconciler) Sync(ctx context.Context, eventType wat
 			return err
 		}
 
-		// Apply the policy (OpenSearch ISM PUT is idempotent - creates or updates)
-		if err := r.applyISMPolicy(ctx, esConnection.Client, policyName, desiredPolicy); err != nil {
-			logger.Error(err, fmt.Sprintf("Failed to apply ISM policy %s", policyName))
+		// Policies are only written when they changed, as every write creates a new version of the policy
+		livePolicy, exists, err := r.getISMPolicy(ctx, esConnection.Client, policyName)
+		if err != nil {
+			logger.Error(err, fmt.Sprintf("Failed to get ISM policy %s", policyName))
 			return err
 		}
-		logger.Info(fmt.Sprintf("ISM policy %s applied successfully", policyName))
+		if exists && len(globals.DiffJSON(desiredPolicy, livePolicy.Policy)) == 0 {
+			logger.Info(fmt.Sprintf("ISM policy %s is up to date, skipping", policyName))
+		} else {
+			// Apply the policy (creates it, or updates the current version of the existing one)
+			if err := r.applyISMPolicy(ctx, esConnection.Client, policyName, desiredPolicy); err != nil {
+				logger.Error(err, fmt.Sprintf("Failed to apply ISM policy %s", policyName))
+				return err
+			}
+			logger.Info(fmt.Sprintf("ISM policy %s applied successfully", policyName))
+		}
 		newAppliedPolicies = append(newAppliedPolicies, policyName)
+
+		// The ism_template of a policy only applies to the indices created after it, so the existing ones are attached
+		if resource.Spec.ApplyToExistingIndices {
+			if err := r.applyToExistingIndices(ctx, esConnection.Client, policyName, desiredPolicy); err != nil {
+				logger.Error(err, fmt.Sprintf("Failed to apply ISM policy %s to the existing indices", policyName))
+				return err
+			}
+		}
+
+		// Indices keep running the version of the policy they started with, unless they are changed to the new one
+		if resource.Spec.UpdateManagedIndices {
+			if err := r.updateManagedIndices(ctx, esConnection.Client, policyName, desiredPolicy); err != nil {
+				logger.Error(err, fmt.Sprintf("Failed to update the indices managed by ISM policy %s", policyName))
+				return err
+			}
+		}
 	}
 
 	// Step 6: Update the Status with the new list of applied policies
-	targetCluster := fmt.Sprintf("%s/%s", resource.Spec.ResourceSelector.Namespace, resource.Spec.ResourceSelector.Name)
-	if err := r.SetReady(ctx, resource, targetCluster, newAppliedPolicies); err != nil {
-		logger.Error(err, "Failed to update IndexStateManagement status")
-		return err
-	}
+	targetCluster := fmt.Sprintf("%s/%s", resourceSelector.Namespace, resourceSelector.Name)
+	r.SetReady(ctx, resource, targetCluster, newAppliedPolicies)
 
 	logger.Info(fmt.Sprintf("IndexStateManagement %s/%s synced successfully", resource.Namespace, resource.Name))
 
 	return nil
 }
 
-// applyISMPolicy creates or updates an ISM policy in OpenSearch
+// errISMPolicyChanged is returned when an ISM policy changed between reading its version and updating it
+var errISMPolicyChanged = errors.New("ISM policy changed while updating it")
+
+// changePolicyBatchSize is the number of indices whose policy is set by a request, so the request line stays below the
+// limit of OpenSearch
+const changePolicyBatchSize = 50
+
+// ismPolicy is an ISM policy stored in OpenSearch, with the sequence number and primary term of its version, required
+// to update it
+type ismPolicy struct {
+	SeqNo       int64                  `json:"_seq_no"`
+	PrimaryTerm int64                  `json:"_primary_term"`
+	Policy      map[string]interface{} `json:"policy"`
+}
+
+// ismManagedIndex is an index explained by ISM, with the policy and the version of the policy it runs. Indices whose
+// policy was just added only have the policy in their settings until ISM initializes them
+type ismManagedIndex struct {
+	PolicyID          string  `json:"policy_id"`
+	SettingsPolicyID  *string `json:"index.plugins.index_state_management.policy_id"`
+	PolicySeqNo       *int64  `json:"policy_seq_no"`
+	PolicyPrimaryTerm *int64  `json:"policy_primary_term"`
+}
+
+// managed reports whether the index is managed by an ISM policy
+func (i ismManagedIndex) managed() bool {
+	return i.PolicyID != "" || (i.SettingsPolicyID != nil && *i.SettingsPolicyID != "")
+}
+
+// applyISMPolicy creates or updates an ISM policy in OpenSearch. OpenSearch rejects the updates of existing policies
+// without their if_seq_no and if_primary_term, so the current version of the policy is read first, and read again
+// when the policy changed in the meantime
 func (r *IndexStateManagementReconciler) applyISMPolicy(ctx context.Context, esClient *elasticsearch.Client, policyName string, policy map[string]interface{}) error {
 	logger := log.FromContext(ctx)
 
@@ -170,11 +268,232 @@ func (r *IndexStateManagementReconciler) applyISMPolicy(ctx context.Context, esC
 
 	logger.Info(fmt.Sprintf("Applying ISM policy %s to OpenSearch", policyName))
 
-	// Apply the ISM policy using OpenSearch ISM API
-	// PUT /_plugins/_ism/policies/{policy_name}
-	req, err := http.NewRequestWithContext(ctx, "PUT",
+	return retry.OnError(retry.DefaultRetry, func(err error) bool {
+		return errors.Is(err, errISMPolicyChanged)
+	}, func() error {
+		current, exists, err := r.getISMPolicy(ctx, esClient, policyName)
+		if err != nil {
+			return err
+		}
+
+		// Apply the ISM policy using OpenSearch ISM API, conditioned to the current version of existing policies
+		// PUT /_plugins/_ism/policies/{policy_name}?if_seq_no={seq_no}&if_primary_term={primary_term}
+		path := fmt.Sprintf("/_plugins/_ism/policies/%s", policyName)
+		if exists {
+			query := url.Values{}
+			query.Set("if_seq_no", strconv.FormatInt(current.SeqNo, 10))
+			query.Set("if_primary_term", strconv.FormatInt(current.PrimaryTerm, 10))
+			path += "?" + query.Encode()
+		}
+
+		req, err := http.NewRequestWithContext(ctx, "PUT", path, bytes.NewReader(policyJSON))
+		if err != nil {
+			return fmt.Errorf("failed to create request: %w", err)
+		}
+
+		req.Header.Set("Content-Type", "application/json")
+
+		res, err := esClient.Perform(req)
+		if err != nil {
+			return fmt.Errorf("failed to apply ISM policy: %w", err)
+		}
+		defer res.Body.Close()
+
+		// The policy was changed, or created, since its version was read
+		if res.StatusCode == http.StatusConflict {
+			bodyBytes, _ := io.ReadAll(res.Body)
+			return fmt.Errorf("%w: %s", errISMPolicyChanged, string(bodyBytes))
+		}
+
+		if res.StatusCode >= 400 {
+			bodyBytes, _ := io.ReadAll(res.Body)
+			return fmt.Errorf("OpenSearch API error: %s - %s", res.Status, string(bodyBytes))
+		}
+
+		return nil
+	})
+}
+
+// getISMPolicy returns the ISM policy stored in OpenSearch with its version, and whether it exists
+func (r *IndexStateManagementReconciler) getISMPolicy(ctx context.Context, esClient *elasticsearch.Client, policyName string) (ismPolicy, bool, error) {
+	// GET /_plugins/_ism/policies/{policy_name}
+	req, err := http.NewRequestWithContext(ctx, "GET",
 		fmt.Sprintf("/_plugins/_ism/policies/%s", policyName),
-		bytes.NewReader(policyJSON))
+		nil)
+	if err != nil {
+		return ismPolicy{}, false, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	res, err := esClient.Perform(req)
+	if err != nil {
+		return ismPolicy{}, false, fmt.Errorf("failed to get ISM policy: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return ismPolicy{}, false, nil
+	}
+
+	bodyBytes, err := io.ReadAll(res.Body)
+	if err != nil {
+		return ismPolicy{}, false, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if res.StatusCode >= 400 {
+		return ismPolicy{}, false, fmt.Errorf("OpenSearch API error: %s - %s", res.Status, string(bodyBytes))
+	}
+
+	var policy ismPolicy
+	if err := json.Unmarshal(bodyBytes, &policy); err != nil {
+		return ismPolicy{}, false, fmt.Errorf("failed to parse ISM policy: %w", err)
+	}
+
+	return policy, true, nil
+}
+
+// updateManagedIndices changes the indices managed by an ISM policy that run a previous version of it to its latest
+// version, through the change_policy API. Only the indices matching the index patterns of the ism_template of the
+// policy are looked up, and the ones managed by other policies are left untouched. OpenSearch switches every index to
+// the new version once it finishes the actions of its current state
+func (r *IndexStateManagementReconciler) updateManagedIndices(ctx context.Context, esClient *elasticsearch.Client, policyName string, policy map[string]interface{}) error {
+	logger := log.FromContext(ctx)
+
+	indexPatterns := ismTemplateIndexPatterns(policy)
+	if len(indexPatterns) == 0 {
+		logger.Info(fmt.Sprintf("ISM policy %s has no ism_template index patterns, leaving its managed indices unchanged", policyName))
+		return nil
+	}
+
+	current, exists, err := r.getISMPolicy(ctx, esClient, policyName)
+	if err != nil || !exists {
+		return err
+	}
+
+	indices, err := r.explainISMIndices(ctx, esClient, indexPatterns)
+	if err != nil {
+		return err
+	}
+
+	// Indices not initialized yet by ISM have no version, and start with the latest one
+	var outdatedIndices []string
+	for indexName, index := range indices {
+		if index.PolicyID != policyName || index.PolicySeqNo == nil || index.PolicyPrimaryTerm == nil {
+			continue
+		}
+		if *index.PolicySeqNo != current.SeqNo || *index.PolicyPrimaryTerm != current.PrimaryTerm {
+			outdatedIndices = append(outdatedIndices, indexName)
+		}
+	}
+	if len(outdatedIndices) == 0 {
+		return nil
+	}
+	sort.Strings(outdatedIndices)
+
+	logger.Info(fmt.Sprintf("Changing %d indices to the latest version of ISM policy %s", len(outdatedIndices), policyName))
+
+	for batch := range slices.Chunk(outdatedIndices, changePolicyBatchSize) {
+		if err := r.setISMPolicy(ctx, esClient, "change_policy", policyName, batch); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// applyToExistingIndices attaches an ISM policy to the existing indices matching the index patterns of its
+// ism_template that are not managed by any policy, through the add API, as the ism_template only applies to the
+// indices created after the policy
+func (r *IndexStateManagementReconciler) applyToExistingIndices(ctx context.Context, esClient *elasticsearch.Client, policyName string, policy map[string]interface{}) error {
+	logger := log.FromContext(ctx)
+
+	indexPatterns := ismTemplateIndexPatterns(policy)
+	if len(indexPatterns) == 0 {
+		logger.Info(fmt.Sprintf("ISM policy %s has no ism_template index patterns, not applying it to existing indices", policyName))
+		return nil
+	}
+
+	indices, err := r.explainISMIndices(ctx, esClient, indexPatterns)
+	if err != nil {
+		return err
+	}
+
+	var unmanagedIndices []string
+	for indexName, index := range indices {
+		if !index.managed() {
+			unmanagedIndices = append(unmanagedIndices, indexName)
+		}
+	}
+	if len(unmanagedIndices) == 0 {
+		return nil
+	}
+	sort.Strings(unmanagedIndices)
+
+	logger.Info(fmt.Sprintf("Applying ISM policy %s to %d existing indices", policyName, len(unmanagedIndices)))
+
+	for batch := range slices.Chunk(unmanagedIndices, changePolicyBatchSize) {
+		if err := r.setISMPolicy(ctx, esClient, "add", policyName, batch); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// explainISMIndices returns the indices matching the index patterns, with the ISM policy managing them, if any
+func (r *IndexStateManagementReconciler) explainISMIndices(ctx context.Context, esClient *elasticsearch.Client, indexPatterns []string) (map[string]ismManagedIndex, error) {
+	// GET /_plugins/_ism/explain/{index_patterns}
+	req, err := http.NewRequestWithContext(ctx, "GET",
+		fmt.Sprintf("/_plugins/_ism/explain/%s", strings.Join(indexPatterns, ",")),
+		nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	res, err := esClient.Perform(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to explain the managed indices: %w", err)
+	}
+	defer res.Body.Close()
+
+	bodyBytes, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if res.StatusCode >= 400 {
+		return nil, fmt.Errorf("OpenSearch API error: %s - %s", res.Status, string(bodyBytes))
+	}
+
+	var explained map[string]json.RawMessage
+	if err := json.Unmarshal(bodyBytes, &explained); err != nil {
+		return nil, fmt.Errorf("failed to parse the managed indices: %w", err)
+	}
+
+	// The response holds the totals along with the indices, which are the objects
+	indices := make(map[string]ismManagedIndex, len(explained))
+	for indexName, rawIndex := range explained {
+		var index ismManagedIndex
+		if json.Unmarshal(rawIndex, &index) != nil {
+			continue
+		}
+		indices[indexName] = index
+	}
+
+	return indices, nil
+}
+
+// setISMPolicy sets an ISM policy on the indices through one of the ISM APIs taking a policy_id: add, for the indices
+// not managed yet, or change_policy, for the managed ones
+func (r *IndexStateManagementReconciler) setISMPolicy(ctx context.Context, esClient *elasticsearch.Client, api, policyName string, indices []string) error {
+	requestJSON, err := json.Marshal(map[string]interface{}{
+		"policy_id": policyName,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s request: %w", api, err)
+	}
+
+	// POST /_plugins/_ism/{api}/{indices}
+	req, err := http.NewRequestWithContext(ctx, "POST",
+		fmt.Sprintf("/_plugins/_ism/%s/%s", api, strings.Join(indices, ",")),
+		bytes.NewReader(requestJSON))
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
@@ -183,18 +502,66 @@ func (r *IndexStateManagementReconciler) applyISMPolicy(ctx context.Context, esC
 
 	res, err := esClient.Perform(req)
 	if err != nil {
-		return fmt.Errorf("failed to apply ISM policy: %w", err)
+		return fmt.Errorf("failed to %s ISM policy: %w", api, err)
 	}
 	defer res.Body.Close()
 
+	bodyBytes, err := io.ReadAll(res.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
 	if res.StatusCode >= 400 {
-		bodyBytes, _ := io.ReadAll(res.Body)
 		return fmt.Errorf("OpenSearch API error: %s - %s", res.Status, string(bodyBytes))
 	}
 
+	var response struct {
+		Failures      bool `json:"failures"`
+		FailedIndices []struct {
+			IndexName string `json:"index_name"`
+			Reason    string `json:"reason"`
+		} `json:"failed_indices"`
+	}
+	if err := json.Unmarshal(bodyBytes, &response); err != nil {
+		return fmt.Errorf("failed to parse %s response: %w", api, err)
+	}
+	if response.Failures {
+		failures := make([]string, 0, len(response.FailedIndices))
+		for _, failed := range response.FailedIndices {
+			failures = append(failures, fmt.Sprintf("%s: %s", failed.IndexName, failed.Reason))
+		}
+		return fmt.Errorf("failed to %s ISM policy of some indices: %s", api, strings.Join(failures, "; "))
+	}
+
 	return nil
 }
 
+// ismTemplateIndexPatterns returns the index patterns of the ism_template of a policy, which is either a template or a
+// list of templates
+func ismTemplateIndexPatterns(policy map[string]interface{}) []string {
+	var templates []interface{}
+	switch ismTemplate := policy["ism_template"].(type) {
+	case []interface{}:
+		templates = ismTemplate
+	case map[string]interface{}:
+		templates = []interface{}{ismTemplate}
+	}
+
+	var indexPatterns []string
+	for _, template := range templates {
+		templateMap, ok := template.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		patterns, _ := templateMap["index_patterns"].([]interface{})
+		for _, pattern := range patterns {
+			if patternString, ok := pattern.(string); ok && !slices.Contains(indexPatterns, patternString) {
+				indexPatterns = append(indexPatterns, patternString)
+			}
+		}
+	}
+	return indexPatterns
+}
+
 // deleteISMPolicy deletes an ISM policy from OpenSearch
 func (r *IndexStateManagementReconciler) deleteISMPolicy(ctx context.Context, esClient *elasticsearch.Client, policyName string) error {
 	logger := log.FromContext(ctx)
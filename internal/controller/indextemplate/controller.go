@@ -19,11 +19,12 @@ package indextemplate
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -31,6 +32,7 @@ import (
 
 	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
 	"elastic-config-operator.freepik.com/elastic-config-operator/internal/controller"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/globals"
 	"elastic-config-operator.freepik.com/elastic-config-operator/internal/pools"
 )
 
@@ -39,6 +41,7 @@ type IndexTemplateReconciler struct {
 	client.Client
 	Scheme                       *runtime.Scheme
 	ElasticsearchConnectionsPool *pools.ElasticsearchConnectionsStore
+	Recorder                     record.EventRecorder
 }
 
 // +kubebuilder:rbac:groups=elastic-config-operator.freepik.com,resources=indextemplates,verbs=get;list;watch;create;update;patch;delete
@@ -47,19 +50,12 @@ type IndexTemplateReconciler struct {
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
 // +kubebuilder:rbac:groups=elasticsearch.k8s.elastic.co,resources=elasticsearches,verbs=get;list;watch
 
-// Reconcile is part of the main kubernetes reconciliation loop which aims to
-// move the current state of the cluster closer to the desired state.
-// TODO(user): Modify the Reconcile function to compare the state specified by
-// the IndexTemplate object against the actual cluster state, and then
-// perform operations to make the cluster state reflect the state specified by
-// the user.
-//
-// For more details, check Reconcile and its Result here:
-// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.22.4/pkg/reconcile
+// Reconcile writes the index templates of an IndexTemplate to its cluster on every syncInterval, and deletes them from
+// the cluster once the resource is deleted, unless its deletionPolicy retains them
 func (r *IndexTemplateReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
 	logger := logf.FromContext(ctx)
 
-	// 1. Get the content of the Patch
+	// 1. Get the content of the resource
 	indexTemplateResource := &v1alpha1.IndexTemplate{}
 	err = r.Get(ctx, req.NamespacedName, indexTemplateResource)
 
@@ -77,16 +73,53 @@ func (r *IndexTemplateReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		return result, err
 	}
 
+	// Suspended resources are not reconciled, even when deleted: the cluster and their status are left untouched until
+	// they are resumed
+	if indexTemplateResource.Spec.Suspend {
+		logger.Info(fmt.Sprintf(controller.ResourceSuspendedMessage, controller.IndexTemplateResourceType, req.NamespacedName))
+		return result, nil
+	}
+
+	// Resources selecting their clusters by labels are synced through the resources created for every cluster
+	if len(indexTemplateResource.Spec.ResourceSelector.MatchLabels) > 0 {
+		logger.Info(fmt.Sprintf(controller.ResourceSelectsClustersByLabelsMessage, controller.IndexTemplateResourceType, req.NamespacedName))
+		return result, nil
+	}
+
 	// 3. Check if the IndexTemplate instance is marked to be deleted: indicated by the deletion timestamp being set
 	if !indexTemplateResource.DeletionTimestamp.IsZero() {
 		if controllerutil.ContainsFinalizer(indexTemplateResource, controller.ResourceFinalizer) {
+			original := indexTemplateResource.DeepCopy()
+
+			// The objects claimed by the resource, and counted in the quota of its namespace, are released even when
+			// their cleanup is skipped or fails, so the newer resources declaring them are not left in conflict
+			globals.ReleaseObjects(globals.ClaimTypeIndexTemplate, globals.ManagedByMarker(controller.IndexTemplateResourceType, indexTemplateResource))
+			globals.ReleaseNamespaceQuota(globals.ManagedByMarker(controller.IndexTemplateResourceType, indexTemplateResource))
+
+			// 3.1 Delete the resources associated with the IndexTemplate, unless the deletion policy retains them
+			if indexTemplateResource.Spec.DeletionPolicy == v1alpha1.DeletionPolicyRetain {
+				logger.Info(fmt.Sprintf(controller.ResourceRetainedMessage, controller.IndexTemplateResourceType, req.NamespacedName))
+			} else if globals.TargetClusterGone(ctx, &indexTemplateResource.Spec.ResourceSelector, indexTemplateResource.Namespace) {
+				logger.Info(fmt.Sprintf(controller.ResourceTargetGoneMessage, controller.IndexTemplateResourceType, req.NamespacedName))
+			} else {
+				err = r.Sync(ctx, watch.Deleted, indexTemplateResource)
+			}
 
-			// 3.1 Delete the resources associated with the SearchRule
-			err = r.Sync(ctx, watch.Deleted, indexTemplateResource)
+			// Failed cleanups are retried with backoff, keeping the finalizer and reporting why in the status, until they
+			// are given up
+			if globals.RetryCleanup(indexTemplateResource, err) {
+				logger.Info(fmt.Sprintf(controller.ResourceCleanupError, controller.IndexTemplateResourceType, req.NamespacedName, err.Error()))
+				if updateErr := globals.PatchStatus(ctx, r.Client, indexTemplateResource, original); updateErr != nil {
+					logger.Info(fmt.Sprintf(controller.ResourceConditionUpdateError, controller.IndexTemplateResourceType, req.NamespacedName, updateErr.Error()))
+				}
+				return result, err
+			}
+			if err != nil {
+				logger.Info(fmt.Sprintf(controller.ResourceCleanupGivenUpMessage, controller.IndexTemplateResourceType, req.NamespacedName, err.Error()))
+			}
 
-			// Remove the finalizers on Patch CR
-			controllerutil.RemoveFinalizer(indexTemplateResource, controller.ResourceFinalizer)
-			err = r.Update(ctx, indexTemplateResource)
+			// Remove the finalizers on IndexTemplate CR
+			err = globals.RemoveFinalizer(ctx, r.Client, indexTemplateResource, controller.ResourceFinalizer)
 			if err != nil {
 				logger.Info(fmt.Sprintf(controller.ResourceFinalizersUpdateError, controller.IndexTemplateResourceType, req.NamespacedName, err.Error()))
 			}
@@ -97,29 +130,26 @@ func (r *IndexTemplateReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		return result, err
 	}
 
-	// 4. Add finalizer to the SearchRule CR
+	// 4. Add finalizer to the IndexTemplate CR
 	if !controllerutil.ContainsFinalizer(indexTemplateResource, controller.ResourceFinalizer) {
-		controllerutil.AddFinalizer(indexTemplateResource, controller.ResourceFinalizer)
-		err = r.Update(ctx, indexTemplateResource)
+		err = globals.AddFinalizer(ctx, r.Client, indexTemplateResource, controller.ResourceFinalizer)
 		if err != nil {
 			return result, err
 		}
 	}
 
-	// 5. Update the status before the requeue
+	// 5. Update the status before the requeue, writing the changes made to it during the reconcile at once
+	original := indexTemplateResource.DeepCopy()
 	defer func() {
-		err = r.Status().Update(ctx, indexTemplateResource)
-		if err != nil {
-			logger.Info(fmt.Sprintf(controller.ResourceConditionUpdateError, controller.IndexTemplateResourceType, req.NamespacedName, err.Error()))
+		indexTemplateResource.Status.ObservedGeneration = indexTemplateResource.Generation
+		// The sync error is kept as the result, so failed syncs are requeued with backoff
+		if updateErr := globals.PatchStatus(ctx, r.Client, indexTemplateResource, original); updateErr != nil {
+			logger.Info(fmt.Sprintf(controller.ResourceConditionUpdateError, controller.IndexTemplateResourceType, req.NamespacedName, updateErr.Error()))
 		}
 	}()
 
 	// 6. Schedule periodical request
-	syncInterval := indexTemplateResource.Spec.SyncInterval
-	if syncInterval == "" {
-		syncInterval = controller.DefaultSyncInterval
-	}
-	RequeueTime, err := time.ParseDuration(syncInterval)
+	RequeueTime, err := controller.RequeueInterval(indexTemplateResource.Spec.SyncInterval)
 	if err != nil {
 		logger.Info(fmt.Sprintf(controller.ResourceSyncTimeRetrievalError, controller.IndexTemplateResourceType, req.NamespacedName, err.Error()))
 		return result, err
@@ -128,9 +158,11 @@ func (r *IndexTemplateReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		RequeueAfter: RequeueTime,
 	}
 
-	// 7. Check the rule
+	// 7. Sync the objects of the resource
 	err = r.Sync(ctx, watch.Modified, indexTemplateResource)
 	if err != nil {
+		// Failed syncs are requeued with the error backoff instead of the syncInterval
+		result = ctrl.Result{}
 		r.UpdateConditionKubernetesApiCallFailure(indexTemplateResource)
 		logger.Info(fmt.Sprintf(controller.SyncTargetError, controller.IndexTemplateResourceType, req.NamespacedName, err.Error()))
 		return result, err
@@ -142,11 +174,34 @@ func (r *IndexTemplateReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 	return result, err
 }
 
-// SetupWithManager sets up the controller with the Manager.
+// SetupWithManager sets up the controller with the Manager. The ECK clusters, and the Secrets and ConfigMaps referenced
+// by the resources are watched too, so the resources are synced as soon as their cluster becomes ready or the
+// references change
 func (r *IndexTemplateReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&v1alpha1.IndexTemplate{}).
-		WithEventFilter(predicate.GenerationChangedPredicate{}).
+	controllerBuilder := ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.IndexTemplate{}, builder.WithPredicates(predicate.GenerationChangedPredicate{}))
+
+	controllerBuilder = globals.WatchECKClusters(controllerBuilder, mgr, &v1alpha1.IndexTemplateList{}, func(object, cluster client.Object) bool {
+		return globals.SelectsECKCluster(&object.(*v1alpha1.IndexTemplate).Spec.ResourceSelector, object.GetNamespace(), cluster)
+	})
+
+	controllerBuilder, err := globals.WatchReferencedSecrets(controllerBuilder, mgr, &v1alpha1.IndexTemplate{}, &v1alpha1.IndexTemplateList{}, func(object client.Object) []*v1alpha1.ResourceSelector {
+		return []*v1alpha1.ResourceSelector{&object.(*v1alpha1.IndexTemplate).Spec.ResourceSelector}
+	})
+	if err != nil {
+		return err
+	}
+
+	controllerBuilder, err = globals.WatchReferencedSources(controllerBuilder, mgr, &v1alpha1.IndexTemplate{}, &v1alpha1.IndexTemplateList{}, func(object client.Object) []globals.SourceReference {
+		spec := &object.(*v1alpha1.IndexTemplate).Spec
+		return append(globals.ResourcesFromReferences(spec.ResourcesFrom), globals.ValuesFromReferences(spec.ValuesFrom)...)
+	})
+	if err != nil {
+		return err
+	}
+
+	return controllerBuilder.
 		Named("indextemplate").
+		WithOptions(globals.ControllerOptions()).
 		Complete(r)
 }
@@ -20,9 +20,14 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
+	"slices"
+	"sort"
+	"strings"
 
 	"github.com/elastic/go-elasticsearch/v8"
 	"k8s.io/apimachinery/pkg/watch"
@@ -30,6 +35,7 @@ import (
 
 	//
 	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/controller"
 	"elastic-config-operator.freepik.com/elastic-config-operator/internal/globals"
 )
 
@@ -39,26 +45,74 @@ func (r *IndexTemplateReconciler) Sync(ctx context.Context, eventType watch.Even
 
 	logger := log.FromContext(ctx)
 
-	// Get the ECK cluster associated to the resource
-	if resource.Spec.ResourceSelector.Namespace == "" {
-		resource.Spec.ResourceSelector.Namespace = resource.Namespace
+	// Resources only report the changes they would make in dry run or audit mode
+	dryRun := globals.IsDryRun(resource.Spec.DryRun)
+
+	// The target cluster is resolved without changing the spec: the NamespaceDefaultCluster is used when the resource
+	// doesn't select one, and the namespace of the resource when the selector has none
+	resourceSelector, err := globals.ResolveResourceSelector(ctx, &resource.Spec.ResourceSelector, resource.Namespace)
+	if err != nil {
+		logger.Error(err, "Failed to resolve the target cluster")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	// Check the namespace of the resource is allowed to target the cluster
+	if err := globals.AuthorizeClusterAccess(ctx, resourceSelector, resource.Namespace, &resource.Status.Conditions); err != nil {
+		logger.Error(err, "Access to the target cluster denied")
+		r.SetError(ctx, resource, err)
+		return err
 	}
 
-	// Build the cluster key for the pools
-	clusterKey := fmt.Sprintf("%s_%s", resource.Spec.ResourceSelector.Namespace, resource.Spec.ResourceSelector.Name)
+	if err := globals.ValidateTLSVerification(resourceSelector, &resource.Status.Conditions); err != nil {
+		logger.Error(err, "TLS verification of the target cluster not configured")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	// Build the cluster key identifying the target cluster in the logs
+	clusterKey := fmt.Sprintf("%s_%s", resourceSelector.Namespace, resourceSelector.Name)
 
 	if eventType == watch.Deleted {
 		logger.Info(fmt.Sprintf("Deleting IndexTemplate %s/%s", resource.Namespace, resource.Name))
 
+		globals.DeletePendingChanges(controller.IndexTemplateResourceType, resource.Namespace, resource.Name)
+
+		// Resources in dry run or audit mode never change Elasticsearch, not even when they are deleted
+		if dryRun {
+			logger.Info("Dry run enabled, leaving Elasticsearch unchanged")
+			return nil
+		}
+
 		// Get Elasticsearch connection to delete the templates
-		esConnection, err := globals.GetOrCreateElasticsearchConnection(ctx, clusterKey, &resource.Spec.ResourceSelector, resource.Namespace, r.ElasticsearchConnectionsPool)
+		esConnection, err := globals.GetOrCreateElasticsearchConnection(ctx, resourceSelector, resource.Namespace, r.ElasticsearchConnectionsPool)
 		if err != nil {
 			logger.Error(err, "Failed to get Elasticsearch connection for deletion")
 			return err
 		}
 
-		// Delete each index template from Elasticsearch
-		for templateName := range resource.Spec.Resources {
+		// Writes are serialized with the other resources targeting the same cluster
+		unlock, err := globals.LockCluster(ctx, esConnection.Endpoint)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+
+		// Delete each index template owned by the resource from Elasticsearch
+		marker := globals.ManagedByMarker(controller.IndexTemplateResourceType, resource)
+		// The templates loaded from resourcesFrom are only known by the status
+		templateNames := slices.Concat(slices.Collect(maps.Keys(resource.Spec.Resources)), resource.Status.AppliedResources)
+		slices.Sort(templateNames)
+		for _, templateName := range slices.Compact(templateNames) {
+			owned, err := r.ownsIndexTemplate(ctx, esConnection.Client, templateName, marker, slices.Contains(resource.Status.AppliedResources, templateName))
+			if err != nil {
+				logger.Error(err, fmt.Sprintf("Failed to get index template %s", templateName))
+				return err
+			}
+			if !owned || globals.ClaimedByOthers(esConnection.Endpoint, globals.ClaimTypeIndexTemplate, marker, templateName) {
+				logger.Info(fmt.Sprintf("Index template %s is owned by someone else, leaving it in Elasticsearch", templateName))
+				continue
+			}
 			logger.Info(fmt.Sprintf("Deleting index template %s from Elasticsearch", templateName))
 			if err := r.deleteIndexTemplate(ctx, esConnection.Client, templateName); err != nil {
 				logger.Error(err, fmt.Sprintf("Failed to delete index template %s", templateName))
@@ -72,16 +126,83 @@ func (r *IndexTemplateReconciler) Sync(ctx context.Context, eventType watch.Even
 
 	logger.Info(fmt.Sprintf("Syncing IndexTemplate %s/%s", resource.Namespace, resource.Name))
 
+	// Changes are postponed until the resources it depends on are Ready. Dry runs never change the cluster, so they
+	// are not gated
+	if !dryRun {
+		err = globals.CheckDependencies(ctx, resource.Spec.DependsOn, resource.Namespace)
+		globals.UpdateDependenciesReadyCondition(&resource.Status.Conditions, resource.Spec.DependsOn, err)
+		if err != nil {
+			logger.Error(err, "Failed to check the dependencies")
+			if errors.Is(err, globals.ErrDependenciesNotReady) {
+				r.SetWaitingForDependencies(ctx, resource, err)
+				return err
+			}
+			r.SetError(ctx, resource, err)
+			return err
+		}
+	}
+
+	// The templates of resourcesFrom are merged with the inline ones, then the ${key} references of the templates are
+	// replaced by the values of valuesFrom
+	templateResources, err := globals.LoadResources(ctx, resource.Spec.ResourcesFrom, resource.Namespace, resource.Spec.Resources)
+	if err != nil {
+		logger.Error(err, "Failed to load the templates of resourcesFrom")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+	templateResources, err = globals.ExpandValues(ctx, resource.Spec.ValuesFrom, resource.Namespace, templateResources)
+	if err != nil {
+		logger.Error(err, "Failed to expand the values of valuesFrom")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
 	// Set status to Syncing at the beginning
 	r.SetSyncing(ctx, resource)
 
 	// Step 1: Get or create Elasticsearch connection
-	esConnection, err := globals.GetOrCreateElasticsearchConnection(ctx, clusterKey, &resource.Spec.ResourceSelector, resource.Namespace, r.ElasticsearchConnectionsPool)
+	esConnection, err := globals.GetOrCreateElasticsearchConnection(ctx, resourceSelector, resource.Namespace, r.ElasticsearchConnectionsPool)
+	globals.UpdateClusterReachableCondition(&resource.Status.Conditions, err)
 	if err != nil {
 		logger.Error(err, "Failed to get or create Elasticsearch connection")
-		r.SetError(ctx, resource, fmt.Errorf("failed to connect to Elasticsearch: %w", err))
+		if errors.Is(err, globals.ErrClusterNotReady) {
+			r.SetWaitingForCluster(ctx, resource, err)
+			return err
+		}
+		r.SetError(ctx, resource, fmt.Errorf("%w to Elasticsearch: %w", globals.ErrConnectionFailed, err))
+		return err
+	}
+
+	// The Go templates of the templates are rendered with the cluster they are synced to
+	templateResources, err = globals.RenderTemplates(resource.Spec.Templating, templateResources, globals.NewTemplateData(resource, resourceSelector, esConnection))
+	if err != nil {
+		logger.Error(err, "Failed to render the templates of the templates")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	// Changes are postponed while the cluster is below the required health (e.g., while it is red). Dry runs never
+	// change the cluster, so they are not gated
+	if !dryRun {
+		err = globals.CheckClusterHealth(ctx, esConnection.Client, resource.Spec.RequiredClusterHealth)
+		globals.UpdateClusterHealthyCondition(&resource.Status.Conditions, resource.Spec.RequiredClusterHealth, err)
+		if err != nil {
+			logger.Error(err, "Failed to check the cluster health")
+			if errors.Is(err, globals.ErrClusterUnhealthy) {
+				r.SetWaitingForClusterHealth(ctx, resource, err)
+				return err
+			}
+			r.SetError(ctx, resource, err)
+			return err
+		}
+	}
+
+	// Writes are serialized with the other resources targeting the same cluster
+	unlock, err := globals.LockCluster(ctx, esConnection.Endpoint)
+	if err != nil {
 		return err
 	}
+	defer unlock()
 
 	logger.Info(fmt.Sprintf("Elasticsearch connection established for cluster %s", clusterKey))
 
@@ -93,25 +214,74 @@ func (r *IndexTemplateReconciler) Sync(ctx context.Context, eventType watch.Even
 
 	// Step 3: Get the list of desired templates (from Spec)
 	desiredTemplates := make(map[string]bool)
-	for templateName := range resource.Spec.Resources {
+	for templateName := range templateResources {
 		desiredTemplates[templateName] = true
 	}
 
+	// Changes made to Elasticsearch by this synchronization, reported in events and in the status
+	var changes []v1alpha1.ObjectChange
+
+	// A failing template does not stop the synchronization of the other ones, its error is reported once all of
+	// them are synced
+	failed := make(map[string]error)
+	newAppliedTemplates := make([]string, 0, len(templateResources))
+	newResources := make(map[string]v1alpha1.ResourceStatus, len(templateResources))
+
+	// Objects are marked with the resource that wrote them, so the ones owned by someone else are left untouched
+	marker := globals.ManagedByMarker(controller.IndexTemplateResourceType, resource)
+	conflicts := make(map[string]error)
+
+	// Resources taking their namespace over its quota of objects on the cluster are rejected before changing anything
+	if err := globals.CheckNamespaceQuota(esConnection.Endpoint, marker, resource, len(templateResources), dryRun); err != nil {
+		logger.Error(err, "Index templates rejected")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	// Templates declared by an older resource for the same cluster too are left to it, whatever the adoption policy,
+	// so the resources do not overwrite each other on every sync
+	claimConflicts := globals.ClaimObjects(esConnection.Endpoint, globals.ClaimTypeIndexTemplate, marker, resource,
+		slices.Collect(maps.Keys(desiredTemplates)), dryRun)
+
 	// Step 4: Delete templates that are no longer desired
 	for templateName := range appliedTemplates {
 		if !desiredTemplates[templateName] {
+			owned, err := r.ownsIndexTemplate(ctx, esConnection.Client, templateName, marker, true)
+			if err != nil {
+				logger.Error(err, fmt.Sprintf("Failed to get index template %s", templateName))
+				failed[templateName] = fmt.Errorf("failed to delete: %w", err)
+				continue
+			}
+			if !owned || globals.ClaimedByOthers(esConnection.Endpoint, globals.ClaimTypeIndexTemplate, marker, templateName) {
+				logger.Info(fmt.Sprintf("Template %s is no longer desired but owned by someone else, leaving it in Elasticsearch", templateName))
+				continue
+			}
 			logger.Info(fmt.Sprintf("Template %s is no longer desired, deleting from Elasticsearch", templateName))
+			change := v1alpha1.ObjectChange{Name: templateName, Action: globals.ChangeActionDelete}
+			globals.RecordChange(r.Recorder, resource, change, dryRun)
+			changes = append(changes, change)
+			if dryRun {
+				continue
+			}
 			if err := r.deleteIndexTemplate(ctx, esConnection.Client, templateName); err != nil {
 				logger.Error(err, fmt.Sprintf("Failed to delete index template %s", templateName))
-				return err
+				failed[templateName] = fmt.Errorf("failed to delete: %w", err)
+				continue
 			}
 			logger.Info(fmt.Sprintf("Index template %s deleted successfully", templateName))
+			globals.RecordPruned(r.Recorder, resource, templateName)
 		}
 	}
 
-	// Step 5: Apply all desired templates (idempotent)
-	newAppliedTemplates := make([]string, 0, len(resource.Spec.Resources))
-	for templateName, templateResource := range resource.Spec.Resources {
+	// Step 5: Apply the desired templates that changed in the spec or in Elasticsearch since they were last applied,
+	// detecting the out-of-band changes of the templates whose desired body did not change. Templates are applied in
+	// the order of applyOrder, then sorted by name
+	drifted := make(map[string][]string)
+	templateBodies := make(map[string]map[string]interface{}, len(templateResources))
+	overlaps := make(map[string][]string)
+	unchanged := make(map[string]bool)
+	for _, templateName := range resource.Spec.OrderNames(templateResources) {
+		templateResource := templateResources[templateName]
 		logger.Info(fmt.Sprintf("Processing index template: %s", templateName))
 
 		// Parse the desired template from the resource
@@ -119,29 +289,154 @@ func (r *IndexTemplateReconciler) Sync(ctx context.Context, eventType watch.Even
 		templateJSON, err := templateResource.MarshalJSON()
 		if err != nil {
 			logger.Error(err, fmt.Sprintf("Failed to marshal template %s", templateName))
-			return err
+			failed[templateName] = err
+			continue
 		}
 		if err := json.Unmarshal(templateJSON, &desiredTemplate); err != nil {
 			logger.Error(err, fmt.Sprintf("Failed to unmarshal template %s", templateName))
-			return err
+			failed[templateName] = err
+			continue
+		}
+		templateBodies[templateName] = desiredTemplate
+
+		if claimErr := claimConflicts[templateName]; claimErr != nil {
+			logger.Info(fmt.Sprintf("Index template %s left untouched: %s", templateName, claimErr))
+			conflicts[templateName] = claimErr
+			failed[templateName] = claimErr
+			continue
+		}
+
+		liveTemplate, exists, err := r.getIndexTemplate(ctx, esConnection.Client, templateName)
+		if err != nil {
+			logger.Error(err, fmt.Sprintf("Failed to get index template %s", templateName))
+			failed[templateName] = err
+			continue
+		}
+
+		// Objects existing in the cluster but not created by the resource are handled by its adoption policy
+		ownershipErr := globals.CheckOwnership(globals.ManagedBy(liveTemplate), exists, marker, appliedTemplates[templateName])
+		switch {
+		case ownershipErr == nil:
+		case resource.Spec.AdoptionPolicy == v1alpha1.AdoptionPolicyAdopt:
+			logger.Info(fmt.Sprintf("Adopting index template %s: %s", templateName, ownershipErr))
+		case resource.Spec.AdoptionPolicy == v1alpha1.AdoptionPolicyIgnoreDifferences:
+			logger.Info(fmt.Sprintf("Index template %s left as it is: %s", templateName, ownershipErr))
+			newResources[templateName] = globals.IgnoredResourceStatus()
+			continue
+		default:
+			logger.Info(fmt.Sprintf("Index template %s left untouched: %s", templateName, ownershipErr))
+			conflicts[templateName] = ownershipErr
+			failed[templateName] = ownershipErr
+			continue
+		}
+		desiredTemplate = globals.SetManagedBy(desiredTemplate, marker)
+
+		desiredHash := globals.HashJSON(desiredTemplate)
+		if resource.Status.Resources[templateName].Hash == desiredHash {
+			paths := globals.DetectDrift(normalizeTemplateSettings(desiredTemplate), normalizeTemplateSettings(liveTemplate), exists)
+			if len(paths) == 0 {
+				logger.Info(fmt.Sprintf("Index template %s is up to date, skipping", templateName))
+				unchanged[templateName] = true
+				newAppliedTemplates = append(newAppliedTemplates, templateName)
+				newResources[templateName] = resource.Status.Resources[templateName]
+				continue
+			}
+			logger.Info(fmt.Sprintf("Index template %s was changed out of band: %s", templateName, strings.Join(paths, ", ")))
+			drifted[templateName] = paths
+		}
+
+		// The template is simulated before being written, so the templates that would break the creation of the
+		// indices (e.g., with conflicting mappings or missing component templates) are never stored, and the templates
+		// of the cluster it overlaps are reported
+		overlapping, err := r.simulateIndexTemplate(ctx, esConnection.Client, templateName, desiredTemplate)
+		if err != nil {
+			logger.Error(err, fmt.Sprintf("Index template %s failed the simulation", templateName))
+			failed[templateName] = err
+			continue
+		}
+		overlaps[templateName] = overlapping
+
+		change := globals.NewObjectChange(templateName, normalizeTemplateSettings(desiredTemplate), normalizeTemplateSettings(liveTemplate), exists)
+		globals.RecordChange(r.Recorder, resource, change, dryRun)
+		changes = append(changes, change)
+		if dryRun {
+			continue
 		}
 
 		// Apply the template (PutIndexTemplate is idempotent - creates or updates)
 		if err := r.applyIndexTemplate(ctx, esConnection.Client, templateName, desiredTemplate); err != nil {
 			logger.Error(err, fmt.Sprintf("Failed to apply index template %s", templateName))
-			return err
+			failed[templateName] = err
+			continue
 		}
 		logger.Info(fmt.Sprintf("Index template %s applied successfully", templateName))
 		newAppliedTemplates = append(newAppliedTemplates, templateName)
+		newResources[templateName] = globals.AppliedResourceStatus(desiredHash)
 	}
 
-	// Step 6: Update the Status with the new list of applied templates
-	targetCluster := fmt.Sprintf("%s/%s", resource.Spec.ResourceSelector.Namespace, resource.Spec.ResourceSelector.Name)
-	if err := r.SetReady(ctx, resource, targetCluster, newAppliedTemplates); err != nil {
-		logger.Error(err, "Failed to update IndexTemplate status")
-		return err
+	// Keep tracking the failed templates applied by a previous synchronization, so they are deleted once removed from the
+	// spec, and report their error in their status, without their hash, so they are applied again
+	for templateName, err := range failed {
+		newResources[templateName] = globals.FailedResourceStatus(resource.Status.Resources[templateName], err)
+		if appliedTemplates[templateName] {
+			newAppliedTemplates = append(newAppliedTemplates, templateName)
+		}
+	}
+	failedErr := globals.ResourceErrors(failed)
+
+	globals.RecordDrift(r.Recorder, resource, &resource.Status.Conditions, drifted, dryRun)
+	globals.RecordConflicts(r.Recorder, resource, &resource.Status.Conditions, conflicts)
+	globals.RecordOverlaps(r.Recorder, resource, &resource.Status.Conditions, overlaps, unchanged)
+
+	// Step 6: Create the initial indices of the rollover aliases, once the templates they match are applied
+	bootstrapChanges, err := r.bootstrapRolloverAliases(ctx, esConnection.Client, resource, dryRun)
+	changes = append(changes, bootstrapChanges...)
+	if err != nil {
+		logger.Error(err, "Failed to bootstrap some rollover aliases")
+		failedErr = errors.Join(failedErr, err)
+	}
+
+	// Create the data streams of the templates, so producers don't race the templates on fresh clusters. Failed
+	// templates and the ones left as they are in the cluster are skipped
+	if resource.Spec.CreateDataStreams {
+		dataStreamTemplates := make(map[string]map[string]interface{}, len(templateBodies))
+		for templateName, templateBody := range templateBodies {
+			if _, templateFailed := failed[templateName]; templateFailed || newResources[templateName].State == globals.ResourceStateIgnored {
+				continue
+			}
+			dataStreamTemplates[templateName] = templateBody
+		}
+		dataStreamChanges, err := r.bootstrapDataStreams(ctx, esConnection.Client, resource, dataStreamTemplates, dryRun)
+		changes = append(changes, dataStreamChanges...)
+		if err != nil {
+			logger.Error(err, "Failed to create some data streams")
+			failedErr = errors.Join(failedErr, err)
+		}
 	}
 
+	targetCluster := fmt.Sprintf("%s/%s", resourceSelector.Namespace, resourceSelector.Name)
+
+	// Resources in dry run or audit mode only report the changes the synchronization would make
+	if dryRun {
+		logger.Info(fmt.Sprintf("Dry run of IndexTemplate %s/%s found %d pending changes", resource.Namespace, resource.Name, len(changes)))
+		r.SetDryRun(ctx, resource, targetCluster, changes, failedErr)
+		return failedErr
+	}
+
+	// Keep the changes of the last synchronization that changed anything
+	if len(changes) > 0 {
+		resource.Status.Changes = changes
+	}
+
+	if failedErr != nil {
+		logger.Error(failedErr, "Failed to sync some templates")
+		r.SetDegraded(ctx, resource, targetCluster, newAppliedTemplates, newResources, failedErr)
+		return failedErr
+	}
+
+	// Step 7: Update the Status with the new list of applied templates
+	r.SetReady(ctx, resource, targetCluster, newAppliedTemplates, newResources)
+
 	logger.Info(fmt.Sprintf("IndexTemplate %s/%s synced successfully", resource.Namespace, resource.Name))
 
 	return nil
@@ -178,6 +473,341 @@ func (r *IndexTemplateReconciler) applyIndexTemplate(ctx context.Context, esClie
 	return nil
 }
 
+// simulateIndexTemplate validates an index template with the simulate API, which composes it with its component
+// templates as if it replaced the template of the same name, without storing it. It returns the templates of the
+// cluster the template overlaps, which match the same indices with a lower priority, with their index patterns
+func (r *IndexTemplateReconciler) simulateIndexTemplate(ctx context.Context, esClient *elasticsearch.Client, templateName string, template map[string]interface{}) ([]string, error) {
+	templateJSON, err := json.Marshal(template)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal template: %w", err)
+	}
+
+	res, err := esClient.Indices.SimulateTemplate(
+		esClient.Indices.SimulateTemplate.WithName(templateName),
+		esClient.Indices.SimulateTemplate.WithBody(bytes.NewReader(templateJSON)),
+		esClient.Indices.SimulateTemplate.WithContext(ctx),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to simulate index template: %w", err)
+	}
+	defer res.Body.Close()
+
+	bodyBytes, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if res.IsError() {
+		return nil, fmt.Errorf("invalid index template: %s - %s", res.Status(), string(bodyBytes))
+	}
+
+	var simulation struct {
+		Overlapping []struct {
+			Name          string   `json:"name"`
+			IndexPatterns []string `json:"index_patterns"`
+		} `json:"overlapping"`
+	}
+	if err := json.Unmarshal(bodyBytes, &simulation); err != nil {
+		return nil, fmt.Errorf("failed to parse index template simulation: %w", err)
+	}
+
+	overlapping := make([]string, 0, len(simulation.Overlapping))
+	for _, template := range simulation.Overlapping {
+		overlapping = append(overlapping, fmt.Sprintf("%s (%s)", template.Name, strings.Join(template.IndexPatterns, ", ")))
+	}
+	sort.Strings(overlapping)
+	return overlapping, nil
+}
+
+// bootstrapRolloverAliases creates the initial index of every rollover alias of the resource that does not exist yet,
+// with the alias as its write alias, and returns the changes made. Dry runs only return the changes
+func (r *IndexTemplateReconciler) bootstrapRolloverAliases(ctx context.Context, esClient *elasticsearch.Client, resource *v1alpha1.IndexTemplate, dryRun bool) ([]v1alpha1.ObjectChange, error) {
+	logger := log.FromContext(ctx)
+
+	var changes []v1alpha1.ObjectChange
+	var errs []error
+	for _, bootstrap := range resource.Spec.Bootstrap {
+		indexName := bootstrap.Index
+		if indexName == "" {
+			indexName = bootstrap.Alias + "-000001"
+		}
+
+		exists, err := r.aliasExists(ctx, esClient, bootstrap.Alias)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to bootstrap alias %s: %w", bootstrap.Alias, err))
+			continue
+		}
+		if exists {
+			continue
+		}
+
+		logger.Info(fmt.Sprintf("Rollover alias %s does not exist, creating its initial index %s", bootstrap.Alias, indexName))
+		change := v1alpha1.ObjectChange{Name: indexName, Action: globals.ChangeActionCreate}
+		globals.RecordChange(r.Recorder, resource, change, dryRun)
+		changes = append(changes, change)
+		if dryRun {
+			continue
+		}
+
+		if err := r.createBootstrapIndex(ctx, esClient, indexName, bootstrap.Alias); err != nil {
+			errs = append(errs, fmt.Errorf("failed to bootstrap alias %s: %w", bootstrap.Alias, err))
+			continue
+		}
+		logger.Info(fmt.Sprintf("Initial index %s of rollover alias %s created successfully", indexName, bootstrap.Alias))
+	}
+
+	return changes, errors.Join(errs...)
+}
+
+// bootstrapDataStreams creates the missing data streams of the data stream templates, one for every index pattern
+// without wildcards, and returns the changes made. Patterns with wildcards name no data stream, so they are skipped.
+// Dry runs only return the changes
+func (r *IndexTemplateReconciler) bootstrapDataStreams(ctx context.Context, esClient *elasticsearch.Client, resource *v1alpha1.IndexTemplate, templates map[string]map[string]interface{}, dryRun bool) ([]v1alpha1.ObjectChange, error) {
+	logger := log.FromContext(ctx)
+
+	templateNames := make([]string, 0, len(templates))
+	for templateName := range templates {
+		templateNames = append(templateNames, templateName)
+	}
+	sort.Strings(templateNames)
+
+	var changes []v1alpha1.ObjectChange
+	var errs []error
+	for _, templateName := range templateNames {
+		template := templates[templateName]
+		if _, isDataStream := template["data_stream"]; !isDataStream {
+			continue
+		}
+
+		for _, indexPattern := range templateIndexPatterns(template) {
+			if strings.Contains(indexPattern, "*") {
+				logger.Info(fmt.Sprintf("Index pattern %s of template %s has wildcards, not creating its data stream", indexPattern, templateName))
+				continue
+			}
+
+			exists, err := r.dataStreamExists(ctx, esClient, indexPattern)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("failed to create data stream %s: %w", indexPattern, err))
+				continue
+			}
+			if exists {
+				continue
+			}
+
+			change := v1alpha1.ObjectChange{Name: indexPattern, Action: globals.ChangeActionCreate}
+			globals.RecordChange(r.Recorder, resource, change, dryRun)
+			changes = append(changes, change)
+			if dryRun {
+				continue
+			}
+
+			if err := r.createDataStream(ctx, esClient, indexPattern); err != nil {
+				errs = append(errs, fmt.Errorf("failed to create data stream %s: %w", indexPattern, err))
+				continue
+			}
+			logger.Info(fmt.Sprintf("Data stream %s of template %s created successfully", indexPattern, templateName))
+		}
+	}
+
+	return changes, errors.Join(errs...)
+}
+
+// templateIndexPatterns returns the index patterns of a template, which are either a pattern or a list of patterns
+func templateIndexPatterns(template map[string]interface{}) []string {
+	switch indexPatterns := template["index_patterns"].(type) {
+	case string:
+		return []string{indexPatterns}
+	case []interface{}:
+		patterns := make([]string, 0, len(indexPatterns))
+		for _, pattern := range indexPatterns {
+			if patternString, ok := pattern.(string); ok {
+				patterns = append(patterns, patternString)
+			}
+		}
+		return patterns
+	}
+	return nil
+}
+
+// dataStreamExists returns whether a data stream exists in Elasticsearch
+func (r *IndexTemplateReconciler) dataStreamExists(ctx context.Context, esClient *elasticsearch.Client, name string) (bool, error) {
+	res, err := esClient.Indices.GetDataStream(
+		esClient.Indices.GetDataStream.WithName(name),
+		esClient.Indices.GetDataStream.WithContext(ctx),
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to get data stream: %w", err)
+	}
+	defer res.Body.Close()
+
+	switch res.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		bodyBytes, _ := io.ReadAll(res.Body)
+		return false, fmt.Errorf("elasticsearch API error: %s - %s", res.Status(), string(bodyBytes))
+	}
+}
+
+// createDataStream creates a data stream in Elasticsearch, with the settings of its template
+func (r *IndexTemplateReconciler) createDataStream(ctx context.Context, esClient *elasticsearch.Client, name string) error {
+	res, err := esClient.Indices.CreateDataStream(
+		name,
+		esClient.Indices.CreateDataStream.WithContext(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create data stream: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		bodyBytes, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("elasticsearch API error: %s - %s", res.Status(), string(bodyBytes))
+	}
+
+	return nil
+}
+
+// aliasExists returns whether an alias exists in Elasticsearch
+func (r *IndexTemplateReconciler) aliasExists(ctx context.Context, esClient *elasticsearch.Client, alias string) (bool, error) {
+	res, err := esClient.Indices.ExistsAlias(
+		[]string{alias},
+		esClient.Indices.ExistsAlias.WithContext(ctx),
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to check alias: %w", err)
+	}
+	defer res.Body.Close()
+
+	switch res.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		bodyBytes, _ := io.ReadAll(res.Body)
+		return false, fmt.Errorf("elasticsearch API error: %s - %s", res.Status(), string(bodyBytes))
+	}
+}
+
+// createBootstrapIndex creates the initial index of a rollover alias, with the alias as its write alias
+func (r *IndexTemplateReconciler) createBootstrapIndex(ctx context.Context, esClient *elasticsearch.Client, indexName, alias string) error {
+	indexJSON, err := json.Marshal(map[string]interface{}{
+		"aliases": map[string]interface{}{
+			alias: map[string]interface{}{
+				"is_write_index": true,
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal index: %w", err)
+	}
+
+	res, err := esClient.Indices.Create(
+		indexName,
+		esClient.Indices.Create.WithBody(bytes.NewReader(indexJSON)),
+		esClient.Indices.Create.WithContext(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create index: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		bodyBytes, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("elasticsearch API error: %s - %s", res.Status(), string(bodyBytes))
+	}
+
+	return nil
+}
+
+// getIndexTemplate returns the index template stored in Elasticsearch, and whether it exists
+func (r *IndexTemplateReconciler) getIndexTemplate(ctx context.Context, esClient *elasticsearch.Client, templateName string) (map[string]interface{}, bool, error) {
+	res, err := esClient.Indices.GetIndexTemplate(
+		esClient.Indices.GetIndexTemplate.WithName(templateName),
+		esClient.Indices.GetIndexTemplate.WithContext(ctx),
+	)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get index template: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, false, nil
+	}
+
+	bodyBytes, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if res.IsError() {
+		return nil, false, fmt.Errorf("elasticsearch API error: %s - %s", res.Status(), string(bodyBytes))
+	}
+
+	var response struct {
+		IndexTemplates []struct {
+			IndexTemplate map[string]interface{} `json:"index_template"`
+		} `json:"index_templates"`
+	}
+	if err := json.Unmarshal(bodyBytes, &response); err != nil {
+		return nil, false, fmt.Errorf("failed to parse index template: %w", err)
+	}
+	if len(response.IndexTemplates) == 0 {
+		return nil, false, nil
+	}
+
+	return response.IndexTemplates[0].IndexTemplate, true, nil
+}
+
+// ownsIndexTemplate reports whether the resource owns an index template of Elasticsearch, given its marker and
+// whether the resource applied it. Missing templates are owned, as deleting them changes nothing
+func (r *IndexTemplateReconciler) ownsIndexTemplate(ctx context.Context, esClient *elasticsearch.Client, templateName, marker string, applied bool) (bool, error) {
+	liveTemplate, exists, err := r.getIndexTemplate(ctx, esClient, templateName)
+	if err != nil {
+		return false, err
+	}
+	if !exists {
+		return true, nil
+	}
+	return globals.OwnsObject(globals.ManagedBy(liveTemplate), marker, applied), nil
+}
+
+// normalizeTemplateSettings returns a copy of the template with its index settings flattened and prefixed with
+// "index.", as Elasticsearch stores them nested under "index" whatever the form they were written in
+func normalizeTemplateSettings(template map[string]interface{}) map[string]interface{} {
+	templateSection, ok := template["template"].(map[string]interface{})
+	if !ok {
+		return template
+	}
+	settings, ok := templateSection["settings"].(map[string]interface{})
+	if !ok {
+		return template
+	}
+
+	normalizedSettings := make(map[string]interface{})
+	for key, value := range globals.FlattenSettings(settings) {
+		if !strings.HasPrefix(key, "index.") {
+			key = "index." + key
+		}
+		normalizedSettings[key] = value
+	}
+
+	normalizedSection := make(map[string]interface{}, len(templateSection))
+	for key, value := range templateSection {
+		normalizedSection[key] = value
+	}
+	normalizedSection["settings"] = normalizedSettings
+
+	normalizedTemplate := make(map[string]interface{}, len(template))
+	for key, value := range template {
+		normalizedTemplate[key] = value
+	}
+	normalizedTemplate["template"] = normalizedSection
+
+	return normalizedTemplate
+}
+
 // deleteIndexTemplate deletes an index template from Elasticsearch
 func (r *IndexTemplateReconciler) deleteIndexTemplate(ctx context.Context, esClient *elasticsearch.Client, templateName string) error {
 	logger := log.FromContext(ctx)
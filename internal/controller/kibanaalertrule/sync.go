@@ -0,0 +1,387 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kibanaalertrule
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/globals"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/pools"
+)
+
+const defaultSpace = "default"
+
+// ruleCreateOnlyFields are accepted when creating a rule but rejected by the update API
+var ruleCreateOnlyFields = []string{"rule_type_id", "consumer", "enabled"}
+
+// liveRule is the subset of the rule GET response used by the controller
+type liveRule struct {
+	RuleTypeID string `json:"rule_type_id"`
+	Consumer   string `json:"consumer"`
+	Enabled    bool   `json:"enabled"`
+}
+
+// Sync executes the synchronization of alerting rules and connectors with Kibana
+func (r *KibanaAlertRuleReconciler) Sync(ctx context.Context, eventType watch.EventType, resource *v1alpha1.KibanaAlertRule) (err error) {
+
+	logger := log.FromContext(ctx)
+
+	// The Kibana instance is resolved without changing the spec, in the namespace of the resource when the selector
+	// has none
+	kibanaSelector := resource.Spec.KibanaSelector.DeepCopy()
+	if kibanaSelector.Namespace == "" {
+		kibanaSelector.Namespace = resource.Namespace
+	}
+
+	// Build the Kibana key for the pools
+	kibanaKey := fmt.Sprintf("%s_%s", kibanaSelector.Namespace, kibanaSelector.Name)
+
+	if eventType == watch.Deleted {
+		logger.Info(fmt.Sprintf("Deleting KibanaAlertRule %s/%s", resource.Namespace, resource.Name))
+
+		// Get Kibana connection to delete the rules and connectors
+		kibanaConnection, err := globals.GetOrCreateKibanaConnection(ctx, kibanaKey, kibanaSelector, resource.Namespace, r.KibanaConnectionsPool)
+		if err != nil {
+			logger.Error(err, "Failed to get Kibana connection for deletion")
+			return err
+		}
+
+		// Rules are deleted first, as they reference the connectors
+		for _, ruleID := range resource.Status.AppliedResources {
+			logger.Info(fmt.Sprintf("Deleting rule %s from Kibana", ruleID))
+			if err := r.deleteObject(ctx, kibanaConnection, resource.Status.Space, fmt.Sprintf("/api/alerting/rule/%s", ruleID)); err != nil {
+				logger.Error(err, fmt.Sprintf("Failed to delete rule %s", ruleID))
+				return err
+			}
+			logger.Info(fmt.Sprintf("Rule %s deleted successfully", ruleID))
+		}
+
+		for _, connectorID := range resource.Status.AppliedConnectors {
+			logger.Info(fmt.Sprintf("Deleting connector %s from Kibana", connectorID))
+			if err := r.deleteObject(ctx, kibanaConnection, resource.Status.Space, fmt.Sprintf("/api/actions/connector/%s", connectorID)); err != nil {
+				logger.Error(err, fmt.Sprintf("Failed to delete connector %s", connectorID))
+				return err
+			}
+			logger.Info(fmt.Sprintf("Connector %s deleted successfully", connectorID))
+		}
+
+		return nil
+	}
+
+	logger.Info(fmt.Sprintf("Syncing KibanaAlertRule %s/%s", resource.Namespace, resource.Name))
+
+	// Set status to Syncing at the beginning
+	r.SetSyncing(ctx, resource)
+
+	// Step 1: Get or create Kibana connection
+	kibanaConnection, err := globals.GetOrCreateKibanaConnection(ctx, kibanaKey, kibanaSelector, resource.Namespace, r.KibanaConnectionsPool)
+	if err != nil {
+		logger.Error(err, "Failed to get or create Kibana connection")
+		r.SetError(ctx, resource, fmt.Errorf("%w to Kibana: %w", globals.ErrConnectionFailed, err))
+		return err
+	}
+
+	logger.Info(fmt.Sprintf("Kibana connection established for %s (version: %s)", kibanaKey, kibanaConnection.Version))
+
+	space := resource.Spec.Space
+	if space == "" {
+		space = defaultSpace
+	}
+
+	// When the space changes, everything created in the previous space is removed
+	previousSpace := resource.Status.Space
+	if previousSpace == "" {
+		previousSpace = space
+	}
+
+	// Step 2: Delete rules that are no longer desired, before the connectors they may reference
+	for _, ruleID := range resource.Status.AppliedResources {
+		if _, desired := resource.Spec.Resources[ruleID]; desired && previousSpace == space {
+			continue
+		}
+		logger.Info(fmt.Sprintf("Rule %s is no longer desired in space %s, deleting from Kibana", ruleID, previousSpace))
+		if err := r.deleteObject(ctx, kibanaConnection, previousSpace, fmt.Sprintf("/api/alerting/rule/%s", ruleID)); err != nil {
+			logger.Error(err, fmt.Sprintf("Failed to delete rule %s", ruleID))
+			r.SetError(ctx, resource, fmt.Errorf("failed to delete rule %s: %w", ruleID, err))
+			return err
+		}
+		logger.Info(fmt.Sprintf("Rule %s deleted successfully", ruleID))
+		globals.RecordPruned(r.Recorder, resource, ruleID)
+	}
+
+	// Step 3: Create or update all desired connectors
+	newAppliedConnectors := make([]string, 0, len(resource.Spec.Connectors))
+	for connectorID, connector := range resource.Spec.Connectors {
+		logger.Info(fmt.Sprintf("Applying connector %s to Kibana", connectorID))
+		if err := r.applyConnector(ctx, kibanaConnection, space, connectorID, connector, resource.Namespace); err != nil {
+			logger.Error(err, fmt.Sprintf("Failed to apply connector %s", connectorID))
+			r.SetError(ctx, resource, fmt.Errorf("failed to apply connector %s: %w", connectorID, err))
+			return err
+		}
+		newAppliedConnectors = append(newAppliedConnectors, connectorID)
+		logger.Info(fmt.Sprintf("Connector %s applied successfully", connectorID))
+	}
+
+	// Step 4: Create or update all desired rules
+	newAppliedRules := make([]string, 0, len(resource.Spec.Resources))
+	for ruleID, ruleResource := range resource.Spec.Resources {
+		var rule map[string]interface{}
+		if err := json.Unmarshal(ruleResource.Raw, &rule); err != nil {
+			logger.Error(err, fmt.Sprintf("Failed to unmarshal rule %s", ruleID))
+			r.SetError(ctx, resource, fmt.Errorf("failed to unmarshal rule %s: %w", ruleID, err))
+			return err
+		}
+
+		// The ID is taken from the resource key and the name defaults to it
+		delete(rule, "id")
+		if name, _ := rule["name"].(string); name == "" {
+			rule["name"] = ruleID
+		}
+
+		logger.Info(fmt.Sprintf("Applying rule %s to Kibana", ruleID))
+		if err := r.applyRule(ctx, kibanaConnection, space, ruleID, rule); err != nil {
+			logger.Error(err, fmt.Sprintf("Failed to apply rule %s", ruleID))
+			r.SetError(ctx, resource, fmt.Errorf("failed to apply rule %s: %w", ruleID, err))
+			return err
+		}
+		newAppliedRules = append(newAppliedRules, ruleID)
+		logger.Info(fmt.Sprintf("Rule %s applied successfully", ruleID))
+	}
+
+	// Step 5: Delete connectors that are no longer desired, now that no managed rule references them
+	for _, connectorID := range resource.Status.AppliedConnectors {
+		if _, desired := resource.Spec.Connectors[connectorID]; desired && previousSpace == space {
+			continue
+		}
+		logger.Info(fmt.Sprintf("Connector %s is no longer desired in space %s, deleting from Kibana", connectorID, previousSpace))
+		if err := r.deleteObject(ctx, kibanaConnection, previousSpace, fmt.Sprintf("/api/actions/connector/%s", connectorID)); err != nil {
+			logger.Error(err, fmt.Sprintf("Failed to delete connector %s", connectorID))
+			r.SetError(ctx, resource, fmt.Errorf("failed to delete connector %s: %w", connectorID, err))
+			return err
+		}
+		logger.Info(fmt.Sprintf("Connector %s deleted successfully", connectorID))
+		globals.RecordPruned(r.Recorder, resource, connectorID)
+	}
+
+	// Step 6: Update the Status with the new list of applied rules and connectors
+	resource.Status.Space = space
+	resource.Status.AppliedConnectors = newAppliedConnectors
+	targetKibana := fmt.Sprintf("%s/%s", kibanaSelector.Namespace, kibanaSelector.Name)
+	r.SetReady(ctx, resource, targetKibana, newAppliedRules)
+
+	logger.Info(fmt.Sprintf("KibanaAlertRule %s/%s synced successfully", resource.Namespace, resource.Name))
+
+	return nil
+}
+
+// applyConnector updates a Kibana connector, creating it when it doesn't exist yet
+func (r *KibanaAlertRuleReconciler) applyConnector(ctx context.Context, kibanaConnection *pools.KibanaConnection, space, connectorID string, connector v1alpha1.KibanaConnector, namespace string) error {
+	logger := log.FromContext(ctx)
+
+	body := map[string]interface{}{
+		"name":   connector.Name,
+		"config": map[string]interface{}{},
+	}
+	if connector.Name == "" {
+		body["name"] = connectorID
+	}
+	if connector.Config != nil {
+		var config map[string]interface{}
+		if err := json.Unmarshal(connector.Config.Raw, &config); err != nil {
+			return fmt.Errorf("failed to unmarshal connector config: %w", err)
+		}
+		body["config"] = config
+	}
+
+	// Resolve the sensitive settings from their Secrets
+	secrets := make(map[string]interface{}, len(connector.SecretRefs))
+	for field, secretRef := range connector.SecretRefs {
+		value, err := globals.GetSecretValue(ctx, &secretRef, namespace)
+		if err != nil {
+			return fmt.Errorf("failed to get secret for %s: %w", field, err)
+		}
+		secrets[field] = value
+	}
+	body["secrets"] = secrets
+
+	// PUT /s/{space}/api/actions/connector/{id}
+	path := fmt.Sprintf("/api/actions/connector/%s", connectorID)
+	res, err := r.performRequest(ctx, kibanaConnection, http.MethodPut, space, path, body)
+	if err != nil {
+		return fmt.Errorf("failed to update connector: %w", err)
+	}
+	if res.StatusCode != http.StatusNotFound {
+		return r.expectSuccess(res, nil)
+	}
+	res.Body.Close()
+
+	// If the connector doesn't exist (404), create it. The connector type can only be set on creation
+	logger.Info(fmt.Sprintf("Connector %s not found in Kibana, creating it", connectorID))
+	body["connector_type_id"] = connector.ConnectorTypeID
+
+	// POST /s/{space}/api/actions/connector/{id}
+	return r.expectSuccess(r.performRequest(ctx, kibanaConnection, http.MethodPost, space, path, body))
+}
+
+// applyRule creates or updates a Kibana alerting rule and moves it to the desired enabled state.
+// Rules whose type or consumer changed are recreated, as those fields can not be updated
+func (r *KibanaAlertRuleReconciler) applyRule(ctx context.Context, kibanaConnection *pools.KibanaConnection, space, ruleID string, rule map[string]interface{}) error {
+	logger := log.FromContext(ctx)
+
+	path := fmt.Sprintf("/api/alerting/rule/%s", ruleID)
+
+	desiredEnabled := true
+	if enabled, ok := rule["enabled"].(bool); ok {
+		desiredEnabled = enabled
+	}
+
+	current, err := r.getRule(ctx, kibanaConnection, space, ruleID)
+	if err != nil {
+		return err
+	}
+
+	if current != nil {
+		ruleTypeID, _ := rule["rule_type_id"].(string)
+		consumer, _ := rule["consumer"].(string)
+		if (ruleTypeID != "" && ruleTypeID != current.RuleTypeID) || (consumer != "" && consumer != current.Consumer) {
+			logger.Info(fmt.Sprintf("Rule %s changed its type or consumer, recreating it", ruleID))
+			if err := r.deleteObject(ctx, kibanaConnection, space, path); err != nil {
+				return err
+			}
+			current = nil
+		}
+	}
+
+	// POST /s/{space}/api/alerting/rule/{id}
+	if current == nil {
+		logger.Info(fmt.Sprintf("Creating rule %s in Kibana", ruleID))
+		return r.expectSuccess(r.performRequest(ctx, kibanaConnection, http.MethodPost, space, path, rule))
+	}
+
+	// PUT /s/{space}/api/alerting/rule/{id}
+	update := make(map[string]interface{}, len(rule))
+	for field, value := range rule {
+		update[field] = value
+	}
+	for _, field := range ruleCreateOnlyFields {
+		delete(update, field)
+	}
+	if err := r.expectSuccess(r.performRequest(ctx, kibanaConnection, http.MethodPut, space, path, update)); err != nil {
+		return err
+	}
+
+	// POST /s/{space}/api/alerting/rule/{id}/_enable or _disable
+	if current.Enabled != desiredEnabled {
+		action := "_disable"
+		if desiredEnabled {
+			action = "_enable"
+		}
+		logger.Info(fmt.Sprintf("Changing rule %s enabled state to %t", ruleID, desiredEnabled))
+		return r.expectSuccess(r.performRequest(ctx, kibanaConnection, http.MethodPost, space, fmt.Sprintf("%s/%s", path, action), nil))
+	}
+
+	return nil
+}
+
+// getRule returns the rule from Kibana, or nil if it does not exist
+func (r *KibanaAlertRuleReconciler) getRule(ctx context.Context, kibanaConnection *pools.KibanaConnection, space, ruleID string) (*liveRule, error) {
+	// GET /s/{space}/api/alerting/rule/{id}
+	res, err := r.performRequest(ctx, kibanaConnection, http.MethodGet, space, fmt.Sprintf("/api/alerting/rule/%s", ruleID), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rule: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+
+	bodyBytes, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if res.StatusCode >= 400 {
+		return nil, fmt.Errorf("kibana API error: %s - %s", res.Status, string(bodyBytes))
+	}
+
+	rule := &liveRule{}
+	if err := json.Unmarshal(bodyBytes, rule); err != nil {
+		return nil, fmt.Errorf("failed to parse rule: %w", err)
+	}
+
+	return rule, nil
+}
+
+// deleteObject deletes a rule or connector from a Kibana space
+func (r *KibanaAlertRuleReconciler) deleteObject(ctx context.Context, kibanaConnection *pools.KibanaConnection, space, path string) error {
+	logger := log.FromContext(ctx)
+
+	res, err := r.performRequest(ctx, kibanaConnection, http.MethodDelete, space, path, nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete %s: %w", path, err)
+	}
+
+	// If the object doesn't exist (404), consider it already deleted
+	if res.StatusCode == http.StatusNotFound {
+		res.Body.Close()
+		logger.Info(fmt.Sprintf("Object %s not found in Kibana (already deleted)", path))
+		return nil
+	}
+
+	return r.expectSuccess(res, nil)
+}
+
+// performRequest sends a JSON request to the Kibana API
+func (r *KibanaAlertRuleReconciler) performRequest(ctx context.Context, kibanaConnection *pools.KibanaConnection, method, space, path string, body map[string]interface{}) (*http.Response, error) {
+	var bodyReader io.Reader
+	contentType := ""
+	if body != nil {
+		bodyJSON, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		bodyReader = bytes.NewReader(bodyJSON)
+		contentType = "application/json"
+	}
+
+	return globals.PerformKibanaRequest(ctx, kibanaConnection, method, space, path, bodyReader, contentType)
+}
+
+// expectSuccess closes the response and turns any error status into an error
+func (r *KibanaAlertRuleReconciler) expectSuccess(res *http.Response, err error) error {
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= 400 {
+		bodyBytes, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("kibana API error: %s - %s", res.Status, string(bodyBytes))
+	}
+
+	return nil
+}
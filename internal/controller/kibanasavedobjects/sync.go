@@ -0,0 +1,283 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kibanasavedobjects
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/globals"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/pools"
+)
+
+const defaultSpace = "default"
+
+// Sync executes the import of saved objects into Kibana
+func (r *KibanaSavedObjectsReconciler) Sync(ctx context.Context, eventType watch.EventType, resource *v1alpha1.KibanaSavedObjects) (err error) {
+
+	logger := log.FromContext(ctx)
+
+	// The Kibana instance is resolved without changing the spec, in the namespace of the resource when the selector
+	// has none
+	kibanaSelector := resource.Spec.KibanaSelector.DeepCopy()
+	if kibanaSelector.Namespace == "" {
+		kibanaSelector.Namespace = resource.Namespace
+	}
+
+	// Build the Kibana key for the pools
+	kibanaKey := fmt.Sprintf("%s_%s", kibanaSelector.Namespace, kibanaSelector.Name)
+
+	if eventType == watch.Deleted {
+		logger.Info(fmt.Sprintf("Deleting KibanaSavedObjects %s/%s", resource.Namespace, resource.Name))
+
+		// Get Kibana connection to delete the saved objects
+		kibanaConnection, err := globals.GetOrCreateKibanaConnection(ctx, kibanaKey, kibanaSelector, resource.Namespace, r.KibanaConnectionsPool)
+		if err != nil {
+			logger.Error(err, "Failed to get Kibana connection for deletion")
+			return err
+		}
+
+		// Delete each saved object imported by this resource
+		for _, objectKey := range resource.Status.AppliedResources {
+			logger.Info(fmt.Sprintf("Deleting saved object %s from Kibana", objectKey))
+			if err := r.deleteSavedObject(ctx, kibanaConnection, resource.Status.Space, objectKey); err != nil {
+				logger.Error(err, fmt.Sprintf("Failed to delete saved object %s", objectKey))
+				return err
+			}
+			logger.Info(fmt.Sprintf("Saved object %s deleted successfully", objectKey))
+		}
+
+		return nil
+	}
+
+	logger.Info(fmt.Sprintf("Syncing KibanaSavedObjects %s/%s", resource.Namespace, resource.Name))
+
+	// Set status to Syncing at the beginning
+	r.SetSyncing(ctx, resource)
+
+	// Step 1: Get or create Kibana connection
+	kibanaConnection, err := globals.GetOrCreateKibanaConnection(ctx, kibanaKey, kibanaSelector, resource.Namespace, r.KibanaConnectionsPool)
+	if err != nil {
+		logger.Error(err, "Failed to get or create Kibana connection")
+		r.SetError(ctx, resource, fmt.Errorf("%w to Kibana: %w", globals.ErrConnectionFailed, err))
+		return err
+	}
+
+	logger.Info(fmt.Sprintf("Kibana connection established for %s (version: %s)", kibanaKey, kibanaConnection.Version))
+
+	space := resource.Spec.Space
+	if space == "" {
+		space = defaultSpace
+	}
+
+	overwrite := true
+	if resource.Spec.Overwrite != nil {
+		overwrite = *resource.Spec.Overwrite
+	}
+
+	// Step 2: Build the list of desired saved objects from Spec
+	// Format: "type/id"
+	desiredObjects := make(map[string]bool)
+	objects := make([]map[string]interface{}, 0, len(resource.Spec.Resources))
+	for objectID, objectResource := range resource.Spec.Resources {
+		var object map[string]interface{}
+		if err := json.Unmarshal(objectResource.Raw, &object); err != nil {
+			logger.Error(err, fmt.Sprintf("Failed to unmarshal saved object %s", objectID))
+			r.SetError(ctx, resource, fmt.Errorf("failed to unmarshal saved object %s: %w", objectID, err))
+			return err
+		}
+
+		objectType, _ := object["type"].(string)
+		if objectType == "" {
+			err := fmt.Errorf("saved object %s does not define its type", objectID)
+			r.SetError(ctx, resource, err)
+			return err
+		}
+
+		// The resource key is the source of truth for the saved object ID
+		object["id"] = objectID
+
+		desiredObjects[fmt.Sprintf("%s/%s", objectType, objectID)] = true
+		objects = append(objects, object)
+	}
+
+	// Step 3: Delete saved objects that are no longer desired, or all of them when the space changed
+	previousSpace := resource.Status.Space
+	if previousSpace == "" {
+		previousSpace = space
+	}
+	for _, objectKey := range resource.Status.AppliedResources {
+		if previousSpace == space && desiredObjects[objectKey] {
+			continue
+		}
+		logger.Info(fmt.Sprintf("Saved object %s is no longer desired in space %s, deleting from Kibana", objectKey, previousSpace))
+		if err := r.deleteSavedObject(ctx, kibanaConnection, previousSpace, objectKey); err != nil {
+			logger.Error(err, fmt.Sprintf("Failed to delete saved object %s", objectKey))
+			r.SetError(ctx, resource, fmt.Errorf("failed to delete saved object %s: %w", objectKey, err))
+			return err
+		}
+		logger.Info(fmt.Sprintf("Saved object %s deleted successfully", objectKey))
+		globals.RecordPruned(r.Recorder, resource, objectKey)
+	}
+
+	// Step 4: Import all desired saved objects in a single request
+	if len(objects) > 0 {
+		if err := r.importSavedObjects(ctx, kibanaConnection, space, overwrite, objects); err != nil {
+			logger.Error(err, "Failed to import saved objects")
+			r.SetError(ctx, resource, fmt.Errorf("failed to import saved objects: %w", err))
+			return err
+		}
+		logger.Info(fmt.Sprintf("%d saved objects imported successfully into space %s", len(objects), space))
+	}
+
+	newAppliedObjects := make([]string, 0, len(desiredObjects))
+	for objectKey := range desiredObjects {
+		newAppliedObjects = append(newAppliedObjects, objectKey)
+	}
+
+	// Step 5: Update the Status with the new list of applied saved objects
+	resource.Status.Space = space
+	targetKibana := fmt.Sprintf("%s/%s", kibanaSelector.Namespace, kibanaSelector.Name)
+	r.SetReady(ctx, resource, targetKibana, newAppliedObjects)
+
+	logger.Info(fmt.Sprintf("KibanaSavedObjects %s/%s synced successfully", resource.Namespace, resource.Name))
+
+	return nil
+}
+
+// importSavedObjects imports saved objects into a Kibana space using the saved objects import API
+func (r *KibanaSavedObjectsReconciler) importSavedObjects(ctx context.Context, kibanaConnection *pools.KibanaConnection, space string, overwrite bool, objects []map[string]interface{}) error {
+	logger := log.FromContext(ctx)
+
+	// The import API expects an NDJSON file with one saved object per line
+	var ndjson bytes.Buffer
+	for _, object := range objects {
+		objectJSON, err := json.Marshal(object)
+		if err != nil {
+			return fmt.Errorf("failed to marshal saved object: %w", err)
+		}
+		ndjson.Write(objectJSON)
+		ndjson.WriteByte('\n')
+	}
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("file", "export.ndjson")
+	if err != nil {
+		return fmt.Errorf("failed to create multipart body: %w", err)
+	}
+	if _, err := part.Write(ndjson.Bytes()); err != nil {
+		return fmt.Errorf("failed to write multipart body: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("failed to close multipart body: %w", err)
+	}
+
+	logger.Info(fmt.Sprintf("Importing %d saved objects into Kibana space %s (overwrite: %t)", len(objects), space, overwrite))
+
+	// POST /s/{space}/api/saved_objects/_import?overwrite=true
+	path := "/api/saved_objects/_import"
+	if overwrite {
+		path += "?overwrite=true"
+	}
+	res, err := globals.PerformKibanaRequest(ctx, kibanaConnection, http.MethodPost, space, path, &body, writer.FormDataContentType())
+	if err != nil {
+		return fmt.Errorf("failed to import saved objects: %w", err)
+	}
+	defer res.Body.Close()
+
+	bodyBytes, err := io.ReadAll(res.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if res.StatusCode >= 400 {
+		return fmt.Errorf("kibana API error: %s - %s", res.Status, string(bodyBytes))
+	}
+
+	var importResponse struct {
+		Success bool `json:"success"`
+		Errors  []struct {
+			ID    string `json:"id"`
+			Type  string `json:"type"`
+			Error struct {
+				Type string `json:"type"`
+			} `json:"error"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(bodyBytes, &importResponse); err != nil {
+		return fmt.Errorf("failed to parse import response: %w", err)
+	}
+
+	// Without overwrite, conflicts mean the object already exists and is intentionally left untouched
+	failures := make([]string, 0, len(importResponse.Errors))
+	for _, importError := range importResponse.Errors {
+		if !overwrite && importError.Error.Type == "conflict" {
+			logger.Info(fmt.Sprintf("Saved object %s/%s already exists, skipping (overwrite disabled)", importError.Type, importError.ID))
+			continue
+		}
+		failures = append(failures, fmt.Sprintf("%s/%s: %s", importError.Type, importError.ID, importError.Error.Type))
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("saved objects import failed for %s", strings.Join(failures, ", "))
+	}
+
+	return nil
+}
+
+// deleteSavedObject deletes a saved object from a Kibana space. objectKey has the format "type/id"
+func (r *KibanaSavedObjectsReconciler) deleteSavedObject(ctx context.Context, kibanaConnection *pools.KibanaConnection, space, objectKey string) error {
+	logger := log.FromContext(ctx)
+
+	objectType, objectID, found := strings.Cut(objectKey, "/")
+	if !found {
+		return fmt.Errorf("invalid saved object key %s, expected type/id", objectKey)
+	}
+
+	logger.Info(fmt.Sprintf("Deleting saved object %s from Kibana space %s", objectKey, space))
+
+	// DELETE /s/{space}/api/saved_objects/{type}/{id}?force=true
+	res, err := globals.PerformKibanaRequest(ctx, kibanaConnection, http.MethodDelete, space,
+		fmt.Sprintf("/api/saved_objects/%s/%s?force=true", objectType, objectID), nil, "")
+	if err != nil {
+		return fmt.Errorf("failed to delete saved object: %w", err)
+	}
+	defer res.Body.Close()
+
+	// If the saved object doesn't exist (404), consider it already deleted
+	if res.StatusCode == http.StatusNotFound {
+		logger.Info(fmt.Sprintf("Saved object %s not found in Kibana (already deleted)", objectKey))
+		return nil
+	}
+
+	if res.StatusCode >= 400 {
+		bodyBytes, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("kibana API error: %s - %s", res.Status, string(bodyBytes))
+	}
+
+	return nil
+}
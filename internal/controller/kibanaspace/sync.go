@@ -0,0 +1,222 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kibanaspace
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/globals"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/pools"
+)
+
+// defaultSpace is the built-in Kibana space, which can be updated but never deleted
+const defaultSpace = "default"
+
+// Sync executes the sync of the spaces into Kibana
+func (r *KibanaSpaceReconciler) Sync(ctx context.Context, eventType watch.EventType, resource *v1alpha1.KibanaSpace) (err error) {
+
+	logger := log.FromContext(ctx)
+
+	// The Kibana instance is resolved without changing the spec, in the namespace of the resource when the selector
+	// has none
+	kibanaSelector := resource.Spec.KibanaSelector.DeepCopy()
+	if kibanaSelector.Namespace == "" {
+		kibanaSelector.Namespace = resource.Namespace
+	}
+
+	// Build the Kibana key for the pools
+	kibanaKey := fmt.Sprintf("%s_%s", kibanaSelector.Namespace, kibanaSelector.Name)
+
+	if eventType == watch.Deleted {
+		logger.Info(fmt.Sprintf("Deleting KibanaSpace %s/%s", resource.Namespace, resource.Name))
+
+		// Get Kibana connection to delete the spaces
+		kibanaConnection, err := globals.GetOrCreateKibanaConnection(ctx, kibanaKey, kibanaSelector, resource.Namespace, r.KibanaConnectionsPool)
+		if err != nil {
+			logger.Error(err, "Failed to get Kibana connection for deletion")
+			return err
+		}
+
+		// Delete each space created by this resource
+		for _, spaceID := range resource.Status.AppliedResources {
+			logger.Info(fmt.Sprintf("Deleting space %s from Kibana", spaceID))
+			if err := r.deleteSpace(ctx, kibanaConnection, spaceID); err != nil {
+				logger.Error(err, fmt.Sprintf("Failed to delete space %s", spaceID))
+				return err
+			}
+			logger.Info(fmt.Sprintf("Space %s deleted successfully", spaceID))
+		}
+
+		return nil
+	}
+
+	logger.Info(fmt.Sprintf("Syncing KibanaSpace %s/%s", resource.Namespace, resource.Name))
+
+	// Set status to Syncing at the beginning
+	r.SetSyncing(ctx, resource)
+
+	// Step 1: Get or create Kibana connection
+	kibanaConnection, err := globals.GetOrCreateKibanaConnection(ctx, kibanaKey, kibanaSelector, resource.Namespace, r.KibanaConnectionsPool)
+	if err != nil {
+		logger.Error(err, "Failed to get or create Kibana connection")
+		r.SetError(ctx, resource, fmt.Errorf("%w to Kibana: %w", globals.ErrConnectionFailed, err))
+		return err
+	}
+
+	logger.Info(fmt.Sprintf("Kibana connection established for %s (version: %s)", kibanaKey, kibanaConnection.Version))
+
+	// Step 2: Build the list of desired spaces from Spec
+	desiredSpaces := make(map[string]bool)
+	for spaceID := range resource.Spec.Resources {
+		desiredSpaces[spaceID] = true
+	}
+
+	// Step 3: Delete spaces that are no longer desired
+	for _, spaceID := range resource.Status.AppliedResources {
+		if !desiredSpaces[spaceID] {
+			logger.Info(fmt.Sprintf("Space %s is no longer desired, deleting from Kibana", spaceID))
+			if err := r.deleteSpace(ctx, kibanaConnection, spaceID); err != nil {
+				logger.Error(err, fmt.Sprintf("Failed to delete space %s", spaceID))
+				r.SetError(ctx, resource, fmt.Errorf("failed to delete space %s: %w", spaceID, err))
+				return err
+			}
+			logger.Info(fmt.Sprintf("Space %s deleted successfully", spaceID))
+			globals.RecordPruned(r.Recorder, resource, spaceID)
+		}
+	}
+
+	// Step 4: Create or update all desired spaces
+	newAppliedSpaces := make([]string, 0, len(resource.Spec.Resources))
+	for spaceID, spaceResource := range resource.Spec.Resources {
+		var space map[string]interface{}
+		if err := json.Unmarshal(spaceResource.Raw, &space); err != nil {
+			logger.Error(err, fmt.Sprintf("Failed to unmarshal space %s", spaceID))
+			r.SetError(ctx, resource, fmt.Errorf("failed to unmarshal space %s: %w", spaceID, err))
+			return err
+		}
+
+		// The resource key is the source of truth for the space ID
+		space["id"] = spaceID
+		if name, _ := space["name"].(string); name == "" {
+			space["name"] = spaceID
+		}
+
+		logger.Info(fmt.Sprintf("Applying space %s to Kibana", spaceID))
+		if err := r.applySpace(ctx, kibanaConnection, spaceID, space); err != nil {
+			logger.Error(err, fmt.Sprintf("Failed to apply space %s", spaceID))
+			r.SetError(ctx, resource, fmt.Errorf("failed to apply space %s: %w", spaceID, err))
+			return err
+		}
+
+		newAppliedSpaces = append(newAppliedSpaces, spaceID)
+		logger.Info(fmt.Sprintf("Space %s applied successfully", spaceID))
+	}
+
+	// Step 5: Update the Status with the new list of applied spaces
+	targetKibana := fmt.Sprintf("%s/%s", kibanaSelector.Namespace, kibanaSelector.Name)
+	r.SetReady(ctx, resource, targetKibana, newAppliedSpaces)
+
+	logger.Info(fmt.Sprintf("KibanaSpace %s/%s synced successfully", resource.Namespace, resource.Name))
+
+	return nil
+}
+
+// applySpace updates a Kibana space, creating it when it doesn't exist yet
+func (r *KibanaSpaceReconciler) applySpace(ctx context.Context, kibanaConnection *pools.KibanaConnection, spaceID string, space map[string]interface{}) error {
+	logger := log.FromContext(ctx)
+
+	spaceJSON, err := json.Marshal(space)
+	if err != nil {
+		return fmt.Errorf("failed to marshal space: %w", err)
+	}
+
+	// PUT /api/spaces/space/{id}
+	res, err := globals.PerformKibanaRequest(ctx, kibanaConnection, http.MethodPut, "",
+		fmt.Sprintf("/api/spaces/space/%s", spaceID), bytes.NewReader(spaceJSON), "application/json")
+	if err != nil {
+		return fmt.Errorf("failed to update space: %w", err)
+	}
+	defer res.Body.Close()
+
+	// If the space doesn't exist (404), create it
+	if res.StatusCode == http.StatusNotFound {
+		logger.Info(fmt.Sprintf("Space %s not found in Kibana, creating it", spaceID))
+
+		// POST /api/spaces/space
+		createRes, err := globals.PerformKibanaRequest(ctx, kibanaConnection, http.MethodPost, "",
+			"/api/spaces/space", bytes.NewReader(spaceJSON), "application/json")
+		if err != nil {
+			return fmt.Errorf("failed to create space: %w", err)
+		}
+		defer createRes.Body.Close()
+
+		if createRes.StatusCode >= 400 {
+			bodyBytes, _ := io.ReadAll(createRes.Body)
+			return fmt.Errorf("kibana API error: %s - %s", createRes.Status, string(bodyBytes))
+		}
+
+		return nil
+	}
+
+	if res.StatusCode >= 400 {
+		bodyBytes, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("kibana API error: %s - %s", res.Status, string(bodyBytes))
+	}
+
+	return nil
+}
+
+// deleteSpace deletes a Kibana space and all the saved objects it contains
+func (r *KibanaSpaceReconciler) deleteSpace(ctx context.Context, kibanaConnection *pools.KibanaConnection, spaceID string) error {
+	logger := log.FromContext(ctx)
+
+	// The default space cannot be deleted, so it is only released from management
+	if spaceID == defaultSpace {
+		logger.Info("Space default cannot be deleted from Kibana, skipping")
+		return nil
+	}
+
+	// DELETE /api/spaces/space/{id}
+	res, err := globals.PerformKibanaRequest(ctx, kibanaConnection, http.MethodDelete, "",
+		fmt.Sprintf("/api/spaces/space/%s", spaceID), nil, "")
+	if err != nil {
+		return fmt.Errorf("failed to delete space: %w", err)
+	}
+	defer res.Body.Close()
+
+	// If the space doesn't exist (404), consider it already deleted
+	if res.StatusCode == http.StatusNotFound {
+		logger.Info(fmt.Sprintf("Space %s not found in Kibana (already deleted)", spaceID))
+		return nil
+	}
+
+	if res.StatusCode >= 400 {
+		bodyBytes, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("kibana API error: %s - %s", res.Status, string(bodyBytes))
+	}
+
+	return nil
+}
@@ -0,0 +1,391 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinelearningjob
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/globals"
+)
+
+const (
+	// Desired states of a job
+	stateClosed  = "closed"
+	stateOpened  = "opened"
+	stateStarted = "started"
+
+	// Datafeed states reported by the datafeed stats API
+	datafeedStateStopped = "stopped"
+)
+
+// updatableJobFields are the job fields accepted by the update job API. Any other change requires recreating the job
+var updatableJobFields = map[string]bool{
+	"allow_lazy_open":                           true,
+	"analysis_limits":                           true,
+	"background_persist_interval":               true,
+	"custom_settings":                           true,
+	"daily_model_snapshot_retention_after_days": true,
+	"description":                               true,
+	"detectors":                                 true,
+	"groups":                                    true,
+	"model_plot_config":                         true,
+	"model_prune_window":                        true,
+	"model_snapshot_retention_days":             true,
+	"per_partition_categorization":              true,
+	"renormalization_window_days":               true,
+	"results_retention_days":                    true,
+}
+
+// datafeedID returns the ID of the datafeed managed for a job
+func datafeedID(jobID string) string {
+	return fmt.Sprintf("datafeed-%s", jobID)
+}
+
+// applyJob creates or updates a job and its datafeed, and moves them to the desired state
+func (r *MachineLearningJobReconciler) applyJob(ctx context.Context, esClient *elasticsearch.Client, jobID string, definition v1alpha1.MachineLearningJobDefinition) error {
+	state := definition.State
+	if state == "" {
+		state = stateStarted
+	}
+
+	if err := r.putJob(ctx, esClient, jobID, definition.Job.Raw); err != nil {
+		return err
+	}
+
+	if definition.Datafeed != nil {
+		if err := r.putDatafeed(ctx, esClient, jobID, definition.Datafeed.Raw); err != nil {
+			return err
+		}
+	} else {
+		// Remove the datafeed if it was dropped from the definition
+		if err := r.deleteDatafeed(ctx, esClient, jobID); err != nil {
+			return err
+		}
+	}
+
+	return r.setJobState(ctx, esClient, jobID, state, definition.Datafeed != nil)
+}
+
+// putJob creates the job, or updates the fields that changed when it already exists
+func (r *MachineLearningJobReconciler) putJob(ctx context.Context, esClient *elasticsearch.Client, jobID string, body []byte) error {
+	logger := log.FromContext(ctx)
+
+	var desired map[string]interface{}
+	if err := json.Unmarshal(body, &desired); err != nil {
+		return fmt.Errorf("failed to unmarshal job: %w", err)
+	}
+
+	res, err := esClient.ML.GetJobs(
+		esClient.ML.GetJobs.WithJobID(jobID),
+		esClient.ML.GetJobs.WithContext(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to get job: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		logger.Info(fmt.Sprintf("Creating machine learning job %s", jobID))
+		return r.performJobRequest(esClient.ML.PutJob(jobID, bytes.NewReader(body), esClient.ML.PutJob.WithContext(ctx)))
+	}
+
+	var response struct {
+		Jobs []map[string]interface{} `json:"jobs"`
+	}
+	if err := decodeResponse(res, &response); err != nil {
+		return err
+	}
+	if len(response.Jobs) == 0 {
+		return fmt.Errorf("job %s not found in the get jobs response", jobID)
+	}
+	live := response.Jobs[0]
+
+	// Split the changed fields between the ones that can be updated and the immutable ones
+	update := make(map[string]interface{})
+	immutable := make([]string, 0)
+	for field, value := range desired {
+		if field == "job_id" || globals.IsSubset(value, live[field]) {
+			continue
+		}
+		if updatableJobFields[field] {
+			update[field] = value
+			continue
+		}
+		immutable = append(immutable, field)
+	}
+
+	if len(immutable) > 0 {
+		sort.Strings(immutable)
+		return fmt.Errorf("fields %s of job %s can not be updated, remove the job from the resource and add it again to recreate it",
+			strings.Join(immutable, ", "), jobID)
+	}
+
+	if len(update) == 0 {
+		logger.Info(fmt.Sprintf("Machine learning job %s is up to date", jobID))
+		return nil
+	}
+
+	updateJSON, err := json.Marshal(update)
+	if err != nil {
+		return fmt.Errorf("failed to marshal job update: %w", err)
+	}
+
+	logger.Info(fmt.Sprintf("Updating machine learning job %s", jobID))
+	return r.performJobRequest(esClient.ML.UpdateJob(jobID, bytes.NewReader(updateJSON), esClient.ML.UpdateJob.WithContext(ctx)))
+}
+
+// putDatafeed creates the datafeed of a job, or updates it when it differs from the desired configuration
+func (r *MachineLearningJobReconciler) putDatafeed(ctx context.Context, esClient *elasticsearch.Client, jobID string, body []byte) error {
+	logger := log.FromContext(ctx)
+
+	var desired map[string]interface{}
+	if err := json.Unmarshal(body, &desired); err != nil {
+		return fmt.Errorf("failed to unmarshal datafeed: %w", err)
+	}
+	desired["job_id"] = jobID
+
+	res, err := esClient.ML.GetDatafeeds(
+		esClient.ML.GetDatafeeds.WithDatafeedID(datafeedID(jobID)),
+		esClient.ML.GetDatafeeds.WithContext(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to get datafeed: %w", err)
+	}
+	defer res.Body.Close()
+
+	exists := res.StatusCode != http.StatusNotFound
+	if exists {
+		var response struct {
+			Datafeeds []map[string]interface{} `json:"datafeeds"`
+		}
+		if err := decodeResponse(res, &response); err != nil {
+			return err
+		}
+		if len(response.Datafeeds) > 0 && globals.IsSubset(desired, response.Datafeeds[0]) {
+			logger.Info(fmt.Sprintf("Datafeed of machine learning job %s is up to date", jobID))
+			return nil
+		}
+	}
+
+	requestJSON, err := json.Marshal(desired)
+	if err != nil {
+		return fmt.Errorf("failed to marshal datafeed: %w", err)
+	}
+
+	if !exists {
+		logger.Info(fmt.Sprintf("Creating datafeed of machine learning job %s", jobID))
+		return r.performJobRequest(esClient.ML.PutDatafeed(bytes.NewReader(requestJSON), datafeedID(jobID), esClient.ML.PutDatafeed.WithContext(ctx)))
+	}
+
+	// A running datafeed is restarted by Elasticsearch with the updated configuration
+	logger.Info(fmt.Sprintf("Updating datafeed of machine learning job %s", jobID))
+	return r.performJobRequest(esClient.ML.UpdateDatafeed(bytes.NewReader(requestJSON), datafeedID(jobID), esClient.ML.UpdateDatafeed.WithContext(ctx)))
+}
+
+// setJobState opens or closes the job and starts or stops its datafeed to reach the desired state
+func (r *MachineLearningJobReconciler) setJobState(ctx context.Context, esClient *elasticsearch.Client, jobID, state string, hasDatafeed bool) error {
+	logger := log.FromContext(ctx)
+
+	jobState, err := r.getJobState(ctx, esClient, jobID)
+	if err != nil {
+		return err
+	}
+
+	datafeedState := datafeedStateStopped
+	if hasDatafeed {
+		datafeedState, err = r.getDatafeedState(ctx, esClient, jobID)
+		if err != nil {
+			return err
+		}
+	}
+
+	// The datafeed must be stopped before closing the job
+	if state != stateStarted && datafeedState != datafeedStateStopped {
+		logger.Info(fmt.Sprintf("Stopping datafeed of machine learning job %s", jobID))
+		if err := r.performJobRequest(esClient.ML.StopDatafeed(datafeedID(jobID), esClient.ML.StopDatafeed.WithContext(ctx))); err != nil {
+			return err
+		}
+	}
+
+	if state == stateClosed && jobState != stateClosed {
+		logger.Info(fmt.Sprintf("Closing machine learning job %s", jobID))
+		return r.performJobRequest(esClient.ML.CloseJob(jobID, esClient.ML.CloseJob.WithContext(ctx)))
+	}
+
+	// The job must be opened before starting its datafeed
+	if state != stateClosed && jobState == stateClosed {
+		logger.Info(fmt.Sprintf("Opening machine learning job %s", jobID))
+		if err := r.performJobRequest(esClient.ML.OpenJob(jobID, esClient.ML.OpenJob.WithContext(ctx))); err != nil {
+			return err
+		}
+	}
+
+	if state == stateStarted && datafeedState == datafeedStateStopped {
+		logger.Info(fmt.Sprintf("Starting datafeed of machine learning job %s", jobID))
+		return r.performJobRequest(esClient.ML.StartDatafeed(datafeedID(jobID), esClient.ML.StartDatafeed.WithContext(ctx)))
+	}
+
+	return nil
+}
+
+// getJobState returns the state of a job (closed, opening, opened, closing, failed)
+func (r *MachineLearningJobReconciler) getJobState(ctx context.Context, esClient *elasticsearch.Client, jobID string) (string, error) {
+	res, err := esClient.ML.GetJobStats(
+		esClient.ML.GetJobStats.WithJobID(jobID),
+		esClient.ML.GetJobStats.WithContext(ctx),
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to get job stats: %w", err)
+	}
+	defer res.Body.Close()
+
+	var response struct {
+		Jobs []struct {
+			State string `json:"state"`
+		} `json:"jobs"`
+	}
+	if err := decodeResponse(res, &response); err != nil {
+		return "", err
+	}
+	if len(response.Jobs) == 0 {
+		return "", fmt.Errorf("job %s not found in the job stats response", jobID)
+	}
+
+	return response.Jobs[0].State, nil
+}
+
+// getDatafeedState returns the state of the datafeed of a job (stopped, starting, started, stopping)
+func (r *MachineLearningJobReconciler) getDatafeedState(ctx context.Context, esClient *elasticsearch.Client, jobID string) (string, error) {
+	res, err := esClient.ML.GetDatafeedStats(
+		esClient.ML.GetDatafeedStats.WithDatafeedID(datafeedID(jobID)),
+		esClient.ML.GetDatafeedStats.WithContext(ctx),
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to get datafeed stats: %w", err)
+	}
+	defer res.Body.Close()
+
+	var response struct {
+		Datafeeds []struct {
+			State string `json:"state"`
+		} `json:"datafeeds"`
+	}
+	if err := decodeResponse(res, &response); err != nil {
+		return "", err
+	}
+	if len(response.Datafeeds) == 0 {
+		return "", fmt.Errorf("datafeed %s not found in the datafeed stats response", datafeedID(jobID))
+	}
+
+	return response.Datafeeds[0].State, nil
+}
+
+// deleteJob deletes the datafeed and the job. Both are force deleted, so running datafeeds and opened jobs are removed
+func (r *MachineLearningJobReconciler) deleteJob(ctx context.Context, esClient *elasticsearch.Client, jobID string) error {
+	logger := log.FromContext(ctx)
+
+	if err := r.deleteDatafeed(ctx, esClient, jobID); err != nil {
+		return err
+	}
+
+	res, err := esClient.ML.DeleteJob(
+		jobID,
+		esClient.ML.DeleteJob.WithForce(true),
+		esClient.ML.DeleteJob.WithContext(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete job: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		// If the job doesn't exist (404), consider it already deleted
+		if res.StatusCode == http.StatusNotFound {
+			logger.Info(fmt.Sprintf("Machine learning job %s not found in Elasticsearch (already deleted)", jobID))
+			return nil
+		}
+		bodyBytes, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("elasticsearch API error: %s - %s", res.Status(), string(bodyBytes))
+	}
+
+	return nil
+}
+
+// deleteDatafeed deletes the datafeed of a job, if it exists
+func (r *MachineLearningJobReconciler) deleteDatafeed(ctx context.Context, esClient *elasticsearch.Client, jobID string) error {
+	res, err := esClient.ML.DeleteDatafeed(
+		datafeedID(jobID),
+		esClient.ML.DeleteDatafeed.WithForce(true),
+		esClient.ML.DeleteDatafeed.WithContext(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete datafeed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() && res.StatusCode != http.StatusNotFound {
+		bodyBytes, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("elasticsearch API error: %s - %s", res.Status(), string(bodyBytes))
+	}
+
+	return nil
+}
+
+// performJobRequest checks the response of a machine learning API request
+func (r *MachineLearningJobReconciler) performJobRequest(res *esapi.Response, err error) error {
+	if err != nil {
+		return fmt.Errorf("failed to perform machine learning request: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		bodyBytes, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("elasticsearch API error: %s - %s", res.Status(), string(bodyBytes))
+	}
+
+	return nil
+}
+
+// decodeResponse checks the response status and decodes its body
+func decodeResponse(res *esapi.Response, target interface{}) error {
+	bodyBytes, err := io.ReadAll(res.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if res.IsError() {
+		return fmt.Errorf("elasticsearch API error: %s - %s", res.Status(), string(bodyBytes))
+	}
+
+	if err := json.Unmarshal(bodyBytes, target); err != nil {
+		return fmt.Errorf("failed to parse response body: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinelearningjob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/globals"
+)
+
+// Sync executes the synchronization of machine learning jobs with Elasticsearch
+func (r *MachineLearningJobReconciler) Sync(ctx context.Context, eventType watch.EventType, resource *v1alpha1.MachineLearningJob) (err error) {
+
+	logger := log.FromContext(ctx)
+
+	// The target cluster is resolved without changing the spec: the NamespaceDefaultCluster is used when the resource
+	// doesn't select one, and the namespace of the resource when the selector has none
+	resourceSelector, err := globals.ResolveResourceSelector(ctx, &resource.Spec.ResourceSelector, resource.Namespace)
+	if err != nil {
+		logger.Error(err, "Failed to resolve the target cluster")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	// Check the namespace of the resource is allowed to target the cluster
+	if err := globals.AuthorizeClusterAccess(ctx, resourceSelector, resource.Namespace, &resource.Status.Conditions); err != nil {
+		logger.Error(err, "Access to the target cluster denied")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	if err := globals.ValidateTLSVerification(resourceSelector, &resource.Status.Conditions); err != nil {
+		logger.Error(err, "TLS verification of the target cluster not configured")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	// Build the cluster key identifying the target cluster in the logs
+	clusterKey := fmt.Sprintf("%s_%s", resourceSelector.Namespace, resourceSelector.Name)
+
+	if eventType == watch.Deleted {
+		logger.Info(fmt.Sprintf("Deleting MachineLearningJob %s/%s", resource.Namespace, resource.Name))
+
+		// Get Elasticsearch connection to delete the jobs
+		esConnection, err := globals.GetOrCreateElasticsearchConnection(ctx, resourceSelector, resource.Namespace, r.ElasticsearchConnectionsPool)
+		if err != nil {
+			logger.Error(err, "Failed to get Elasticsearch connection for deletion")
+			return err
+		}
+
+		// Writes are serialized with the other resources targeting the same cluster
+		unlock, err := globals.LockCluster(ctx, esConnection.Endpoint)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+
+		// Delete each job and its datafeed from Elasticsearch
+		for _, jobID := range resource.Status.AppliedResources {
+			logger.Info(fmt.Sprintf("Deleting machine learning job %s from Elasticsearch", jobID))
+			if err := r.deleteJob(ctx, esConnection.Client, jobID); err != nil {
+				logger.Error(err, fmt.Sprintf("Failed to delete machine learning job %s", jobID))
+				return err
+			}
+			logger.Info(fmt.Sprintf("Machine learning job %s deleted successfully", jobID))
+		}
+
+		return nil
+	}
+
+	logger.Info(fmt.Sprintf("Syncing MachineLearningJob %s/%s", resource.Namespace, resource.Name))
+
+	// Set status to Syncing at the beginning
+	r.SetSyncing(ctx, resource)
+
+	// Step 1: Get or create Elasticsearch connection
+	esConnection, err := globals.GetOrCreateElasticsearchConnection(ctx, resourceSelector, resource.Namespace, r.ElasticsearchConnectionsPool)
+	globals.UpdateClusterReachableCondition(&resource.Status.Conditions, err)
+	if err != nil {
+		logger.Error(err, "Failed to get or create Elasticsearch connection")
+		if errors.Is(err, globals.ErrClusterNotReady) {
+			r.SetWaitingForCluster(ctx, resource, err)
+			return err
+		}
+		r.SetError(ctx, resource, fmt.Errorf("%w to Elasticsearch: %w", globals.ErrConnectionFailed, err))
+		return err
+	}
+
+	// Writes are serialized with the other resources targeting the same cluster
+	unlock, err := globals.LockCluster(ctx, esConnection.Endpoint)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	logger.Info(fmt.Sprintf("Elasticsearch connection established for cluster %s (type: %s, version: %s)", clusterKey, esConnection.ClusterType, esConnection.Version))
+
+	// Validate cluster type - machine learning is only available in Elasticsearch
+	if esConnection.ClusterType == "opensearch" {
+		err := fmt.Errorf("machine learning jobs are not available in OpenSearch. Use the OpenSearchAnomalyDetector CRD instead")
+		logger.Error(err, "Incompatible cluster type for MachineLearningJob")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	// Step 2: Delete jobs that are no longer desired
+	for _, jobID := range resource.Status.AppliedResources {
+		if _, desired := resource.Spec.Resources[jobID]; !desired {
+			logger.Info(fmt.Sprintf("Machine learning job %s is no longer desired, deleting from Elasticsearch", jobID))
+			if err := r.deleteJob(ctx, esConnection.Client, jobID); err != nil {
+				logger.Error(err, fmt.Sprintf("Failed to delete machine learning job %s", jobID))
+				r.SetError(ctx, resource, fmt.Errorf("failed to delete machine learning job %s: %w", jobID, err))
+				return err
+			}
+			logger.Info(fmt.Sprintf("Machine learning job %s deleted successfully", jobID))
+			globals.RecordPruned(r.Recorder, resource, jobID)
+		}
+	}
+
+	// Step 3: Apply all desired jobs, with their datafeeds and state
+	newAppliedJobs := make([]string, 0, len(resource.Spec.Resources))
+	for jobID, jobDefinition := range resource.Spec.Resources {
+		logger.Info(fmt.Sprintf("Applying machine learning job %s", jobID))
+		if err := r.applyJob(ctx, esConnection.Client, jobID, jobDefinition); err != nil {
+			logger.Error(err, fmt.Sprintf("Failed to apply machine learning job %s", jobID))
+			r.SetError(ctx, resource, fmt.Errorf("failed to apply machine learning job %s: %w", jobID, err))
+			return err
+		}
+		newAppliedJobs = append(newAppliedJobs, jobID)
+		logger.Info(fmt.Sprintf("Machine learning job %s applied successfully", jobID))
+	}
+
+	// Step 4: Update the Status with the new list of applied jobs
+	targetCluster := fmt.Sprintf("%s/%s", resourceSelector.Namespace, resourceSelector.Name)
+	r.SetReady(ctx, resource, targetCluster, newAppliedJobs)
+
+	logger.Info(fmt.Sprintf("MachineLearningJob %s/%s synced successfully", resource.Namespace, resource.Name))
+
+	return nil
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package multicluster
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/controller"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/globals"
+)
+
+// SupportedKinds are the kinds whose resources can select their clusters with resourceSelector.matchLabels
+var SupportedKinds = []string{
+	controller.IndexTemplateResourceType,
+	controller.IndexLifecyclePolicyResourceType,
+	controller.SnapshotLifecyclePolicyResourceType,
+	controller.SnapshotRepositoryResourceType,
+	controller.ClusterSettingsResourceType,
+	controller.ElasticConfigBundleResourceType,
+}
+
+// MultiClusterReconciler reconciles the resources of a kind selecting their clusters with
+// resourceSelector.matchLabels: a resource of the same kind is created for every selected ECK cluster, owned by the
+// selecting one and synced by the controller of the kind, and their phases are reported in the status of the
+// selecting resource
+type MultiClusterReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	// Kind is the kind of the resources, one of the SupportedKinds
+	Kind string
+}
+
+// Reconcile creates the resources of the clusters selected by the labels of a resource, and reports their status
+func (r *MultiClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
+	logger := logf.FromContext(ctx)
+
+	// 1. Get the content of the resource
+	resource := r.newObject()
+	err = r.Get(ctx, req.NamespacedName, resource)
+	if err != nil {
+		if err = client.IgnoreNotFound(err); err == nil {
+			logger.Info(fmt.Sprintf(controller.ResourceNotFoundError, r.Kind, req.NamespacedName))
+			return result, err
+		}
+		logger.Info(fmt.Sprintf(controller.ResourceSyncTimeRetrievalError, r.Kind, req.NamespacedName, err.Error()))
+		return result, err
+	}
+
+	// 2. Resources targeting a single cluster are synced by the controller of the kind, and the resources of the
+	// clusters of deleted ones are garbage collected, cleaning up their clusters
+	matchLabels := MatchLabels(resource)
+	if len(matchLabels) == 0 || !resource.GetDeletionTimestamp().IsZero() {
+		return result, nil
+	}
+
+	// Suspended resources suspend the resources of their clusters too, which leave their clusters untouched until
+	// the resource is resumed
+	if suspend, _, _ := unstructured.NestedBool(resource.Object, "spec", "suspend"); suspend {
+		logger.Info(fmt.Sprintf(controller.ResourceSuspendedMessage, r.Kind, req.NamespacedName))
+		if err = r.SuspendChildren(ctx, resource); err != nil {
+			logger.Info(fmt.Sprintf(controller.SyncTargetError, r.Kind, req.NamespacedName, err.Error()))
+		}
+		return result, err
+	}
+
+	// 3. Schedule periodical request, picking up the clusters labeled since the last one
+	syncInterval, _, _ := unstructured.NestedString(resource.Object, "spec", "syncInterval")
+	requeueTime, err := controller.RequeueInterval(syncInterval)
+	if err != nil {
+		logger.Info(fmt.Sprintf(controller.ResourceSyncTimeRetrievalError, r.Kind, req.NamespacedName, err.Error()))
+		return result, err
+	}
+	result = ctrl.Result{
+		RequeueAfter: requeueTime,
+	}
+
+	// 4. Sync the resources of the selected clusters
+	clusters, err := r.Sync(ctx, resource, matchLabels)
+	if err != nil {
+		// Failed syncs are requeued with the error backoff instead of the syncInterval
+		result = ctrl.Result{}
+		r.SetError(ctx, resource, clusters, err)
+		logger.Info(fmt.Sprintf(controller.SyncTargetError, r.Kind, req.NamespacedName, err.Error()))
+		return result, err
+	}
+
+	// 5. Report the status of every cluster
+	if err = r.SetClusters(ctx, resource, clusters); err != nil {
+		logger.Info(fmt.Sprintf(controller.ResourceConditionUpdateError, r.Kind, req.NamespacedName, err.Error()))
+		return ctrl.Result{}, err
+	}
+
+	return result, nil
+}
+
+// SetupWithManager sets up the controller with the Manager. The resources of the clusters are watched too, so their
+// phases are reported as soon as they change, and so are the ECK clusters, so new clusters matching the labels are
+// targeted as soon as they are created
+func (r *MultiClusterReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(r.groupVersionKind().GroupVersion().WithKind(r.Kind + "List"))
+
+	controllerBuilder := ctrl.NewControllerManagedBy(mgr).
+		For(r.newObject(), builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Owns(r.newObject())
+
+	controllerBuilder = globals.WatchECKClusters(controllerBuilder, mgr, list, func(object, cluster client.Object) bool {
+		resource := object.(*unstructured.Unstructured)
+		matchLabels := MatchLabels(resource)
+		return len(matchLabels) > 0 && clustersNamespace(resource) == cluster.GetNamespace() &&
+			labels.SelectorFromSet(matchLabels).Matches(labels.Set(cluster.GetLabels()))
+	})
+
+	return controllerBuilder.
+		Named(strings.ToLower(r.Kind) + "-multicluster").
+		WithOptions(globals.ControllerOptions()).
+		Complete(r)
+}
+
+// MatchLabels returns the resourceSelector.matchLabels of a resource, empty for the resources targeting a single
+// cluster
+func MatchLabels(resource *unstructured.Unstructured) map[string]string {
+	matchLabels, _, _ := unstructured.NestedStringMap(resource.Object, "spec", "resourceSelector", "matchLabels")
+	return matchLabels
+}
+
+// clustersNamespace returns the namespace of the clusters selected by a resource: the one of its resourceSelector,
+// or its own
+func clustersNamespace(resource *unstructured.Unstructured) string {
+	namespace, _, _ := unstructured.NestedString(resource.Object, "spec", "resourceSelector", "namespace")
+	if namespace == "" {
+		return resource.GetNamespace()
+	}
+	return namespace
+}
+
+// groupVersionKind returns the GroupVersionKind of the resources of the controller
+func (r *MultiClusterReconciler) groupVersionKind() schema.GroupVersionKind {
+	return v1alpha1.GroupVersion.WithKind(r.Kind)
+}
+
+// newObject returns an empty resource of the kind of the controller
+func (r *MultiClusterReconciler) newObject() *unstructured.Unstructured {
+	object := &unstructured.Unstructured{}
+	object.SetGroupVersionKind(r.groupVersionKind())
+	return object
+}
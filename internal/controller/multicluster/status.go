@@ -0,0 +1,137 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package multicluster
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/controller"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/globals"
+)
+
+// multiClusterStatus are the fields of the status written for the resources selecting their clusters by labels,
+// shared by all the SupportedKinds
+type multiClusterStatus struct {
+	Phase              string                         `json:"phase,omitempty"`
+	Message            string                         `json:"message,omitempty"`
+	Clusters           []v1alpha1.ClusterTargetStatus `json:"clusters,omitempty"`
+	ObservedGeneration int64                          `json:"observedGeneration,omitempty"`
+	Conditions         []metav1.Condition             `json:"conditions,omitempty"`
+}
+
+// SetClusters updates the status with the status of every selected cluster, summarized in the phase of the
+// resource: Ready once all of them are Ready, Error or Degraded when all or some of them failed, and Syncing otherwise
+func (r *MultiClusterReconciler) SetClusters(ctx context.Context, resource *unstructured.Unstructured, clusters []v1alpha1.ClusterTargetStatus) error {
+	status := r.statusOf(resource)
+	status.Clusters = clusters
+
+	var ready int
+	var failed []string
+	for _, cluster := range clusters {
+		switch cluster.Phase {
+		case controller.PhaseReady:
+			ready++
+		case controller.PhaseError, controller.PhaseDegraded:
+			failed = append(failed, cluster.Cluster)
+		}
+	}
+
+	switch {
+	case len(clusters) == 0:
+		status.Phase = controller.PhaseWaitingForCluster
+		status.Message = fmt.Sprintf("No ECK cluster of namespace %s matches resourceSelector.matchLabels", clustersNamespace(resource))
+		globals.SetReconcilingConditions(&status.Conditions, globals.ConditionReasonWaitingForCluster, status.Message)
+	case len(failed) == len(clusters):
+		status.Phase = controller.PhaseError
+		status.Message = fmt.Sprintf("Failed to sync to all the %d selected clusters", len(clusters))
+		globals.SetStalledConditions(&status.Conditions, globals.ConditionReasonSyncFailed, status.Message)
+	case len(failed) > 0:
+		status.Phase = controller.PhaseDegraded
+		status.Message = fmt.Sprintf("Failed to sync to %d of %d selected clusters: %s", len(failed), len(clusters), strings.Join(failed, ", "))
+		globals.SetStalledConditions(&status.Conditions, globals.ConditionReasonPartiallySynced, status.Message)
+	case ready == len(clusters):
+		status.Phase = controller.PhaseReady
+		status.Message = fmt.Sprintf("Successfully synced to %d clusters", len(clusters))
+		globals.SetReadyConditions(&status.Conditions, globals.ConditionReasonTargetSynced, status.Message)
+	default:
+		status.Phase = controller.PhaseSyncing
+		status.Message = fmt.Sprintf("Synchronizing with %d clusters, %d of them Ready", len(clusters), ready)
+		globals.SetReconcilingConditions(&status.Conditions, globals.ConditionReasonProgressing, status.Message)
+	}
+
+	return r.updateStatus(ctx, resource, status)
+}
+
+// SetError updates the status to Error phase with error message, keeping the status of the clusters synced before
+// the error
+func (r *MultiClusterReconciler) SetError(ctx context.Context, resource *unstructured.Unstructured, clusters []v1alpha1.ClusterTargetStatus, err error) {
+	logger := log.FromContext(ctx)
+	status := r.statusOf(resource)
+	if clusters != nil {
+		status.Clusters = clusters
+	}
+	status.Phase = controller.PhaseError
+	status.Message = err.Error()
+	globals.SetStalledConditions(&status.Conditions, globals.ConditionReasonSyncFailed, status.Message)
+	if updateErr := r.updateStatus(ctx, resource, status); updateErr != nil {
+		logger.Error(updateErr, "Failed to update status to Error")
+	}
+}
+
+// statusOf returns the fields of the status of a resource written by the controller
+func (r *MultiClusterReconciler) statusOf(resource *unstructured.Unstructured) *multiClusterStatus {
+	status := &multiClusterStatus{}
+	if content, ok, _ := unstructured.NestedMap(resource.Object, "status"); ok {
+		_ = runtime.DefaultUnstructuredConverter.FromUnstructured(content, status)
+	}
+	return status
+}
+
+// updateStatus writes the fields of the status of a resource written by the controller, keeping the other ones
+func (r *MultiClusterReconciler) updateStatus(ctx context.Context, resource *unstructured.Unstructured, status *multiClusterStatus) error {
+	status.ObservedGeneration = resource.GetGeneration()
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(status)
+	if err != nil {
+		return err
+	}
+
+	current, _, _ := unstructured.NestedMap(resource.Object, "status")
+	if current == nil {
+		current = make(map[string]interface{}, len(content))
+	}
+	for _, field := range []string{"phase", "message", "clusters", "observedGeneration", "conditions"} {
+		if value, ok := content[field]; ok {
+			current[field] = value
+		} else {
+			delete(current, field)
+		}
+	}
+
+	original := resource.DeepCopy()
+	if err := unstructured.SetNestedMap(resource.Object, current, "status"); err != nil {
+		return err
+	}
+	return globals.PatchStatus(ctx, r.Client, resource, original)
+}
@@ -0,0 +1,259 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package multicluster
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/controller"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/globals"
+)
+
+const (
+	// ParentUIDLabel is the label of the resources created for the selected clusters, holding the UID of the resource
+	// selecting them
+	ParentUIDLabel = "elastic-config-operator.freepik.com/parent-uid"
+
+	// childNameHashLength is the length of the hash ending the names of the resources of the selected clusters
+	childNameHashLength = 8
+)
+
+// Sync creates or updates the resource of every cluster selected by the labels of a resource, and deletes the ones
+// of the clusters no longer selected, whose finalizers clean up their clusters. It returns the status of every
+// selected cluster, sorted by namespace/name
+func (r *MultiClusterReconciler) Sync(ctx context.Context, resource *unstructured.Unstructured, matchLabels map[string]string) ([]v1alpha1.ClusterTargetStatus, error) {
+	logger := log.FromContext(ctx)
+
+	targets, err := globals.ResolveTargetClusters(ctx, nil, &v1alpha1.ClusterSelector{
+		LabelSelector: metav1.LabelSelector{MatchLabels: matchLabels},
+		Namespaces:    []string{clustersNamespace(resource)},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The resources of the clusters selected before, to delete the ones of the clusters no longer selected
+	children, err := r.listChildren(ctx, resource)
+	if err != nil {
+		return nil, err
+	}
+
+	// The resources of the clusters keep the names they were created with, so renaming them never deletes the objects
+	// they manage from their clusters
+	childNames := make(map[string]string, len(children))
+	for _, child := range children {
+		clusterName, _, _ := unstructured.NestedString(child.Object, "spec", "resourceSelector", "name")
+		clusterNamespace, _, _ := unstructured.NestedString(child.Object, "spec", "resourceSelector", "namespace")
+		childNames[fmt.Sprintf("%s/%s", clusterNamespace, clusterName)] = child.GetName()
+	}
+
+	clusters := make([]v1alpha1.ClusterTargetStatus, 0, len(targets))
+	selected := make(map[string]bool, len(targets))
+	for _, target := range targets {
+		cluster := fmt.Sprintf("%s/%s", target.Namespace, target.Name)
+		name, found := childNames[cluster]
+		if !found {
+			name = childName(resource, target)
+		}
+
+		child, err := r.newChild(resource, target, name)
+		if err != nil {
+			return clusters, err
+		}
+		selected[child.GetName()] = true
+
+		clusterStatus := v1alpha1.ClusterTargetStatus{
+			Cluster:  cluster,
+			Resource: child.GetName(),
+		}
+
+		// A cluster whose resource can't be written does not hold back the other clusters
+		current, err := r.applyChild(ctx, resource, child)
+		if err != nil {
+			clusterStatus.Phase = controller.PhaseError
+			clusterStatus.Message = err.Error()
+		} else {
+			clusterStatus.Phase, clusterStatus.Message = childPhase(current)
+		}
+		clusters = append(clusters, clusterStatus)
+	}
+
+	for _, child := range children {
+		if selected[child.GetName()] {
+			continue
+		}
+
+		logger.Info(fmt.Sprintf("Deleting %s %s/%s, as its cluster is no longer selected", r.Kind, child.GetNamespace(), child.GetName()))
+		if err := r.Delete(ctx, child); client.IgnoreNotFound(err) != nil {
+			return clusters, fmt.Errorf("failed to delete %s %s: %w", r.Kind, child.GetName(), err)
+		}
+	}
+
+	return clusters, nil
+}
+
+// SuspendChildren suspends the resources of the selected clusters, so they leave their clusters untouched too while
+// the resource is suspended. They are resumed by the next Sync, which copies the spec of the resource to them again
+func (r *MultiClusterReconciler) SuspendChildren(ctx context.Context, resource *unstructured.Unstructured) error {
+	children, err := r.listChildren(ctx, resource)
+	if err != nil {
+		return err
+	}
+
+	for _, child := range children {
+		if suspend, _, _ := unstructured.NestedBool(child.Object, "spec", "suspend"); suspend {
+			continue
+		}
+
+		if err := unstructured.SetNestedField(child.Object, true, "spec", "suspend"); err != nil {
+			return fmt.Errorf("failed to suspend %s %s: %w", r.Kind, child.GetName(), err)
+		}
+		if err := r.Update(ctx, child); err != nil {
+			return fmt.Errorf("failed to suspend %s %s: %w", r.Kind, child.GetName(), err)
+		}
+	}
+	return nil
+}
+
+// listChildren returns the resources of the clusters selected by a resource, owned by it
+func (r *MultiClusterReconciler) listChildren(ctx context.Context, resource *unstructured.Unstructured) ([]*unstructured.Unstructured, error) {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(r.groupVersionKind().GroupVersion().WithKind(r.Kind + "List"))
+	err := r.List(ctx, list, client.InNamespace(resource.GetNamespace()),
+		client.MatchingLabels{ParentUIDLabel: string(resource.GetUID())})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the resources of the selected clusters: %w", err)
+	}
+
+	children := make([]*unstructured.Unstructured, 0, len(list.Items))
+	for i := range list.Items {
+		if metav1.IsControlledBy(&list.Items[i], resource) {
+			children = append(children, &list.Items[i])
+		}
+	}
+	return children, nil
+}
+
+// childName returns the name of the resource created for a selected cluster: the name of the resource followed by the
+// one of the cluster, prefixed by its namespace when it is not the namespace of the resource, and by a hash of both.
+// The hash tells apart the pairs joined into the same name (e.g., "logs-eu" with "es" and "logs" with "eu-es"), and
+// keeps the names unique when they are truncated to the longest name of a resource
+func childName(resource *unstructured.Unstructured, target v1alpha1.ResourceSelector) string {
+	name := fmt.Sprintf("%s-%s", resource.GetName(), target.Name)
+	if target.Namespace != resource.GetNamespace() {
+		name = fmt.Sprintf("%s-%s-%s", resource.GetName(), target.Namespace, target.Name)
+	}
+
+	hash := sha256.Sum256([]byte(fmt.Sprintf("%s/%s/%s", resource.GetName(), target.Namespace, target.Name)))
+	suffix := hex.EncodeToString(hash[:])[:childNameHashLength]
+
+	// Truncated names must still end with an alphanumeric character before the hash
+	name = name[:min(len(name), validation.DNS1123SubdomainMaxLength-len(suffix)-1)]
+	return fmt.Sprintf("%s-%s", strings.TrimRight(name, "-."), suffix)
+}
+
+// newChild returns the resource of a selected cluster, with the given name: a copy of the spec of the resource
+// targeting the cluster by name, owned by the resource
+func (r *MultiClusterReconciler) newChild(resource *unstructured.Unstructured, target v1alpha1.ResourceSelector, name string) (*unstructured.Unstructured, error) {
+	spec, _, _ := unstructured.NestedMap(resource.Object, "spec")
+	resourceSelector, _, _ := unstructured.NestedMap(spec, "resourceSelector")
+	delete(resourceSelector, "matchLabels")
+	resourceSelector["name"] = target.Name
+	resourceSelector["namespace"] = target.Namespace
+	spec["resourceSelector"] = resourceSelector
+
+	childLabels := maps.Clone(resource.GetLabels())
+	if childLabels == nil {
+		childLabels = make(map[string]string, 1)
+	}
+	childLabels[ParentUIDLabel] = string(resource.GetUID())
+
+	child := r.newObject()
+	child.SetName(name)
+	child.SetNamespace(resource.GetNamespace())
+	child.SetLabels(childLabels)
+	child.Object["spec"] = spec
+
+	if err := controllerutil.SetControllerReference(resource, child, r.Scheme); err != nil {
+		return nil, fmt.Errorf("failed to set the owner of %s %s: %w", r.Kind, name, err)
+	}
+	return child, nil
+}
+
+// applyChild creates the resource of a selected cluster, or updates the spec and labels of the existing one. Existing
+// resources not owned by the resource are left untouched
+func (r *MultiClusterReconciler) applyChild(ctx context.Context, resource, child *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	current := r.newObject()
+	err := r.Get(ctx, client.ObjectKeyFromObject(child), current)
+	if apierrors.IsNotFound(err) {
+		if err := r.Create(ctx, child); err != nil {
+			return nil, fmt.Errorf("failed to create %s %s: %w", r.Kind, child.GetName(), err)
+		}
+		return child, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s %s: %w", r.Kind, child.GetName(), err)
+	}
+
+	if !metav1.IsControlledBy(current, resource) {
+		return nil, fmt.Errorf("%s %s already exists and is not owned by %s", r.Kind, child.GetName(), resource.GetName())
+	}
+
+	if equality.Semantic.DeepEqual(current.Object["spec"], child.Object["spec"]) &&
+		equality.Semantic.DeepEqual(current.GetLabels(), child.GetLabels()) {
+		return current, nil
+	}
+
+	current.Object["spec"] = runtime.DeepCopyJSONValue(child.Object["spec"])
+	current.SetLabels(child.GetLabels())
+	if err := r.Update(ctx, current); err != nil {
+		return nil, fmt.Errorf("failed to update %s %s: %w", r.Kind, child.GetName(), err)
+	}
+	return current, nil
+}
+
+// childPhase returns the phase and message of the resource of a selected cluster. Resources whose last spec was not
+// synced yet are reported as Syncing, as their status belongs to the previous one
+func childPhase(child *unstructured.Unstructured) (string, string) {
+	phase, _, _ := unstructured.NestedString(child.Object, "status", "phase")
+	message, _, _ := unstructured.NestedString(child.Object, "status", "message")
+	observedGeneration, _, _ := unstructured.NestedInt64(child.Object, "status", "observedGeneration")
+
+	switch {
+	case phase == "":
+		return controller.PhasePending, "Waiting for the first sync"
+	case observedGeneration != child.GetGeneration():
+		return controller.PhaseSyncing, "Waiting for the sync of the latest spec"
+	}
+	return phase, message
+}
@@ -0,0 +1,79 @@
+package multicluster
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
+)
+
+func TestChildName(t *testing.T) {
+	resourceOf := func(name string) *unstructured.Unstructured {
+		resource := &unstructured.Unstructured{}
+		resource.SetNamespace("logging")
+		resource.SetName(name)
+		return resource
+	}
+
+	tests := []struct {
+		name       string
+		resource   string
+		target     v1alpha1.ResourceSelector
+		wantPrefix string
+	}{
+		{
+			name:       "cluster of the namespace of the resource",
+			resource:   "logs-retention",
+			target:     v1alpha1.ResourceSelector{Namespace: "logging", Name: "es-eu"},
+			wantPrefix: "logs-retention-es-eu-",
+		},
+		{
+			name:       "cluster of another namespace",
+			resource:   "logs-retention",
+			target:     v1alpha1.ResourceSelector{Namespace: "search", Name: "es-eu"},
+			wantPrefix: "logs-retention-search-es-eu-",
+		},
+		{
+			name:       "names longer than a resource name are truncated",
+			resource:   strings.Repeat("a", 200),
+			target:     v1alpha1.ResourceSelector{Namespace: "logging", Name: strings.Repeat("b", 100)},
+			wantPrefix: strings.Repeat("a", 200) + "-" + strings.Repeat("b", 43) + "-",
+		},
+		{
+			name:       "truncated names don't end with a separator before the hash",
+			resource:   strings.Repeat("a", 244),
+			target:     v1alpha1.ResourceSelector{Namespace: "logging", Name: "es-eu"},
+			wantPrefix: strings.Repeat("a", 244) + "-",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := childName(resourceOf(tt.resource), tt.target)
+			if !strings.HasPrefix(got, tt.wantPrefix) || len(got) != len(tt.wantPrefix)+childNameHashLength {
+				t.Errorf("childName() = %q, want %q followed by the hash", got, tt.wantPrefix)
+			}
+			if errs := validation.IsDNS1123Subdomain(got); len(errs) > 0 {
+				t.Errorf("childName() = %q is not a valid name: %v", got, errs)
+			}
+		})
+	}
+}
+
+func TestChildNameTellsApartJoinedNames(t *testing.T) {
+	resource := &unstructured.Unstructured{}
+	resource.SetNamespace("logging")
+	resource.SetName("logs-eu")
+	other := &unstructured.Unstructured{}
+	other.SetNamespace("logging")
+	other.SetName("logs")
+
+	name := childName(resource, v1alpha1.ResourceSelector{Namespace: "logging", Name: "es"})
+	otherName := childName(other, v1alpha1.ResourceSelector{Namespace: "logging", Name: "eu-es"})
+	if name == otherName {
+		t.Errorf("childName() = %q for both resources, want different names", name)
+	}
+}
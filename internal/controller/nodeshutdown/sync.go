@@ -0,0 +1,375 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodeshutdown
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+
+	"github.com/elastic/go-elasticsearch/v8"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/globals"
+)
+
+// Sync executes the synchronization of node shutdowns with Elasticsearch
+func (r *NodeShutdownReconciler) Sync(ctx context.Context, eventType watch.EventType, resource *v1alpha1.NodeShutdown) (err error) {
+
+	logger := log.FromContext(ctx)
+
+	// The target cluster is resolved without changing the spec: the NamespaceDefaultCluster is used when the resource
+	// doesn't select one, and the namespace of the resource when the selector has none
+	resourceSelector, err := globals.ResolveResourceSelector(ctx, &resource.Spec.ResourceSelector, resource.Namespace)
+	if err != nil {
+		logger.Error(err, "Failed to resolve the target cluster")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	// Check the namespace of the resource is allowed to target the cluster
+	if err := globals.AuthorizeClusterAccess(ctx, resourceSelector, resource.Namespace, &resource.Status.Conditions); err != nil {
+		logger.Error(err, "Access to the target cluster denied")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	if err := globals.ValidateTLSVerification(resourceSelector, &resource.Status.Conditions); err != nil {
+		logger.Error(err, "TLS verification of the target cluster not configured")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	// Build the cluster key identifying the target cluster in the logs
+	clusterKey := fmt.Sprintf("%s_%s", resourceSelector.Namespace, resourceSelector.Name)
+
+	if eventType == watch.Deleted {
+		logger.Info(fmt.Sprintf("Deleting NodeShutdown %s/%s", resource.Namespace, resource.Name))
+
+		// Get Elasticsearch connection to cancel the shutdowns
+		esConnection, err := globals.GetOrCreateElasticsearchConnection(ctx, resourceSelector, resource.Namespace, r.ElasticsearchConnectionsPool)
+		if err != nil {
+			logger.Error(err, "Failed to get Elasticsearch connection for deletion")
+			return err
+		}
+
+		// Writes are serialized with the other resources targeting the same cluster
+		unlock, err := globals.LockCluster(ctx, esConnection.Endpoint)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+
+		// Cancel the shutdown of each node
+		for _, nodeName := range resource.Status.AppliedResources {
+			logger.Info(fmt.Sprintf("Cancelling shutdown of node %s", nodeName))
+			if err := r.cancelNodeShutdown(ctx, esConnection.Client, resource.Status.Nodes[nodeName].NodeID); err != nil {
+				logger.Error(err, fmt.Sprintf("Failed to cancel shutdown of node %s", nodeName))
+				return err
+			}
+			logger.Info(fmt.Sprintf("Shutdown of node %s cancelled successfully", nodeName))
+		}
+
+		return nil
+	}
+
+	logger.Info(fmt.Sprintf("Syncing NodeShutdown %s/%s", resource.Namespace, resource.Name))
+
+	// Set status to Syncing at the beginning
+	r.SetSyncing(ctx, resource)
+
+	// Step 1: Get or create Elasticsearch connection
+	esConnection, err := globals.GetOrCreateElasticsearchConnection(ctx, resourceSelector, resource.Namespace, r.ElasticsearchConnectionsPool)
+	globals.UpdateClusterReachableCondition(&resource.Status.Conditions, err)
+	if err != nil {
+		logger.Error(err, "Failed to get or create Elasticsearch connection")
+		if errors.Is(err, globals.ErrClusterNotReady) {
+			r.SetWaitingForCluster(ctx, resource, err)
+			return err
+		}
+		r.SetError(ctx, resource, fmt.Errorf("%w to Elasticsearch: %w", globals.ErrConnectionFailed, err))
+		return err
+	}
+
+	// Writes are serialized with the other resources targeting the same cluster
+	unlock, err := globals.LockCluster(ctx, esConnection.Endpoint)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	logger.Info(fmt.Sprintf("Elasticsearch connection established for cluster %s (type: %s, version: %s)", clusterKey, esConnection.ClusterType, esConnection.Version))
+
+	// Validate cluster type - node shutdown is only available in Elasticsearch
+	if esConnection.ClusterType == "opensearch" {
+		err := fmt.Errorf("the node shutdown API is not available in OpenSearch")
+		logger.Error(err, "Incompatible cluster type for NodeShutdown")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	// Step 2: Cancel the shutdowns that are no longer desired, so the nodes get shards allocated again
+	for _, nodeName := range resource.Status.AppliedResources {
+		if _, desired := resource.Spec.Resources[nodeName]; !desired {
+			logger.Info(fmt.Sprintf("Shutdown of node %s is no longer desired, cancelling it", nodeName))
+			if err := r.cancelNodeShutdown(ctx, esConnection.Client, resource.Status.Nodes[nodeName].NodeID); err != nil {
+				logger.Error(err, fmt.Sprintf("Failed to cancel shutdown of node %s", nodeName))
+				r.SetError(ctx, resource, fmt.Errorf("failed to cancel shutdown of node %s: %w", nodeName, err))
+				return err
+			}
+			logger.Info(fmt.Sprintf("Shutdown of node %s cancelled successfully", nodeName))
+			globals.RecordPruned(r.Recorder, resource, nodeName)
+		}
+	}
+
+	nodeNames := make([]string, 0, len(resource.Spec.Resources))
+	for nodeName := range resource.Spec.Resources {
+		nodeNames = append(nodeNames, nodeName)
+	}
+	sort.Strings(nodeNames)
+
+	// Step 3: Register all desired shutdowns and collect their progress
+	newAppliedShutdowns := make([]string, 0, len(nodeNames))
+	newNodes := make(map[string]v1alpha1.NodeShutdownProgress, len(nodeNames))
+	for _, nodeName := range nodeNames {
+		// The node ID is kept once resolved, as removed nodes can't be resolved anymore after leaving the cluster
+		nodeID := resource.Status.Nodes[nodeName].NodeID
+		if nodeID == "" {
+			nodeID, err = r.resolveNodeID(ctx, esConnection.Client, nodeName)
+			if err != nil {
+				logger.Error(err, fmt.Sprintf("Failed to resolve node %s", nodeName))
+				r.SetError(ctx, resource, fmt.Errorf("failed to resolve node %s: %w", nodeName, err))
+				return err
+			}
+		}
+
+		logger.Info(fmt.Sprintf("Applying shutdown of node %s (%s)", nodeName, nodeID))
+		progress, err := r.applyNodeShutdown(ctx, esConnection.Client, nodeID, resource.Spec.Resources[nodeName])
+		if err != nil {
+			logger.Error(err, fmt.Sprintf("Failed to apply shutdown of node %s", nodeName))
+			r.SetError(ctx, resource, fmt.Errorf("failed to apply shutdown of node %s: %w", nodeName, err))
+			return err
+		}
+		newAppliedShutdowns = append(newAppliedShutdowns, nodeName)
+		newNodes[nodeName] = progress
+		logger.Info(fmt.Sprintf("Shutdown of node %s applied successfully (status: %s)", nodeName, progress.Status))
+	}
+
+	// Step 4: Update the Status with the new list of applied shutdowns and their progress
+	targetCluster := fmt.Sprintf("%s/%s", resourceSelector.Namespace, resourceSelector.Name)
+	r.SetReady(ctx, resource, targetCluster, newAppliedShutdowns, newNodes)
+
+	logger.Info(fmt.Sprintf("NodeShutdown %s/%s synced successfully", resource.Namespace, resource.Name))
+
+	return nil
+}
+
+const (
+	// shutdownStatusComplete is reported once the node can be safely shut down
+	shutdownStatusComplete = "COMPLETE"
+)
+
+// nodeShutdownResponse is the response of the get node shutdown API
+type nodeShutdownResponse struct {
+	Nodes []struct {
+		NodeID          string `json:"node_id"`
+		Type            string `json:"type"`
+		Reason          string `json:"reason"`
+		AllocationDelay string `json:"allocation_delay"`
+		TargetNodeName  string `json:"target_node_name"`
+		Status          string `json:"status"`
+		ShardMigration  struct {
+			Status                   string `json:"status"`
+			ShardMigrationsRemaining int64  `json:"shard_migrations_remaining"`
+			Explanation              string `json:"explanation"`
+		} `json:"shard_migration"`
+	} `json:"nodes"`
+}
+
+// applyNodeShutdown registers the shutdown of a node when it is missing or differs, and returns its progress
+func (r *NodeShutdownReconciler) applyNodeShutdown(ctx context.Context, esClient *elasticsearch.Client, nodeID string, definition v1alpha1.NodeShutdownDefinition) (v1alpha1.NodeShutdownProgress, error) {
+	logger := log.FromContext(ctx)
+
+	live, err := r.getNodeShutdown(ctx, esClient, nodeID)
+	if err != nil {
+		return v1alpha1.NodeShutdownProgress{}, err
+	}
+
+	upToDate := len(live.Nodes) > 0 &&
+		live.Nodes[0].Type == definition.Type &&
+		live.Nodes[0].Reason == definition.Reason &&
+		live.Nodes[0].TargetNodeName == definition.TargetNodeName &&
+		(definition.AllocationDelay == "" || live.Nodes[0].AllocationDelay == definition.AllocationDelay)
+
+	if !upToDate {
+		request := map[string]string{
+			"type":   definition.Type,
+			"reason": definition.Reason,
+		}
+		if definition.AllocationDelay != "" {
+			request["allocation_delay"] = definition.AllocationDelay
+		}
+		if definition.TargetNodeName != "" {
+			request["target_node_name"] = definition.TargetNodeName
+		}
+
+		requestJSON, err := json.Marshal(request)
+		if err != nil {
+			return v1alpha1.NodeShutdownProgress{}, fmt.Errorf("failed to marshal node shutdown: %w", err)
+		}
+
+		res, err := esClient.ShutdownPutNode(
+			bytes.NewReader(requestJSON),
+			nodeID,
+			esClient.ShutdownPutNode.WithContext(ctx),
+		)
+		if err != nil {
+			return v1alpha1.NodeShutdownProgress{}, fmt.Errorf("failed to put node shutdown: %w", err)
+		}
+		defer res.Body.Close()
+
+		if res.IsError() {
+			bodyBytes, _ := io.ReadAll(res.Body)
+			return v1alpha1.NodeShutdownProgress{}, fmt.Errorf("elasticsearch API error: %s - %s", res.Status(), string(bodyBytes))
+		}
+
+		// Read the shutdown again to report the progress of the new registration
+		live, err = r.getNodeShutdown(ctx, esClient, nodeID)
+		if err != nil {
+			return v1alpha1.NodeShutdownProgress{}, err
+		}
+	} else {
+		logger.Info(fmt.Sprintf("Shutdown of node %s is up to date", nodeID))
+	}
+
+	progress := v1alpha1.NodeShutdownProgress{NodeID: nodeID}
+	if len(live.Nodes) > 0 {
+		progress.Status = live.Nodes[0].Status
+		progress.ShardMigrationStatus = live.Nodes[0].ShardMigration.Status
+		progress.ShardMigrationsRemaining = live.Nodes[0].ShardMigration.ShardMigrationsRemaining
+		progress.Explanation = live.Nodes[0].ShardMigration.Explanation
+	}
+
+	return progress, nil
+}
+
+// getNodeShutdown returns the shutdown registered for a node. The response has no nodes when there is none
+func (r *NodeShutdownReconciler) getNodeShutdown(ctx context.Context, esClient *elasticsearch.Client, nodeID string) (*nodeShutdownResponse, error) {
+	res, err := esClient.ShutdownGetNode(
+		esClient.ShutdownGetNode.WithNodeID(nodeID),
+		esClient.ShutdownGetNode.WithContext(ctx),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get node shutdown: %w", err)
+	}
+	defer res.Body.Close()
+
+	bodyBytes, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if res.IsError() {
+		return nil, fmt.Errorf("elasticsearch API error: %s - %s", res.Status(), string(bodyBytes))
+	}
+
+	response := &nodeShutdownResponse{}
+	if err := json.Unmarshal(bodyBytes, response); err != nil {
+		return nil, fmt.Errorf("failed to parse node shutdown response: %w", err)
+	}
+
+	return response, nil
+}
+
+// resolveNodeID returns the ID of the node with the given name or ID
+func (r *NodeShutdownReconciler) resolveNodeID(ctx context.Context, esClient *elasticsearch.Client, nodeName string) (string, error) {
+	res, err := esClient.Nodes.Info(
+		esClient.Nodes.Info.WithNodeID(nodeName),
+		esClient.Nodes.Info.WithFilterPath("nodes.*.name"),
+		esClient.Nodes.Info.WithContext(ctx),
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to get nodes info: %w", err)
+	}
+	defer res.Body.Close()
+
+	bodyBytes, err := io.ReadAll(res.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if res.IsError() {
+		return "", fmt.Errorf("elasticsearch API error: %s - %s", res.Status(), string(bodyBytes))
+	}
+
+	var response struct {
+		Nodes map[string]struct {
+			Name string `json:"name"`
+		} `json:"nodes"`
+	}
+	if err := json.Unmarshal(bodyBytes, &response); err != nil {
+		return "", fmt.Errorf("failed to parse nodes info: %w", err)
+	}
+
+	if len(response.Nodes) != 1 {
+		return "", fmt.Errorf("expected a single node matching %s, found %d", nodeName, len(response.Nodes))
+	}
+
+	for nodeID := range response.Nodes {
+		return nodeID, nil
+	}
+
+	return "", nil
+}
+
+// cancelNodeShutdown removes the shutdown registered for a node
+func (r *NodeShutdownReconciler) cancelNodeShutdown(ctx context.Context, esClient *elasticsearch.Client, nodeID string) error {
+	logger := log.FromContext(ctx)
+
+	if nodeID == "" {
+		logger.Info("Node ID of the shutdown is unknown, skipping cancellation")
+		return nil
+	}
+
+	res, err := esClient.ShutdownDeleteNode(
+		nodeID,
+		esClient.ShutdownDeleteNode.WithContext(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete node shutdown: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		// If the shutdown doesn't exist (404), consider it already cancelled
+		if res.StatusCode == http.StatusNotFound {
+			logger.Info(fmt.Sprintf("Shutdown of node %s not found in Elasticsearch (already cancelled)", nodeID))
+			return nil
+		}
+		bodyBytes, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("elasticsearch API error: %s - %s", res.Status(), string(bodyBytes))
+	}
+
+	return nil
+}
@@ -0,0 +1,295 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package opensearchalertingmonitor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/elastic/go-elasticsearch/v8"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/globals"
+)
+
+// Sync executes the synchronization of alerting monitors with OpenSearch
+func (r *OpenSearchAlertingMonitorReconciler) Sync(ctx context.Context, eventType watch.EventType, resource *v1alpha1.OpenSearchAlertingMonitor) (err error) {
+
+	logger := log.FromContext(ctx)
+
+	// The target cluster is resolved without changing the spec: the NamespaceDefaultCluster is used when the resource
+	// doesn't select one, and the namespace of the resource when the selector has none
+	resourceSelector, err := globals.ResolveResourceSelector(ctx, &resource.Spec.ResourceSelector, resource.Namespace)
+	if err != nil {
+		logger.Error(err, "Failed to resolve the target cluster")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	// Check the namespace of the resource is allowed to target the cluster
+	if err := globals.AuthorizeClusterAccess(ctx, resourceSelector, resource.Namespace, &resource.Status.Conditions); err != nil {
+		logger.Error(err, "Access to the target cluster denied")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	if err := globals.ValidateTLSVerification(resourceSelector, &resource.Status.Conditions); err != nil {
+		logger.Error(err, "TLS verification of the target cluster not configured")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	// Build the cluster key identifying the target cluster in the logs
+	clusterKey := fmt.Sprintf("%s_%s", resourceSelector.Namespace, resourceSelector.Name)
+
+	if eventType == watch.Deleted {
+		logger.Info(fmt.Sprintf("Deleting OpenSearchAlertingMonitor %s/%s", resource.Namespace, resource.Name))
+
+		// Get OpenSearch connection to delete the monitors
+		esConnection, err := globals.GetOrCreateElasticsearchConnection(ctx, resourceSelector, resource.Namespace, r.ElasticsearchConnectionsPool)
+		if err != nil {
+			logger.Error(err, "Failed to get OpenSearch connection for deletion")
+			return err
+		}
+
+		// Writes are serialized with the other resources targeting the same cluster
+		unlock, err := globals.LockCluster(ctx, esConnection.Endpoint)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+
+		// Delete each monitor created by this resource (monitors are addressed by ID)
+		for monitorName, monitorID := range resource.Status.MonitorIDs {
+			logger.Info(fmt.Sprintf("Deleting monitor %s (%s) from OpenSearch", monitorName, monitorID))
+			if err := r.deleteMonitor(ctx, esConnection.Client, monitorID); err != nil {
+				logger.Error(err, fmt.Sprintf("Failed to delete monitor %s", monitorName))
+				return err
+			}
+			logger.Info(fmt.Sprintf("Monitor %s deleted successfully", monitorName))
+		}
+
+		return nil
+	}
+
+	logger.Info(fmt.Sprintf("Syncing OpenSearchAlertingMonitor %s/%s", resource.Namespace, resource.Name))
+
+	// Set status to Syncing at the beginning
+	r.SetSyncing(ctx, resource)
+
+	// Step 1: Get or create OpenSearch connection
+	esConnection, err := globals.GetOrCreateElasticsearchConnection(ctx, resourceSelector, resource.Namespace, r.ElasticsearchConnectionsPool)
+	globals.UpdateClusterReachableCondition(&resource.Status.Conditions, err)
+	if err != nil {
+		logger.Error(err, "Failed to get or create OpenSearch connection")
+		if errors.Is(err, globals.ErrClusterNotReady) {
+			r.SetWaitingForCluster(ctx, resource, err)
+			return err
+		}
+		r.SetError(ctx, resource, fmt.Errorf("%w to OpenSearch: %w", globals.ErrConnectionFailed, err))
+		return err
+	}
+
+	// Writes are serialized with the other resources targeting the same cluster
+	unlock, err := globals.LockCluster(ctx, esConnection.Endpoint)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	logger.Info(fmt.Sprintf("OpenSearch connection established for cluster %s (type: %s, version: %s)", clusterKey, esConnection.ClusterType, esConnection.Version))
+
+	// Validate cluster type - the Alerting plugin is only available in OpenSearch
+	if esConnection.ClusterType == "elasticsearch" {
+		err := fmt.Errorf("alerting monitors are only available in OpenSearch (Alerting plugin). Elasticsearch uses Watcher or Kibana alerting instead")
+		logger.Error(err, "Incompatible cluster type for OpenSearchAlertingMonitor")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	// Monitor IDs are updated in place so that monitors created before a failure
+	// are persisted by the error status update and never orphaned
+	if resource.Status.MonitorIDs == nil {
+		resource.Status.MonitorIDs = make(map[string]string)
+	}
+
+	// Step 2: Delete monitors that are no longer desired
+	for monitorName, monitorID := range resource.Status.MonitorIDs {
+		if _, desired := resource.Spec.Resources[monitorName]; desired {
+			continue
+		}
+		logger.Info(fmt.Sprintf("Monitor %s is no longer desired, deleting from OpenSearch", monitorName))
+		if err := r.deleteMonitor(ctx, esConnection.Client, monitorID); err != nil {
+			logger.Error(err, fmt.Sprintf("Failed to delete monitor %s", monitorName))
+			r.SetError(ctx, resource, fmt.Errorf("failed to delete monitor %s: %w", monitorName, err))
+			return err
+		}
+		delete(resource.Status.MonitorIDs, monitorName)
+		logger.Info(fmt.Sprintf("Monitor %s deleted successfully", monitorName))
+		globals.RecordPruned(r.Recorder, resource, monitorName)
+	}
+
+	// Step 3: Create or update all desired monitors
+	newAppliedMonitors := make([]string, 0, len(resource.Spec.Resources))
+	for monitorName, monitorResource := range resource.Spec.Resources {
+		logger.Info(fmt.Sprintf("Processing monitor: %s", monitorName))
+
+		// Parse the desired monitor from the resource
+		var desiredMonitor map[string]interface{}
+		if err := json.Unmarshal(monitorResource.Raw, &desiredMonitor); err != nil {
+			logger.Error(err, fmt.Sprintf("Failed to unmarshal monitor %s", monitorName))
+			r.SetError(ctx, resource, fmt.Errorf("failed to unmarshal monitor %s: %w", monitorName, err))
+			return err
+		}
+
+		// The resource key is the source of truth for the monitor name
+		desiredMonitor["name"] = monitorName
+
+		monitorID, err := r.applyMonitor(ctx, esConnection.Client, resource.Status.MonitorIDs[monitorName], desiredMonitor)
+		if err != nil {
+			logger.Error(err, fmt.Sprintf("Failed to apply monitor %s", monitorName))
+			r.SetError(ctx, resource, fmt.Errorf("failed to apply monitor %s: %w", monitorName, err))
+			return err
+		}
+		resource.Status.MonitorIDs[monitorName] = monitorID
+		logger.Info(fmt.Sprintf("Monitor %s applied successfully (id: %s)", monitorName, monitorID))
+		newAppliedMonitors = append(newAppliedMonitors, monitorName)
+	}
+
+	// Step 4: Update the Status with the new list of applied monitors
+	targetCluster := fmt.Sprintf("%s/%s", resourceSelector.Namespace, resourceSelector.Name)
+	r.SetReady(ctx, resource, targetCluster, newAppliedMonitors)
+
+	logger.Info(fmt.Sprintf("OpenSearchAlertingMonitor %s/%s synced successfully", resource.Namespace, resource.Name))
+
+	return nil
+}
+
+// applyMonitor creates or updates a monitor in OpenSearch and returns its ID.
+// The Alerting plugin assigns IDs on creation (POST), so updates must target the stored ID (PUT).
+// If the stored monitor no longer exists, it is created again.
+func (r *OpenSearchAlertingMonitorReconciler) applyMonitor(ctx context.Context, esClient *elasticsearch.Client, monitorID string, monitor map[string]interface{}) (string, error) {
+	logger := log.FromContext(ctx)
+
+	// Marshal the monitor to JSON
+	monitorJSON, err := json.Marshal(monitor)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal monitor: %w", err)
+	}
+
+	if monitorID != "" {
+		// PUT /_plugins/_alerting/monitors/{monitor_id}
+		logger.Info(fmt.Sprintf("Updating monitor %s in OpenSearch", monitorID))
+		res, err := r.performRequest(ctx, esClient, http.MethodPut, fmt.Sprintf("/_plugins/_alerting/monitors/%s", monitorID), monitorJSON)
+		if err != nil {
+			return "", fmt.Errorf("failed to update monitor: %w", err)
+		}
+		defer res.Body.Close()
+
+		if res.StatusCode != http.StatusNotFound {
+			if res.StatusCode >= 400 {
+				bodyBytes, _ := io.ReadAll(res.Body)
+				return "", fmt.Errorf("OpenSearch API error: %s - %s", res.Status, string(bodyBytes))
+			}
+			return monitorID, nil
+		}
+
+		logger.Info(fmt.Sprintf("Monitor %s not found in OpenSearch, creating it again", monitorID))
+	}
+
+	// POST /_plugins/_alerting/monitors
+	logger.Info("Creating monitor in OpenSearch")
+	res, err := r.performRequest(ctx, esClient, http.MethodPost, "/_plugins/_alerting/monitors", monitorJSON)
+	if err != nil {
+		return "", fmt.Errorf("failed to create monitor: %w", err)
+	}
+	defer res.Body.Close()
+
+	bodyBytes, err := io.ReadAll(res.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if res.StatusCode >= 400 {
+		return "", fmt.Errorf("OpenSearch API error: %s - %s", res.Status, string(bodyBytes))
+	}
+
+	var created struct {
+		ID string `json:"_id"`
+	}
+	if err := json.Unmarshal(bodyBytes, &created); err != nil {
+		return "", fmt.Errorf("failed to parse monitor creation response: %w", err)
+	}
+	if created.ID == "" {
+		return "", fmt.Errorf("monitor creation response does not contain an ID: %s", string(bodyBytes))
+	}
+
+	return created.ID, nil
+}
+
+// deleteMonitor deletes a monitor from OpenSearch by ID
+func (r *OpenSearchAlertingMonitorReconciler) deleteMonitor(ctx context.Context, esClient *elasticsearch.Client, monitorID string) error {
+	logger := log.FromContext(ctx)
+
+	logger.Info(fmt.Sprintf("Deleting monitor %s from OpenSearch", monitorID))
+
+	// DELETE /_plugins/_alerting/monitors/{monitor_id}
+	res, err := r.performRequest(ctx, esClient, http.MethodDelete, fmt.Sprintf("/_plugins/_alerting/monitors/%s", monitorID), nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete monitor: %w", err)
+	}
+	defer res.Body.Close()
+
+	// If the monitor doesn't exist (404), consider it already deleted
+	if res.StatusCode == http.StatusNotFound {
+		logger.Info(fmt.Sprintf("Monitor %s not found in OpenSearch (already deleted)", monitorID))
+		return nil
+	}
+
+	if res.StatusCode >= 400 {
+		bodyBytes, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("OpenSearch API error: %s - %s", res.Status, string(bodyBytes))
+	}
+
+	return nil
+}
+
+// performRequest sends a raw request to the OpenSearch Alerting plugin API
+func (r *OpenSearchAlertingMonitorReconciler) performRequest(ctx context.Context, esClient *elasticsearch.Client, method, path string, body []byte) (*http.Response, error) {
+	var bodyReader io.Reader
+	if body != nil {
+		bodyReader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, path, bodyReader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	return esClient.Perform(req)
+}
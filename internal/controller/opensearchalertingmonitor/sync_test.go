@@ -0,0 +1,210 @@
+package opensearchalertingmonitor
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/elastic/go-elasticsearch/v8"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+
+	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/controller"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/globals"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/pools"
+)
+
+const monitorsPath = "/_plugins/_alerting/monitors"
+
+// fakeAlerting is an OpenSearch Alerting plugin storing the IDs of its monitors, and recording the requests it serves
+type fakeAlerting struct {
+	mu         sync.Mutex
+	monitorIDs map[string]bool
+	failCreate bool
+	created    int
+	requests   []string
+}
+
+func (f *fakeAlerting) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	// The client refuses the responses of servers not identified as Elasticsearch
+	w.Header().Set("X-Elastic-Product", "Elasticsearch")
+	w.Header().Set("Content-Type", "application/json")
+	f.requests = append(f.requests, fmt.Sprintf("%s %s", r.Method, r.URL.Path))
+
+	monitorID := strings.TrimPrefix(r.URL.Path, monitorsPath+"/")
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == monitorsPath:
+		if f.failCreate {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"error":"failed"}`))
+			return
+		}
+		f.created++
+		monitorID = fmt.Sprintf("id-%d", f.created)
+		f.monitorIDs[monitorID] = true
+		_, _ = fmt.Fprintf(w, `{"_id":%q}`, monitorID)
+	case (r.Method == http.MethodPut || r.Method == http.MethodDelete) && f.monitorIDs[monitorID]:
+		if r.Method == http.MethodDelete {
+			delete(f.monitorIDs, monitorID)
+		}
+		_, _ = fmt.Fprintf(w, `{"_id":%q}`, monitorID)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{}`))
+	}
+}
+
+func TestSync(t *testing.T) {
+	monitor := apiextensionsv1.JSON{Raw: []byte(`{"type":"monitor","enabled":true}`)}
+
+	tests := []struct {
+		name        string
+		eventType   watch.EventType
+		clusterType string
+		// existingIDs are the IDs of the monitors stored in OpenSearch
+		existingIDs []string
+		failCreate  bool
+		resources   map[string]apiextensionsv1.JSON
+		monitorIDs  map[string]string
+
+		wantErr        bool
+		wantPhase      string
+		wantMonitorIDs map[string]string
+		wantRequests   []string
+	}{
+		{
+			name:           "new monitors are created",
+			eventType:      watch.Modified,
+			resources:      map[string]apiextensionsv1.JSON{"cpu": monitor},
+			wantPhase:      controller.PhaseReady,
+			wantMonitorIDs: map[string]string{"cpu": "id-1"},
+			wantRequests:   []string{"POST " + monitorsPath},
+		},
+		{
+			name:           "existing monitors are updated by their ID",
+			eventType:      watch.Modified,
+			existingIDs:    []string{"id-7"},
+			resources:      map[string]apiextensionsv1.JSON{"cpu": monitor},
+			monitorIDs:     map[string]string{"cpu": "id-7"},
+			wantPhase:      controller.PhaseReady,
+			wantMonitorIDs: map[string]string{"cpu": "id-7"},
+			wantRequests:   []string{"PUT " + monitorsPath + "/id-7"},
+		},
+		{
+			name:           "monitors deleted out of band are created again",
+			eventType:      watch.Modified,
+			resources:      map[string]apiextensionsv1.JSON{"cpu": monitor},
+			monitorIDs:     map[string]string{"cpu": "id-7"},
+			wantPhase:      controller.PhaseReady,
+			wantMonitorIDs: map[string]string{"cpu": "id-1"},
+			wantRequests:   []string{"POST " + monitorsPath, "PUT " + monitorsPath + "/id-7"},
+		},
+		{
+			name:           "monitors removed from the spec are deleted",
+			eventType:      watch.Modified,
+			existingIDs:    []string{"id-7", "id-8"},
+			resources:      map[string]apiextensionsv1.JSON{"cpu": monitor},
+			monitorIDs:     map[string]string{"cpu": "id-7", "disk": "id-8"},
+			wantPhase:      controller.PhaseReady,
+			wantMonitorIDs: map[string]string{"cpu": "id-7"},
+			wantRequests:   []string{"DELETE " + monitorsPath + "/id-8", "PUT " + monitorsPath + "/id-7"},
+		},
+		{
+			name:           "failed creations are reported",
+			eventType:      watch.Modified,
+			failCreate:     true,
+			resources:      map[string]apiextensionsv1.JSON{"cpu": monitor},
+			wantErr:        true,
+			wantPhase:      controller.PhaseError,
+			wantMonitorIDs: map[string]string{},
+			wantRequests:   []string{"POST " + monitorsPath},
+		},
+		{
+			name:           "Elasticsearch clusters are rejected",
+			eventType:      watch.Modified,
+			clusterType:    "elasticsearch",
+			resources:      map[string]apiextensionsv1.JSON{"cpu": monitor},
+			wantErr:        true,
+			wantPhase:      controller.PhaseError,
+			wantMonitorIDs: nil,
+			wantRequests:   nil,
+		},
+		{
+			name:           "deleted resources delete their monitors",
+			eventType:      watch.Deleted,
+			existingIDs:    []string{"id-7"},
+			resources:      map[string]apiextensionsv1.JSON{"cpu": monitor},
+			monitorIDs:     map[string]string{"cpu": "id-7", "disk": "id-8"},
+			wantMonitorIDs: map[string]string{"cpu": "id-7", "disk": "id-8"},
+			wantRequests:   []string{"DELETE " + monitorsPath + "/id-7", "DELETE " + monitorsPath + "/id-8"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			alerting := &fakeAlerting{monitorIDs: make(map[string]bool), failCreate: tt.failCreate}
+			for _, monitorID := range tt.existingIDs {
+				alerting.monitorIDs[monitorID] = true
+			}
+			server := httptest.NewServer(alerting)
+			t.Cleanup(server.Close)
+
+			esClient, err := elasticsearch.NewClient(elasticsearch.Config{Addresses: []string{server.URL}})
+			if err != nil {
+				t.Fatalf("failed to create the client: %v", err)
+			}
+			clusterType := tt.clusterType
+			if clusterType == "" {
+				clusterType = "opensearch"
+			}
+
+			// The resources reference a connection already pooled, so no connection is created to the fake cluster
+			pool := &pools.ElasticsearchConnectionsStore{Store: make(map[string]*pools.ElasticsearchConnection)}
+			pool.Set(globals.ClusterConnectionKey("monitoring", "opensearch"), &pools.ElasticsearchConnection{
+				Endpoint:    server.URL,
+				Client:      esClient,
+				ClusterType: clusterType,
+			})
+			r := &OpenSearchAlertingMonitorReconciler{ElasticsearchConnectionsPool: pool}
+
+			resource := &v1alpha1.OpenSearchAlertingMonitor{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "monitoring", Name: "alerts"},
+				Spec: v1alpha1.OpenSearchAlertingMonitorSpec{
+					ResourceSelector: v1alpha1.ResourceSelector{
+						ConnectionRef: &v1alpha1.ClusterConnectionReference{Name: "opensearch"},
+					},
+					Resources: tt.resources,
+				},
+				Status: v1alpha1.OpenSearchAlertingMonitorStatus{MonitorIDs: maps.Clone(tt.monitorIDs)},
+			}
+
+			err = r.Sync(context.Background(), tt.eventType, resource)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Sync() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if resource.Status.Phase != tt.wantPhase {
+				t.Errorf("Sync() phase = %q, want %q", resource.Status.Phase, tt.wantPhase)
+			}
+			if !maps.Equal(resource.Status.MonitorIDs, tt.wantMonitorIDs) {
+				t.Errorf("Sync() monitorIDs = %v, want %v", resource.Status.MonitorIDs, tt.wantMonitorIDs)
+			}
+
+			// The monitors are synced in the order of their maps, so the requests are compared sorted
+			requests := slices.Sorted(slices.Values(alerting.requests))
+			if !slices.Equal(requests, tt.wantRequests) {
+				t.Errorf("Sync() requests = %v, want %v", requests, tt.wantRequests)
+			}
+		})
+	}
+}
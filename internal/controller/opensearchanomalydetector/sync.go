@@ -0,0 +1,437 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package opensearchanomalydetector
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/elastic/go-elasticsearch/v8"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/globals"
+)
+
+const (
+	// Detector real-time job states
+	detectorStateStarted = "Started"
+	detectorStateStopped = "Stopped"
+
+	detectorsPath = "/_plugins/_anomaly_detection/detectors"
+)
+
+// liveDetector is the subset of the detector GET response used by the controller
+type liveDetector struct {
+	ID       string                 `json:"_id"`
+	Detector map[string]interface{} `json:"anomaly_detector"`
+	Job      *struct {
+		Enabled bool `json:"enabled"`
+	} `json:"anomaly_detector_job"`
+}
+
+// Sync executes the synchronization of anomaly detectors with OpenSearch
+func (r *OpenSearchAnomalyDetectorReconciler) Sync(ctx context.Context, eventType watch.EventType, resource *v1alpha1.OpenSearchAnomalyDetector) (err error) {
+
+	logger := log.FromContext(ctx)
+
+	// The target cluster is resolved without changing the spec: the NamespaceDefaultCluster is used when the resource
+	// doesn't select one, and the namespace of the resource when the selector has none
+	resourceSelector, err := globals.ResolveResourceSelector(ctx, &resource.Spec.ResourceSelector, resource.Namespace)
+	if err != nil {
+		logger.Error(err, "Failed to resolve the target cluster")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	// Check the namespace of the resource is allowed to target the cluster
+	if err := globals.AuthorizeClusterAccess(ctx, resourceSelector, resource.Namespace, &resource.Status.Conditions); err != nil {
+		logger.Error(err, "Access to the target cluster denied")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	if err := globals.ValidateTLSVerification(resourceSelector, &resource.Status.Conditions); err != nil {
+		logger.Error(err, "TLS verification of the target cluster not configured")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	// Build the cluster key identifying the target cluster in the logs
+	clusterKey := fmt.Sprintf("%s_%s", resourceSelector.Namespace, resourceSelector.Name)
+
+	if eventType == watch.Deleted {
+		logger.Info(fmt.Sprintf("Deleting OpenSearchAnomalyDetector %s/%s", resource.Namespace, resource.Name))
+
+		// Get OpenSearch connection to delete the detectors
+		esConnection, err := globals.GetOrCreateElasticsearchConnection(ctx, resourceSelector, resource.Namespace, r.ElasticsearchConnectionsPool)
+		if err != nil {
+			logger.Error(err, "Failed to get OpenSearch connection for deletion")
+			return err
+		}
+
+		// Writes are serialized with the other resources targeting the same cluster
+		unlock, err := globals.LockCluster(ctx, esConnection.Endpoint)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+
+		// Delete each detector created by this resource (detectors are addressed by ID)
+		for detectorName, detectorID := range resource.Status.DetectorIDs {
+			logger.Info(fmt.Sprintf("Deleting detector %s (%s) from OpenSearch", detectorName, detectorID))
+			if err := r.deleteDetector(ctx, esConnection.Client, detectorID); err != nil {
+				logger.Error(err, fmt.Sprintf("Failed to delete detector %s", detectorName))
+				return err
+			}
+			logger.Info(fmt.Sprintf("Detector %s deleted successfully", detectorName))
+		}
+
+		return nil
+	}
+
+	logger.Info(fmt.Sprintf("Syncing OpenSearchAnomalyDetector %s/%s", resource.Namespace, resource.Name))
+
+	// Set status to Syncing at the beginning
+	r.SetSyncing(ctx, resource)
+
+	// Step 1: Get or create OpenSearch connection
+	esConnection, err := globals.GetOrCreateElasticsearchConnection(ctx, resourceSelector, resource.Namespace, r.ElasticsearchConnectionsPool)
+	globals.UpdateClusterReachableCondition(&resource.Status.Conditions, err)
+	if err != nil {
+		logger.Error(err, "Failed to get or create OpenSearch connection")
+		if errors.Is(err, globals.ErrClusterNotReady) {
+			r.SetWaitingForCluster(ctx, resource, err)
+			return err
+		}
+		r.SetError(ctx, resource, fmt.Errorf("%w to OpenSearch: %w", globals.ErrConnectionFailed, err))
+		return err
+	}
+
+	// Writes are serialized with the other resources targeting the same cluster
+	unlock, err := globals.LockCluster(ctx, esConnection.Endpoint)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	logger.Info(fmt.Sprintf("OpenSearch connection established for cluster %s (type: %s, version: %s)", clusterKey, esConnection.ClusterType, esConnection.Version))
+
+	// Validate cluster type - the Anomaly Detection plugin is only available in OpenSearch
+	if esConnection.ClusterType == "elasticsearch" {
+		err := fmt.Errorf("anomaly detectors are only available in OpenSearch (Anomaly Detection plugin). Elasticsearch uses Machine Learning jobs instead")
+		logger.Error(err, "Incompatible cluster type for OpenSearchAnomalyDetector")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	// Detector IDs and states are updated in place so that detectors created before a failure
+	// are persisted by the error status update and never orphaned
+	if resource.Status.DetectorIDs == nil {
+		resource.Status.DetectorIDs = make(map[string]string)
+	}
+	if resource.Status.DetectorStates == nil {
+		resource.Status.DetectorStates = make(map[string]string)
+	}
+
+	// Step 2: Delete detectors that are no longer desired
+	for detectorName, detectorID := range resource.Status.DetectorIDs {
+		if _, desired := resource.Spec.Resources[detectorName]; desired {
+			continue
+		}
+		logger.Info(fmt.Sprintf("Detector %s is no longer desired, deleting from OpenSearch", detectorName))
+		if err := r.deleteDetector(ctx, esConnection.Client, detectorID); err != nil {
+			logger.Error(err, fmt.Sprintf("Failed to delete detector %s", detectorName))
+			r.SetError(ctx, resource, fmt.Errorf("failed to delete detector %s: %w", detectorName, err))
+			return err
+		}
+		delete(resource.Status.DetectorIDs, detectorName)
+		delete(resource.Status.DetectorStates, detectorName)
+		logger.Info(fmt.Sprintf("Detector %s deleted successfully", detectorName))
+		globals.RecordPruned(r.Recorder, resource, detectorName)
+	}
+
+	// Step 3: Create or update all desired detectors and reconcile their real-time job state
+	newAppliedDetectors := make([]string, 0, len(resource.Spec.Resources))
+	for detectorName, detectorResource := range resource.Spec.Resources {
+		logger.Info(fmt.Sprintf("Processing detector: %s", detectorName))
+
+		// Parse the desired detector from the resource
+		var desiredDetector map[string]interface{}
+		if err := json.Unmarshal(detectorResource.Definition.Raw, &desiredDetector); err != nil {
+			logger.Error(err, fmt.Sprintf("Failed to unmarshal detector %s", detectorName))
+			r.SetError(ctx, resource, fmt.Errorf("failed to unmarshal detector %s: %w", detectorName, err))
+			return err
+		}
+
+		// The resource key is the source of truth for the detector name
+		desiredDetector["name"] = detectorName
+
+		desiredState := detectorResource.State
+		if desiredState == "" {
+			desiredState = detectorStateStarted
+		}
+
+		detectorID, state, err := r.applyDetector(ctx, esConnection.Client, resource.Status.DetectorIDs[detectorName], desiredDetector, desiredState)
+		if detectorID != "" {
+			resource.Status.DetectorIDs[detectorName] = detectorID
+			resource.Status.DetectorStates[detectorName] = state
+		}
+		if err != nil {
+			logger.Error(err, fmt.Sprintf("Failed to apply detector %s", detectorName))
+			r.SetError(ctx, resource, fmt.Errorf("failed to apply detector %s: %w", detectorName, err))
+			return err
+		}
+		logger.Info(fmt.Sprintf("Detector %s applied successfully (id: %s, state: %s)", detectorName, detectorID, state))
+		newAppliedDetectors = append(newAppliedDetectors, detectorName)
+	}
+
+	// Step 4: Update the Status with the new list of applied detectors
+	targetCluster := fmt.Sprintf("%s/%s", resourceSelector.Namespace, resourceSelector.Name)
+	r.SetReady(ctx, resource, targetCluster, newAppliedDetectors)
+
+	logger.Info(fmt.Sprintf("OpenSearchAnomalyDetector %s/%s synced successfully", resource.Namespace, resource.Name))
+
+	return nil
+}
+
+// applyDetector creates or updates a detector and moves its real-time job to the desired state.
+// It returns the detector ID and the resulting job state. The ID is returned even on failure
+// once the detector exists, so the caller can persist it.
+// Running detectors can not be updated, so they are stopped before the update and started again afterwards.
+func (r *OpenSearchAnomalyDetectorReconciler) applyDetector(ctx context.Context, esClient *elasticsearch.Client, detectorID string, detector map[string]interface{}, desiredState string) (string, string, error) {
+	logger := log.FromContext(ctx)
+
+	// Fetch the current detector, if it was created before
+	var live *liveDetector
+	if detectorID != "" {
+		var err error
+		live, err = r.getDetector(ctx, esClient, detectorID)
+		if err != nil {
+			return detectorID, "", err
+		}
+		if live == nil {
+			logger.Info(fmt.Sprintf("Detector %s not found in OpenSearch, creating it again", detectorID))
+			detectorID = ""
+		}
+	}
+
+	running := live != nil && live.Job != nil && live.Job.Enabled
+
+	if live == nil {
+		// POST /_plugins/_anomaly_detection/detectors
+		var err error
+		detectorID, err = r.createDetector(ctx, esClient, detector)
+		if err != nil {
+			return "", "", err
+		}
+	} else if !globals.IsSubset(detector, live.Detector) {
+		// The definition drifted from the desired one: stop, update and restart if needed
+		if running {
+			if err := r.detectorAction(ctx, esClient, detectorID, "_stop"); err != nil {
+				return detectorID, detectorStateStarted, err
+			}
+			running = false
+		}
+
+		// PUT /_plugins/_anomaly_detection/detectors/{detector_id}
+		logger.Info(fmt.Sprintf("Updating detector %s in OpenSearch", detectorID))
+		if err := r.expectSuccess(r.performRequest(ctx, esClient, http.MethodPut, fmt.Sprintf("%s/%s", detectorsPath, detectorID), detector)); err != nil {
+			return detectorID, detectorStateStopped, fmt.Errorf("failed to update detector: %w", err)
+		}
+	}
+
+	// Reconcile the real-time job state
+	switch {
+	case desiredState == detectorStateStarted && !running:
+		if err := r.detectorAction(ctx, esClient, detectorID, "_start"); err != nil {
+			return detectorID, detectorStateStopped, err
+		}
+	case desiredState == detectorStateStopped && running:
+		if err := r.detectorAction(ctx, esClient, detectorID, "_stop"); err != nil {
+			return detectorID, detectorStateStarted, err
+		}
+	}
+
+	return detectorID, desiredState, nil
+}
+
+// createDetector creates a detector in OpenSearch and returns the assigned ID
+func (r *OpenSearchAnomalyDetectorReconciler) createDetector(ctx context.Context, esClient *elasticsearch.Client, detector map[string]interface{}) (string, error) {
+	logger := log.FromContext(ctx)
+
+	logger.Info("Creating detector in OpenSearch")
+	res, err := r.performRequest(ctx, esClient, http.MethodPost, detectorsPath, detector)
+	if err != nil {
+		return "", fmt.Errorf("failed to create detector: %w", err)
+	}
+	defer res.Body.Close()
+
+	bodyBytes, err := io.ReadAll(res.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if res.StatusCode >= 400 {
+		return "", fmt.Errorf("OpenSearch API error: %s - %s", res.Status, string(bodyBytes))
+	}
+
+	var created struct {
+		ID string `json:"_id"`
+	}
+	if err := json.Unmarshal(bodyBytes, &created); err != nil {
+		return "", fmt.Errorf("failed to parse detector creation response: %w", err)
+	}
+	if created.ID == "" {
+		return "", fmt.Errorf("detector creation response does not contain an ID: %s", string(bodyBytes))
+	}
+
+	return created.ID, nil
+}
+
+// getDetector returns the detector and its real-time job, or nil if it does not exist
+func (r *OpenSearchAnomalyDetectorReconciler) getDetector(ctx context.Context, esClient *elasticsearch.Client, detectorID string) (*liveDetector, error) {
+
+	// GET /_plugins/_anomaly_detection/detectors/{detector_id}?job=true
+	res, err := r.performRequest(ctx, esClient, http.MethodGet, fmt.Sprintf("%s/%s?job=true", detectorsPath, detectorID), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get detector: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+
+	bodyBytes, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if res.StatusCode >= 400 {
+		return nil, fmt.Errorf("OpenSearch API error: %s - %s", res.Status, string(bodyBytes))
+	}
+
+	live := &liveDetector{}
+	if err := json.Unmarshal(bodyBytes, live); err != nil {
+		return nil, fmt.Errorf("failed to parse detector: %w", err)
+	}
+
+	return live, nil
+}
+
+// detectorAction starts or stops the real-time job of a detector
+func (r *OpenSearchAnomalyDetectorReconciler) detectorAction(ctx context.Context, esClient *elasticsearch.Client, detectorID string, action string) error {
+	logger := log.FromContext(ctx)
+
+	logger.Info(fmt.Sprintf("Running %s on detector %s", action, detectorID))
+
+	// POST /_plugins/_anomaly_detection/detectors/{detector_id}/_start|_stop
+	if err := r.expectSuccess(r.performRequest(ctx, esClient, http.MethodPost, fmt.Sprintf("%s/%s/%s", detectorsPath, detectorID, action), nil)); err != nil {
+		return fmt.Errorf("failed to run %s on detector: %w", action, err)
+	}
+
+	return nil
+}
+
+// deleteDetector stops the real-time job of a detector (if running) and deletes it from OpenSearch
+func (r *OpenSearchAnomalyDetectorReconciler) deleteDetector(ctx context.Context, esClient *elasticsearch.Client, detectorID string) error {
+	logger := log.FromContext(ctx)
+
+	live, err := r.getDetector(ctx, esClient, detectorID)
+	if err != nil {
+		return err
+	}
+
+	// If the detector doesn't exist, consider it already deleted
+	if live == nil {
+		logger.Info(fmt.Sprintf("Detector %s not found in OpenSearch (already deleted)", detectorID))
+		return nil
+	}
+
+	// Running detectors can not be deleted
+	if live.Job != nil && live.Job.Enabled {
+		if err := r.detectorAction(ctx, esClient, detectorID, "_stop"); err != nil {
+			return err
+		}
+	}
+
+	logger.Info(fmt.Sprintf("Deleting detector %s from OpenSearch", detectorID))
+
+	// DELETE /_plugins/_anomaly_detection/detectors/{detector_id}
+	res, err := r.performRequest(ctx, esClient, http.MethodDelete, fmt.Sprintf("%s/%s", detectorsPath, detectorID), nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete detector: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		logger.Info(fmt.Sprintf("Detector %s not found in OpenSearch (already deleted)", detectorID))
+		return nil
+	}
+
+	if res.StatusCode >= 400 {
+		bodyBytes, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("OpenSearch API error: %s - %s", res.Status, string(bodyBytes))
+	}
+
+	return nil
+}
+
+// performRequest sends a raw request to the OpenSearch Anomaly Detection plugin API
+func (r *OpenSearchAnomalyDetectorReconciler) performRequest(ctx context.Context, esClient *elasticsearch.Client, method, path string, body map[string]interface{}) (*http.Response, error) {
+	var bodyReader io.Reader
+	if body != nil {
+		bodyJSON, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		bodyReader = bytes.NewReader(bodyJSON)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, path, bodyReader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	return esClient.Perform(req)
+}
+
+// expectSuccess closes the response and turns any error status into an error
+func (r *OpenSearchAnomalyDetectorReconciler) expectSuccess(res *http.Response, err error) error {
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= 400 {
+		bodyBytes, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("OpenSearch API error: %s - %s", res.Status, string(bodyBytes))
+	}
+
+	return nil
+}
@@ -0,0 +1,172 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package opensearchdashboardssavedobjects
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/controller"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/globals"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/pools"
+)
+
+// OpenSearchDashboardsSavedObjectsReconciler reconciles an OpenSearchDashboardsSavedObjects object
+type OpenSearchDashboardsSavedObjectsReconciler struct {
+	client.Client
+	Scheme                *runtime.Scheme
+	KibanaConnectionsPool *pools.KibanaConnectionsStore
+	Recorder              record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=elastic-config-operator.freepik.com,resources=opensearchdashboardssavedobjects,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=elastic-config-operator.freepik.com,resources=opensearchdashboardssavedobjects/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=elastic-config-operator.freepik.com,resources=opensearchdashboardssavedobjects/finalizers,verbs=update
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+
+// Reconcile writes the saved objects of an OpenSearchDashboardsSavedObjects to its OpenSearch Dashboards on every
+// syncInterval, and deletes them from the OpenSearch Dashboards once the resource is deleted, unless its deletionPolicy
+// retains them
+func (r *OpenSearchDashboardsSavedObjectsReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
+	logger := logf.FromContext(ctx)
+
+	// 1. Get the content of the resource
+	openSearchDashboardsSavedObjectsResource := &v1alpha1.OpenSearchDashboardsSavedObjects{}
+	err = r.Get(ctx, req.NamespacedName, openSearchDashboardsSavedObjectsResource)
+
+	// 2. Check existence on the cluster
+	if err != nil {
+
+		// 2.1 It does NOT exist: manage removal
+		if err = client.IgnoreNotFound(err); err == nil {
+			logger.Info(fmt.Sprintf(controller.ResourceNotFoundError, controller.OpenSearchDashboardsSavedObjectsResourceType, req.NamespacedName))
+			return result, err
+		}
+
+		// 2.2 Failed to get the resource, requeue the request
+		logger.Info(fmt.Sprintf(controller.ResourceSyncTimeRetrievalError, controller.OpenSearchDashboardsSavedObjectsResourceType, req.NamespacedName, err.Error()))
+		return result, err
+	}
+
+	// Suspended resources are not reconciled, even when deleted: the cluster and their status are left untouched until
+	// they are resumed
+	if openSearchDashboardsSavedObjectsResource.Spec.Suspend {
+		logger.Info(fmt.Sprintf(controller.ResourceSuspendedMessage, controller.OpenSearchDashboardsSavedObjectsResourceType, req.NamespacedName))
+		return result, nil
+	}
+
+	// 3. Check if the OpenSearchDashboardsSavedObjects instance is marked to be deleted
+	if !openSearchDashboardsSavedObjectsResource.DeletionTimestamp.IsZero() {
+		if controllerutil.ContainsFinalizer(openSearchDashboardsSavedObjectsResource, controller.ResourceFinalizer) {
+			original := openSearchDashboardsSavedObjectsResource.DeepCopy()
+
+			// 3.1 Delete the resources associated with the OpenSearchDashboardsSavedObjects, unless the deletion policy retains them
+			if openSearchDashboardsSavedObjectsResource.Spec.DeletionPolicy == v1alpha1.DeletionPolicyRetain {
+				logger.Info(fmt.Sprintf(controller.ResourceRetainedMessage, controller.OpenSearchDashboardsSavedObjectsResourceType, req.NamespacedName))
+			} else {
+				err = r.Sync(ctx, watch.Deleted, openSearchDashboardsSavedObjectsResource)
+			}
+
+			// Failed cleanups are retried with backoff, keeping the finalizer and reporting why in the status, until they
+			// are given up
+			if globals.RetryCleanup(openSearchDashboardsSavedObjectsResource, err) {
+				logger.Info(fmt.Sprintf(controller.ResourceCleanupError, controller.OpenSearchDashboardsSavedObjectsResourceType, req.NamespacedName, err.Error()))
+				if updateErr := globals.PatchStatus(ctx, r.Client, openSearchDashboardsSavedObjectsResource, original); updateErr != nil {
+					logger.Info(fmt.Sprintf(controller.ResourceConditionUpdateError, controller.OpenSearchDashboardsSavedObjectsResourceType, req.NamespacedName, updateErr.Error()))
+				}
+				return result, err
+			}
+			if err != nil {
+				logger.Info(fmt.Sprintf(controller.ResourceCleanupGivenUpMessage, controller.OpenSearchDashboardsSavedObjectsResourceType, req.NamespacedName, err.Error()))
+			}
+
+			// Remove the finalizers on OpenSearchDashboardsSavedObjects CR
+			err = globals.RemoveFinalizer(ctx, r.Client, openSearchDashboardsSavedObjectsResource, controller.ResourceFinalizer)
+			if err != nil {
+				logger.Info(fmt.Sprintf(controller.ResourceFinalizersUpdateError, controller.OpenSearchDashboardsSavedObjectsResourceType, req.NamespacedName, err.Error()))
+			}
+		}
+
+		result = ctrl.Result{}
+		err = nil
+		return result, err
+	}
+
+	// 4. Add finalizer to the OpenSearchDashboardsSavedObjects CR
+	if !controllerutil.ContainsFinalizer(openSearchDashboardsSavedObjectsResource, controller.ResourceFinalizer) {
+		err = globals.AddFinalizer(ctx, r.Client, openSearchDashboardsSavedObjectsResource, controller.ResourceFinalizer)
+		if err != nil {
+			return result, err
+		}
+	}
+
+	// 5. Update the status before the requeue, writing the changes made to it during the reconcile at once
+	original := openSearchDashboardsSavedObjectsResource.DeepCopy()
+	defer func() {
+		openSearchDashboardsSavedObjectsResource.Status.ObservedGeneration = openSearchDashboardsSavedObjectsResource.Generation
+		// The sync error is kept as the result, so failed syncs are requeued with backoff
+		if updateErr := globals.PatchStatus(ctx, r.Client, openSearchDashboardsSavedObjectsResource, original); updateErr != nil {
+			logger.Info(fmt.Sprintf(controller.ResourceConditionUpdateError, controller.OpenSearchDashboardsSavedObjectsResourceType, req.NamespacedName, updateErr.Error()))
+		}
+	}()
+
+	// 6. Schedule periodical request
+	RequeueTime, err := controller.RequeueInterval(openSearchDashboardsSavedObjectsResource.Spec.SyncInterval)
+	if err != nil {
+		logger.Info(fmt.Sprintf(controller.ResourceSyncTimeRetrievalError, controller.OpenSearchDashboardsSavedObjectsResourceType, req.NamespacedName, err.Error()))
+		return result, err
+	}
+	result = ctrl.Result{
+		RequeueAfter: RequeueTime,
+	}
+
+	// 7. Sync the saved objects
+	err = r.Sync(ctx, watch.Modified, openSearchDashboardsSavedObjectsResource)
+	if err != nil {
+		// Failed syncs are requeued with the error backoff instead of the syncInterval
+		result = ctrl.Result{}
+		r.UpdateConditionKubernetesApiCallFailure(openSearchDashboardsSavedObjectsResource)
+		logger.Info(fmt.Sprintf(controller.SyncTargetError, controller.OpenSearchDashboardsSavedObjectsResourceType, req.NamespacedName, err.Error()))
+		return result, err
+	}
+
+	// 8. Success, update the status
+	r.UpdateConditionSuccess(openSearchDashboardsSavedObjectsResource)
+
+	return result, err
+
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *OpenSearchDashboardsSavedObjectsReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.OpenSearchDashboardsSavedObjects{}).
+		WithEventFilter(predicate.GenerationChangedPredicate{}).
+		Named("opensearchdashboardssavedobjects").
+		WithOptions(globals.ControllerOptions()).
+		Complete(r)
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package opensearchdashboardssavedobjects
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/controller"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/globals"
+)
+
+// UpdateConditionSuccess updates the status of the OpenSearchDashboardsSavedObjects resource with a success condition
+func (r *OpenSearchDashboardsSavedObjectsReconciler) UpdateConditionSuccess(openSearchDashboardsSavedObjects *v1alpha1.OpenSearchDashboardsSavedObjects) {
+
+	// Create the new condition with the success status
+	condition := globals.NewCondition(globals.ConditionTypeResourceSynced, metav1.ConditionTrue,
+		globals.ConditionReasonTargetSynced, globals.ConditionReasonTargetSyncedMessage)
+
+	// Update the status of the OpenSearchDashboardsSavedObjects resource
+	globals.UpdateCondition(&openSearchDashboardsSavedObjects.Status.Conditions, condition)
+}
+
+// UpdateConditionKubernetesApiCallFailure updates the status of the OpenSearchDashboardsSavedObjects resource with a failure condition
+func (r *OpenSearchDashboardsSavedObjectsReconciler) UpdateConditionKubernetesApiCallFailure(openSearchDashboardsSavedObjects *v1alpha1.OpenSearchDashboardsSavedObjects) {
+
+	// Create the new condition with the failure status
+	condition := globals.NewCondition(globals.ConditionTypeResourceSynced, metav1.ConditionFalse,
+		globals.ConditionReasonKubernetesApiCallErrorType, globals.ConditionReasonKubernetesApiCallErrorMessage)
+
+	// Update the status of the OpenSearchDashboardsSavedObjects resource
+	globals.UpdateCondition(&openSearchDashboardsSavedObjects.Status.Conditions, condition)
+}
+
+// SetSyncing updates the status to Syncing phase
+func (r *OpenSearchDashboardsSavedObjectsReconciler) SetSyncing(ctx context.Context, resource *v1alpha1.OpenSearchDashboardsSavedObjects) {
+	if globals.IsPeriodicSync(resource.Status.Conditions, resource.Status.ObservedGeneration, resource.Generation) {
+		return
+	}
+	resource.Status.Phase = controller.PhaseSyncing
+	resource.Status.Message = "Synchronizing with OpenSearch Dashboards"
+	globals.SetSyncingConditions(&resource.Status.Conditions, resource.Status.ObservedGeneration, resource.Generation, resource.Status.Message)
+	resource.Status.ObservedGeneration = resource.Generation
+}
+
+// SetReady updates the status to Ready phase with applied resources
+func (r *OpenSearchDashboardsSavedObjectsReconciler) SetReady(ctx context.Context, resource *v1alpha1.OpenSearchDashboardsSavedObjects, targetDashboards string, appliedResources []string) {
+	now := metav1.Now()
+	resource.Status.Phase = controller.PhaseReady
+	resource.Status.Message = fmt.Sprintf("Successfully synced %d saved objects", len(appliedResources))
+	resource.Status.TargetDashboards = targetDashboards
+	resource.Status.AppliedResources = appliedResources
+	resource.Status.LastSyncTime = &now
+	globals.RecordApplied(r.Recorder, resource, resource.Status.Conditions, resource.Status.Message)
+	globals.SetReadyConditions(&resource.Status.Conditions, globals.ConditionReasonTargetSynced, resource.Status.Message)
+	resource.Status.ObservedGeneration = resource.Generation
+}
+
+// SetError updates the status to Error phase with error message
+func (r *OpenSearchDashboardsSavedObjectsReconciler) SetError(ctx context.Context, resource *v1alpha1.OpenSearchDashboardsSavedObjects, err error) {
+	resource.Status.Phase = controller.PhaseError
+	resource.Status.Message = err.Error()
+	globals.SetStalledConditions(&resource.Status.Conditions, globals.ConditionReasonSyncFailed, resource.Status.Message)
+	globals.RecordSyncError(r.Recorder, resource, err)
+	resource.Status.ObservedGeneration = resource.Generation
+}
@@ -0,0 +1,376 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package opensearchnotificationchannel
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/elastic/go-elasticsearch/v8"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/globals"
+)
+
+// Sync executes the synchronization of notification channels with OpenSearch
+func (r *OpenSearchNotificationChannelReconciler) Sync(ctx context.Context, eventType watch.EventType, resource *v1alpha1.OpenSearchNotificationChannel) (err error) {
+
+	logger := log.FromContext(ctx)
+
+	// The target cluster is resolved without changing the spec: the NamespaceDefaultCluster is used when the resource
+	// doesn't select one, and the namespace of the resource when the selector has none
+	resourceSelector, err := globals.ResolveResourceSelector(ctx, &resource.Spec.ResourceSelector, resource.Namespace)
+	if err != nil {
+		logger.Error(err, "Failed to resolve the target cluster")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	// Check the namespace of the resource is allowed to target the cluster
+	if err := globals.AuthorizeClusterAccess(ctx, resourceSelector, resource.Namespace, &resource.Status.Conditions); err != nil {
+		logger.Error(err, "Access to the target cluster denied")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	if err := globals.ValidateTLSVerification(resourceSelector, &resource.Status.Conditions); err != nil {
+		logger.Error(err, "TLS verification of the target cluster not configured")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	// Build the cluster key identifying the target cluster in the logs
+	clusterKey := fmt.Sprintf("%s_%s", resourceSelector.Namespace, resourceSelector.Name)
+
+	if eventType == watch.Deleted {
+		logger.Info(fmt.Sprintf("Deleting OpenSearchNotificationChannel %s/%s", resource.Namespace, resource.Name))
+
+		// Get OpenSearch connection to delete the channels
+		esConnection, err := globals.GetOrCreateElasticsearchConnection(ctx, resourceSelector, resource.Namespace, r.ElasticsearchConnectionsPool)
+		if err != nil {
+			logger.Error(err, "Failed to get OpenSearch connection for deletion")
+			return err
+		}
+
+		// Writes are serialized with the other resources targeting the same cluster
+		unlock, err := globals.LockCluster(ctx, esConnection.Endpoint)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+
+		// Delete each notification channel from OpenSearch
+		for configID := range resource.Spec.Resources {
+			logger.Info(fmt.Sprintf("Deleting notification channel %s from OpenSearch", configID))
+			if err := r.deleteChannel(ctx, esConnection.Client, configID); err != nil {
+				logger.Error(err, fmt.Sprintf("Failed to delete notification channel %s", configID))
+				return err
+			}
+			logger.Info(fmt.Sprintf("Notification channel %s deleted successfully", configID))
+		}
+
+		return nil
+	}
+
+	logger.Info(fmt.Sprintf("Syncing OpenSearchNotificationChannel %s/%s", resource.Namespace, resource.Name))
+
+	// Set status to Syncing at the beginning
+	r.SetSyncing(ctx, resource)
+
+	// Step 1: Get or create OpenSearch connection
+	esConnection, err := globals.GetOrCreateElasticsearchConnection(ctx, resourceSelector, resource.Namespace, r.ElasticsearchConnectionsPool)
+	globals.UpdateClusterReachableCondition(&resource.Status.Conditions, err)
+	if err != nil {
+		logger.Error(err, "Failed to get or create OpenSearch connection")
+		if errors.Is(err, globals.ErrClusterNotReady) {
+			r.SetWaitingForCluster(ctx, resource, err)
+			return err
+		}
+		r.SetError(ctx, resource, fmt.Errorf("%w to OpenSearch: %w", globals.ErrConnectionFailed, err))
+		return err
+	}
+
+	// Writes are serialized with the other resources targeting the same cluster
+	unlock, err := globals.LockCluster(ctx, esConnection.Endpoint)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	logger.Info(fmt.Sprintf("OpenSearch connection established for cluster %s (type: %s, version: %s)", clusterKey, esConnection.ClusterType, esConnection.Version))
+
+	// Validate cluster type - the Notifications plugin is only available in OpenSearch
+	if esConnection.ClusterType == "elasticsearch" {
+		err := fmt.Errorf("notification channels are only available in OpenSearch (Notifications plugin). Elasticsearch uses Kibana connectors instead")
+		logger.Error(err, "Incompatible cluster type for OpenSearchNotificationChannel")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	// Step 2: Get the list of channels currently applied (from Status)
+	appliedChannels := make(map[string]bool)
+	for _, configID := range resource.Status.AppliedResources {
+		appliedChannels[configID] = true
+	}
+
+	// Step 3: Delete channels that are no longer desired
+	for configID := range appliedChannels {
+		if _, desired := resource.Spec.Resources[configID]; !desired {
+			logger.Info(fmt.Sprintf("Notification channel %s is no longer desired, deleting from OpenSearch", configID))
+			if err := r.deleteChannel(ctx, esConnection.Client, configID); err != nil {
+				logger.Error(err, fmt.Sprintf("Failed to delete notification channel %s", configID))
+				r.SetError(ctx, resource, fmt.Errorf("failed to delete notification channel %s: %w", configID, err))
+				return err
+			}
+			logger.Info(fmt.Sprintf("Notification channel %s deleted successfully", configID))
+			globals.RecordPruned(r.Recorder, resource, configID)
+		}
+	}
+
+	// Step 4: Apply all desired channels (idempotent)
+	newAppliedChannels := make([]string, 0, len(resource.Spec.Resources))
+	for configID, channel := range resource.Spec.Resources {
+		logger.Info(fmt.Sprintf("Processing notification channel: %s", configID))
+
+		// Build the channel config, resolving credentials from Secrets
+		channelConfig, err := r.buildChannelConfig(ctx, resource.Namespace, configID, channel)
+		if err != nil {
+			logger.Error(err, fmt.Sprintf("Failed to build notification channel %s", configID))
+			r.SetError(ctx, resource, fmt.Errorf("failed to build notification channel %s: %w", configID, err))
+			return err
+		}
+
+		if err := r.applyChannel(ctx, esConnection.Client, configID, channelConfig); err != nil {
+			logger.Error(err, fmt.Sprintf("Failed to apply notification channel %s", configID))
+			r.SetError(ctx, resource, fmt.Errorf("failed to apply notification channel %s: %w", configID, err))
+			return err
+		}
+		logger.Info(fmt.Sprintf("Notification channel %s applied successfully", configID))
+		newAppliedChannels = append(newAppliedChannels, configID)
+	}
+
+	// Step 5: Update the Status with the new list of applied channels
+	targetCluster := fmt.Sprintf("%s/%s", resourceSelector.Namespace, resourceSelector.Name)
+	r.SetReady(ctx, resource, targetCluster, newAppliedChannels)
+
+	logger.Info(fmt.Sprintf("OpenSearchNotificationChannel %s/%s synced successfully", resource.Namespace, resource.Name))
+
+	return nil
+}
+
+// buildChannelConfig translates a NotificationChannel into the Notifications plugin config format
+func (r *OpenSearchNotificationChannelReconciler) buildChannelConfig(ctx context.Context, namespace, configID string, channel v1alpha1.NotificationChannel) (map[string]interface{}, error) {
+
+	name := channel.Name
+	if name == "" {
+		name = configID
+	}
+
+	enabled := true
+	if channel.Enabled != nil {
+		enabled = *channel.Enabled
+	}
+
+	config := map[string]interface{}{
+		"name":        name,
+		"description": channel.Description,
+		"config_type": channel.ConfigType,
+		"is_enabled":  enabled,
+	}
+
+	switch channel.ConfigType {
+	case "webhook":
+		if channel.Webhook == nil {
+			return nil, fmt.Errorf("webhook configuration is required when configType is webhook")
+		}
+		url, err := resolveValue(ctx, channel.Webhook.URL, channel.Webhook.URLSecretRef, namespace)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve webhook url: %w", err)
+		}
+
+		headers := make(map[string]string, len(channel.Webhook.HeaderParams)+len(channel.Webhook.HeaderSecretRefs))
+		for header, value := range channel.Webhook.HeaderParams {
+			headers[header] = value
+		}
+		for header, secretRef := range channel.Webhook.HeaderSecretRefs {
+			value, err := globals.GetSecretValue(ctx, &secretRef, namespace)
+			if err != nil {
+				return nil, fmt.Errorf("failed to resolve webhook header %s: %w", header, err)
+			}
+			headers[header] = value
+		}
+
+		method := channel.Webhook.Method
+		if method == "" {
+			method = http.MethodPost
+		}
+
+		config["webhook"] = map[string]interface{}{
+			"url":           url,
+			"method":        method,
+			"header_params": headers,
+		}
+
+	case "slack":
+		if channel.Slack == nil {
+			return nil, fmt.Errorf("slack configuration is required when configType is slack")
+		}
+		url, err := resolveValue(ctx, channel.Slack.URL, channel.Slack.URLSecretRef, namespace)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve slack url: %w", err)
+		}
+
+		config["slack"] = map[string]interface{}{
+			"url": url,
+		}
+
+	case "sns":
+		if channel.SNS == nil {
+			return nil, fmt.Errorf("sns configuration is required when configType is sns")
+		}
+
+		snsConfig := map[string]interface{}{
+			"topic_arn": channel.SNS.TopicARN,
+		}
+		if channel.SNS.RoleARN != "" {
+			snsConfig["role_arn"] = channel.SNS.RoleARN
+		}
+		config["sns"] = snsConfig
+
+	default:
+		return nil, fmt.Errorf("unsupported configType %s", channel.ConfigType)
+	}
+
+	return config, nil
+}
+
+// resolveValue returns the plain value when set, otherwise reads it from the referenced Secret
+func resolveValue(ctx context.Context, value string, secretRef *v1alpha1.SecretKeySelector, namespace string) (string, error) {
+	if value != "" {
+		return value, nil
+	}
+	if secretRef == nil {
+		return "", fmt.Errorf("either a plain value or a secret reference must be set")
+	}
+	return globals.GetSecretValue(ctx, secretRef, namespace)
+}
+
+// applyChannel creates or updates a notification channel in OpenSearch.
+// Channels are updated in place by config ID, and created with that same ID when missing
+func (r *OpenSearchNotificationChannelReconciler) applyChannel(ctx context.Context, esClient *elasticsearch.Client, configID string, config map[string]interface{}) error {
+	logger := log.FromContext(ctx)
+
+	// PUT /_plugins/_notifications/configs/{config_id}
+	updateJSON, err := json.Marshal(map[string]interface{}{
+		"config": config,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal channel: %w", err)
+	}
+
+	logger.Info(fmt.Sprintf("Applying notification channel %s to OpenSearch", configID))
+
+	res, err := r.performRequest(ctx, esClient, http.MethodPut, fmt.Sprintf("/_plugins/_notifications/configs/%s", configID), updateJSON)
+	if err != nil {
+		return fmt.Errorf("failed to update notification channel: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusNotFound {
+		if res.StatusCode >= 400 {
+			bodyBytes, _ := io.ReadAll(res.Body)
+			return fmt.Errorf("OpenSearch API error: %s - %s", res.Status, string(bodyBytes))
+		}
+		return nil
+	}
+
+	// POST /_plugins/_notifications/configs
+	logger.Info(fmt.Sprintf("Notification channel %s not found in OpenSearch, creating it", configID))
+	createJSON, err := json.Marshal(map[string]interface{}{
+		"config_id": configID,
+		"config":    config,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal channel: %w", err)
+	}
+
+	createRes, err := r.performRequest(ctx, esClient, http.MethodPost, "/_plugins/_notifications/configs", createJSON)
+	if err != nil {
+		return fmt.Errorf("failed to create notification channel: %w", err)
+	}
+	defer createRes.Body.Close()
+
+	if createRes.StatusCode >= 400 {
+		bodyBytes, _ := io.ReadAll(createRes.Body)
+		return fmt.Errorf("OpenSearch API error: %s - %s", createRes.Status, string(bodyBytes))
+	}
+
+	return nil
+}
+
+// deleteChannel deletes a notification channel from OpenSearch
+func (r *OpenSearchNotificationChannelReconciler) deleteChannel(ctx context.Context, esClient *elasticsearch.Client, configID string) error {
+	logger := log.FromContext(ctx)
+
+	logger.Info(fmt.Sprintf("Deleting notification channel %s from OpenSearch", configID))
+
+	// DELETE /_plugins/_notifications/configs/{config_id}
+	res, err := r.performRequest(ctx, esClient, http.MethodDelete, fmt.Sprintf("/_plugins/_notifications/configs/%s", configID), nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete notification channel: %w", err)
+	}
+	defer res.Body.Close()
+
+	// If the channel doesn't exist (404), consider it already deleted
+	if res.StatusCode == http.StatusNotFound {
+		logger.Info(fmt.Sprintf("Notification channel %s not found in OpenSearch (already deleted)", configID))
+		return nil
+	}
+
+	if res.StatusCode >= 400 {
+		bodyBytes, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("OpenSearch API error: %s - %s", res.Status, string(bodyBytes))
+	}
+
+	return nil
+}
+
+// performRequest sends a raw request to the OpenSearch Notifications plugin API
+func (r *OpenSearchNotificationChannelReconciler) performRequest(ctx context.Context, esClient *elasticsearch.Client, method, path string, body []byte) (*http.Response, error) {
+	var bodyReader io.Reader
+	if body != nil {
+		bodyReader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, path, bodyReader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	return esClient.Perform(req)
+}
@@ -0,0 +1,210 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queryruleset
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/elastic/go-elasticsearch/v8"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/globals"
+)
+
+// Sync executes the synchronization of query rulesets with Elasticsearch
+func (r *QueryRulesetReconciler) Sync(ctx context.Context, eventType watch.EventType, resource *v1alpha1.QueryRuleset) (err error) {
+
+	logger := log.FromContext(ctx)
+
+	// The target cluster is resolved without changing the spec: the NamespaceDefaultCluster is used when the resource
+	// doesn't select one, and the namespace of the resource when the selector has none
+	resourceSelector, err := globals.ResolveResourceSelector(ctx, &resource.Spec.ResourceSelector, resource.Namespace)
+	if err != nil {
+		logger.Error(err, "Failed to resolve the target cluster")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	// Check the namespace of the resource is allowed to target the cluster
+	if err := globals.AuthorizeClusterAccess(ctx, resourceSelector, resource.Namespace, &resource.Status.Conditions); err != nil {
+		logger.Error(err, "Access to the target cluster denied")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	if err := globals.ValidateTLSVerification(resourceSelector, &resource.Status.Conditions); err != nil {
+		logger.Error(err, "TLS verification of the target cluster not configured")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	// Build the cluster key identifying the target cluster in the logs
+	clusterKey := fmt.Sprintf("%s_%s", resourceSelector.Namespace, resourceSelector.Name)
+
+	if eventType == watch.Deleted {
+		logger.Info(fmt.Sprintf("Deleting QueryRuleset %s/%s", resource.Namespace, resource.Name))
+
+		// Get Elasticsearch connection to delete the rulesets
+		esConnection, err := globals.GetOrCreateElasticsearchConnection(ctx, resourceSelector, resource.Namespace, r.ElasticsearchConnectionsPool)
+		if err != nil {
+			logger.Error(err, "Failed to get Elasticsearch connection for deletion")
+			return err
+		}
+
+		// Writes are serialized with the other resources targeting the same cluster
+		unlock, err := globals.LockCluster(ctx, esConnection.Endpoint)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+
+		// Delete each query ruleset from Elasticsearch
+		for _, rulesetID := range resource.Status.AppliedResources {
+			logger.Info(fmt.Sprintf("Deleting query ruleset %s from Elasticsearch", rulesetID))
+			if err := r.deleteQueryRuleset(ctx, esConnection.Client, rulesetID); err != nil {
+				logger.Error(err, fmt.Sprintf("Failed to delete query ruleset %s", rulesetID))
+				return err
+			}
+			logger.Info(fmt.Sprintf("Query ruleset %s deleted successfully", rulesetID))
+		}
+
+		return nil
+	}
+
+	logger.Info(fmt.Sprintf("Syncing QueryRuleset %s/%s", resource.Namespace, resource.Name))
+
+	// Set status to Syncing at the beginning
+	r.SetSyncing(ctx, resource)
+
+	// Step 1: Get or create Elasticsearch connection
+	esConnection, err := globals.GetOrCreateElasticsearchConnection(ctx, resourceSelector, resource.Namespace, r.ElasticsearchConnectionsPool)
+	globals.UpdateClusterReachableCondition(&resource.Status.Conditions, err)
+	if err != nil {
+		logger.Error(err, "Failed to get or create Elasticsearch connection")
+		if errors.Is(err, globals.ErrClusterNotReady) {
+			r.SetWaitingForCluster(ctx, resource, err)
+			return err
+		}
+		r.SetError(ctx, resource, fmt.Errorf("%w to Elasticsearch: %w", globals.ErrConnectionFailed, err))
+		return err
+	}
+
+	// Writes are serialized with the other resources targeting the same cluster
+	unlock, err := globals.LockCluster(ctx, esConnection.Endpoint)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	logger.Info(fmt.Sprintf("Elasticsearch connection established for cluster %s (type: %s, version: %s)", clusterKey, esConnection.ClusterType, esConnection.Version))
+
+	// Validate cluster type - query rules are only available in Elasticsearch
+	if esConnection.ClusterType == "opensearch" {
+		err := fmt.Errorf("query rules are not available in OpenSearch")
+		logger.Error(err, "Incompatible cluster type for QueryRuleset")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	// Step 2: Delete rulesets that are no longer desired
+	for _, rulesetID := range resource.Status.AppliedResources {
+		if _, desired := resource.Spec.Resources[rulesetID]; !desired {
+			logger.Info(fmt.Sprintf("Query ruleset %s is no longer desired, deleting from Elasticsearch", rulesetID))
+			if err := r.deleteQueryRuleset(ctx, esConnection.Client, rulesetID); err != nil {
+				logger.Error(err, fmt.Sprintf("Failed to delete query ruleset %s", rulesetID))
+				r.SetError(ctx, resource, fmt.Errorf("failed to delete query ruleset %s: %w", rulesetID, err))
+				return err
+			}
+			logger.Info(fmt.Sprintf("Query ruleset %s deleted successfully", rulesetID))
+			globals.RecordPruned(r.Recorder, resource, rulesetID)
+		}
+	}
+
+	// Step 3: Apply all desired rulesets (PUT replaces the whole ruleset, so it is idempotent)
+	newAppliedRulesets := make([]string, 0, len(resource.Spec.Resources))
+	for rulesetID, rulesetResource := range resource.Spec.Resources {
+		logger.Info(fmt.Sprintf("Applying query ruleset %s", rulesetID))
+		if err := r.applyQueryRuleset(ctx, esConnection.Client, rulesetID, rulesetResource.Raw); err != nil {
+			logger.Error(err, fmt.Sprintf("Failed to apply query ruleset %s", rulesetID))
+			r.SetError(ctx, resource, fmt.Errorf("failed to apply query ruleset %s: %w", rulesetID, err))
+			return err
+		}
+		newAppliedRulesets = append(newAppliedRulesets, rulesetID)
+		logger.Info(fmt.Sprintf("Query ruleset %s applied successfully", rulesetID))
+	}
+
+	// Step 4: Update the Status with the new list of applied rulesets
+	targetCluster := fmt.Sprintf("%s/%s", resourceSelector.Namespace, resourceSelector.Name)
+	r.SetReady(ctx, resource, targetCluster, newAppliedRulesets)
+
+	logger.Info(fmt.Sprintf("QueryRuleset %s/%s synced successfully", resource.Namespace, resource.Name))
+
+	return nil
+}
+
+// applyQueryRuleset creates or replaces a query ruleset in Elasticsearch
+func (r *QueryRulesetReconciler) applyQueryRuleset(ctx context.Context, esClient *elasticsearch.Client, rulesetID string, ruleset []byte) error {
+	res, err := esClient.QueryRulesPutRuleset(
+		bytes.NewReader(ruleset),
+		rulesetID,
+		esClient.QueryRulesPutRuleset.WithContext(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to put query ruleset: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		bodyBytes, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("elasticsearch API error: %s - %s", res.Status(), string(bodyBytes))
+	}
+
+	return nil
+}
+
+// deleteQueryRuleset deletes a query ruleset from Elasticsearch
+func (r *QueryRulesetReconciler) deleteQueryRuleset(ctx context.Context, esClient *elasticsearch.Client, rulesetID string) error {
+	logger := log.FromContext(ctx)
+
+	res, err := esClient.QueryRulesDeleteRuleset(
+		rulesetID,
+		esClient.QueryRulesDeleteRuleset.WithContext(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete query ruleset: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		// If the ruleset doesn't exist (404), consider it already deleted
+		if res.StatusCode == http.StatusNotFound {
+			logger.Info(fmt.Sprintf("Query ruleset %s not found in Elasticsearch (already deleted)", rulesetID))
+			return nil
+		}
+		bodyBytes, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("elasticsearch API error: %s - %s", res.Status(), string(bodyBytes))
+	}
+
+	return nil
+}
@@ -0,0 +1,241 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package searchapplication
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/elastic/go-elasticsearch/v8"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/globals"
+)
+
+// Sync executes the synchronization of search applications with Elasticsearch
+func (r *SearchApplicationReconciler) Sync(ctx context.Context, eventType watch.EventType, resource *v1alpha1.SearchApplication) (err error) {
+
+	logger := log.FromContext(ctx)
+
+	// The target cluster is resolved without changing the spec: the NamespaceDefaultCluster is used when the resource
+	// doesn't select one, and the namespace of the resource when the selector has none
+	resourceSelector, err := globals.ResolveResourceSelector(ctx, &resource.Spec.ResourceSelector, resource.Namespace)
+	if err != nil {
+		logger.Error(err, "Failed to resolve the target cluster")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	// Check the namespace of the resource is allowed to target the cluster
+	if err := globals.AuthorizeClusterAccess(ctx, resourceSelector, resource.Namespace, &resource.Status.Conditions); err != nil {
+		logger.Error(err, "Access to the target cluster denied")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	if err := globals.ValidateTLSVerification(resourceSelector, &resource.Status.Conditions); err != nil {
+		logger.Error(err, "TLS verification of the target cluster not configured")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	// Build the cluster key identifying the target cluster in the logs
+	clusterKey := fmt.Sprintf("%s_%s", resourceSelector.Namespace, resourceSelector.Name)
+
+	if eventType == watch.Deleted {
+		logger.Info(fmt.Sprintf("Deleting SearchApplication %s/%s", resource.Namespace, resource.Name))
+
+		// Get Elasticsearch connection to delete the search applications
+		esConnection, err := globals.GetOrCreateElasticsearchConnection(ctx, resourceSelector, resource.Namespace, r.ElasticsearchConnectionsPool)
+		if err != nil {
+			logger.Error(err, "Failed to get Elasticsearch connection for deletion")
+			return err
+		}
+
+		// Writes are serialized with the other resources targeting the same cluster
+		unlock, err := globals.LockCluster(ctx, esConnection.Endpoint)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+
+		// Delete each search application from Elasticsearch
+		for _, applicationName := range resource.Status.AppliedResources {
+			logger.Info(fmt.Sprintf("Deleting search application %s from Elasticsearch", applicationName))
+			if err := r.deleteSearchApplication(ctx, esConnection.Client, applicationName); err != nil {
+				logger.Error(err, fmt.Sprintf("Failed to delete search application %s", applicationName))
+				return err
+			}
+			logger.Info(fmt.Sprintf("Search application %s deleted successfully", applicationName))
+		}
+
+		return nil
+	}
+
+	logger.Info(fmt.Sprintf("Syncing SearchApplication %s/%s", resource.Namespace, resource.Name))
+
+	// Set status to Syncing at the beginning
+	r.SetSyncing(ctx, resource)
+
+	// Step 1: Get or create Elasticsearch connection
+	esConnection, err := globals.GetOrCreateElasticsearchConnection(ctx, resourceSelector, resource.Namespace, r.ElasticsearchConnectionsPool)
+	globals.UpdateClusterReachableCondition(&resource.Status.Conditions, err)
+	if err != nil {
+		logger.Error(err, "Failed to get or create Elasticsearch connection")
+		if errors.Is(err, globals.ErrClusterNotReady) {
+			r.SetWaitingForCluster(ctx, resource, err)
+			return err
+		}
+		r.SetError(ctx, resource, fmt.Errorf("%w to Elasticsearch: %w", globals.ErrConnectionFailed, err))
+		return err
+	}
+
+	// Writes are serialized with the other resources targeting the same cluster
+	unlock, err := globals.LockCluster(ctx, esConnection.Endpoint)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	logger.Info(fmt.Sprintf("Elasticsearch connection established for cluster %s (type: %s, version: %s)", clusterKey, esConnection.ClusterType, esConnection.Version))
+
+	// Validate cluster type - search applications are only available in Elasticsearch
+	if esConnection.ClusterType == "opensearch" {
+		err := fmt.Errorf("search applications are not available in OpenSearch")
+		logger.Error(err, "Incompatible cluster type for SearchApplication")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	// Step 2: Delete search applications that are no longer desired
+	for _, applicationName := range resource.Status.AppliedResources {
+		if _, desired := resource.Spec.Resources[applicationName]; !desired {
+			logger.Info(fmt.Sprintf("Search application %s is no longer desired, deleting from Elasticsearch", applicationName))
+			if err := r.deleteSearchApplication(ctx, esConnection.Client, applicationName); err != nil {
+				logger.Error(err, fmt.Sprintf("Failed to delete search application %s", applicationName))
+				r.SetError(ctx, resource, fmt.Errorf("failed to delete search application %s: %w", applicationName, err))
+				return err
+			}
+			logger.Info(fmt.Sprintf("Search application %s deleted successfully", applicationName))
+			globals.RecordPruned(r.Recorder, resource, applicationName)
+		}
+	}
+
+	// Step 3: Apply all desired search applications (PUT replaces the whole application, so it is idempotent)
+	newAppliedApplications := make([]string, 0, len(resource.Spec.Resources))
+	for applicationName, applicationResource := range resource.Spec.Resources {
+		logger.Info(fmt.Sprintf("Applying search application %s", applicationName))
+		if err := r.applySearchApplication(ctx, esConnection.Client, applicationName, applicationResource); err != nil {
+			logger.Error(err, fmt.Sprintf("Failed to apply search application %s", applicationName))
+			r.SetError(ctx, resource, fmt.Errorf("failed to apply search application %s: %w", applicationName, err))
+			return err
+		}
+		newAppliedApplications = append(newAppliedApplications, applicationName)
+		logger.Info(fmt.Sprintf("Search application %s applied successfully", applicationName))
+	}
+
+	// Step 4: Update the Status with the new list of applied search applications
+	targetCluster := fmt.Sprintf("%s/%s", resourceSelector.Namespace, resourceSelector.Name)
+	r.SetReady(ctx, resource, targetCluster, newAppliedApplications)
+
+	logger.Info(fmt.Sprintf("SearchApplication %s/%s synced successfully", resource.Namespace, resource.Name))
+
+	return nil
+}
+
+// applySearchApplication creates or replaces a search application in Elasticsearch
+func (r *SearchApplicationReconciler) applySearchApplication(ctx context.Context, esClient *elasticsearch.Client, applicationName string, application v1alpha1.SearchApplicationDefinition) error {
+	body := map[string]interface{}{
+		"indices": application.Indices,
+	}
+	if application.AnalyticsCollectionName != "" {
+		body["analytics_collection_name"] = application.AnalyticsCollectionName
+	}
+	if application.Template != nil {
+		script := map[string]interface{}{
+			"source": application.Template.Source,
+		}
+		if len(application.Template.Params) > 0 {
+			script["params"] = application.Template.Params
+		}
+		if application.Template.Lang != "" {
+			script["lang"] = application.Template.Lang
+		}
+		template := map[string]interface{}{
+			"script": script,
+		}
+		if application.Template.Dictionary != nil {
+			template["dictionary"] = application.Template.Dictionary
+		}
+		body["template"] = template
+	}
+
+	applicationJSON, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal search application: %w", err)
+	}
+
+	res, err := esClient.SearchApplicationPut(
+		applicationName,
+		bytes.NewReader(applicationJSON),
+		esClient.SearchApplicationPut.WithContext(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to put search application: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		bodyBytes, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("elasticsearch API error: %s - %s", res.Status(), string(bodyBytes))
+	}
+
+	return nil
+}
+
+// deleteSearchApplication deletes a search application from Elasticsearch
+func (r *SearchApplicationReconciler) deleteSearchApplication(ctx context.Context, esClient *elasticsearch.Client, applicationName string) error {
+	logger := log.FromContext(ctx)
+
+	res, err := esClient.SearchApplicationDelete(
+		applicationName,
+		esClient.SearchApplicationDelete.WithContext(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete search application: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		// If the search application doesn't exist (404), consider it already deleted
+		if res.StatusCode == http.StatusNotFound {
+			logger.Info(fmt.Sprintf("Search application %s not found in Elasticsearch (already deleted)", applicationName))
+			return nil
+		}
+		bodyBytes, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("elasticsearch API error: %s - %s", res.Status(), string(bodyBytes))
+	}
+
+	return nil
+}
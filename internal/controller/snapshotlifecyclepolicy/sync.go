@@ -20,9 +20,13 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
+	"slices"
+	"strings"
 
 	"github.com/elastic/go-elasticsearch/v8"
 	"k8s.io/apimachinery/pkg/watch"
@@ -30,6 +34,7 @@ import (
 
 	//
 	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/controller"
 	"elastic-config-operator.freepik.com/elastic-config-operator/internal/globals"
 )
 
@@ -39,26 +44,66 @@ func (r *SnapshotLifecyclePolicyReconciler) Sync(ctx context.Context, eventType
 
 	logger := log.FromContext(ctx)
 
-	// Get the ECK cluster associated to the resource
-	if resource.Spec.ResourceSelector.Namespace == "" {
-		resource.Spec.ResourceSelector.Namespace = resource.Namespace
+	// Resources only report the changes they would make in dry run or audit mode
+	dryRun := globals.IsDryRun(resource.Spec.DryRun)
+
+	// The target cluster is resolved without changing the spec: the NamespaceDefaultCluster is used when the resource
+	// doesn't select one, and the namespace of the resource when the selector has none
+	resourceSelector, err := globals.ResolveResourceSelector(ctx, &resource.Spec.ResourceSelector, resource.Namespace)
+	if err != nil {
+		logger.Error(err, "Failed to resolve the target cluster")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	// Check the namespace of the resource is allowed to target the cluster
+	if err := globals.AuthorizeClusterAccess(ctx, resourceSelector, resource.Namespace, &resource.Status.Conditions); err != nil {
+		logger.Error(err, "Access to the target cluster denied")
+		r.SetError(ctx, resource, err)
+		return err
 	}
 
-	// Build the cluster key for the pools
-	clusterKey := fmt.Sprintf("%s_%s", resource.Spec.ResourceSelector.Namespace, resource.Spec.ResourceSelector.Name)
+	if err := globals.ValidateTLSVerification(resourceSelector, &resource.Status.Conditions); err != nil {
+		logger.Error(err, "TLS verification of the target cluster not configured")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	// Build the cluster key identifying the target cluster in the logs
+	clusterKey := fmt.Sprintf("%s_%s", resourceSelector.Namespace, resourceSelector.Name)
 
 	if eventType == watch.Deleted {
 		logger.Info(fmt.Sprintf("Deleting SnapshotLifecyclePolicy %s/%s", resource.Namespace, resource.Name))
 
+		globals.DeletePendingChanges(controller.SnapshotLifecyclePolicyResourceType, resource.Namespace, resource.Name)
+		marker := globals.ManagedByMarker(controller.SnapshotLifecyclePolicyResourceType, resource)
+
+		// Resources in dry run or audit mode never change Elasticsearch, not even when they are deleted
+		if dryRun {
+			logger.Info("Dry run enabled, leaving Elasticsearch unchanged")
+			return nil
+		}
+
 		// Get Elasticsearch connection to delete the policies
-		esConnection, err := globals.GetOrCreateElasticsearchConnection(ctx, clusterKey, &resource.Spec.ResourceSelector, resource.Namespace, r.ElasticsearchConnectionsPool)
+		esConnection, err := globals.GetOrCreateElasticsearchConnection(ctx, resourceSelector, resource.Namespace, r.ElasticsearchConnectionsPool)
 		if err != nil {
 			logger.Error(err, "Failed to get Elasticsearch connection for deletion")
 			return err
 		}
 
-		// Delete each snapshot lifecycle policy from Elasticsearch
+		// Writes are serialized with the other resources targeting the same cluster
+		unlock, err := globals.LockCluster(ctx, esConnection.Endpoint)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+
+		// Delete each snapshot lifecycle policy from Elasticsearch, but the ones another resource still declares
 		for policyName := range resource.Spec.Resources {
+			if globals.ClaimedByOthers(esConnection.Endpoint, globals.ClaimTypeSnapshotPolicy, marker, policyName) {
+				logger.Info(fmt.Sprintf("Snapshot lifecycle policy %s is declared by another resource, leaving it in Elasticsearch", policyName))
+				continue
+			}
 			logger.Info(fmt.Sprintf("Deleting snapshot lifecycle policy %s from Elasticsearch", policyName))
 			if err := r.deleteSnapshotLifecyclePolicy(ctx, esConnection.Client, policyName); err != nil {
 				logger.Error(err, fmt.Sprintf("Failed to delete snapshot lifecycle policy %s", policyName))
@@ -72,17 +117,77 @@ func (r *SnapshotLifecyclePolicyReconciler) Sync(ctx context.Context, eventType
 
 	logger.Info(fmt.Sprintf("Syncing SnapshotLifecyclePolicy %s/%s", resource.Namespace, resource.Name))
 
+	// Changes are postponed until the resources it depends on are Ready. Dry runs never change the cluster, so they
+	// are not gated
+	if !dryRun {
+		err = globals.CheckDependencies(ctx, resource.Spec.DependsOn, resource.Namespace)
+		globals.UpdateDependenciesReadyCondition(&resource.Status.Conditions, resource.Spec.DependsOn, err)
+		if err != nil {
+			logger.Error(err, "Failed to check the dependencies")
+			if errors.Is(err, globals.ErrDependenciesNotReady) {
+				r.SetWaitingForDependencies(ctx, resource, err)
+				return err
+			}
+			r.SetError(ctx, resource, err)
+			return err
+		}
+	}
+
+	// The ${key} references of the policies are replaced by the values of valuesFrom
+	policyResources, err := globals.ExpandValues(ctx, resource.Spec.ValuesFrom, resource.Namespace, resource.Spec.Resources)
+	if err != nil {
+		logger.Error(err, "Failed to expand the values of valuesFrom")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
 	// Set status to Syncing at the beginning
 	r.SetSyncing(ctx, resource)
 
 	// Step 1: Get or create Elasticsearch connection
-	esConnection, err := globals.GetOrCreateElasticsearchConnection(ctx, clusterKey, &resource.Spec.ResourceSelector, resource.Namespace, r.ElasticsearchConnectionsPool)
+	esConnection, err := globals.GetOrCreateElasticsearchConnection(ctx, resourceSelector, resource.Namespace, r.ElasticsearchConnectionsPool)
+	globals.UpdateClusterReachableCondition(&resource.Status.Conditions, err)
 	if err != nil {
 		logger.Error(err, "Failed to get or create Elasticsearch connection")
-		r.SetError(ctx, resource, fmt.Errorf("failed to connect to Elasticsearch: %w", err))
+		if errors.Is(err, globals.ErrClusterNotReady) {
+			r.SetWaitingForCluster(ctx, resource, err)
+			return err
+		}
+		r.SetError(ctx, resource, fmt.Errorf("%w to Elasticsearch: %w", globals.ErrConnectionFailed, err))
+		return err
+	}
+
+	// The Go templates of the policies are rendered with the cluster they are synced to
+	policyResources, err = globals.RenderTemplates(resource.Spec.Templating, policyResources, globals.NewTemplateData(resource, resourceSelector, esConnection))
+	if err != nil {
+		logger.Error(err, "Failed to render the templates of the policies")
+		r.SetError(ctx, resource, err)
 		return err
 	}
 
+	// Changes are postponed while the cluster is below the required health (e.g., while it is red). Dry runs never
+	// change the cluster, so they are not gated
+	if !dryRun {
+		err = globals.CheckClusterHealth(ctx, esConnection.Client, resource.Spec.RequiredClusterHealth)
+		globals.UpdateClusterHealthyCondition(&resource.Status.Conditions, resource.Spec.RequiredClusterHealth, err)
+		if err != nil {
+			logger.Error(err, "Failed to check the cluster health")
+			if errors.Is(err, globals.ErrClusterUnhealthy) {
+				r.SetWaitingForClusterHealth(ctx, resource, err)
+				return err
+			}
+			r.SetError(ctx, resource, err)
+			return err
+		}
+	}
+
+	// Writes are serialized with the other resources targeting the same cluster
+	unlock, err := globals.LockCluster(ctx, esConnection.Endpoint)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	logger.Info(fmt.Sprintf("Elasticsearch connection established for cluster %s", clusterKey))
 
 	// Step 2: Get the list of policies currently applied (from Status)
@@ -93,55 +198,154 @@ func (r *SnapshotLifecyclePolicyReconciler) Sync(ctx context.Context, eventType
 
 	// Step 3: Get the list of desired policies (from Spec)
 	desiredPolicies := make(map[string]bool)
-	for policyName := range resource.Spec.Resources {
+	for policyName := range policyResources {
 		desiredPolicies[policyName] = true
 	}
 
+	// Changes made to Elasticsearch by this synchronization, reported in events and in the status
+	var changes []v1alpha1.ObjectChange
+
+	// A failing policy does not stop the synchronization of the other ones, its error is reported once all of
+	// them are synced
+	failed := make(map[string]error)
+	newAppliedPolicies := make([]string, 0, len(policyResources))
+	newResources := make(map[string]v1alpha1.ResourceStatus, len(policyResources))
+
+	// Resources taking their namespace over its quota of objects on the cluster are rejected before changing
+	// anything, and the policies declared by an older resource for the same cluster too are left to it, so the
+	// resources do not overwrite each other on every sync
+	marker := globals.ManagedByMarker(controller.SnapshotLifecyclePolicyResourceType, resource)
+	if err := globals.CheckNamespaceQuota(esConnection.Endpoint, marker, resource, len(policyResources), dryRun); err != nil {
+		logger.Error(err, "Snapshot lifecycle policies rejected")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+	conflicts := globals.ClaimObjects(esConnection.Endpoint, globals.ClaimTypeSnapshotPolicy, marker, resource,
+		slices.Collect(maps.Keys(desiredPolicies)), dryRun)
+
 	// Step 4: Delete policies that are no longer desired
 	for policyName := range appliedPolicies {
 		if !desiredPolicies[policyName] {
+			if globals.ClaimedByOthers(esConnection.Endpoint, globals.ClaimTypeSnapshotPolicy, marker, policyName) {
+				logger.Info(fmt.Sprintf("Policy %s is no longer desired but declared by another resource, leaving it in Elasticsearch", policyName))
+				continue
+			}
 			logger.Info(fmt.Sprintf("Policy %s is no longer desired, deleting from Elasticsearch", policyName))
+			change := v1alpha1.ObjectChange{Name: policyName, Action: globals.ChangeActionDelete}
+			globals.RecordChange(r.Recorder, resource, change, dryRun)
+			changes = append(changes, change)
+			if dryRun {
+				continue
+			}
 			if err := r.deleteSnapshotLifecyclePolicy(ctx, esConnection.Client, policyName); err != nil {
 				logger.Error(err, fmt.Sprintf("Failed to delete snapshot lifecycle policy %s", policyName))
-				return err
+				failed[policyName] = fmt.Errorf("failed to delete: %w", err)
+				continue
 			}
 			logger.Info(fmt.Sprintf("Snapshot lifecycle policy %s deleted successfully", policyName))
+			globals.RecordPruned(r.Recorder, resource, policyName)
 		}
 	}
 
-	// Step 5: Apply all desired policies (idempotent)
-	newAppliedPolicies := make([]string, 0, len(resource.Spec.Resources))
-	for policyName, policyResource := range resource.Spec.Resources {
+	// Step 5: Apply the desired policies that changed in the spec or in Elasticsearch since they were last applied,
+	// detecting the out-of-band changes of the policies whose desired body did not change
+	drifted := make(map[string][]string)
+	for policyName, policyResource := range policyResources {
 		logger.Info(fmt.Sprintf("Processing snapshot lifecycle policy: %s", policyName))
 
+		if claimErr := conflicts[policyName]; claimErr != nil {
+			logger.Info(fmt.Sprintf("Snapshot lifecycle policy %s left untouched: %s", policyName, claimErr))
+			failed[policyName] = claimErr
+			continue
+		}
+
 		// Parse the desired policy from the resource
 		var desiredPolicy map[string]interface{}
 		policyJSON, err := policyResource.MarshalJSON()
 		if err != nil {
 			logger.Error(err, fmt.Sprintf("Failed to marshal policy %s", policyName))
-			return err
+			failed[policyName] = err
+			continue
 		}
 		if err := json.Unmarshal(policyJSON, &desiredPolicy); err != nil {
 			logger.Error(err, fmt.Sprintf("Failed to unmarshal policy %s", policyName))
-			return err
+			failed[policyName] = err
+			continue
+		}
+
+		livePolicy, exists, err := r.getSnapshotLifecyclePolicy(ctx, esConnection.Client, policyName)
+		if err != nil {
+			logger.Error(err, fmt.Sprintf("Failed to get snapshot lifecycle policy %s", policyName))
+			failed[policyName] = err
+			continue
+		}
+		desiredHash := globals.HashJSON(desiredPolicy)
+		if resource.Status.Resources[policyName].Hash == desiredHash {
+			paths := globals.DetectDrift(desiredPolicy, livePolicy, exists)
+			if len(paths) == 0 {
+				logger.Info(fmt.Sprintf("Snapshot lifecycle policy %s is up to date, skipping", policyName))
+				newAppliedPolicies = append(newAppliedPolicies, policyName)
+				newResources[policyName] = resource.Status.Resources[policyName]
+				continue
+			}
+			logger.Info(fmt.Sprintf("Snapshot lifecycle policy %s was changed out of band: %s", policyName, strings.Join(paths, ", ")))
+			drifted[policyName] = paths
+		}
+
+		change := globals.NewObjectChange(policyName, desiredPolicy, livePolicy, exists)
+		globals.RecordChange(r.Recorder, resource, change, dryRun)
+		changes = append(changes, change)
+		if dryRun {
+			continue
 		}
 
 		// Apply the policy (PutLifecycle is idempotent - creates or updates)
 		if err := r.applySnapshotLifecyclePolicy(ctx, esConnection.Client, policyName, desiredPolicy); err != nil {
 			logger.Error(err, fmt.Sprintf("Failed to apply snapshot lifecycle policy %s", policyName))
-			return err
+			failed[policyName] = err
+			continue
 		}
 		logger.Info(fmt.Sprintf("Snapshot lifecycle policy %s applied successfully", policyName))
 		newAppliedPolicies = append(newAppliedPolicies, policyName)
+		newResources[policyName] = globals.AppliedResourceStatus(desiredHash)
 	}
 
-	// Step 6: Update the Status with the new list of applied policies
-	targetCluster := fmt.Sprintf("%s/%s", resource.Spec.ResourceSelector.Namespace, resource.Spec.ResourceSelector.Name)
-	if err := r.SetReady(ctx, resource, targetCluster, newAppliedPolicies); err != nil {
-		logger.Error(err, "Failed to update SnapshotLifecyclePolicy status")
-		return err
+	// Keep tracking the failed policies applied by a previous synchronization, so they are deleted once removed from the
+	// spec, and report their error in their status, without their hash, so they are applied again
+	for policyName, err := range failed {
+		newResources[policyName] = globals.FailedResourceStatus(resource.Status.Resources[policyName], err)
+		if appliedPolicies[policyName] {
+			newAppliedPolicies = append(newAppliedPolicies, policyName)
+		}
+	}
+	failedErr := globals.ResourceErrors(failed)
+
+	globals.RecordDrift(r.Recorder, resource, &resource.Status.Conditions, drifted, dryRun)
+	globals.RecordConflicts(r.Recorder, resource, &resource.Status.Conditions, conflicts)
+
+	targetCluster := fmt.Sprintf("%s/%s", resourceSelector.Namespace, resourceSelector.Name)
+
+	// Resources in dry run or audit mode only report the changes the synchronization would make
+	if dryRun {
+		logger.Info(fmt.Sprintf("Dry run of SnapshotLifecyclePolicy %s/%s found %d pending changes", resource.Namespace, resource.Name, len(changes)))
+		r.SetDryRun(ctx, resource, targetCluster, changes, failedErr)
+		return failedErr
 	}
 
+	// Keep the changes of the last synchronization that changed anything
+	if len(changes) > 0 {
+		resource.Status.Changes = changes
+	}
+
+	if failedErr != nil {
+		logger.Error(failedErr, "Failed to sync some policies")
+		r.SetDegraded(ctx, resource, targetCluster, newAppliedPolicies, newResources, failedErr)
+		return failedErr
+	}
+
+	// Step 6: Update the Status with the new list of applied policies
+	r.SetReady(ctx, resource, targetCluster, newAppliedPolicies, newResources)
+
 	logger.Info(fmt.Sprintf("SnapshotLifecyclePolicy %s/%s synced successfully", resource.Namespace, resource.Name))
 
 	return nil
@@ -178,6 +382,40 @@ func (r *SnapshotLifecyclePolicyReconciler) applySnapshotLifecyclePolicy(ctx con
 	return nil
 }
 
+// getSnapshotLifecyclePolicy returns the snapshot lifecycle policy stored in Elasticsearch, and whether it exists
+func (r *SnapshotLifecyclePolicyReconciler) getSnapshotLifecyclePolicy(ctx context.Context, esClient *elasticsearch.Client, policyName string) (map[string]interface{}, bool, error) {
+	res, err := esClient.SlmGetLifecycle(
+		esClient.SlmGetLifecycle.WithPolicyID(policyName),
+		esClient.SlmGetLifecycle.WithContext(ctx),
+	)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get snapshot lifecycle policy: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, false, nil
+	}
+
+	bodyBytes, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if res.IsError() {
+		return nil, false, fmt.Errorf("elasticsearch API error: %s - %s", res.Status(), string(bodyBytes))
+	}
+
+	var policies map[string]struct {
+		Policy map[string]interface{} `json:"policy"`
+	}
+	if err := json.Unmarshal(bodyBytes, &policies); err != nil {
+		return nil, false, fmt.Errorf("failed to parse snapshot lifecycle policy: %w", err)
+	}
+
+	policy, exists := policies[policyName]
+	return policy.Policy, exists, nil
+}
+
 // deleteSnapshotLifecyclePolicy deletes a snapshot lifecycle policy from Elasticsearch
 func (r *SnapshotLifecyclePolicyReconciler) deleteSnapshotLifecyclePolicy(ctx context.Context, esClient *elasticsearch.Client, policyName string) error {
 	logger := log.FromContext(ctx)
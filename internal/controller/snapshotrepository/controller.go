@@ -19,11 +19,12 @@ package snapshotrepository
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -31,6 +32,7 @@ import (
 
 	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
 	"elastic-config-operator.freepik.com/elastic-config-operator/internal/controller"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/globals"
 	"elastic-config-operator.freepik.com/elastic-config-operator/internal/pools"
 )
 
@@ -39,6 +41,7 @@ type SnapshotRepositoryReconciler struct {
 	client.Client
 	Scheme                       *runtime.Scheme
 	ElasticsearchConnectionsPool *pools.ElasticsearchConnectionsStore
+	Recorder                     record.EventRecorder
 }
 
 // +kubebuilder:rbac:groups=elastic-config-operator.freepik.com,resources=snapshotrepositories,verbs=get;list;watch;create;update;patch;delete
@@ -47,19 +50,12 @@ type SnapshotRepositoryReconciler struct {
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
 // +kubebuilder:rbac:groups=elasticsearch.k8s.elastic.co,resources=elasticsearches,verbs=get;list;watch
 
-// Reconcile is part of the main kubernetes reconciliation loop which aims to
-// move the current state of the cluster closer to the desired state.
-// TODO(user): Modify the Reconcile function to compare the state specified by
-// the SnapshotRepository object against the actual cluster state, and then
-// perform operations to make the cluster state reflect the state specified by
-// the user.
-//
-// For more details, check Reconcile and its Result here:
-// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.22.4/pkg/reconcile
+// Reconcile writes the snapshot repositories of a SnapshotRepository to its cluster on every syncInterval, and deletes
+// them from the cluster once the resource is deleted, unless its deletionPolicy retains them
 func (r *SnapshotRepositoryReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
 	logger := logf.FromContext(ctx)
 
-	// 1. Get the content of the Patch
+	// 1. Get the content of the resource
 	snapshotRepositoryResource := &v1alpha1.SnapshotRepository{}
 	err = r.Get(ctx, req.NamespacedName, snapshotRepositoryResource)
 
@@ -77,16 +73,48 @@ func (r *SnapshotRepositoryReconciler) Reconcile(ctx context.Context, req ctrl.R
 		return result, err
 	}
 
+	// Suspended resources are not reconciled, even when deleted: the cluster and their status are left untouched until
+	// they are resumed
+	if snapshotRepositoryResource.Spec.Suspend {
+		logger.Info(fmt.Sprintf(controller.ResourceSuspendedMessage, controller.SnapshotRepositoryResourceType, req.NamespacedName))
+		return result, nil
+	}
+
+	// Resources selecting their clusters by labels are synced through the resources created for every cluster
+	if len(snapshotRepositoryResource.Spec.ResourceSelector.MatchLabels) > 0 {
+		logger.Info(fmt.Sprintf(controller.ResourceSelectsClustersByLabelsMessage, controller.SnapshotRepositoryResourceType, req.NamespacedName))
+		return result, nil
+	}
+
 	// 3. Check if the SnapshotRepository instance is marked to be deleted: indicated by the deletion timestamp being set
 	if !snapshotRepositoryResource.DeletionTimestamp.IsZero() {
 		if controllerutil.ContainsFinalizer(snapshotRepositoryResource, controller.ResourceFinalizer) {
+			original := snapshotRepositoryResource.DeepCopy()
+
+			// 3.1 Delete the resources associated with the SnapshotRepository, unless the deletion policy retains them
+			if snapshotRepositoryResource.Spec.DeletionPolicy == v1alpha1.DeletionPolicyRetain {
+				logger.Info(fmt.Sprintf(controller.ResourceRetainedMessage, controller.SnapshotRepositoryResourceType, req.NamespacedName))
+			} else if globals.TargetClusterGone(ctx, &snapshotRepositoryResource.Spec.ResourceSelector, snapshotRepositoryResource.Namespace) {
+				logger.Info(fmt.Sprintf(controller.ResourceTargetGoneMessage, controller.SnapshotRepositoryResourceType, req.NamespacedName))
+			} else {
+				err = r.Sync(ctx, watch.Deleted, snapshotRepositoryResource)
+			}
 
-			// 3.1 Delete the resources associated with the SnapshotRepository
-			err = r.Sync(ctx, watch.Deleted, snapshotRepositoryResource)
+			// Failed cleanups are retried with backoff, keeping the finalizer and reporting why in the status, until they
+			// are given up
+			if globals.RetryCleanup(snapshotRepositoryResource, err) {
+				logger.Info(fmt.Sprintf(controller.ResourceCleanupError, controller.SnapshotRepositoryResourceType, req.NamespacedName, err.Error()))
+				if updateErr := globals.PatchStatus(ctx, r.Client, snapshotRepositoryResource, original); updateErr != nil {
+					logger.Info(fmt.Sprintf(controller.ResourceConditionUpdateError, controller.SnapshotRepositoryResourceType, req.NamespacedName, updateErr.Error()))
+				}
+				return result, err
+			}
+			if err != nil {
+				logger.Info(fmt.Sprintf(controller.ResourceCleanupGivenUpMessage, controller.SnapshotRepositoryResourceType, req.NamespacedName, err.Error()))
+			}
 
-			// Remove the finalizers on Patch CR
-			controllerutil.RemoveFinalizer(snapshotRepositoryResource, controller.ResourceFinalizer)
-			err = r.Update(ctx, snapshotRepositoryResource)
+			// Remove the finalizers on SnapshotRepository CR
+			err = globals.RemoveFinalizer(ctx, r.Client, snapshotRepositoryResource, controller.ResourceFinalizer)
 			if err != nil {
 				logger.Info(fmt.Sprintf(controller.ResourceFinalizersUpdateError, controller.SnapshotRepositoryResourceType, req.NamespacedName, err.Error()))
 			}
@@ -99,27 +127,24 @@ func (r *SnapshotRepositoryReconciler) Reconcile(ctx context.Context, req ctrl.R
 
 	// 4. Add finalizer to the SnapshotRepository CR
 	if !controllerutil.ContainsFinalizer(snapshotRepositoryResource, controller.ResourceFinalizer) {
-		controllerutil.AddFinalizer(snapshotRepositoryResource, controller.ResourceFinalizer)
-		err = r.Update(ctx, snapshotRepositoryResource)
+		err = globals.AddFinalizer(ctx, r.Client, snapshotRepositoryResource, controller.ResourceFinalizer)
 		if err != nil {
 			return result, err
 		}
 	}
 
-	// 5. Update the status before the requeue
+	// 5. Update the status before the requeue, writing the changes made to it during the reconcile at once
+	original := snapshotRepositoryResource.DeepCopy()
 	defer func() {
-		err = r.Status().Update(ctx, snapshotRepositoryResource)
-		if err != nil {
-			logger.Info(fmt.Sprintf(controller.ResourceConditionUpdateError, controller.SnapshotRepositoryResourceType, req.NamespacedName, err.Error()))
+		snapshotRepositoryResource.Status.ObservedGeneration = snapshotRepositoryResource.Generation
+		// The sync error is kept as the result, so failed syncs are requeued with backoff
+		if updateErr := globals.PatchStatus(ctx, r.Client, snapshotRepositoryResource, original); updateErr != nil {
+			logger.Info(fmt.Sprintf(controller.ResourceConditionUpdateError, controller.SnapshotRepositoryResourceType, req.NamespacedName, updateErr.Error()))
 		}
 	}()
 
 	// 6. Schedule periodical request
-	syncInterval := snapshotRepositoryResource.Spec.SyncInterval
-	if syncInterval == "" {
-		syncInterval = controller.DefaultSyncInterval
-	}
-	RequeueTime, err := time.ParseDuration(syncInterval)
+	RequeueTime, err := controller.RequeueInterval(snapshotRepositoryResource.Spec.SyncInterval)
 	if err != nil {
 		logger.Info(fmt.Sprintf(controller.ResourceSyncTimeRetrievalError, controller.SnapshotRepositoryResourceType, req.NamespacedName, err.Error()))
 		return result, err
@@ -128,9 +153,11 @@ func (r *SnapshotRepositoryReconciler) Reconcile(ctx context.Context, req ctrl.R
 		RequeueAfter: RequeueTime,
 	}
 
-	// 7. Check the rule
+	// 7. Sync the objects of the resource
 	err = r.Sync(ctx, watch.Modified, snapshotRepositoryResource)
 	if err != nil {
+		// Failed syncs are requeued with the error backoff instead of the syncInterval
+		result = ctrl.Result{}
 		r.UpdateConditionKubernetesApiCallFailure(snapshotRepositoryResource)
 		logger.Info(fmt.Sprintf(controller.SyncTargetError, controller.SnapshotRepositoryResourceType, req.NamespacedName, err.Error()))
 		return result, err
@@ -142,11 +169,25 @@ func (r *SnapshotRepositoryReconciler) Reconcile(ctx context.Context, req ctrl.R
 	return result, err
 }
 
-// SetupWithManager sets up the controller with the Manager.
+// SetupWithManager sets up the controller with the Manager. The ECK clusters and the Secrets referenced by the
+// resources are watched too, so the resources are synced as soon as their cluster becomes ready or their Secrets change
 func (r *SnapshotRepositoryReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&v1alpha1.SnapshotRepository{}).
+	controllerBuilder := ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.SnapshotRepository{}, builder.WithPredicates(predicate.GenerationChangedPredicate{}))
+
+	controllerBuilder = globals.WatchECKClusters(controllerBuilder, mgr, &v1alpha1.SnapshotRepositoryList{}, func(object, cluster client.Object) bool {
+		return globals.SelectsECKCluster(&object.(*v1alpha1.SnapshotRepository).Spec.ResourceSelector, object.GetNamespace(), cluster)
+	})
+
+	controllerBuilder, err := globals.WatchReferencedSecrets(controllerBuilder, mgr, &v1alpha1.SnapshotRepository{}, &v1alpha1.SnapshotRepositoryList{}, func(object client.Object) []*v1alpha1.ResourceSelector {
+		return []*v1alpha1.ResourceSelector{&object.(*v1alpha1.SnapshotRepository).Spec.ResourceSelector}
+	})
+	if err != nil {
+		return err
+	}
+
+	return controllerBuilder.
 		Named("snapshotrepository").
-		WithEventFilter(predicate.GenerationChangedPredicate{}).
+		WithOptions(globals.ControllerOptions()).
 		Complete(r)
 }
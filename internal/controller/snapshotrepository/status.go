@@ -21,7 +21,6 @@ import (
 	"fmt"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	//
 	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
@@ -29,52 +28,82 @@ import (
 	"elastic-config-operator.freepik.com/elastic-config-operator/internal/globals"
 )
 
-// UpdateConditionSuccess updates the status of the SearchRule resource with a success condition
+// UpdateConditionSuccess updates the status of the SnapshotRepository resource with a success condition
 func (r *SnapshotRepositoryReconciler) UpdateConditionSuccess(SnapshotRepository *v1alpha1.SnapshotRepository) {
 
 	// Create the new condition with the success status
 	condition := globals.NewCondition(globals.ConditionTypeResourceSynced, metav1.ConditionTrue,
 		globals.ConditionReasonTargetSynced, globals.ConditionReasonTargetSyncedMessage)
 
-	// Update the status of the SearchRule resource
+	// Update the status of the SnapshotRepository resource
 	globals.UpdateCondition(&SnapshotRepository.Status.Conditions, condition)
 }
 
-// UpdateConditionKubernetesApiCallFailure updates the status of the SearchRule resource with a failure condition
+// UpdateConditionKubernetesApiCallFailure updates the status of the SnapshotRepository resource with a failure condition
 func (r *SnapshotRepositoryReconciler) UpdateConditionKubernetesApiCallFailure(SnapshotRepository *v1alpha1.SnapshotRepository) {
 
 	// Create the new condition with the failure status
-	condition := globals.NewCondition(globals.ConditionTypeResourceSynced, metav1.ConditionTrue,
+	condition := globals.NewCondition(globals.ConditionTypeResourceSynced, metav1.ConditionFalse,
 		globals.ConditionReasonKubernetesApiCallErrorType, globals.ConditionReasonKubernetesApiCallErrorMessage)
 
-	// Update the status of the SearchRule resource
+	// Update the status of the SnapshotRepository resource
 	globals.UpdateCondition(&SnapshotRepository.Status.Conditions, condition)
 }
 
 // SetSyncing updates the status to Syncing phase
 func (r *SnapshotRepositoryReconciler) SetSyncing(ctx context.Context, resource *v1alpha1.SnapshotRepository) {
-	logger := log.FromContext(ctx)
+	if globals.IsPeriodicSync(resource.Status.Conditions, resource.Status.ObservedGeneration, resource.Generation) {
+		return
+	}
 	resource.Status.Phase = controller.PhaseSyncing
 	resource.Status.Message = "Synchronizing with Elasticsearch"
-	if err := r.Status().Update(ctx, resource); err != nil {
-		logger.Error(err, "Failed to update status to Syncing")
-	}
+	globals.SetSyncingConditions(&resource.Status.Conditions, resource.Status.ObservedGeneration, resource.Generation, resource.Status.Message)
+	resource.Status.ObservedGeneration = resource.Generation
 }
 
 // SetReady updates the status to Ready phase with applied resources
-func (r *SnapshotRepositoryReconciler) SetReady(ctx context.Context, resource *v1alpha1.SnapshotRepository, targetCluster string, appliedResources []string) error {
+func (r *SnapshotRepositoryReconciler) SetReady(ctx context.Context, resource *v1alpha1.SnapshotRepository, targetCluster string, appliedResources []string) {
 	now := metav1.Now()
 	resource.Status.Phase = controller.PhaseReady
 	resource.Status.Message = fmt.Sprintf("Successfully synced %d repositories", len(appliedResources))
 	resource.Status.TargetCluster = targetCluster
 	resource.Status.AppliedResources = appliedResources
 	resource.Status.LastSyncTime = &now
-	return r.Status().Update(ctx, resource)
+	globals.RecordApplied(r.Recorder, resource, resource.Status.Conditions, resource.Status.Message)
+	globals.UpdateClusterBlockedCondition(&resource.Status.Conditions, nil)
+	globals.SetReadyConditions(&resource.Status.Conditions, globals.ConditionReasonTargetSynced, resource.Status.Message)
+	resource.Status.ObservedGeneration = resource.Generation
+}
+
+// SetDegraded updates the status to Degraded phase when some repositories failed to sync, tracking the ones applied
+func (r *SnapshotRepositoryReconciler) SetDegraded(ctx context.Context, resource *v1alpha1.SnapshotRepository, targetCluster string, appliedResources []string, err error) {
+	now := metav1.Now()
+	resource.Status.Phase = controller.PhaseDegraded
+	resource.Status.Message = fmt.Sprintf("Synced with errors: %s", err)
+	resource.Status.TargetCluster = targetCluster
+	resource.Status.AppliedResources = appliedResources
+	resource.Status.LastSyncTime = &now
+	globals.UpdateClusterBlockedCondition(&resource.Status.Conditions, err)
+	globals.SetStalledConditions(&resource.Status.Conditions, globals.ConditionReasonPartiallySynced, resource.Status.Message)
+	globals.RecordSyncError(r.Recorder, resource, err)
+	resource.Status.ObservedGeneration = resource.Generation
 }
 
 // SetError updates the status to Error phase with error message
 func (r *SnapshotRepositoryReconciler) SetError(ctx context.Context, resource *v1alpha1.SnapshotRepository, err error) {
 	resource.Status.Phase = controller.PhaseError
 	resource.Status.Message = err.Error()
-	_ = r.Status().Update(ctx, resource)
+	globals.UpdateClusterBlockedCondition(&resource.Status.Conditions, err)
+	globals.SetStalledConditions(&resource.Status.Conditions, globals.ConditionReasonSyncFailed, resource.Status.Message)
+	globals.RecordSyncError(r.Recorder, resource, err)
+	resource.Status.ObservedGeneration = resource.Generation
+}
+
+// SetWaitingForCluster updates the status to WaitingForCluster phase while the target cluster is not reachable yet
+func (r *SnapshotRepositoryReconciler) SetWaitingForCluster(ctx context.Context, resource *v1alpha1.SnapshotRepository, err error) {
+	resource.Status.Phase = controller.PhaseWaitingForCluster
+	resource.Status.Message = err.Error()
+	globals.SetReconcilingConditions(&resource.Status.Conditions, globals.ConditionReasonWaitingForCluster, resource.Status.Message)
+	globals.RecordSyncError(r.Recorder, resource, err)
+	resource.Status.ObservedGeneration = resource.Generation
 }
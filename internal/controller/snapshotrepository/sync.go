@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -39,26 +40,58 @@ func (r *SnapshotRepositoryReconciler) Sync(ctx context.Context, eventType watch
 
 	logger := log.FromContext(ctx)
 
-	// Get the ECK cluster associated to the resource
-	if resource.Spec.ResourceSelector.Namespace == "" {
-		resource.Spec.ResourceSelector.Namespace = resource.Namespace
+	// The target cluster is resolved without changing the spec: the NamespaceDefaultCluster is used when the resource
+	// doesn't select one, and the namespace of the resource when the selector has none
+	resourceSelector, err := globals.ResolveResourceSelector(ctx, &resource.Spec.ResourceSelector, resource.Namespace)
+	if err != nil {
+		logger.Error(err, "Failed to resolve the target cluster")
+		r.SetError(ctx, resource, err)
+		return err
 	}
 
-	// Build the cluster key for the pools
-	clusterKey := fmt.Sprintf("%s_%s", resource.Spec.ResourceSelector.Namespace, resource.Spec.ResourceSelector.Name)
+	// Check the namespace of the resource is allowed to target the cluster
+	if err := globals.AuthorizeClusterAccess(ctx, resourceSelector, resource.Namespace, &resource.Status.Conditions); err != nil {
+		logger.Error(err, "Access to the target cluster denied")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	if err := globals.ValidateTLSVerification(resourceSelector, &resource.Status.Conditions); err != nil {
+		logger.Error(err, "TLS verification of the target cluster not configured")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	// Build the cluster key identifying the target cluster in the logs
+	clusterKey := fmt.Sprintf("%s_%s", resourceSelector.Namespace, resourceSelector.Name)
 
 	if eventType == watch.Deleted {
 		logger.Info(fmt.Sprintf("Deleting SnapshotRepository %s/%s", resource.Namespace, resource.Name))
 
 		// Get Elasticsearch connection to delete the repositories
-		esConnection, err := globals.GetOrCreateElasticsearchConnection(ctx, clusterKey, &resource.Spec.ResourceSelector, resource.Namespace, r.ElasticsearchConnectionsPool)
+		esConnection, err := globals.GetOrCreateElasticsearchConnection(ctx, resourceSelector, resource.Namespace, r.ElasticsearchConnectionsPool)
 		if err != nil {
 			logger.Error(err, "Failed to get Elasticsearch connection for deletion")
 			return err
 		}
 
-		// Delete each snapshot repository from Elasticsearch
+		// Writes are serialized with the other resources targeting the same cluster
+		unlock, err := globals.LockCluster(ctx, esConnection.Endpoint)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+
+		// Delete each snapshot repository from Elasticsearch, but the ones holding snapshots unless allowed
+		blocked := make(map[string]error)
 		for repoName := range resource.Spec.Resources {
+			if !resource.Spec.AllowDeleteWithSnapshots {
+				if err := r.checkRepositoryEmpty(ctx, esConnection.Client, repoName); err != nil {
+					logger.Info(fmt.Sprintf("Snapshot repository %s left in Elasticsearch: %s", repoName, err))
+					blocked[repoName] = err
+					continue
+				}
+			}
 			logger.Info(fmt.Sprintf("Deleting snapshot repository %s from Elasticsearch", repoName))
 			if err := r.deleteSnapshotRepository(ctx, esConnection.Client, repoName); err != nil {
 				logger.Error(err, fmt.Sprintf("Failed to delete snapshot repository %s", repoName))
@@ -67,21 +100,55 @@ func (r *SnapshotRepositoryReconciler) Sync(ctx context.Context, eventType watch
 			logger.Info(fmt.Sprintf("Snapshot repository %s deleted successfully", repoName))
 		}
 
+		// The deletion is retried until the snapshots are gone, and given up with the other failed cleanups
+		if len(blocked) > 0 {
+			globals.UpdateDeletionBlockedCondition(&resource.Status.Conditions, blocked, "allowDeleteWithSnapshots")
+			return globals.ResourceErrors(blocked)
+		}
+
 		return nil
 	}
 
 	logger.Info(fmt.Sprintf("Syncing SnapshotRepository %s/%s", resource.Namespace, resource.Name))
 
+	// The ${key} references of the repositories are replaced by the values of valuesFrom
+	repositoryResources, err := globals.ExpandValues(ctx, resource.Spec.ValuesFrom, resource.Namespace, resource.Spec.Resources)
+	if err != nil {
+		logger.Error(err, "Failed to expand the values of valuesFrom")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
 	// Set status to Syncing at the beginning
 	r.SetSyncing(ctx, resource)
 
 	// Step 1: Get or create Elasticsearch connection
-	esConnection, err := globals.GetOrCreateElasticsearchConnection(ctx, clusterKey, &resource.Spec.ResourceSelector, resource.Namespace, r.ElasticsearchConnectionsPool)
+	esConnection, err := globals.GetOrCreateElasticsearchConnection(ctx, resourceSelector, resource.Namespace, r.ElasticsearchConnectionsPool)
+	globals.UpdateClusterReachableCondition(&resource.Status.Conditions, err)
 	if err != nil {
 		logger.Error(err, "Failed to get or create Elasticsearch connection")
-		r.SetError(ctx, resource, fmt.Errorf("failed to connect to Elasticsearch: %w", err))
+		if errors.Is(err, globals.ErrClusterNotReady) {
+			r.SetWaitingForCluster(ctx, resource, err)
+			return err
+		}
+		r.SetError(ctx, resource, fmt.Errorf("%w to Elasticsearch: %w", globals.ErrConnectionFailed, err))
+		return err
+	}
+
+	// The Go templates of the repositories are rendered with the cluster they are synced to
+	repositoryResources, err = globals.RenderTemplates(resource.Spec.Templating, repositoryResources, globals.NewTemplateData(resource, resourceSelector, esConnection))
+	if err != nil {
+		logger.Error(err, "Failed to render the templates of the repositories")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	// Writes are serialized with the other resources targeting the same cluster
+	unlock, err := globals.LockCluster(ctx, esConnection.Endpoint)
+	if err != nil {
 		return err
 	}
+	defer unlock()
 
 	logger.Info(fmt.Sprintf("Elasticsearch connection established for cluster %s", clusterKey))
 
@@ -93,25 +160,40 @@ func (r *SnapshotRepositoryReconciler) Sync(ctx context.Context, eventType watch
 
 	// Step 3: Get the list of desired repositories (from Spec)
 	desiredRepositories := make(map[string]bool)
-	for repoName := range resource.Spec.Resources {
+	for repoName := range repositoryResources {
 		desiredRepositories[repoName] = true
 	}
 
-	// Step 4: Delete repositories that are no longer desired
+	// Step 4: Delete repositories that are no longer desired. The ones holding snapshots are kept as applied, so their
+	// deletion is retried on every sync until they are empty or allowed to be deleted
+	newAppliedRepositories := make([]string, 0, len(repositoryResources))
+	blocked := make(map[string]error)
 	for repoName := range appliedRepositories {
 		if !desiredRepositories[repoName] {
+			if !resource.Spec.AllowDeleteWithSnapshots {
+				if err := r.checkRepositoryEmpty(ctx, esConnection.Client, repoName); err != nil {
+					if !errors.Is(err, globals.ErrObjectInUse) {
+						logger.Error(err, fmt.Sprintf("Failed to list the snapshots of repository %s", repoName))
+						return err
+					}
+					logger.Info(fmt.Sprintf("Repository %s is no longer desired but left in Elasticsearch: %s", repoName, err))
+					blocked[repoName] = err
+					newAppliedRepositories = append(newAppliedRepositories, repoName)
+					continue
+				}
+			}
 			logger.Info(fmt.Sprintf("Repository %s is no longer desired, deleting from Elasticsearch", repoName))
 			if err := r.deleteSnapshotRepository(ctx, esConnection.Client, repoName); err != nil {
 				logger.Error(err, fmt.Sprintf("Failed to delete snapshot repository %s", repoName))
 				return err
 			}
 			logger.Info(fmt.Sprintf("Snapshot repository %s deleted successfully", repoName))
+			globals.RecordPruned(r.Recorder, resource, repoName)
 		}
 	}
 
 	// Step 5: Apply all desired repositories (idempotent)
-	newAppliedRepositories := make([]string, 0, len(resource.Spec.Resources))
-	for repoName, repoResource := range resource.Spec.Resources {
+	for repoName, repoResource := range repositoryResources {
 		logger.Info(fmt.Sprintf("Processing snapshot repository: %s", repoName))
 
 		// Parse the desired repository from the resource
@@ -136,11 +218,14 @@ func (r *SnapshotRepositoryReconciler) Sync(ctx context.Context, eventType watch
 	}
 
 	// Step 6: Update the Status with the new list of applied repositories
-	targetCluster := fmt.Sprintf("%s/%s", resource.Spec.ResourceSelector.Namespace, resource.Spec.ResourceSelector.Name)
-	if err := r.SetReady(ctx, resource, targetCluster, newAppliedRepositories); err != nil {
-		logger.Error(err, "Failed to update SnapshotRepository status")
-		return err
+	globals.UpdateDeletionBlockedCondition(&resource.Status.Conditions, blocked, "allowDeleteWithSnapshots")
+	targetCluster := fmt.Sprintf("%s/%s", resourceSelector.Namespace, resourceSelector.Name)
+	if blockedErr := globals.ResourceErrors(blocked); blockedErr != nil {
+		logger.Error(blockedErr, "Failed to delete some repositories")
+		r.SetDegraded(ctx, resource, targetCluster, newAppliedRepositories, blockedErr)
+		return blockedErr
 	}
+	r.SetReady(ctx, resource, targetCluster, newAppliedRepositories)
 
 	logger.Info(fmt.Sprintf("SnapshotRepository %s/%s synced successfully", resource.Namespace, resource.Name))
 
@@ -178,6 +263,44 @@ func (r *SnapshotRepositoryReconciler) applySnapshotRepository(ctx context.Conte
 	return nil
 }
 
+// checkRepositoryEmpty fails with ErrObjectInUse when a snapshot repository holds snapshots, counting them.
+// Missing repositories are empty
+func (r *SnapshotRepositoryReconciler) checkRepositoryEmpty(ctx context.Context, esClient *elasticsearch.Client, repoName string) error {
+	res, err := esClient.Snapshot.Get(
+		repoName,
+		[]string{"_all"},
+		esClient.Snapshot.Get.WithVerbose(false),
+		esClient.Snapshot.Get.WithContext(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to list the snapshots of repository %s: %w", repoName, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil
+	}
+
+	bodyBytes, err := io.ReadAll(res.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+	if res.IsError() {
+		return fmt.Errorf("elasticsearch API error: %s - %s", res.Status(), string(bodyBytes))
+	}
+
+	var snapshots struct {
+		Snapshots []json.RawMessage `json:"snapshots"`
+	}
+	if err := json.Unmarshal(bodyBytes, &snapshots); err != nil {
+		return fmt.Errorf("failed to parse the snapshots of repository %s: %w", repoName, err)
+	}
+	if len(snapshots.Snapshots) > 0 {
+		return fmt.Errorf("%w: it holds %d snapshots", globals.ErrObjectInUse, len(snapshots.Snapshots))
+	}
+	return nil
+}
+
 // deleteSnapshotRepository deletes a snapshot repository from Elasticsearch
 func (r *SnapshotRepositoryReconciler) deleteSnapshotRepository(ctx context.Context, esClient *elasticsearch.Client, repoName string) error {
 	logger := log.FromContext(ctx)
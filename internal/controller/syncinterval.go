@@ -0,0 +1,28 @@
+package controller
+
+import (
+	"math/rand/v2"
+	"time"
+
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/globals"
+)
+
+// RequeueInterval returns the interval between the periodic syncs of a resource from its syncInterval, or from
+// DefaultSyncInterval when it has none. Intervals below Application.MinSyncInterval are raised to it, so a tiny
+// syncInterval (e.g., 10ms) can't flood the clusters with requests. A random fraction of the interval, up to
+// Application.SyncIntervalJitter, is added to it, so resources with the same syncInterval drift apart instead of
+// syncing in lockstep
+func RequeueInterval(syncInterval string) (time.Duration, error) {
+	if syncInterval == "" {
+		syncInterval = DefaultSyncInterval
+	}
+	interval, err := time.ParseDuration(syncInterval)
+	if err != nil {
+		return 0, err
+	}
+	interval = max(interval, globals.Application.MinSyncInterval)
+	if jitter := globals.Application.SyncIntervalJitter; jitter > 0 {
+		interval += time.Duration(rand.Float64() * jitter * float64(interval))
+	}
+	return interval, nil
+}
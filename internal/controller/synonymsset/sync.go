@@ -0,0 +1,409 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package synonymsset
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+
+	"github.com/elastic/go-elasticsearch/v8"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/globals"
+)
+
+const (
+	// synonymsPageSize is the number of rules read per request when fetching a synonyms set
+	synonymsPageSize = 1000
+
+	// synonymsMaxRulesPerRequest is the maximum number of rules sent in a single synonyms set PUT
+	synonymsMaxRulesPerRequest = 10000
+
+	// synonymsIncrementalThreshold is the maximum number of changed rules applied rule by rule.
+	// Every rule update reloads the analyzers using the set, so bigger changes replace the whole set at once
+	synonymsIncrementalThreshold = 100
+)
+
+// synonymRule is a single rule of a synonyms set, as returned and accepted by the synonyms API
+type synonymRule struct {
+	ID       string `json:"id"`
+	Synonyms string `json:"synonyms"`
+}
+
+// Sync executes the synchronization of synonyms sets with Elasticsearch
+func (r *SynonymsSetReconciler) Sync(ctx context.Context, eventType watch.EventType, resource *v1alpha1.SynonymsSet) (err error) {
+
+	logger := log.FromContext(ctx)
+
+	// The target cluster is resolved without changing the spec: the NamespaceDefaultCluster is used when the resource
+	// doesn't select one, and the namespace of the resource when the selector has none
+	resourceSelector, err := globals.ResolveResourceSelector(ctx, &resource.Spec.ResourceSelector, resource.Namespace)
+	if err != nil {
+		logger.Error(err, "Failed to resolve the target cluster")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	// Check the namespace of the resource is allowed to target the cluster
+	if err := globals.AuthorizeClusterAccess(ctx, resourceSelector, resource.Namespace, &resource.Status.Conditions); err != nil {
+		logger.Error(err, "Access to the target cluster denied")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	if err := globals.ValidateTLSVerification(resourceSelector, &resource.Status.Conditions); err != nil {
+		logger.Error(err, "TLS verification of the target cluster not configured")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	// Build the cluster key identifying the target cluster in the logs
+	clusterKey := fmt.Sprintf("%s_%s", resourceSelector.Namespace, resourceSelector.Name)
+
+	if eventType == watch.Deleted {
+		logger.Info(fmt.Sprintf("Deleting SynonymsSet %s/%s", resource.Namespace, resource.Name))
+
+		// Get Elasticsearch connection to delete the synonyms sets
+		esConnection, err := globals.GetOrCreateElasticsearchConnection(ctx, resourceSelector, resource.Namespace, r.ElasticsearchConnectionsPool)
+		if err != nil {
+			logger.Error(err, "Failed to get Elasticsearch connection for deletion")
+			return err
+		}
+
+		// Writes are serialized with the other resources targeting the same cluster
+		unlock, err := globals.LockCluster(ctx, esConnection.Endpoint)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+
+		// Delete each synonyms set from Elasticsearch
+		for _, setName := range resource.Status.AppliedResources {
+			logger.Info(fmt.Sprintf("Deleting synonyms set %s from Elasticsearch", setName))
+			if err := r.deleteSynonymsSet(ctx, esConnection.Client, setName); err != nil {
+				logger.Error(err, fmt.Sprintf("Failed to delete synonyms set %s", setName))
+				return err
+			}
+			logger.Info(fmt.Sprintf("Synonyms set %s deleted successfully", setName))
+		}
+
+		return nil
+	}
+
+	logger.Info(fmt.Sprintf("Syncing SynonymsSet %s/%s", resource.Namespace, resource.Name))
+
+	// Set status to Syncing at the beginning
+	r.SetSyncing(ctx, resource)
+
+	// Step 1: Get or create Elasticsearch connection
+	esConnection, err := globals.GetOrCreateElasticsearchConnection(ctx, resourceSelector, resource.Namespace, r.ElasticsearchConnectionsPool)
+	globals.UpdateClusterReachableCondition(&resource.Status.Conditions, err)
+	if err != nil {
+		logger.Error(err, "Failed to get or create Elasticsearch connection")
+		if errors.Is(err, globals.ErrClusterNotReady) {
+			r.SetWaitingForCluster(ctx, resource, err)
+			return err
+		}
+		r.SetError(ctx, resource, fmt.Errorf("%w to Elasticsearch: %w", globals.ErrConnectionFailed, err))
+		return err
+	}
+
+	// Writes are serialized with the other resources targeting the same cluster
+	unlock, err := globals.LockCluster(ctx, esConnection.Endpoint)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	logger.Info(fmt.Sprintf("Elasticsearch connection established for cluster %s (type: %s, version: %s)", clusterKey, esConnection.ClusterType, esConnection.Version))
+
+	// Validate cluster type - the synonyms API is only available in Elasticsearch
+	if esConnection.ClusterType == "opensearch" {
+		err := fmt.Errorf("the synonyms API is not available in OpenSearch. Synonyms must be defined in the analyzer settings of the index templates instead")
+		logger.Error(err, "Incompatible cluster type for SynonymsSet")
+		r.SetError(ctx, resource, err)
+		return err
+	}
+
+	// Step 2: Delete synonyms sets that are no longer desired
+	for _, setName := range resource.Status.AppliedResources {
+		if _, desired := resource.Spec.Resources[setName]; !desired {
+			logger.Info(fmt.Sprintf("Synonyms set %s is no longer desired, deleting from Elasticsearch", setName))
+			if err := r.deleteSynonymsSet(ctx, esConnection.Client, setName); err != nil {
+				logger.Error(err, fmt.Sprintf("Failed to delete synonyms set %s", setName))
+				r.SetError(ctx, resource, fmt.Errorf("failed to delete synonyms set %s: %w", setName, err))
+				return err
+			}
+			logger.Info(fmt.Sprintf("Synonyms set %s deleted successfully", setName))
+			globals.RecordPruned(r.Recorder, resource, setName)
+		}
+	}
+
+	// Step 3: Apply all desired synonyms sets
+	newAppliedSets := make([]string, 0, len(resource.Spec.Resources))
+	for setName, rules := range resource.Spec.Resources {
+		logger.Info(fmt.Sprintf("Applying synonyms set %s (%d rules)", setName, len(rules)))
+		if err := r.applySynonymsSet(ctx, esConnection.Client, setName, rules); err != nil {
+			logger.Error(err, fmt.Sprintf("Failed to apply synonyms set %s", setName))
+			r.SetError(ctx, resource, fmt.Errorf("failed to apply synonyms set %s: %w", setName, err))
+			return err
+		}
+		newAppliedSets = append(newAppliedSets, setName)
+		logger.Info(fmt.Sprintf("Synonyms set %s applied successfully", setName))
+	}
+
+	// Step 4: Update the Status with the new list of applied synonyms sets
+	targetCluster := fmt.Sprintf("%s/%s", resourceSelector.Namespace, resourceSelector.Name)
+	r.SetReady(ctx, resource, targetCluster, newAppliedSets)
+
+	logger.Info(fmt.Sprintf("SynonymsSet %s/%s synced successfully", resource.Namespace, resource.Name))
+
+	return nil
+}
+
+// applySynonymsSet reconciles the rules of a synonyms set with the desired ones.
+// Small changes are applied rule by rule, while new sets and big changes replace the whole set.
+// Sets bigger than a single request allows are written in chunks: the first chunk replaces the set
+// and the remaining rules are added to it one by one
+func (r *SynonymsSetReconciler) applySynonymsSet(ctx context.Context, esClient *elasticsearch.Client, setName string, desiredRules map[string]string) error {
+	logger := log.FromContext(ctx)
+
+	liveRules, exists, err := r.getSynonymsSet(ctx, esClient, setName)
+	if err != nil {
+		return err
+	}
+
+	// Compute the rules to upsert and delete
+	changedRules := make([]string, 0)
+	for ruleID, synonyms := range desiredRules {
+		if liveSynonyms, found := liveRules[ruleID]; !found || liveSynonyms != synonyms {
+			changedRules = append(changedRules, ruleID)
+		}
+	}
+	removedRules := make([]string, 0)
+	for ruleID := range liveRules {
+		if _, found := desiredRules[ruleID]; !found {
+			removedRules = append(removedRules, ruleID)
+		}
+	}
+
+	if exists && len(changedRules) == 0 && len(removedRules) == 0 {
+		logger.Info(fmt.Sprintf("Synonyms set %s is up to date", setName))
+		return nil
+	}
+
+	// Apply small changes rule by rule
+	if exists && len(changedRules)+len(removedRules) <= synonymsIncrementalThreshold {
+		logger.Info(fmt.Sprintf("Updating synonyms set %s incrementally (%d changed, %d removed rules)", setName, len(changedRules), len(removedRules)))
+		for _, ruleID := range changedRules {
+			if err := r.putSynonymRule(ctx, esClient, setName, ruleID, desiredRules[ruleID]); err != nil {
+				return err
+			}
+		}
+		for _, ruleID := range removedRules {
+			if err := r.deleteSynonymRule(ctx, esClient, setName, ruleID); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	// Replace the whole set, sorting the rules so chunks are stable between reconciliations
+	ruleIDs := make([]string, 0, len(desiredRules))
+	for ruleID := range desiredRules {
+		ruleIDs = append(ruleIDs, ruleID)
+	}
+	sort.Strings(ruleIDs)
+
+	firstChunk := ruleIDs
+	if len(firstChunk) > synonymsMaxRulesPerRequest {
+		firstChunk = ruleIDs[:synonymsMaxRulesPerRequest]
+	}
+
+	rules := make([]synonymRule, 0, len(firstChunk))
+	for _, ruleID := range firstChunk {
+		rules = append(rules, synonymRule{ID: ruleID, Synonyms: desiredRules[ruleID]})
+	}
+
+	logger.Info(fmt.Sprintf("Replacing synonyms set %s with %d rules", setName, len(rules)))
+	if err := r.putSynonymsSet(ctx, esClient, setName, rules); err != nil {
+		return err
+	}
+
+	for _, ruleID := range ruleIDs[len(firstChunk):] {
+		if err := r.putSynonymRule(ctx, esClient, setName, ruleID, desiredRules[ruleID]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getSynonymsSet reads all the rules of a synonyms set page by page.
+// It returns the rules keyed by rule ID and whether the set exists
+func (r *SynonymsSetReconciler) getSynonymsSet(ctx context.Context, esClient *elasticsearch.Client, setName string) (map[string]string, bool, error) {
+	rules := make(map[string]string)
+
+	for from := 0; ; from += synonymsPageSize {
+		res, err := esClient.SynonymsGetSynonym(
+			setName,
+			esClient.SynonymsGetSynonym.WithContext(ctx),
+			esClient.SynonymsGetSynonym.WithFrom(from),
+			esClient.SynonymsGetSynonym.WithSize(synonymsPageSize),
+		)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to get synonyms set: %w", err)
+		}
+
+		if res.StatusCode == http.StatusNotFound {
+			res.Body.Close()
+			return rules, false, nil
+		}
+
+		bodyBytes, err := io.ReadAll(res.Body)
+		res.Body.Close()
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to read response body: %w", err)
+		}
+
+		if res.IsError() {
+			return nil, false, fmt.Errorf("elasticsearch API error: %s - %s", res.Status(), string(bodyBytes))
+		}
+
+		var page struct {
+			Count       int           `json:"count"`
+			SynonymsSet []synonymRule `json:"synonyms_set"`
+		}
+		if err := json.Unmarshal(bodyBytes, &page); err != nil {
+			return nil, false, fmt.Errorf("failed to parse synonyms set: %w", err)
+		}
+
+		for _, rule := range page.SynonymsSet {
+			rules[rule.ID] = rule.Synonyms
+		}
+
+		if len(page.SynonymsSet) < synonymsPageSize || from+synonymsPageSize >= page.Count {
+			return rules, true, nil
+		}
+	}
+}
+
+// putSynonymsSet creates or replaces a synonyms set with the given rules
+func (r *SynonymsSetReconciler) putSynonymsSet(ctx context.Context, esClient *elasticsearch.Client, setName string, rules []synonymRule) error {
+	body, err := json.Marshal(map[string]interface{}{"synonyms_set": rules})
+	if err != nil {
+		return fmt.Errorf("failed to marshal synonyms set: %w", err)
+	}
+
+	res, err := esClient.SynonymsPutSynonym(
+		setName,
+		bytes.NewReader(body),
+		esClient.SynonymsPutSynonym.WithContext(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to put synonyms set: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		bodyBytes, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("elasticsearch API error: %s - %s", res.Status(), string(bodyBytes))
+	}
+
+	return nil
+}
+
+// putSynonymRule creates or updates a single rule of a synonyms set
+func (r *SynonymsSetReconciler) putSynonymRule(ctx context.Context, esClient *elasticsearch.Client, setName, ruleID, synonyms string) error {
+	body, err := json.Marshal(map[string]string{"synonyms": synonyms})
+	if err != nil {
+		return fmt.Errorf("failed to marshal synonym rule: %w", err)
+	}
+
+	res, err := esClient.SynonymsPutSynonymRule(
+		bytes.NewReader(body),
+		ruleID,
+		setName,
+		esClient.SynonymsPutSynonymRule.WithContext(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to put synonym rule %s: %w", ruleID, err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		bodyBytes, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("elasticsearch API error: %s - %s", res.Status(), string(bodyBytes))
+	}
+
+	return nil
+}
+
+// deleteSynonymRule deletes a single rule from a synonyms set
+func (r *SynonymsSetReconciler) deleteSynonymRule(ctx context.Context, esClient *elasticsearch.Client, setName, ruleID string) error {
+	res, err := esClient.SynonymsDeleteSynonymRule(
+		ruleID,
+		setName,
+		esClient.SynonymsDeleteSynonymRule.WithContext(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete synonym rule %s: %w", ruleID, err)
+	}
+	defer res.Body.Close()
+
+	// If the rule doesn't exist (404), consider it already deleted
+	if res.IsError() && res.StatusCode != http.StatusNotFound {
+		bodyBytes, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("elasticsearch API error: %s - %s", res.Status(), string(bodyBytes))
+	}
+
+	return nil
+}
+
+// deleteSynonymsSet deletes a synonyms set from Elasticsearch
+func (r *SynonymsSetReconciler) deleteSynonymsSet(ctx context.Context, esClient *elasticsearch.Client, setName string) error {
+	logger := log.FromContext(ctx)
+
+	res, err := esClient.SynonymsDeleteSynonym(
+		setName,
+		esClient.SynonymsDeleteSynonym.WithContext(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete synonyms set: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		// If the synonyms set doesn't exist (404), consider it already deleted
+		if res.StatusCode == http.StatusNotFound {
+			logger.Info(fmt.Sprintf("Synonyms set %s not found in Elasticsearch (already deleted)", setName))
+			return nil
+		}
+		bodyBytes, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("elasticsearch API error: %s - %s", res.Status(), string(bodyBytes))
+	}
+
+	return nil
+}
@@ -0,0 +1,245 @@
+package synonymsset
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/elastic/go-elasticsearch/v8"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+
+	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/controller"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/globals"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/pools"
+)
+
+// fakeSynonyms is an Elasticsearch synonyms API storing the rules of its sets, and recording the requests
+// that change them
+type fakeSynonyms struct {
+	mu       sync.Mutex
+	sets     map[string]map[string]string
+	requests []string
+}
+
+func (f *fakeSynonyms) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	// The client refuses the responses of servers not identified as Elasticsearch
+	w.Header().Set("X-Elastic-Product", "Elasticsearch")
+	w.Header().Set("Content-Type", "application/json")
+
+	path := strings.Split(strings.TrimPrefix(r.URL.Path, "/_synonyms/"), "/")
+	setName := path[0]
+	rules, exists := f.sets[setName]
+
+	if r.Method != http.MethodGet {
+		f.requests = append(f.requests, fmt.Sprintf("%s %s", r.Method, r.URL.Path))
+	}
+
+	switch {
+	case r.Method == http.MethodGet && exists:
+		page := make([]synonymRule, 0, len(rules))
+		for _, ruleID := range slices.Sorted(maps.Keys(rules)) {
+			page = append(page, synonymRule{ID: ruleID, Synonyms: rules[ruleID]})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"count": len(page), "synonyms_set": page})
+	case r.Method == http.MethodPut && len(path) == 1:
+		var body struct {
+			SynonymsSet []synonymRule `json:"synonyms_set"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		f.sets[setName] = make(map[string]string)
+		for _, rule := range body.SynonymsSet {
+			f.sets[setName][rule.ID] = rule.Synonyms
+		}
+		_, _ = w.Write([]byte(`{"result":"created"}`))
+	case r.Method == http.MethodPut && exists:
+		var body struct {
+			Synonyms string `json:"synonyms"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		rules[path[1]] = body.Synonyms
+		_, _ = w.Write([]byte(`{"result":"updated"}`))
+	case r.Method == http.MethodDelete && len(path) == 1 && exists:
+		delete(f.sets, setName)
+		_, _ = w.Write([]byte(`{"acknowledged":true}`))
+	case r.Method == http.MethodDelete && exists:
+		delete(rules, path[1])
+		_, _ = w.Write([]byte(`{"result":"deleted"}`))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{}`))
+	}
+}
+
+func TestSync(t *testing.T) {
+	// manyRules are more rules than the ones changed rule by rule
+	manyRules := make(map[string]string)
+	for i := 0; i <= synonymsIncrementalThreshold; i++ {
+		manyRules[fmt.Sprintf("rule-%03d", i)] = fmt.Sprintf("word%d, term%d", i, i)
+	}
+
+	tests := []struct {
+		name        string
+		eventType   watch.EventType
+		clusterType string
+		// existingSets are the synonyms sets stored in Elasticsearch
+		existingSets map[string]map[string]string
+		resources    map[string]map[string]string
+		applied      []string
+
+		wantErr      bool
+		wantPhase    string
+		wantApplied  []string
+		wantRequests []string
+	}{
+		{
+			name:         "new sets are created at once",
+			eventType:    watch.Modified,
+			resources:    map[string]map[string]string{"products": {"tv": "tv, television"}},
+			wantPhase:    controller.PhaseReady,
+			wantApplied:  []string{"products"},
+			wantRequests: []string{"PUT /_synonyms/products"},
+		},
+		{
+			name:         "unchanged sets are not written",
+			eventType:    watch.Modified,
+			existingSets: map[string]map[string]string{"products": {"tv": "tv, television"}},
+			resources:    map[string]map[string]string{"products": {"tv": "tv, television"}},
+			applied:      []string{"products"},
+			wantPhase:    controller.PhaseReady,
+			wantApplied:  []string{"products"},
+			wantRequests: nil,
+		},
+		{
+			name:         "small changes are applied rule by rule",
+			eventType:    watch.Modified,
+			existingSets: map[string]map[string]string{"products": {"tv": "tv, television", "pc": "pc, computer"}},
+			resources:    map[string]map[string]string{"products": {"tv": "tv, telly", "ipod": "i-pod, i pod => ipod"}},
+			applied:      []string{"products"},
+			wantPhase:    controller.PhaseReady,
+			wantApplied:  []string{"products"},
+			wantRequests: []string{
+				"DELETE /_synonyms/products/pc",
+				"PUT /_synonyms/products/ipod",
+				"PUT /_synonyms/products/tv",
+			},
+		},
+		{
+			name:         "big changes replace the whole set",
+			eventType:    watch.Modified,
+			existingSets: map[string]map[string]string{"products": {"tv": "tv, television"}},
+			resources:    map[string]map[string]string{"products": manyRules},
+			applied:      []string{"products"},
+			wantPhase:    controller.PhaseReady,
+			wantApplied:  []string{"products"},
+			wantRequests: []string{"PUT /_synonyms/products"},
+		},
+		{
+			name:         "sets removed from the spec are deleted",
+			eventType:    watch.Modified,
+			existingSets: map[string]map[string]string{"products": {"tv": "tv, television"}, "brands": {"hp": "hp, hewlett packard"}},
+			resources:    map[string]map[string]string{"products": {"tv": "tv, television"}},
+			applied:      []string{"brands", "products"},
+			wantPhase:    controller.PhaseReady,
+			wantApplied:  []string{"products"},
+			wantRequests: []string{"DELETE /_synonyms/brands"},
+		},
+		{
+			name:         "OpenSearch clusters are rejected",
+			eventType:    watch.Modified,
+			clusterType:  "opensearch",
+			resources:    map[string]map[string]string{"products": {"tv": "tv, television"}},
+			wantErr:      true,
+			wantPhase:    controller.PhaseError,
+			wantApplied:  nil,
+			wantRequests: nil,
+		},
+		{
+			name:         "deleted resources delete their sets",
+			eventType:    watch.Deleted,
+			existingSets: map[string]map[string]string{"products": {"tv": "tv, television"}},
+			resources:    map[string]map[string]string{"products": {"tv": "tv, television"}},
+			applied:      []string{"brands", "products"},
+			wantApplied:  []string{"brands", "products"},
+			wantRequests: []string{"DELETE /_synonyms/brands", "DELETE /_synonyms/products"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			synonyms := &fakeSynonyms{sets: make(map[string]map[string]string)}
+			for setName, rules := range tt.existingSets {
+				synonyms.sets[setName] = maps.Clone(rules)
+			}
+			server := httptest.NewServer(synonyms)
+			t.Cleanup(server.Close)
+
+			esClient, err := elasticsearch.NewClient(elasticsearch.Config{Addresses: []string{server.URL}})
+			if err != nil {
+				t.Fatalf("failed to create the client: %v", err)
+			}
+			clusterType := tt.clusterType
+			if clusterType == "" {
+				clusterType = "elasticsearch"
+			}
+
+			// The resources reference a connection already pooled, so no connection is created to the fake cluster
+			pool := &pools.ElasticsearchConnectionsStore{Store: make(map[string]*pools.ElasticsearchConnection)}
+			pool.Set(globals.ClusterConnectionKey("search", "elasticsearch"), &pools.ElasticsearchConnection{
+				Endpoint:    server.URL,
+				Client:      esClient,
+				ClusterType: clusterType,
+			})
+			r := &SynonymsSetReconciler{ElasticsearchConnectionsPool: pool}
+
+			resource := &v1alpha1.SynonymsSet{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "search", Name: "catalog"},
+				Spec: v1alpha1.SynonymsSetSpec{
+					ResourceSelector: v1alpha1.ResourceSelector{
+						ConnectionRef: &v1alpha1.ClusterConnectionReference{Name: "elasticsearch"},
+					},
+					Resources: tt.resources,
+				},
+				Status: v1alpha1.SynonymsSetStatus{AppliedResources: slices.Clone(tt.applied)},
+			}
+
+			err = r.Sync(context.Background(), tt.eventType, resource)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Sync() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if resource.Status.Phase != tt.wantPhase {
+				t.Errorf("Sync() phase = %q, want %q", resource.Status.Phase, tt.wantPhase)
+			}
+
+			// The sets are synced in the order of their maps, so the lists are compared sorted
+			applied := slices.Sorted(slices.Values(resource.Status.AppliedResources))
+			if !slices.Equal(applied, tt.wantApplied) {
+				t.Errorf("Sync() appliedResources = %v, want %v", applied, tt.wantApplied)
+			}
+			requests := slices.Sorted(slices.Values(synonyms.requests))
+			if !slices.Equal(requests, tt.wantRequests) {
+				t.Errorf("Sync() requests = %v, want %v", requests, tt.wantRequests)
+			}
+
+			if tt.wantErr || tt.eventType == watch.Deleted {
+				return
+			}
+			for setName, rules := range tt.resources {
+				if !maps.Equal(synonyms.sets[setName], rules) {
+					t.Errorf("Sync() rules of %s = %v, want %v", setName, synonyms.sets[setName], rules)
+				}
+			}
+		})
+	}
+}
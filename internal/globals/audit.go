@@ -0,0 +1,112 @@
+package globals
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const metricsNamespace = "elastic_config_operator"
+
+// readOnlyEndpoints are the endpoints of the read requests sent with POST, allowed in audit mode. They are the last
+// path segment of the requests, or the one before the name of the object (e.g., _index_template/_simulate/<name>)
+var readOnlyEndpoints = map[string]bool{
+	"_search":         true,
+	"_msearch":        true,
+	"_count":          true,
+	"_mget":           true,
+	"_field_caps":     true,
+	"_has_privileges": true,
+	"_simulate":       true,
+	"_simulate_index": true,
+}
+
+var (
+	pendingChanges = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Name:      "pending_changes",
+		Help:      "Number of changes found by the last dry run of a resource, in dry run or audit mode",
+	}, []string{"kind", "namespace", "name"})
+
+	auditBlockedRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "audit_blocked_requests_total",
+		Help:      "Number of requests changing the clusters rejected in audit mode, by method",
+	}, []string{"method"})
+)
+
+// RegisterMetrics registers the metrics of the dry runs and of the audit mode in the metrics registry of the manager
+func RegisterMetrics() {
+	metrics.Registry.MustRegister(pendingChanges, auditBlockedRequests)
+}
+
+// IsDryRun reports whether a resource only reports the changes it would make, because it asks for a dry run or
+// because the operator runs in audit mode
+func IsDryRun(dryRun bool) bool {
+	return dryRun || Application.AuditMode
+}
+
+// SetPendingChanges exposes the number of changes found by the dry run of a resource
+func SetPendingChanges(kind, namespace, name string, changes int) {
+	pendingChanges.WithLabelValues(kind, namespace, name).Set(float64(changes))
+}
+
+// DeletePendingChanges removes the pending changes of a resource that is synced or deleted
+func DeletePendingChanges(kind, namespace, name string) {
+	pendingChanges.DeleteLabelValues(kind, namespace, name)
+}
+
+// auditTransport rejects the requests changing the clusters, answering them with 403 Forbidden without sending them
+type auditTransport struct {
+	transport http.RoundTripper
+}
+
+// newAuditTransport wraps a transport rejecting the requests changing the clusters, unless the operator does not
+// run in audit mode
+func newAuditTransport(transport http.RoundTripper) http.RoundTripper {
+	if !Application.AuditMode {
+		return transport
+	}
+	return &auditTransport{transport: transport}
+}
+
+// RoundTrip sends the read requests, and answers the other ones with 403 Forbidden. An answer is returned instead of
+// an error, so the clients do not retry the rejected requests
+func (t *auditTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if isReadRequest(req) {
+		return t.transport.RoundTrip(req)
+	}
+
+	if req.Body != nil {
+		req.Body.Close()
+	}
+	auditBlockedRequests.WithLabelValues(req.Method).Inc()
+
+	message := fmt.Sprintf("%s %s rejected: the operator runs in audit mode and does not write to the clusters", req.Method, req.URL.Path)
+	return &http.Response{
+		Status:     fmt.Sprintf("%d %s", http.StatusForbidden, http.StatusText(http.StatusForbidden)),
+		StatusCode: http.StatusForbidden,
+		Proto:      req.Proto,
+		ProtoMajor: req.ProtoMajor,
+		ProtoMinor: req.ProtoMinor,
+		Header:     http.Header{"Content-Type": []string{"text/plain; charset=utf-8"}},
+		Body:       io.NopCloser(strings.NewReader(message)),
+		Request:    req,
+	}, nil
+}
+
+// isReadRequest reports whether a request only reads from the cluster
+func isReadRequest(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	case http.MethodPost:
+		return readOnlyEndpoints[path.Base(req.URL.Path)] || readOnlyEndpoints[path.Base(path.Dir(req.URL.Path))]
+	}
+	return false
+}
@@ -0,0 +1,105 @@
+package globals
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+// defaultAWSService is the signing name of Amazon OpenSearch Service managed domains
+const defaultAWSService = "es"
+
+// sigV4Transport signs every request with AWS Signature Version 4 before sending it
+type sigV4Transport struct {
+	transport   http.RoundTripper
+	credentials aws.CredentialsProvider
+	signer      *v4.Signer
+	region      string
+	service     string
+}
+
+// newSigV4TransportWrapper returns a function wrapping a transport with the SigV4 signing of the AWS settings.
+// Static keys are read from Secrets of the default namespace, and the default credential chain is used otherwise
+func newSigV4TransportWrapper(ctx context.Context, awsAuthentication *v1alpha1.AWSAuthentication, defaultNamespace string) (func(http.RoundTripper) http.RoundTripper, error) {
+	awsCfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(awsAuthentication.Region))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS configuration: %w", err)
+	}
+
+	if awsAuthentication.AccessKeyIDSecretRef != nil && awsAuthentication.SecretAccessKeySecretRef != nil {
+		accessKeyID, err := GetSecretValue(ctx, awsAuthentication.AccessKeyIDSecretRef, defaultNamespace)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get AWS access key ID: %w", err)
+		}
+		secretAccessKey, err := GetSecretValue(ctx, awsAuthentication.SecretAccessKeySecretRef, defaultNamespace)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get AWS secret access key: %w", err)
+		}
+		awsCfg.Credentials = credentials.NewStaticCredentialsProvider(accessKeyID, secretAccessKey, "")
+	}
+
+	if awsAuthentication.RoleARN != "" {
+		awsCfg.Credentials = aws.NewCredentialsCache(stscreds.NewAssumeRoleProvider(sts.NewFromConfig(awsCfg), awsAuthentication.RoleARN))
+	}
+
+	service := awsAuthentication.Service
+	if service == "" {
+		service = defaultAWSService
+	}
+
+	return func(transport http.RoundTripper) http.RoundTripper {
+		return &sigV4Transport{
+			transport:   transport,
+			credentials: awsCfg.Credentials,
+			signer:      v4.NewSigner(),
+			region:      awsAuthentication.Region,
+			service:     service,
+		}
+	}, nil
+}
+
+// RoundTrip signs a copy of the request, as the signature covers the payload hash, and sends it
+func (t *sigV4Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	credentials, err := t.credentials.Retrieve(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve AWS credentials: %w", err)
+	}
+
+	signedReq := req.Clone(ctx)
+
+	var payload []byte
+	if req.Body != nil {
+		payload, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+		signedReq.Body = io.NopCloser(bytes.NewReader(payload))
+		signedReq.ContentLength = int64(len(payload))
+	}
+	payloadHash := sha256.Sum256(payload)
+
+	// Amazon OpenSearch Serverless requires the payload hash as a header
+	signedReq.Header.Set("X-Amz-Content-Sha256", hex.EncodeToString(payloadHash[:]))
+
+	if err := t.signer.SignHTTP(ctx, credentials, signedReq, hex.EncodeToString(payloadHash[:]), t.service, t.region, time.Now()); err != nil {
+		return nil, fmt.Errorf("failed to sign request: %w", err)
+	}
+
+	return t.transport.RoundTrip(signedReq)
+}
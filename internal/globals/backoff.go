@@ -0,0 +1,27 @@
+package globals
+
+import (
+	"golang.org/x/time/rate"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// controllerQPS and controllerBurst bound the overall rate of the requeues of a controller, like the default
+	// rate limiter of controller-runtime
+	controllerQPS   = 10
+	controllerBurst = 100
+)
+
+// ControllerOptions returns the options shared by the controllers of the resources. Failed reconciles are requeued
+// with an exponential backoff, from ErrorBackoffBase up to ErrorBackoffMax, so unreachable clusters are not
+// hammered, while healthy resources keep being requeued at their syncInterval
+func ControllerOptions() controller.Options {
+	return controller.Options{
+		RateLimiter: workqueue.NewTypedMaxOfRateLimiter(
+			workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](Application.ErrorBackoffBase, Application.ErrorBackoffMax),
+			&workqueue.TypedBucketRateLimiter[reconcile.Request]{Limiter: rate.NewLimiter(rate.Limit(controllerQPS), controllerBurst)},
+		),
+	}
+}
@@ -0,0 +1,180 @@
+package globals
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// Condition type reporting the writes rejected by a block of the target cluster (e.g., cluster.blocks.read_only).
+	// It is only True while the cluster rejects the writes of the resource
+	ConditionTypeClusterBlocked = "ClusterBlocked"
+
+	ConditionReasonClusterBlocked  = "ClusterBlocked"
+	ConditionReasonClusterWritable = "ClusterWritable"
+
+	// clusterBlockException is the type of the errors returned by Elasticsearch and OpenSearch for the requests
+	// rejected by a block
+	clusterBlockException = "cluster_block_exception"
+
+	// clusterBlockHold is the time the writes to a cluster are held after it rejected one with a cluster-wide block.
+	// They are answered with the block without being sent meanwhile
+	clusterBlockHold = 30 * time.Second
+)
+
+// clusterBlock is a cluster-wide block reported by a cluster, and the time until the writes to it are held
+type clusterBlock struct {
+	statusCode int
+	reason     string
+	until      time.Time
+}
+
+var (
+	clusterBlocksMutex sync.Mutex
+	// clusterBlocks are the cluster-wide blocks reported by the clusters, by endpoint
+	clusterBlocks = make(map[string]clusterBlock)
+)
+
+// IsClusterBlocked reports whether an error was caused by a block of the cluster, such as cluster.blocks.read_only,
+// the no master block or the read-only-allow-delete block set when the flood-stage disk watermark is exceeded
+func IsClusterBlocked(err error) bool {
+	return err != nil && strings.Contains(err.Error(), clusterBlockException)
+}
+
+// UpdateClusterBlockedCondition records in the ClusterBlocked condition whether the cluster rejected the writes of a
+// resource with a block. A successful sync clears a previous block, and other failures leave it untouched
+func UpdateClusterBlockedCondition(conditions *[]metav1.Condition, err error) {
+	switch {
+	case IsClusterBlocked(err):
+		UpdateCondition(conditions, NewCondition(ConditionTypeClusterBlocked, metav1.ConditionTrue,
+			ConditionReasonClusterBlocked, err.Error()))
+	case err == nil && meta.FindStatusCondition(*conditions, ConditionTypeClusterBlocked) != nil:
+		UpdateCondition(conditions, NewCondition(ConditionTypeClusterBlocked, metav1.ConditionFalse,
+			ConditionReasonClusterWritable, "The cluster accepts the writes"))
+	}
+}
+
+// clusterBlockTransport holds the writes to a cluster for a while once it rejects one with a cluster-wide block, so
+// the resources back off instead of sending writes that can not succeed until the block is removed
+type clusterBlockTransport struct {
+	transport http.RoundTripper
+	endpoint  string
+}
+
+// newClusterBlockTransport wraps a transport holding the writes to a blocked cluster
+func newClusterBlockTransport(transport http.RoundTripper, endpoint string) http.RoundTripper {
+	return &clusterBlockTransport{
+		transport: transport,
+		endpoint:  endpoint,
+	}
+}
+
+// RoundTrip sends the read requests, and the writes unless the cluster is held by a block, which are answered with
+// the block instead. Blocks of single indices only reject the writes to those indices, so they hold nothing
+func (t *clusterBlockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if isReadRequest(req) {
+		return t.transport.RoundTrip(req)
+	}
+
+	if block, held := heldClusterBlock(t.endpoint); held {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return newClusterBlockResponse(req, block), nil
+	}
+
+	res, err := t.transport.RoundTrip(req)
+	if err != nil || !isClusterBlockStatus(res.StatusCode) {
+		return res, err
+	}
+
+	// The body is read to find the block, and handed back to the client untouched
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	res.Body = io.NopCloser(bytes.NewReader(body))
+
+	var answer struct {
+		Error struct {
+			Type   string `json:"type"`
+			Reason string `json:"reason"`
+		} `json:"error"`
+	}
+	if json.Unmarshal(body, &answer) == nil && answer.Error.Type == clusterBlockException &&
+		!strings.HasPrefix(answer.Error.Reason, "index [") {
+		holdClusterBlock(t.endpoint, clusterBlock{
+			statusCode: res.StatusCode,
+			reason:     answer.Error.Reason,
+			until:      time.Now().Add(clusterBlockHold),
+		})
+	}
+
+	return res, nil
+}
+
+// isClusterBlockStatus reports whether a response status can be the one of a block: 403 for the blocks set through
+// the API, 429 for the flood-stage disk watermark and 503 for the no master block
+func isClusterBlockStatus(statusCode int) bool {
+	return statusCode == http.StatusForbidden || statusCode == http.StatusTooManyRequests ||
+		statusCode == http.StatusServiceUnavailable
+}
+
+// heldClusterBlock returns the block holding the writes to a cluster, if it still holds them
+func heldClusterBlock(endpoint string) (clusterBlock, bool) {
+	clusterBlocksMutex.Lock()
+	defer clusterBlocksMutex.Unlock()
+
+	block, exists := clusterBlocks[endpoint]
+	if !exists {
+		return clusterBlock{}, false
+	}
+	if time.Now().After(block.until) {
+		delete(clusterBlocks, endpoint)
+		return clusterBlock{}, false
+	}
+	return block, true
+}
+
+// holdClusterBlock holds the writes to a cluster until the block expires
+func holdClusterBlock(endpoint string, block clusterBlock) {
+	clusterBlocksMutex.Lock()
+	defer clusterBlocksMutex.Unlock()
+
+	clusterBlocks[endpoint] = block
+}
+
+// newClusterBlockResponse answers a held write with the block of the cluster, in the format of the errors of
+// Elasticsearch and OpenSearch, so the write fails as if it had been sent
+func newClusterBlockResponse(req *http.Request, block clusterBlock) *http.Response {
+	reason := fmt.Sprintf("%s (write held by the operator until %s)", block.reason, block.until.UTC().Format(time.RFC3339))
+	body, _ := json.Marshal(map[string]any{
+		"error": map[string]string{
+			"type":   clusterBlockException,
+			"reason": reason,
+		},
+		"status": block.statusCode,
+	})
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", block.statusCode, http.StatusText(block.statusCode)),
+		StatusCode:    block.statusCode,
+		Proto:         req.Proto,
+		ProtoMajor:    req.ProtoMajor,
+		ProtoMinor:    req.ProtoMinor,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,156 @@
+package globals
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+)
+
+const (
+	// Actions of the changes made to the objects of the cluster
+	ChangeActionCreate = "Create"
+	ChangeActionUpdate = "Update"
+	ChangeActionDelete = "Delete"
+
+	// States of the objects of a resource in the cluster
+	ResourceStateApplied = "Applied"
+	ResourceStateFailed  = "Failed"
+	ResourceStateIgnored = "Ignored"
+
+	// EventReasonApplyingChanges is the reason of the events recorded before changing an object of the cluster
+	EventReasonApplyingChanges = "ApplyingChanges"
+
+	// EventReasonChangesPending is the reason of the events recorded for the changes found by a dry run
+	EventReasonChangesPending = "ChangesPending"
+
+	// maxFieldValueLength is the length the values of the changed fields are truncated to, so large values do not
+	// bloat the status and the events
+	maxFieldValueLength = 256
+)
+
+// NewObjectChange returns the change that writing the desired body makes to the live object: a creation when the
+// object does not exist, and an update of the fields different in the live object otherwise
+func NewObjectChange(name string, desired, live interface{}, exists bool) v1alpha1.ObjectChange {
+	if !exists {
+		return v1alpha1.ObjectChange{Name: name, Action: ChangeActionCreate}
+	}
+	return v1alpha1.ObjectChange{Name: name, Action: ChangeActionUpdate, Fields: DiffFields(desired, live)}
+}
+
+// RecordChange emits an event describing the change about to be made to an object of the cluster, or the change a
+// dry run would make
+func RecordChange(recorder record.EventRecorder, object runtime.Object, change v1alpha1.ObjectChange, dryRun bool) {
+	if recorder == nil {
+		return
+	}
+
+	message := fmt.Sprintf("%s %s", change.Action, change.Name)
+	if len(change.Fields) > 0 {
+		fields := make([]string, 0, len(change.Fields))
+		for _, field := range change.Fields {
+			fields = append(fields, fmt.Sprintf("%s: %s -> %s", field.Path, valueOrNone(field.Live), valueOrNone(field.Desired)))
+		}
+		message = fmt.Sprintf("%s (%s)", message, strings.Join(fields, "; "))
+	}
+
+	if dryRun {
+		recorder.Event(object, corev1.EventTypeNormal, EventReasonChangesPending, "Dry run: "+message)
+		return
+	}
+	recorder.Event(object, corev1.EventTypeNormal, EventReasonApplyingChanges, message)
+}
+
+// formatFieldValue returns the JSON form of a field value, truncated to maxFieldValueLength, or an empty string
+// when the field is missing
+func formatFieldValue(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		encoded = []byte(fmt.Sprint(value))
+	}
+	if len(encoded) > maxFieldValueLength {
+		return string(encoded[:maxFieldValueLength]) + "..."
+	}
+	return string(encoded)
+}
+
+// valueOrNone returns the value of a changed field, or a marker for the missing fields
+func valueOrNone(value string) string {
+	if value == "" {
+		return "(none)"
+	}
+	return value
+}
+
+// ResourceErrors aggregates the errors of the objects of a resource that failed to sync, sorted by name, or returns
+// nil when none failed
+func ResourceErrors(failed map[string]error) error {
+	if len(failed) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(failed))
+	for name := range failed {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	messages := make([]string, 0, len(names))
+	for _, name := range names {
+		messages = append(messages, fmt.Sprintf("%s: %s", name, failed[name]))
+	}
+
+	return fmt.Errorf("%d failed to sync: %s", len(failed), strings.Join(messages, "; "))
+}
+
+// AppliedResourceStatus returns the status of an object just written to the cluster with the body of the hash
+func AppliedResourceStatus(hash string) v1alpha1.ResourceStatus {
+	now := metav1.Now()
+	return v1alpha1.ResourceStatus{
+		State:           ResourceStateApplied,
+		LastAppliedTime: &now,
+		Hash:            hash,
+	}
+}
+
+// FailedResourceStatus returns the status of an object that failed to sync, keeping the time it was last applied.
+// The hash is cleared, so the object is written again on the next synchronization
+func FailedResourceStatus(previous v1alpha1.ResourceStatus, err error) v1alpha1.ResourceStatus {
+	return v1alpha1.ResourceStatus{
+		State:           ResourceStateFailed,
+		LastAppliedTime: previous.LastAppliedTime,
+		LastError:       err.Error(),
+	}
+}
+
+// CountResources returns the number of objects of a resource applied and failed to sync, from their status. The
+// ignored objects are not counted
+func CountResources(resources map[string]v1alpha1.ResourceStatus) (applied, failed int32) {
+	for _, resourceStatus := range resources {
+		switch resourceStatus.State {
+		case ResourceStateApplied:
+			applied++
+		case ResourceStateFailed:
+			failed++
+		}
+	}
+	return applied, failed
+}
+
+// IgnoredResourceStatus returns the status of an object existing in the cluster that is left as it is, as it was not
+// created by the resource and its differences are ignored
+func IgnoredResourceStatus() v1alpha1.ResourceStatus {
+	return v1alpha1.ResourceStatus{
+		State: ResourceStateIgnored,
+	}
+}
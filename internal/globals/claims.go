@@ -0,0 +1,124 @@
+package globals
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// Object types of the claims, the objects of different types never conflict even when they share a name
+	ClaimTypeIndexTemplate   = "index template"
+	ClaimTypeLifecyclePolicy = "lifecycle policy"
+	ClaimTypeSnapshotPolicy  = "snapshot lifecycle policy"
+)
+
+// ErrDuplicateManagement is returned for the objects of a cluster declared by an older resource too, which are
+// left to it so the resources do not overwrite each other on every sync
+var ErrDuplicateManagement = errors.New("the object is declared by another resource")
+
+// objectClaim holds the objects of a type declared by a resource for a cluster
+type objectClaim struct {
+	cluster string
+	created time.Time
+	names   map[string]bool
+}
+
+// objectClaims holds the claims of every resource, keyed by the object type and the marker of the resource, so
+// the resources declaring the same objects for the same cluster are told apart whatever their namespace. Claims
+// live in memory, and are made again by the syncs of the resources when the operator restarts
+var objectClaims = struct {
+	sync.Mutex
+	claims map[string]map[string]objectClaim
+}{claims: make(map[string]map[string]objectClaim)}
+
+// ClaimObjects records the objects of a type a resource declares for the cluster of the endpoint, replacing its
+// previous claims, and returns the ones declared by an older resource for the same cluster too, which the resource
+// must leave untouched. The oldest resource wins, by creation time and then by marker. Resources in dry run only
+// check the claims of the others, as they never write the objects. conflicts maps the name of every object claimed
+// first by another resource to its error
+func ClaimObjects(endpoint, objectType, marker string, object client.Object, names []string, dryRun bool) map[string]error {
+	cluster := clusterHost(endpoint)
+	created := object.GetCreationTimestamp().Time
+
+	objectClaims.Lock()
+	defer objectClaims.Unlock()
+
+	claims, exists := objectClaims.claims[objectType]
+	if !exists {
+		claims = make(map[string]objectClaim)
+		objectClaims.claims[objectType] = claims
+	}
+
+	conflicts := make(map[string]error)
+	for _, name := range names {
+		if owner := olderClaimant(claims, cluster, name, marker, created); owner != "" {
+			conflicts[name] = fmt.Errorf("%w: %s declared it first for the same cluster", ErrDuplicateManagement,
+				strings.TrimPrefix(owner, managedByPrefix+"/"))
+		}
+	}
+
+	if dryRun {
+		delete(claims, marker)
+		return conflicts
+	}
+	claim := objectClaim{cluster: cluster, created: created, names: make(map[string]bool, len(names))}
+	for _, name := range names {
+		claim.names[name] = true
+	}
+	claims[marker] = claim
+	return conflicts
+}
+
+// ClaimedByOthers reports whether a resource other than the one of the marker declares an object of a type for the
+// cluster of the endpoint, so the resource does not delete an object another one still manages
+func ClaimedByOthers(endpoint, objectType, marker, name string) bool {
+	cluster := clusterHost(endpoint)
+
+	objectClaims.Lock()
+	defer objectClaims.Unlock()
+
+	for claimant, claim := range objectClaims.claims[objectType] {
+		if claimant != marker && claim.cluster == cluster && claim.names[name] {
+			return true
+		}
+	}
+	return false
+}
+
+// ReleaseObjects drops the claims of a resource on the objects of a type, once it is deleted, so the newer resources
+// declaring them take them over on their next sync
+func ReleaseObjects(objectType, marker string) {
+	objectClaims.Lock()
+	defer objectClaims.Unlock()
+
+	delete(objectClaims.claims[objectType], marker)
+}
+
+// olderClaimant returns the marker of the oldest resource declaring the object for the cluster before the one of the
+// marker, empty when there is none
+func olderClaimant(claims map[string]objectClaim, cluster, name, marker string, created time.Time) string {
+	var owners []string
+	for claimant, claim := range claims {
+		if claimant == marker || claim.cluster != cluster || !claim.names[name] {
+			continue
+		}
+		if claim.created.Before(created) || (claim.created.Equal(created) && claimant < marker) {
+			owners = append(owners, claimant)
+		}
+	}
+	if len(owners) == 0 {
+		return ""
+	}
+	return slices.MinFunc(owners, func(a, b string) int {
+		if c := claims[a].created.Compare(claims[b].created); c != 0 {
+			return c
+		}
+		return strings.Compare(a, b)
+	})
+}
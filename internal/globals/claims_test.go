@@ -0,0 +1,201 @@
+package globals
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// claimTestObject returns a resource of the namespace created at the given time
+func claimTestObject(namespace, name string, created time.Time) client.Object {
+	return &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{
+		Namespace:         namespace,
+		Name:              name,
+		CreationTimestamp: metav1.NewTime(created),
+	}}
+}
+
+// resetObjectClaims drops the claims recorded by a test
+func resetObjectClaims(t *testing.T) {
+	t.Cleanup(func() {
+		objectClaims.Lock()
+		defer objectClaims.Unlock()
+		objectClaims.claims = make(map[string]map[string]objectClaim)
+	})
+}
+
+func TestOlderClaimant(t *testing.T) {
+	now := time.Now()
+	earlier, later := now.Add(-time.Hour), now.Add(time.Hour)
+	claimOf := func(cluster string, created time.Time, names ...string) objectClaim {
+		claim := objectClaim{cluster: cluster, created: created, names: make(map[string]bool)}
+		for _, name := range names {
+			claim.names[name] = true
+		}
+		return claim
+	}
+
+	tests := []struct {
+		name   string
+		claims map[string]objectClaim
+		want   string
+	}{
+		{
+			name:   "no other claims",
+			claims: map[string]objectClaim{"b": claimOf("es:9200", now, "logs")},
+			want:   "",
+		},
+		{
+			name:   "older claimant wins",
+			claims: map[string]objectClaim{"c": claimOf("es:9200", earlier, "logs")},
+			want:   "c",
+		},
+		{
+			name:   "newer claimant is ignored",
+			claims: map[string]objectClaim{"a": claimOf("es:9200", later, "logs")},
+			want:   "",
+		},
+		{
+			name:   "same creation time, lower marker wins",
+			claims: map[string]objectClaim{"a": claimOf("es:9200", now, "logs")},
+			want:   "a",
+		},
+		{
+			name:   "same creation time, higher marker is ignored",
+			claims: map[string]objectClaim{"c": claimOf("es:9200", now, "logs")},
+			want:   "",
+		},
+		{
+			name:   "other clusters are ignored",
+			claims: map[string]objectClaim{"a": claimOf("other:9200", earlier, "logs")},
+			want:   "",
+		},
+		{
+			name:   "other objects are ignored",
+			claims: map[string]objectClaim{"a": claimOf("es:9200", earlier, "metrics")},
+			want:   "",
+		},
+		{
+			name: "oldest of several claimants",
+			claims: map[string]objectClaim{
+				"a": claimOf("es:9200", earlier.Add(time.Minute), "logs"),
+				"c": claimOf("es:9200", earlier, "logs"),
+				"d": claimOf("es:9200", now, "logs"),
+			},
+			want: "c",
+		},
+		{
+			name: "several claimants created at the same time, lowest marker wins",
+			claims: map[string]objectClaim{
+				"d": claimOf("es:9200", earlier, "logs"),
+				"c": claimOf("es:9200", earlier, "logs"),
+			},
+			want: "c",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The claim of the resource itself is never its own conflict
+			tt.claims["b"] = claimOf("es:9200", now, "logs")
+
+			if got := olderClaimant(tt.claims, "es:9200", "logs", "b", now); got != tt.want {
+				t.Errorf("olderClaimant() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClaimObjects(t *testing.T) {
+	now := time.Now()
+	const endpoint = "https://es.example.com:9200"
+
+	older := claimTestObject("team-a", "templates", now.Add(-time.Hour))
+	newer := claimTestObject("team-b", "templates", now)
+	olderMarker := ManagedByMarker("IndexTemplate", older)
+	newerMarker := ManagedByMarker("IndexTemplate", newer)
+
+	tests := []struct {
+		name string
+		run  func(t *testing.T)
+	}{
+		{
+			name: "newer resource gets the conflicts of the objects declared first by the older one",
+			run: func(t *testing.T) {
+				ClaimObjects(endpoint, ClaimTypeIndexTemplate, olderMarker, older, []string{"logs", "metrics"}, false)
+				conflicts := ClaimObjects(endpoint, ClaimTypeIndexTemplate, newerMarker, newer, []string{"logs", "traces"}, false)
+
+				if len(conflicts) != 1 || !errors.Is(conflicts["logs"], ErrDuplicateManagement) {
+					t.Fatalf("ClaimObjects() = %v, want a single ErrDuplicateManagement for logs", conflicts)
+				}
+				if !strings.Contains(conflicts["logs"].Error(), "IndexTemplate/team-a/templates") {
+					t.Errorf("conflict %q does not name the older resource", conflicts["logs"])
+				}
+			},
+		},
+		{
+			name: "older resource keeps its objects when it syncs after the newer one",
+			run: func(t *testing.T) {
+				ClaimObjects(endpoint, ClaimTypeIndexTemplate, newerMarker, newer, []string{"logs"}, false)
+				if conflicts := ClaimObjects(endpoint, ClaimTypeIndexTemplate, olderMarker, older, []string{"logs"}, false); len(conflicts) != 0 {
+					t.Errorf("ClaimObjects() = %v, want no conflicts for the older resource", conflicts)
+				}
+			},
+		},
+		{
+			name: "objects of other types and clusters never conflict",
+			run: func(t *testing.T) {
+				ClaimObjects(endpoint, ClaimTypeIndexTemplate, olderMarker, older, []string{"logs"}, false)
+				if conflicts := ClaimObjects(endpoint, ClaimTypeLifecyclePolicy, newerMarker, newer, []string{"logs"}, false); len(conflicts) != 0 {
+					t.Errorf("ClaimObjects() = %v, want no conflicts with another object type", conflicts)
+				}
+				if conflicts := ClaimObjects("https://other.example.com:9200", ClaimTypeIndexTemplate, newerMarker, newer, []string{"logs"}, false); len(conflicts) != 0 {
+					t.Errorf("ClaimObjects() = %v, want no conflicts on another cluster", conflicts)
+				}
+			},
+		},
+		{
+			name: "dry run drops the claims of the resource but reports the conflicts",
+			run: func(t *testing.T) {
+				ClaimObjects(endpoint, ClaimTypeIndexTemplate, olderMarker, older, []string{"logs"}, false)
+				ClaimObjects(endpoint, ClaimTypeIndexTemplate, newerMarker, newer, []string{"logs", "traces"}, false)
+
+				conflicts := ClaimObjects(endpoint, ClaimTypeIndexTemplate, newerMarker, newer, []string{"logs", "traces"}, true)
+				if len(conflicts) != 1 || !errors.Is(conflicts["logs"], ErrDuplicateManagement) {
+					t.Errorf("ClaimObjects() in dry run = %v, want a single ErrDuplicateManagement for logs", conflicts)
+				}
+				if ClaimedByOthers(endpoint, ClaimTypeIndexTemplate, olderMarker, "traces") {
+					t.Errorf("traces still claimed by the resource in dry run")
+				}
+			},
+		},
+		{
+			name: "released objects are taken over by the newer resource",
+			run: func(t *testing.T) {
+				ClaimObjects(endpoint, ClaimTypeIndexTemplate, olderMarker, older, []string{"logs"}, false)
+				if !ClaimedByOthers(endpoint, ClaimTypeIndexTemplate, newerMarker, "logs") {
+					t.Fatalf("logs not claimed by the older resource")
+				}
+
+				ReleaseObjects(ClaimTypeIndexTemplate, olderMarker)
+				if ClaimedByOthers(endpoint, ClaimTypeIndexTemplate, newerMarker, "logs") {
+					t.Errorf("logs still claimed after the older resource released it")
+				}
+				if conflicts := ClaimObjects(endpoint, ClaimTypeIndexTemplate, newerMarker, newer, []string{"logs"}, false); len(conflicts) != 0 {
+					t.Errorf("ClaimObjects() = %v, want no conflicts once released", conflicts)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetObjectClaims(t)
+			tt.run(t)
+		})
+	}
+}
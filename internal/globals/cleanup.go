@@ -0,0 +1,87 @@
+package globals
+
+import (
+	"context"
+	"sync"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
+)
+
+var (
+	failedCleanupsMutex sync.Mutex
+	// failedCleanups are the consecutive failed cleanups of the deleted resources, by UID
+	failedCleanups = make(map[types.UID]int)
+)
+
+// RetryCleanup reports whether the failed cleanup of a deleted resource must be retried, keeping its finalizer.
+// Cleanups are given up once they failed Application.DeletionMaxAttempts times (0 retries them forever), so resources
+// whose cluster is unreachable for good don't get stuck terminating. The failures of a resource are forgotten once
+// its cleanup succeeds or is given up
+func RetryCleanup(object client.Object, err error) bool {
+	failedCleanupsMutex.Lock()
+	defer failedCleanupsMutex.Unlock()
+
+	if err == nil {
+		delete(failedCleanups, object.GetUID())
+		return false
+	}
+
+	failedCleanups[object.GetUID()]++
+	if Application.DeletionMaxAttempts > 0 && failedCleanups[object.GetUID()] >= Application.DeletionMaxAttempts {
+		delete(failedCleanups, object.GetUID())
+		return false
+	}
+	return true
+}
+
+// TargetClusterGone reports whether the cluster discovered for a resourceSelector of a resource of the given
+// namespace no longer exists: its ECK Elasticsearch or OpenSearchCluster resource, or its
+// ElasticsearchClusterConnection, is not found. Manually configured clusters are never considered gone
+func TargetClusterGone(ctx context.Context, resourceSelector *v1alpha1.ResourceSelector, namespace string) bool {
+	if resourceSelector == nil {
+		return false
+	}
+
+	if resourceSelector.ConnectionRef != nil {
+		connectionNamespace := resourceSelector.ConnectionRef.Namespace
+		if connectionNamespace == "" {
+			connectionNamespace = namespace
+		}
+		return resourceNotFound(ctx, schema.GroupVersionResource{
+			Group:    v1alpha1.GroupVersion.Group,
+			Version:  v1alpha1.GroupVersion.Version,
+			Resource: "elasticsearchclusterconnections",
+		}, connectionNamespace, resourceSelector.ConnectionRef.Name)
+	}
+
+	if resourceSelector.Name == "" || resourceSelector.Endpoint != "" || resourceSelector.EndpointFrom != nil ||
+		resourceSelector.CloudID != "" {
+		return false
+	}
+
+	targetNamespace := resourceSelector.Namespace
+	if targetNamespace == "" {
+		targetNamespace = namespace
+	}
+	return resourceNotFound(ctx, schema.GroupVersionResource{
+		Group:    "elasticsearch.k8s.elastic.co",
+		Version:  "v1",
+		Resource: "elasticsearches",
+	}, targetNamespace, resourceSelector.Name) && resourceNotFound(ctx, schema.GroupVersionResource{
+		Group:    "opensearch.opster.io",
+		Version:  "v1",
+		Resource: "opensearchclusters",
+	}, targetNamespace, resourceSelector.Name)
+}
+
+// resourceNotFound reports whether a resource is known not to exist. Other errors reading it report it as existing
+func resourceNotFound(ctx context.Context, resource schema.GroupVersionResource, namespace, name string) bool {
+	_, err := Application.KubeRawClient.Resource(resource).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	return apierrors.IsNotFound(err)
+}
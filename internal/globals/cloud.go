@@ -0,0 +1,33 @@
+package globals
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// cloudIDEndpoint returns the Elasticsearch URL of an Elastic Cloud deployment from its Cloud ID
+// ({name}:{base64 of host$elasticsearch-uuid$kibana-uuid}). The host may carry a port, which is kept in the URL
+func cloudIDEndpoint(cloudID string) (string, error) {
+	separator := strings.LastIndex(cloudID, ":")
+	if separator < 0 {
+		return "", fmt.Errorf("invalid cloudID: expected the format <name>:<base64 data>")
+	}
+
+	data, err := base64.StdEncoding.DecodeString(cloudID[separator+1:])
+	if err != nil {
+		return "", fmt.Errorf("invalid cloudID: %w", err)
+	}
+
+	parts := strings.Split(string(data), "$")
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return "", fmt.Errorf("invalid cloudID: the decoded value must contain the host and the Elasticsearch UUID")
+	}
+
+	host, port, err := net.SplitHostPort(parts[0])
+	if err != nil {
+		return fmt.Sprintf("https://%s.%s", parts[1], parts[0]), nil
+	}
+	return fmt.Sprintf("https://%s.%s:%s", parts[1], host, port), nil
+}
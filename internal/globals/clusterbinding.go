@@ -0,0 +1,123 @@
+package globals
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// Condition type for the access of a resource to its target cluster
+	ConditionTypeClusterAccess = "ClusterAccess"
+
+	ConditionReasonClusterBindingFound    = "ClusterBindingFound"
+	ConditionReasonClusterBindingNotFound = "ClusterBindingNotFound"
+	ConditionReasonClusterBindingError    = "ClusterBindingError"
+)
+
+// +kubebuilder:rbac:groups=elastic-config-operator.freepik.com,resources=elasticclusterbindings,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+
+// AuthorizeClusterAccess checks that a resource of the given namespace is allowed to target the cluster of the
+// resourceSelector. Clusters of the same namespace are always allowed, while clusters of other namespaces need an
+// ElasticClusterBinding in their namespace. The check only runs when cluster bindings are enforced, and its result
+// is recorded in the ClusterAccess condition
+func AuthorizeClusterAccess(ctx context.Context, resourceSelector *v1alpha1.ResourceSelector, namespace string, conditions *[]metav1.Condition) error {
+	logger := log.FromContext(ctx)
+
+	if !Application.EnforceClusterBindings {
+		return nil
+	}
+
+	// Referenced connections are bound like the ECK clusters, using the name of the ElasticsearchClusterConnection
+	clusterNamespace, clusterName := resourceSelector.Namespace, resourceSelector.Name
+	if resourceSelector.ConnectionRef != nil {
+		clusterNamespace, clusterName = resourceSelector.ConnectionRef.Namespace, resourceSelector.ConnectionRef.Name
+	}
+	if clusterNamespace == "" || clusterNamespace == namespace {
+		return nil
+	}
+
+	allowed, err := isClusterBound(ctx, clusterNamespace, clusterName, namespace)
+	if err != nil {
+		UpdateCondition(conditions, NewCondition(ConditionTypeClusterAccess, metav1.ConditionUnknown,
+			ConditionReasonClusterBindingError, err.Error()))
+		return err
+	}
+
+	if !allowed {
+		err := fmt.Errorf("namespace %s is not allowed to target cluster %s/%s: no ElasticClusterBinding in namespace %s grants it",
+			namespace, clusterNamespace, clusterName, clusterNamespace)
+		UpdateCondition(conditions, NewCondition(ConditionTypeClusterAccess, metav1.ConditionFalse,
+			ConditionReasonClusterBindingNotFound, err.Error()))
+		return err
+	}
+
+	logger.Info(fmt.Sprintf("Namespace %s is allowed to target cluster %s/%s", namespace, clusterNamespace, clusterName))
+	UpdateCondition(conditions, NewCondition(ConditionTypeClusterAccess, metav1.ConditionTrue,
+		ConditionReasonClusterBindingFound, fmt.Sprintf("Access to cluster %s/%s granted by an ElasticClusterBinding", clusterNamespace, clusterName)))
+
+	return nil
+}
+
+// isClusterBound reports whether an ElasticClusterBinding of the cluster namespace allows the namespace to target the cluster
+func isClusterBound(ctx context.Context, clusterNamespace, clusterName, namespace string) (bool, error) {
+	objects, err := Application.KubeRawClient.Resource(schema.GroupVersionResource{
+		Group:    v1alpha1.GroupVersion.Group,
+		Version:  v1alpha1.GroupVersion.Version,
+		Resource: "elasticclusterbindings",
+	}).Namespace(clusterNamespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return false, fmt.Errorf("failed to list the ElasticClusterBindings of namespace %s: %w", clusterNamespace, err)
+	}
+
+	// Labels of the namespace are only read when a binding uses a namespaceSelector
+	var namespaceLabels labels.Set
+	namespaceLabelsLoaded := false
+
+	for _, object := range objects.Items {
+		binding := &v1alpha1.ElasticClusterBinding{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(object.Object, binding); err != nil {
+			return false, fmt.Errorf("failed to decode ElasticClusterBinding %s/%s: %w", object.GetNamespace(), object.GetName(), err)
+		}
+
+		if !slices.Contains(binding.Spec.Clusters, clusterName) {
+			continue
+		}
+
+		if slices.Contains(binding.Spec.Namespaces, namespace) {
+			return true, nil
+		}
+
+		if binding.Spec.NamespaceSelector == nil {
+			continue
+		}
+
+		selector, err := metav1.LabelSelectorAsSelector(binding.Spec.NamespaceSelector)
+		if err != nil {
+			return false, fmt.Errorf("invalid namespaceSelector in ElasticClusterBinding %s/%s: %w", binding.Namespace, binding.Name, err)
+		}
+
+		if !namespaceLabelsLoaded {
+			namespaceObject, err := Application.KubeRawCoreClient.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
+			if err != nil {
+				return false, fmt.Errorf("failed to get namespace %s: %w", namespace, err)
+			}
+			namespaceLabels = labels.Set(namespaceObject.Labels)
+			namespaceLabelsLoaded = true
+		}
+
+		if selector.Matches(namespaceLabels) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,140 @@
+package globals
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+
+	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/pools"
+	"github.com/elastic/go-elasticsearch/v8"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// It can not collide with the endpoint#hash keys of the clusters configured by a ResourceSelector
+// It can not collide with the namespace_name keys of the clusters selected by name
+func ClusterConnectionKey(namespace, name string) string {
+	return fmt.Sprintf("connection/%s/%s", namespace, name)
+}
+
+// getOrCreateClusterConnection retrieves or creates the connection defined by a referenced ElasticsearchClusterConnection
+func getOrCreateClusterConnection(ctx context.Context, connectionRef *v1alpha1.ClusterConnectionReference, crNamespace string, elasticsearchConnectionsPool *pools.ElasticsearchConnectionsStore) (*pools.ElasticsearchConnection, error) {
+	logger := log.FromContext(ctx)
+
+	namespace := connectionRef.Namespace
+	if namespace == "" {
+		namespace = crNamespace
+	}
+
+	connectionKey := ClusterConnectionKey(namespace, connectionRef.Name)
+	if connection, exists := elasticsearchConnectionsPool.Get(connectionKey); exists {
+		logger.Info(fmt.Sprintf("Using existing Elasticsearch connection %s", connectionKey))
+		return connection, nil
+	}
+
+	logger.Info(fmt.Sprintf("Creating new Elasticsearch connection %s", connectionKey))
+
+	object, err := Application.KubeRawClient.Resource(schema.GroupVersionResource{
+		Group:    v1alpha1.GroupVersion.Group,
+		Version:  v1alpha1.GroupVersion.Version,
+		Resource: "elasticsearchclusterconnections",
+	}).Namespace(namespace).Get(ctx, connectionRef.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ElasticsearchClusterConnection %s/%s: %w", namespace, connectionRef.Name, err)
+	}
+
+	clusterConnection := &v1alpha1.ElasticsearchClusterConnection{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(object.Object, clusterConnection); err != nil {
+		return nil, fmt.Errorf("failed to decode ElasticsearchClusterConnection %s/%s: %w", namespace, connectionRef.Name, err)
+	}
+
+	connection, err := NewClusterConnection(ctx, clusterConnection)
+	if err != nil {
+		return nil, err
+	}
+
+	elasticsearchConnectionsPool.Set(connectionKey, connection)
+
+	return connection, nil
+}
+
+// NewClusterConnection creates a connection from the endpoint, authentication and TLS settings of an
+// ElasticsearchClusterConnection. Secrets without namespace are read from the namespace of the connection
+func NewClusterConnection(ctx context.Context, clusterConnection *v1alpha1.ElasticsearchClusterConnection) (*pools.ElasticsearchConnection, error) {
+	spec := &clusterConnection.Spec
+
+	cfg := elasticsearch.Config{
+		Addresses: []string{spec.Endpoint},
+		Header:    newHeader(spec.Headers),
+
+		CompressRequestBody: spec.Compression,
+	}
+
+	if spec.APIKeySecretRef != nil {
+		apiKey, err := GetSecretValue(ctx, spec.APIKeySecretRef, clusterConnection.Namespace)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get API key: %w", err)
+		}
+		cfg.APIKey = apiKey
+	}
+
+	if spec.Username != "" {
+		if spec.PasswordSecretRef == nil {
+			return nil, fmt.Errorf("passwordSecretRef is required when username is set")
+		}
+		password, err := GetSecretValue(ctx, spec.PasswordSecretRef, clusterConnection.Namespace)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get password: %w", err)
+		}
+		cfg.Username = spec.Username
+		cfg.Password = password
+	}
+
+	if err := applyNodeDiscovery(&cfg, spec.NodeDiscovery); err != nil {
+		return nil, err
+	}
+
+	if err := validateEndpointScheme(spec.Endpoint); err != nil {
+		return nil, err
+	}
+
+	// Without TLS settings, the cluster certificate is verified against the system CAs. Plain HTTP endpoints
+	// skip the TLS configuration entirely
+	var tlsConfig *tls.Config
+	var caCert string
+	if !isPlainHTTP(spec.Endpoint) {
+		tlsConfig = &tls.Config{}
+	}
+	if spec.TLS != nil && tlsConfig != nil {
+		if spec.TLS.CACertSecretRef != nil {
+			var err error
+			caCert, err = GetSecretValue(ctx, spec.TLS.CACertSecretRef, clusterConnection.Namespace)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get CA certificate: %w", err)
+			}
+			caCertPool := x509.NewCertPool()
+			if !caCertPool.AppendCertsFromPEM([]byte(caCert)) {
+				return nil, fmt.Errorf("no valid PEM certificate found in the CA certificate secret")
+			}
+			tlsConfig.RootCAs = caCertPool
+		}
+		tlsConfig.InsecureSkipVerify = spec.TLS.InsecureSkipVerify
+	}
+
+	options := transportOptions{tlsConfig: tlsConfig}
+	if err := options.applyTimeouts(spec.Timeouts); err != nil {
+		return nil, err
+	}
+
+	connection, err := newElasticsearchConnection(ctx, cfg, options, spec.ClusterType)
+	if err != nil {
+		return nil, err
+	}
+	connection.CACert = caCert
+
+	return connection, nil
+}
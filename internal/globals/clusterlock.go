@@ -0,0 +1,63 @@
+package globals
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// clusterLock is the lock of a target cluster, with the number of resources holding or waiting for it, so it is
+// dropped once no resource needs it
+type clusterLock struct {
+	lock  chan struct{}
+	users int
+}
+
+// clusterLocks holds the lock of every target cluster, keyed by the host of its endpoint, so the resources
+// targeting the same cluster write to it one at a time, while different clusters are synced in parallel. Only
+// the clusters being synced have a lock, so the hosts that go away are not kept forever
+var clusterLocks = struct {
+	sync.Mutex
+	locks map[string]*clusterLock
+}{locks: make(map[string]*clusterLock)}
+
+// LockCluster waits until no other resource is syncing the cluster of the endpoint, so the writes of different
+// resources (e.g., the reset and update of cluster settings) are not interleaved. The returned function releases
+// the lock, and waiting is given up when the context is cancelled
+func LockCluster(ctx context.Context, endpoint string) (func(), error) {
+	key := clusterHost(endpoint)
+
+	clusterLocks.Lock()
+	entry, exists := clusterLocks.locks[key]
+	if !exists {
+		entry = &clusterLock{lock: make(chan struct{}, 1)}
+		clusterLocks.locks[key] = entry
+	}
+	entry.users++
+	clusterLocks.Unlock()
+
+	select {
+	case entry.lock <- struct{}{}:
+		var once sync.Once
+		return func() {
+			once.Do(func() {
+				<-entry.lock
+				releaseClusterLock(key, entry)
+			})
+		}, nil
+	case <-ctx.Done():
+		releaseClusterLock(key, entry)
+		return nil, fmt.Errorf("cluster %s still being synced by another resource: %w", key, ctx.Err())
+	}
+}
+
+// releaseClusterLock drops a user of the lock of a cluster, deleting the lock once it has no users left
+func releaseClusterLock(key string, entry *clusterLock) {
+	clusterLocks.Lock()
+	defer clusterLocks.Unlock()
+
+	entry.users--
+	if entry.users == 0 && clusterLocks.locks[key] == entry {
+		delete(clusterLocks.locks, key)
+	}
+}
@@ -0,0 +1,57 @@
+package globals
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// Condition types following the kstatus conventions, understood by Argo CD, Flux and kubectl wait.
+	// Ready has normal polarity, while Reconciling and Stalled are only True when something needs attention
+	ConditionTypeReady       = "Ready"
+	ConditionTypeReconciling = "Reconciling"
+	ConditionTypeStalled     = "Stalled"
+
+	ConditionReasonProgressing     = "Progressing"
+	ConditionReasonDryRun          = "DryRun"
+	ConditionReasonPartiallySynced = "PartiallySynced"
+	ConditionReasonSyncFailed      = "SyncFailed"
+)
+
+// SetReconcilingConditions marks a resource as being reconciled: it is not Ready yet, but not Stalled either
+func SetReconcilingConditions(conditions *[]metav1.Condition, reason, message string) {
+	UpdateCondition(conditions, NewCondition(ConditionTypeReady, metav1.ConditionFalse, reason, message))
+	UpdateCondition(conditions, NewCondition(ConditionTypeReconciling, metav1.ConditionTrue, reason, message))
+	UpdateCondition(conditions, NewCondition(ConditionTypeStalled, metav1.ConditionFalse, reason, message))
+}
+
+// SetReadyConditions marks a resource as Ready, once its spec has been reconciled
+func SetReadyConditions(conditions *[]metav1.Condition, reason, message string) {
+	UpdateCondition(conditions, NewCondition(ConditionTypeReady, metav1.ConditionTrue, reason, message))
+	UpdateCondition(conditions, NewCondition(ConditionTypeReconciling, metav1.ConditionFalse, reason, message))
+	UpdateCondition(conditions, NewCondition(ConditionTypeStalled, metav1.ConditionFalse, reason, message))
+}
+
+// SetStalledConditions marks a resource as Stalled, as its reconciliation failed and will keep failing until the
+// spec or the target cluster are fixed
+func SetStalledConditions(conditions *[]metav1.Condition, reason, message string) {
+	UpdateCondition(conditions, NewCondition(ConditionTypeReady, metav1.ConditionFalse, reason, message))
+	UpdateCondition(conditions, NewCondition(ConditionTypeReconciling, metav1.ConditionFalse, reason, message))
+	UpdateCondition(conditions, NewCondition(ConditionTypeStalled, metav1.ConditionTrue, reason, message))
+}
+
+// SetSyncingConditions marks a resource as Reconciling when its spec changed since the last status update, or when
+// it was not Ready yet. Periodic syncs of an unchanged spec keep it Ready, so health checks don't flap on every sync
+func SetSyncingConditions(conditions *[]metav1.Condition, observedGeneration, generation int64, message string) {
+	if IsPeriodicSync(*conditions, observedGeneration, generation) {
+		return
+	}
+	SetReconcilingConditions(conditions, ConditionReasonProgressing, message)
+}
+
+// IsPeriodicSync reports whether a sync is a periodic one of a Ready resource whose spec did not change since the last
+// status update. These syncs keep the resource Ready and don't report the Syncing phase, so they only write the
+// status once they end
+func IsPeriodicSync(conditions []metav1.Condition, observedGeneration, generation int64) bool {
+	return observedGeneration == generation && meta.IsStatusConditionTrue(conditions, ConditionTypeReady)
+}
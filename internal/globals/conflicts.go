@@ -0,0 +1,98 @@
+package globals
+
+import (
+	"context"
+	"maps"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// lastSyncTimeInterval is how often the lastSyncTime of the resources whose status doesn't change otherwise is written,
+// so it stays close to the time of the last sync without writing the status on every periodic sync
+const lastSyncTimeInterval = 10 * time.Minute
+
+// PatchStatus writes the status of a resource once per reconcile, as a merge patch of the changes made to it since
+// the original version read, so it never conflicts with the writes of the metadata and spec. Nothing is written when
+// the status is the original one but for a lastSyncTime bumped by less than lastSyncTimeInterval, so periodic syncs
+// changing nothing don't load the API server
+func PatchStatus(ctx context.Context, c client.Client, object, original client.Object) error {
+	if statusUnchanged(object, original) {
+		return nil
+	}
+	return c.Status().Patch(ctx, object, client.MergeFrom(original))
+}
+
+// statusUnchanged reports whether the status of a resource is the original one, compared in their JSON form without
+// the lastSyncTime, which every sync bumps, unless it was bumped by lastSyncTimeInterval or more
+func statusUnchanged(object, original client.Object) bool {
+	current, synced, err := comparableStatus(object)
+	if err != nil {
+		return false
+	}
+	previous, previouslySynced, err := comparableStatus(original)
+	if err != nil {
+		return false
+	}
+	return equality.Semantic.DeepEqual(current, previous) && synced.Sub(previouslySynced) < lastSyncTimeInterval
+}
+
+// comparableStatus returns the status of a resource in its JSON form without the lastSyncTime, and the lastSyncTime
+// (zero when it is not set)
+func comparableStatus(object client.Object) (interface{}, time.Time, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(object)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	status, ok := content["status"].(map[string]interface{})
+	if !ok {
+		return content["status"], time.Time{}, nil
+	}
+	// The content of unstructured resources is their own, so the status is cloned before dropping the field
+	status = maps.Clone(status)
+	lastSyncTime, _ := status["lastSyncTime"].(string)
+	delete(status, "lastSyncTime")
+	synced, _ := time.Parse(time.RFC3339, lastSyncTime)
+	return status, synced, nil
+}
+
+// AddFinalizer adds a finalizer to a resource, retrying on conflicts with the latest version of the resource, which
+// replaces the one passed
+func AddFinalizer(ctx context.Context, c client.Client, object client.Object, finalizer string) error {
+	return updateFinalizers(ctx, c, object, func() bool {
+		return controllerutil.AddFinalizer(object, finalizer)
+	})
+}
+
+// RemoveFinalizer removes a finalizer from a resource, retrying on conflicts with the latest version of the
+// resource. Resources already gone are ignored
+func RemoveFinalizer(ctx context.Context, c client.Client, object client.Object, finalizer string) error {
+	return client.IgnoreNotFound(updateFinalizers(ctx, c, object, func() bool {
+		return controllerutil.RemoveFinalizer(object, finalizer)
+	}))
+}
+
+// updateFinalizers updates a resource once the finalizers are changed, getting its latest version on conflicts to
+// change them again. Nothing is updated when the finalizers are already the desired ones
+func updateFinalizers(ctx context.Context, c client.Client, object client.Object, change func() bool) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if !change() {
+			return nil
+		}
+
+		err := c.Update(ctx, object)
+		if !apierrors.IsConflict(err) {
+			return err
+		}
+
+		if getErr := c.Get(ctx, client.ObjectKeyFromObject(object), object); getErr != nil {
+			return getErr
+		}
+		return err
+	})
+}
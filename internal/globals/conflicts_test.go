@@ -0,0 +1,89 @@
+package globals
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
+)
+
+func TestStatusUnchanged(t *testing.T) {
+	synced := metav1.NewTime(time.Now().Add(-time.Minute))
+	original := &v1alpha1.IndexTemplate{Status: v1alpha1.IndexTemplateStatus{
+		Phase:            "Ready",
+		AppliedResources: []string{"logs"},
+		LastSyncTime:     &synced,
+	}}
+
+	tests := []struct {
+		name   string
+		change func(status *v1alpha1.IndexTemplateStatus)
+		want   bool
+	}{
+		{
+			name:   "same status",
+			change: func(status *v1alpha1.IndexTemplateStatus) {},
+			want:   true,
+		},
+		{
+			name: "only the lastSyncTime bumped",
+			change: func(status *v1alpha1.IndexTemplateStatus) {
+				now := metav1.Now()
+				status.LastSyncTime = &now
+			},
+			want: true,
+		},
+		{
+			name: "only the lastSyncTime bumped by lastSyncTimeInterval",
+			change: func(status *v1alpha1.IndexTemplateStatus) {
+				later := metav1.NewTime(synced.Add(lastSyncTimeInterval))
+				status.LastSyncTime = &later
+			},
+			want: false,
+		},
+		{
+			name: "phase changed",
+			change: func(status *v1alpha1.IndexTemplateStatus) {
+				status.Phase = "Error"
+			},
+			want: false,
+		},
+		{
+			name: "applied resources changed along with the lastSyncTime",
+			change: func(status *v1alpha1.IndexTemplateStatus) {
+				now := metav1.Now()
+				status.LastSyncTime = &now
+				status.AppliedResources = append(status.AppliedResources, "metrics")
+			},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			object := original.DeepCopy()
+			tt.change(&object.Status)
+			if got := statusUnchanged(object, original); got != tt.want {
+				t.Errorf("statusUnchanged() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStatusUnchangedKeepsUnstructuredContent(t *testing.T) {
+	original := &unstructured.Unstructured{Object: map[string]interface{}{
+		"status": map[string]interface{}{"phase": "Ready", "lastSyncTime": "2025-01-01T00:00:00Z"},
+	}}
+	object := original.DeepCopy()
+	_ = unstructured.SetNestedField(object.Object, "2025-01-01T00:01:00Z", "status", "lastSyncTime")
+
+	if !statusUnchanged(object, original) {
+		t.Errorf("statusUnchanged() = false, want true when only the lastSyncTime changed")
+	}
+	if _, found, _ := unstructured.NestedString(object.Object, "status", "lastSyncTime"); !found {
+		t.Errorf("lastSyncTime dropped from the unstructured resource")
+	}
+}
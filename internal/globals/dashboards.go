@@ -0,0 +1,160 @@
+package globals
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/pools"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// DashboardsConnectionKey returns the pool key of an OpenSearch Dashboards connection.
+// OpenSearch Dashboards shares the Kibana pool, and this key can not collide with the namespace_name keys of Kibana
+func DashboardsConnectionKey(namespace string, dashboardsSelector *v1alpha1.OpenSearchDashboardsSelector) string {
+	return fmt.Sprintf("dashboards/%s/%s@%s", namespace, dashboardsSelector.Username, dashboardsSelector.Endpoint)
+}
+
+// GetOrCreateDashboardsConnection retrieves or creates a connection to an OpenSearch Dashboards instance.
+// OpenSearch Dashboards is not managed by ECK, so the endpoint and credentials are always configured manually
+func GetOrCreateDashboardsConnection(ctx context.Context, dashboardsSelector *v1alpha1.OpenSearchDashboardsSelector, crNamespace string, kibanaConnectionsPool *pools.KibanaConnectionsStore) (*pools.KibanaConnection, error) {
+	logger := log.FromContext(ctx)
+
+	dashboardsKey := DashboardsConnectionKey(crNamespace, dashboardsSelector)
+
+	// Check if connection already exists in pool
+	if connection, exists := kibanaConnectionsPool.Get(dashboardsKey); exists {
+		logger.Info(fmt.Sprintf("Using existing OpenSearch Dashboards connection for %s", dashboardsKey))
+		return connection, nil
+	}
+
+	logger.Info(fmt.Sprintf("Creating new OpenSearch Dashboards connection for %s", dashboardsKey))
+
+	var password string
+	var caCert []byte
+
+	// Get password from secret (optional, Dashboards without the security plugin accept anonymous requests)
+	if dashboardsSelector.Username != "" {
+		if dashboardsSelector.PasswordSecretRef == nil {
+			return nil, fmt.Errorf("passwordSecretRef is required when username is set")
+		}
+		var err error
+		password, err = GetSecretValue(ctx, dashboardsSelector.PasswordSecretRef, crNamespace)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get password: %w", err)
+		}
+	}
+
+	// Get CA certificate from secret (optional)
+	if dashboardsSelector.CACertSecretRef != nil {
+		caCertValue, err := GetSecretValue(ctx, dashboardsSelector.CACertSecretRef, crNamespace)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get CA certificate: %w", err)
+		}
+		caCert = []byte(caCertValue)
+	}
+
+	// Create TLS config
+	var tlsConfig *tls.Config
+	if len(caCert) > 0 {
+		// Use provided CA certificate
+		caCertPool := x509.NewCertPool()
+		caCertPool.AppendCertsFromPEM(caCert)
+		tlsConfig = &tls.Config{
+			RootCAs: caCertPool,
+		}
+	} else {
+		// No CA certificate provided - use system's default or skip verification
+		tlsConfig = &tls.Config{
+			InsecureSkipVerify: true, // Use with caution - only for development/testing
+		}
+		logger.Info("No CA certificate provided, using InsecureSkipVerify (not recommended for production)")
+	}
+
+	connection := &pools.KibanaConnection{
+		Endpoint: strings.TrimSuffix(dashboardsSelector.Endpoint, "/"),
+		Username: dashboardsSelector.Username,
+		Password: password,
+		CACert:   string(caCert),
+		Client: &http.Client{
+			Transport: newAuditTransport(&http.Transport{
+				TLSClientConfig:       tlsConfig,
+				ResponseHeaderTimeout: 10 * time.Second,
+				IdleConnTimeout:       10 * time.Second,
+			}),
+		},
+	}
+
+	// Verify connection and get the OpenSearch Dashboards version
+	version, err := detectDashboardsVersion(ctx, connection)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to OpenSearch Dashboards: %w", err)
+	}
+	connection.Version = version
+
+	logger.Info(fmt.Sprintf("Connected to OpenSearch Dashboards version %s", version))
+
+	// Store connection in pool
+	kibanaConnectionsPool.Set(dashboardsKey, connection)
+
+	return connection, nil
+}
+
+// PerformDashboardsRequest sends an authenticated request to the OpenSearch Dashboards API.
+// When tenant is set, the request is scoped to that security tenant (global, private or a custom tenant)
+func PerformDashboardsRequest(ctx context.Context, connection *pools.KibanaConnection, method, tenant, path string, body io.Reader, contentType string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, connection.Endpoint+path, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	if connection.Username != "" {
+		req.SetBasicAuth(connection.Username, connection.Password)
+	}
+	// OpenSearch Dashboards rejects mutating requests without this header (CSRF protection)
+	req.Header.Set("osd-xsrf", "true")
+	if tenant != "" {
+		req.Header.Set("securitytenant", tenant)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	return connection.Client.Do(req)
+}
+
+// detectDashboardsVersion verifies the connection to OpenSearch Dashboards and returns its version
+func detectDashboardsVersion(ctx context.Context, connection *pools.KibanaConnection) (string, error) {
+	res, err := PerformDashboardsRequest(ctx, connection, http.MethodGet, "", "/api/status", nil, "")
+	if err != nil {
+		return "", fmt.Errorf("failed to get OpenSearch Dashboards status: %w", err)
+	}
+	defer res.Body.Close()
+
+	bodyBytes, err := io.ReadAll(res.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if res.StatusCode >= 400 {
+		return "", fmt.Errorf("opensearch dashboards status request failed: %s - %s", res.Status, string(bodyBytes))
+	}
+
+	var status struct {
+		Version struct {
+			Number string `json:"number"`
+		} `json:"version"`
+	}
+	if err := json.Unmarshal(bodyBytes, &status); err != nil {
+		return "", fmt.Errorf("failed to parse OpenSearch Dashboards status: %w", err)
+	}
+
+	return status.Version.Number, nil
+}
@@ -0,0 +1,148 @@
+package globals
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	// Condition type for the dependencies of a resource, only set on resources with dependsOn
+	ConditionTypeDependenciesReady = "DependenciesReady"
+
+	ConditionReasonDependenciesReady      = "DependenciesReady"
+	ConditionReasonWaitingForDependencies = "WaitingForDependencies"
+
+	// dependencyReadyPhase is the phase of the resources synced successfully
+	dependencyReadyPhase = "Ready"
+)
+
+// ErrDependenciesNotReady is returned while some dependencies of a resource are missing or not Ready. Resources
+// failing with it are retried with backoff, without changing the cluster
+var ErrDependenciesNotReady = errors.New("some dependencies are not ready")
+
+// dependencyResource is the resource of a kind that can be depended on
+type dependencyResource struct {
+	resource   string
+	namespaced bool
+}
+
+// dependencyResources are the resources of the kinds accepted in dependsOn, keyed by kind
+var dependencyResources = map[string]dependencyResource{
+	"IndexTemplate":                    {"indextemplates", true},
+	"ClusterIndexTemplate":             {"clusterindextemplates", false},
+	"IndexLifecyclePolicy":             {"indexlifecyclepolicies", true},
+	"ClusterIndexLifecyclePolicy":      {"clusterindexlifecyclepolicies", false},
+	"SnapshotLifecyclePolicy":          {"snapshotlifecyclepolicies", true},
+	"SnapshotRepository":               {"snapshotrepositories", true},
+	"IndexStateManagement":             {"indexstatemanagements", true},
+	"ClusterSettings":                  {"clustersettings", true},
+	"ElasticConfigBundle":              {"elasticconfigbundles", true},
+	"AutoscalingPolicy":                {"autoscalingpolicies", true},
+	"QueryRuleset":                     {"queryrulesets", true},
+	"OpenSearchAlertingMonitor":        {"opensearchalertingmonitors", true},
+	"ApplicationPrivilege":             {"applicationprivileges", true},
+	"ElasticsearchRawResource":         {"elasticsearchrawresources", true},
+	"MachineLearningJob":               {"machinelearningjobs", true},
+	"NodeShutdown":                     {"nodeshutdowns", true},
+	"OpenSearchAnomalyDetector":        {"opensearchanomalydetectors", true},
+	"OpenSearchNotificationChannel":    {"opensearchnotificationchannels", true},
+	"SearchApplication":                {"searchapplications", true},
+	"SynonymsSet":                      {"synonymssets", true},
+	"KibanaAlertRule":                  {"kibanaalertrules", true},
+	"KibanaSavedObjects":               {"kibanasavedobjects", true},
+	"KibanaSpace":                      {"kibanaspaces", true},
+	"OpenSearchDashboardsSavedObjects": {"opensearchdashboardssavedobjects", true},
+	"ElasticsearchClusterConnection":   {"elasticsearchclusterconnections", true},
+	"FleetAgentPolicy":                 {"fleetagentpolicies", true},
+	"IndexLifecycle":                   {"indexlifecycles", true},
+}
+
+// CheckDependencies fails with ErrDependenciesNotReady when any of the dependencies of a resource is missing, or is
+// not Ready with its current spec. namespace is the namespace of the resource, used for the dependencies without one
+func CheckDependencies(ctx context.Context, dependsOn []v1alpha1.Dependency, namespace string) error {
+	var pending []string
+	for _, dependency := range dependsOn {
+		reason, err := dependencyPending(ctx, dependency, namespace)
+		if err != nil {
+			return err
+		}
+		if reason != "" {
+			pending = append(pending, reason)
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrDependenciesNotReady, strings.Join(pending, ", "))
+}
+
+// dependencyPending returns why a dependency is not Ready yet, empty when it is
+func dependencyPending(ctx context.Context, dependency v1alpha1.Dependency, namespace string) (string, error) {
+	dependencyResource, ok := dependencyResources[dependency.Kind]
+	if !ok {
+		return "", fmt.Errorf("unsupported dependency kind %s", dependency.Kind)
+	}
+
+	resourceClient := Application.KubeRawClient.Resource(schema.GroupVersionResource{
+		Group:    v1alpha1.GroupVersion.Group,
+		Version:  v1alpha1.GroupVersion.Version,
+		Resource: dependencyResource.resource,
+	})
+
+	var object *unstructured.Unstructured
+	var err error
+	reference := fmt.Sprintf("%s %s", dependency.Kind, dependency.Name)
+	if dependencyResource.namespaced {
+		if dependency.Namespace != "" {
+			namespace = dependency.Namespace
+		}
+		reference = fmt.Sprintf("%s %s/%s", dependency.Kind, namespace, dependency.Name)
+		object, err = resourceClient.Namespace(namespace).Get(ctx, dependency.Name, metav1.GetOptions{})
+	} else {
+		object, err = resourceClient.Get(ctx, dependency.Name, metav1.GetOptions{})
+	}
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return fmt.Sprintf("%s not found", reference), nil
+		}
+		return "", fmt.Errorf("failed to get %s: %w", reference, err)
+	}
+
+	phase, _, _ := unstructured.NestedString(object.Object, "status", "phase")
+	observedGeneration, _, _ := unstructured.NestedInt64(object.Object, "status", "observedGeneration")
+	switch {
+	case phase != dependencyReadyPhase:
+		if phase == "" {
+			phase = "not synced yet"
+		}
+		return fmt.Sprintf("%s is %s", reference, phase), nil
+	case observedGeneration < object.GetGeneration():
+		return fmt.Sprintf("%s has not synced its latest spec yet", reference), nil
+	}
+	return "", nil
+}
+
+// UpdateDependenciesReadyCondition records the result of a check of the dependencies of a resource in the
+// DependenciesReady condition, which is removed when the resource has no dependencies. Failures reading them leave
+// it untouched
+func UpdateDependenciesReadyCondition(conditions *[]metav1.Condition, dependsOn []v1alpha1.Dependency, err error) {
+	switch {
+	case len(dependsOn) == 0:
+		meta.RemoveStatusCondition(conditions, ConditionTypeDependenciesReady)
+	case err == nil:
+		UpdateCondition(conditions, NewCondition(ConditionTypeDependenciesReady, metav1.ConditionTrue,
+			ConditionReasonDependenciesReady, fmt.Sprintf("All the %d dependencies are ready", len(dependsOn))))
+	case errors.Is(err, ErrDependenciesNotReady):
+		UpdateCondition(conditions, NewCondition(ConditionTypeDependenciesReady, metav1.ConditionFalse,
+			ConditionReasonWaitingForDependencies, err.Error()))
+	}
+}
@@ -0,0 +1,180 @@
+package globals
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+)
+
+const (
+	// Condition type for the out-of-band changes of the objects managed in the cluster
+	ConditionTypeDrifted = "Drifted"
+
+	ConditionReasonDriftCorrected = "DriftCorrected"
+	ConditionReasonNoDrift        = "NoDrift"
+	ConditionReasonDriftPending   = "DriftPending"
+
+	// EventReasonDriftDetected is the reason of the events recorded for every drifted object
+	EventReasonDriftDetected = "DriftDetected"
+)
+
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+// DiffJSON returns the dotted paths of the desired fields that are missing or different in the live object.
+// Fields only present in the live object are ignored, as clusters add defaults to the stored objects, and scalars
+// are compared by their string form, as clusters return the numbers and booleans of settings as strings
+func DiffJSON(desired, live interface{}) []string {
+	fields := DiffFields(desired, live)
+	paths := make([]string, 0, len(fields))
+	for _, field := range fields {
+		paths = append(paths, field.Path)
+	}
+	return paths
+}
+
+// DiffFields returns the desired fields that are missing or different in the live object, sorted by path, with
+// their desired and live values. Fields are compared like in DiffJSON
+func DiffFields(desired, live interface{}) []v1alpha1.FieldChange {
+	var fields []v1alpha1.FieldChange
+	diffJSON("", desired, live, &fields)
+	sort.Slice(fields, func(i, j int) bool {
+		return fields[i].Path < fields[j].Path
+	})
+	return fields
+}
+
+// diffJSON appends the desired fields different in the live object
+func diffJSON(path string, desired, live interface{}, fields *[]v1alpha1.FieldChange) {
+	addField := func() {
+		*fields = append(*fields, v1alpha1.FieldChange{
+			Path:    pathOrRoot(path),
+			Desired: formatFieldValue(desired),
+			Live:    formatFieldValue(live),
+		})
+	}
+
+	switch desiredValue := desired.(type) {
+	case map[string]interface{}:
+		liveMap, ok := live.(map[string]interface{})
+		if !ok {
+			addField()
+			return
+		}
+		for key, value := range desiredValue {
+			diffJSON(joinPath(path, key), value, liveMap[key], fields)
+		}
+	case []interface{}:
+		liveSlice, ok := live.([]interface{})
+		if !ok || len(liveSlice) != len(desiredValue) {
+			addField()
+			return
+		}
+		for i := range desiredValue {
+			diffJSON(fmt.Sprintf("%s[%d]", path, i), desiredValue[i], liveSlice[i], fields)
+		}
+	default:
+		if fmt.Sprint(desired) != fmt.Sprint(live) {
+			addField()
+		}
+	}
+}
+
+// HashJSON returns the hash of the JSON encoding of a desired body, stored in the status of the resources to skip
+// the writes of the bodies applied already. Maps are encoded with sorted keys, so the hash is stable
+func HashJSON(body interface{}) string {
+	encoded, _ := json.Marshal(body)
+	hash := sha256.Sum256(encoded)
+	return hex.EncodeToString(hash[:])
+}
+
+// DetectDrift returns the paths of the desired fields changed out of band in the live object, or a marker when the
+// object was deleted out of band
+func DetectDrift(desired, live interface{}, exists bool) []string {
+	if !exists {
+		return []string{"(deleted)"}
+	}
+	return DiffJSON(desired, live)
+}
+
+// FlattenSettings returns the settings with their nested objects flattened into dotted keys, so settings written
+// nested ({"index": {"number_of_shards": 1}}) and flat ("index.number_of_shards") can be compared
+func FlattenSettings(settings map[string]interface{}) map[string]interface{} {
+	flat := make(map[string]interface{})
+	flattenSettings("", settings, flat)
+	return flat
+}
+
+// flattenSettings adds the settings under the prefix to the flat settings
+func flattenSettings(prefix string, settings map[string]interface{}, flat map[string]interface{}) {
+	for key, value := range settings {
+		if nested, ok := value.(map[string]interface{}); ok {
+			flattenSettings(joinPath(prefix, key), nested, flat)
+			continue
+		}
+		flat[joinPath(prefix, key)] = value
+	}
+}
+
+// RecordDrift records the objects changed out of band in the Drifted condition, and emits a warning event for
+// every one of them. drifted maps the name of every drifted object to the paths of its changed fields. Dry runs
+// record the drift as pending, as it is not corrected
+func RecordDrift(recorder record.EventRecorder, object runtime.Object, conditions *[]metav1.Condition, drifted map[string][]string, dryRun bool) {
+	if len(drifted) == 0 {
+		UpdateCondition(conditions, NewCondition(ConditionTypeDrifted, metav1.ConditionFalse,
+			ConditionReasonNoDrift, "No out-of-band changes detected"))
+		return
+	}
+
+	names := make([]string, 0, len(drifted))
+	for name := range drifted {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if recorder == nil {
+			continue
+		}
+		if dryRun {
+			recorder.Eventf(object, corev1.EventTypeWarning, EventReasonDriftDetected,
+				"%s was changed out of band (%s)", name, strings.Join(drifted[name], ", "))
+			continue
+		}
+		recorder.Eventf(object, corev1.EventTypeWarning, EventReasonDriftDetected,
+			"%s was changed out of band (%s), restoring the desired state", name, strings.Join(drifted[name], ", "))
+	}
+
+	if dryRun {
+		UpdateCondition(conditions, NewCondition(ConditionTypeDrifted, metav1.ConditionTrue,
+			ConditionReasonDriftPending, fmt.Sprintf("Out-of-band changes pending correction in: %s", strings.Join(names, ", "))))
+		return
+	}
+
+	UpdateCondition(conditions, NewCondition(ConditionTypeDrifted, metav1.ConditionTrue,
+		ConditionReasonDriftCorrected, fmt.Sprintf("Out-of-band changes corrected in: %s", strings.Join(names, ", "))))
+}
+
+// joinPath appends a key to a dotted path
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// pathOrRoot returns the path, or a marker for the root of the object
+func pathOrRoot(path string) string {
+	if path == "" {
+		return "(root)"
+	}
+	return path
+}
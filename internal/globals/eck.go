@@ -0,0 +1,90 @@
+package globals
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// eckHTTPPort is the port of the HTTP service created by ECK, unless its service spec overrides it
+const eckHTTPPort = 9200
+
+// eckHTTPEndpoint describes how the HTTP service of an ECK Elasticsearch cluster is reached
+type eckHTTPEndpoint struct {
+	URL        string
+	TLSEnabled bool
+	// ServerName is the hostname verified against the certificate when the cluster is reached through a custom
+	// Service, as the certificates of ECK cover its own HTTP service
+	ServerName string
+}
+
+// getECKHTTPEndpoint reads the ECK Elasticsearch resource and builds the URL of its HTTP service
+// ({name}-es-http), detecting disabled TLS and the port of a customized service. A custom serviceName and port
+// (e.g., the Service of a coordinating-only nodeSet) replace the HTTP service when set
+func getECKHTTPEndpoint(ctx context.Context, namespace, name, serviceName string, port int32) (*eckHTTPEndpoint, error) {
+	elasticsearchResource, err := Application.KubeRawClient.Resource(schema.GroupVersionResource{
+		Group:    "elasticsearch.k8s.elastic.co",
+		Version:  "v1",
+		Resource: "elasticsearches",
+	}).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ECK cluster: %w", err)
+	}
+
+	// TLS is only disabled when the self-signed certificate is disabled and no custom certificate is provided
+	selfSignedDisabled, _, _ := unstructured.NestedBool(elasticsearchResource.Object, "spec", "http", "tls", "selfSignedCertificate", "disabled")
+	customCertificate, _, _ := unstructured.NestedString(elasticsearchResource.Object, "spec", "http", "tls", "certificate", "secretName")
+	tlsEnabled := !selfSignedDisabled || customCertificate != ""
+
+	scheme := "https"
+	if !tlsEnabled {
+		scheme = "http"
+	}
+
+	endpoint := &eckHTTPEndpoint{
+		URL:        fmt.Sprintf("%s://%s-es-http.%s.svc:%d", scheme, name, namespace, eckServicePort(elasticsearchResource)),
+		TLSEnabled: tlsEnabled,
+	}
+
+	if serviceName != "" || port != 0 {
+		if serviceName == "" {
+			serviceName = fmt.Sprintf("%s-es-http", name)
+		} else {
+			endpoint.ServerName = fmt.Sprintf("%s-es-http.%s.svc", name, namespace)
+		}
+		servicePort := int64(port)
+		if servicePort == 0 {
+			servicePort = eckServicePort(elasticsearchResource)
+		}
+		endpoint.URL = fmt.Sprintf("%s://%s.%s.svc:%d", scheme, serviceName, namespace, servicePort)
+	}
+
+	return endpoint, nil
+}
+
+// eckServicePort returns the port of the HTTP service of an ECK Elasticsearch resource. The service spec can
+// override it, preferring the port named after the scheme (https or http) over the first one
+func eckServicePort(elasticsearchResource *unstructured.Unstructured) int64 {
+	ports, _, _ := unstructured.NestedSlice(elasticsearchResource.Object, "spec", "http", "service", "spec", "ports")
+
+	port := int64(0)
+	for _, item := range ports {
+		portSpec, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		number, _, _ := unstructured.NestedInt64(portSpec, "port")
+		portName, _, _ := unstructured.NestedString(portSpec, "name")
+		if number > 0 && (port == 0 || portName == "https" || portName == "http") {
+			port = number
+		}
+	}
+
+	if port == 0 {
+		return eckHTTPPort
+	}
+	return port
+}
@@ -0,0 +1,121 @@
+package globals
+
+import (
+	"context"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
+)
+
+// eckElasticsearchGVK is the kind of the Elasticsearch clusters managed by ECK
+var eckElasticsearchGVK = schema.GroupVersionKind{Group: "elasticsearch.k8s.elastic.co", Version: "v1", Kind: "Elasticsearch"}
+
+// eckReadyPhase is the phase of an ECK Elasticsearch cluster whose nodes are all running the desired spec
+const eckReadyPhase = "Ready"
+
+// WatchECKClusters requeues the resources selecting an ECK Elasticsearch cluster when the cluster is created or
+// becomes ready, so resources created before their cluster are synced within seconds instead of on their next sync
+// interval. The selects function reports whether an item of the list targets the cluster. Nothing is watched when
+// ECK is not installed
+func WatchECKClusters(b *builder.Builder, mgr ctrl.Manager, list client.ObjectList, selects func(object, cluster client.Object) bool) *builder.Builder {
+	if _, err := mgr.GetRESTMapper().RESTMapping(eckElasticsearchGVK.GroupKind(), eckElasticsearchGVK.Version); err != nil {
+		log.Log.Info("ECK is not installed, the Elasticsearch clusters of ECK are not watched")
+		return b
+	}
+
+	cluster := &unstructured.Unstructured{}
+	cluster.SetGroupVersionKind(eckElasticsearchGVK)
+
+	return b.Watches(cluster, handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, cluster client.Object) []reconcile.Request {
+		objects := list.DeepCopyObject().(client.ObjectList)
+		if err := mgr.GetClient().List(ctx, objects); err != nil {
+			log.FromContext(ctx).Error(err, "Failed to list the resources selecting an ECK cluster")
+			return nil
+		}
+
+		items, err := meta.ExtractList(objects)
+		if err != nil {
+			return nil
+		}
+
+		requests := make([]reconcile.Request, 0)
+		for _, item := range items {
+			object, ok := item.(client.Object)
+			if !ok || !selects(object, cluster) {
+				continue
+			}
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: object.GetNamespace(), Name: object.GetName()},
+			})
+		}
+		return requests
+	}), builder.WithPredicates(eckClusterReadyPredicate()))
+}
+
+// eckClusterReadyPredicate only lets through the creation of the ECK clusters, and the updates moving them to the
+// Ready phase, as the resources selecting them can't be synced before
+func eckClusterReadyPredicate() predicate.Funcs {
+	return predicate.Funcs{
+		CreateFunc: func(event.CreateEvent) bool { return true },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return eckClusterPhase(e.ObjectOld) != eckReadyPhase && eckClusterPhase(e.ObjectNew) == eckReadyPhase
+		},
+		DeleteFunc:  func(event.DeleteEvent) bool { return false },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+	}
+}
+
+// eckClusterPhase returns the phase reported in the status of an ECK Elasticsearch cluster
+func eckClusterPhase(cluster client.Object) string {
+	object, ok := cluster.(*unstructured.Unstructured)
+	if !ok {
+		return ""
+	}
+	phase, _, _ := unstructured.NestedString(object.Object, "status", "phase")
+	return phase
+}
+
+// SelectsECKCluster reports whether a resourceSelector of a resource of the given namespace targets the ECK
+// cluster. Selectors relying on the NamespaceDefaultCluster of the namespace are not resolved
+func SelectsECKCluster(resourceSelector *v1alpha1.ResourceSelector, namespace string, cluster client.Object) bool {
+	if resourceSelector == nil || resourceSelector.ConnectionRef != nil || resourceSelector.Name != cluster.GetName() {
+		return false
+	}
+
+	selectorNamespace := resourceSelector.Namespace
+	if selectorNamespace == "" {
+		selectorNamespace = namespace
+	}
+	return selectorNamespace == cluster.GetNamespace()
+}
+
+// MatchesClusterSelector reports whether the ECK cluster is one of the clusters selected by a clusterSelector
+func MatchesClusterSelector(clusterSelector *v1alpha1.ClusterSelector, cluster client.Object) bool {
+	if clusterSelector == nil {
+		return false
+	}
+	if len(clusterSelector.Namespaces) > 0 && !slices.Contains(clusterSelector.Namespaces, cluster.GetNamespace()) {
+		return false
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(&clusterSelector.LabelSelector)
+	if err != nil {
+		return false
+	}
+	return selector.Matches(labels.Set(cluster.GetLabels()))
+}
@@ -2,187 +2,717 @@ package globals
 
 import (
 	"context"
+	"crypto/sha256"
 	"crypto/tls"
-	"crypto/x509"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
 	"elastic-config-operator.freepik.com/elastic-config-operator/internal/pools"
 	"github.com/elastic/go-elasticsearch/v8"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
-// getOrCreateElasticsearchConnection retrieves or creates a connection to an Elasticsearch cluster
-func GetOrCreateElasticsearchConnection(ctx context.Context, clusterKey string, resourceSelector *v1alpha1.ResourceSelector, crNamespace string, elasticsearchConnectionsPool *pools.ElasticsearchConnectionsStore) (*pools.ElasticsearchConnection, error) {
+// connectionKeys holds the pool key of the connection of every ResourceSelector, keyed by the target namespace and
+// the selector, so its Secrets, ConfigMaps and ECK resources are only read again once the connection is evicted from
+// the pool (e.g., when one of its Secrets changes, or when an ECK cluster moves to another endpoint and fails its
+// health check) instead of on every reconcile
+var connectionKeys = struct {
+	sync.Mutex
+	keys map[string]string
+}{keys: make(map[string]string)}
+
+// GetOrCreateElasticsearchConnection retrieves or creates a connection to an Elasticsearch cluster. Connections are
+// pooled by their identity (endpoint, credentials, CA certificate and transport settings), so resources selecting
+// the same cluster through different selectors share a connection, and changed credentials create a new one
+func GetOrCreateElasticsearchConnection(ctx context.Context, resourceSelector *v1alpha1.ResourceSelector, crNamespace string, elasticsearchConnectionsPool *pools.ElasticsearchConnectionsStore) (*pools.ElasticsearchConnection, error) {
 	logger := log.FromContext(ctx)
 
-	// Check if connection already exists in pool
-	if connection, exists := elasticsearchConnectionsPool.Get(clusterKey); exists {
-		logger.Info(fmt.Sprintf("Using existing Elasticsearch connection for cluster %s", clusterKey))
-		return connection, nil
+	// Resources referencing an ElasticsearchClusterConnection share the connection pooled under its own key
+	if resourceSelector.ConnectionRef != nil {
+		return getOrCreateClusterConnection(ctx, resourceSelector.ConnectionRef, crNamespace, elasticsearchConnectionsPool)
 	}
 
-	logger.Info(fmt.Sprintf("Creating new Elasticsearch connection for cluster %s", clusterKey))
-
 	// Use resourceSelector namespace if provided, otherwise use CR namespace
 	targetNamespace := resourceSelector.Namespace
 	if targetNamespace == "" {
 		targetNamespace = crNamespace
-		logger.Info(fmt.Sprintf("ResourceSelector namespace not specified, using CR namespace: %s", targetNamespace))
 	}
 
-	var endpoint, username, password string
-	var caCert []byte
+	// Selectors whose connection is still pooled are not resolved again
+	selectorKey := connectionSelectorKey(resourceSelector, targetNamespace)
+	connectionKeys.Lock()
+	connectionKey, known := connectionKeys.keys[selectorKey]
+	connectionKeys.Unlock()
+	if known {
+		if connection, exists := elasticsearchConnectionsPool.Get(connectionKey); exists {
+			logger.Info(fmt.Sprintf("Using existing Elasticsearch connection %s", connectionKey))
+			return connection, nil
+		}
+	}
+
+	settings, err := resolveConnectionSettings(ctx, resourceSelector, targetNamespace)
+	if err != nil {
+		return nil, err
+	}
+	connectionKey = settings.key(resourceSelector)
+
+	connectionKeys.Lock()
+	connectionKeys.keys[selectorKey] = connectionKey
+	connectionKeys.Unlock()
+
+	// Check if connection already exists in pool
+	if connection, exists := elasticsearchConnectionsPool.Get(connectionKey); exists {
+		logger.Info(fmt.Sprintf("Using existing Elasticsearch connection %s", connectionKey))
+		return connection, nil
+	}
 
-	// Check if manual configuration is provided
-	if resourceSelector.Endpoint != "" {
-		logger.Info("Using manual Elasticsearch configuration")
+	logger.Info(fmt.Sprintf("Creating new Elasticsearch connection %s", connectionKey))
 
-		endpoint = resourceSelector.Endpoint
-		logger.Info(fmt.Sprintf("Manual endpoint: %s", endpoint))
+	// Domains of Amazon OpenSearch Service authenticate the requests by their AWS Signature Version 4
+	var wrapTransport func(http.RoundTripper) http.RoundTripper
+	if resourceSelector.AWS != nil {
+		wrapTransport, err = newSigV4TransportWrapper(ctx, resourceSelector.AWS, targetNamespace)
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure AWS request signing: %w", err)
+		}
+		logger.Info(fmt.Sprintf("Using AWS SigV4 request signing (region: %s)", resourceSelector.AWS.Region))
+	}
 
-		// Get username
-		if resourceSelector.Username != "" {
-			username = resourceSelector.Username
-		} else {
-			return nil, fmt.Errorf("username is required when using manual configuration")
+	// Create TLS config, skipping it entirely for plain HTTP endpoints
+	var tlsConfig *tls.Config
+	if isPlainHTTP(settings.endpoint) {
+		if settings.clientCert != nil {
+			return nil, fmt.Errorf("clientCertSecretRef can not be used with the plain HTTP endpoint %s", settings.endpoint)
+		}
+		logger.Info(fmt.Sprintf("Using plain HTTP endpoint %s without TLS", settings.endpoint))
+	} else {
+		// Elastic Cloud deployments serve publicly trusted certificates
+		useSystemCA := resourceSelector.UseSystemCA || resourceSelector.CloudID != ""
+		tlsConfig, err = newTLSConfig(settings.caCert, useSystemCA, resourceSelector.InsecureSkipTLSVerify)
+		if err != nil {
+			return nil, err
 		}
+		if tlsConfig.InsecureSkipVerify {
+			logger.Info("TLS verification disabled by insecureSkipTLSVerify (not recommended for production)")
+		}
+		tlsConfig.ServerName = settings.serverName
+		if resourceSelector.TLSServerName != "" {
+			tlsConfig.ServerName = resourceSelector.TLSServerName
+		}
+		if settings.clientCert != nil {
+			tlsConfig.Certificates = []tls.Certificate{*settings.clientCert}
+		}
+	}
+
+	cfg := elasticsearch.Config{
+		Addresses:    []string{settings.endpoint},
+		Username:     settings.username,
+		Password:     settings.password,
+		APIKey:       settings.apiKey,
+		ServiceToken: settings.token,
+		Header:       newHeader(resourceSelector.Headers),
+
+		CompressRequestBody: resourceSelector.Compression,
+	}
+	if err := applyNodeDiscovery(&cfg, resourceSelector.NodeDiscovery); err != nil {
+		return nil, err
+	}
+
+	options := transportOptions{
+		tlsConfig:     tlsConfig,
+		wrapTransport: wrapTransport,
+	}
+	if resourceSelector.ProxyURL != "" {
+		options.proxyURL, err = url.Parse(resourceSelector.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxyURL: %w", err)
+		}
+	}
+	if err := options.applyTimeouts(resourceSelector.Timeouts); err != nil {
+		return nil, err
+	}
+
+	connection, err := newElasticsearchConnection(ctx, cfg, options, resourceSelector.ClusterType)
+	if err != nil {
+		return nil, err
+	}
+	connection.CACert = string(settings.caCert)
+	connection.Secrets = append(connectionSecrets(resourceSelector, targetNamespace), settings.secrets...)
+	connection.ConfigMaps = connectionConfigMaps(resourceSelector, targetNamespace)
+
+	// Store connection in pool
+	elasticsearchConnectionsPool.Set(connectionKey, connection)
+
+	return connection, nil
+}
+
+// connectionSettings holds the endpoint, credentials and certificates of a cluster, read from the ResourceSelector,
+// its Secrets and ECK
+type connectionSettings struct {
+	endpoint   string
+	username   string
+	password   string
+	apiKey     string
+	token      string
+	caCert     []byte
+	clientCert *tls.Certificate
+	serverName string
+	// secrets are the namespace/name of the Secrets discovered from the operator managing the cluster
+	secrets []string
+}
+
+// resolveConnectionSettings reads the endpoint, credentials and certificates of the cluster of a ResourceSelector.
+// Secrets without namespace are read from the target namespace
+func resolveConnectionSettings(ctx context.Context, resourceSelector *v1alpha1.ResourceSelector, targetNamespace string) (*connectionSettings, error) {
+	settings := &connectionSettings{}
+	var err error
+
+	// An API key, a token, a client certificate, AWS signing or a basic-auth Secret replaces the inline basic
+	// authentication, both for manual and ECK automatic configuration
+	if resourceSelector.APIKeySecretRef != nil {
+		settings.apiKey, err = GetSecretValue(ctx, resourceSelector.APIKeySecretRef, targetNamespace)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get API key: %w", err)
+		}
+	}
+
+	if resourceSelector.TokenSecretRef != nil {
+		settings.token, err = GetSecretValue(ctx, resourceSelector.TokenSecretRef, targetNamespace)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get token: %w", err)
+		}
+	}
+
+	// A client certificate authenticates the operator against the PKI realm of the cluster
+	if resourceSelector.ClientCertSecretRef != nil {
+		settings.clientCert, err = GetClientCertificate(ctx, resourceSelector.ClientCertSecretRef, targetNamespace)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get client certificate: %w", err)
+		}
+	}
+
+	// A basic-auth Secret provides both the username and the password
+	if resourceSelector.BasicAuthSecretRef != nil {
+		settings.username, settings.password, err = GetBasicAuthCredentials(ctx, resourceSelector.BasicAuthSecretRef, targetNamespace)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get basic auth credentials: %w", err)
+		}
+	}
+
+	// The inline username or the ECK elastic user are only used when no other credentials are provided
+	externalCredentials := settings.username != "" || settings.apiKey != "" || settings.token != "" ||
+		settings.clientCert != nil || resourceSelector.AWS != nil
+
+	// The inline username authenticates both manually configured clusters and ECK clusters, where it replaces the
+	// elastic superuser (e.g., a file realm user with limited privileges)
+	if !externalCredentials && resourceSelector.Username != "" {
+		settings.username = resourceSelector.Username
 
 		// Get password from secret
 		if resourceSelector.PasswordSecretRef == nil {
-			return nil, fmt.Errorf("passwordSecretRef is required when using manual configuration")
-		}
-		// Use specified namespace or default to target namespace
-		passwordSecretNamespace := resourceSelector.PasswordSecretRef.Namespace
-		if passwordSecretNamespace == "" {
-			passwordSecretNamespace = targetNamespace
+			return nil, fmt.Errorf("passwordSecretRef is required when username is set")
 		}
-		passwordSecret, err := Application.KubeRawCoreClient.CoreV1().Secrets(passwordSecretNamespace).Get(ctx, resourceSelector.PasswordSecretRef.Name, metav1.GetOptions{})
+		settings.password, err = GetSecretValue(ctx, resourceSelector.PasswordSecretRef, targetNamespace)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get password secret: %w", err)
+			return nil, fmt.Errorf("failed to get password: %w", err)
 		}
-		password = string(passwordSecret.Data[resourceSelector.PasswordSecretRef.Key])
-		if password == "" {
-			return nil, fmt.Errorf("password not found in secret %s/%s key %s", passwordSecretNamespace, resourceSelector.PasswordSecretRef.Name, resourceSelector.PasswordSecretRef.Key)
+		externalCredentials = true
+	}
+
+	// Check if manual configuration is provided, either through an endpoint or the Cloud ID of an Elastic Cloud deployment
+	settings.endpoint, err = resolveEndpoint(ctx, resourceSelector, targetNamespace)
+	if err != nil {
+		return nil, err
+	}
+	if settings.endpoint != "" || resourceSelector.CloudID != "" {
+		if resourceSelector.CloudID != "" {
+			settings.endpoint, err = cloudIDEndpoint(resourceSelector.CloudID)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if !externalCredentials {
+			return nil, fmt.Errorf("username, basicAuthSecretRef, apiKeySecretRef, tokenSecretRef, clientCertSecretRef or aws is required when using manual configuration or cloudID")
 		}
 
 		// Get CA certificate from secret (optional)
 		if resourceSelector.CACertSecretRef != nil {
-			// Use specified namespace or default to target namespace
-			caCertSecretNamespace := resourceSelector.CACertSecretRef.Namespace
-			if caCertSecretNamespace == "" {
-				caCertSecretNamespace = targetNamespace
-			}
-			caCertSecret, err := Application.KubeRawCoreClient.CoreV1().Secrets(caCertSecretNamespace).Get(ctx, resourceSelector.CACertSecretRef.Name, metav1.GetOptions{})
+			caCertValue, err := GetSecretValue(ctx, resourceSelector.CACertSecretRef, targetNamespace)
 			if err != nil {
-				return nil, fmt.Errorf("failed to get CA certificate secret: %w", err)
-			}
-			caCert = caCertSecret.Data[resourceSelector.CACertSecretRef.Key]
-			if len(caCert) == 0 {
-				return nil, fmt.Errorf("CA certificate not found in secret %s/%s key %s", caCertSecretNamespace, resourceSelector.CACertSecretRef.Name, resourceSelector.CACertSecretRef.Key)
+				return nil, fmt.Errorf("failed to get CA certificate: %w", err)
 			}
+			settings.caCert = []byte(caCertValue)
 		}
-	} else {
-		logger.Info("Using ECK automatic configuration")
 
-		// Get the ECK Elasticsearch resource (we mainly need to verify it exists)
-		_, err := Application.KubeRawClient.Resource(schema.GroupVersionResource{
-			Group:    "elasticsearch.k8s.elastic.co",
-			Version:  "v1",
-			Resource: "elasticsearches",
-		}).Namespace(targetNamespace).Get(ctx, resourceSelector.Name, metav1.GetOptions{})
-		if err != nil {
-			return nil, fmt.Errorf("failed to get ECK cluster: %w", err)
+		// Get CA certificate from configmap (optional)
+		if resourceSelector.CACertConfigMapRef != nil {
+			caCertValue, err := GetConfigMapValue(ctx, resourceSelector.CACertConfigMapRef, targetNamespace)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get CA certificate: %w", err)
+			}
+			settings.caCert = []byte(caCertValue)
 		}
 
-		// Get the service name (ECK creates a service with name {elasticsearch-name}-es-http)
-		serviceName := fmt.Sprintf("%s-es-http", resourceSelector.Name)
-		endpoint = fmt.Sprintf("https://%s.%s.svc:9200", serviceName, targetNamespace)
+		return settings, nil
+	}
 
-		logger.Info(fmt.Sprintf("ECK Elasticsearch endpoint: %s", endpoint))
+	// ECK creates a service with name {elasticsearch-name}-es-http, whose scheme and port depend on the
+	// HTTP settings of the Elasticsearch resource
+	eckEndpoint, err := getECKHTTPEndpoint(ctx, targetNamespace, resourceSelector.Name, resourceSelector.ServiceName, resourceSelector.Port)
+	if apierrors.IsNotFound(err) {
+		// Clusters managed by the OpenSearch Kubernetes operator are discovered the same way
+		openSearchCluster, openSearchErr := getOpenSearchCluster(ctx, targetNamespace, resourceSelector.Name)
+		if openSearchErr != nil {
+			if apierrors.IsNotFound(openSearchErr) {
+				return nil, fmt.Errorf("no ECK Elasticsearch or OpenSearchCluster %s found in namespace %s", resourceSelector.Name, targetNamespace)
+			}
+			return nil, fmt.Errorf("failed to get OpenSearchCluster: %w", openSearchErr)
+		}
+		if err := resolveOpenSearchOperatorSettings(ctx, openSearchCluster, resourceSelector, settings, externalCredentials); err != nil {
+			return nil, err
+		}
+		return settings, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	settings.endpoint = eckEndpoint.URL
+	settings.serverName = eckEndpoint.ServerName
 
-		// Get credentials from the secret created by ECK (secret name: {elasticsearch-name}-es-elastic-user)
+	// Get credentials from the secret created by ECK (secret name: {elasticsearch-name}-es-elastic-user),
+	// unless other credentials are provided, as some clusters disable the elastic user
+	if !externalCredentials {
 		secretName := fmt.Sprintf("%s-es-elastic-user", resourceSelector.Name)
 		secret, err := Application.KubeRawCoreClient.CoreV1().Secrets(targetNamespace).Get(ctx, secretName, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("%w: credentials secret %s not created by ECK yet", ErrClusterNotReady, secretName)
+		}
 		if err != nil {
 			return nil, fmt.Errorf("failed to get Elasticsearch credentials secret: %w", err)
 		}
 
-		username = "elastic"
-		password = string(secret.Data["elastic"])
+		settings.username = "elastic"
+		settings.password = string(secret.Data["elastic"])
+		settings.secrets = append(settings.secrets, targetNamespace+"/"+secretName)
+	}
 
-		// Get the CA certificate
+	// Get the CA certificate, unless TLS is disabled
+	if eckEndpoint.TLSEnabled {
 		caCertSecretName := fmt.Sprintf("%s-es-http-certs-public", resourceSelector.Name)
 		caCertSecret, err := Application.KubeRawCoreClient.CoreV1().Secrets(targetNamespace).Get(ctx, caCertSecretName, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("%w: CA certificate secret %s not created by ECK yet", ErrClusterNotReady, caCertSecretName)
+		}
 		if err != nil {
 			return nil, fmt.Errorf("failed to get CA certificate secret: %w", err)
 		}
+		settings.caCert = caCertSecret.Data["tls.crt"]
+		settings.secrets = append(settings.secrets, targetNamespace+"/"+caCertSecretName)
+	}
+
+	return settings, nil
+}
+
+// resolveEndpoint returns the manually configured endpoint, read from its Secret or ConfigMap when endpointFrom is
+// set, or an empty string for ECK automatic discovery
+func resolveEndpoint(ctx context.Context, resourceSelector *v1alpha1.ResourceSelector, targetNamespace string) (string, error) {
+	endpointFrom := resourceSelector.EndpointFrom
+	if endpointFrom == nil {
+		if resourceSelector.Endpoint == "" {
+			return "", nil
+		}
+		return resourceSelector.Endpoint, validateEndpointScheme(resourceSelector.Endpoint)
+	}
 
-		caCert = caCertSecret.Data["tls.crt"]
+	var endpoint string
+	var err error
+	switch {
+	case endpointFrom.SecretKeyRef != nil:
+		endpoint, err = GetSecretValue(ctx, endpointFrom.SecretKeyRef, targetNamespace)
+	case endpointFrom.ConfigMapKeyRef != nil:
+		endpoint, err = GetConfigMapValue(ctx, endpointFrom.ConfigMapKeyRef, targetNamespace)
+	default:
+		return "", fmt.Errorf("endpointFrom needs one of secretKeyRef or configMapKeyRef")
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get endpoint: %w", err)
 	}
 
-	// Create TLS config
-	var tlsConfig *tls.Config
-	if len(caCert) > 0 {
-		// Use provided CA certificate
-		caCertPool := x509.NewCertPool()
-		caCertPool.AppendCertsFromPEM(caCert)
-		tlsConfig = &tls.Config{
-			RootCAs: caCertPool,
+	// Values written by provisioning tools often end with a newline
+	endpoint = strings.TrimSpace(endpoint)
+	if endpoint == "" {
+		return "", fmt.Errorf("the endpoint referenced by endpointFrom is empty")
+	}
+	if err := validateEndpointScheme(endpoint); err != nil {
+		return "", err
+	}
+	return endpoint, nil
+}
+
+// validateEndpointScheme checks that an endpoint is an HTTP or HTTPS URL with a host
+func validateEndpointScheme(endpoint string) error {
+	parsed, err := url.Parse(endpoint)
+	if err != nil {
+		return fmt.Errorf("invalid endpoint %s: %w", endpoint, err)
+	}
+	if (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("invalid endpoint %s: expected an http:// or https:// URL", endpoint)
+	}
+	return nil
+}
+
+// isPlainHTTP reports whether an endpoint is reached without TLS
+func isPlainHTTP(endpoint string) bool {
+	return strings.HasPrefix(endpoint, "http://")
+}
+
+// key returns the pool key of the connection: its endpoint followed by a hash of its identity, so the credentials
+// are never exposed through the key. The settings of the ResourceSelector shaping the connection are part of it
+func (s *connectionSettings) key(resourceSelector *v1alpha1.ResourceSelector) string {
+	identity := struct {
+		Username      string
+		Password      string
+		APIKey        string
+		Token         string
+		CACert        []byte
+		ClientCert    [][]byte
+		UseSystemCA   bool
+		SkipVerify    bool
+		TLSServerName string
+		AWS           *v1alpha1.AWSAuthentication
+		ProxyURL      string
+		Headers       map[string]string
+		Timeouts      *v1alpha1.ConnectionTimeouts
+		NodeDiscovery *v1alpha1.NodeDiscovery
+		Compression   bool
+		ClusterType   string
+	}{
+		Username:      s.username,
+		Password:      s.password,
+		APIKey:        s.apiKey,
+		Token:         s.token,
+		CACert:        s.caCert,
+		UseSystemCA:   resourceSelector.UseSystemCA,
+		SkipVerify:    resourceSelector.InsecureSkipTLSVerify,
+		TLSServerName: resourceSelector.TLSServerName,
+		AWS:           resourceSelector.AWS,
+		ProxyURL:      resourceSelector.ProxyURL,
+		Headers:       resourceSelector.Headers,
+		Timeouts:      resourceSelector.Timeouts,
+		NodeDiscovery: resourceSelector.NodeDiscovery,
+		Compression:   resourceSelector.Compression,
+		ClusterType:   resourceSelector.ClusterType,
+	}
+	if s.clientCert != nil {
+		identity.ClientCert = s.clientCert.Certificate
+	}
+
+	// Maps are encoded with sorted keys, so the encoding is stable
+	encoded, _ := json.Marshal(identity)
+	hash := sha256.Sum256(encoded)
+	return fmt.Sprintf("%s#%s", s.endpoint, hex.EncodeToString(hash[:8]))
+}
+
+// connectionSelectorKey returns the key of a ResourceSelector in connectionKeys. The selector is encoded whole, so
+// any change of its spec resolves the connection again
+func connectionSelectorKey(resourceSelector *v1alpha1.ResourceSelector, targetNamespace string) string {
+	encoded, _ := json.Marshal(resourceSelector)
+	return targetNamespace + "/" + string(encoded)
+}
+
+// connectionSecrets returns the namespace/name of the Secrets of the ResourceSelector a connection is created from,
+// so the connection is evicted from the pool when one of them changes. The Secrets discovered from ECK or the
+// OpenSearch Kubernetes operator are added by resolveConnectionSettings. Secrets without namespace are read from
+// the target namespace
+func connectionSecrets(resourceSelector *v1alpha1.ResourceSelector, targetNamespace string) []string {
+	var secrets []string
+	addSecret := func(namespace, name string) {
+		if namespace == "" {
+			namespace = targetNamespace
 		}
-	} else {
-		// No CA certificate provided - use system's default or skip verification
-		tlsConfig = &tls.Config{
-			InsecureSkipVerify: true, // Use with caution - only for development/testing
+		secrets = append(secrets, namespace+"/"+name)
+	}
+
+	for _, selector := range []*v1alpha1.SecretKeySelector{
+		resourceSelector.PasswordSecretRef,
+		resourceSelector.APIKeySecretRef,
+		resourceSelector.TokenSecretRef,
+		resourceSelector.CACertSecretRef,
+	} {
+		if selector != nil {
+			addSecret(selector.Namespace, selector.Name)
 		}
-		logger.Info("No CA certificate provided, using InsecureSkipVerify (not recommended for production)")
+	}
+	for _, reference := range []*v1alpha1.SecretReference{
+		resourceSelector.BasicAuthSecretRef,
+		resourceSelector.ClientCertSecretRef,
+	} {
+		if reference != nil {
+			addSecret(reference.Namespace, reference.Name)
+		}
+	}
+	if resourceSelector.AWS != nil && resourceSelector.AWS.AccessKeyIDSecretRef != nil && resourceSelector.AWS.SecretAccessKeySecretRef != nil {
+		addSecret(resourceSelector.AWS.AccessKeyIDSecretRef.Namespace, resourceSelector.AWS.AccessKeyIDSecretRef.Name)
+		addSecret(resourceSelector.AWS.SecretAccessKeySecretRef.Namespace, resourceSelector.AWS.SecretAccessKeySecretRef.Name)
 	}
 
-	// Create Elasticsearch client with 10 second timeout
-	cfg := elasticsearch.Config{
-		Addresses: []string{endpoint},
-		Username:  username,
-		Password:  password,
-		Transport: &http.Transport{
-			TLSClientConfig:       tlsConfig,
-			ResponseHeaderTimeout: 10 * time.Second,
-			IdleConnTimeout:       10 * time.Second,
-		},
+	if resourceSelector.EndpointFrom != nil && resourceSelector.EndpointFrom.SecretKeyRef != nil {
+		addSecret(resourceSelector.EndpointFrom.SecretKeyRef.Namespace, resourceSelector.EndpointFrom.SecretKeyRef.Name)
+	}
+
+	return secrets
+}
+
+// connectionConfigMaps returns the namespace/name of the ConfigMaps of the ResourceSelector a connection is created
+// from, holding its CA certificate or endpoint, so the connection is evicted from the pool when one of them changes.
+// ConfigMaps without namespace are read from the target namespace
+func connectionConfigMaps(resourceSelector *v1alpha1.ResourceSelector, targetNamespace string) []string {
+	var configMaps []string
+	for _, selector := range []*v1alpha1.ConfigMapKeySelector{
+		resourceSelector.CACertConfigMapRef,
+		endpointConfigMapRef(resourceSelector.EndpointFrom),
+	} {
+		if selector == nil {
+			continue
+		}
+		namespace := selector.Namespace
+		if namespace == "" {
+			namespace = targetNamespace
+		}
+		configMaps = append(configMaps, namespace+"/"+selector.Name)
+	}
+	return configMaps
+}
+
+// endpointConfigMapRef returns the ConfigMap the endpoint is read from, nil when it is not read from one
+func endpointConfigMapRef(endpointFrom *v1alpha1.EndpointSource) *v1alpha1.ConfigMapKeySelector {
+	if endpointFrom == nil {
+		return nil
+	}
+	return endpointFrom.ConfigMapKeyRef
+}
+
+// newHeader returns the extra headers attached to every request sent to a cluster, or nil when there are none
+func newHeader(headers map[string]string) http.Header {
+	if len(headers) == 0 {
+		return nil
+	}
+
+	header := http.Header{}
+	for name, value := range headers {
+		header.Set(name, value)
+	}
+	return header
+}
+
+// applyNodeDiscovery enables the node discovery of the client configuration when it is set
+func applyNodeDiscovery(cfg *elasticsearch.Config, nodeDiscovery *v1alpha1.NodeDiscovery) error {
+	if nodeDiscovery == nil {
+		return nil
+	}
+
+	cfg.DiscoverNodesOnStart = true
+	if nodeDiscovery.Interval != "" {
+		interval, err := time.ParseDuration(nodeDiscovery.Interval)
+		if err != nil {
+			return fmt.Errorf("invalid nodeDiscovery interval %q: %w", nodeDiscovery.Interval, err)
+		}
+		cfg.DiscoverNodesInterval = interval
+	}
+
+	return nil
+}
+
+// transportOptions configures the HTTP transport of an Elasticsearch client
+type transportOptions struct {
+	tlsConfig *tls.Config
+	// proxyURL is used instead of the proxy of the environment when set
+	proxyURL *url.URL
+	// requestTimeout and dialTimeout default to the timeouts of the operator flags
+	requestTimeout time.Duration
+	dialTimeout    time.Duration
+	// wrapTransport wraps the HTTP transport when set (e.g., to sign requests)
+	wrapTransport func(http.RoundTripper) http.RoundTripper
+}
+
+// applyTimeouts sets the timeouts of the cluster, keeping the defaults of the operator for the unset ones
+func (o *transportOptions) applyTimeouts(timeouts *v1alpha1.ConnectionTimeouts) error {
+	if timeouts == nil {
+		return nil
 	}
 
+	if timeouts.Request != "" {
+		requestTimeout, err := time.ParseDuration(timeouts.Request)
+		if err != nil {
+			return fmt.Errorf("invalid request timeout %q: %w", timeouts.Request, err)
+		}
+		o.requestTimeout = requestTimeout
+	}
+
+	if timeouts.Dial != "" {
+		dialTimeout, err := time.ParseDuration(timeouts.Dial)
+		if err != nil {
+			return fmt.Errorf("invalid dial timeout %q: %w", timeouts.Dial, err)
+		}
+		o.dialTimeout = dialTimeout
+	}
+
+	return nil
+}
+
+// newElasticsearchConnection creates an Elasticsearch client with the transport options, and verifies the connection
+// by detecting the cluster type and version
+func newElasticsearchConnection(ctx context.Context, cfg elasticsearch.Config, options transportOptions, clusterTypeOverride string) (*pools.ElasticsearchConnection, error) {
+	logger := log.FromContext(ctx)
+
+	proxy := http.ProxyFromEnvironment
+	if options.proxyURL != nil {
+		proxy = http.ProxyURL(options.proxyURL)
+	}
+
+	requestTimeout := options.requestTimeout
+	if requestTimeout == 0 {
+		requestTimeout = Application.ElasticsearchRequestTimeout
+	}
+	dialTimeout := options.dialTimeout
+	if dialTimeout == 0 {
+		dialTimeout = Application.ElasticsearchDialTimeout
+	}
+
+	transport := &http.Transport{
+		Proxy: proxy,
+		DialContext: (&net.Dialer{
+			Timeout:   dialTimeout,
+			KeepAlive: Application.ElasticsearchKeepAlive,
+		}).DialContext,
+		TLSClientConfig:       options.tlsConfig,
+		TLSHandshakeTimeout:   Application.ElasticsearchTLSHandshakeTimeout,
+		ResponseHeaderTimeout: requestTimeout,
+		MaxIdleConnsPerHost:   Application.ElasticsearchMaxIdleConnsPerHost,
+		IdleConnTimeout:       Application.ElasticsearchIdleConnTimeout,
+		ForceAttemptHTTP2:     Application.ElasticsearchEnableHTTP2,
+	}
+	cfg.Transport = transport
+	if options.wrapTransport != nil {
+		cfg.Transport = options.wrapTransport(cfg.Transport)
+	}
+
+	// Every request, including the retries, is rate limited per cluster
+	if len(cfg.Addresses) > 0 {
+		cfg.Transport = newRateLimitTransport(cfg.Transport, cfg.Addresses[0])
+	}
+
+	// Throttled and unavailable responses are retried by the transport honoring Retry-After, so the client only
+	// retries the gateway errors, without backoff
+	cfg.Transport = newRetryTransport(cfg.Transport, Application.ElasticsearchMaxRetries)
+	cfg.RetryOnStatus = []int{http.StatusBadGateway, http.StatusGatewayTimeout}
+
+	// Writes to a cluster rejecting them with a cluster-wide block are held for a while, instead of being sent again
+	if len(cfg.Addresses) > 0 {
+		cfg.Transport = newClusterBlockTransport(cfg.Transport, cfg.Addresses[0])
+	}
+
+	// Writes are rejected before being rate limited or retried when the operator runs in audit mode
+	cfg.Transport = newAuditTransport(cfg.Transport)
+
 	esClient, err := elasticsearch.NewClient(cfg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Elasticsearch client: %w", err)
 	}
 
+	// Verify the cluster is up before detecting its type, so clusters still starting are told apart from errors
+	if err := pingCluster(ctx, esClient); err != nil {
+		_ = esClient.Close(ctx)
+		return nil, err
+	}
+
 	// Verify connection and detect cluster type
-	clusterType, version, err := detectClusterType(ctx, esClient, resourceSelector.ClusterType)
+	clusterType, version, err := detectClusterType(ctx, esClient, clusterTypeOverride)
 	if err != nil {
+		_ = esClient.Close(ctx)
 		return nil, fmt.Errorf("failed to detect cluster type: %w", err)
 	}
 
 	logger.Info(fmt.Sprintf("Detected cluster type: %s, version: %s", clusterType, version))
 
-	// Store connection in pool
-	connection := &pools.ElasticsearchConnection{
-		Endpoint:    endpoint,
-		Username:    username,
-		Password:    password,
-		CACert:      string(caCert),
+	return &pools.ElasticsearchConnection{
+		Endpoint:    cfg.Addresses[0],
+		Username:    cfg.Username,
+		Password:    cfg.Password,
 		Client:      esClient,
+		Transport:   transport,
 		ClusterType: clusterType,
 		Version:     version,
+	}, nil
+}
+
+// ResolveTargetClusters returns the clusters targeted by a cluster-scoped resource, sorted by namespace/name.
+// An explicit resourceSelector targets a single cluster, while a clusterSelector targets every ECK
+// Elasticsearch resource matching its labels in the selected namespaces
+func ResolveTargetClusters(ctx context.Context, resourceSelector *v1alpha1.ResourceSelector, clusterSelector *v1alpha1.ClusterSelector) ([]v1alpha1.ResourceSelector, error) {
+	if resourceSelector != nil {
+		if resourceSelector.Namespace == "" {
+			return nil, fmt.Errorf("resourceSelector.namespace is required for cluster-scoped resources")
+		}
+		return []v1alpha1.ResourceSelector{*resourceSelector}, nil
 	}
 
-	elasticsearchConnectionsPool.Set(clusterKey, connection)
+	if clusterSelector == nil {
+		return nil, fmt.Errorf("one of resourceSelector or clusterSelector must be set")
+	}
 
-	return connection, nil
+	selector, err := metav1.LabelSelectorAsSelector(&clusterSelector.LabelSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid label selector: %w", err)
+	}
+
+	// An empty namespace lists the clusters of all namespaces
+	namespaces := clusterSelector.Namespaces
+	if len(namespaces) == 0 {
+		namespaces = []string{metav1.NamespaceAll}
+	}
+
+	targets := make([]v1alpha1.ResourceSelector, 0)
+	for _, namespace := range namespaces {
+		clusters, err := Application.KubeRawClient.Resource(schema.GroupVersionResource{
+			Group:    "elasticsearch.k8s.elastic.co",
+			Version:  "v1",
+			Resource: "elasticsearches",
+		}).Namespace(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list ECK clusters: %w", err)
+		}
+
+		for _, cluster := range clusters.Items {
+			targets = append(targets, v1alpha1.ResourceSelector{
+				Name:      cluster.GetName(),
+				Namespace: cluster.GetNamespace(),
+			})
+		}
+	}
+
+	sort.Slice(targets, func(i, j int) bool {
+		if targets[i].Namespace != targets[j].Namespace {
+			return targets[i].Namespace < targets[j].Namespace
+		}
+		return targets[i].Name < targets[j].Name
+	})
+
+	return targets, nil
 }
 
 // detectClusterType detects the type of cluster (Elasticsearch or OpenSearch) and its version
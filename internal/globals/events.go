@@ -0,0 +1,59 @@
+package globals
+
+import (
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+)
+
+const (
+	// Reasons of the events recorded on the resources during their synchronization
+	EventReasonResourceApplied  = "ResourceApplied"
+	EventReasonResourcePruned   = "ResourcePruned"
+	EventReasonConnectionFailed = "ConnectionFailed"
+
+	// EventReasonElasticsearchAPIError is recorded for the errors answered by the target APIs, including the
+	// OpenSearch and Kibana ones
+	EventReasonElasticsearchAPIError = "ElasticsearchAPIError"
+)
+
+// ErrConnectionFailed is wrapped by the errors of the connections to the target clusters, so they are told apart
+// from the errors answered by their APIs
+var ErrConnectionFailed = errors.New("failed to connect")
+
+// RecordApplied emits an event when a resource becomes Ready, so periodic syncs of an unchanged resource don't
+// flood its events. It must be called before the Ready condition is updated
+func RecordApplied(recorder record.EventRecorder, object runtime.Object, conditions []metav1.Condition, message string) {
+	if recorder == nil || meta.IsStatusConditionTrue(conditions, ConditionTypeReady) {
+		return
+	}
+	recorder.Event(object, corev1.EventTypeNormal, EventReasonResourceApplied, message)
+}
+
+// RecordPruned emits an event for an object removed from the cluster, as it is no longer in the spec of the resource
+func RecordPruned(recorder record.EventRecorder, object runtime.Object, name string) {
+	if recorder == nil {
+		return
+	}
+	recorder.Event(object, corev1.EventTypeNormal, EventReasonResourcePruned,
+		fmt.Sprintf("%s was removed as it is no longer in the spec", name))
+}
+
+// RecordSyncError emits a warning event for a failed synchronization, telling the connection failures apart
+// from the errors answered by the target APIs
+func RecordSyncError(recorder record.EventRecorder, object runtime.Object, err error) {
+	if recorder == nil || err == nil {
+		return
+	}
+
+	reason := EventReasonElasticsearchAPIError
+	if errors.Is(err, ErrConnectionFailed) || errors.Is(err, ErrClusterNotReady) {
+		reason = EventReasonConnectionFailed
+	}
+	recorder.Event(object, corev1.EventTypeWarning, reason, err.Error())
+}
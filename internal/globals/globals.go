@@ -76,10 +76,12 @@ func UpdateCondition(conditions *[]metav1.Condition, condition metav1.Condition)
 		// Create the condition when not existent
 		*conditions = append(*conditions, condition)
 	} else {
-		// Update the condition when existent.
+		// Update the condition when existent. The transition time only moves when the status changes
+		if currentCondition.Status != condition.Status {
+			currentCondition.LastTransitionTime = metav1.Now()
+		}
 		currentCondition.Status = condition.Status
 		currentCondition.Reason = condition.Reason
 		currentCondition.Message = condition.Message
-		currentCondition.LastTransitionTime = metav1.Now()
 	}
 }
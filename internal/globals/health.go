@@ -0,0 +1,82 @@
+package globals
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/elastic/go-elasticsearch/v8"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// Condition type for the health of the target cluster, only set on resources with a requiredClusterHealth
+	ConditionTypeClusterHealthy = "ClusterHealthy"
+
+	ConditionReasonClusterHealthy          = "ClusterHealthy"
+	ConditionReasonWaitingForClusterHealth = "WaitingForClusterHealth"
+
+	// Health statuses of a cluster, from the healthiest
+	ClusterHealthGreen  = "green"
+	ClusterHealthYellow = "yellow"
+	ClusterHealthRed    = "red"
+)
+
+// ErrClusterUnhealthy is returned while the health of the target cluster is below the one required by a resource.
+// Resources failing with it are retried with backoff, without changing the cluster
+var ErrClusterUnhealthy = errors.New("the cluster health is below the required one")
+
+// clusterHealthLevels ranks the health statuses, so they can be compared
+var clusterHealthLevels = map[string]int{
+	ClusterHealthRed:    0,
+	ClusterHealthYellow: 1,
+	ClusterHealthGreen:  2,
+}
+
+// CheckClusterHealth reads the health of the cluster from _cluster/health and fails with ErrClusterUnhealthy when it is
+// below the required health. Nothing is checked when no health is required
+func CheckClusterHealth(ctx context.Context, client *elasticsearch.Client, requiredHealth string) error {
+	if requiredHealth == "" {
+		return nil
+	}
+
+	res, err := client.Cluster.Health(client.Cluster.Health.WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to get the cluster health: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return fmt.Errorf("failed to get the cluster health: %s", res.String())
+	}
+
+	var health struct {
+		Status string `json:"status"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&health); err != nil {
+		return fmt.Errorf("failed to decode the cluster health: %w", err)
+	}
+
+	level, known := clusterHealthLevels[health.Status]
+	if !known || level < clusterHealthLevels[requiredHealth] {
+		return fmt.Errorf("%w: the cluster is %s, %s is required", ErrClusterUnhealthy, health.Status, requiredHealth)
+	}
+	return nil
+}
+
+// UpdateClusterHealthyCondition records the result of a health check of the target cluster in the ClusterHealthy
+// condition, which is removed when no health is required. Failures reading the health leave it untouched
+func UpdateClusterHealthyCondition(conditions *[]metav1.Condition, requiredHealth string, err error) {
+	switch {
+	case requiredHealth == "":
+		meta.RemoveStatusCondition(conditions, ConditionTypeClusterHealthy)
+	case err == nil:
+		UpdateCondition(conditions, NewCondition(ConditionTypeClusterHealthy, metav1.ConditionTrue,
+			ConditionReasonClusterHealthy, fmt.Sprintf("The cluster health is at least %s", requiredHealth)))
+	case errors.Is(err, ErrClusterUnhealthy):
+		UpdateCondition(conditions, NewCondition(ConditionTypeClusterHealthy, metav1.ConditionFalse,
+			ConditionReasonWaitingForClusterHealth, err.Error()))
+	}
+}
@@ -0,0 +1,237 @@
+package globals
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// Condition type for the ILM policies written as ISM policies, only set on resources converting their policies
+	// for an OpenSearch cluster
+	ConditionTypeConvertedToISM = "ConvertedToISM"
+
+	ConditionReasonConverted          = "Converted"
+	ConditionReasonPartiallyConverted = "PartiallyConverted"
+)
+
+// ilmPhases are the phases of the ILM policies, in the order ILM runs them
+var ilmPhases = []string{"hot", "warm", "cold", "frozen", "delete"}
+
+// ilmActions are the actions of the ILM phases, in the order ILM runs them, which the ISM states keep
+var ilmActions = []string{"set_priority", "unfollow", "rollover", "readonly", "downsample", "allocate", "migrate",
+	"shrink", "forcemerge", "searchable_snapshot", "freeze", "wait_for_snapshot", "delete"}
+
+// ilmActionConversions are the ISM actions of the ILM actions converted field by field, with the ISM names of
+// the fields. The fields missing from them have no ISM equivalent
+var ilmActionConversions = map[string]struct {
+	action string
+	fields map[string]string
+}{
+	"set_priority": {"index_priority", map[string]string{"priority": "priority"}},
+	"rollover": {"rollover", map[string]string{
+		"max_age":                "min_index_age",
+		"max_docs":               "min_doc_count",
+		"max_size":               "min_size",
+		"max_primary_shard_size": "min_primary_shard_size",
+	}},
+	"readonly": {"read_only", map[string]string{}},
+	"shrink": {"shrink", map[string]string{
+		"number_of_shards":       "num_new_shards",
+		"max_primary_shard_size": "max_shard_size",
+	}},
+	"forcemerge": {"force_merge", map[string]string{"max_num_segments": "max_num_segments"}},
+	"delete":     {"delete", map[string]string{}},
+}
+
+// ConvertILMPolicy translates the body of an ILM policy ({"policy": {"phases": ...}}) to the body of an ISM policy
+// ({"policy": {"states": ...}}), with a state per phase transitioning to the next one at its min_age. Like ILM,
+// the age is counted from the rollover once the indices were rolled over, and from their creation otherwise.
+// The phases, actions and fields without an ISM equivalent are left out, and returned as dotted paths
+func ConvertILMPolicy(policyName string, body map[string]interface{}) (map[string]interface{}, []string, error) {
+	ilmPolicy, ok := body["policy"].(map[string]interface{})
+	if !ok {
+		return nil, nil, fmt.Errorf("the ILM policy %s has no policy field", policyName)
+	}
+	phases, _ := ilmPolicy["phases"].(map[string]interface{})
+
+	var skipped []string
+	var phaseNames []string
+	for phaseName := range phases {
+		if !slices.Contains(ilmPhases, phaseName) {
+			skipped = append(skipped, phaseName)
+		}
+	}
+	for _, phaseName := range ilmPhases {
+		if _, ok := phases[phaseName]; ok {
+			phaseNames = append(phaseNames, phaseName)
+		}
+	}
+	if len(phaseNames) == 0 {
+		return nil, nil, fmt.Errorf("the ILM policy %s has no phases to convert", policyName)
+	}
+
+	states := make([]interface{}, 0, len(phaseNames))
+	rolledOver := false
+	for i, phaseName := range phaseNames {
+		phase, _ := phases[phaseName].(map[string]interface{})
+		actions, _ := phase["actions"].(map[string]interface{})
+		ismActions, skippedActions := convertILMActions(phaseName, actions)
+		skipped = append(skipped, skippedActions...)
+		if _, ok := actions["rollover"]; ok {
+			rolledOver = true
+		}
+
+		transitions := []interface{}{}
+		if i+1 < len(phaseNames) {
+			nextPhaseName := phaseNames[i+1]
+			transition := map[string]interface{}{"state_name": nextPhaseName}
+			nextPhase, _ := phases[nextPhaseName].(map[string]interface{})
+			if minAge, _ := nextPhase["min_age"].(string); !isZeroAge(minAge) {
+				ageCondition := "min_index_age"
+				if rolledOver {
+					ageCondition = "min_rollover_age"
+				}
+				transition["conditions"] = map[string]interface{}{ageCondition: minAge}
+			}
+			transitions = append(transitions, transition)
+		}
+
+		states = append(states, map[string]interface{}{
+			"name":        phaseName,
+			"actions":     ismActions,
+			"transitions": transitions,
+		})
+	}
+
+	description := fmt.Sprintf("Converted from the ILM policy %s", policyName)
+	if metadata, ok := ilmPolicy["_meta"].(map[string]interface{}); ok {
+		if metaDescription, ok := metadata["description"].(string); ok && metaDescription != "" {
+			description = metaDescription
+		}
+	}
+
+	sort.Strings(skipped)
+	return map[string]interface{}{
+		"policy": map[string]interface{}{
+			"description":   description,
+			"default_state": phaseNames[0],
+			"states":        states,
+		},
+	}, skipped, nil
+}
+
+// convertILMActions returns the ISM actions of the actions of an ILM phase, and the dotted paths of the actions and
+// fields left out of them
+func convertILMActions(phaseName string, actions map[string]interface{}) ([]interface{}, []string) {
+	var skipped []string
+	for actionName := range actions {
+		if !slices.Contains(ilmActions, actionName) {
+			skipped = append(skipped, phaseName+"."+actionName)
+		}
+	}
+
+	ismActions := []interface{}{}
+	for _, actionName := range ilmActions {
+		action, ok := actions[actionName]
+		if !ok {
+			continue
+		}
+		settings, _ := action.(map[string]interface{})
+
+		// Allocations are split into the ISM actions changing the replicas and the allocation filters
+		if actionName == "allocate" {
+			replicas, skippedFields := renameFields(settings, map[string]string{"number_of_replicas": "number_of_replicas"},
+				"include", "exclude", "require")
+			for _, field := range skippedFields {
+				skipped = append(skipped, phaseName+"."+actionName+"."+field)
+			}
+			if len(replicas) > 0 {
+				ismActions = append(ismActions, map[string]interface{}{"replica_count": replicas})
+			}
+			filters, _ := renameFields(settings, map[string]string{"include": "include", "exclude": "exclude", "require": "require"},
+				"number_of_replicas", "total_shards_per_node")
+			if len(filters) > 0 {
+				filters["wait_for"] = false
+				ismActions = append(ismActions, map[string]interface{}{"allocation": filters})
+			}
+			continue
+		}
+
+		conversion, ok := ilmActionConversions[actionName]
+		if !ok {
+			skipped = append(skipped, phaseName+"."+actionName)
+			continue
+		}
+		fields, skippedFields := renameFields(settings, conversion.fields)
+		for _, field := range skippedFields {
+			skipped = append(skipped, phaseName+"."+actionName+"."+field)
+		}
+		// Priorities can be unset in ILM, but not in ISM
+		if actionName == "set_priority" && fields["priority"] == nil {
+			continue
+		}
+		ismActions = append(ismActions, map[string]interface{}{conversion.action: fields})
+	}
+	return ismActions, skipped
+}
+
+// renameFields returns the fields of an action renamed by names, and the fields missing from names, but for the
+// ignored ones. Null fields are dropped, as ILM uses them for the unset fields
+func renameFields(settings map[string]interface{}, names map[string]string, ignored ...string) (map[string]interface{}, []string) {
+	renamed := make(map[string]interface{}, len(settings))
+	var skipped []string
+	for field, value := range settings {
+		ismField, ok := names[field]
+		switch {
+		case value == nil || slices.Contains(ignored, field):
+		case ok:
+			renamed[ismField] = value
+		default:
+			skipped = append(skipped, field)
+		}
+	}
+	return renamed, skipped
+}
+
+// isZeroAge reports whether a min_age of ILM is unset or zero (e.g., "0ms"), so the phase starts right away
+func isZeroAge(age string) bool {
+	return strings.Trim(strings.TrimRight(age, "abcdefghijklmnopqrstuvwxyz"), "0") == ""
+}
+
+// UpdateConvertedToISMCondition records the ILM policies written as ISM policies in the ConvertedToISM condition,
+// naming the generated ISM policies and what was left out of each of them. skipped maps the name of every
+// converted policy to the paths left out of it. The condition is removed when no policy was converted
+func UpdateConvertedToISMCondition(conditions *[]metav1.Condition, skipped map[string][]string) {
+	if len(skipped) == 0 {
+		meta.RemoveStatusCondition(conditions, ConditionTypeConvertedToISM)
+		return
+	}
+
+	policyNames := make([]string, 0, len(skipped))
+	for policyName := range skipped {
+		policyNames = append(policyNames, policyName)
+	}
+	sort.Strings(policyNames)
+
+	var partial []string
+	for _, policyName := range policyNames {
+		if len(skipped[policyName]) > 0 {
+			partial = append(partial, fmt.Sprintf("%s (%s)", policyName, strings.Join(skipped[policyName], ", ")))
+		}
+	}
+
+	message := fmt.Sprintf("Written as the ISM policies %s", strings.Join(policyNames, ", "))
+	if len(partial) == 0 {
+		UpdateCondition(conditions, NewCondition(ConditionTypeConvertedToISM, metav1.ConditionTrue,
+			ConditionReasonConverted, message))
+		return
+	}
+	UpdateCondition(conditions, NewCondition(ConditionTypeConvertedToISM, metav1.ConditionTrue,
+		ConditionReasonPartiallyConverted, fmt.Sprintf("%s, leaving out the parts without an ISM equivalent: %s",
+			message, strings.Join(partial, "; "))))
+}
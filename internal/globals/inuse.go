@@ -0,0 +1,76 @@
+package globals
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// Condition type for the objects left in the cluster instead of being deleted because they are still in use,
+	// only set while some of them are
+	ConditionTypeDeletionBlocked = "DeletionBlocked"
+
+	ConditionReasonObjectsInUse = "ObjectsInUse"
+)
+
+// ErrObjectInUse is returned for the objects still in use in the cluster (e.g., ILM policies managing live data
+// streams, or snapshot repositories holding snapshots), which are not deleted unless the deletion is forced
+var ErrObjectInUse = errors.New("the object is still in use")
+
+// ILMPolicyUsers describes the indices, data streams and composable templates using an ILM policy, as listed in the
+// in_use_by field of the policies returned by Elasticsearch, empty when nothing uses it
+func ILMPolicyUsers(policy map[string]interface{}) string {
+	inUseBy, _ := policy["in_use_by"].(map[string]interface{})
+
+	var users []string
+	for _, field := range []struct{ key, name string }{
+		{"data_streams", "data streams"},
+		{"indices", "indices"},
+		{"composable_templates", "index templates"},
+	} {
+		items, _ := inUseBy[field.key].([]interface{})
+		if len(items) == 0 {
+			continue
+		}
+		names := make([]string, 0, len(items))
+		for _, item := range items {
+			names = append(names, fmt.Sprint(item))
+		}
+		sort.Strings(names)
+		// Policies can manage thousands of indices, so only the first ones are named
+		if len(names) > 5 {
+			names = append(names[:5], fmt.Sprintf("and %d more", len(names)-5))
+		}
+		users = append(users, fmt.Sprintf("%s %s", field.name, strings.Join(names, ", ")))
+	}
+	return strings.Join(users, "; ")
+}
+
+// UpdateDeletionBlockedCondition records the objects left in the cluster because they are still in use in the
+// DeletionBlocked condition, naming the field of the spec that deletes them anyway. blocked maps the name of every
+// object to its error. The condition is removed once nothing is blocked
+func UpdateDeletionBlockedCondition(conditions *[]metav1.Condition, blocked map[string]error, forceField string) {
+	if len(blocked) == 0 {
+		meta.RemoveStatusCondition(conditions, ConditionTypeDeletionBlocked)
+		return
+	}
+
+	names := make([]string, 0, len(blocked))
+	for name := range blocked {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	messages := make([]string, 0, len(names))
+	for _, name := range names {
+		messages = append(messages, fmt.Sprintf("%s: %s", name, blocked[name]))
+	}
+	UpdateCondition(conditions, NewCondition(ConditionTypeDeletionBlocked, metav1.ConditionTrue,
+		ConditionReasonObjectsInUse, fmt.Sprintf("Objects still in use left in the cluster, set %s to delete them: %s",
+			forceField, strings.Join(messages, "; "))))
+}
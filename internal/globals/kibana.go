@@ -0,0 +1,221 @@
+package globals
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/pools"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// GetOrCreateKibanaConnection retrieves or creates a connection to a Kibana instance
+func GetOrCreateKibanaConnection(ctx context.Context, kibanaKey string, kibanaSelector *v1alpha1.KibanaSelector, crNamespace string, kibanaConnectionsPool *pools.KibanaConnectionsStore) (*pools.KibanaConnection, error) {
+	logger := log.FromContext(ctx)
+
+	// Check if connection already exists in pool
+	if connection, exists := kibanaConnectionsPool.Get(kibanaKey); exists {
+		logger.Info(fmt.Sprintf("Using existing Kibana connection for %s", kibanaKey))
+		return connection, nil
+	}
+
+	logger.Info(fmt.Sprintf("Creating new Kibana connection for %s", kibanaKey))
+
+	// Use kibanaSelector namespace if provided, otherwise use CR namespace
+	targetNamespace := kibanaSelector.Namespace
+	if targetNamespace == "" {
+		targetNamespace = crNamespace
+		logger.Info(fmt.Sprintf("KibanaSelector namespace not specified, using CR namespace: %s", targetNamespace))
+	}
+
+	var endpoint, username, password string
+	var caCert []byte
+
+	// Check if manual configuration is provided
+	if kibanaSelector.Endpoint != "" {
+		logger.Info("Using manual Kibana configuration")
+
+		endpoint = kibanaSelector.Endpoint
+		logger.Info(fmt.Sprintf("Manual endpoint: %s", endpoint))
+
+		// Get username
+		if kibanaSelector.Username == "" {
+			return nil, fmt.Errorf("username is required when using manual configuration")
+		}
+		username = kibanaSelector.Username
+
+		// Get password from secret
+		if kibanaSelector.PasswordSecretRef == nil {
+			return nil, fmt.Errorf("passwordSecretRef is required when using manual configuration")
+		}
+		var err error
+		password, err = GetSecretValue(ctx, kibanaSelector.PasswordSecretRef, targetNamespace)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get password: %w", err)
+		}
+
+		// Get CA certificate from secret (optional)
+		if kibanaSelector.CACertSecretRef != nil {
+			caCertValue, err := GetSecretValue(ctx, kibanaSelector.CACertSecretRef, targetNamespace)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get CA certificate: %w", err)
+			}
+			caCert = []byte(caCertValue)
+		}
+	} else {
+		logger.Info("Using ECK automatic configuration")
+
+		// Get the ECK Kibana resource to find the Elasticsearch cluster it is associated to
+		kibana, err := Application.KubeRawClient.Resource(schema.GroupVersionResource{
+			Group:    "kibana.k8s.elastic.co",
+			Version:  "v1",
+			Resource: "kibanas",
+		}).Namespace(targetNamespace).Get(ctx, kibanaSelector.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get ECK Kibana: %w", err)
+		}
+
+		elasticsearchName, _, _ := unstructured.NestedString(kibana.Object, "spec", "elasticsearchRef", "name")
+		if elasticsearchName == "" {
+			return nil, fmt.Errorf("ECK Kibana %s/%s has no elasticsearchRef, configure the endpoint manually", targetNamespace, kibanaSelector.Name)
+		}
+		elasticsearchNamespace, _, _ := unstructured.NestedString(kibana.Object, "spec", "elasticsearchRef", "namespace")
+		if elasticsearchNamespace == "" {
+			elasticsearchNamespace = targetNamespace
+		}
+
+		// Get the service name (ECK creates a service with name {kibana-name}-kb-http)
+		serviceName := fmt.Sprintf("%s-kb-http", kibanaSelector.Name)
+		endpoint = fmt.Sprintf("https://%s.%s.svc:5601", serviceName, targetNamespace)
+
+		logger.Info(fmt.Sprintf("ECK Kibana endpoint: %s", endpoint))
+
+		// Kibana authenticates against its Elasticsearch cluster, so use the credentials created by ECK
+		// for that cluster (secret name: {elasticsearch-name}-es-elastic-user)
+		secretName := fmt.Sprintf("%s-es-elastic-user", elasticsearchName)
+		secret, err := Application.KubeRawCoreClient.CoreV1().Secrets(elasticsearchNamespace).Get(ctx, secretName, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get Elasticsearch credentials secret: %w", err)
+		}
+
+		username = "elastic"
+		password = string(secret.Data["elastic"])
+
+		// Get the CA certificate
+		caCertSecretName := fmt.Sprintf("%s-kb-http-certs-public", kibanaSelector.Name)
+		caCertSecret, err := Application.KubeRawCoreClient.CoreV1().Secrets(targetNamespace).Get(ctx, caCertSecretName, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get CA certificate secret: %w", err)
+		}
+
+		caCert = caCertSecret.Data["tls.crt"]
+	}
+
+	// Create TLS config
+	var tlsConfig *tls.Config
+	if len(caCert) > 0 {
+		// Use provided CA certificate
+		caCertPool := x509.NewCertPool()
+		caCertPool.AppendCertsFromPEM(caCert)
+		tlsConfig = &tls.Config{
+			RootCAs: caCertPool,
+		}
+	} else {
+		// No CA certificate provided - use system's default or skip verification
+		tlsConfig = &tls.Config{
+			InsecureSkipVerify: true, // Use with caution - only for development/testing
+		}
+		logger.Info("No CA certificate provided, using InsecureSkipVerify (not recommended for production)")
+	}
+
+	connection := &pools.KibanaConnection{
+		Endpoint: strings.TrimSuffix(endpoint, "/"),
+		Username: username,
+		Password: password,
+		CACert:   string(caCert),
+		Client: &http.Client{
+			Transport: newAuditTransport(&http.Transport{
+				TLSClientConfig:       tlsConfig,
+				ResponseHeaderTimeout: 10 * time.Second,
+				IdleConnTimeout:       10 * time.Second,
+			}),
+		},
+	}
+
+	// Verify connection and get the Kibana version
+	version, err := detectKibanaVersion(ctx, connection)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to Kibana: %w", err)
+	}
+	connection.Version = version
+
+	logger.Info(fmt.Sprintf("Connected to Kibana version %s", version))
+
+	// Store connection in pool
+	kibanaConnectionsPool.Set(kibanaKey, connection)
+
+	return connection, nil
+}
+
+// PerformKibanaRequest sends an authenticated request to the Kibana API.
+// When space is set, the request is scoped to that Kibana space (/s/{space}/...)
+func PerformKibanaRequest(ctx context.Context, connection *pools.KibanaConnection, method, space, path string, body io.Reader, contentType string) (*http.Response, error) {
+	url := connection.Endpoint
+	if space != "" && space != "default" {
+		url = fmt.Sprintf("%s/s/%s", url, space)
+	}
+	url += path
+
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.SetBasicAuth(connection.Username, connection.Password)
+	// Kibana rejects mutating requests without this header (CSRF protection)
+	req.Header.Set("kbn-xsrf", "true")
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	return connection.Client.Do(req)
+}
+
+// detectKibanaVersion verifies the connection to Kibana and returns its version
+func detectKibanaVersion(ctx context.Context, connection *pools.KibanaConnection) (string, error) {
+	res, err := PerformKibanaRequest(ctx, connection, http.MethodGet, "", "/api/status", nil, "")
+	if err != nil {
+		return "", fmt.Errorf("failed to get Kibana status: %w", err)
+	}
+	defer res.Body.Close()
+
+	bodyBytes, err := io.ReadAll(res.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if res.StatusCode >= 400 {
+		return "", fmt.Errorf("kibana status request failed: %s - %s", res.Status, string(bodyBytes))
+	}
+
+	var status struct {
+		Version struct {
+			Number string `json:"number"`
+		} `json:"version"`
+	}
+	if err := json.Unmarshal(bodyBytes, &status); err != nil {
+		return "", fmt.Errorf("failed to parse Kibana status: %w", err)
+	}
+
+	return status.Version.Number, nil
+}
@@ -0,0 +1,62 @@
+package globals
+
+import (
+	"context"
+	"fmt"
+
+	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// +kubebuilder:rbac:groups=elastic-config-operator.freepik.com,resources=namespacedefaultclusters,verbs=get;list;watch
+
+// ResolveResourceSelector returns a copy of the resourceSelector of a resource with the cluster it targets, so the spec
+// is never changed in memory. An empty selector is replaced by the one of the NamespaceDefaultCluster of the
+// namespace, and a selector without namespace targets the namespace of the resource, as the ones admitted without the
+// defaulting webhook
+func ResolveResourceSelector(ctx context.Context, resourceSelector *v1alpha1.ResourceSelector, namespace string) (*v1alpha1.ResourceSelector, error) {
+	resolved := resourceSelector.DeepCopy()
+	if err := applyNamespaceDefaultCluster(ctx, resolved, namespace); err != nil {
+		return nil, err
+	}
+	if resolved.Namespace == "" {
+		resolved.Namespace = namespace
+	}
+	return resolved, nil
+}
+
+// applyNamespaceDefaultCluster fills an empty resourceSelector with the one defined by the NamespaceDefaultCluster
+// of the namespace. Selectors that already target a cluster are left untouched
+func applyNamespaceDefaultCluster(ctx context.Context, resourceSelector *v1alpha1.ResourceSelector, namespace string) error {
+	logger := log.FromContext(ctx)
+
+	if resourceSelector.Name != "" || resourceSelector.Endpoint != "" || resourceSelector.EndpointFrom != nil || resourceSelector.CloudID != "" || resourceSelector.ConnectionRef != nil || len(resourceSelector.MatchLabels) > 0 {
+		return nil
+	}
+
+	object, err := Application.KubeRawClient.Resource(schema.GroupVersionResource{
+		Group:    v1alpha1.GroupVersion.Group,
+		Version:  v1alpha1.GroupVersion.Version,
+		Resource: "namespacedefaultclusters",
+	}).Namespace(namespace).Get(ctx, v1alpha1.NamespaceDefaultClusterName, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return fmt.Errorf("resourceSelector is not set and namespace %s has no NamespaceDefaultCluster", namespace)
+		}
+		return fmt.Errorf("failed to get the NamespaceDefaultCluster of namespace %s: %w", namespace, err)
+	}
+
+	defaultCluster := &v1alpha1.NamespaceDefaultCluster{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(object.Object, defaultCluster); err != nil {
+		return fmt.Errorf("failed to decode the NamespaceDefaultCluster of namespace %s: %w", namespace, err)
+	}
+
+	logger.Info(fmt.Sprintf("ResourceSelector not specified, using the NamespaceDefaultCluster of namespace %s", namespace))
+	*resourceSelector = defaultCluster.Spec.ResourceSelector
+
+	return nil
+}
@@ -0,0 +1,116 @@
+package globals
+
+import (
+	"context"
+	"fmt"
+
+	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// openSearchOperatorHTTPPort is the HTTP port of the clusters of the OpenSearch Kubernetes operator, unless
+// spec.general.httpPort overrides it
+const openSearchOperatorHTTPPort = 9200
+
+// +kubebuilder:rbac:groups=opensearch.opster.io,resources=opensearchclusters,verbs=get;list;watch
+
+// getOpenSearchCluster returns the OpenSearchCluster resource of the OpenSearch Kubernetes operator
+func getOpenSearchCluster(ctx context.Context, namespace, name string) (*unstructured.Unstructured, error) {
+	return Application.KubeRawClient.Resource(schema.GroupVersionResource{
+		Group:    "opensearch.opster.io",
+		Version:  "v1",
+		Resource: "opensearchclusters",
+	}).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+}
+
+// resolveOpenSearchOperatorSettings fills the endpoint, admin credentials and CA certificate of a cluster managed
+// by the OpenSearch Kubernetes operator, mirroring the ECK automatic discovery. The admin credentials are only
+// read when no other credentials are provided
+func resolveOpenSearchOperatorSettings(ctx context.Context, openSearchCluster *unstructured.Unstructured, resourceSelector *v1alpha1.ResourceSelector, settings *connectionSettings, externalCredentials bool) error {
+	namespace, name := openSearchCluster.GetNamespace(), openSearchCluster.GetName()
+
+	// The operator exposes the cluster through the Service of spec.general.serviceName, over HTTPS unless the
+	// security plugin is disabled
+	serviceName, _, _ := unstructured.NestedString(openSearchCluster.Object, "spec", "general", "serviceName")
+	if serviceName == "" {
+		serviceName = name
+	}
+	port, found, _ := unstructured.NestedInt64(openSearchCluster.Object, "spec", "general", "httpPort")
+	if !found || port == 0 {
+		port = openSearchOperatorHTTPPort
+	}
+	securityDisabled, _, _ := unstructured.NestedString(openSearchCluster.Object, "spec", "general", "additionalConfig", "plugins.security.disabled")
+	tlsEnabled := securityDisabled != "true"
+
+	if resourceSelector.ServiceName != "" {
+		// Certificates generated by the operator cover the Service of the cluster
+		settings.serverName = fmt.Sprintf("%s.%s.svc", serviceName, namespace)
+		serviceName = resourceSelector.ServiceName
+	}
+	if resourceSelector.Port != 0 {
+		port = int64(resourceSelector.Port)
+	}
+
+	scheme := "https"
+	if !tlsEnabled {
+		scheme = "http"
+	}
+	settings.endpoint = fmt.Sprintf("%s://%s.%s.svc:%d", scheme, serviceName, namespace, port)
+
+	// The admin credentials are read from the Secret of spec.security.config.adminCredentialsSecret, or the one
+	// generated by the operator ({name}-admin-password)
+	if !externalCredentials && tlsEnabled {
+		secretName, _, _ := unstructured.NestedString(openSearchCluster.Object, "spec", "security", "config", "adminCredentialsSecret", "name")
+		if secretName == "" {
+			secretName = fmt.Sprintf("%s-admin-password", name)
+		}
+		secret, err := Application.KubeRawCoreClient.CoreV1().Secrets(namespace).Get(ctx, secretName, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return fmt.Errorf("%w: admin credentials secret %s not created yet", ErrClusterNotReady, secretName)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get OpenSearch admin credentials secret: %w", err)
+		}
+		settings.username = string(secret.Data["username"])
+		settings.password = string(secret.Data["password"])
+		settings.secrets = append(settings.secrets, namespace+"/"+secretName)
+	}
+
+	if !tlsEnabled {
+		return nil
+	}
+
+	// The CA certificate is the one generated by the operator ({name}-http-cert) or the one of
+	// spec.security.tls.http.caSecret. Clusters using the demo certificates need the TLS settings of the selector
+	generated, _, _ := unstructured.NestedBool(openSearchCluster.Object, "spec", "security", "tls", "http", "generate")
+	caSecretName, _, _ := unstructured.NestedString(openSearchCluster.Object, "spec", "security", "tls", "http", "caSecret", "name")
+	if generated {
+		caSecretName = fmt.Sprintf("%s-http-cert", name)
+	}
+
+	if caSecretName != "" {
+		caSecret, err := Application.KubeRawCoreClient.CoreV1().Secrets(namespace).Get(ctx, caSecretName, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return fmt.Errorf("%w: CA certificate secret %s not created yet", ErrClusterNotReady, caSecretName)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get CA certificate secret: %w", err)
+		}
+		settings.caCert = caSecret.Data["ca.crt"]
+		settings.secrets = append(settings.secrets, namespace+"/"+caSecretName)
+		return nil
+	}
+
+	if resourceSelector.CACertSecretRef != nil {
+		caCertValue, err := GetSecretValue(ctx, resourceSelector.CACertSecretRef, namespace)
+		if err != nil {
+			return fmt.Errorf("failed to get CA certificate: %w", err)
+		}
+		settings.caCert = []byte(caCertValue)
+	}
+
+	return nil
+}
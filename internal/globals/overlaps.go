@@ -0,0 +1,92 @@
+package globals
+
+import (
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+)
+
+const (
+	// Condition type warning about the templates of a resource overlapping other templates of the cluster, which
+	// match the same indices with a lower priority and are silently ignored for them
+	ConditionTypeTemplatesOverlapping = "TemplatesOverlapping"
+
+	ConditionReasonTemplatesOverlap = "TemplatesOverlap"
+	ConditionReasonNoOverlap        = "NoOverlap"
+
+	// EventReasonTemplatesOverlap is the reason of the events recorded for every overlapping template
+	EventReasonTemplatesOverlap = "TemplatesOverlap"
+
+	overlapsMessagePrefix = "Templates with a lower priority are ignored for the matching indices: "
+	overlapSeparator      = " overlaps "
+)
+
+// RecordOverlaps records the templates overlapping other templates of the cluster in the TemplatesOverlapping
+// condition, and emits a warning event for every one of them when the overlaps change. overlaps maps the name of every
+// checked template to the templates it overlaps, described with their index patterns. Overlaps are only checked
+// when the templates are applied, so the ones recorded for the unchanged templates are kept as they are
+func RecordOverlaps(recorder record.EventRecorder, object runtime.Object, conditions *[]metav1.Condition, overlaps map[string][]string, unchanged map[string]bool) {
+	for name, overlapping := range recordedOverlaps(*conditions) {
+		if _, checked := overlaps[name]; !checked && unchanged[name] {
+			overlaps[name] = overlapping
+		}
+	}
+	for name, overlapping := range overlaps {
+		if len(overlapping) == 0 {
+			delete(overlaps, name)
+		}
+	}
+
+	if len(overlaps) == 0 {
+		UpdateCondition(conditions, NewCondition(ConditionTypeTemplatesOverlapping, metav1.ConditionFalse,
+			ConditionReasonNoOverlap, "No templates overlapping other templates"))
+		return
+	}
+
+	names := make([]string, 0, len(overlaps))
+	for name := range overlaps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	messages := make([]string, 0, len(names))
+	for _, name := range names {
+		messages = append(messages, name+overlapSeparator+strings.Join(overlaps[name], ", "))
+	}
+	message := overlapsMessagePrefix + strings.Join(messages, "; ")
+
+	// Overlaps last until the templates change, so they are only notified once
+	current := meta.FindStatusCondition(*conditions, ConditionTypeTemplatesOverlapping)
+	if recorder != nil && (current == nil || current.Status != metav1.ConditionTrue || current.Message != message) {
+		for _, overlap := range messages {
+			recorder.Eventf(object, corev1.EventTypeWarning, EventReasonTemplatesOverlap,
+				"%s, which have a lower priority and are ignored for the indices matched by both", overlap)
+		}
+	}
+
+	UpdateCondition(conditions, NewCondition(ConditionTypeTemplatesOverlapping, metav1.ConditionTrue,
+		ConditionReasonTemplatesOverlap, message))
+}
+
+// recordedOverlaps returns the overlaps recorded in the TemplatesOverlapping condition by the last synchronization,
+// as passed to RecordOverlaps
+func recordedOverlaps(conditions []metav1.Condition) map[string][]string {
+	current := meta.FindStatusCondition(conditions, ConditionTypeTemplatesOverlapping)
+	if current == nil || current.Status != metav1.ConditionTrue || !strings.HasPrefix(current.Message, overlapsMessagePrefix) {
+		return nil
+	}
+
+	overlaps := make(map[string][]string)
+	for _, overlap := range strings.Split(strings.TrimPrefix(current.Message, overlapsMessagePrefix), "; ") {
+		name, overlapping, found := strings.Cut(overlap, overlapSeparator)
+		if found {
+			overlaps[name] = strings.Split(overlapping, ", ")
+		}
+	}
+	return overlaps
+}
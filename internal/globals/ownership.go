@@ -0,0 +1,118 @@
+package globals
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// Condition type for the objects of the cluster owned by someone else than the resource, which are not written
+	// unless the resource adopts them, or declared first by another resource, which are never written
+	ConditionTypeConflict = "Conflict"
+
+	ConditionReasonOwnershipConflict = "OwnershipConflict"
+	ConditionReasonNoConflict        = "NoConflict"
+
+	// EventReasonOwnershipConflict is the reason of the events recorded for every object owned by someone else
+	EventReasonOwnershipConflict = "OwnershipConflict"
+
+	// ManagedByMetaKey is the key of the _meta of the objects holding the marker of the resource that wrote them
+	ManagedByMetaKey = "managed-by"
+
+	// managedByPrefix prefixes the markers, telling the objects written by the operator from the ones of other tools
+	managedByPrefix = "elastic-config-operator"
+)
+
+// ErrOwnershipConflict is returned for the objects of the cluster owned by another resource, or created out of band,
+// which are left untouched unless the resource adopts them
+var ErrOwnershipConflict = errors.New("the object is owned by someone else")
+
+// ManagedByMarker returns the marker written in the _meta of the objects of a resource of the given kind:
+// elastic-config-operator/<kind>/<namespace>/<name>, without the namespace for cluster-scoped resources
+func ManagedByMarker(kind string, object client.Object) string {
+	if object.GetNamespace() == "" {
+		return fmt.Sprintf("%s/%s/%s", managedByPrefix, kind, object.GetName())
+	}
+	return fmt.Sprintf("%s/%s/%s/%s", managedByPrefix, kind, object.GetNamespace(), object.GetName())
+}
+
+// SetManagedBy returns a copy of the body with the marker in its _meta, keeping the other _meta fields of the body
+func SetManagedBy(body map[string]interface{}, marker string) map[string]interface{} {
+	metadata := make(map[string]interface{})
+	if current, ok := body["_meta"].(map[string]interface{}); ok {
+		for key, value := range current {
+			metadata[key] = value
+		}
+	}
+	metadata[ManagedByMetaKey] = marker
+
+	marked := make(map[string]interface{}, len(body)+1)
+	for key, value := range body {
+		marked[key] = value
+	}
+	marked["_meta"] = metadata
+	return marked
+}
+
+// ManagedBy returns the marker in the _meta of a body, empty when it has none
+func ManagedBy(body map[string]interface{}) string {
+	metadata, ok := body["_meta"].(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	marker, _ := metadata[ManagedByMetaKey].(string)
+	return marker
+}
+
+// OwnsObject reports whether a resource owns an object of the cluster, given the marker of the object: objects with
+// its marker, and objects without one it applied before, which were written before the markers existed
+func OwnsObject(objectMarker, marker string, applied bool) bool {
+	return objectMarker == marker || (objectMarker == "" && applied)
+}
+
+// CheckOwnership fails with ErrOwnershipConflict when an object of the cluster is owned by someone else than the
+// resource: another resource, when its marker is a different one, or a human or another tool, when it has no marker
+// and the resource never applied it. Missing objects never conflict
+func CheckOwnership(objectMarker string, exists bool, marker string, applied bool) error {
+	if !exists || OwnsObject(objectMarker, marker, applied) {
+		return nil
+	}
+	if objectMarker == "" {
+		return fmt.Errorf("%w: it was not created by this resource", ErrOwnershipConflict)
+	}
+	return fmt.Errorf("%w: it is managed by %s", ErrOwnershipConflict, strings.TrimPrefix(objectMarker, managedByPrefix+"/"))
+}
+
+// RecordConflicts records the objects owned by someone else in the Conflict condition, and emits a warning event for
+// every one of them. conflicts maps the name of every conflicting object to its ownership error
+func RecordConflicts(recorder record.EventRecorder, object runtime.Object, conditions *[]metav1.Condition, conflicts map[string]error) {
+	if len(conflicts) == 0 {
+		UpdateCondition(conditions, NewCondition(ConditionTypeConflict, metav1.ConditionFalse,
+			ConditionReasonNoConflict, "No objects owned by someone else"))
+		return
+	}
+
+	names := make([]string, 0, len(conflicts))
+	for name := range conflicts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	if recorder != nil {
+		for _, name := range names {
+			recorder.Eventf(object, corev1.EventTypeWarning, EventReasonOwnershipConflict,
+				"%s left untouched: %s", name, conflicts[name])
+		}
+	}
+
+	UpdateCondition(conditions, NewCondition(ConditionTypeConflict, metav1.ConditionTrue,
+		ConditionReasonOwnershipConflict, fmt.Sprintf("Objects owned by someone else, left untouched: %s", strings.Join(names, ", "))))
+}
@@ -0,0 +1,74 @@
+package globals
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v8"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// Condition type for the reachability of the target cluster
+	ConditionTypeClusterReachable = "ClusterReachable"
+
+	ConditionReasonClusterReachable  = "ClusterReachable"
+	ConditionReasonWaitingForCluster = "WaitingForCluster"
+
+	// preflightAttempts is the number of pings sent to a new cluster before it is considered not reachable yet
+	preflightAttempts = 3
+
+	// preflightBackoff is the wait before the second ping, increased linearly on every attempt
+	preflightBackoff = time.Second
+)
+
+// ErrClusterNotReady is returned while the target cluster is not reachable yet, such as a fresh ECK deployment
+// whose nodes or Secrets are still being created. Resources failing with it are retried with backoff
+var ErrClusterNotReady = errors.New("the cluster is not reachable yet")
+
+// UpdateClusterReachableCondition records the result of a connection to the target cluster in the ClusterReachable
+// condition. Failures not caused by an unreachable cluster (e.g., invalid credentials) leave it untouched
+func UpdateClusterReachableCondition(conditions *[]metav1.Condition, err error) {
+	switch {
+	case err == nil:
+		UpdateCondition(conditions, NewCondition(ConditionTypeClusterReachable, metav1.ConditionTrue,
+			ConditionReasonClusterReachable, "The target cluster is reachable"))
+	case errors.Is(err, ErrClusterNotReady):
+		UpdateCondition(conditions, NewCondition(ConditionTypeClusterReachable, metav1.ConditionFalse,
+			ConditionReasonWaitingForCluster, err.Error()))
+	}
+}
+
+// pingCluster checks that a new cluster answers before detecting its type, pinging it a bounded number of times.
+// Any answer but a server error proves the cluster is up, as invalid credentials are reported by the detection
+func pingCluster(ctx context.Context, client *elasticsearch.Client) error {
+	logger := log.FromContext(ctx)
+
+	var lastErr error
+	for attempt := 1; attempt <= preflightAttempts; attempt++ {
+		res, err := client.Ping(client.Ping.WithContext(ctx))
+		if err == nil {
+			res.Body.Close()
+			if res.StatusCode < http.StatusInternalServerError {
+				return nil
+			}
+			err = fmt.Errorf("ping answered with %s", res.Status())
+		}
+		lastErr = err
+
+		if attempt < preflightAttempts {
+			logger.Info(fmt.Sprintf("Cluster not reachable (attempt %d/%d): %s", attempt, preflightAttempts, err.Error()))
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("%w: %w", ErrClusterNotReady, ctx.Err())
+			case <-time.After(preflightBackoff * time.Duration(attempt)):
+			}
+		}
+	}
+
+	return fmt.Errorf("%w: %w", ErrClusterNotReady, lastErr)
+}
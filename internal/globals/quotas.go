@@ -0,0 +1,70 @@
+package globals
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ErrNamespaceQuotaExceeded is returned for the resources that would take the objects managed by their namespace on
+// a cluster over Application.MaxObjectsPerNamespace, which are not applied at all
+var ErrNamespaceQuotaExceeded = errors.New("namespace quota of managed objects exceeded")
+
+// objectUsage holds the number of objects a resource manages on a cluster
+type objectUsage struct {
+	cluster   string
+	namespace string
+	count     int
+}
+
+// namespaceUsages holds the objects managed by every resource, keyed by its marker, so the objects of all the
+// resources of a namespace are counted together, whatever their kind. Usages live in memory, and are counted again by
+// the syncs of the resources when the operator restarts
+var namespaceUsages = struct {
+	sync.Mutex
+	usages map[string]objectUsage
+}{usages: make(map[string]objectUsage)}
+
+// CheckNamespaceQuota records the number of templates, policies or settings a resource manages on the cluster of the
+// endpoint, and fails with ErrNamespaceQuotaExceeded when, added to the ones of the other resources of its namespace,
+// they exceed Application.MaxObjectsPerNamespace. Only the resources adding objects are rejected, so lowering the
+// limit never breaks the resources left unchanged. Rejected resources keep the usage of their last accepted sync, as
+// their objects are left as they are, and resources in dry run are only checked. Cluster-scoped resources have no
+// quota
+func CheckNamespaceQuota(endpoint, marker string, object client.Object, count int, dryRun bool) error {
+	if Application.MaxObjectsPerNamespace <= 0 || object.GetNamespace() == "" {
+		return nil
+	}
+	cluster := clusterHost(endpoint)
+
+	namespaceUsages.Lock()
+	defer namespaceUsages.Unlock()
+
+	total := count
+	for claimant, usage := range namespaceUsages.usages {
+		if claimant != marker && usage.cluster == cluster && usage.namespace == object.GetNamespace() {
+			total += usage.count
+		}
+	}
+	previous, exists := namespaceUsages.usages[marker]
+	grows := !exists || previous.cluster != cluster || count > previous.count
+	if grows && total > Application.MaxObjectsPerNamespace {
+		return fmt.Errorf("%w: the namespace %s would manage %d objects in the cluster %s, over the limit of %d",
+			ErrNamespaceQuotaExceeded, object.GetNamespace(), total, cluster, Application.MaxObjectsPerNamespace)
+	}
+
+	if !dryRun {
+		namespaceUsages.usages[marker] = objectUsage{cluster: cluster, namespace: object.GetNamespace(), count: count}
+	}
+	return nil
+}
+
+// ReleaseNamespaceQuota drops the objects of a resource from the usage of its namespace, once it is deleted
+func ReleaseNamespaceQuota(marker string) {
+	namespaceUsages.Lock()
+	defer namespaceUsages.Unlock()
+
+	delete(namespaceUsages.usages, marker)
+}
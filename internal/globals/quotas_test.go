@@ -0,0 +1,217 @@
+package globals
+
+import (
+	"errors"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCheckNamespaceQuota(t *testing.T) {
+	const (
+		endpoint      = "https://es.example.com:9200"
+		otherEndpoint = "https://other.example.com:9200"
+	)
+	resourceOf := func(namespace, name string) *metav1.PartialObjectMetadata {
+		return &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+	}
+
+	// usage is a sync already accepted before the checked one
+	type usage struct {
+		endpoint string
+		name     string
+		count    int
+	}
+
+	tests := []struct {
+		name      string
+		limit     int
+		namespace string
+		usages    []usage
+		endpoint  string
+		count     int
+		dryRun    bool
+		wantErr   bool
+		// wantCount is the usage recorded for the checked resource afterwards, -1 when it has none
+		wantCount int
+	}{
+		{
+			name:      "no limit",
+			limit:     0,
+			namespace: "team-a",
+			usages:    []usage{{endpoint, "other", 100}},
+			endpoint:  endpoint,
+			count:     100,
+			wantCount: -1,
+		},
+		{
+			name:      "cluster-scoped resources have no quota",
+			limit:     1,
+			namespace: "",
+			endpoint:  endpoint,
+			count:     5,
+			wantCount: -1,
+		},
+		{
+			name:      "within the limit",
+			limit:     10,
+			namespace: "team-a",
+			usages:    []usage{{endpoint, "other", 4}},
+			endpoint:  endpoint,
+			count:     6,
+			wantCount: 6,
+		},
+		{
+			name:      "new resource over the limit",
+			limit:     10,
+			namespace: "team-a",
+			usages:    []usage{{endpoint, "other", 8}},
+			endpoint:  endpoint,
+			count:     3,
+			wantErr:   true,
+			wantCount: -1,
+		},
+		{
+			name:      "resource growing over the limit keeps its last accepted usage",
+			limit:     10,
+			namespace: "team-a",
+			usages:    []usage{{endpoint, "other", 8}, {endpoint, "checked", 2}},
+			endpoint:  endpoint,
+			count:     3,
+			wantErr:   true,
+			wantCount: 2,
+		},
+		{
+			name:      "unchanged resource over a lowered limit is accepted",
+			limit:     5,
+			namespace: "team-a",
+			usages:    []usage{{endpoint, "other", 8}, {endpoint, "checked", 2}},
+			endpoint:  endpoint,
+			count:     2,
+			wantCount: 2,
+		},
+		{
+			name:      "shrinking resource over the limit is accepted",
+			limit:     5,
+			namespace: "team-a",
+			usages:    []usage{{endpoint, "other", 8}, {endpoint, "checked", 4}},
+			endpoint:  endpoint,
+			count:     1,
+			wantCount: 1,
+		},
+		{
+			name:      "resource moved to a full cluster grows even with fewer objects",
+			limit:     10,
+			namespace: "team-a",
+			usages:    []usage{{endpoint, "other", 9}, {otherEndpoint, "checked", 5}},
+			endpoint:  endpoint,
+			count:     2,
+			wantErr:   true,
+			wantCount: 5,
+		},
+		{
+			name:      "resource moved to a cluster with room",
+			limit:     10,
+			namespace: "team-a",
+			usages:    []usage{{otherEndpoint, "other", 9}, {otherEndpoint, "checked", 1}},
+			endpoint:  endpoint,
+			count:     2,
+			wantCount: 2,
+		},
+		{
+			name:      "other clusters and namespaces are not counted",
+			limit:     10,
+			namespace: "team-a",
+			usages:    []usage{{otherEndpoint, "other", 9}},
+			endpoint:  endpoint,
+			count:     10,
+			wantCount: 10,
+		},
+		{
+			name:      "dry run is only checked",
+			limit:     10,
+			namespace: "team-a",
+			usages:    []usage{{endpoint, "checked", 2}},
+			endpoint:  endpoint,
+			count:     5,
+			dryRun:    true,
+			wantCount: 2,
+		},
+		{
+			name:      "dry run over the limit fails",
+			limit:     10,
+			namespace: "team-a",
+			usages:    []usage{{endpoint, "other", 9}},
+			endpoint:  endpoint,
+			count:     2,
+			dryRun:    true,
+			wantErr:   true,
+			wantCount: -1,
+		},
+	}
+
+	previousLimit := Application.MaxObjectsPerNamespace
+	t.Cleanup(func() {
+		Application.MaxObjectsPerNamespace = previousLimit
+	})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(func() {
+				namespaceUsages.Lock()
+				defer namespaceUsages.Unlock()
+				namespaceUsages.usages = make(map[string]objectUsage)
+			})
+
+			for _, u := range tt.usages {
+				namespaceUsages.usages[u.name] = objectUsage{cluster: clusterHost(u.endpoint), namespace: "team-a", count: u.count}
+			}
+			// The same resource in another namespace is never counted
+			namespaceUsages.usages["elsewhere"] = objectUsage{cluster: clusterHost(endpoint), namespace: "team-b", count: 100}
+			Application.MaxObjectsPerNamespace = tt.limit
+
+			err := CheckNamespaceQuota(tt.endpoint, "checked", resourceOf(tt.namespace, "checked"), tt.count, tt.dryRun)
+			if tt.wantErr != errors.Is(err, ErrNamespaceQuotaExceeded) {
+				t.Fatalf("CheckNamespaceQuota() = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("CheckNamespaceQuota() = %v, want nil", err)
+			}
+
+			recorded, exists := namespaceUsages.usages["checked"]
+			switch {
+			case tt.wantCount < 0 && exists:
+				t.Errorf("usage %+v recorded, want none", recorded)
+			case tt.wantCount >= 0 && (!exists || recorded.count != tt.wantCount):
+				t.Errorf("usage %+v recorded, want %d objects", recorded, tt.wantCount)
+			}
+		})
+	}
+}
+
+func TestReleaseNamespaceQuota(t *testing.T) {
+	const endpoint = "https://es.example.com:9200"
+	previousLimit := Application.MaxObjectsPerNamespace
+	t.Cleanup(func() {
+		Application.MaxObjectsPerNamespace = previousLimit
+		namespaceUsages.Lock()
+		defer namespaceUsages.Unlock()
+		namespaceUsages.usages = make(map[string]objectUsage)
+	})
+	Application.MaxObjectsPerNamespace = 10
+
+	first := &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "first"}}
+	second := &metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "second"}}
+
+	if err := CheckNamespaceQuota(endpoint, "first", first, 8, false); err != nil {
+		t.Fatalf("CheckNamespaceQuota() = %v, want nil", err)
+	}
+	if err := CheckNamespaceQuota(endpoint, "second", second, 5, false); !errors.Is(err, ErrNamespaceQuotaExceeded) {
+		t.Fatalf("CheckNamespaceQuota() = %v, want ErrNamespaceQuotaExceeded", err)
+	}
+
+	ReleaseNamespaceQuota("first")
+	if err := CheckNamespaceQuota(endpoint, "second", second, 5, false); err != nil {
+		t.Errorf("CheckNamespaceQuota() = %v once the first resource released its objects, want nil", err)
+	}
+}
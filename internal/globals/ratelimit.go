@@ -0,0 +1,73 @@
+package globals
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+// clusterRateLimiters holds the request rate limiter of every target cluster, keyed by the host of its endpoint, so
+// all the connections and controllers targeting the same cluster share its limit
+var clusterRateLimiters = struct {
+	sync.Mutex
+	limiters map[string]*rate.Limiter
+}{limiters: make(map[string]*rate.Limiter)}
+
+// rateLimitTransport waits for the rate limiter of the cluster before sending every request
+type rateLimitTransport struct {
+	transport http.RoundTripper
+	limiter   *rate.Limiter
+}
+
+// newRateLimitTransport wraps a transport with the rate limiter of the cluster of the endpoint, unless the rate of
+// requests is not limited
+func newRateLimitTransport(transport http.RoundTripper, endpoint string) http.RoundTripper {
+	if Application.ElasticsearchRequestsPerSecond <= 0 {
+		return transport
+	}
+	return &rateLimitTransport{
+		transport: transport,
+		limiter:   clusterRateLimiter(endpoint),
+	}
+}
+
+// clusterRateLimiter returns the rate limiter of the cluster of the endpoint, creating it on first use
+func clusterRateLimiter(endpoint string) *rate.Limiter {
+	key := clusterHost(endpoint)
+
+	clusterRateLimiters.Lock()
+	defer clusterRateLimiters.Unlock()
+
+	limiter, exists := clusterRateLimiters.limiters[key]
+	if !exists {
+		burst := Application.ElasticsearchRequestsBurst
+		if burst <= 0 {
+			burst = max(1, int(Application.ElasticsearchRequestsPerSecond))
+		}
+		limiter = rate.NewLimiter(rate.Limit(Application.ElasticsearchRequestsPerSecond), burst)
+		clusterRateLimiters.limiters[key] = limiter
+	}
+	return limiter
+}
+
+// clusterHost returns the host of the endpoint of a cluster, identifying the cluster across its connections
+func clusterHost(endpoint string) string {
+	if parsed, err := url.Parse(endpoint); err == nil && parsed.Host != "" {
+		return parsed.Host
+	}
+	return endpoint
+}
+
+// RoundTrip waits for the rate limiter, giving up when the request is cancelled, and sends the request
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.Wait(req.Context()); err != nil {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, fmt.Errorf("request rate limit of the cluster not reached in time: %w", err)
+	}
+	return t.transport.RoundTrip(req)
+}
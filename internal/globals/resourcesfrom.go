@@ -0,0 +1,118 @@
+package globals
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
+)
+
+// referencedSourcesIndexField indexes the resources by the kind/namespace/name of the ConfigMaps and Secrets of their
+// resourcesFrom and valuesFrom
+const referencedSourcesIndexField = ".spec.sourceRefs"
+
+// SourceReference is a ConfigMap or a Secret of the namespace of a resource, read on every sync
+type SourceReference struct {
+	Kind string
+	Name string
+}
+
+// ResourcesFromReferences returns the sources of resourcesFrom
+func ResourcesFromReferences(resourcesFrom []v1alpha1.ResourcesSource) []SourceReference {
+	references := make([]SourceReference, 0, len(resourcesFrom))
+	for _, source := range resourcesFrom {
+		references = append(references, SourceReference{Kind: source.Kind, Name: source.Name})
+	}
+	return references
+}
+
+// ValuesFromReferences returns the sources of valuesFrom
+func ValuesFromReferences(valuesFrom []v1alpha1.ValuesSource) []SourceReference {
+	references := make([]SourceReference, 0, len(valuesFrom))
+	for _, source := range valuesFrom {
+		references = append(references, SourceReference{Kind: source.Kind, Name: source.Name})
+	}
+	return references
+}
+
+// LoadResources returns the bodies of the resources merged with the ones of the ConfigMaps and Secrets of
+// resourcesFrom, read again on every call. The bodies are named after their keys without the .json extension, and
+// must be JSON objects. Names defined more than once fail, as well as missing sources and keys unless they are optional
+func LoadResources(ctx context.Context, resourcesFrom []v1alpha1.ResourcesSource, namespace string, resources map[string]apiextensionsv1.JSON) (map[string]apiextensionsv1.JSON, error) {
+	if len(resourcesFrom) == 0 {
+		return resources, nil
+	}
+
+	merged := make(map[string]apiextensionsv1.JSON, len(resources))
+	for name, body := range resources {
+		merged[name] = body
+	}
+
+	for _, source := range resourcesFrom {
+		data, err := readSource(ctx, source.Kind, source.Name, namespace)
+		if apierrors.IsNotFound(err) && source.Optional {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s %s/%s of resourcesFrom: %w", source.Kind, namespace, source.Name, err)
+		}
+
+		if source.Key != "" {
+			value, ok := data[source.Key]
+			if !ok {
+				if source.Optional {
+					continue
+				}
+				return nil, fmt.Errorf("key %s not found in %s %s/%s of resourcesFrom", source.Key, source.Kind, namespace, source.Name)
+			}
+			data = map[string]string{source.Key: value}
+		}
+
+		for key, value := range data {
+			name := strings.TrimSuffix(key, ".json")
+			if _, ok := merged[name]; ok {
+				return nil, fmt.Errorf("%s is defined more than once in resources and resourcesFrom", name)
+			}
+
+			var body map[string]interface{}
+			if err := json.Unmarshal([]byte(value), &body); err != nil || body == nil {
+				return nil, fmt.Errorf("key %s of %s %s/%s of resourcesFrom is not a JSON object", key, source.Kind, namespace, source.Name)
+			}
+			merged[name] = apiextensionsv1.JSON{Raw: []byte(value)}
+		}
+	}
+	return merged, nil
+}
+
+// WatchReferencedSources requeues the resources referencing a ConfigMap or a Secret through resourcesFrom or
+// valuesFrom when it is created or changes, so the new bodies and values are synced right away instead of on the
+// next sync interval. The sources function returns the references of a resource of the given type. Only the
+// metadata of the ConfigMaps and Secrets is watched
+func WatchReferencedSources(b *builder.Builder, mgr ctrl.Manager, object client.Object, list client.ObjectList, sources func(object client.Object) []SourceReference) (*builder.Builder, error) {
+	err := mgr.GetFieldIndexer().IndexField(context.Background(), object, referencedSourcesIndexField, func(object client.Object) []string {
+		var keys []string
+		for _, source := range sources(object) {
+			keys = append(keys, source.Kind+"/"+object.GetNamespace()+"/"+source.Name)
+		}
+		return keys
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for kind, watched := range map[string]client.Object{"ConfigMap": &corev1.ConfigMap{}, "Secret": &corev1.Secret{}} {
+		b = b.Watches(watched, enqueueReferencing(mgr, list, referencedSourcesIndexField, func(source client.Object) string {
+			return kind + "/" + source.GetNamespace() + "/" + source.GetName()
+		}), builder.OnlyMetadata, builder.WithPredicates(referenceChangedPredicate()))
+	}
+	return b, nil
+}
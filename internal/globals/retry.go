@@ -0,0 +1,102 @@
+package globals
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// retryBaseBackoff is the wait before the first retry, doubled on every attempt
+	retryBaseBackoff = 500 * time.Millisecond
+
+	// retryMaxBackoff caps the wait between attempts, including the one requested by Retry-After
+	retryMaxBackoff = 30 * time.Second
+)
+
+// retryTransport retries the requests throttled (429) or rejected while the cluster is unavailable (503), waiting
+// the time requested by the Retry-After header or an exponential backoff otherwise
+type retryTransport struct {
+	transport  http.RoundTripper
+	maxRetries int
+}
+
+// newRetryTransport wraps a transport with the retries of throttled requests, unless retries are disabled
+func newRetryTransport(transport http.RoundTripper, maxRetries int) http.RoundTripper {
+	if maxRetries <= 0 {
+		return transport
+	}
+	return &retryTransport{
+		transport:  transport,
+		maxRetries: maxRetries,
+	}
+}
+
+// RoundTrip sends the request, sending it again with the same body while the response is retryable
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	// The body is buffered, as every attempt needs to send it again
+	var payload []byte
+	if req.Body != nil {
+		var err error
+		payload, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		attemptReq := req.Clone(ctx)
+		if req.Body != nil {
+			attemptReq.Body = io.NopCloser(bytes.NewReader(payload))
+			attemptReq.ContentLength = int64(len(payload))
+		}
+
+		res, err := t.transport.RoundTrip(attemptReq)
+		if err != nil || attempt >= t.maxRetries || !isRetryableStatus(res.StatusCode) {
+			return res, err
+		}
+
+		backoff := retryBackoff(attempt, res.Header.Get("Retry-After"))
+
+		// The response of a retried attempt is discarded, so the connection can be reused
+		_, _ = io.Copy(io.Discard, res.Body)
+		res.Body.Close()
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// isRetryableStatus reports whether a response status means the request can succeed later
+func isRetryableStatus(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode == http.StatusServiceUnavailable
+}
+
+// retryBackoff returns the wait before the next attempt. The Retry-After header is honored in seconds or as an
+// HTTP date, and an exponential backoff with jitter is used otherwise
+func retryBackoff(attempt int, retryAfter string) time.Duration {
+	if retryAfter != "" {
+		if seconds, err := strconv.Atoi(retryAfter); err == nil && seconds >= 0 {
+			return min(time.Duration(seconds)*time.Second, retryMaxBackoff)
+		}
+		if date, err := http.ParseTime(retryAfter); err == nil {
+			return min(max(time.Until(date), 0), retryMaxBackoff)
+		}
+	}
+
+	// The shift is bounded, as retryMaxBackoff is reached long before it overflows
+	backoff := min(retryBaseBackoff<<min(attempt, 16), retryMaxBackoff)
+	return backoff/2 + rand.N(backoff/2+1)
+}
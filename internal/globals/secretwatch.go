@@ -0,0 +1,103 @@
+package globals
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
+)
+
+// referencedSecretsIndexField indexes the resources by the namespace/name of the Secrets their resourceSelector
+// references
+const referencedSecretsIndexField = ".spec.resourceSelector.secretRefs"
+
+// WatchReferencedSecrets requeues the resources referencing a Secret (e.g., through passwordSecretRef or
+// caCertSecretRef) when it is created or changes, so rotated credentials and CA certificates are used right away
+// instead of on the next sync interval. The resourceSelectors function returns the selectors of a resource of the
+// given type, which is indexed by the Secrets of its selectors. Only the metadata of the Secrets is watched
+func WatchReferencedSecrets(b *builder.Builder, mgr ctrl.Manager, object client.Object, list client.ObjectList, resourceSelectors func(object client.Object) []*v1alpha1.ResourceSelector) (*builder.Builder, error) {
+	err := mgr.GetFieldIndexer().IndexField(context.Background(), object, referencedSecretsIndexField, func(object client.Object) []string {
+		var secrets []string
+		for _, resourceSelector := range resourceSelectors(object) {
+			secrets = append(secrets, ResourceSelectorSecrets(resourceSelector, object.GetNamespace())...)
+		}
+		return secrets
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return b.Watches(&corev1.Secret{}, enqueueReferencing(mgr, list, referencedSecretsIndexField, func(secret client.Object) string {
+		return secret.GetNamespace() + "/" + secret.GetName()
+	}), builder.OnlyMetadata, builder.WithPredicates(referenceChangedPredicate())), nil
+}
+
+// enqueueReferencing returns a handler requeueing the resources of the list whose index field holds the key of the
+// watched object
+func enqueueReferencing(mgr ctrl.Manager, list client.ObjectList, indexField string, key func(object client.Object) string) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, referenced client.Object) []reconcile.Request {
+		objects := list.DeepCopyObject().(client.ObjectList)
+		err := mgr.GetClient().List(ctx, objects, client.MatchingFields{indexField: key(referenced)})
+		if err != nil {
+			log.FromContext(ctx).Error(err, fmt.Sprintf("Failed to list the resources referencing %s/%s", referenced.GetNamespace(), referenced.GetName()))
+			return nil
+		}
+
+		items, err := meta.ExtractList(objects)
+		if err != nil {
+			return nil
+		}
+
+		requests := make([]reconcile.Request, 0, len(items))
+		for _, item := range items {
+			object, ok := item.(client.Object)
+			if !ok {
+				continue
+			}
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: object.GetNamespace(), Name: object.GetName()},
+			})
+		}
+		return requests
+	})
+}
+
+// referenceChangedPredicate only lets through the creation of the referenced Secrets and ConfigMaps, for resources
+// waiting for them, and the changes of their content. Deleted ones would only make the sync fail, so they are left to
+// the sync interval
+func referenceChangedPredicate() predicate.Funcs {
+	return predicate.Funcs{
+		CreateFunc: func(event.CreateEvent) bool { return true },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return e.ObjectOld.GetResourceVersion() != e.ObjectNew.GetResourceVersion()
+		},
+		DeleteFunc:  func(event.DeleteEvent) bool { return false },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+	}
+}
+
+// ResourceSelectorSecrets returns the namespace/name of the Secrets referenced by a resourceSelector of a resource
+// of the given namespace. Selectors referencing an ElasticsearchClusterConnection hold no Secrets of their own
+func ResourceSelectorSecrets(resourceSelector *v1alpha1.ResourceSelector, namespace string) []string {
+	if resourceSelector == nil || resourceSelector.ConnectionRef != nil {
+		return nil
+	}
+
+	targetNamespace := resourceSelector.Namespace
+	if targetNamespace == "" {
+		targetNamespace = namespace
+	}
+	return connectionSecrets(resourceSelector, targetNamespace)
+}
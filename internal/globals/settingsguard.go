@@ -0,0 +1,67 @@
+package globals
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+// ErrClusterSettingForbidden is returned for the ClusterSettings setting keys the operator is configured to reject,
+// which are never written to the clusters
+var ErrClusterSettingForbidden = errors.New("cluster settings forbidden by the operator configuration")
+
+// ParseClusterSettingPatterns parses a comma-separated list of setting patterns, such as cluster.blocks.* or
+// discovery, failing on malformed patterns so the operator does not start with a guard that matches nothing
+func ParseClusterSettingPatterns(list string) ([]string, error) {
+	var patterns []string
+	for _, pattern := range strings.Split(list, ",") {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid cluster setting pattern %q: %w", pattern, err)
+		}
+		patterns = append(patterns, pattern)
+	}
+	return patterns, nil
+}
+
+// CheckClusterSettings fails with ErrClusterSettingForbidden when any of the flat setting keys matches one of the
+// Application.ForbiddenClusterSettings, or none of the Application.AllowedClusterSettings when they are set. The
+// forbidden patterns win over the allowed ones
+func CheckClusterSettings(settingKeys []string) error {
+	var rejected []string
+	for _, settingKey := range settingKeys {
+		if matchesClusterSetting(Application.ForbiddenClusterSettings, settingKey) ||
+			(len(Application.AllowedClusterSettings) > 0 && !matchesClusterSetting(Application.AllowedClusterSettings, settingKey)) {
+			rejected = append(rejected, settingKey)
+		}
+	}
+	if len(rejected) == 0 {
+		return nil
+	}
+
+	sort.Strings(rejected)
+	return fmt.Errorf("%w: %s", ErrClusterSettingForbidden, strings.Join(rejected, ", "))
+}
+
+// matchesClusterSetting reports whether a flat setting key matches any of the patterns. A pattern matches the setting
+// and the settings under it, so discovery matches discovery.seed_hosts too
+func matchesClusterSetting(patterns []string, settingKey string) bool {
+	for _, pattern := range patterns {
+		for prefix := settingKey; ; {
+			if matched, _ := path.Match(pattern, prefix); matched {
+				return true
+			}
+			dotIndex := strings.LastIndex(prefix, ".")
+			if dotIndex < 0 {
+				break
+			}
+			prefix = prefix[:dotIndex]
+		}
+	}
+	return false
+}
@@ -0,0 +1,126 @@
+package globals
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseClusterSettingPatterns(t *testing.T) {
+	tests := []struct {
+		name    string
+		list    string
+		want    []string
+		wantErr bool
+	}{
+		{name: "empty list", list: "", want: nil},
+		{name: "trims and skips empty patterns", list: " cluster.blocks.* ,, discovery ", want: []string{"cluster.blocks.*", "discovery"}},
+		{name: "malformed pattern", list: "cluster.routing.[", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseClusterSettingPatterns(tt.list)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseClusterSettingPatterns(%q) error = %v, wantErr %v", tt.list, err, tt.wantErr)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ParseClusterSettingPatterns(%q) = %v, want %v", tt.list, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchesClusterSetting(t *testing.T) {
+	tests := []struct {
+		name       string
+		patterns   []string
+		settingKey string
+		want       bool
+	}{
+		{name: "no patterns", patterns: nil, settingKey: "discovery.seed_hosts", want: false},
+		{name: "exact key", patterns: []string{"cluster.max_shards_per_node"}, settingKey: "cluster.max_shards_per_node", want: true},
+		{name: "prefix matches the settings under it", patterns: []string{"discovery"}, settingKey: "discovery.seed_hosts", want: true},
+		{name: "prefix only matches whole segments", patterns: []string{"discovery"}, settingKey: "discovery_type", want: false},
+		{name: "wildcard segment", patterns: []string{"cluster.blocks.*"}, settingKey: "cluster.blocks.read_only", want: true},
+		{name: "wildcard segment matches deeper keys through their prefix", patterns: []string{"cluster.blocks.*"}, settingKey: "cluster.blocks.read_only.allow_delete", want: true},
+		{name: "wildcard segment does not match its parent", patterns: []string{"cluster.blocks.*"}, settingKey: "cluster.blocks", want: false},
+		{name: "wildcard inside a segment", patterns: []string{"cluster.routing.allocation.disk.watermark.flood*"}, settingKey: "cluster.routing.allocation.disk.watermark.flood_stage", want: true},
+		{name: "any of the patterns", patterns: []string{"discovery", "indices.*"}, settingKey: "indices.recovery.max_bytes_per_sec", want: true},
+		{name: "unrelated key", patterns: []string{"cluster.blocks.*", "discovery"}, settingKey: "action.auto_create_index", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchesClusterSetting(tt.patterns, tt.settingKey); got != tt.want {
+				t.Errorf("matchesClusterSetting(%v, %q) = %v, want %v", tt.patterns, tt.settingKey, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckClusterSettings(t *testing.T) {
+	tests := []struct {
+		name         string
+		forbidden    []string
+		allowed      []string
+		settingKeys  []string
+		wantRejected string
+	}{
+		{
+			name:        "no guard",
+			settingKeys: []string{"cluster.blocks.read_only", "discovery.seed_hosts"},
+		},
+		{
+			name:         "forbidden keys are rejected, sorted",
+			forbidden:    []string{"cluster.blocks.*", "discovery"},
+			settingKeys:  []string{"discovery.seed_hosts", "action.auto_create_index", "cluster.blocks.read_only"},
+			wantRejected: "cluster.blocks.read_only, discovery.seed_hosts",
+		},
+		{
+			name:         "keys outside of the allowed ones are rejected",
+			allowed:      []string{"indices.recovery.*"},
+			settingKeys:  []string{"indices.recovery.max_bytes_per_sec", "cluster.max_shards_per_node"},
+			wantRejected: "cluster.max_shards_per_node",
+		},
+		{
+			name:         "forbidden wins over allowed",
+			forbidden:    []string{"cluster.blocks.*"},
+			allowed:      []string{"cluster"},
+			settingKeys:  []string{"cluster.blocks.read_only", "cluster.max_shards_per_node"},
+			wantRejected: "cluster.blocks.read_only",
+		},
+		{
+			name:        "all keys allowed and none forbidden",
+			forbidden:   []string{"discovery"},
+			allowed:     []string{"cluster", "indices.*"},
+			settingKeys: []string{"cluster.max_shards_per_node", "indices.recovery.max_bytes_per_sec"},
+		},
+	}
+
+	previousForbidden, previousAllowed := Application.ForbiddenClusterSettings, Application.AllowedClusterSettings
+	t.Cleanup(func() {
+		Application.ForbiddenClusterSettings, Application.AllowedClusterSettings = previousForbidden, previousAllowed
+	})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Application.ForbiddenClusterSettings, Application.AllowedClusterSettings = tt.forbidden, tt.allowed
+
+			err := CheckClusterSettings(tt.settingKeys)
+			if tt.wantRejected == "" {
+				if err != nil {
+					t.Fatalf("CheckClusterSettings(%v) = %v, want nil", tt.settingKeys, err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrClusterSettingForbidden) {
+				t.Fatalf("CheckClusterSettings(%v) = %v, want ErrClusterSettingForbidden", tt.settingKeys, err)
+			}
+			if rejected := strings.TrimPrefix(err.Error(), ErrClusterSettingForbidden.Error()+": "); rejected != tt.wantRejected {
+				t.Errorf("CheckClusterSettings(%v) rejected %q, want %q", tt.settingKeys, rejected, tt.wantRejected)
+			}
+		})
+	}
+}
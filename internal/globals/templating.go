@@ -0,0 +1,176 @@
+package globals
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/pools"
+)
+
+// TemplatingGoTemplate renders the strings of the bodies as Go templates
+const TemplatingGoTemplate = "GoTemplate"
+
+// TemplateData is the data the Go templates of the bodies are rendered with
+type TemplateData struct {
+	// Name and Namespace are the ones of the resource
+	Name      string
+	Namespace string
+
+	Cluster TemplateCluster
+}
+
+// TemplateCluster is the cluster the bodies are synced to. Name and Namespace are the ones of the ECK cluster or
+// the ElasticsearchClusterConnection of the resourceSelector, empty for clusters configured manually
+type TemplateCluster struct {
+	Name      string
+	Namespace string
+	Type      string
+	Version   string
+	Endpoint  string
+}
+
+// NewTemplateData returns the data to render the bodies of a resource synced to the cluster of the connection
+func NewTemplateData(object client.Object, resourceSelector *v1alpha1.ResourceSelector, connection *pools.ElasticsearchConnection) TemplateData {
+	cluster := TemplateCluster{
+		Type:     connection.ClusterType,
+		Version:  connection.Version,
+		Endpoint: connection.Endpoint,
+	}
+
+	switch {
+	case resourceSelector.ConnectionRef != nil:
+		cluster.Name = resourceSelector.ConnectionRef.Name
+		cluster.Namespace = resourceSelector.ConnectionRef.Namespace
+	case resourceSelector.Name != "":
+		cluster.Name = resourceSelector.Name
+		cluster.Namespace = resourceSelector.Namespace
+	}
+	if cluster.Name != "" && cluster.Namespace == "" {
+		cluster.Namespace = object.GetNamespace()
+	}
+
+	return TemplateData{
+		Name:      object.GetName(),
+		Namespace: object.GetNamespace(),
+		Cluster:   cluster,
+	}
+}
+
+// templateFuncs are the functions of the Go templates besides the builtin ones
+var templateFuncs = template.FuncMap{
+	"versionAtLeast": versionAtLeast,
+}
+
+// RenderTemplates returns the bodies with their strings rendered as Go templates with the data, when templating is
+// GoTemplate. Only the strings holding {{ are rendered, so the keys and values without templates are kept as they are.
+// The bodies are returned untouched for any other templating
+func RenderTemplates(templating string, bodies map[string]apiextensionsv1.JSON, data TemplateData) (map[string]apiextensionsv1.JSON, error) {
+	if templating != TemplatingGoTemplate {
+		return bodies, nil
+	}
+
+	rendered := make(map[string]apiextensionsv1.JSON, len(bodies))
+	for name, body := range bodies {
+		decoder := json.NewDecoder(bytes.NewReader(body.Raw))
+		decoder.UseNumber()
+		var value interface{}
+		if err := decoder.Decode(&value); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", name, err)
+		}
+
+		value, err := renderValue(name, value, data)
+		if err != nil {
+			return nil, err
+		}
+
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal %s: %w", name, err)
+		}
+		rendered[name] = apiextensionsv1.JSON{Raw: raw}
+	}
+	return rendered, nil
+}
+
+// renderValue renders the strings of a JSON value, including the keys of its objects
+func renderValue(name string, value interface{}, data TemplateData) (interface{}, error) {
+	switch typed := value.(type) {
+	case string:
+		return renderString(name, typed, data)
+	case map[string]interface{}:
+		object := make(map[string]interface{}, len(typed))
+		for key, item := range typed {
+			renderedKey, err := renderString(name, key, data)
+			if err != nil {
+				return nil, err
+			}
+			if object[renderedKey], err = renderValue(name, item, data); err != nil {
+				return nil, err
+			}
+		}
+		return object, nil
+	case []interface{}:
+		array := make([]interface{}, len(typed))
+		for i, item := range typed {
+			var err error
+			if array[i], err = renderValue(name, item, data); err != nil {
+				return nil, err
+			}
+		}
+		return array, nil
+	}
+	return value, nil
+}
+
+// renderString renders a string as a Go template, failing on references to fields the data does not have
+func renderString(name, text string, data TemplateData) (string, error) {
+	if !strings.Contains(text, "{{") {
+		return text, nil
+	}
+
+	parsed, err := template.New(name).Funcs(templateFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse the template of %s: %w", name, err)
+	}
+
+	var rendered strings.Builder
+	if err := parsed.Execute(&rendered, data); err != nil {
+		return "", fmt.Errorf("failed to render the template of %s: %w", name, err)
+	}
+	return rendered.String(), nil
+}
+
+// versionAtLeast reports whether a dotted version (e.g., 8.11.0) is the minimum one or a later one. Missing parts
+// count as zero, and the suffixes of the parts (e.g., -SNAPSHOT) are ignored
+func versionAtLeast(minimum, version string) bool {
+	minimumParts := strings.Split(minimum, ".")
+	versionParts := strings.Split(version, ".")
+	for i := 0; i < max(len(minimumParts), len(versionParts)); i++ {
+		minimumPart, versionPart := versionNumber(minimumParts, i), versionNumber(versionParts, i)
+		if versionPart != minimumPart {
+			return versionPart > minimumPart
+		}
+	}
+	return true
+}
+
+// versionNumber returns the number of the i-th part of a version, zero when it is missing
+func versionNumber(parts []string, i int) int {
+	if i >= len(parts) {
+		return 0
+	}
+	digits := strings.IndexFunc(parts[i], func(r rune) bool { return r < '0' || r > '9' })
+	if digits < 0 {
+		digits = len(parts[i])
+	}
+	number, _ := strconv.Atoi(parts[i][:digits])
+	return number
+}
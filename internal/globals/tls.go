@@ -0,0 +1,73 @@
+package globals
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+
+	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// Condition type for the verification of the certificate of a manually configured cluster
+	ConditionTypeTLSVerification = "TLSVerification"
+
+	ConditionReasonTLSVerificationConfigured    = "TLSVerificationConfigured"
+	ConditionReasonTLSVerificationDisabled      = "TLSVerificationDisabled"
+	ConditionReasonTLSVerificationNotConfigured = "TLSVerificationNotConfigured"
+)
+
+// ErrTLSVerificationNotConfigured is returned for manually configured clusters without a CA certificate, where
+// neither useSystemCA nor insecureSkipTLSVerify is set
+var ErrTLSVerificationNotConfigured = errors.New("TLS verification is not configured: set caCertSecretRef, " +
+	"caCertConfigMapRef or useSystemCA to verify the cluster certificate, or insecureSkipTLSVerify to skip the verification")
+
+// ValidateTLSVerification checks that the certificate verification of a manually configured cluster is explicit.
+// ECK clusters always provide their CA certificate and referenced connections configure their own TLS, so the check
+// only runs when an HTTPS endpoint is set, and its result is recorded in the TLSVerification condition. Endpoints
+// read through endpointFrom are only known when connecting, where the same verification is enforced
+func ValidateTLSVerification(resourceSelector *v1alpha1.ResourceSelector, conditions *[]metav1.Condition) error {
+	if resourceSelector.Endpoint == "" || resourceSelector.ConnectionRef != nil || isPlainHTTP(resourceSelector.Endpoint) {
+		return nil
+	}
+
+	switch {
+	case resourceSelector.CACertSecretRef != nil || resourceSelector.CACertConfigMapRef != nil:
+		UpdateCondition(conditions, NewCondition(ConditionTypeTLSVerification, metav1.ConditionTrue,
+			ConditionReasonTLSVerificationConfigured, "The cluster certificate is verified with the provided CA certificate"))
+	case resourceSelector.UseSystemCA:
+		UpdateCondition(conditions, NewCondition(ConditionTypeTLSVerification, metav1.ConditionTrue,
+			ConditionReasonTLSVerificationConfigured, "The cluster certificate is verified with the system CA certificates"))
+	case resourceSelector.InsecureSkipTLSVerify:
+		UpdateCondition(conditions, NewCondition(ConditionTypeTLSVerification, metav1.ConditionFalse,
+			ConditionReasonTLSVerificationDisabled, "The verification of the cluster certificate is disabled by insecureSkipTLSVerify"))
+	default:
+		UpdateCondition(conditions, NewCondition(ConditionTypeTLSVerification, metav1.ConditionFalse,
+			ConditionReasonTLSVerificationNotConfigured, ErrTLSVerificationNotConfigured.Error()))
+		return ErrTLSVerificationNotConfigured
+	}
+
+	return nil
+}
+
+// newTLSConfig returns the TLS configuration of a cluster connection. A CA certificate takes precedence, then the
+// system CA certificates, and the verification is only skipped when explicitly requested
+func newTLSConfig(caCert []byte, useSystemCA, insecureSkipVerify bool) (*tls.Config, error) {
+	switch {
+	case len(caCert) > 0:
+		caCertPool := x509.NewCertPool()
+		if !caCertPool.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("no valid PEM certificate found in the CA certificate")
+		}
+		return &tls.Config{RootCAs: caCertPool}, nil
+	case useSystemCA:
+		// A nil RootCAs verifies the certificate with the CA certificates of the host
+		return &tls.Config{}, nil
+	case insecureSkipVerify:
+		return &tls.Config{InsecureSkipVerify: true}, nil // Use with caution - only for development/testing
+	default:
+		return nil, ErrTLSVerificationNotConfigured
+	}
+}
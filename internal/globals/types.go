@@ -18,6 +18,7 @@ package globals
 
 import (
 	"context"
+	"time"
 
 	//
 	"k8s.io/client-go/dynamic"
@@ -33,4 +34,57 @@ type applicationT struct {
 	// Kubernetes clients
 	KubeRawClient     *dynamic.DynamicClient
 	KubeRawCoreClient *kubernetes.Clientset
+
+	// EnforceClusterBindings requires an ElasticClusterBinding to target clusters of other namespaces
+	EnforceClusterBindings bool
+
+	// AuditMode runs the controllers read-only, reporting the changes they would make without writing to the clusters
+	AuditMode bool
+
+	// ElasticsearchRequestTimeout and ElasticsearchDialTimeout are the default timeouts of the requests to the
+	// clusters, overridden per cluster by the timeouts of the ResourceSelector
+	ElasticsearchRequestTimeout time.Duration
+	ElasticsearchDialTimeout    time.Duration
+
+	// ElasticsearchMaxRetries is the number of retries of the requests throttled or rejected by unavailable clusters
+	ElasticsearchMaxRetries int
+
+	// ElasticsearchRequestsPerSecond and ElasticsearchRequestsBurst limit the rate of the requests sent to every
+	// cluster, shared by all the controllers. A rate of 0 disables the limit
+	ElasticsearchRequestsPerSecond float64
+	ElasticsearchRequestsBurst     int
+
+	// ElasticsearchMaxIdleConnsPerHost, ElasticsearchKeepAlive, ElasticsearchIdleConnTimeout,
+	// ElasticsearchTLSHandshakeTimeout and ElasticsearchEnableHTTP2 tune the HTTP transport of the connections to
+	// the clusters, and how their TCP connections are reused
+	ElasticsearchMaxIdleConnsPerHost int
+	ElasticsearchKeepAlive           time.Duration
+	ElasticsearchIdleConnTimeout     time.Duration
+	ElasticsearchTLSHandshakeTimeout time.Duration
+	ElasticsearchEnableHTTP2         bool
+
+	// ErrorBackoffBase and ErrorBackoffMax bound the exponential backoff of the requeues of failed reconciles
+	ErrorBackoffBase time.Duration
+	ErrorBackoffMax  time.Duration
+
+	// MinSyncInterval is the shortest interval between the periodic syncs of a resource, enforced over the
+	// syncInterval of the resources
+	MinSyncInterval time.Duration
+
+	// SyncIntervalJitter is the largest fraction of the interval between the periodic syncs of a resource added to it
+	// at random, spreading the syncs of the resources sharing a syncInterval. 0 disables it
+	SyncIntervalJitter float64
+
+	// DeletionMaxAttempts is the number of failed cleanups of a deleted resource before it is given up and its
+	// finalizer removed. 0 retries them forever
+	DeletionMaxAttempts int
+
+	// ForbiddenClusterSettings and AllowedClusterSettings are the patterns of the setting keys the ClusterSettings
+	// resources are denied and allowed to manage. No setting is denied nor restricted when they are empty
+	ForbiddenClusterSettings []string
+	AllowedClusterSettings   []string
+
+	// MaxObjectsPerNamespace is the largest number of templates, policies and settings the resources of a namespace
+	// may manage on each cluster. 0 disables the limit
+	MaxObjectsPerNamespace int
 }
@@ -17,13 +17,19 @@ limitations under the License.
 package globals
 
 import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"reflect"
 
 	//
-
-	//
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	ctrl "sigs.k8s.io/controller-runtime"
+
+	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
 )
 
 // NewKubernetesClient return a new Kubernetes Dynamic client from client-go SDK
@@ -48,3 +54,122 @@ func NewKubernetesClient() (client *dynamic.DynamicClient, coreClient *kubernete
 
 	return client, coreClient, err
 }
+
+// GetSecretValue returns the value stored under the selected key of a Secret.
+// If the selector does not set a namespace, defaultNamespace is used
+func GetSecretValue(ctx context.Context, selector *v1alpha1.SecretKeySelector, defaultNamespace string) (string, error) {
+	secretNamespace := selector.Namespace
+	if secretNamespace == "" {
+		secretNamespace = defaultNamespace
+	}
+
+	secret, err := Application.KubeRawCoreClient.CoreV1().Secrets(secretNamespace).Get(ctx, selector.Name, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get secret %s/%s: %w", secretNamespace, selector.Name, err)
+	}
+
+	value := string(secret.Data[selector.Key])
+	if value == "" {
+		return "", fmt.Errorf("key %s not found in secret %s/%s", selector.Key, secretNamespace, selector.Name)
+	}
+
+	return value, nil
+}
+
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
+
+// GetConfigMapValue returns the value stored under the selected key of a ConfigMap.
+// ConfigMaps without namespace are read from the default namespace
+func GetConfigMapValue(ctx context.Context, selector *v1alpha1.ConfigMapKeySelector, defaultNamespace string) (string, error) {
+	configMapNamespace := selector.Namespace
+	if configMapNamespace == "" {
+		configMapNamespace = defaultNamespace
+	}
+
+	configMap, err := Application.KubeRawCoreClient.CoreV1().ConfigMaps(configMapNamespace).Get(ctx, selector.Name, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get configmap %s/%s: %w", configMapNamespace, selector.Name, err)
+	}
+
+	value := configMap.Data[selector.Key]
+	if value == "" {
+		return "", fmt.Errorf("key %s not found in configmap %s/%s", selector.Key, configMapNamespace, selector.Name)
+	}
+
+	return value, nil
+}
+
+// GetClientCertificate returns the TLS client certificate stored in a Secret under the tls.crt and tls.key keys,
+// as in the Secrets of type kubernetes.io/tls
+func GetClientCertificate(ctx context.Context, reference *v1alpha1.SecretReference, defaultNamespace string) (*tls.Certificate, error) {
+	secretNamespace := reference.Namespace
+	if secretNamespace == "" {
+		secretNamespace = defaultNamespace
+	}
+
+	secret, err := Application.KubeRawCoreClient.CoreV1().Secrets(secretNamespace).Get(ctx, reference.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get secret %s/%s: %w", secretNamespace, reference.Name, err)
+	}
+
+	certificate, err := tls.X509KeyPair(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		return nil, fmt.Errorf("invalid client certificate in secret %s/%s: %w", secretNamespace, reference.Name, err)
+	}
+
+	return &certificate, nil
+}
+
+// GetBasicAuthCredentials returns the username and password stored in a Secret under the username and password keys,
+// as in the Secrets of type kubernetes.io/basic-auth
+func GetBasicAuthCredentials(ctx context.Context, reference *v1alpha1.SecretReference, defaultNamespace string) (string, string, error) {
+	secretNamespace := reference.Namespace
+	if secretNamespace == "" {
+		secretNamespace = defaultNamespace
+	}
+
+	secret, err := Application.KubeRawCoreClient.CoreV1().Secrets(secretNamespace).Get(ctx, reference.Name, metav1.GetOptions{})
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get secret %s/%s: %w", secretNamespace, reference.Name, err)
+	}
+
+	username := string(secret.Data[corev1.BasicAuthUsernameKey])
+	password := string(secret.Data[corev1.BasicAuthPasswordKey])
+	if username == "" || password == "" {
+		return "", "", fmt.Errorf("keys %s and %s are required in secret %s/%s",
+			corev1.BasicAuthUsernameKey, corev1.BasicAuthPasswordKey, secretNamespace, reference.Name)
+	}
+
+	return username, password, nil
+}
+
+// IsSubset reports whether every field set in desired has the same value in live.
+// Fields only present in live (timestamps, defaults filled by the cluster) are ignored
+func IsSubset(desired, live interface{}) bool {
+	switch desiredValue := desired.(type) {
+	case map[string]interface{}:
+		liveMap, ok := live.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for key, value := range desiredValue {
+			if !IsSubset(value, liveMap[key]) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		liveSlice, ok := live.([]interface{})
+		if !ok || len(liveSlice) != len(desiredValue) {
+			return false
+		}
+		for i := range desiredValue {
+			if !IsSubset(desiredValue[i], liveSlice[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return reflect.DeepEqual(desired, live)
+	}
+}
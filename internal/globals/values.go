@@ -0,0 +1,110 @@
+package globals
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// valueReference matches the ${key} references to the values of valuesFrom, and the $${key} escaped ones
+var valueReference = regexp.MustCompile(`\$?\$\{([-._a-zA-Z0-9]+)\}`)
+
+// ResolveValues reads the keys of the ConfigMaps and Secrets of valuesFrom, in the namespace of the resource. The keys
+// of later sources override the ones of earlier sources, and missing optional sources are skipped
+func ResolveValues(ctx context.Context, valuesFrom []v1alpha1.ValuesSource, namespace string) (map[string]string, error) {
+	values := make(map[string]string)
+	for _, source := range valuesFrom {
+		data, err := readSource(ctx, source.Kind, source.Name, namespace)
+		if apierrors.IsNotFound(err) && source.Optional {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s %s/%s of valuesFrom: %w", source.Kind, namespace, source.Name, err)
+		}
+
+		for key, value := range data {
+			values[key] = value
+		}
+	}
+	return values, nil
+}
+
+// readSource returns the data of a ConfigMap or a Secret, with the values of the Secrets decoded
+func readSource(ctx context.Context, kind, name, namespace string) (map[string]string, error) {
+	switch kind {
+	case "ConfigMap":
+		configMap, err := Application.KubeRawCoreClient.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return configMap.Data, nil
+	case "Secret":
+		secret, err := Application.KubeRawCoreClient.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		data := make(map[string]string, len(secret.Data))
+		for key, value := range secret.Data {
+			data[key] = string(value)
+		}
+		return data, nil
+	}
+	return nil, fmt.Errorf("unsupported kind %s", kind)
+}
+
+// ExpandValues returns the bodies with the ${key} references of their strings replaced by the values of valuesFrom,
+// read again on every call, while $${key} is kept as the literal ${key}. The bodies are returned as they are when
+// valuesFrom is empty, and references to keys missing from all the sources fail
+func ExpandValues(ctx context.Context, valuesFrom []v1alpha1.ValuesSource, namespace string, bodies map[string]apiextensionsv1.JSON) (map[string]apiextensionsv1.JSON, error) {
+	if len(valuesFrom) == 0 {
+		return bodies, nil
+	}
+
+	values, err := ResolveValues(ctx, valuesFrom, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	missing := make(map[string]bool)
+	expanded := make(map[string]apiextensionsv1.JSON, len(bodies))
+	for name, body := range bodies {
+		raw := valueReference.ReplaceAllStringFunc(string(body.Raw), func(reference string) string {
+			if strings.HasPrefix(reference, "$$") {
+				return reference[1:]
+			}
+
+			key := valueReference.FindStringSubmatch(reference)[1]
+			value, ok := values[key]
+			if !ok {
+				missing[key] = true
+				return reference
+			}
+			return escapeJSONString(value)
+		})
+		expanded[name] = apiextensionsv1.JSON{Raw: []byte(raw)}
+	}
+
+	if len(missing) > 0 {
+		keys := make([]string, 0, len(missing))
+		for key := range missing {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		return nil, fmt.Errorf("values referenced but not defined in valuesFrom: %s", strings.Join(keys, ", "))
+	}
+	return expanded, nil
+}
+
+// escapeJSONString escapes a value to be written inside a JSON string, as the references are only found in strings
+func escapeJSONString(value string) string {
+	quoted, _ := json.Marshal(value)
+	return string(quoted[1 : len(quoted)-1])
+}
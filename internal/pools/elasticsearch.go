@@ -17,7 +17,11 @@ limitations under the License.
 package pools
 
 import (
+	"context"
+	"net/http"
+	"slices"
 	"sync"
+	"time"
 
 	"github.com/elastic/go-elasticsearch/v8"
 )
@@ -29,26 +33,63 @@ type ElasticsearchConnection struct {
 	Password    string
 	CACert      string
 	Client      *elasticsearch.Client
-	ClusterType string // "elasticsearch" or "opensearch"
-	Version     string // cluster version (e.g., "8.11.0", "2.11.0")
+	ClusterType string   // "elasticsearch" or "opensearch"
+	Version     string   // cluster version (e.g., "8.11.0", "2.11.0")
+	Secrets     []string // namespace/name of the Secrets the connection was created from
+	ConfigMaps  []string // namespace/name of the ConfigMaps the connection was created from
+
+	// Transport is the HTTP transport of the client, whose idle sockets are closed when the connection is evicted
+	Transport *http.Transport
+
+	createdAt  time.Time
+	lastUsedAt time.Time
 }
 
-// ElasticsearchConnectionsStore stores Elasticsearch connections by namespace_name
+// ElasticsearchConnectionsStore stores Elasticsearch connections by endpoint and identity hash, or by
+// ElasticsearchClusterConnection
 type ElasticsearchConnectionsStore struct {
 	mu    sync.RWMutex
 	Store map[string]*ElasticsearchConnection
+
+	// TTL is the maximum age of a connection, and IdleTimeout the maximum time since it was last used.
+	// Connections exceeding them are removed by EvictExpired and re-created on their next use. Zero disables them
+	TTL         time.Duration
+	IdleTimeout time.Duration
+
+	// MaxSize is the maximum number of connections, evicting the least recently used one when a new connection
+	// exceeds it. Zero disables it
+	MaxSize int
 }
 
 func (c *ElasticsearchConnectionsStore) Set(key string, connection *ElasticsearchConnection) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
+
+	if _, exists := c.Store[key]; !exists && c.MaxSize > 0 && len(c.Store) >= c.MaxSize {
+		lruKey := leastRecentlyUsed(c.Store, func(connection *ElasticsearchConnection) time.Time { return connection.lastUsedAt })
+		lru := c.Store[lruKey]
+		delete(c.Store, lruKey)
+		connectionEvictions.WithLabelValues(elasticsearchPool, evictionReasonMaxSize).Inc()
+		lru.closeIdleConnections()
+	}
+
+	// Replaced connections release their idle sockets too
+	if replaced, exists := c.Store[key]; exists && replaced != connection {
+		replaced.closeIdleConnections()
+	}
+
+	now := time.Now()
+	connection.createdAt, connection.lastUsedAt = now, now
 	c.Store[key] = connection
 }
 
 func (c *ElasticsearchConnectionsStore) Get(key string) (*ElasticsearchConnection, bool) {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	connection, exists := c.Store[key]
+	if exists {
+		connection.lastUsedAt = time.Now()
+	}
 	return connection, exists
 }
 
@@ -58,8 +99,127 @@ func (c *ElasticsearchConnectionsStore) GetAll() map[string]*ElasticsearchConnec
 	return c.Store
 }
 
+// Delete removes a connection and closes its idle sockets
 func (c *ElasticsearchConnectionsStore) Delete(key string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	delete(c.Store, key)
+	if connection, exists := c.Store[key]; exists {
+		delete(c.Store, key)
+		connection.closeIdleConnections()
+	}
+}
+
+// EvictExpired removes the connections exceeding the TTL or the idle timeout and closes their idle sockets, so stale
+// credentials and dead endpoints are re-created on the next use. It returns the keys of the removed connections
+func (c *ElasticsearchConnectionsStore) EvictExpired(ctx context.Context) []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var evicted []string
+	now := time.Now()
+	for key, connection := range c.Store {
+		reason := expirationReason(now, connection.createdAt, connection.lastUsedAt, c.TTL, c.IdleTimeout)
+		if reason == "" {
+			continue
+		}
+		delete(c.Store, key)
+		connectionEvictions.WithLabelValues(elasticsearchPool, reason).Inc()
+		evicted = append(evicted, key)
+		connection.closeIdleConnections()
+	}
+	return evicted
+}
+
+// ReferencesSecret reports whether a pooled connection was created from the Secret
+func (c *ElasticsearchConnectionsStore) ReferencesSecret(namespace, name string) bool {
+	return c.references(namespace+"/"+name, func(connection *ElasticsearchConnection) []string {
+		return connection.Secrets
+	})
+}
+
+// ReferencesConfigMap reports whether a pooled connection was created from the ConfigMap
+func (c *ElasticsearchConnectionsStore) ReferencesConfigMap(namespace, name string) bool {
+	return c.references(namespace+"/"+name, func(connection *ElasticsearchConnection) []string {
+		return connection.ConfigMaps
+	})
+}
+
+// EvictBySecret removes the connections created from the Secret and closes their idle sockets, so they are re-created
+// with its new content on the next use. It returns the keys of the removed connections
+func (c *ElasticsearchConnectionsStore) EvictBySecret(namespace, name string) []string {
+	return c.evictReferencing(namespace+"/"+name, evictionReasonSecret, func(connection *ElasticsearchConnection) []string {
+		return connection.Secrets
+	})
+}
+
+// EvictByConfigMap removes the connections created from the ConfigMap (e.g., through caCertConfigMapRef or
+// endpointFrom) and closes their idle sockets, so a renewed CA certificate or a moved endpoint is used on the next use.
+// It returns the keys of the removed connections
+func (c *ElasticsearchConnectionsStore) EvictByConfigMap(namespace, name string) []string {
+	return c.evictReferencing(namespace+"/"+name, evictionReasonConfigMap, func(connection *ElasticsearchConnection) []string {
+		return connection.ConfigMaps
+	})
+}
+
+// references reports whether the references of a pooled connection contain the namespace/name of an object
+func (c *ElasticsearchConnectionsStore) references(object string, references func(*ElasticsearchConnection) []string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, connection := range c.Store {
+		if slices.Contains(references(connection), object) {
+			return true
+		}
+	}
+	return false
+}
+
+// evictReferencing removes the connections whose references contain the namespace/name of an object and closes
+// their idle sockets. It returns the keys of the removed connections
+func (c *ElasticsearchConnectionsStore) evictReferencing(object, reason string, references func(*ElasticsearchConnection) []string) []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var evicted []string
+	for key, connection := range c.Store {
+		if slices.Contains(references(connection), object) {
+			delete(c.Store, key)
+			connectionEvictions.WithLabelValues(elasticsearchPool, reason).Inc()
+			evicted = append(evicted, key)
+			connection.closeIdleConnections()
+		}
+	}
+	return evicted
+}
+
+// closeIdleConnections closes the idle sockets of an evicted connection. Its client is never closed, as the
+// reconciles that got the connection before its eviction may still be using it, and closed clients fail every request
+func (c *ElasticsearchConnection) closeIdleConnections() {
+	if c.Transport != nil {
+		c.Transport.CloseIdleConnections()
+	}
+}
+
+// expirationReason returns the eviction reason of a connection exceeding the TTL or the idle timeout of its store,
+// or an empty string when it has not expired
+func expirationReason(now, createdAt, lastUsedAt time.Time, ttl, idleTimeout time.Duration) string {
+	switch {
+	case ttl > 0 && now.Sub(createdAt) > ttl:
+		return evictionReasonTTL
+	case idleTimeout > 0 && now.Sub(lastUsedAt) > idleTimeout:
+		return evictionReasonIdle
+	default:
+		return ""
+	}
+}
+
+// leastRecentlyUsed returns the key of the connection used the longest time ago
+func leastRecentlyUsed[T any](store map[string]T, lastUsedAt func(T) time.Time) string {
+	var lruKey string
+	var lruTime time.Time
+	for key, connection := range store {
+		if used := lastUsedAt(connection); lruKey == "" || used.Before(lruTime) {
+			lruKey, lruTime = key, used
+		}
+	}
+	return lruKey
 }
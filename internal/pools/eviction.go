@@ -0,0 +1,76 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pools
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// ConnectionsEvictor periodically evicts the expired connections of the pools, and the unhealthy ones when
+// HealthCheckInterval is set
+type ConnectionsEvictor struct {
+	ElasticsearchConnectionsPool *ElasticsearchConnectionsStore
+	KibanaConnectionsPool        *KibanaConnectionsStore
+	Interval                     time.Duration
+	HealthCheckInterval          time.Duration
+}
+
+// Start runs the eviction until the context is done
+func (e *ConnectionsEvictor) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("connections-evictor")
+
+	ticker := time.NewTicker(e.Interval)
+	defer ticker.Stop()
+
+	// A nil channel never fires, disabling the health checks
+	var healthCheckC <-chan time.Time
+	if e.HealthCheckInterval > 0 {
+		healthCheckTicker := time.NewTicker(e.HealthCheckInterval)
+		defer healthCheckTicker.Stop()
+		healthCheckC = healthCheckTicker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			for _, key := range e.ElasticsearchConnectionsPool.EvictExpired(ctx) {
+				logger.Info(fmt.Sprintf("Evicted expired Elasticsearch connection %s", key))
+			}
+			for _, key := range e.KibanaConnectionsPool.EvictExpired() {
+				logger.Info(fmt.Sprintf("Evicted expired Kibana connection %s", key))
+			}
+		case <-healthCheckC:
+			for key, err := range e.ElasticsearchConnectionsPool.EvictUnhealthy(ctx) {
+				logger.Info(fmt.Sprintf("Evicted unhealthy Elasticsearch connection %s: %s", key, err))
+			}
+			for key, err := range e.KibanaConnectionsPool.EvictUnhealthy(ctx) {
+				logger.Info(fmt.Sprintf("Evicted unhealthy Kibana connection %s: %s", key, err))
+			}
+		}
+	}
+}
+
+// NeedLeaderElection returns false, as every replica has its own pools
+func (e *ConnectionsEvictor) NeedLeaderElection() bool {
+	return false
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pools
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// healthCheckTimeout bounds every health check, so an unreachable cluster does not delay the others
+const healthCheckTimeout = 10 * time.Second
+
+// EvictUnhealthy checks every pooled connection and removes the ones that are unreachable or rejected with 401,
+// so they are re-created with fresh credentials on their next use. It returns the removed keys and their errors
+func (c *ElasticsearchConnectionsStore) EvictUnhealthy(ctx context.Context) map[string]error {
+	// Connections are checked outside of the lock, as the checks can take up to the timeout
+	c.mu.RLock()
+	connections := make(map[string]*ElasticsearchConnection, len(c.Store))
+	for key, connection := range c.Store {
+		connections[key] = connection
+	}
+	c.mu.RUnlock()
+
+	evicted := map[string]error{}
+	for key, connection := range connections {
+		err := checkElasticsearchConnection(ctx, connection)
+		if err == nil {
+			healthChecks.WithLabelValues(elasticsearchPool, healthCheckResultHealthy).Inc()
+			continue
+		}
+		healthChecks.WithLabelValues(elasticsearchPool, healthCheckResultUnhealthy).Inc()
+
+		c.mu.Lock()
+		// The connection may have been replaced while it was checked
+		removed := c.Store[key] == connection
+		if removed {
+			delete(c.Store, key)
+		}
+		c.mu.Unlock()
+
+		if removed {
+			connectionEvictions.WithLabelValues(elasticsearchPool, evictionReasonUnhealthy).Inc()
+			connection.closeIdleConnections()
+			evicted[key] = err
+		}
+	}
+	return evicted
+}
+
+// checkElasticsearchConnection requests the cluster info, failing when the cluster is unreachable or rejects the
+// credentials. Other errors, such as a missing privilege, do not make the connection unhealthy
+func checkElasticsearchConnection(ctx context.Context, connection *ElasticsearchConnection) error {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	res, err := connection.Client.Info(connection.Client.Info.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusUnauthorized {
+		return fmt.Errorf("authentication failed: %s", res.Status())
+	}
+	return nil
+}
+
+// EvictUnhealthy checks every pooled connection and removes the ones that are unreachable or rejected with 401,
+// so they are re-created with fresh credentials on their next use. It returns the removed keys and their errors
+func (c *KibanaConnectionsStore) EvictUnhealthy(ctx context.Context) map[string]error {
+	// Connections are checked outside of the lock, as the checks can take up to the timeout
+	c.mu.RLock()
+	connections := make(map[string]*KibanaConnection, len(c.Store))
+	for key, connection := range c.Store {
+		connections[key] = connection
+	}
+	c.mu.RUnlock()
+
+	evicted := map[string]error{}
+	for key, connection := range connections {
+		err := checkKibanaConnection(ctx, connection)
+		if err == nil {
+			healthChecks.WithLabelValues(kibanaPool, healthCheckResultHealthy).Inc()
+			continue
+		}
+		healthChecks.WithLabelValues(kibanaPool, healthCheckResultUnhealthy).Inc()
+
+		c.mu.Lock()
+		// The connection may have been replaced while it was checked
+		if c.Store[key] == connection {
+			delete(c.Store, key)
+			connectionEvictions.WithLabelValues(kibanaPool, evictionReasonUnhealthy).Inc()
+			connection.Client.CloseIdleConnections()
+			evicted[key] = err
+		}
+		c.mu.Unlock()
+	}
+	return evicted
+}
+
+// checkKibanaConnection requests the status of Kibana or OpenSearch Dashboards, failing when it is unreachable or
+// rejects the credentials
+func checkKibanaConnection(ctx context.Context, connection *KibanaConnection) error {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, connection.Endpoint+"/api/status", nil)
+	if err != nil {
+		return err
+	}
+	if connection.Username != "" {
+		req.SetBasicAuth(connection.Username, connection.Password)
+	}
+
+	res, err := connection.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, res.Body)
+
+	if res.StatusCode == http.StatusUnauthorized {
+		return fmt.Errorf("authentication failed: %s", res.Status)
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pools
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// KibanaConnection holds the connection details and HTTP client for a Kibana instance
+type KibanaConnection struct {
+	Endpoint string
+	Username string
+	Password string
+	CACert   string
+	Client   *http.Client
+	Version  string // Kibana version (e.g., "8.11.0")
+
+	createdAt  time.Time
+	lastUsedAt time.Time
+}
+
+// KibanaConnectionsStore stores Kibana connections by namespace_name
+type KibanaConnectionsStore struct {
+	mu    sync.RWMutex
+	Store map[string]*KibanaConnection
+
+	// TTL is the maximum age of a connection, and IdleTimeout the maximum time since it was last used.
+	// Connections exceeding them are removed by EvictExpired and re-created on their next use. Zero disables them
+	TTL         time.Duration
+	IdleTimeout time.Duration
+
+	// MaxSize is the maximum number of connections, evicting the least recently used one when a new connection
+	// exceeds it. Zero disables it
+	MaxSize int
+}
+
+func (c *KibanaConnectionsStore) Set(key string, connection *KibanaConnection) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, exists := c.Store[key]; !exists && c.MaxSize > 0 && len(c.Store) >= c.MaxSize {
+		lruKey := leastRecentlyUsed(c.Store, func(connection *KibanaConnection) time.Time { return connection.lastUsedAt })
+		c.Store[lruKey].Client.CloseIdleConnections()
+		delete(c.Store, lruKey)
+		connectionEvictions.WithLabelValues(kibanaPool, evictionReasonMaxSize).Inc()
+	}
+
+	now := time.Now()
+	connection.createdAt, connection.lastUsedAt = now, now
+	c.Store[key] = connection
+}
+
+func (c *KibanaConnectionsStore) Get(key string) (*KibanaConnection, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	connection, exists := c.Store[key]
+	if exists {
+		connection.lastUsedAt = time.Now()
+	}
+	return connection, exists
+}
+
+func (c *KibanaConnectionsStore) GetAll() map[string]*KibanaConnection {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.Store
+}
+
+func (c *KibanaConnectionsStore) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.Store, key)
+}
+
+// EvictExpired removes the connections exceeding the TTL or the idle timeout and closes their idle HTTP
+// connections, so stale credentials and dead endpoints are re-created on the next use.
+// It returns the keys of the removed connections
+func (c *KibanaConnectionsStore) EvictExpired() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var evicted []string
+	now := time.Now()
+	for key, connection := range c.Store {
+		reason := expirationReason(now, connection.createdAt, connection.lastUsedAt, c.TTL, c.IdleTimeout)
+		if reason == "" {
+			continue
+		}
+		delete(c.Store, key)
+		connectionEvictions.WithLabelValues(kibanaPool, reason).Inc()
+		connection.Client.CloseIdleConnections()
+		evicted = append(evicted, key)
+	}
+	return evicted
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pools
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	metricsNamespace = "elastic_config_operator"
+
+	elasticsearchPool = "elasticsearch"
+	kibanaPool        = "kibana"
+
+	evictionReasonTTL       = "ttl"
+	evictionReasonIdle      = "idle"
+	evictionReasonSecret    = "secret"
+	evictionReasonConfigMap = "configmap"
+	evictionReasonUnhealthy = "unhealthy"
+	evictionReasonMaxSize   = "max_size"
+
+	healthCheckResultHealthy   = "healthy"
+	healthCheckResultUnhealthy = "unhealthy"
+)
+
+var (
+	connectionEvictions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "pool_evictions_total",
+		Help:      "Number of connections evicted from the pools, by pool and reason",
+	}, []string{"pool", "reason"})
+
+	healthChecks = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "pool_health_checks_total",
+		Help:      "Number of health checks of the pooled connections, by pool and result",
+	}, []string{"pool", "result"})
+
+	poolConnectionsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "", "pool_connections"),
+		"Number of pooled connections", []string{"pool"}, nil)
+
+	poolMaxSizeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "", "pool_max_size"),
+		"Maximum number of pooled connections, 0 when unlimited", []string{"pool"}, nil)
+
+	connectionAgeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "", "pool_connection_age_seconds"),
+		"Time since the pooled connection was created", []string{"pool", "connection"}, nil)
+
+	connectionIdleDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "", "pool_connection_idle_seconds"),
+		"Time since the pooled connection was last used", []string{"pool", "connection"}, nil)
+)
+
+// poolsCollector collects the size of the pools and the age of their connections on every scrape
+type poolsCollector struct {
+	elasticsearchConnectionsPool *ElasticsearchConnectionsStore
+	kibanaConnectionsPool        *KibanaConnectionsStore
+}
+
+// RegisterMetrics registers the metrics of the pools in the metrics registry of the manager
+func RegisterMetrics(elasticsearchConnectionsPool *ElasticsearchConnectionsStore, kibanaConnectionsPool *KibanaConnectionsStore) {
+	metrics.Registry.MustRegister(connectionEvictions, healthChecks, &poolsCollector{
+		elasticsearchConnectionsPool: elasticsearchConnectionsPool,
+		kibanaConnectionsPool:        kibanaConnectionsPool,
+	})
+}
+
+// Describe sends the descriptors of the collected metrics
+func (c *poolsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- poolConnectionsDesc
+	ch <- poolMaxSizeDesc
+	ch <- connectionAgeDesc
+	ch <- connectionIdleDesc
+}
+
+// Collect sends the current size of the pools and the age of their connections
+func (c *poolsCollector) Collect(ch chan<- prometheus.Metric) {
+	now := time.Now()
+
+	c.elasticsearchConnectionsPool.mu.RLock()
+	ch <- prometheus.MustNewConstMetric(poolConnectionsDesc, prometheus.GaugeValue, float64(len(c.elasticsearchConnectionsPool.Store)), elasticsearchPool)
+	ch <- prometheus.MustNewConstMetric(poolMaxSizeDesc, prometheus.GaugeValue, float64(c.elasticsearchConnectionsPool.MaxSize), elasticsearchPool)
+	for key, connection := range c.elasticsearchConnectionsPool.Store {
+		ch <- prometheus.MustNewConstMetric(connectionAgeDesc, prometheus.GaugeValue, now.Sub(connection.createdAt).Seconds(), elasticsearchPool, key)
+		ch <- prometheus.MustNewConstMetric(connectionIdleDesc, prometheus.GaugeValue, now.Sub(connection.lastUsedAt).Seconds(), elasticsearchPool, key)
+	}
+	c.elasticsearchConnectionsPool.mu.RUnlock()
+
+	c.kibanaConnectionsPool.mu.RLock()
+	ch <- prometheus.MustNewConstMetric(poolConnectionsDesc, prometheus.GaugeValue, float64(len(c.kibanaConnectionsPool.Store)), kibanaPool)
+	ch <- prometheus.MustNewConstMetric(poolMaxSizeDesc, prometheus.GaugeValue, float64(c.kibanaConnectionsPool.MaxSize), kibanaPool)
+	for key, connection := range c.kibanaConnectionsPool.Store {
+		ch <- prometheus.MustNewConstMetric(connectionAgeDesc, prometheus.GaugeValue, now.Sub(connection.createdAt).Seconds(), kibanaPool, key)
+		ch <- prometheus.MustNewConstMetric(connectionIdleDesc, prometheus.GaugeValue, now.Sub(connection.lastUsedAt).Seconds(), kibanaPool, key)
+	}
+	c.kibanaConnectionsPool.mu.RUnlock()
+}
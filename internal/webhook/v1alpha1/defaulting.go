@@ -0,0 +1,196 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/controller"
+)
+
+// +kubebuilder:webhook:path=/mutate-elastic-config-operator-freepik-com-v1alpha1-indextemplate,mutating=true,failurePolicy=fail,sideEffects=None,groups=elastic-config-operator.freepik.com,resources=indextemplates,verbs=create;update,versions=v1alpha1,name=mindextemplate-v1alpha1.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/mutate-elastic-config-operator-freepik-com-v1alpha1-clusterindextemplate,mutating=true,failurePolicy=fail,sideEffects=None,groups=elastic-config-operator.freepik.com,resources=clusterindextemplates,verbs=create;update,versions=v1alpha1,name=mclusterindextemplate-v1alpha1.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/mutate-elastic-config-operator-freepik-com-v1alpha1-indexlifecyclepolicy,mutating=true,failurePolicy=fail,sideEffects=None,groups=elastic-config-operator.freepik.com,resources=indexlifecyclepolicies,verbs=create;update,versions=v1alpha1,name=mindexlifecyclepolicy-v1alpha1.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/mutate-elastic-config-operator-freepik-com-v1alpha1-clusterindexlifecyclepolicy,mutating=true,failurePolicy=fail,sideEffects=None,groups=elastic-config-operator.freepik.com,resources=clusterindexlifecyclepolicies,verbs=create;update,versions=v1alpha1,name=mclusterindexlifecyclepolicy-v1alpha1.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/mutate-elastic-config-operator-freepik-com-v1alpha1-snapshotlifecyclepolicy,mutating=true,failurePolicy=fail,sideEffects=None,groups=elastic-config-operator.freepik.com,resources=snapshotlifecyclepolicies,verbs=create;update,versions=v1alpha1,name=msnapshotlifecyclepolicy-v1alpha1.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/mutate-elastic-config-operator-freepik-com-v1alpha1-snapshotrepository,mutating=true,failurePolicy=fail,sideEffects=None,groups=elastic-config-operator.freepik.com,resources=snapshotrepositories,verbs=create;update,versions=v1alpha1,name=msnapshotrepository-v1alpha1.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/mutate-elastic-config-operator-freepik-com-v1alpha1-indexstatemanagement,mutating=true,failurePolicy=fail,sideEffects=None,groups=elastic-config-operator.freepik.com,resources=indexstatemanagements,verbs=create;update,versions=v1alpha1,name=mindexstatemanagement-v1alpha1.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/mutate-elastic-config-operator-freepik-com-v1alpha1-clustersettings,mutating=true,failurePolicy=fail,sideEffects=None,groups=elastic-config-operator.freepik.com,resources=clustersettings,verbs=create;update,versions=v1alpha1,name=mclustersettings-v1alpha1.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/mutate-elastic-config-operator-freepik-com-v1alpha1-elasticconfigbundle,mutating=true,failurePolicy=fail,sideEffects=None,groups=elastic-config-operator.freepik.com,resources=elasticconfigbundles,verbs=create;update,versions=v1alpha1,name=melasticconfigbundle-v1alpha1.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/mutate-elastic-config-operator-freepik-com-v1alpha1-autoscalingpolicy,mutating=true,failurePolicy=fail,sideEffects=None,groups=elastic-config-operator.freepik.com,resources=autoscalingpolicies,verbs=create;update,versions=v1alpha1,name=mautoscalingpolicy-v1alpha1.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/mutate-elastic-config-operator-freepik-com-v1alpha1-queryruleset,mutating=true,failurePolicy=fail,sideEffects=None,groups=elastic-config-operator.freepik.com,resources=queryrulesets,verbs=create;update,versions=v1alpha1,name=mqueryruleset-v1alpha1.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/mutate-elastic-config-operator-freepik-com-v1alpha1-opensearchalertingmonitor,mutating=true,failurePolicy=fail,sideEffects=None,groups=elastic-config-operator.freepik.com,resources=opensearchalertingmonitors,verbs=create;update,versions=v1alpha1,name=mopensearchalertingmonitor-v1alpha1.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/mutate-elastic-config-operator-freepik-com-v1alpha1-applicationprivilege,mutating=true,failurePolicy=fail,sideEffects=None,groups=elastic-config-operator.freepik.com,resources=applicationprivileges,verbs=create;update,versions=v1alpha1,name=mapplicationprivilege-v1alpha1.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/mutate-elastic-config-operator-freepik-com-v1alpha1-elasticsearchrawresource,mutating=true,failurePolicy=fail,sideEffects=None,groups=elastic-config-operator.freepik.com,resources=elasticsearchrawresources,verbs=create;update,versions=v1alpha1,name=melasticsearchrawresource-v1alpha1.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/mutate-elastic-config-operator-freepik-com-v1alpha1-machinelearningjob,mutating=true,failurePolicy=fail,sideEffects=None,groups=elastic-config-operator.freepik.com,resources=machinelearningjobs,verbs=create;update,versions=v1alpha1,name=mmachinelearningjob-v1alpha1.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/mutate-elastic-config-operator-freepik-com-v1alpha1-nodeshutdown,mutating=true,failurePolicy=fail,sideEffects=None,groups=elastic-config-operator.freepik.com,resources=nodeshutdowns,verbs=create;update,versions=v1alpha1,name=mnodeshutdown-v1alpha1.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/mutate-elastic-config-operator-freepik-com-v1alpha1-opensearchanomalydetector,mutating=true,failurePolicy=fail,sideEffects=None,groups=elastic-config-operator.freepik.com,resources=opensearchanomalydetectors,verbs=create;update,versions=v1alpha1,name=mopensearchanomalydetector-v1alpha1.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/mutate-elastic-config-operator-freepik-com-v1alpha1-opensearchnotificationchannel,mutating=true,failurePolicy=fail,sideEffects=None,groups=elastic-config-operator.freepik.com,resources=opensearchnotificationchannels,verbs=create;update,versions=v1alpha1,name=mopensearchnotificationchannel-v1alpha1.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/mutate-elastic-config-operator-freepik-com-v1alpha1-searchapplication,mutating=true,failurePolicy=fail,sideEffects=None,groups=elastic-config-operator.freepik.com,resources=searchapplications,verbs=create;update,versions=v1alpha1,name=msearchapplication-v1alpha1.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/mutate-elastic-config-operator-freepik-com-v1alpha1-synonymsset,mutating=true,failurePolicy=fail,sideEffects=None,groups=elastic-config-operator.freepik.com,resources=synonymssets,verbs=create;update,versions=v1alpha1,name=msynonymsset-v1alpha1.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/mutate-elastic-config-operator-freepik-com-v1alpha1-namespacedefaultcluster,mutating=true,failurePolicy=fail,sideEffects=None,groups=elastic-config-operator.freepik.com,resources=namespacedefaultclusters,verbs=create;update,versions=v1alpha1,name=mnamespacedefaultcluster-v1alpha1.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/mutate-elastic-config-operator-freepik-com-v1alpha1-kibanaalertrule,mutating=true,failurePolicy=fail,sideEffects=None,groups=elastic-config-operator.freepik.com,resources=kibanaalertrules,verbs=create;update,versions=v1alpha1,name=mkibanaalertrule-v1alpha1.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/mutate-elastic-config-operator-freepik-com-v1alpha1-kibanasavedobjects,mutating=true,failurePolicy=fail,sideEffects=None,groups=elastic-config-operator.freepik.com,resources=kibanasavedobjects,verbs=create;update,versions=v1alpha1,name=mkibanasavedobjects-v1alpha1.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/mutate-elastic-config-operator-freepik-com-v1alpha1-kibanaspace,mutating=true,failurePolicy=fail,sideEffects=None,groups=elastic-config-operator.freepik.com,resources=kibanaspaces,verbs=create;update,versions=v1alpha1,name=mkibanaspace-v1alpha1.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/mutate-elastic-config-operator-freepik-com-v1alpha1-opensearchdashboardssavedobjects,mutating=true,failurePolicy=fail,sideEffects=None,groups=elastic-config-operator.freepik.com,resources=opensearchdashboardssavedobjects,verbs=create;update,versions=v1alpha1,name=mopensearchdashboardssavedobjects-v1alpha1.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/mutate-elastic-config-operator-freepik-com-v1alpha1-elasticsearchclusterconnection,mutating=true,failurePolicy=fail,sideEffects=None,groups=elastic-config-operator.freepik.com,resources=elasticsearchclusterconnections,verbs=create;update,versions=v1alpha1,name=melasticsearchclusterconnection-v1alpha1.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/mutate-elastic-config-operator-freepik-com-v1alpha1-fleetagentpolicy,mutating=true,failurePolicy=fail,sideEffects=None,groups=elastic-config-operator.freepik.com,resources=fleetagentpolicies,verbs=create;update,versions=v1alpha1,name=mfleetagentpolicy-v1alpha1.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/mutate-elastic-config-operator-freepik-com-v1alpha1-indexlifecycle,mutating=true,failurePolicy=fail,sideEffects=None,groups=elastic-config-operator.freepik.com,resources=indexlifecycles,verbs=create;update,versions=v1alpha1,name=mindexlifecycle-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// defaultedResources are the kinds of resources defaulted by the webhook
+var defaultedResources = []client.Object{
+	&v1alpha1.IndexTemplate{},
+	&v1alpha1.ClusterIndexTemplate{},
+	&v1alpha1.IndexLifecyclePolicy{},
+	&v1alpha1.ClusterIndexLifecyclePolicy{},
+	&v1alpha1.SnapshotLifecyclePolicy{},
+	&v1alpha1.SnapshotRepository{},
+	&v1alpha1.IndexStateManagement{},
+	&v1alpha1.ClusterSettings{},
+	&v1alpha1.ElasticConfigBundle{},
+	&v1alpha1.AutoscalingPolicy{},
+	&v1alpha1.QueryRuleset{},
+	&v1alpha1.OpenSearchAlertingMonitor{},
+	&v1alpha1.ApplicationPrivilege{},
+	&v1alpha1.ElasticsearchRawResource{},
+	&v1alpha1.MachineLearningJob{},
+	&v1alpha1.NodeShutdown{},
+	&v1alpha1.OpenSearchAnomalyDetector{},
+	&v1alpha1.OpenSearchNotificationChannel{},
+	&v1alpha1.SearchApplication{},
+	&v1alpha1.SynonymsSet{},
+	&v1alpha1.NamespaceDefaultCluster{},
+	&v1alpha1.KibanaAlertRule{},
+	&v1alpha1.KibanaSavedObjects{},
+	&v1alpha1.KibanaSpace{},
+	&v1alpha1.OpenSearchDashboardsSavedObjects{},
+	&v1alpha1.ElasticsearchClusterConnection{},
+	&v1alpha1.FleetAgentPolicy{},
+	&v1alpha1.IndexLifecycle{},
+}
+
+// resourceDefaulter fills in the fields defaulted by the controllers when the resources are admitted, so the defaults
+// are visible in the stored resources instead of only being applied in memory on every sync
+type resourceDefaulter struct{}
+
+var _ admission.CustomDefaulter = &resourceDefaulter{}
+
+// Default sets the syncInterval to the DefaultSyncInterval of the controllers, and the namespace of the
+// resourceSelector or kibanaSelector to the namespace of the resource. Empty resourceSelectors are left untouched, as
+// they use the NamespaceDefaultCluster of the namespace, and so are the ones of cluster-scoped resources, which
+// require a namespace
+func (d *resourceDefaulter) Default(_ context.Context, obj runtime.Object) error {
+	syncInterval, resourceSelector := defaultedFields(obj)
+
+	if syncInterval != nil && *syncInterval == "" {
+		*syncInterval = controller.DefaultSyncInterval
+	}
+
+	namespace := obj.(client.Object).GetNamespace()
+	if resourceSelector != nil && resourceSelector.Name != "" && resourceSelector.Namespace == "" && namespace != "" {
+		resourceSelector.Namespace = namespace
+	}
+
+	if kibanaSelector := defaultedKibanaSelector(obj); kibanaSelector != nil && kibanaSelector.Name != "" && kibanaSelector.Namespace == "" {
+		kibanaSelector.Namespace = namespace
+	}
+
+	return nil
+}
+
+// defaultedFields returns the defaulted fields of the spec of a resource, nil for the ones it does not have
+func defaultedFields(obj runtime.Object) (syncInterval *string, resourceSelector *v1alpha1.ResourceSelector) {
+	switch resource := obj.(type) {
+	case *v1alpha1.IndexTemplate:
+		return &resource.Spec.SyncInterval, &resource.Spec.ResourceSelector
+	case *v1alpha1.ClusterIndexTemplate:
+		return &resource.Spec.SyncInterval, resource.Spec.ResourceSelector
+	case *v1alpha1.IndexLifecyclePolicy:
+		return &resource.Spec.SyncInterval, &resource.Spec.ResourceSelector
+	case *v1alpha1.ClusterIndexLifecyclePolicy:
+		return &resource.Spec.SyncInterval, resource.Spec.ResourceSelector
+	case *v1alpha1.SnapshotLifecyclePolicy:
+		return &resource.Spec.SyncInterval, &resource.Spec.ResourceSelector
+	case *v1alpha1.SnapshotRepository:
+		return &resource.Spec.SyncInterval, &resource.Spec.ResourceSelector
+	case *v1alpha1.IndexStateManagement:
+		return &resource.Spec.SyncInterval, &resource.Spec.ResourceSelector
+	case *v1alpha1.ClusterSettings:
+		return &resource.Spec.SyncInterval, &resource.Spec.ResourceSelector
+	case *v1alpha1.ElasticConfigBundle:
+		return &resource.Spec.SyncInterval, &resource.Spec.ResourceSelector
+	case *v1alpha1.AutoscalingPolicy:
+		return &resource.Spec.SyncInterval, &resource.Spec.ResourceSelector
+	case *v1alpha1.QueryRuleset:
+		return &resource.Spec.SyncInterval, &resource.Spec.ResourceSelector
+	case *v1alpha1.OpenSearchAlertingMonitor:
+		return &resource.Spec.SyncInterval, &resource.Spec.ResourceSelector
+	case *v1alpha1.ApplicationPrivilege:
+		return &resource.Spec.SyncInterval, &resource.Spec.ResourceSelector
+	case *v1alpha1.ElasticsearchRawResource:
+		return &resource.Spec.SyncInterval, &resource.Spec.ResourceSelector
+	case *v1alpha1.MachineLearningJob:
+		return &resource.Spec.SyncInterval, &resource.Spec.ResourceSelector
+	case *v1alpha1.NodeShutdown:
+		return &resource.Spec.SyncInterval, &resource.Spec.ResourceSelector
+	case *v1alpha1.OpenSearchAnomalyDetector:
+		return &resource.Spec.SyncInterval, &resource.Spec.ResourceSelector
+	case *v1alpha1.OpenSearchNotificationChannel:
+		return &resource.Spec.SyncInterval, &resource.Spec.ResourceSelector
+	case *v1alpha1.SearchApplication:
+		return &resource.Spec.SyncInterval, &resource.Spec.ResourceSelector
+	case *v1alpha1.SynonymsSet:
+		return &resource.Spec.SyncInterval, &resource.Spec.ResourceSelector
+	case *v1alpha1.NamespaceDefaultCluster:
+		return nil, &resource.Spec.ResourceSelector
+	case *v1alpha1.KibanaAlertRule:
+		return &resource.Spec.SyncInterval, nil
+	case *v1alpha1.KibanaSavedObjects:
+		return &resource.Spec.SyncInterval, nil
+	case *v1alpha1.KibanaSpace:
+		return &resource.Spec.SyncInterval, nil
+	case *v1alpha1.OpenSearchDashboardsSavedObjects:
+		return &resource.Spec.SyncInterval, nil
+	case *v1alpha1.ElasticsearchClusterConnection:
+		return &resource.Spec.SyncInterval, nil
+	case *v1alpha1.FleetAgentPolicy:
+		return &resource.Spec.SyncInterval, nil
+	case *v1alpha1.IndexLifecycle:
+		return &resource.Spec.SyncInterval, &resource.Spec.ResourceSelector
+	}
+	return nil, nil
+}
+
+// defaultedKibanaSelector returns the kibanaSelector of the spec of a resource, nil when it has none
+func defaultedKibanaSelector(obj runtime.Object) *v1alpha1.KibanaSelector {
+	switch resource := obj.(type) {
+	case *v1alpha1.KibanaAlertRule:
+		return &resource.Spec.KibanaSelector
+	case *v1alpha1.KibanaSavedObjects:
+		return &resource.Spec.KibanaSelector
+	case *v1alpha1.KibanaSpace:
+		return &resource.Spec.KibanaSelector
+	case *v1alpha1.FleetAgentPolicy:
+		return &resource.Spec.KibanaSelector
+	}
+	return nil
+}
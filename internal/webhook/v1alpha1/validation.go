@@ -0,0 +1,451 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/controller/multicluster"
+	"elastic-config-operator.freepik.com/elastic-config-operator/internal/globals"
+)
+
+const (
+	// invalidNameCharacters are the characters rejected by Elasticsearch in the names of templates, policies and
+	// repositories. '#' is accepted in some of them, but it would be taken as the fragment of the request URL
+	invalidNameCharacters = `\/*?"<>| ,#`
+
+	// maxNameBytes is the longest name accepted by Elasticsearch
+	maxNameBytes = 255
+)
+
+// clusterSettingsCategories are the categories of the cluster settings accepted by the cluster settings API
+var clusterSettingsCategories = []string{"persistent", "transient"}
+
+// validateResource returns the errors of the spec of a resource caught before it is synced: an incomplete
+// resourceSelector or cluster connection, bodies which are not JSON objects, unknown categories of cluster settings,
+// names rejected by Elasticsearch and fields set for the wrong type of object. The rest of the spec is validated by
+// the CRD schema, and by the cluster when the objects are written
+func validateResource(object runtime.Object) field.ErrorList {
+	spec := field.NewPath("spec")
+	resourceSelector := spec.Child("resourceSelector")
+	resources := spec.Child("resources")
+
+	switch resource := object.(type) {
+	case *v1alpha1.IndexTemplate:
+		return slices.Concat(
+			validateResourceSelector(resourceSelector, &resource.Spec.ResourceSelector),
+			validateJSONObjects(resources, resource.Spec.Resources),
+			validateNames(resources, resource.Spec.Resources, true))
+	case *v1alpha1.ClusterIndexTemplate:
+		return slices.Concat(
+			validateResourceSelector(resourceSelector, resource.Spec.ResourceSelector),
+			validateJSONObjects(resources, resource.Spec.Resources),
+			validateNames(resources, resource.Spec.Resources, true))
+	case *v1alpha1.IndexLifecyclePolicy:
+		return slices.Concat(
+			validateResourceSelector(resourceSelector, &resource.Spec.ResourceSelector),
+			validateJSONObjects(resources, resource.Spec.Resources),
+			validateNames(resources, resource.Spec.Resources, false),
+			validateNames(spec.Child("policies"), resource.Spec.Policies, false))
+	case *v1alpha1.ClusterIndexLifecyclePolicy:
+		return slices.Concat(
+			validateResourceSelector(resourceSelector, resource.Spec.ResourceSelector),
+			validateJSONObjects(resources, resource.Spec.Resources),
+			validateNames(resources, resource.Spec.Resources, false))
+	case *v1alpha1.SnapshotLifecyclePolicy:
+		return slices.Concat(
+			validateResourceSelector(resourceSelector, &resource.Spec.ResourceSelector),
+			validateJSONObjects(resources, resource.Spec.Resources),
+			validateNames(resources, resource.Spec.Resources, false))
+	case *v1alpha1.SnapshotRepository:
+		return slices.Concat(
+			validateResourceSelector(resourceSelector, &resource.Spec.ResourceSelector),
+			validateJSONObjects(resources, resource.Spec.Resources),
+			validateNames(resources, resource.Spec.Resources, false))
+	case *v1alpha1.IndexStateManagement:
+		return slices.Concat(
+			validateResourceSelector(resourceSelector, &resource.Spec.ResourceSelector),
+			validateJSONObjects(resources, resource.Spec.Resources),
+			validateNames(resources, resource.Spec.Resources, false))
+	case *v1alpha1.ClusterSettings:
+		return slices.Concat(
+			validateResourceSelector(resourceSelector, &resource.Spec.ResourceSelector),
+			validateJSONObjects(resources, resource.Spec.Resources),
+			validateClusterSettingsCategories(resources, resource.Spec.Resources))
+	case *v1alpha1.ElasticConfigBundle:
+		return slices.Concat(
+			validateResourceSelector(resourceSelector, &resource.Spec.ResourceSelector),
+			validateJSONObjects(spec.Child("clusterSettings"), resource.Spec.ClusterSettings),
+			validateClusterSettingsCategories(spec.Child("clusterSettings"), resource.Spec.ClusterSettings),
+			validateJSONObjects(spec.Child("ingestPipelines"), resource.Spec.IngestPipelines),
+			validateJSONObjects(spec.Child("indexLifecyclePolicies"), resource.Spec.IndexLifecyclePolicies),
+			validateNames(spec.Child("indexLifecyclePolicies"), resource.Spec.IndexLifecyclePolicies, false),
+			validateJSONObjects(spec.Child("componentTemplates"), resource.Spec.ComponentTemplates),
+			validateNames(spec.Child("componentTemplates"), resource.Spec.ComponentTemplates, true),
+			validateJSONObjects(spec.Child("indexTemplates"), resource.Spec.IndexTemplates),
+			validateNames(spec.Child("indexTemplates"), resource.Spec.IndexTemplates, true))
+	case *v1alpha1.AutoscalingPolicy:
+		return slices.Concat(
+			validateResourceSelector(resourceSelector, &resource.Spec.ResourceSelector),
+			validateJSONObjects(resources, resource.Spec.Resources))
+	case *v1alpha1.QueryRuleset:
+		return slices.Concat(
+			validateResourceSelector(resourceSelector, &resource.Spec.ResourceSelector),
+			validateJSONObjects(resources, resource.Spec.Resources))
+	case *v1alpha1.OpenSearchAlertingMonitor:
+		return slices.Concat(
+			validateResourceSelector(resourceSelector, &resource.Spec.ResourceSelector),
+			validateJSONObjects(resources, resource.Spec.Resources))
+	case *v1alpha1.ApplicationPrivilege:
+		return slices.Concat(
+			validateResourceSelector(resourceSelector, &resource.Spec.ResourceSelector),
+			validateNames(resources, resource.Spec.Resources, false),
+			validateApplicationPrivileges(resources, resource.Spec.Resources))
+	case *v1alpha1.ElasticsearchRawResource:
+		return slices.Concat(
+			validateResourceSelector(resourceSelector, &resource.Spec.ResourceSelector),
+			validateNames(resources, resource.Spec.Resources, false),
+			validateRawResources(resources, resource.Spec.Resources))
+	case *v1alpha1.MachineLearningJob:
+		return slices.Concat(
+			validateResourceSelector(resourceSelector, &resource.Spec.ResourceSelector),
+			validateNames(resources, resource.Spec.Resources, true),
+			validateMachineLearningJobs(resources, resource.Spec.Resources))
+	case *v1alpha1.NodeShutdown:
+		return slices.Concat(
+			validateResourceSelector(resourceSelector, &resource.Spec.ResourceSelector),
+			validateNodeShutdowns(resources, resource.Spec.Resources))
+	case *v1alpha1.OpenSearchAnomalyDetector:
+		return slices.Concat(
+			validateResourceSelector(resourceSelector, &resource.Spec.ResourceSelector),
+			validateNames(resources, resource.Spec.Resources, false),
+			validateAnomalyDetectors(resources, resource.Spec.Resources))
+	case *v1alpha1.OpenSearchNotificationChannel:
+		return slices.Concat(
+			validateResourceSelector(resourceSelector, &resource.Spec.ResourceSelector),
+			validateNames(resources, resource.Spec.Resources, false),
+			validateNotificationChannels(resources, resource.Spec.Resources))
+	case *v1alpha1.SearchApplication:
+		return slices.Concat(
+			validateResourceSelector(resourceSelector, &resource.Spec.ResourceSelector),
+			validateNames(resources, resource.Spec.Resources, true),
+			validateSearchApplications(resources, resource.Spec.Resources))
+	case *v1alpha1.SynonymsSet:
+		return slices.Concat(
+			validateResourceSelector(resourceSelector, &resource.Spec.ResourceSelector),
+			validateNames(resources, resource.Spec.Resources, false))
+	case *v1alpha1.NamespaceDefaultCluster:
+		return validateResourceSelector(resourceSelector, &resource.Spec.ResourceSelector)
+	case *v1alpha1.KibanaAlertRule:
+		return validateJSONObjects(resources, resource.Spec.Resources)
+	case *v1alpha1.KibanaSavedObjects:
+		return validateJSONObjects(resources, resource.Spec.Resources)
+	case *v1alpha1.KibanaSpace:
+		return validateJSONObjects(resources, resource.Spec.Resources)
+	case *v1alpha1.OpenSearchDashboardsSavedObjects:
+		return validateJSONObjects(resources, resource.Spec.Resources)
+	case *v1alpha1.IndexLifecycle:
+		return slices.Concat(
+			validateResourceSelector(resourceSelector, &resource.Spec.ResourceSelector),
+			validateNames(spec.Child("policies"), resource.Spec.Policies, false))
+	case *v1alpha1.FleetAgentPolicy:
+		return validateFleetAgentPolicies(resources, resource.Spec.Resources)
+	case *v1alpha1.ElasticsearchClusterConnection:
+		return validateClusterConnection(spec, &resource.Spec)
+	case *v1alpha1.ElasticClusterBinding:
+		return validateClusterBinding(spec, &resource.Spec)
+	}
+	return nil
+}
+
+// validateResourceSelector validates the credentials of a ResourceSelector, which are required to reach manually
+// configured clusters, and the verification of their certificate. The exclusive fields are validated by the CRD
+// schema, and selectors left empty use the NamespaceDefaultCluster of the namespace
+func validateResourceSelector(fldPath *field.Path, resourceSelector *v1alpha1.ResourceSelector) field.ErrorList {
+	var errs field.ErrorList
+	if resourceSelector == nil {
+		return errs
+	}
+
+	if resourceSelector.Username != "" && resourceSelector.PasswordSecretRef == nil {
+		errs = append(errs, field.Required(fldPath.Child("passwordSecretRef"), "passwordSecretRef is required when username is set"))
+	}
+	if resourceSelector.PasswordSecretRef != nil && resourceSelector.Username == "" {
+		errs = append(errs, field.Required(fldPath.Child("username"), "username is required when passwordSecretRef is set"))
+	}
+
+	manual := resourceSelector.Endpoint != "" || resourceSelector.EndpointFrom != nil || resourceSelector.CloudID != ""
+	credentials := resourceSelector.Username != "" || resourceSelector.BasicAuthSecretRef != nil ||
+		resourceSelector.APIKeySecretRef != nil || resourceSelector.TokenSecretRef != nil ||
+		resourceSelector.ClientCertSecretRef != nil || resourceSelector.AWS != nil
+	if manual && !credentials {
+		errs = append(errs, field.Required(fldPath, "username and passwordSecretRef, basicAuthSecretRef, apiKeySecretRef, "+
+			"tokenSecretRef, clientCertSecretRef or aws is required when using manual configuration or cloudID"))
+	}
+
+	// The TLSVerification condition is set by the controllers, only the error is kept here
+	if err := globals.ValidateTLSVerification(resourceSelector, &[]metav1.Condition{}); err != nil {
+		errs = append(errs, field.Required(fldPath, err.Error()))
+	}
+
+	return errs
+}
+
+// validateMatchLabels rejects the resourceSelector.matchLabels of the kinds which can't select their clusters by
+// labels, as they would be left unsynced
+func validateMatchLabels(object runtime.Object) field.ErrorList {
+	_, resourceSelector := defaultedFields(object)
+	if resourceSelector == nil || len(resourceSelector.MatchLabels) == 0 {
+		return nil
+	}
+
+	switch object.(type) {
+	case *v1alpha1.IndexTemplate, *v1alpha1.IndexLifecyclePolicy, *v1alpha1.SnapshotLifecyclePolicy,
+		*v1alpha1.SnapshotRepository, *v1alpha1.ClusterSettings, *v1alpha1.ElasticConfigBundle:
+		return nil
+	}
+	return field.ErrorList{field.Forbidden(field.NewPath("spec", "resourceSelector", "matchLabels"),
+		"matchLabels is only supported by "+strings.Join(multicluster.SupportedKinds, ", "))}
+}
+
+// validateJSONObjects validates the bodies of the objects of a resource are JSON objects, as expected by the APIs
+// they are sent to. The bodies are omitted from the errors, as they can be large
+func validateJSONObjects(fldPath *field.Path, objects map[string]apiextensionsv1.JSON) field.ErrorList {
+	var errs field.ErrorList
+	for _, name := range slices.Sorted(maps.Keys(objects)) {
+		body := objects[name]
+		errs = append(errs, validateJSONObject(fldPath.Key(name), &body)...)
+	}
+	return errs
+}
+
+// validateJSONObject validates an optional body is a JSON object
+func validateJSONObject(fldPath *field.Path, object *apiextensionsv1.JSON) field.ErrorList {
+	if object == nil {
+		return nil
+	}
+	var body map[string]interface{}
+	if err := json.Unmarshal(object.Raw, &body); err != nil || body == nil {
+		return field.ErrorList{field.Invalid(fldPath, field.OmitValueType{}, "must be a JSON object")}
+	}
+	return nil
+}
+
+// validateApplicationPrivileges validates the names of the privileges of every application, and their metadata
+func validateApplicationPrivileges(fldPath *field.Path, applications map[string]v1alpha1.ApplicationPrivilegeSet) field.ErrorList {
+	var errs field.ErrorList
+	for _, application := range slices.Sorted(maps.Keys(applications)) {
+		privilegesPath := fldPath.Key(application).Child("privileges")
+		privileges := applications[application].Privileges
+		errs = append(errs, validateNames(privilegesPath, privileges, false)...)
+		for _, name := range slices.Sorted(maps.Keys(privileges)) {
+			errs = append(errs, validateJSONObject(privilegesPath.Key(name).Child("metadata"), privileges[name].Metadata)...)
+		}
+	}
+	return errs
+}
+
+// validateRawResources validates the bodies of the raw resources
+func validateRawResources(fldPath *field.Path, rawResources map[string]v1alpha1.RawResource) field.ErrorList {
+	var errs field.ErrorList
+	for _, name := range slices.Sorted(maps.Keys(rawResources)) {
+		errs = append(errs, validateJSONObject(fldPath.Key(name).Child("body"), rawResources[name].Body)...)
+	}
+	return errs
+}
+
+// validateMachineLearningJobs validates the bodies of the jobs and of their datafeeds
+func validateMachineLearningJobs(fldPath *field.Path, jobs map[string]v1alpha1.MachineLearningJobDefinition) field.ErrorList {
+	var errs field.ErrorList
+	for _, name := range slices.Sorted(maps.Keys(jobs)) {
+		job := jobs[name]
+		errs = append(errs, validateJSONObject(fldPath.Key(name).Child("job"), &job.Job)...)
+		errs = append(errs, validateJSONObject(fldPath.Key(name).Child("datafeed"), job.Datafeed)...)
+	}
+	return errs
+}
+
+// validateNodeShutdowns validates the fields of every shutdown are accepted for its type: the target node is
+// required by replacements, and only used by them, and the allocation delay is only accepted by restarts
+func validateNodeShutdowns(fldPath *field.Path, shutdowns map[string]v1alpha1.NodeShutdownDefinition) field.ErrorList {
+	var errs field.ErrorList
+	for _, name := range slices.Sorted(maps.Keys(shutdowns)) {
+		shutdown := shutdowns[name]
+		shutdownPath := fldPath.Key(name)
+		switch {
+		case shutdown.Type == "replace" && shutdown.TargetNodeName == "":
+			errs = append(errs, field.Required(shutdownPath.Child("targetNodeName"), "targetNodeName is required when type is replace"))
+		case shutdown.Type != "replace" && shutdown.TargetNodeName != "":
+			errs = append(errs, field.Forbidden(shutdownPath.Child("targetNodeName"), "targetNodeName is only supported when type is replace"))
+		}
+		if shutdown.Type != "restart" && shutdown.AllocationDelay != "" {
+			errs = append(errs, field.Forbidden(shutdownPath.Child("allocationDelay"), "allocationDelay is only supported when type is restart"))
+		}
+	}
+	return errs
+}
+
+// validateAnomalyDetectors validates the definitions of the detectors
+func validateAnomalyDetectors(fldPath *field.Path, detectors map[string]v1alpha1.AnomalyDetector) field.ErrorList {
+	var errs field.ErrorList
+	for _, name := range slices.Sorted(maps.Keys(detectors)) {
+		detector := detectors[name]
+		errs = append(errs, validateJSONObject(fldPath.Key(name).Child("definition"), &detector.Definition)...)
+	}
+	return errs
+}
+
+// validateNotificationChannels validates every channel sets the configuration block of its configType
+func validateNotificationChannels(fldPath *field.Path, channels map[string]v1alpha1.NotificationChannel) field.ErrorList {
+	var errs field.ErrorList
+	for _, name := range slices.Sorted(maps.Keys(channels)) {
+		channel := channels[name]
+		configured := map[string]bool{
+			"webhook": channel.Webhook != nil,
+			"slack":   channel.Slack != nil,
+			"sns":     channel.SNS != nil,
+		}
+		if !configured[channel.ConfigType] {
+			errs = append(errs, field.Required(fldPath.Key(name).Child(channel.ConfigType),
+				fmt.Sprintf("%s configuration is required when configType is %s", channel.ConfigType, channel.ConfigType)))
+		}
+	}
+	return errs
+}
+
+// validateSearchApplications validates the dictionaries of the templates of the applications. The template sources
+// are either query objects or mustache strings, so they are left to Elasticsearch
+func validateSearchApplications(fldPath *field.Path, applications map[string]v1alpha1.SearchApplicationDefinition) field.ErrorList {
+	var errs field.ErrorList
+	for _, name := range slices.Sorted(maps.Keys(applications)) {
+		template := applications[name].Template
+		if template == nil {
+			continue
+		}
+		errs = append(errs, validateJSONObject(fldPath.Key(name).Child("template", "dictionary"), template.Dictionary)...)
+	}
+	return errs
+}
+
+// validateFleetAgentPolicies validates the bodies of the agent policies and of their package policies
+func validateFleetAgentPolicies(fldPath *field.Path, policies map[string]v1alpha1.FleetAgentPolicyDefinition) field.ErrorList {
+	var errs field.ErrorList
+	for _, name := range slices.Sorted(maps.Keys(policies)) {
+		policy := policies[name]
+		errs = append(errs, validateJSONObject(fldPath.Key(name).Child("policy"), &policy.Policy)...)
+		errs = append(errs, validateJSONObjects(fldPath.Key(name).Child("packagePolicies"), policy.PackagePolicies)...)
+	}
+	return errs
+}
+
+// validateClusterConnection validates the credentials of an ElasticsearchClusterConnection, and the durations of its
+// timeouts and node discovery
+func validateClusterConnection(fldPath *field.Path, connection *v1alpha1.ElasticsearchClusterConnectionSpec) field.ErrorList {
+	var errs field.ErrorList
+	if connection.Username != "" && connection.PasswordSecretRef == nil {
+		errs = append(errs, field.Required(fldPath.Child("passwordSecretRef"), "passwordSecretRef is required when username is set"))
+	}
+	if connection.PasswordSecretRef != nil && connection.Username == "" {
+		errs = append(errs, field.Required(fldPath.Child("username"), "username is required when passwordSecretRef is set"))
+	}
+
+	if connection.Timeouts != nil {
+		errs = append(errs, validateDuration(fldPath.Child("timeouts", "request"), connection.Timeouts.Request)...)
+		errs = append(errs, validateDuration(fldPath.Child("timeouts", "dial"), connection.Timeouts.Dial)...)
+	}
+	if connection.NodeDiscovery != nil {
+		errs = append(errs, validateDuration(fldPath.Child("nodeDiscovery", "interval"), connection.NodeDiscovery.Interval)...)
+	}
+	return errs
+}
+
+// validateDuration validates an optional duration is parsed by time.ParseDuration (e.g., "30s")
+func validateDuration(fldPath *field.Path, duration string) field.ErrorList {
+	if duration == "" {
+		return nil
+	}
+	if _, err := time.ParseDuration(duration); err != nil {
+		return field.ErrorList{field.Invalid(fldPath, duration, "must be a duration (e.g., 30s, 5m)")}
+	}
+	return nil
+}
+
+// validateClusterBinding validates an ElasticClusterBinding names its clusters and grants them to some namespaces,
+// either listed or selected by a valid label selector
+func validateClusterBinding(fldPath *field.Path, binding *v1alpha1.ElasticClusterBindingSpec) field.ErrorList {
+	var errs field.ErrorList
+	for i, cluster := range binding.Clusters {
+		if cluster == "" {
+			errs = append(errs, field.Invalid(fldPath.Child("clusters").Index(i), cluster, "must not be empty"))
+		}
+	}
+
+	if len(binding.Namespaces) == 0 && binding.NamespaceSelector == nil {
+		errs = append(errs, field.Required(fldPath, "namespaces or namespaceSelector is required"))
+	}
+	if binding.NamespaceSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(binding.NamespaceSelector); err != nil {
+			errs = append(errs, field.Invalid(fldPath.Child("namespaceSelector"), field.OmitValueType{}, err.Error()))
+		}
+	}
+	return errs
+}
+
+// validateClusterSettingsCategories validates the cluster settings are keyed by one of the clusterSettingsCategories
+func validateClusterSettingsCategories(fldPath *field.Path, settings map[string]apiextensionsv1.JSON) field.ErrorList {
+	var errs field.ErrorList
+	for _, category := range slices.Sorted(maps.Keys(settings)) {
+		if !slices.Contains(clusterSettingsCategories, category) {
+			errs = append(errs, field.NotSupported(fldPath.Key(category), category, clusterSettingsCategories))
+		}
+	}
+	return errs
+}
+
+// validateNames validates the names of the objects of a resource are accepted by Elasticsearch: not empty, not
+// starting with '_', without the invalidNameCharacters and up to maxNameBytes long. Template names must be lowercase
+// too
+func validateNames[T any](fldPath *field.Path, objects map[string]T, lowercase bool) field.ErrorList {
+	var errs field.ErrorList
+	for _, name := range slices.Sorted(maps.Keys(objects)) {
+		switch {
+		case name == "":
+			errs = append(errs, field.Invalid(fldPath.Key(name), name, "must not be empty"))
+		case strings.HasPrefix(name, "_"):
+			errs = append(errs, field.Invalid(fldPath.Key(name), name, "must not start with '_'"))
+		case strings.ContainsAny(name, invalidNameCharacters):
+			errs = append(errs, field.Invalid(fldPath.Key(name), name,
+				`must not contain spaces or the characters \ / * ? " < > | , #`))
+		case len(name) > maxNameBytes:
+			errs = append(errs, field.TooLong(fldPath.Key(name), name, maxNameBytes))
+		case lowercase && name != strings.ToLower(name):
+			errs = append(errs, field.Invalid(fldPath.Key(name), name, "must be lowercase"))
+		}
+	}
+	return errs
+}
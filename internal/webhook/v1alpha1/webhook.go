@@ -0,0 +1,186 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"elastic-config-operator.freepik.com/elastic-config-operator/api/v1alpha1"
+)
+
+// +kubebuilder:webhook:path=/validate-elastic-config-operator-freepik-com-v1alpha1-indextemplate,mutating=false,failurePolicy=fail,sideEffects=None,groups=elastic-config-operator.freepik.com,resources=indextemplates,verbs=create;update,versions=v1alpha1,name=vindextemplate-v1alpha1.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-elastic-config-operator-freepik-com-v1alpha1-clusterindextemplate,mutating=false,failurePolicy=fail,sideEffects=None,groups=elastic-config-operator.freepik.com,resources=clusterindextemplates,verbs=create;update,versions=v1alpha1,name=vclusterindextemplate-v1alpha1.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-elastic-config-operator-freepik-com-v1alpha1-indexlifecyclepolicy,mutating=false,failurePolicy=fail,sideEffects=None,groups=elastic-config-operator.freepik.com,resources=indexlifecyclepolicies,verbs=create;update,versions=v1alpha1,name=vindexlifecyclepolicy-v1alpha1.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-elastic-config-operator-freepik-com-v1alpha1-clusterindexlifecyclepolicy,mutating=false,failurePolicy=fail,sideEffects=None,groups=elastic-config-operator.freepik.com,resources=clusterindexlifecyclepolicies,verbs=create;update,versions=v1alpha1,name=vclusterindexlifecyclepolicy-v1alpha1.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-elastic-config-operator-freepik-com-v1alpha1-snapshotlifecyclepolicy,mutating=false,failurePolicy=fail,sideEffects=None,groups=elastic-config-operator.freepik.com,resources=snapshotlifecyclepolicies,verbs=create;update,versions=v1alpha1,name=vsnapshotlifecyclepolicy-v1alpha1.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-elastic-config-operator-freepik-com-v1alpha1-snapshotrepository,mutating=false,failurePolicy=fail,sideEffects=None,groups=elastic-config-operator.freepik.com,resources=snapshotrepositories,verbs=create;update,versions=v1alpha1,name=vsnapshotrepository-v1alpha1.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-elastic-config-operator-freepik-com-v1alpha1-indexstatemanagement,mutating=false,failurePolicy=fail,sideEffects=None,groups=elastic-config-operator.freepik.com,resources=indexstatemanagements,verbs=create;update,versions=v1alpha1,name=vindexstatemanagement-v1alpha1.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-elastic-config-operator-freepik-com-v1alpha1-clustersettings,mutating=false,failurePolicy=fail,sideEffects=None,groups=elastic-config-operator.freepik.com,resources=clustersettings,verbs=create;update,versions=v1alpha1,name=vclustersettings-v1alpha1.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-elastic-config-operator-freepik-com-v1alpha1-elasticconfigbundle,mutating=false,failurePolicy=fail,sideEffects=None,groups=elastic-config-operator.freepik.com,resources=elasticconfigbundles,verbs=create;update,versions=v1alpha1,name=velasticconfigbundle-v1alpha1.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-elastic-config-operator-freepik-com-v1alpha1-autoscalingpolicy,mutating=false,failurePolicy=fail,sideEffects=None,groups=elastic-config-operator.freepik.com,resources=autoscalingpolicies,verbs=create;update,versions=v1alpha1,name=vautoscalingpolicy-v1alpha1.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-elastic-config-operator-freepik-com-v1alpha1-queryruleset,mutating=false,failurePolicy=fail,sideEffects=None,groups=elastic-config-operator.freepik.com,resources=queryrulesets,verbs=create;update,versions=v1alpha1,name=vqueryruleset-v1alpha1.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-elastic-config-operator-freepik-com-v1alpha1-opensearchalertingmonitor,mutating=false,failurePolicy=fail,sideEffects=None,groups=elastic-config-operator.freepik.com,resources=opensearchalertingmonitors,verbs=create;update,versions=v1alpha1,name=vopensearchalertingmonitor-v1alpha1.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-elastic-config-operator-freepik-com-v1alpha1-applicationprivilege,mutating=false,failurePolicy=fail,sideEffects=None,groups=elastic-config-operator.freepik.com,resources=applicationprivileges,verbs=create;update,versions=v1alpha1,name=vapplicationprivilege-v1alpha1.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-elastic-config-operator-freepik-com-v1alpha1-elasticsearchrawresource,mutating=false,failurePolicy=fail,sideEffects=None,groups=elastic-config-operator.freepik.com,resources=elasticsearchrawresources,verbs=create;update,versions=v1alpha1,name=velasticsearchrawresource-v1alpha1.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-elastic-config-operator-freepik-com-v1alpha1-machinelearningjob,mutating=false,failurePolicy=fail,sideEffects=None,groups=elastic-config-operator.freepik.com,resources=machinelearningjobs,verbs=create;update,versions=v1alpha1,name=vmachinelearningjob-v1alpha1.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-elastic-config-operator-freepik-com-v1alpha1-nodeshutdown,mutating=false,failurePolicy=fail,sideEffects=None,groups=elastic-config-operator.freepik.com,resources=nodeshutdowns,verbs=create;update,versions=v1alpha1,name=vnodeshutdown-v1alpha1.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-elastic-config-operator-freepik-com-v1alpha1-opensearchanomalydetector,mutating=false,failurePolicy=fail,sideEffects=None,groups=elastic-config-operator.freepik.com,resources=opensearchanomalydetectors,verbs=create;update,versions=v1alpha1,name=vopensearchanomalydetector-v1alpha1.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-elastic-config-operator-freepik-com-v1alpha1-opensearchnotificationchannel,mutating=false,failurePolicy=fail,sideEffects=None,groups=elastic-config-operator.freepik.com,resources=opensearchnotificationchannels,verbs=create;update,versions=v1alpha1,name=vopensearchnotificationchannel-v1alpha1.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-elastic-config-operator-freepik-com-v1alpha1-searchapplication,mutating=false,failurePolicy=fail,sideEffects=None,groups=elastic-config-operator.freepik.com,resources=searchapplications,verbs=create;update,versions=v1alpha1,name=vsearchapplication-v1alpha1.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-elastic-config-operator-freepik-com-v1alpha1-synonymsset,mutating=false,failurePolicy=fail,sideEffects=None,groups=elastic-config-operator.freepik.com,resources=synonymssets,verbs=create;update,versions=v1alpha1,name=vsynonymsset-v1alpha1.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-elastic-config-operator-freepik-com-v1alpha1-namespacedefaultcluster,mutating=false,failurePolicy=fail,sideEffects=None,groups=elastic-config-operator.freepik.com,resources=namespacedefaultclusters,verbs=create;update,versions=v1alpha1,name=vnamespacedefaultcluster-v1alpha1.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-elastic-config-operator-freepik-com-v1alpha1-kibanaalertrule,mutating=false,failurePolicy=fail,sideEffects=None,groups=elastic-config-operator.freepik.com,resources=kibanaalertrules,verbs=create;update,versions=v1alpha1,name=vkibanaalertrule-v1alpha1.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-elastic-config-operator-freepik-com-v1alpha1-kibanasavedobjects,mutating=false,failurePolicy=fail,sideEffects=None,groups=elastic-config-operator.freepik.com,resources=kibanasavedobjects,verbs=create;update,versions=v1alpha1,name=vkibanasavedobjects-v1alpha1.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-elastic-config-operator-freepik-com-v1alpha1-kibanaspace,mutating=false,failurePolicy=fail,sideEffects=None,groups=elastic-config-operator.freepik.com,resources=kibanaspaces,verbs=create;update,versions=v1alpha1,name=vkibanaspace-v1alpha1.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-elastic-config-operator-freepik-com-v1alpha1-opensearchdashboardssavedobjects,mutating=false,failurePolicy=fail,sideEffects=None,groups=elastic-config-operator.freepik.com,resources=opensearchdashboardssavedobjects,verbs=create;update,versions=v1alpha1,name=vopensearchdashboardssavedobjects-v1alpha1.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-elastic-config-operator-freepik-com-v1alpha1-indexlifecycle,mutating=false,failurePolicy=fail,sideEffects=None,groups=elastic-config-operator.freepik.com,resources=indexlifecycles,verbs=create;update,versions=v1alpha1,name=vindexlifecycle-v1alpha1.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-elastic-config-operator-freepik-com-v1alpha1-fleetagentpolicy,mutating=false,failurePolicy=fail,sideEffects=None,groups=elastic-config-operator.freepik.com,resources=fleetagentpolicies,verbs=create;update,versions=v1alpha1,name=vfleetagentpolicy-v1alpha1.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-elastic-config-operator-freepik-com-v1alpha1-elasticsearchclusterconnection,mutating=false,failurePolicy=fail,sideEffects=None,groups=elastic-config-operator.freepik.com,resources=elasticsearchclusterconnections,verbs=create;update,versions=v1alpha1,name=velasticsearchclusterconnection-v1alpha1.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-elastic-config-operator-freepik-com-v1alpha1-elasticclusterbinding,mutating=false,failurePolicy=fail,sideEffects=None,groups=elastic-config-operator.freepik.com,resources=elasticclusterbindings,verbs=create;update,versions=v1alpha1,name=velasticclusterbinding-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// validatedResources are the kinds of resources validated by the webhook
+var validatedResources = []client.Object{
+	&v1alpha1.IndexTemplate{},
+	&v1alpha1.ClusterIndexTemplate{},
+	&v1alpha1.IndexLifecyclePolicy{},
+	&v1alpha1.ClusterIndexLifecyclePolicy{},
+	&v1alpha1.SnapshotLifecyclePolicy{},
+	&v1alpha1.SnapshotRepository{},
+	&v1alpha1.IndexStateManagement{},
+	&v1alpha1.ClusterSettings{},
+	&v1alpha1.ElasticConfigBundle{},
+	&v1alpha1.AutoscalingPolicy{},
+	&v1alpha1.QueryRuleset{},
+	&v1alpha1.OpenSearchAlertingMonitor{},
+	&v1alpha1.ApplicationPrivilege{},
+	&v1alpha1.ElasticsearchRawResource{},
+	&v1alpha1.MachineLearningJob{},
+	&v1alpha1.NodeShutdown{},
+	&v1alpha1.OpenSearchAnomalyDetector{},
+	&v1alpha1.OpenSearchNotificationChannel{},
+	&v1alpha1.SearchApplication{},
+	&v1alpha1.SynonymsSet{},
+	&v1alpha1.NamespaceDefaultCluster{},
+	&v1alpha1.KibanaAlertRule{},
+	&v1alpha1.KibanaSavedObjects{},
+	&v1alpha1.KibanaSpace{},
+	&v1alpha1.OpenSearchDashboardsSavedObjects{},
+	&v1alpha1.IndexLifecycle{},
+	&v1alpha1.FleetAgentPolicy{},
+	&v1alpha1.ElasticsearchClusterConnection{},
+	&v1alpha1.ElasticClusterBinding{},
+}
+
+// SetupWebhooksWithManager registers the defaulting webhook of every kind of defaultedResources and the validating
+// webhook of every kind of validatedResources in the manager. The conversion webhook of the kinds with several
+// versions (e.g., IndexTemplate) is registered by controller-runtime along with them, at /convert
+func SetupWebhooksWithManager(mgr ctrl.Manager) error {
+	for _, object := range defaultedResources {
+		gvk, err := apiutil.GVKForObject(object, mgr.GetScheme())
+		if err != nil {
+			return err
+		}
+
+		err = ctrl.NewWebhookManagedBy(mgr).
+			For(object).
+			WithDefaulter(&resourceDefaulter{}).
+			Complete()
+		if err != nil {
+			return fmt.Errorf("failed to create the defaulting webhook of %s: %w", gvk.Kind, err)
+		}
+	}
+
+	for _, object := range validatedResources {
+		gvk, err := apiutil.GVKForObject(object, mgr.GetScheme())
+		if err != nil {
+			return err
+		}
+
+		err = ctrl.NewWebhookManagedBy(mgr).
+			For(object).
+			WithValidator(&resourceValidator{groupKind: gvk.GroupKind()}).
+			Complete()
+		if err != nil {
+			return fmt.Errorf("failed to create the validating webhook of %s: %w", gvk.Kind, err)
+		}
+	}
+	return nil
+}
+
+// resourceValidator rejects the resources of a kind whose spec would fail to sync, so the errors are reported by
+// kubectl apply instead of in the status of the resources
+type resourceValidator struct {
+	groupKind schema.GroupKind
+}
+
+var _ admission.CustomValidator = &resourceValidator{}
+
+// ValidateCreate validates the spec of the created resources
+func (v *resourceValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, v.validate(obj)
+}
+
+// ValidateUpdate validates the spec of the updated resources. Updates that don't change the spec, such as the ones
+// of the finalizers or of the deletion, are always admitted, so resources created before the webhook can be deleted
+func (v *resourceValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldSpec, err := specOf(oldObj)
+	if err != nil {
+		return nil, err
+	}
+	newSpec, err := specOf(newObj)
+	if err != nil {
+		return nil, err
+	}
+	if equality.Semantic.DeepEqual(oldSpec, newSpec) {
+		return nil, nil
+	}
+	return nil, v.validate(newObj)
+}
+
+// ValidateDelete admits the deletion of every resource
+func (v *resourceValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validate returns an Invalid error listing the errors of the spec of a resource, if any
+func (v *resourceValidator) validate(obj runtime.Object) error {
+	errs := slices.Concat(validateResource(obj), validateMatchLabels(obj))
+	if len(errs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(v.groupKind, obj.(client.Object).GetName(), errs)
+}
+
+// specOf returns the spec of a resource, compared to tell the updates of the spec from the other ones
+func specOf(obj runtime.Object) (interface{}, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert the resource: %w", err)
+	}
+	return content["spec"], nil
+}